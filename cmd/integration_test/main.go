@@ -0,0 +1,53 @@
+// Command integration_test runs lazyprisma's integration tests headless.
+//
+// Usage:
+//
+//	go run ./cmd/integration_test [test names...]
+//
+// With no arguments every test runs; names are as listed, e.g. "migrate/schema_diff_pending".
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+	"github.com/dokadev/lazyprisma/pkg/integration/tests"
+
+	// Register database drivers
+	_ "github.com/dokadev/lazyprisma/pkg/database/drivers"
+)
+
+func main() {
+	names := os.Args[1:]
+
+	var selected []*components.IntegrationTest
+	for _, test := range tests.Tests {
+		if len(names) == 0 || slices.Contains(names, test.Name()) {
+			selected = append(selected, test)
+		}
+	}
+	if len(selected) == 0 {
+		fmt.Fprintln(os.Stderr, "no integration tests match; available:")
+		for _, test := range tests.Tests {
+			fmt.Fprintf(os.Stderr, "  %s\n", test.Name())
+		}
+		os.Exit(1)
+	}
+
+	failed := 0
+	for _, test := range selected {
+		if err := test.Run(); err != nil {
+			failed++
+			fmt.Printf("FAIL %s: %s\n%v\n\n", test.Name(), test.Description(), err)
+			continue
+		}
+		fmt.Printf("PASS %s\n", test.Name())
+	}
+
+	fmt.Printf("\n%d passed, %d failed\n", len(selected)-failed, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -3,12 +3,13 @@ module github.com/dokadev/lazyprisma
 go 1.25.5
 
 require (
-	dario.cat/mergo v1.0.2
 	github.com/alecthomas/chroma/v2 v2.21.1
+	github.com/gdamore/tcell/v2 v2.13.5
 	github.com/go-sql-driver/mysql v1.9.3
 	github.com/jesseduffield/gocui v0.3.1-0.20260128194906-9d8c3cdfac18
 	github.com/jesseduffield/lazycore v0.0.0-20221012050358-03d2e40243c5
 	github.com/lib/pq v1.10.9
+	github.com/rivo/uniseg v0.4.7
 	gopkg.in/yaml.v3 v3.0.1
 )
 
@@ -16,10 +17,8 @@ require (
 	filippo.io/edwards25519 v1.1.0 // indirect
 	github.com/dlclark/regexp2 v1.11.5 // indirect
 	github.com/gdamore/encoding v1.0.1 // indirect
-	github.com/gdamore/tcell/v2 v2.13.5 // indirect
 	github.com/go-errors/errors v1.0.2 // indirect
 	github.com/lucasb-eyer/go-colorful v1.3.0 // indirect
-	github.com/rivo/uniseg v0.4.7 // indirect
 	github.com/samber/lo v1.31.0 // indirect
 	golang.org/x/exp v0.0.0-20220317015231-48e79f11773a // indirect
 	golang.org/x/sys v0.39.0 // indirect
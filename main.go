@@ -5,9 +5,12 @@ import (
 	"os"
 
 	"github.com/dokadev/lazyprisma/pkg/app"
+	"github.com/dokadev/lazyprisma/pkg/commands"
 	"github.com/dokadev/lazyprisma/pkg/config"
-	"github.com/dokadev/lazyprisma/pkg/gui/context"
+	"github.com/dokadev/lazyprisma/pkg/demo"
+	"github.com/dokadev/lazyprisma/pkg/gui/style"
 	"github.com/dokadev/lazyprisma/pkg/i18n"
+	"github.com/dokadev/lazyprisma/pkg/node"
 	"github.com/dokadev/lazyprisma/pkg/prisma"
 
 	// Register database drivers
@@ -31,6 +34,45 @@ func main() {
 		}
 	}
 
+	// --debug profiles panel draws into debug.log in the config directory
+	// --demo opens a fixture project with a fake database instead of the current directory
+	// --read-only opens the project without running commands, e.g. next to another instance
+	// --no-color / --force-color override NO_COLOR, for lazyprisma and the commands it runs
+	debugMode, demoMode, readOnly := false, false, false
+	colorMode := commands.ColorAuto
+	for _, arg := range os.Args[1:] {
+		switch arg {
+		case "--debug":
+			debugMode = true
+		case "--demo":
+			demoMode = true
+		case "--read-only":
+			readOnly = true
+		case "--no-color":
+			colorMode = commands.ColorNever
+		case "--force-color":
+			colorMode = commands.ColorAlways
+		}
+	}
+	commands.SetColorMode(colorMode)
+	style.SetColorEnabled(colorMode == commands.ColorAlways ||
+		colorMode == commands.ColorAuto && os.Getenv("NO_COLOR") == "")
+	debugLogPath, _ := config.DebugLogPath()
+
+	var demoState *demo.State
+	if demoMode {
+		var err error
+		if demoState, err = demo.Setup(); err != nil {
+			fmt.Fprintf(os.Stderr, tr.ErrorDemoSetup, err)
+			os.Exit(1)
+		}
+		defer demoState.Cleanup()
+		if err := os.Chdir(demoState.Dir); err != nil {
+			fmt.Fprintf(os.Stderr, tr.ErrorDemoSetup, err)
+			os.Exit(1)
+		}
+	}
+
 	// Check if current directory is a Prisma workspace
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -38,6 +80,16 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Run node through the version manager with the project's pinned version, if configured
+	if !demoMode {
+		node.SetExecManager(node.Manager(cfg.Node.VersionManager))
+	}
+
+	// Offer to set up Prisma when only a database URL exists
+	if !demoMode && app.CanOnboard(cwd) {
+		app.RunOnboardWizard(tr, prisma.NewCLIRunner(), cwd, os.Stdin, os.Stdout)
+	}
+
 	if !prisma.IsWorkspace(cwd) {
 		fmt.Fprint(os.Stderr, tr.ErrorNotPrismaWorkspace)
 		fmt.Fprint(os.Stderr, tr.ErrorExpectedOneOf)
@@ -46,96 +98,48 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Create app
-	tuiApp, err := app.NewApp(app.AppConfig{
-		DebugMode: false,
-		AppName:   "LazyPrisma",
-		Version:   Version,
-		Developer: Developer,
-		Language:  cfg.Language,
-	})
+	// Warn when another instance has the project open: the two would race on
+	// commands and state files. A read-only instance leaves the lock alone.
+	if !demoMode && !readOnly {
+		lock, holder, err := config.AcquireInstanceLock(cwd)
+		switch {
+		case err != nil:
+			fmt.Fprintf(os.Stderr, tr.InstanceLockFailed, err)
+		case holder != nil:
+			switch app.AskInstanceChoice(tr, holder, os.Stdin, os.Stdout) {
+			case app.InstanceQuit:
+				os.Exit(0)
+			case app.InstanceReadOnly:
+				readOnly = true
+			}
+		default:
+			defer lock.Release()
+		}
+	}
+	config.SetStateReadOnly(readOnly)
+
+	// Detect an offline machine up front so the first prisma commands don't stall on npx
+	if !demoMode {
+		node.CheckNetwork()
+	}
+
+	// Create app with its panels and controllers
+	tuiApp, err := app.Bootstrap(cfg, app.AppConfig{
+		DebugMode:    debugMode,
+		DebugLogPath: debugLogPath,
+		DemoMode:     demoMode,
+		ReadOnly:     readOnly,
+		AppName:      "LazyPrisma",
+		Version:      Version,
+		Developer:    Developer,
+		Language:     cfg.Language,
+		ScanProgress: os.Stderr,
+	}, demoState)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, tr.ErrorFailedCreateApp, err)
 		os.Exit(1)
 	}
 
-	// Create and register panels
-	workspace := context.NewWorkspaceContext(context.WorkspaceContextOpts{
-		Gui:      tuiApp.GetGui(),
-		Tr:       tr,
-		ViewName: "workspace",
-	})
-	migrationsCtx := context.NewMigrationsContext(context.MigrationsContextOpts{
-		Gui:      tuiApp.GetGui(),
-		Tr:       tr,
-		ViewName: "migrations",
-	})
-	detailsCtx := context.NewDetailsContext(context.DetailsContextOpts{
-		Gui:      tuiApp.GetGui(),
-		Tr:       tr,
-		ViewName: "details",
-	})
-	output := context.NewOutputContext(context.OutputContextOpts{
-		Gui:      tuiApp.GetGui(),
-		Tr:       tr,
-		ViewName: "outputs",
-	})
-	statusbar := context.NewStatusBarContext(context.StatusBarContextOpts{
-		Gui:      tuiApp.GetGui(),
-		Tr:       tr,
-		ViewName: "statusbar",
-		State:    tuiApp.StatusBarState(),
-		Config: context.StatusBarConfig{
-			Developer: Developer,
-			Version:   Version,
-		},
-	})
-
-	// Wire callbacks (replaces old bidirectional coupling)
-	migrationsCtx.SetOnSelectionChanged(func(mig *prisma.Migration, tab string) {
-		detailsCtx.UpdateFromMigration(mig, tab)
-	})
-	migrationsCtx.SetModalCallbacks(tuiApp.HasActiveModal, func(viewID string) {
-		tuiApp.HandlePanelClick(viewID)
-	})
-	detailsCtx.SetModalCallbacks(tuiApp.HasActiveModal, func(viewID string) {
-		tuiApp.HandlePanelClick(viewID)
-	})
-
-	// Load action-needed data for details context
-	detailsCtx.SetActionNeededMigrations(collectActionNeededMigrations(migrationsCtx.GetCategory()))
-	detailsCtx.LoadActionNeededData()
-
-	tuiApp.RegisterPanel(workspace)
-	tuiApp.RegisterPanel(migrationsCtx)
-	tuiApp.RegisterPanel(detailsCtx)
-	tuiApp.RegisterPanel(output)
-	tuiApp.RegisterPanel(statusbar)
-
-	// Create and wire controllers
-	gui := tuiApp.GetGui()
-
-	migrationsController := app.NewMigrationsController(
-		tuiApp, gui, migrationsCtx, output,
-		tuiApp.OpenModal, tuiApp.CloseModal,
-		tuiApp.RunStreamingCommand,
-	)
-	generateController := app.NewGenerateController(
-		tuiApp, gui, output,
-		tuiApp.OpenModal,
-		tuiApp.RunStreamingCommand,
-	)
-	studioController := app.NewStudioController(
-		tuiApp, gui, output,
-		tuiApp.OpenModal,
-	)
-	clipboardController := app.NewClipboardController(
-		tuiApp, gui, migrationsCtx,
-		tuiApp.OpenModal, tuiApp.CloseModal,
-	)
-
-	tuiApp.SetControllers(migrationsController, generateController, studioController, clipboardController)
-
 	// Register keybindings
 	if err := tuiApp.RegisterKeybindings(); err != nil {
 		fmt.Fprintf(os.Stderr, tr.ErrorFailedRegisterKeybindings, err)
@@ -151,15 +155,3 @@ func main() {
 		os.Exit(1)
 	}
 }
-
-// collectActionNeededMigrations extracts migrations that need action (Empty or Checksum Mismatch)
-// from the Local category.
-func collectActionNeededMigrations(cat prisma.MigrationCategory) []prisma.Migration {
-	var result []prisma.Migration
-	for _, mig := range cat.Local {
-		if mig.IsEmpty || mig.ChecksumMismatch {
-			result = append(result, mig)
-		}
-	}
-	return result
-}
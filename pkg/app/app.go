@@ -1,19 +1,34 @@
 package app
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
 	"sync/atomic"
 	"time"
 
+	"github.com/dokadev/lazyprisma/pkg/audit"
 	"github.com/dokadev/lazyprisma/pkg/common"
+	"github.com/dokadev/lazyprisma/pkg/config"
 	"github.com/dokadev/lazyprisma/pkg/gui/context"
+	"github.com/dokadev/lazyprisma/pkg/gui/types"
 	"github.com/dokadev/lazyprisma/pkg/i18n"
+	"github.com/dokadev/lazyprisma/pkg/node"
+	"github.com/dokadev/lazyprisma/pkg/notify"
 	"github.com/dokadev/lazyprisma/pkg/prisma"
+	"github.com/dokadev/lazyprisma/pkg/stats"
 	"github.com/jesseduffield/gocui"
 )
 
 const (
 	spinnerTickInterval = 50 * time.Millisecond
+
+	// How often panels are redrawn while idle, to keep their "updated 4m ago"
+	// footers current
+	panelAgeTickInterval = 30 * time.Second
 )
 
 type App struct {
@@ -28,30 +43,111 @@ type App struct {
 	// Modal management
 	activeModal Modal
 	savedFocus  int
+	quitModal   Modal // Asks before quitting rolls back the SQL console's writes
+
+	// Output panel line where the last failed command's output starts, for
+	// its error modal to link to (-1 = none)
+	failedOutput int
 
 	// Command execution tracking
 	commandRunning     atomic.Bool   // Thread-safe flag for command execution
 	runningCommandName atomic.Value  // Name of currently running command (string)
+	commandStep        atomic.Value  // Sub-step the running command is at, e.g. "Loading migrations..." (string)
 	spinnerFrame       atomic.Uint32 // Current spinner frame index (0-3)
-	stopSpinnerCh      chan struct{} // Channel to stop spinner goroutine
+	stopSpinnerCh      chan struct{} // Closed on exit to stop the spinner and file watcher goroutines
+
+	// Refresh requests waiting for the running refresh or command
+	refreshQueue refreshQueue
+
+	// Polls project files to refresh when they change (nil = watch.enabled is off)
+	fileWatcher *fileWatcher
+
+	// Brief message shown in the status bar until it expires (nil = none)
+	toast atomic.Pointer[toastMessage]
+
+	// Prisma engine download progress of the running command
+	engineDownloading     atomic.Bool
+	engineDownloadPercent atomic.Int32 // 0-100, or -1 if unknown
+
+	// Migrate deploy progress of the running command (deployTotal 0 = not tracked)
+	deployTotal     atomic.Int32
+	deployApplying  atomic.Int32 // 1-based index of the migration being applied
+	deployMigration atomic.Value // Name of the migration being applied (string)
+
+	// The Prisma CLI could not be run: only browsing works until it is installed
+	prismaMissing atomic.Bool
+
+	// Per-project settings (.lazyprisma.yaml), reloaded on refresh
+	projectConfig atomic.Pointer[config.ProjectConfig]
+
+	// Audit trail of executed commands (nil = disabled)
+	auditLog *audit.Log
+
+	// Commands run this session, for the command history modal
+	history commandHistory
+
+	// Log each command's command line, directory and environment overrides
+	// before it runs
+	showInvocation bool
+
+	// Local usage statistics (nil = disabled)
+	usageStats *stats.Store
+
+	// Frame and panel draw times, written to the debug log (nil unless in debug mode)
+	drawProfiler *drawProfiler
+
+	// Runs the Prisma CLI (a prisma.MockRunner in integration tests)
+	prismaRunner prisma.Runner
 
 	// Controllers
 	migrationsController *MigrationsController
 	generateController   *GenerateController
 	studioController     *StudioController
 	clipboardController  *ClipboardController
+	impactController     *ImpactController
+	detailsController    *DetailsController
+	branchController     *BranchController
+	envController        *EnvironmentController
+	auditController      *AuditController
+	historyController    *HistoryController
+	statsController      *StatsController
+	doctorController     *DoctorController
+	scriptsController    *ScriptsController
+	reviewController     *ReviewController
+	workspaceController  *WorkspaceController
+	peekController       *PeekController
+	consoleController    *ConsoleController
 }
 
 type AppConfig struct {
-	DebugMode bool
-	AppName   string
-	Version   string
-	Developer string
-	Language  string
+	DebugMode    bool
+	DebugLogPath string // Draw profiling output in debug mode
+	DemoMode     bool   // Fixture project without Prisma or a database: commands are not run
+	ReadOnly     bool   // Another instance has the project open: commands are not run
+	AppName      string
+	Version      string
+	Developer    string
+	Language     string
+
+	// ScanProgress is where the first load of a very large migrations
+	// directory shows its progress, before the TUI starts (nil = nowhere)
+	ScanProgress io.Writer
+
+	// Headless runs on a simulated screen of Width x Height fed with scripted
+	// key events (see pkg/integration) instead of the terminal
+	Headless bool
+	Width    int
+	Height   int
 }
 
 func NewApp(config AppConfig) (*App, error) {
-	g, err := gocui.NewGui(gocui.NewGuiOpts{OutputMode: gocui.OutputTrue})
+	g, err := gocui.NewGui(gocui.NewGuiOpts{
+		OutputMode:    gocui.OutputTrue,
+		Headless:      config.Headless,
+		PlayRecording: config.Headless,
+		Width:         config.Width,
+		Height:        config.Height,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -64,9 +160,17 @@ func NewApp(config AppConfig) (*App, error) {
 		Common:        cmn,
 		Tr:            cmn.Tr,
 		panels:        make(map[string]Panel),
-		focusOrder:    []string{ViewWorkspace, ViewMigrations, ViewDetails, ViewOutputs},
+		focusOrder:    []string{ViewWorkspace, ViewMigrations, ViewSchema, ViewDetails, ViewOutputs},
 		currentFocus:  0,
 		stopSpinnerCh: make(chan struct{}),
+		prismaRunner:  prisma.NewCLIRunner(),
+		failedOutput:  -1,
+	}
+
+	app.loadProjectConfig()
+
+	if config.DebugMode && config.DebugLogPath != "" {
+		app.drawProfiler = newDrawProfiler(config.DebugLogPath)
 	}
 
 	g.SetManagerFunc(gocui.ManagerFunc(app.layoutManager))
@@ -80,22 +184,38 @@ func NewApp(config AppConfig) (*App, error) {
 }
 
 // SetControllers wires the extracted controllers into the App.
-func (a *App) SetControllers(mc *MigrationsController, gc *GenerateController, sc *StudioController, cc *ClipboardController) {
+func (a *App) SetControllers(mc *MigrationsController, gc *GenerateController, sc *StudioController, cc *ClipboardController, ic *ImpactController, dc *DetailsController, bc *BranchController, ec *EnvironmentController, ac *AuditController, hc *HistoryController, stc *StatsController, drc *DoctorController, scc *ScriptsController, rvc *ReviewController, wsc *WorkspaceController, pc *PeekController, csc *ConsoleController) {
 	a.migrationsController = mc
 	a.generateController = gc
 	a.studioController = sc
 	a.clipboardController = cc
+	a.impactController = ic
+	a.detailsController = dc
+	a.branchController = bc
+	a.envController = ec
+	a.auditController = ac
+	a.historyController = hc
+	a.statsController = stc
+	a.doctorController = drc
+	a.scriptsController = scc
+	a.reviewController = rvc
+	a.workspaceController = wsc
+	a.peekController = pc
+	a.consoleController = csc
 }
 
 func (a *App) Run() error {
 	defer a.g.Close()
 	defer close(a.stopSpinnerCh) // Stop spinner goroutine
+	defer a.drawProfiler.Close()
 	defer func() {
 		// Kill studio process if running
 		if a.studioController != nil {
-			if cmd := a.studioController.GetStudioCmd(); cmd != nil {
-				cmd.Kill()
-			}
+			a.studioController.Cleanup()
+		}
+		// Roll back the SQL console's uncommitted writes
+		if a.consoleController != nil {
+			a.consoleController.Cleanup()
 		}
 	}()
 
@@ -106,12 +226,48 @@ func (a *App) Run() error {
 		}
 	}
 
+	// Without the Prisma CLI, offer to install it before anything else
+	if a.prismaMissing.Load() {
+		a.g.Update(func(g *gocui.Gui) error {
+			a.ShowPrismaMissing("")
+			return nil
+		})
+	}
+
 	if err := a.g.MainLoop(); err != nil && err != gocui.ErrQuit {
 		return err
 	}
 	return nil
 }
 
+// quit exits the app, asking first if the SQL console's open transaction has
+// statements quitting would roll back. Asking to quit again while the question
+// is shown quits.
+func (a *App) quit() error {
+	count := 0
+	if a.consoleController != nil {
+		count = a.consoleController.UncommittedWrites()
+	}
+	if count == 0 || (a.quitModal != nil && a.activeModal == a.quitModal) {
+		return gocui.ErrQuit
+	}
+
+	a.quitModal = NewConfirmModal(a.g, a.Tr, a.Tr.ModalTitleQuitUncommitted,
+		fmt.Sprintf(a.Tr.ModalMsgQuitUncommitted, count),
+		func() {
+			a.CloseModal()
+			a.g.Update(func(g *gocui.Gui) error {
+				return gocui.ErrQuit
+			})
+		},
+		func() {
+			a.CloseModal()
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+	a.OpenModal(a.quitModal)
+	return nil
+}
+
 func (a *App) RegisterPanel(panel Panel) {
 	a.panels[panel.ID()] = panel
 }
@@ -141,6 +297,41 @@ func (a *App) StatusBarState() context.StatusBarState {
 			}
 			return ""
 		},
+		GetCommandStep: func() string {
+			step, _ := a.commandStep.Load().(string)
+			return step
+		},
+		IsOffline: node.IsOffline,
+		GetToast: func() string {
+			toast := a.toast.Load()
+			if toast == nil || time.Now().After(toast.until) {
+				return ""
+			}
+			return toast.text
+		},
+		IsPrismaMissing: func() bool {
+			return a.prismaMissing.Load()
+		},
+		IsDemo: func() bool {
+			return a.config.DemoMode
+		},
+		IsReadOnly: func() bool {
+			return a.config.ReadOnly
+		},
+		GetUncommittedWrites: func() int {
+			if a.consoleController != nil {
+				return a.consoleController.UncommittedWrites()
+			}
+			return 0
+		},
+		GetEngineDownload: func() (int, bool) {
+			return int(a.engineDownloadPercent.Load()), a.engineDownloading.Load()
+		},
+		GetDeployProgress: func() (int, int, string, bool) {
+			total, current := int(a.deployTotal.Load()), int(a.deployApplying.Load())
+			name, _ := a.deployMigration.Load().(string)
+			return current, total, name, total > 0 && current > 0
+		},
 	}
 }
 
@@ -174,6 +365,16 @@ func (a *App) CloseModal() {
 	}
 }
 
+// ShowFailedOutput closes the modal, focuses the Output panel and scrolls it
+// to the start of the last failed command's output
+func (a *App) ShowFailedOutput() {
+	a.CloseModal()
+	if out, ok := a.panels[ViewOutputs].(*context.OutputContext); ok && a.failedOutput >= 0 {
+		out.RevealOutput(a.failedOutput)
+	}
+	a.focusPanel(ViewOutputs)
+}
+
 // HasActiveModal returns true if a modal is currently active
 func (a *App) HasActiveModal() bool {
 	return a.activeModal != nil
@@ -187,6 +388,11 @@ func (a *App) GetCurrentPanel() Panel {
 	return nil
 }
 
+// currentPanelIs reports whether the panel with the given view ID has focus
+func (a *App) currentPanelIs(viewID string) bool {
+	return a.currentFocus >= 0 && a.currentFocus < len(a.focusOrder) && a.focusOrder[a.currentFocus] == viewID
+}
+
 // TryStartCommand attempts to start a command execution.
 // Returns true if command can start, false if another command is already running.
 func (a *App) TryStartCommand(commandName string) bool {
@@ -202,8 +408,58 @@ func (a *App) TryStartCommand(commandName string) bool {
 // FinishCommand marks command execution as complete.
 func (a *App) FinishCommand() {
 	a.runningCommandName.Store("")
+	a.commandStep.Store("")
 	a.commandRunning.Store(false)
 	a.spinnerFrame.Store(0) // Reset spinner to first frame
+	a.engineDownloading.Store(false)
+	a.deployTotal.Store(0)
+	a.deployApplying.Store(0)
+	a.deployMigration.Store("")
+
+	// Refreshes requested while the command ran
+	a.startQueuedRefresh()
+}
+
+// TrackDeployProgress starts counting "Applying migration" lines of the
+// running command against the number of migrations it is expected to apply
+func (a *App) TrackDeployProgress(total int) {
+	a.deployApplying.Store(0)
+	a.deployMigration.Store("")
+	a.deployTotal.Store(int32(total))
+}
+
+// trackDeployProgress advances the migrate deploy progress from a line of
+// command output
+func (a *App) trackDeployProgress(line string) {
+	if a.deployTotal.Load() == 0 {
+		return
+	}
+	name, ok := prisma.ParseApplyingMigration(line)
+	if !ok {
+		return
+	}
+	a.deployMigration.Store(name)
+	current := a.deployApplying.Add(1)
+	// More migrations than expected (e.g. added since the last refresh)
+	if current > a.deployTotal.Load() {
+		a.deployTotal.Store(current)
+	}
+}
+
+// trackEngineDownload updates the engine download progress from a line of
+// command output. It returns false if the line should not be shown in the
+// Output panel (intermediate progress bar redraws).
+func (a *App) trackEngineDownload(line string) bool {
+	percent, ok := prisma.ParseEngineDownload(line)
+	if !ok {
+		// Any other output means the download is over
+		a.engineDownloading.Store(false)
+		return true
+	}
+
+	started := !a.engineDownloading.Swap(true)
+	previous := a.engineDownloadPercent.Swap(int32(percent))
+	return started || percent < 0 || percent == 100 && previous != 100
 }
 
 // LogCommandBlocked logs a message when command execution is blocked.
@@ -226,11 +482,14 @@ func (a *App) LogCommandBlocked(commandName string) {
 	})
 }
 
-// startSpinnerUpdater starts a background goroutine that updates the spinner frame
+// startSpinnerUpdater starts a background goroutine that updates the spinner
+// frame, and redraws the panels now and then so their age footers advance
 func (a *App) startSpinnerUpdater() {
 	go func() {
 		ticker := time.NewTicker(spinnerTickInterval)
 		defer ticker.Stop()
+		ageTicker := time.NewTicker(panelAgeTickInterval)
+		defer ageTicker.Stop()
 
 		for {
 			select {
@@ -248,6 +507,11 @@ func (a *App) startSpinnerUpdater() {
 						return nil
 					})
 				}
+			case <-ageTicker.C:
+				a.g.Update(func(g *gocui.Gui) error {
+					// Panels will be redrawn by layout manager
+					return nil
+				})
 			case <-a.stopSpinnerCh:
 				return
 			}
@@ -268,7 +532,13 @@ func (a *App) handlePanelClick(viewID string) error {
 		return nil
 	}
 
-	// Find the index of the clicked panel in focus order
+	a.focusPanel(viewID)
+	return nil
+}
+
+// focusPanel moves the focus to the panel of viewID
+func (a *App) focusPanel(viewID string) {
+	// Find the index of the panel in focus order
 	targetIndex := -1
 	for i, id := range a.focusOrder {
 		if id == viewID {
@@ -279,7 +549,7 @@ func (a *App) handlePanelClick(viewID string) error {
 
 	// If panel not found or already focused, do nothing
 	if targetIndex == -1 || targetIndex == a.currentFocus {
-		return nil
+		return
 	}
 
 	// Blur current panel
@@ -294,8 +564,6 @@ func (a *App) handlePanelClick(viewID string) error {
 	if panel, ok := a.panels[a.focusOrder[a.currentFocus]]; ok {
 		panel.OnFocus()
 	}
-
-	return nil
 }
 
 // registerMouseClickForFocus registers a mouse click handler to switch focus
@@ -312,9 +580,9 @@ func (a *App) registerMouseClickForFocus(viewID string) {
 
 // RegisterMouseBindings registers mouse click handlers for all panels
 func (a *App) RegisterMouseBindings() {
-	// Register click handlers for all panels except MigrationsPanel and DetailsPanel
+	// Register click handlers for all panels except the list panels and DetailsPanel
 	for _, viewID := range a.focusOrder {
-		if viewID != ViewMigrations && viewID != ViewDetails {
+		if viewID != ViewMigrations && viewID != ViewSchema && viewID != ViewDetails {
 			a.registerMouseClickForFocus(viewID)
 		}
 	}
@@ -337,6 +605,18 @@ func (a *App) RegisterMouseBindings() {
 		})
 	}
 
+	// List item click binding for SchemaContext
+	if schemaCtx, ok := a.panels[ViewSchema].(*context.SchemaContext); ok {
+		a.g.SetViewClickBinding(&gocui.ViewMouseBinding{
+			ViewName: ViewSchema,
+			Key:      gocui.MouseLeft,
+			Modifier: gocui.ModNone,
+			Handler: func(opts gocui.ViewMouseBindingOpts) error {
+				return schemaCtx.HandleListClick(opts.Y)
+			},
+		})
+	}
+
 	// Register special bindings for DetailsContext
 	if detailsCtx, ok := a.panels[ViewDetails].(*context.DetailsContext); ok {
 		// Tab click binding
@@ -410,6 +690,34 @@ func (a *App) registerMouseWheelBindings() {
 		})
 	}
 
+	// Schema context
+	if schemaCtx, ok := a.panels[ViewSchema].(*context.SchemaContext); ok {
+		a.g.SetViewClickBinding(&gocui.ViewMouseBinding{
+			ViewName: ViewSchema,
+			Key:      gocui.MouseWheelUp,
+			Modifier: gocui.ModNone,
+			Handler: func(opts gocui.ViewMouseBindingOpts) error {
+				if a.HasActiveModal() {
+					return nil
+				}
+				schemaCtx.ScrollUpByWheel()
+				return nil
+			},
+		})
+		a.g.SetViewClickBinding(&gocui.ViewMouseBinding{
+			ViewName: ViewSchema,
+			Key:      gocui.MouseWheelDown,
+			Modifier: gocui.ModNone,
+			Handler: func(opts gocui.ViewMouseBindingOpts) error {
+				if a.HasActiveModal() {
+					return nil
+				}
+				schemaCtx.ScrollDownByWheel()
+				return nil
+			},
+		})
+	}
+
 	// Details context
 	if detailsCtx, ok := a.panels[ViewDetails].(*context.DetailsContext); ok {
 		a.g.SetViewClickBinding(&gocui.ViewMouseBinding{
@@ -467,51 +775,57 @@ func (a *App) registerMouseWheelBindings() {
 	}
 }
 
-// RefreshAll refreshes all panels asynchronously
-func (a *App) RefreshAll(onComplete ...func()) bool {
-	// Try to start command - if another command is running, block
-	if !a.TryStartCommand("Refresh All") {
-		a.LogCommandBlocked("Refresh All")
-		return false
-	}
+// RefreshPanels reloads the panels of scope (blocking, internal). Each step
+// is shown in the status bar, and panels show what they are waiting for
+// instead of their stale content.
+func (a *App) RefreshPanels(scope types.RefreshScope) {
+	defer a.commandStep.Store("")
 
-	// Run refresh in background to avoid blocking UI
-	go func() {
-		defer a.FinishCommand() // Always mark command as complete
+	a.loadProjectConfig()
 
-		a.RefreshPanels()
+	// Re-check connectivity in the background; the result applies to the next command
+	go node.CheckNetwork()
 
-		// Update UI on main thread (thread-safe)
-		a.g.Update(func(g *gocui.Gui) error {
-			// Add refresh notification to output panel
-			if outputPanel, ok := a.panels[ViewOutputs].(*context.OutputContext); ok {
-				outputPanel.LogAction(a.Tr.ActionRefresh, a.Tr.SuccessAllPanelsRefreshed)
-			}
+	workspaceCtx, hasWorkspace := a.panels[ViewWorkspace].(*context.WorkspaceContext)
+	migrationsCtx, hasMigrations := a.panels[ViewMigrations].(*context.MigrationsContext)
+	detailsCtx, hasDetails := a.panels[ViewDetails].(*context.DetailsContext)
+	schemaCtx, hasSchemaPanel := a.panels[ViewSchema].(*context.SchemaContext)
 
-			// Execute callbacks
-			for _, callback := range onComplete {
-				callback()
-			}
-			return nil
-		})
-	}()
+	hasWorkspace = hasWorkspace && scope&types.RefreshWorkspace != 0
+	hasMigrations = hasMigrations && scope&types.RefreshMigrations != 0
+	hasSchema := hasDetails && scope&types.RefreshSchema != 0
 
-	return true
-}
+	// Every panel is stale until its step has run
+	if hasWorkspace {
+		workspaceCtx.StartLoading(a.Tr.RefreshStepVersions)
+	}
+	if hasMigrations {
+		migrationsCtx.StartLoading(a.Tr.RefreshStepMigrations)
+		if hasDetails {
+			detailsCtx.StartLoading(a.Tr.RefreshStepMigrations)
+		}
+	} else if hasSchema {
+		detailsCtx.StartLoading(a.Tr.RefreshStepSchema)
+	}
 
-// RefreshPanels refreshes all panels (blocking, internal).
-func (a *App) RefreshPanels() {
 	// Refresh workspace panel
-	if workspaceCtx, ok := a.panels[ViewWorkspace].(*context.WorkspaceContext); ok {
-		workspaceCtx.Refresh()
+	if hasWorkspace {
+		a.refreshStep(workspaceCtx, a.Tr.RefreshStepVersions)
+		workspaceCtx.RefreshVersions()
+		a.refreshStep(workspaceCtx, a.Tr.RefreshStepDatabase)
+		workspaceCtx.RefreshDatabase()
+		workspaceCtx.FinishLoading()
+		a.prismaMissing.Store(!workspaceCtx.HasPrismaCLI())
 	}
 
 	// Refresh migrations context
-	if migrationsCtx, ok := a.panels[ViewMigrations].(*context.MigrationsContext); ok {
+	if hasMigrations {
+		a.refreshStep(migrationsCtx, a.Tr.RefreshStepMigrations)
 		migrationsCtx.Refresh()
+		migrationsCtx.FinishLoading()
 
 		// Wire action-needed data from migrations to details
-		if detailsCtx, ok := a.panels[ViewDetails].(*context.DetailsContext); ok {
+		if hasDetails {
 			// Collect action-needed migrations from Local category
 			var actionNeeded []prisma.Migration
 			for _, mig := range migrationsCtx.GetCategory().Local {
@@ -520,7 +834,281 @@ func (a *App) RefreshPanels() {
 				}
 			}
 			detailsCtx.SetActionNeededMigrations(actionNeeded)
+			detailsCtx.SetMigrationTableMissing(migrationsCtx.IsMigrationTableMissing())
+			detailsCtx.SetEdgeDatabase(migrationsCtx.EdgeDatabase())
+			detailsCtx.SetMergePlan(migrationsCtx.MergeFixPlan())
+			a.refreshStep(detailsCtx, a.Tr.RefreshStepSchema)
 			detailsCtx.LoadActionNeededData()
 		}
 	}
+
+	// Refresh the schema panel's outline
+	if hasSchemaPanel && scope&types.RefreshSchema != 0 {
+		schemaCtx.Refresh()
+	}
+
+	// Refresh the schema shown in details
+	if hasSchema {
+		a.refreshStep(detailsCtx, a.Tr.RefreshStepSchema)
+		detailsCtx.LoadSchema()
+	}
+	if hasDetails && (hasMigrations || hasSchema) {
+		detailsCtx.FinishLoading()
+	}
+}
+
+// refreshStep shows the refresh step in the status bar and as the loading
+// placeholder of the panel it reloads
+func (a *App) refreshStep(panel interface{ StartLoading(string) }, step string) {
+	a.commandStep.Store(step)
+	panel.StartLoading(step)
+	a.g.Update(func(g *gocui.Gui) error { return nil })
+}
+
+// loadProjectConfig (re)reads the project config from the working directory.
+// A missing or invalid file leaves every action enabled.
+func (a *App) loadProjectConfig() {
+	cfg := &config.ProjectConfig{}
+	if cwd, err := os.Getwd(); err == nil {
+		if loaded, err := config.LoadProject(cwd); err == nil {
+			cfg = loaded
+		}
+	}
+	a.projectConfig.Store(cfg)
+}
+
+// IsActionDisabled reports whether the project config disables an action.
+func (a *App) IsActionDisabled(action string) bool {
+	if a.config.DemoMode || a.config.ReadOnly {
+		return true
+	}
+	cfg := a.projectConfig.Load()
+	return cfg != nil && cfg.IsActionDisabled(action)
+}
+
+// RejectInReadOnly shows that name would change the project or its database,
+// which only the instance holding the project's lock does, and returns true
+// in a read-only instance.
+func (a *App) RejectInReadOnly(name string) bool {
+	if !a.config.ReadOnly {
+		return false
+	}
+
+	modal := NewMessageModal(a.g, a.Tr, a.Tr.ModalTitleReadOnly,
+		fmt.Sprintf(a.Tr.ModalMsgReadOnlyActionDisabled, name),
+		a.Tr.ModalMsgReadOnlyHint,
+	).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+	a.OpenModal(modal)
+	return true
+}
+
+// RejectOpenTransaction shows that name would wait for the locks the SQL
+// console's open transaction holds, and returns true while it has writes.
+func (a *App) RejectOpenTransaction(name string) bool {
+	count := 0
+	if a.consoleController != nil {
+		count = a.consoleController.UncommittedWrites()
+	}
+	if count == 0 {
+		return false
+	}
+
+	modal := NewMessageModal(a.g, a.Tr, a.Tr.ModalTitleTransactionOpen,
+		fmt.Sprintf(a.Tr.ModalMsgTransactionOpenBlocks, name, count),
+		a.Tr.ModalMsgTransactionOpenHint,
+	).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+	a.OpenModal(modal)
+	return true
+}
+
+// RejectInDemoMode shows that name needs Prisma or a database, which the demo
+// project doesn't have, and returns true in demo mode.
+func (a *App) RejectInDemoMode(name string) bool {
+	if !a.config.DemoMode {
+		return false
+	}
+
+	modal := NewMessageModal(a.g, a.Tr, a.Tr.ModalTitleDemoMode,
+		fmt.Sprintf(a.Tr.ModalMsgDemoActionDisabled, name),
+	).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+	a.OpenModal(modal)
+	return true
+}
+
+// RejectDisabledAction shows an explanatory modal and returns true if the
+// action is disabled (for controllers; see rejectDisabledAction).
+func (a *App) RejectDisabledAction(action string) bool {
+	return a.rejectDisabledAction(action)
+}
+
+// rejectDisabledAction shows an explanatory modal and returns true if the action is disabled.
+func (a *App) rejectDisabledAction(action string) bool {
+	if !a.IsActionDisabled(action) {
+		return false
+	}
+
+	if a.RejectInDemoMode(action) {
+		return true
+	}
+
+	if a.RejectInReadOnly(action) {
+		return true
+	}
+
+	modal := NewMessageModal(a.g, a.Tr, a.Tr.ModalTitleActionDisabled,
+		fmt.Sprintf(a.Tr.ModalMsgActionDisabled, action),
+		fmt.Sprintf(a.Tr.ModalMsgActionDisabledHint, config.ProjectConfigFile),
+	).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+	a.OpenModal(modal)
+	return true
+}
+
+// toggleURLMask reveals or masks the database URL in the workspace panel,
+// explaining why if revealing is turned off in the config
+func (a *App) toggleURLMask() {
+	workspaceCtx, ok := a.panels[ViewWorkspace].(*context.WorkspaceContext)
+	if !ok || workspaceCtx.ToggleURLMask() {
+		return
+	}
+
+	modal := NewMessageModal(a.g, a.Tr, a.Tr.ModalTitleRevealURLDisabled,
+		a.Tr.ModalMsgRevealURLDisabled,
+	).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+	a.OpenModal(modal)
+}
+
+// SetPrismaRunner replaces what runs the Prisma CLI, e.g. with a prisma.MockRunner.
+func (a *App) SetPrismaRunner(runner prisma.Runner) {
+	a.prismaRunner = runner
+}
+
+// PrismaRunner returns what runs the Prisma CLI.
+func (a *App) PrismaRunner() prisma.Runner {
+	return a.prismaRunner
+}
+
+// SetAuditLog enables the audit trail of executed commands.
+func (a *App) SetAuditLog(log *audit.Log) {
+	a.auditLog = log
+}
+
+// GetAuditLog returns the audit log, or nil if auditing is disabled.
+func (a *App) GetAuditLog() *audit.Log {
+	return a.auditLog
+}
+
+// SetUsageStats enables local usage statistics.
+func (a *App) SetUsageStats(store *stats.Store) {
+	a.usageStats = store
+}
+
+// RecordCommand appends an executed command to the audit trail and counts it
+// in the usage statistics. env holds the command's extra environment variables,
+// used to detect a DATABASE_URL override. Safe to call from any goroutine.
+func (a *App) RecordCommand(args []string, env []string, exitCode int, duration time.Duration) {
+	if a.usageStats != nil {
+		// Statistics are a nicety; a failed write is not worth interrupting the user
+		_ = a.usageStats.Record(stats.CommandAction(args), exitCode == 0, duration)
+	}
+
+	if a.auditLog == nil {
+		return
+	}
+
+	cwd, _ := os.Getwd()
+	entry := audit.Entry{
+		Time:       time.Now(),
+		User:       audit.CurrentUser(),
+		Dir:        cwd,
+		DBTarget:   auditDBTarget(cwd, env),
+		Command:    strings.Join(args, " "),
+		ExitCode:   exitCode,
+		DurationMs: duration.Milliseconds(),
+	}
+
+	if err := a.auditLog.Append(entry); err != nil {
+		a.g.Update(func(g *gocui.Gui) error {
+			if out, ok := a.panels[ViewOutputs].(*context.OutputContext); ok {
+				out.LogActionRed(a.Tr.LogActionAudit, a.Tr.LogMsgAuditWriteFailed+" "+err.Error())
+			}
+			return nil
+		})
+	}
+}
+
+// NotifyWebhooks posts summary to the project config's webhooks for its event.
+// The requests are sent in the background; the outcome of each is logged to
+// the output panel.
+func (a *App) NotifyWebhooks(summary notify.Summary) {
+	cfg := a.projectConfig.Load()
+	if cfg == nil || a.config.DemoMode {
+		return
+	}
+	cwd, _ := os.Getwd()
+
+	for _, hook := range cfg.Webhooks {
+		if !hook.Notifies(summary.Event) {
+			continue
+		}
+		hookURL := hook.ResolveURL(func(name string) string { return prisma.ResolveEnvVar(cwd, name) })
+		template := hook.Template
+
+		go func() {
+			var err error
+			if hookURL == "" {
+				err = fmt.Errorf(a.Tr.LogMsgWebhookNoURL, hook.URLEnv)
+			} else if body, renderErr := notify.Render(template, summary); renderErr != nil {
+				err = renderErr
+			} else {
+				err = notify.Post(hookURL, body)
+			}
+			// A failed request's error repeats the URL, secret token included
+			var urlErr *url.Error
+			for errors.As(err, &urlErr) {
+				err = urlErr.Err
+			}
+
+			a.g.Update(func(g *gocui.Gui) error {
+				out, ok := a.panels[ViewOutputs].(*context.OutputContext)
+				if !ok {
+					return nil
+				}
+				switch {
+				case hookURL == "":
+					out.LogActionRed(a.Tr.LogActionWebhook, err.Error())
+				case err != nil:
+					out.LogActionRed(a.Tr.LogActionWebhook, fmt.Sprintf(a.Tr.LogMsgWebhookFailed, summary.Event, webhookHost(hookURL), err))
+				default:
+					out.LogAction(a.Tr.LogActionWebhook, fmt.Sprintf(a.Tr.LogMsgWebhookSent, summary.Event, webhookHost(hookURL)))
+				}
+				return nil
+			})
+		}()
+	}
+}
+
+// webhookHost returns the host of a webhook URL for the output panel; the
+// rest of the URL usually holds its secret token
+func webhookHost(webhookURL string) string {
+	if u, err := url.Parse(webhookURL); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return "webhook"
+}
+
+// auditDBTarget returns the fingerprint of the database a command runs against
+func auditDBTarget(cwd string, env []string) string {
+	if envVar, err := prisma.GetEnvVarName(cwd); err == nil && envVar != "" {
+		// A later override wins, as it does for the command itself
+		for i := len(env) - 1; i >= 0; i-- {
+			if value, ok := strings.CutPrefix(env[i], envVar+"="); ok {
+				return prisma.DBFingerprint(value)
+			}
+		}
+	}
+
+	if ds, err := prisma.GetDatasource(cwd); err == nil {
+		return prisma.DBFingerprint(ds.URL)
+	}
+	return ""
 }
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dokadev/lazyprisma/pkg/audit"
+	"github.com/dokadev/lazyprisma/pkg/gui/style"
+	"github.com/dokadev/lazyprisma/pkg/gui/types"
+	"github.com/jesseduffield/gocui"
+)
+
+// auditViewLimit caps the number of entries shown in the audit viewer
+const auditViewLimit = 500
+
+// AuditController shows the audit trail of executed commands.
+type AuditController struct {
+	c          types.IControllerHost
+	g          *gocui.Gui
+	auditLog   *audit.Log // nil if auditing is disabled
+	openModal  func(Modal)
+	closeModal func()
+}
+
+// NewAuditController creates a new AuditController.
+func NewAuditController(
+	c types.IControllerHost,
+	g *gocui.Gui,
+	auditLog *audit.Log,
+	openModal func(Modal),
+	closeModal func(),
+) *AuditController {
+	return &AuditController{
+		c:          c,
+		g:          g,
+		auditLog:   auditLog,
+		openModal:  openModal,
+		closeModal: closeModal,
+	}
+}
+
+// ShowAuditLog lists recorded commands, newest first
+func (ac *AuditController) ShowAuditLog() {
+	tr := ac.c.GetTranslationSet()
+
+	if ac.auditLog == nil {
+		modal := NewMessageModal(ac.g, tr, tr.ModalTitleAuditLog,
+			tr.ModalMsgAuditDisabled,
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		ac.openModal(modal)
+		return
+	}
+
+	entries, err := ac.auditLog.Read(auditViewLimit)
+	if err != nil {
+		modal := NewMessageModal(ac.g, tr, tr.ModalTitleAuditLog,
+			tr.ModalMsgFailedReadAuditLog,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		ac.openModal(modal)
+		return
+	}
+
+	if len(entries) == 0 {
+		modal := NewMessageModal(ac.g, tr, tr.ModalTitleAuditLog,
+			fmt.Sprintf(tr.ModalMsgAuditLogEmpty, ac.auditLog.Path()),
+		).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+		ac.openModal(modal)
+		return
+	}
+
+	items := make([]ListModalItem, 0, len(entries))
+	for _, entry := range entries {
+		var status string
+		switch {
+		case entry.ExitCode == 0:
+			status = style.Green(fmt.Sprintf("%4d", entry.ExitCode))
+		case entry.ExitCode == audit.ExitCodeNotStarted:
+			status = style.Red(fmt.Sprintf("%4s", "-"))
+		default:
+			status = style.Red(fmt.Sprintf("%4d", entry.ExitCode))
+		}
+
+		items = append(items, ListModalItem{
+			Label: fmt.Sprintf("%s %s  %s",
+				style.Gray(entry.Time.Local().Format("2006-01-02 15:04:05")), status, entry.Command),
+			Description: fmt.Sprintf(tr.AuditEntryDescription,
+				entry.Command,
+				entry.Time.Local().Format(time.RFC1123),
+				entry.User,
+				entry.DBTarget,
+				entry.Dir,
+				entry.ExitCode,
+				time.Duration(entry.DurationMs)*time.Millisecond,
+			),
+			OnSelect: func() error {
+				ac.closeModal()
+				return nil
+			},
+		})
+	}
+
+	modal := NewListModal(ac.g, tr, fmt.Sprintf(tr.ModalTitleAuditLogPath, ac.auditLog.Path()), items,
+		func() { ac.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+
+	ac.openModal(modal)
+}
@@ -3,8 +3,10 @@ package app
 import (
 	"strings"
 
+	"github.com/dokadev/lazyprisma/pkg/gui/style"
 	"github.com/dokadev/lazyprisma/pkg/i18n"
 	"github.com/jesseduffield/gocui"
+	"github.com/rivo/uniseg"
 )
 
 // BaseModal provides common infrastructure shared by all modal types
@@ -115,9 +117,13 @@ func (b *BaseModal) OnClose() {
 	b.g.DeleteView(b.id)
 }
 
-// ColorToGocuiAttr converts a Color to a gocui color attribute value.
+// ColorToGocuiAttr converts a Color to a gocui color attribute value; every
+// colour is the default one when colours are off.
 // Exported so it can be used by any code that needs this conversion.
 func ColorToGocuiAttr(c Color) int {
+	if !style.ColorEnabled() {
+		return int(gocui.ColorDefault)
+	}
 	switch c {
 	case ColorBlack:
 		return int(gocui.ColorBlack)
@@ -142,7 +148,8 @@ func ColorToGocuiAttr(c Color) int {
 
 // WrapText wraps text to fit within the specified width.
 // Each resulting line is prefixed with the given padding string.
-// Handles multiple paragraphs separated by newlines.
+// Handles multiple paragraphs separated by newlines. Widths are measured in
+// terminal columns, so wide (e.g. Korean) characters count as two.
 func WrapText(text string, maxWidth int, padding string) []string {
 	if maxWidth <= 0 {
 		return []string{padding + text}
@@ -157,7 +164,7 @@ func WrapText(text string, maxWidth int, padding string) []string {
 			continue
 		}
 
-		if len(para) <= maxWidth {
+		if uniseg.StringWidth(para) <= maxWidth {
 			lines = append(lines, padding+para)
 		} else {
 			// Word wrapping
@@ -165,7 +172,7 @@ func WrapText(text string, maxWidth int, padding string) []string {
 			currentLine := padding
 
 			for _, word := range words {
-				if len(currentLine)+len(word)+1 <= maxWidth+len(padding) {
+				if uniseg.StringWidth(currentLine)+uniseg.StringWidth(word)+1 <= maxWidth+uniseg.StringWidth(padding) {
 					if currentLine == padding {
 						currentLine += word
 					} else {
@@ -0,0 +1,297 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/dokadev/lazyprisma/pkg/audit"
+	"github.com/dokadev/lazyprisma/pkg/config"
+	"github.com/dokadev/lazyprisma/pkg/demo"
+	"github.com/dokadev/lazyprisma/pkg/gui/context"
+	"github.com/dokadev/lazyprisma/pkg/i18n"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+	"github.com/dokadev/lazyprisma/pkg/stats"
+)
+
+// Bootstrap creates the app for the Prisma project in the current directory
+// with its panels and controllers wired up. demoState (optional) supplies a
+// fake database instead of connecting to the project's. Key and mouse bindings
+// are registered by the caller.
+func Bootstrap(cfg *config.Config, appConfig AppConfig, demoState *demo.State) (*App, error) {
+	// Read the migrations before the TUI takes over the terminal, so a very
+	// large project can show the progress; the Migrations panel then loads
+	// them from the cache
+	if progress := scanProgressPrinter(appConfig.ScanProgress, i18n.NewTranslationSet(appConfig.Language)); progress != nil {
+		if cwd, err := os.Getwd(); err == nil {
+			prisma.ScanLocalMigrations(cwd, progress)
+		}
+	}
+
+	tuiApp, err := NewApp(appConfig)
+	if err != nil {
+		return nil, err
+	}
+	tr := tuiApp.Tr
+	tuiApp.showInvocation = cfg.Output.ShowInvocation || appConfig.DebugMode
+	staleness := context.StaleThresholds{
+		Warn:  time.Duration(cfg.Display.StaleWarnMinutes) * time.Minute,
+		Alert: time.Duration(cfg.Display.StaleAlertMinutes) * time.Minute,
+	}
+
+	// Create and register panels
+	workspace := context.NewWorkspaceContext(context.WorkspaceContextOpts{
+		Gui:          tuiApp.GetGui(),
+		Tr:           tr,
+		ViewName:     ViewWorkspace,
+		RevealURL:    cfg.Display.RevealURL,
+		RevealPeriod: time.Duration(cfg.Display.RevealSeconds) * time.Second,
+		Demo:         demoState,
+		Staleness:    staleness,
+	})
+	tuiApp.prismaMissing.Store(!workspace.HasPrismaCLI())
+	migrationsCtx := context.NewMigrationsContext(context.MigrationsContextOpts{
+		Gui:       tuiApp.GetGui(),
+		Tr:        tr,
+		ViewName:  ViewMigrations,
+		Demo:      demoState,
+		Staleness: staleness,
+	})
+	schemaCtx := context.NewSchemaContext(context.SchemaContextOpts{
+		Gui:      tuiApp.GetGui(),
+		Tr:       tr,
+		ViewName: ViewSchema,
+	})
+	detailsCtx := context.NewDetailsContext(context.DetailsContextOpts{
+		Gui:               tuiApp.GetGui(),
+		Tr:                tr,
+		ViewName:          ViewDetails,
+		SQLHighlight:      context.SQLHighlight(cfg.Display.SQLHighlight),
+		HighlightMaxLines: cfg.Display.HighlightMaxLines,
+		Demo:              demoState,
+		Staleness:         staleness,
+	})
+	outputLogPath, _ := cfg.OutputLogPath()
+	output := context.NewOutputContext(context.OutputContextOpts{
+		Gui:      tuiApp.GetGui(),
+		Tr:       tr,
+		ViewName: ViewOutputs,
+		MaxLines: cfg.Output.MaxLines,
+		LogPath:  outputLogPath,
+	})
+	statusbar := context.NewStatusBarContext(context.StatusBarContextOpts{
+		Gui:      tuiApp.GetGui(),
+		Tr:       tr,
+		ViewName: ViewStatusbar,
+		State:    tuiApp.StatusBarState(),
+		Config: context.StatusBarConfig{
+			Developer: appConfig.Developer,
+			Version:   appConfig.Version,
+		},
+	})
+
+	// Wire callbacks (replaces old bidirectional coupling)
+	migrationsCtx.SetOnSelectionChanged(func(mig *prisma.Migration, tab string) {
+		detailsCtx.UpdateFromMigration(mig, tab)
+	})
+	migrationsCtx.SetModalCallbacks(tuiApp.HasActiveModal, func(viewID string) {
+		tuiApp.HandlePanelClick(viewID)
+	})
+	migrationsCtx.SetOnFocus(detailsCtx.ShowMigration)
+	schemaCtx.SetOnSelectionChanged(func(outline *prisma.SchemaOutline, kind string, entry *prisma.SchemaEntry) {
+		detailsCtx.ShowSchemaEntry(outline, kind, entry)
+	})
+	schemaCtx.SetModalCallbacks(tuiApp.HasActiveModal, func(viewID string) {
+		tuiApp.HandlePanelClick(viewID)
+	})
+	detailsCtx.SetModalCallbacks(tuiApp.HasActiveModal, func(viewID string) {
+		tuiApp.HandlePanelClick(viewID)
+	})
+
+	// Load action-needed data for details context
+	detailsCtx.SetActionNeededMigrations(collectActionNeededMigrations(migrationsCtx.GetCategory()))
+	detailsCtx.SetMigrationTableMissing(migrationsCtx.IsMigrationTableMissing())
+	detailsCtx.SetEdgeDatabase(migrationsCtx.EdgeDatabase())
+	detailsCtx.SetMergePlan(migrationsCtx.MergeFixPlan())
+	detailsCtx.LoadActionNeededData()
+	detailsCtx.LoadSchema()
+
+	tuiApp.RegisterPanel(workspace)
+	tuiApp.RegisterPanel(migrationsCtx)
+	tuiApp.RegisterPanel(schemaCtx)
+	tuiApp.RegisterPanel(detailsCtx)
+	tuiApp.RegisterPanel(output)
+	tuiApp.RegisterPanel(statusbar)
+
+	// Create and wire controllers
+	gui := tuiApp.GetGui()
+
+	migrationsController := NewMigrationsController(
+		tuiApp, gui, migrationsCtx, output, detailsCtx,
+		tuiApp.OpenModal, tuiApp.CloseModal,
+		tuiApp.RunStreamingCommand,
+		time.Duration(cfg.Deploy.CountdownSeconds)*time.Second,
+	)
+	generateController := NewGenerateController(
+		tuiApp, gui, output, detailsCtx,
+		tuiApp.OpenModal, tuiApp.CloseModal,
+		tuiApp.RunStreamingCommand,
+		tuiApp.HandlePanelClick,
+	)
+	studioController := NewStudioController(
+		tuiApp, gui, output,
+		tuiApp.OpenModal, tuiApp.CloseModal,
+		cfg.Studio.Port, cfg.Scan,
+	)
+	clipboardController := NewClipboardController(
+		tuiApp, gui, migrationsCtx,
+		tuiApp.OpenModal, tuiApp.CloseModal,
+	)
+	impactController := NewImpactController(
+		tuiApp, gui, migrationsCtx,
+		tuiApp.OpenModal, tuiApp.CloseModal,
+		tuiApp.HandlePanelClick,
+	)
+	detailsController := NewDetailsController(
+		tuiApp, gui, detailsCtx, migrationsCtx, output,
+		tuiApp.OpenModal, tuiApp.CloseModal,
+		tuiApp.HandlePanelClick,
+	)
+	branchController := NewBranchController(
+		tuiApp, gui, migrationsCtx,
+		tuiApp.OpenModal, tuiApp.CloseModal,
+		tuiApp.HandlePanelClick,
+	)
+	envController := NewEnvironmentController(
+		tuiApp, gui, migrationsCtx, output,
+		tuiApp.OpenModal, tuiApp.CloseModal,
+		tuiApp.RunStreamingCommand,
+	)
+
+	// Audit trail of executed commands
+	var auditLog *audit.Log
+	if cfg.Audit.Enabled {
+		if path, err := cfg.AuditPath(); err == nil {
+			auditLog = audit.NewLog(path, cfg.Audit.Format)
+			tuiApp.SetAuditLog(auditLog)
+		}
+	}
+	auditController := NewAuditController(
+		tuiApp, gui, auditLog,
+		tuiApp.OpenModal, tuiApp.CloseModal,
+	)
+
+	historyController := NewHistoryController(
+		tuiApp, gui, &tuiApp.history, clipboardController,
+		tuiApp.OpenModal, tuiApp.CloseModal,
+		tuiApp.RunStreamingCommand,
+	)
+
+	// Local usage statistics
+	var usageStats *stats.Store
+	if cfg.Stats.Enabled {
+		if path, err := config.StatsPath(); err == nil {
+			usageStats = stats.NewStore(path)
+			tuiApp.SetUsageStats(usageStats)
+		}
+	}
+	statsController := NewStatsController(
+		tuiApp, gui, usageStats,
+		tuiApp.OpenModal, tuiApp.CloseModal,
+	)
+
+	doctorController := NewDoctorController(
+		tuiApp, gui, output,
+		tuiApp.OpenModal, tuiApp.CloseModal,
+	)
+
+	scriptsController := NewScriptsController(
+		tuiApp, gui, output,
+		tuiApp.OpenModal, tuiApp.CloseModal,
+		tuiApp.RunStreamingCommand,
+	)
+
+	reviewController := NewReviewController(
+		tuiApp, gui, migrationsCtx,
+		tuiApp.OpenModal, tuiApp.CloseModal,
+	)
+
+	supabaseController := NewSupabaseController(
+		tuiApp, gui, output, clipboardController,
+		tuiApp.OpenModal, tuiApp.CloseModal,
+	)
+
+	workspaceController := NewWorkspaceController(
+		tuiApp, gui, workspace, output, clipboardController, envController, supabaseController,
+		tuiApp.OpenModal, tuiApp.CloseModal,
+		tuiApp.toggleURLMask,
+		cfg.Open,
+	)
+
+	peekController := NewPeekController(
+		tuiApp, gui, migrationsCtx, detailsCtx, output,
+		tuiApp.OpenModal, tuiApp.CloseModal,
+		tuiApp.HandlePanelClick,
+	)
+
+	consoleController := NewConsoleController(
+		tuiApp, gui, migrationsCtx, detailsCtx, output,
+		tuiApp.OpenModal, tuiApp.CloseModal,
+		tuiApp.HandlePanelClick,
+	)
+
+	tuiApp.SetControllers(migrationsController, generateController, studioController, clipboardController, impactController, detailsController, branchController, envController, auditController, historyController, statsController, doctorController, scriptsController, reviewController, workspaceController, peekController, consoleController)
+
+	if cfg.Watch.Enabled {
+		tuiApp.startFileWatcher(time.Duration(cfg.Watch.DebounceMillis) * time.Millisecond)
+	}
+
+	return tuiApp, nil
+}
+
+// collectActionNeededMigrations extracts migrations that need action (Empty or Checksum Mismatch)
+// from the Local category.
+func collectActionNeededMigrations(cat prisma.MigrationCategory) []prisma.Migration {
+	var result []prisma.Migration
+	for _, mig := range cat.Local {
+		if mig.IsEmpty || mig.ChecksumMismatch {
+			result = append(result, mig)
+		}
+	}
+	return result
+}
+
+// scanProgressDelay is how long the first load of the migrations runs before
+// its progress is shown, so that only very large projects show it
+const scanProgressDelay = 300 * time.Millisecond
+
+// scanProgressInterval limits how often the progress is rewritten
+const scanProgressInterval = 50 * time.Millisecond
+
+// scanProgressPrinter returns a ScanProgress callback that keeps a
+// "Loading migrations... 1200/5000" line up to date on w, and clears it once
+// every migration is read. Returns nil if w is nil.
+func scanProgressPrinter(w io.Writer, tr *i18n.TranslationSet) func(done, total int) {
+	if w == nil {
+		return nil
+	}
+
+	start := time.Now()
+	var shownAt time.Time
+	return func(done, total int) {
+		if done == total {
+			if !shownAt.IsZero() {
+				fmt.Fprint(w, "\r\033[K")
+			}
+			return
+		}
+
+		now := time.Now()
+		if now.Sub(start) < scanProgressDelay || now.Sub(shownAt) < scanProgressInterval {
+			return
+		}
+		shownAt = now
+		fmt.Fprintf(w, "\r"+tr.StartupLoadingMigrations, done, total)
+	}
+}
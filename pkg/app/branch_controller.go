@@ -0,0 +1,165 @@
+package app
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dokadev/lazyprisma/pkg/git"
+	"github.com/dokadev/lazyprisma/pkg/gui/context"
+	"github.com/dokadev/lazyprisma/pkg/gui/style"
+	"github.com/dokadev/lazyprisma/pkg/gui/types"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+	"github.com/jesseduffield/gocui"
+)
+
+// BranchController compares the local migrations with those on another git branch.
+type BranchController struct {
+	c             types.IControllerHost
+	g             *gocui.Gui
+	migrationsCtx *context.MigrationsContext
+	openModal     func(Modal)
+	closeModal    func()
+	focusPanel    func(viewID string)
+}
+
+// NewBranchController creates a new BranchController.
+func NewBranchController(
+	c types.IControllerHost,
+	g *gocui.Gui,
+	migrationsCtx *context.MigrationsContext,
+	openModal func(Modal),
+	closeModal func(),
+	focusPanel func(viewID string),
+) *BranchController {
+	return &BranchController{
+		c:             c,
+		g:             g,
+		migrationsCtx: migrationsCtx,
+		openModal:     openModal,
+		closeModal:    closeModal,
+		focusPanel:    focusPanel,
+	}
+}
+
+// CompareBranches asks for a branch and reports which migrations exist only on each side
+func (bc *BranchController) CompareBranches() {
+	tr := bc.c.GetTranslationSet()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		modal := NewMessageModal(bc.g, tr, tr.ModalTitleError,
+			tr.ErrorFailedGetWorkingDirectory,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		bc.openModal(modal)
+		return
+	}
+
+	branches, err := git.ListBranches(cwd)
+	if err != nil {
+		modal := NewMessageModal(bc.g, tr, tr.ModalTitleCompareBranches,
+			tr.ModalMsgFailedListBranches,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		bc.openModal(modal)
+		return
+	}
+
+	current := git.CurrentBranch(cwd)
+
+	var items []ListModalItem
+	for _, branch := range branches {
+		if branch == current {
+			continue
+		}
+		items = append(items, ListModalItem{
+			Label:       branch,
+			Description: fmt.Sprintf(tr.CompareBranchesItemDescription, current, branch),
+			OnSelect: func() error {
+				bc.closeModal()
+				bc.compare(cwd, current, branch)
+				return nil
+			},
+		})
+	}
+
+	if len(items) == 0 {
+		modal := NewMessageModal(bc.g, tr, tr.ModalTitleCompareBranches,
+			tr.ModalMsgNoOtherBranches,
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		bc.openModal(modal)
+		return
+	}
+
+	modal := NewListModal(bc.g, tr, tr.ModalTitleCompareBranches, items,
+		func() { bc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+
+	bc.openModal(modal)
+}
+
+// compare runs the comparison against branch and shows the result
+func (bc *BranchController) compare(cwd, current, branch string) {
+	tr := bc.c.GetTranslationSet()
+
+	result, err := prisma.CompareBranchMigrations(cwd, branch, bc.migrationsCtx.GetCategory().Local)
+	if err != nil {
+		modal := NewMessageModal(bc.g, tr, tr.ModalTitleCompareBranches,
+			fmt.Sprintf(tr.ModalMsgFailedReadBranchMigrations, branch),
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		bc.openModal(modal)
+		return
+	}
+
+	if result.InSync() {
+		modal := NewMessageModal(bc.g, tr, tr.ModalTitleCompareBranches,
+			fmt.Sprintf(tr.ModalMsgBranchesInSync, current, branch, result.Common),
+		).WithStyle(MessageModalStyle{TitleColor: ColorGreen, BorderColor: ColorGreen})
+		bc.openModal(modal)
+		return
+	}
+
+	outOfOrder := make(map[string]bool, len(result.OutOfOrder))
+	for _, name := range result.OutOfOrder {
+		outOfOrder[name] = true
+	}
+
+	var items []ListModalItem
+	for _, name := range result.OnlyLocal {
+		items = append(items, ListModalItem{
+			Label:       style.Green("◀ ") + name,
+			Description: fmt.Sprintf(tr.CompareBranchesOnlyOn, current),
+			OnSelect: func() error {
+				bc.closeModal()
+				if bc.migrationsCtx.SelectMigrationByName(name) && bc.focusPanel != nil {
+					bc.focusPanel(bc.migrationsCtx.ID())
+				}
+				return nil
+			},
+		})
+	}
+	for _, name := range result.OnlyBranch {
+		label := style.Cyan("▶ ") + name
+		desc := fmt.Sprintf(tr.CompareBranchesOnlyOn, branch)
+		if outOfOrder[name] {
+			label += "  " + style.Yellow("("+tr.CompareBranchesOutOfOrderTag+")")
+			desc += "\n\n" + tr.CompareBranchesOutOfOrderWarning
+		}
+		items = append(items, ListModalItem{
+			Label:       label,
+			Description: desc,
+			OnSelect: func() error {
+				bc.closeModal()
+				return nil
+			},
+		})
+	}
+
+	title := fmt.Sprintf(tr.ModalTitleBranchComparison, current, len(result.OnlyLocal), branch, len(result.OnlyBranch))
+	modal := NewListModal(bc.g, tr, title, items,
+		func() { bc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+
+	bc.openModal(modal)
+}
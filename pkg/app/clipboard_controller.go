@@ -2,6 +2,7 @@ package app
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/dokadev/lazyprisma/pkg/gui/context"
 	"github.com/dokadev/lazyprisma/pkg/gui/types"
@@ -87,6 +88,53 @@ func (cc *ClipboardController) CopyMigrationInfo() {
 	cc.openModal(modal)
 }
 
+// CopyPanelContent copies the text of the given panel to the clipboard
+func (cc *ClipboardController) CopyPanelContent(ctx types.ICopyableContext) {
+	tr := cc.c.GetTranslationSet()
+
+	text := ctx.CopyText()
+	if strings.TrimSpace(text) == "" {
+		modal := NewMessageModal(cc.g, tr, tr.ModalTitleCopyToClipboard,
+			tr.ModalMsgPanelEmpty,
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		cc.openModal(modal)
+		return
+	}
+
+	cc.copyTextToClipboard(text, fmt.Sprintf(tr.CopyLabelPanel, ctx.Title()))
+}
+
+// CopyDatabaseURL copies the Workspace panel's database URL, with its password
+// masked if masked is set
+func (cc *ClipboardController) CopyDatabaseURL(ctx *context.WorkspaceContext, masked bool) {
+	tr := cc.c.GetTranslationSet()
+
+	url := ctx.DatabaseURL(masked)
+	if url == "" {
+		modal := NewMessageModal(cc.g, tr, tr.ModalTitleClipboardError,
+			tr.ModalMsgNoDatabaseURL,
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		cc.openModal(modal)
+		return
+	}
+
+	label := tr.CopyLabelDatabaseURL
+	if masked {
+		label = tr.CopyLabelMaskedURL
+	}
+	cc.copyTextToClipboard(url, label)
+}
+
+// CopyCommandLine copies a command line, e.g. from the command history
+func (cc *ClipboardController) CopyCommandLine(commandLine string) {
+	cc.copyTextToClipboard(commandLine, cc.c.GetTranslationSet().CopyLabelCommandLine)
+}
+
+// CopyLink copies a URL that isn't the database's, e.g. the local Supabase Studio
+func (cc *ClipboardController) CopyLink(link string) {
+	cc.copyTextToClipboard(link, cc.c.GetTranslationSet().CopyLabelLink)
+}
+
 func (cc *ClipboardController) copyTextToClipboard(text, label string) {
 	tr := cc.c.GetTranslationSet()
 
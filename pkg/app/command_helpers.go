@@ -1,20 +1,39 @@
 package app
 
 import (
+	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/dokadev/lazyprisma/pkg/audit"
 	"github.com/dokadev/lazyprisma/pkg/commands"
+	"github.com/dokadev/lazyprisma/pkg/database"
 	"github.com/dokadev/lazyprisma/pkg/gui/context"
+	"github.com/dokadev/lazyprisma/pkg/gui/style"
+	"github.com/dokadev/lazyprisma/pkg/i18n"
+	"github.com/dokadev/lazyprisma/pkg/node"
+	"github.com/dokadev/lazyprisma/pkg/notify"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
 	"github.com/jesseduffield/gocui"
 )
 
 // AsyncCommandOpts configures a streaming async command.
 type AsyncCommandOpts struct {
 	Name         string   // for tryStartCommand / logCommandBlocked
-	Args         []string // full command args: ["npx", "prisma", "migrate", "deploy"]
 	LogAction    string   // log action label (e.g., "Migrate Deploy")
 	LogDetail    string   // log detail text (e.g., "Running prisma migrate deploy...")
 	SkipTryStart bool     // true if tryStartCommand was already called by the caller
+	Env          []string // extra environment variables ("KEY=value"), e.g. a DATABASE_URL override
+	Command      []string // full command line, used instead of PrismaArgs for commands other than prisma (e.g. node)
+	PrismaArgs   []string // prisma arguments run through the app's Runner, e.g. prisma.MigrateDeployArgs()
+
+	// OnOutput receives every stdout/stderr line as it arrives, from the command's goroutines
+	OnOutput func(line string)
 
 	// Callbacks — each callback is responsible for calling finishCommand() at the appropriate time.
 	// The helper never calls finishCommand() itself.
@@ -28,6 +47,83 @@ type AsyncCommandOpts struct {
 	ErrorStartMsg string
 }
 
+// outputCapture collects a streaming command's output for parsing or saving afterwards
+type outputCapture struct {
+	mu   sync.Mutex
+	text strings.Builder
+}
+
+// Add appends a line; pass it as AsyncCommandOpts.OnOutput
+func (oc *outputCapture) Add(line string) {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	oc.text.WriteString(line)
+	oc.text.WriteString("\n")
+}
+
+// String returns the output captured so far
+func (oc *outputCapture) String() string {
+	oc.mu.Lock()
+	defer oc.mu.Unlock()
+	return oc.text.String()
+}
+
+// orderedUpdates runs UI updates in the order they were requested. g.Update
+// queues each from its own goroutine, so a command's output could otherwise
+// be drawn out of order or after its completion.
+type orderedUpdates struct {
+	g       *gocui.Gui
+	mu      sync.Mutex
+	pending []func(g *gocui.Gui) error
+}
+
+func newOrderedUpdates(g *gocui.Gui) *orderedUpdates {
+	return &orderedUpdates{g: g}
+}
+
+// Update queues fn; every queued update runs, in order, on the next UI tick
+func (u *orderedUpdates) Update(fn func(g *gocui.Gui) error) {
+	u.mu.Lock()
+	u.pending = append(u.pending, fn)
+	u.mu.Unlock()
+
+	u.g.Update(func(g *gocui.Gui) error {
+		u.mu.Lock()
+		pending := u.pending
+		u.pending = nil
+		u.mu.Unlock()
+
+		for _, fn := range pending {
+			if err := fn(g); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// deploySummary returns the webhook summary of a migrate deploy run. environment
+// names the environment deployed to ("" for the project's database), env holds
+// the command's extra environment variables and output its captured output.
+func deploySummary(environment string, env []string, output string, exitCode int, duration time.Duration) notify.Summary {
+	cwd, _ := os.Getwd()
+	deploy := prisma.ParseMigrateDeployOutput(output, exitCode).(*prisma.MigrateDeployOutput)
+
+	return notify.Summary{
+		Event:       notify.EventDeploy,
+		Project:     filepath.Base(cwd),
+		Environment: environment,
+		Target:      auditDBTarget(cwd, env),
+		Success:     exitCode == 0,
+		ExitCode:    exitCode,
+		Migrations:  deploy.Applied,
+		Failed:      deploy.Failed,
+		Duration:    duration,
+		User:        audit.CurrentUser(),
+		Time:        time.Now(),
+	}
+}
+
 // RunStreamingCommand handles the common boilerplate for streaming prisma commands.
 // Returns false if the command could not be started (another command running or panel missing).
 // The helper does NOT call FinishCommand() -- each callback is responsible for calling it.
@@ -40,6 +136,16 @@ func (a *App) RunStreamingCommand(opts AsyncCommandOpts) bool {
 		}
 	}
 
+	// Prisma commands can't run in limited mode; offer to install the CLI instead
+	if opts.Command == nil && a.prismaMissing.Load() {
+		a.FinishCommand()
+		a.g.Update(func(g *gocui.Gui) error {
+			a.ShowPrismaMissing(opts.Name)
+			return nil
+		})
+		return false
+	}
+
 	// Phase 2: Get output panel
 	outputPanel, ok := a.panels[ViewOutputs].(*context.OutputContext)
 	if !ok {
@@ -47,6 +153,28 @@ func (a *App) RunStreamingCommand(opts AsyncCommandOpts) bool {
 		return false
 	}
 
+	// There is nothing to run the command with in demo mode
+	if a.config.DemoMode {
+		a.FinishCommand()
+		commandLine := strings.Join(opts.commandLine(), " ")
+		a.g.Update(func(g *gocui.Gui) error {
+			outputPanel.LogActionRed(a.Tr.LogActionDemoMode, fmt.Sprintf(a.Tr.LogMsgDemoCommandSkipped, commandLine))
+			return nil
+		})
+		return false
+	}
+
+	// Commands would race with the instance that has the project open
+	if a.config.ReadOnly {
+		a.FinishCommand()
+		commandLine := strings.Join(opts.commandLine(), " ")
+		a.g.Update(func(g *gocui.Gui) error {
+			outputPanel.LogActionRed(a.Tr.LogActionReadOnly, fmt.Sprintf(a.Tr.LogMsgReadOnlyCommandSkipped, commandLine))
+			return nil
+		})
+		return false
+	}
+
 	// Phase 3: Get cwd
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -63,39 +191,138 @@ func (a *App) RunStreamingCommand(opts AsyncCommandOpts) bool {
 		return false
 	}
 
-	// Phase 4: Log action start
-	a.g.Update(func(g *gocui.Gui) error {
+	// Phase 4: Log action start, led by the database the command targets and
+	// noting the PRISMA_* overrides a prisma command runs with
+	details := []string{commandTargetBanner(a.Tr, cwd, opts.Env), opts.LogDetail}
+	if opts.Command == nil {
+		if overrides := prisma.EngineOverrides(cwd); len(overrides) > 0 {
+			names := make([]string, len(overrides))
+			for i, o := range overrides {
+				names[i] = o.Name
+			}
+			details = append(details, fmt.Sprintf(a.Tr.LogMsgEngineOverrides, strings.Join(names, ", ")))
+		}
+	}
+	// The command's UI updates are applied in the order they are made, which
+	// g.Update alone doesn't guarantee
+	updates := newOrderedUpdates(a.g)
+
+	// The command's output starts at outputMark; it is folded under a summary
+	// line once the command finishes. Its header starts at commandMark.
+	var commandMark, outputMark int
+	updates.Update(func(g *gocui.Gui) error {
 		if out, ok := a.panels[ViewOutputs].(*context.OutputContext); ok {
-			out.LogAction(opts.LogAction, opts.LogDetail)
+			commandMark = out.MarkOutput()
+			out.LogAction(opts.LogAction, details...)
+			outputMark = out.MarkOutput()
 		}
 		return nil
 	})
 
-	// Phase 5: Build command
-	builder := commands.NewCommandBuilder(commands.NewPlatform())
+	// Phase 5: Build output handlers
+	args := opts.Command // Prisma commands report theirs through OnStart
+	startedAt := time.Now()
 
-	cmd := builder.New(opts.Args...).
-		WithWorkingDir(cwd).
-		StreamOutput().
-		OnStdout(func(line string) {
-			a.g.Update(func(g *gocui.Gui) error {
+	// The runner calls OnError and then OnComplete for a non-zero exit, but only
+	// OnError if the process never started; record each run exactly once.
+	var recorded atomic.Bool
+	record := func(exitCode int) {
+		if !recorded.Swap(true) {
+			a.RecordCommand(args, opts.Env, exitCode, time.Since(startedAt))
+			a.recordHistory(opts, args, exitCode, time.Since(startedAt))
+		}
+	}
+
+	// Passwords and secrets Prisma echoes (e.g. in connection errors) never
+	// reach the output panel or a caller's log file
+	scrubber := outputScrubber(cwd, opts.Env)
+
+	// Set when the output shows a registry or engine download failure, so that
+	// a failed command can be explained as a network problem
+	var networkFailure atomic.Bool
+
+	// The whole output, read by the command's output parser once it finishes
+	var captured outputCapture
+
+	streamOpts := prisma.StreamOpts{
+		Env: opts.Env,
+		OnStart: func(commandLine []string) {
+			args = commandLine
+			if !a.showInvocation {
+				return
+			}
+			var env []string
+			if opts.Command == nil {
+				env = prisma.EngineOverrideEnv(cwd)
+			}
+			env = append(append(env, opts.Env...), commands.ColorEnv()...)
+			lines := invocationLines(a.Tr, commandLine, cwd, env, scrubber)
+			updates.Update(func(g *gocui.Gui) error {
+				if out, ok := a.panels[ViewOutputs].(*context.OutputContext); ok {
+					// Kept above the fold the command's output goes in
+					out.AppendOutput(strings.Join(lines, "\n"))
+					outputMark = out.MarkOutput()
+				}
+				return nil
+			})
+		},
+		OnStdout: func(line string) {
+			line = scrubber.Scrub(line)
+			if node.IsNetworkError(line) {
+				networkFailure.Store(true)
+			}
+			captured.Add(line)
+			if opts.OnOutput != nil {
+				opts.OnOutput(line)
+			}
+			a.trackDeployProgress(line)
+			if !a.trackEngineDownload(line) {
+				return
+			}
+			updates.Update(func(g *gocui.Gui) error {
 				if out, ok := a.panels[ViewOutputs].(*context.OutputContext); ok {
 					out.AppendOutput("  " + line)
 				}
 				return nil
 			})
-		}).
-		OnStderr(func(line string) {
-			a.g.Update(func(g *gocui.Gui) error {
+		},
+		OnStderr: func(line string) {
+			line = scrubber.Scrub(line)
+			if node.IsNetworkError(line) {
+				networkFailure.Store(true)
+			}
+			captured.Add(line)
+			if opts.OnOutput != nil {
+				opts.OnOutput(line)
+			}
+			a.trackDeployProgress(line)
+			if !a.trackEngineDownload(line) {
+				return
+			}
+			updates.Update(func(g *gocui.Gui) error {
 				if out, ok := a.panels[ViewOutputs].(*context.OutputContext); ok {
 					out.AppendOutput("  " + line)
 				}
 				return nil
 			})
-		}).
-		OnComplete(func(exitCode int) {
-			a.g.Update(func(g *gocui.Gui) error {
+		},
+		OnComplete: func(exitCode int) {
+			record(exitCode)
+			// Re-check connectivity here, off the UI thread
+			annotate := exitCode != 0 && networkFailure.Load()
+			offline := annotate && node.CheckNetwork()
+			parsed := prisma.ParseCommandOutput(args, captured.String(), exitCode)
+			took := time.Since(startedAt)
+			updates.Update(func(g *gocui.Gui) error {
 				if out, ok := a.panels[ViewOutputs].(*context.OutputContext); ok {
+					// A failed command's output stays expanded
+					summary := describeCommandOutput(a.Tr, parsed)
+					if summary == "" && exitCode == 0 {
+						summary = a.Tr.SummaryCommandDone
+					} else if summary == "" {
+						summary = fmt.Sprintf(a.Tr.SummaryCommandFailed, exitCode)
+					}
+					out.FoldOutput(outputMark, fmt.Sprintf(a.Tr.LogMsgCommandSummary, summary, formatStatsDuration(took)), exitCode == 0)
 					if exitCode == 0 {
 						if opts.OnSuccess != nil {
 							opts.OnSuccess(out, cwd)
@@ -103,21 +330,29 @@ func (a *App) RunStreamingCommand(opts AsyncCommandOpts) bool {
 							a.FinishCommand()
 						}
 					} else {
+						a.failedOutput = commandMark
 						if opts.OnFailure != nil {
 							opts.OnFailure(out, cwd, exitCode)
 						} else {
 							a.FinishCommand()
 						}
+						if annotate {
+							a.logNetworkFailure(out, offline)
+						}
 					}
 				} else {
 					a.FinishCommand()
 				}
 				return nil
 			})
-		}).
-		OnError(func(err error) {
-			a.g.Update(func(g *gocui.Gui) error {
+		},
+		OnError: func(err error) {
+			if _, isExit := err.(*exec.ExitError); !isExit {
+				record(audit.ExitCodeNotStarted)
+			}
+			updates.Update(func(g *gocui.Gui) error {
 				if out, ok := a.panels[ViewOutputs].(*context.OutputContext); ok {
+					a.failedOutput = commandMark
 					if opts.OnError != nil {
 						opts.OnError(out, cwd, err)
 					} else {
@@ -128,11 +363,16 @@ func (a *App) RunStreamingCommand(opts AsyncCommandOpts) bool {
 				}
 				return nil
 			})
-		})
+		},
+	}
 
-	// Phase 6: RunAsync
-	if err := cmd.RunAsync(); err != nil {
+	// Phase 6: Start
+	if err := a.startStreamCommand(cwd, opts, streamOpts); err != nil {
+		record(audit.ExitCodeNotStarted)
 		a.FinishCommand()
+		if a.ShowMissingTool(opts.Name, err) {
+			return false
+		}
 		errorTitle := opts.ErrorTitle
 		errorMsg := opts.ErrorStartMsg
 		a.g.Update(func(g *gocui.Gui) error {
@@ -149,3 +389,132 @@ func (a *App) RunStreamingCommand(opts AsyncCommandOpts) bool {
 
 	return true
 }
+
+// recordHistory adds a command to the session's command history, with what
+// it takes to run it again
+func (a *App) recordHistory(opts AsyncCommandOpts, args []string, exitCode int, duration time.Duration) {
+	if args == nil {
+		args = opts.commandLine() // Never started
+	}
+	a.history.Add(historyEntry{
+		Time:     time.Now(),
+		Args:     args,
+		Env:      opts.Env,
+		ExitCode: exitCode,
+		Duration: duration,
+		opts: AsyncCommandOpts{
+			Name:       opts.Name,
+			LogAction:  opts.LogAction,
+			LogDetail:  opts.LogDetail,
+			Env:        opts.Env,
+			Command:    opts.Command,
+			PrismaArgs: opts.PrismaArgs,
+		},
+	})
+}
+
+// startStreamCommand starts opts.Command, or opts.PrismaArgs through the
+// app's Runner, with its output going to streamOpts
+func (a *App) startStreamCommand(cwd string, opts AsyncCommandOpts, streamOpts prisma.StreamOpts) error {
+	if opts.Command == nil {
+		return a.prismaRunner.Stream(cwd, opts.PrismaArgs, streamOpts)
+	}
+	if streamOpts.OnStart != nil {
+		streamOpts.OnStart(opts.Command)
+	}
+	if err := node.CheckToolchain(opts.Command); err != nil {
+		return err
+	}
+
+	builder := commands.NewCommandBuilder(commands.NewPlatform())
+	return builder.New(opts.Command...).
+		WithWorkingDir(cwd).
+		WithEnv(streamOpts.Env...).
+		StreamOutput().
+		OnStdout(streamOpts.OnStdout).
+		OnStderr(streamOpts.OnStderr).
+		OnComplete(streamOpts.OnComplete).
+		OnError(streamOpts.OnError).
+		RunAsync()
+}
+
+// invocationLines describes exactly what a command is started as: its
+// command line, working directory and environment overrides, with secrets
+// masked
+func invocationLines(tr *i18n.TranslationSet, commandLine []string, cwd string, env []string, scrubber *prisma.SecretScrubber) []string {
+	lines := []string{
+		"  " + style.Gray(fmt.Sprintf(tr.LogMsgInvocationCommand, scrubber.Scrub(commands.QuoteCommandLine(commandLine)))),
+		"  " + style.Gray(fmt.Sprintf(tr.LogMsgInvocationDir, cwd)),
+	}
+	if len(env) > 0 {
+		masked := make([]string, len(env))
+		for i, kv := range env {
+			masked[i] = scrubber.Scrub(kv)
+		}
+		lines = append(lines, "  "+style.Gray(fmt.Sprintf(tr.LogMsgInvocationEnv, strings.Join(masked, " "))))
+	}
+	return lines
+}
+
+// commandTargetBanner names the provider and masked URL of the database a
+// command started with env connects to, resolved now rather than at startup
+func commandTargetBanner(tr *i18n.TranslationSet, cwd string, env []string) string {
+	ds, err := prisma.GetDatasource(cwd)
+	if err != nil {
+		ds = &prisma.Datasource{}
+		ds.Provider, _ = prisma.GetProvider(cwd)
+		ds.EnvVarName, _ = prisma.GetEnvVarName(cwd)
+	}
+
+	// A later override wins, as it does for the command itself
+	if ds.EnvVarName != "" {
+		for i := len(env) - 1; i >= 0; i-- {
+			if value, ok := strings.CutPrefix(env[i], ds.EnvVarName+"="); ok {
+				ds.URL = value
+				if provider := prisma.ProviderFromURL(value); provider != "" {
+					ds.Provider = provider
+				}
+				break
+			}
+		}
+	}
+
+	if ds.URL == "" {
+		return style.Yellow(tr.LogMsgCommandTargetNone)
+	}
+	return fmt.Sprintf(tr.LogMsgCommandTarget,
+		style.YellowBold(database.GetProviderDisplayName(ds.Provider)),
+		prisma.MaskPassword(ds.URL))
+}
+
+// commandLine returns the command line the command runs, e.g.
+// "prisma migrate deploy"
+func (opts AsyncCommandOpts) commandLine() []string {
+	if opts.Command != nil {
+		return opts.Command
+	}
+	return append([]string{"prisma"}, opts.PrismaArgs...)
+}
+
+// outputScrubber masks the passwords of the project's database URLs, of any
+// connection URL in the environment and the values of secret-looking
+// environment variables in a command's output
+func outputScrubber(cwd string, env []string) *prisma.SecretScrubber {
+	var dbURLs []string
+	if ds, err := prisma.GetDatasource(cwd); err == nil {
+		dbURLs = append(dbURLs, ds.URL)
+	}
+	if shadowURL, _ := prisma.GetShadowDatabaseURL(cwd); shadowURL != "" {
+		dbURLs = append(dbURLs, shadowURL)
+	}
+	return prisma.NewSecretScrubber(append(os.Environ(), env...), dbURLs...)
+}
+
+// logNetworkFailure explains that a failed command could not reach the network
+func (a *App) logNetworkFailure(out *context.OutputContext, offline bool) {
+	hint := a.Tr.LogMsgNetworkFailureOnline
+	if offline {
+		hint = a.Tr.LogMsgNetworkFailureOffline
+	}
+	out.LogActionRed(a.Tr.LogActionNetworkFailure, a.Tr.LogMsgNetworkFailure, hint)
+}
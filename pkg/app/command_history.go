@@ -0,0 +1,74 @@
+package app
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dokadev/lazyprisma/pkg/commands"
+)
+
+// commandHistoryLimit caps the commands kept in the session's history
+const commandHistoryLimit = 200
+
+// historyEntry is a command run this session
+type historyEntry struct {
+	Time     time.Time
+	Args     []string // Command line as run
+	Env      []string // Extra environment variables it ran with ("KEY=value")
+	ExitCode int      // audit.ExitCodeNotStarted if it never started
+	Duration time.Duration
+
+	// What it was started with, to run it again: Name, LogAction, LogDetail,
+	// Env and Command or Prisma
+	opts AsyncCommandOpts
+}
+
+// CommandLine returns the command line as a shell would take it
+func (e historyEntry) CommandLine() string {
+	return commands.QuoteCommandLine(e.Args)
+}
+
+// EnvNames returns the names of the extra environment variables, leaving out
+// their values as they are often secrets
+func (e historyEntry) EnvNames() []string {
+	names := make([]string, 0, len(e.Env))
+	for _, kv := range e.Env {
+		name, _, _ := strings.Cut(kv, "=")
+		names = append(names, name)
+	}
+	return names
+}
+
+// commandHistory holds the commands run this session, for the history modal
+type commandHistory struct {
+	mu      sync.Mutex
+	entries []historyEntry // Oldest first
+}
+
+// Add records a command, dropping the oldest beyond commandHistoryLimit
+func (h *commandHistory) Add(entry historyEntry) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.entries = append(h.entries, entry)
+	if len(h.entries) > commandHistoryLimit {
+		h.entries = append([]historyEntry(nil), h.entries[len(h.entries)-commandHistoryLimit:]...)
+	}
+}
+
+// Entries returns the commands whose command line contains filter (ignoring
+// case; "" = all), newest first
+func (h *commandHistory) Entries(filter string) []historyEntry {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	filter = strings.ToLower(filter)
+	var entries []historyEntry
+	for i := len(h.entries) - 1; i >= 0; i-- {
+		if strings.Contains(strings.ToLower(h.entries[i].CommandLine()), filter) {
+			entries = append(entries, h.entries[i])
+		}
+	}
+	return entries
+}
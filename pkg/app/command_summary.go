@@ -0,0 +1,72 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dokadev/lazyprisma/pkg/i18n"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+)
+
+// summaryNameLimit is how many migration or generator names a summary lists
+const summaryNameLimit = 3
+
+// describeCommandOutput returns a one-line summary of what a command's parsed
+// output says it did, e.g. "2 migrations applied (20240101_init, 20240102_add_orders)".
+// Returns "" for output without a parser.
+func describeCommandOutput(tr *i18n.TranslationSet, parsed prisma.CommandOutput) string {
+	switch out := parsed.(type) {
+	case *prisma.MigrateStatusOutput:
+		found := out.Found
+		if found < 0 {
+			found = len(out.Pending) + len(out.Failed)
+		}
+		if out.UpToDate {
+			return fmt.Sprintf(tr.SummaryStatusUpToDate, found)
+		}
+		return fmt.Sprintf(tr.SummaryStatus, found, len(out.Pending), len(out.Failed)) +
+			summaryNames(append(append([]string{}, out.Failed...), out.Pending...))
+
+	case *prisma.MigrateDeployOutput:
+		switch {
+		case out.Failed != "":
+			return fmt.Sprintf(tr.SummaryDeployFailed, len(out.Applied), out.Failed)
+		case out.NoPending && len(out.Applied) == 0:
+			return tr.SummaryDeployNoPending
+		default:
+			return fmt.Sprintf(tr.SummaryDeployApplied, len(out.Applied)) + summaryNames(out.Applied)
+		}
+
+	case *prisma.GenerateOutput:
+		if len(out.Generated) == 0 {
+			return ""
+		}
+		names := make([]string, len(out.Generated))
+		for i, g := range out.Generated {
+			names[i] = g.Name
+		}
+		if d := out.Duration(); d > 0 {
+			return fmt.Sprintf(tr.SummaryGenerateIn, len(out.Generated), d.Round(time.Millisecond)) + summaryNames(names)
+		}
+		return fmt.Sprintf(tr.SummaryGenerate, len(out.Generated)) + summaryNames(names)
+
+	case *prisma.ValidateOutput:
+		if out.Valid {
+			return tr.SummaryValidateValid
+		}
+		return fmt.Sprintf(tr.SummaryValidateErrors, len(out.Errors))
+	}
+	return ""
+}
+
+// summaryNames lists the first few names in parentheses, e.g. " (a, b, c, …)"
+func summaryNames(names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	if len(names) > summaryNameLimit {
+		names = append(names[:summaryNameLimit:summaryNameLimit], "…")
+	}
+	return " (" + strings.Join(names, ", ") + ")"
+}
@@ -0,0 +1,428 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dokadev/lazyprisma/pkg/config"
+	"github.com/dokadev/lazyprisma/pkg/database"
+	"github.com/dokadev/lazyprisma/pkg/gui/context"
+	"github.com/dokadev/lazyprisma/pkg/gui/style"
+	"github.com/dokadev/lazyprisma/pkg/gui/types"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+	"github.com/jesseduffield/gocui"
+)
+
+const (
+	// consoleRowLimit is how many rows of a result the Query tab shows
+	consoleRowLimit = 100
+	// consoleHistorySize is how many statements the console offers to run again
+	consoleHistorySize = 10
+)
+
+// ConsoleController runs ad-hoc SQL against the project's database and shows
+// the rows, or the plan the database chooses, in the Details panel's Query tab.
+// Writes run in a transaction that stays open, so their effects can be checked
+// with further statements, and is rolled back unless it is committed.
+type ConsoleController struct {
+	c             types.IControllerHost
+	g             *gocui.Gui
+	migrationsCtx *context.MigrationsContext
+	detailsCtx    *context.DetailsContext
+	outputCtx     *context.OutputContext
+	openModal     func(Modal)
+	closeModal    func()
+	focusPanel    func(viewID string)
+
+	explain bool     // Show the plan instead of running the statement
+	analyze bool     // Run the statement to measure the plan (EXPLAIN ANALYZE)
+	history []string // Statements run this session, newest first
+
+	tx      database.Session // Transaction holding uncommitted writes (nil = none)
+	pending []string         // Writes run in tx, oldest first
+}
+
+// NewConsoleController creates a new ConsoleController.
+func NewConsoleController(
+	c types.IControllerHost,
+	g *gocui.Gui,
+	migrationsCtx *context.MigrationsContext,
+	detailsCtx *context.DetailsContext,
+	outputCtx *context.OutputContext,
+	openModal func(Modal),
+	closeModal func(),
+	focusPanel func(viewID string),
+) *ConsoleController {
+	return &ConsoleController{
+		c:             c,
+		g:             g,
+		migrationsCtx: migrationsCtx,
+		detailsCtx:    detailsCtx,
+		outputCtx:     outputCtx,
+		openModal:     openModal,
+		closeModal:    closeModal,
+		focusPanel:    focusPanel,
+	}
+}
+
+// OpenConsole opens the SQL console menu: enter a statement, run a recent one
+// again, toggle EXPLAIN / EXPLAIN ANALYZE, or commit or roll back the open
+// transaction
+func (cc *ConsoleController) OpenConsole() {
+	tr := cc.c.GetTranslationSet()
+
+	var modal *ListModal
+	var buildItems func() []ListModalItem
+	buildItems = func() []ListModalItem {
+		var items []ListModalItem
+		if cc.tx != nil {
+			statements := strings.Join(cc.pending, "\n")
+			items = append(items,
+				ListModalItem{
+					Label:       fmt.Sprintf(tr.ListItemCommitTransaction, len(cc.pending)),
+					Description: tr.ListItemDescCommitTransaction + "\n\n" + statements,
+					OnSelect: func() error {
+						cc.closeModal()
+						cc.confirmCommit()
+						return nil
+					},
+				},
+				ListModalItem{
+					Label:       tr.ListItemRollbackTransaction,
+					Description: tr.ListItemDescRollbackTransaction + "\n\n" + statements,
+					OnSelect: func() error {
+						cc.closeModal()
+						cc.endTransaction(false)
+						return nil
+					},
+				},
+			)
+		}
+
+		items = append(items, ListModalItem{
+			Label:       tr.ListItemRunStatement,
+			Description: tr.ListItemDescRunStatement,
+			OnSelect: func() error {
+				cc.closeModal()
+				cc.showStatementInput()
+				return nil
+			},
+		})
+
+		for _, query := range cc.history {
+			items = append(items, ListModalItem{
+				Label:       statementSummary(query),
+				Description: fmt.Sprintf(tr.ListItemDescConsoleHistory, query),
+				OnSelect: func() error {
+					cc.closeModal()
+					cc.run(query)
+					return nil
+				},
+			})
+		}
+
+		toggle := func(label, desc string, enabled bool, onToggle func()) ListModalItem {
+			check := style.Gray("[ ]")
+			if enabled {
+				check = style.Green("[x]")
+			}
+			return ListModalItem{
+				Label:       check + " " + label,
+				Description: desc + "\n\n" + tr.ConsoleToggleHint,
+				OnSelect: func() error {
+					onToggle()
+					modal.SetItems(buildItems())
+					return nil
+				},
+			}
+		}
+		return append(items,
+			toggle(tr.ListItemExplain, tr.ListItemDescExplain, cc.explain, func() {
+				cc.explain = !cc.explain
+				if !cc.explain {
+					cc.analyze = false
+				}
+			}),
+			toggle(tr.ListItemAnalyze, tr.ListItemDescAnalyze, cc.analyze, func() {
+				cc.analyze = !cc.analyze
+				if cc.analyze {
+					cc.explain = true
+				}
+			}),
+		)
+	}
+
+	modal = NewListModal(cc.g, tr, tr.ModalTitleSQLConsole, buildItems(),
+		func() {
+			cc.closeModal()
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+	cc.openModal(modal)
+}
+
+// showStatementInput asks for the statement to run
+func (cc *ConsoleController) showStatementInput() {
+	tr := cc.c.GetTranslationSet()
+
+	modal := NewInputModal(cc.g, tr, tr.ModalTitleSQLStatement,
+		func(query string) {
+			cc.closeModal()
+			cc.run(query)
+		},
+		func() {
+			cc.closeModal()
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan}).
+		WithSubtitle(cc.explainLabel()).
+		WithRequired(true).
+		OnValidationFail(func(reason string) {
+			cc.closeModal()
+			errorModal := NewMessageModal(cc.g, tr, tr.ModalTitleValidationFailed,
+				reason,
+			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+			cc.openModal(errorModal)
+		})
+
+	cc.openModal(modal)
+}
+
+// explainLabel names the toggled EXPLAIN mode ("" when off)
+func (cc *ConsoleController) explainLabel() string {
+	switch {
+	case cc.analyze:
+		return "EXPLAIN ANALYZE"
+	case cc.explain:
+		return "EXPLAIN"
+	}
+	return ""
+}
+
+// run runs query, or explains it if EXPLAIN is toggled on, and shows the
+// outcome in the Query tab. A write opens a transaction that later statements
+// join until it is committed or rolled back.
+func (cc *ConsoleController) run(query string) {
+	tr := cc.c.GetTranslationSet()
+
+	// Reads and writes are told apart by a statement's first keyword, so a
+	// write after a read would slip past both checks below
+	if len(prisma.SplitStatements(query)) > 1 {
+		modal := NewMessageModal(cc.g, tr, tr.ModalTitleSQLConsole,
+			tr.ModalMsgOneStatement,
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		cc.openModal(modal)
+		return
+	}
+
+	// Only the instance holding the project's lock writes to its database.
+	// Without a transaction to join, anything else is rolled back.
+	if !cc.explain && database.IsWrite(query) &&
+		(cc.c.RejectInReadOnly(tr.ModalMsgReadOnlyConsoleWrite) || cc.c.RejectDisabledAction(config.ActionConsoleWrite)) {
+		return
+	}
+
+	name := "Run Query"
+	if cc.explain {
+		name = "Explain Query"
+	}
+	if !cc.c.TryStartCommand(name) {
+		cc.c.LogCommandBlocked(name)
+		return
+	}
+
+	cc.remember(query)
+	explain, analyze := cc.explain, cc.analyze
+	if explain {
+		cc.outputCtx.LogAction(tr.LogActionSQLConsole, fmt.Sprintf(tr.LogMsgExplainingQuery, cc.explainLabel(), statementSummary(query)))
+	} else {
+		cc.outputCtx.LogAction(tr.LogActionSQLConsole, fmt.Sprintf(tr.LogMsgRunningQuery, statementSummary(query)))
+	}
+
+	session := cc.tx
+	go func() {
+		opened := session == nil
+		var err error
+		if opened {
+			session, err = cc.migrationsCtx.OpenSession()
+		}
+
+		var plan *database.QueryPlan
+		var result *database.QueryResult
+		if err == nil {
+			if explain {
+				plan, err = session.Explain(query, analyze)
+			} else {
+				result, err = session.Run(query, consoleRowLimit)
+			}
+		}
+
+		// A transaction opened for a read or a failed statement has nothing to keep
+		write := err == nil && !explain && database.IsWrite(query)
+		if opened && session != nil && !write {
+			session.Rollback()
+		}
+
+		cc.c.OnUIThread(func() error {
+			cc.c.FinishCommand()
+
+			if write {
+				cc.tx = session
+				cc.pending = append(cc.pending, query)
+			}
+
+			if err != nil {
+				msg := tr.ModalMsgQueryFailed
+				switch {
+				case explain:
+					msg = tr.ModalMsgExplainFailed
+				case errors.Is(err, database.ErrImplicitCommit):
+					msg = tr.ModalMsgImplicitCommit
+				}
+				cc.showError(msg, err)
+				return nil
+			}
+
+			if explain {
+				cc.outputCtx.LogAction(tr.LogActionSQLConsole, fmt.Sprintf(tr.LogMsgQueryPlanned, len(plan.Indexes()), len(plan.FullScans())))
+				cc.detailsCtx.ShowQueryPlan(query, plan, len(cc.pending))
+			} else {
+				switch {
+				case write:
+					cc.outputCtx.LogActionRed(tr.LogActionSQLConsole, fmt.Sprintf(tr.LogMsgWriteUncommitted, result.RowsAffected, len(cc.pending)))
+				case result.Columns == nil:
+					cc.outputCtx.LogAction(tr.LogActionSQLConsole, fmt.Sprintf(tr.QueryRowsAffected, result.RowsAffected))
+				default:
+					cc.outputCtx.LogAction(tr.LogActionSQLConsole, fmt.Sprintf(tr.QueryRowCount, len(result.Rows)))
+				}
+				cc.detailsCtx.ShowQueryResult(query, result, len(cc.pending))
+			}
+			cc.focusDetails()
+			return nil
+		})
+	}()
+}
+
+// confirmCommit lists the writes of the open transaction before committing it
+func (cc *ConsoleController) confirmCommit() {
+	tr := cc.c.GetTranslationSet()
+
+	if cc.c.RejectDisabledAction(config.ActionConsoleWrite) {
+		return
+	}
+
+	modal := NewConfirmModal(cc.g, tr, tr.ModalTitleCommitTransaction,
+		strings.Join(cc.pending, "\n")+"\n\n"+fmt.Sprintf(tr.ModalMsgCommitTransaction, len(cc.pending)),
+		func() {
+			cc.closeModal()
+			cc.endTransaction(true)
+		},
+		func() {
+			cc.closeModal()
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+
+	cc.openModal(modal)
+}
+
+// endTransaction commits or rolls back the open transaction
+func (cc *ConsoleController) endTransaction(commit bool) {
+	tr := cc.c.GetTranslationSet()
+
+	name := "Rollback"
+	if commit {
+		name = "Commit"
+	}
+	if !cc.c.TryStartCommand(name) {
+		cc.c.LogCommandBlocked(name)
+		return
+	}
+
+	session, count := cc.tx, len(cc.pending)
+	cc.tx, cc.pending = nil, nil
+
+	go func() {
+		var err error
+		if commit {
+			err = session.Commit()
+		} else {
+			err = session.Rollback()
+		}
+
+		cc.c.OnUIThread(func() error {
+			cc.c.FinishCommand()
+			cc.detailsCtx.SetQueryUncommitted(0)
+
+			if err != nil {
+				cc.outputCtx.LogActionRed(tr.LogActionSQLConsole, err.Error())
+				modal := NewMessageModal(cc.g, tr, tr.ModalTitleSQLConsole,
+					tr.ModalMsgTransactionFailed,
+					err.Error(),
+				).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+				cc.openModal(modal)
+				return nil
+			}
+
+			if commit {
+				cc.outputCtx.LogAction(tr.LogActionSQLConsole, fmt.Sprintf(tr.LogMsgTransactionCommitted, count))
+			} else {
+				cc.outputCtx.LogAction(tr.LogActionSQLConsole, fmt.Sprintf(tr.LogMsgTransactionRolledBack, count))
+			}
+			return nil
+		})
+	}()
+}
+
+// UncommittedWrites returns how many statements the open transaction holds
+// (0 = no transaction)
+func (cc *ConsoleController) UncommittedWrites() int {
+	return len(cc.pending)
+}
+
+// Cleanup rolls back the open transaction when the app exits
+func (cc *ConsoleController) Cleanup() {
+	if cc.tx != nil {
+		cc.tx.Rollback()
+		cc.tx, cc.pending = nil, nil
+	}
+}
+
+// remember puts query first in the history
+func (cc *ConsoleController) remember(query string) {
+	history := []string{query}
+	for _, previous := range cc.history {
+		if previous != query && len(history) < consoleHistorySize {
+			history = append(history, previous)
+		}
+	}
+	cc.history = history
+}
+
+// showError logs a failed statement and shows the database's error; writes
+// of the open transaction made before it are kept
+func (cc *ConsoleController) showError(msg string, err error) {
+	tr := cc.c.GetTranslationSet()
+	cc.outputCtx.LogActionRed(tr.LogActionSQLConsole, err.Error())
+	lines := []string{msg, err.Error()}
+	if cc.tx != nil {
+		lines = append(lines, "", fmt.Sprintf(tr.ModalMsgQueryFailedTransactionKept, len(cc.pending)))
+	}
+	modal := NewMessageModal(cc.g, tr, tr.ModalTitleSQLConsole, lines...).
+		WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+	cc.openModal(modal)
+}
+
+// focusDetails moves the focus to the Details panel showing the Query tab
+func (cc *ConsoleController) focusDetails() {
+	if cc.focusPanel != nil {
+		cc.focusPanel(cc.detailsCtx.ID())
+	}
+}
+
+// statementSummary shortens a statement to one line for lists and the log
+func statementSummary(query string) string {
+	summary := strings.Join(strings.Fields(query), " ")
+	if len([]rune(summary)) > 60 {
+		summary = string([]rune(summary)[:59]) + "…"
+	}
+	return summary
+}
@@ -0,0 +1,143 @@
+package app
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dokadev/lazyprisma/pkg/i18n"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazycore/pkg/boxlayout"
+)
+
+// countdownTickInterval is how often the remaining time is redrawn
+const countdownTickInterval = 250 * time.Millisecond
+
+// CountdownModal counts down to a deadline and then runs an action. Closing it
+// (ESC or q) before the deadline aborts the action.
+type CountdownModal struct {
+	*BaseModal
+	title    string
+	deadline time.Time
+	describe func(remaining time.Duration) []string // Content lines for the time left
+	onExpire func()                                 // Runs on the UI thread at the deadline
+	onAbort  func()                                 // Runs on the UI thread if closed before it
+	fired    bool
+	closed   bool
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	width    int
+	height   int
+}
+
+// NewCountdownModal creates a countdown modal and starts counting down to deadline
+func NewCountdownModal(g *gocui.Gui, tr *i18n.TranslationSet, title string, deadline time.Time, describe func(remaining time.Duration) []string, onExpire func()) *CountdownModal {
+	m := &CountdownModal{
+		BaseModal: NewBaseModal("modal", g, tr),
+		title:     title,
+		deadline:  deadline,
+		describe:  describe,
+		onExpire:  onExpire,
+		stopCh:    make(chan struct{}),
+	}
+	m.start()
+	return m
+}
+
+// WithStyle sets the modal style
+func (m *CountdownModal) WithStyle(style MessageModalStyle) *CountdownModal {
+	m.SetStyle(style)
+	return m
+}
+
+// WithOnAbort sets the callback run when the modal is closed before the deadline
+func (m *CountdownModal) WithOnAbort(onAbort func()) *CountdownModal {
+	m.onAbort = onAbort
+	return m
+}
+
+// start redraws the modal until the deadline, then fires onExpire
+func (m *CountdownModal) start() {
+	go func() {
+		ticker := time.NewTicker(countdownTickInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				m.g.Update(func(g *gocui.Gui) error {
+					if m.closed || m.fired || time.Now().Before(m.deadline) {
+						// Redrawn by the layout manager
+						return nil
+					}
+					m.fired = true
+					m.stop()
+					m.onExpire()
+					return nil
+				})
+			case <-m.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+func (m *CountdownModal) stop() {
+	m.stopOnce.Do(func() { close(m.stopCh) })
+}
+
+// Draw renders the modal
+func (m *CountdownModal) Draw(dim boxlayout.Dimensions) error {
+	m.width = m.CalculateDimensions(4.0/7.0, 70)
+
+	var lines []string
+	for _, line := range m.describe(countdownRemaining(m.deadline)) {
+		if line == "" {
+			lines = append(lines, "")
+			continue
+		}
+		lines = append(lines, WrapText(line, m.width-4, "  ")...)
+	}
+	m.height = len(lines) + 1
+
+	x0, y0, x1, y1 := m.CenterBox(m.width, m.height)
+
+	v, _, err := m.SetupView(m.ID(), x0, y0, x1, y1, 0, " "+m.title+" ", m.tr.ModalFooterCountdownAbort)
+	if err != nil {
+		return err
+	}
+
+	v.Clear()
+	v.Wrap = false
+	for _, line := range lines {
+		fmt.Fprintln(v, line)
+	}
+
+	return nil
+}
+
+// HandleKey handles keyboard input; ESC and q are handled by App, which closes the modal
+func (m *CountdownModal) HandleKey(key any, mod gocui.Modifier) error {
+	return nil
+}
+
+// OnClose stops the countdown; closing before the deadline aborts it
+func (m *CountdownModal) OnClose() {
+	m.closed = true
+	m.stop()
+	m.BaseModal.OnClose()
+
+	if !m.fired && m.onAbort != nil {
+		m.onAbort()
+	}
+}
+
+// countdownRemaining returns the time left until deadline, rounded up to whole
+// seconds so that the countdown shows 1s rather than 0s in its last second
+func countdownRemaining(deadline time.Time) time.Duration {
+	remaining := time.Until(deadline)
+	if remaining <= 0 {
+		return 0
+	}
+	return (remaining + time.Second - 1).Truncate(time.Second)
+}
@@ -0,0 +1,951 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/dokadev/lazyprisma/pkg/config"
+	"github.com/dokadev/lazyprisma/pkg/diff"
+	"github.com/dokadev/lazyprisma/pkg/drift"
+	"github.com/dokadev/lazyprisma/pkg/git"
+	"github.com/dokadev/lazyprisma/pkg/gui/context"
+	"github.com/dokadev/lazyprisma/pkg/gui/style"
+	"github.com/dokadev/lazyprisma/pkg/gui/types"
+	"github.com/dokadev/lazyprisma/pkg/i18n"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+	"github.com/jesseduffield/gocui"
+)
+
+// DetailsController handles display options of the details panel, navigation
+// in its Schema tab and the schema history shown in it.
+type DetailsController struct {
+	c             types.IControllerHost
+	g             *gocui.Gui
+	detailsCtx    *context.DetailsContext
+	migrationsCtx *context.MigrationsContext
+	outputCtx     *context.OutputContext
+	openModal     func(Modal)
+	closeModal    func()
+	focusPanel    func(viewID string)
+}
+
+// NewDetailsController creates a new DetailsController.
+func NewDetailsController(
+	c types.IControllerHost,
+	g *gocui.Gui,
+	detailsCtx *context.DetailsContext,
+	migrationsCtx *context.MigrationsContext,
+	outputCtx *context.OutputContext,
+	openModal func(Modal),
+	closeModal func(),
+	focusPanel func(viewID string),
+) *DetailsController {
+	return &DetailsController{
+		c:             c,
+		g:             g,
+		detailsCtx:    detailsCtx,
+		migrationsCtx: migrationsCtx,
+		outputCtx:     outputCtx,
+		openModal:     openModal,
+		closeModal:    closeModal,
+		focusPanel:    focusPanel,
+	}
+}
+
+// ToggleSQLFormat switches the details panel between raw and pretty-printed SQL
+func (dc *DetailsController) ToggleSQLFormat() {
+	dc.detailsCtx.ToggleSQLFormat()
+}
+
+// CycleSQLHighlight switches the details panel between full, keyword-only and no SQL highlighting
+func (dc *DetailsController) CycleSQLHighlight() {
+	tr := dc.c.GetTranslationSet()
+
+	label := tr.SQLHighlightChroma
+	switch dc.detailsCtx.CycleSQLHighlight() {
+	case context.SQLHighlightKeywords:
+		label = tr.SQLHighlightKeywords
+	case context.SQLHighlightOff:
+		label = tr.SQLHighlightOff
+	}
+	dc.outputCtx.LogAction(tr.LogActionSQLHighlight, label)
+}
+
+// SaveFormattedSQL rewrites the selected pending migration's migration.sql with the formatted SQL
+func (dc *DetailsController) SaveFormattedSQL() {
+	tr := dc.c.GetTranslationSet()
+
+	selected := dc.migrationsCtx.GetSelectedMigration()
+	if selected == nil {
+		modal := NewMessageModal(dc.g, tr, tr.ModalTitleNoMigrationSelected,
+			tr.ModalMsgSelectMigrationFormat,
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		dc.openModal(modal)
+		return
+	}
+
+	if selected.Path == "" || selected.IsEmpty {
+		modal := NewMessageModal(dc.g, tr, tr.ModalTitleFormatSQL,
+			tr.ModalMsgCannotFormatNoSQL,
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		dc.openModal(modal)
+		return
+	}
+
+	// Rewriting an applied migration would change its checksum
+	if selected.AppliedAt != nil {
+		modal := NewMessageModal(dc.g, tr, tr.ModalTitleFormatSQL,
+			tr.ModalMsgMigrationAlreadyApplied,
+			tr.ModalMsgCannotFormatApplied,
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		dc.openModal(modal)
+		return
+	}
+
+	sqlPath := filepath.Join(selected.Path, "migration.sql")
+	content, err := os.ReadFile(sqlPath)
+	if err != nil {
+		modal := NewMessageModal(dc.g, tr, tr.ModalTitleFormatSQL,
+			tr.ModalMsgFailedReadMigrationFile,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		dc.openModal(modal)
+		return
+	}
+
+	cwd, _ := os.Getwd()
+	opts := prisma.ProjectFormatSQLOpts(cwd)
+	formatted := prisma.FormatSQL(string(content), opts)
+	if !prisma.FormatSQLPreservesLiterals(string(content), formatted, opts) {
+		modal := NewMessageModal(dc.g, tr, tr.ModalTitleFormatSQL,
+			tr.ModalMsgFormatChangesLiterals,
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		dc.openModal(modal)
+		return
+	}
+	if formatted == string(content) {
+		modal := NewMessageModal(dc.g, tr, tr.ModalTitleFormatSQL,
+			tr.ModalMsgSQLAlreadyFormatted,
+		).WithStyle(MessageModalStyle{TitleColor: ColorGreen, BorderColor: ColorGreen})
+		dc.openModal(modal)
+		return
+	}
+
+	relPath := sqlPath
+	if rel, err := filepath.Rel(cwd, sqlPath); err == nil {
+		relPath = rel
+	}
+
+	modal := NewConfirmModal(dc.g, tr, tr.ModalTitleFormatSQL,
+		fmt.Sprintf(tr.ModalMsgConfirmSaveFormatted, relPath),
+		func() {
+			dc.closeModal()
+			dc.writeFormattedSQL(sqlPath, relPath, formatted)
+		},
+		func() {
+			dc.closeModal()
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+	dc.openModal(modal)
+}
+
+// writeFormattedSQL writes the formatted SQL to disk and refreshes
+func (dc *DetailsController) writeFormattedSQL(sqlPath, relPath, formatted string) {
+	tr := dc.c.GetTranslationSet()
+
+	if err := os.WriteFile(sqlPath, []byte(formatted), 0644); err != nil {
+		dc.outputCtx.LogAction(tr.LogActionFormatSQL, tr.ModalMsgFailedWriteMigrationFile+" "+err.Error())
+		modal := NewMessageModal(dc.g, tr, tr.ModalTitleFormatSQL,
+			tr.ModalMsgFailedWriteMigrationFile,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		dc.openModal(modal)
+		return
+	}
+
+	dc.outputCtx.LogAction(tr.LogActionFormatSQL, fmt.Sprintf(tr.ModalMsgFormattedSQLSaved, relPath))
+	dc.c.RequestRefresh(types.RefreshMigrations)
+
+	modal := NewMessageModal(dc.g, tr, tr.ModalTitleFormatSQL,
+		fmt.Sprintf(tr.ModalMsgFormattedSQLSaved, relPath),
+	).WithStyle(MessageModalStyle{TitleColor: ColorGreen, BorderColor: ColorGreen})
+	dc.openModal(modal)
+}
+
+// ShowSchemaHistory lists the commits that changed schema.prisma; a revision can be
+// viewed or diffed against the current schema in the Schema History tab. The history
+// is read from git when opened and kept until HEAD moves.
+func (dc *DetailsController) ShowSchemaHistory() {
+	if !dc.c.TryStartCommand("Schema History") {
+		dc.c.LogCommandBlocked("Schema History")
+		return
+	}
+
+	cachedHead := dc.detailsCtx.SchemaHistoryHead()
+
+	go func() {
+		cwd, _ := os.Getwd()
+		head := git.HeadHash(cwd)
+		reload := head == "" || head != cachedHead
+		var history []prisma.SchemaRevision
+		if reload {
+			history = dc.detailsCtx.LoadSchemaHistory()
+		}
+
+		dc.c.OnUIThread(func() error {
+			dc.c.FinishCommand()
+			if reload {
+				dc.detailsCtx.SetSchemaHistory(history, head)
+			}
+			dc.showSchemaHistoryList()
+			return nil
+		})
+	}()
+}
+
+// showSchemaHistoryList shows the loaded schema history
+func (dc *DetailsController) showSchemaHistoryList() {
+	tr := dc.c.GetTranslationSet()
+
+	history := dc.detailsCtx.GetSchemaHistory()
+	if len(history) == 0 {
+		modal := NewMessageModal(dc.g, tr, tr.ModalTitleSchemaHistory,
+			tr.ModalMsgNoSchemaHistory,
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		dc.openModal(modal)
+		return
+	}
+
+	items := []ListModalItem{
+		{
+			Label:       tr.SchemaHistoryShowTimeline,
+			Description: tr.SchemaHistoryTitle,
+			OnSelect: func() error {
+				dc.closeModal()
+				dc.detailsCtx.ShowSchemaHistoryTimeline()
+				dc.focusDetails()
+				return nil
+			},
+		},
+	}
+
+	for _, rev := range history {
+		var desc strings.Builder
+		desc.WriteString(fmt.Sprintf("%s\n%s, %s\n", rev.Subject, rev.Author, rev.Date.Format("2006-01-02 15:04")))
+		if len(rev.ModelsAdded) > 0 {
+			desc.WriteString("\n" + style.Green("+ "+strings.Join(rev.ModelsAdded, ", ")))
+		}
+		if len(rev.ModelsRemoved) > 0 {
+			desc.WriteString("\n" + style.Red("- "+strings.Join(rev.ModelsRemoved, ", ")))
+		}
+
+		items = append(items, ListModalItem{
+			Label:       fmt.Sprintf("%s  %s  %s", rev.ShortHash(), rev.Date.Format("2006-01-02"), rev.Subject),
+			Description: strings.TrimRight(desc.String(), "\n"),
+			OnSelect: func() error {
+				dc.closeModal()
+				dc.showRevisionActions(rev)
+				return nil
+			},
+		})
+	}
+
+	modal := NewListModal(dc.g, tr, tr.ModalTitleSchemaHistory, items,
+		func() { dc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+
+	dc.openModal(modal)
+}
+
+// showRevisionActions offers viewing or diffing a schema revision
+func (dc *DetailsController) showRevisionActions(rev prisma.SchemaRevision) {
+	tr := dc.c.GetTranslationSet()
+
+	items := []ListModalItem{
+		{
+			Label:       tr.SchemaHistoryActionView,
+			Description: rev.Subject,
+			OnSelect: func() error {
+				dc.closeModal()
+				dc.viewSchemaAt(rev)
+				return nil
+			},
+		},
+		{
+			Label:       tr.SchemaHistoryActionDiff,
+			Description: rev.Subject,
+			OnSelect: func() error {
+				dc.closeModal()
+				dc.diffSchemaAgainstCurrent(rev)
+				return nil
+			},
+		},
+	}
+
+	modal := NewListModal(dc.g, tr, fmt.Sprintf(tr.ModalTitleSchemaRevision, rev.ShortHash()), items,
+		func() { dc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+
+	dc.openModal(modal)
+}
+
+// viewSchemaAt shows schema.prisma as of a revision in the Schema History tab
+func (dc *DetailsController) viewSchemaAt(rev prisma.SchemaRevision) {
+	tr := dc.c.GetTranslationSet()
+
+	content, ok := dc.readSchemaAt(rev)
+	if !ok {
+		return
+	}
+
+	header := style.YellowBold(fmt.Sprintf(tr.SchemaHistoryViewTitle,
+		rev.ShortHash(), rev.Author, rev.Date.Format("2006-01-02")))
+	dc.detailsCtx.ShowSchemaHistoryView(header + "\n\n" + context.NumberLines(content))
+	dc.focusDetails()
+}
+
+// diffSchemaAgainstCurrent shows a unified diff from a revision to the working tree schema.prisma
+func (dc *DetailsController) diffSchemaAgainstCurrent(rev prisma.SchemaRevision) {
+	tr := dc.c.GetTranslationSet()
+
+	old, ok := dc.readSchemaAt(rev)
+	if !ok {
+		return
+	}
+
+	cwd, _ := os.Getwd()
+	current, err := prisma.ReadSchema(cwd)
+	if err != nil {
+		modal := NewMessageModal(dc.g, tr, tr.ModalTitleSchemaHistory,
+			tr.ModalMsgFailedReadSchema,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		dc.openModal(modal)
+		return
+	}
+
+	header := style.YellowBold(fmt.Sprintf(tr.SchemaHistoryDiffTitle, rev.ShortHash()))
+	unified := diff.Unified(rev.ShortHash()+"/schema.prisma", "schema.prisma", old, current, 3)
+	body := style.Gray(tr.SchemaHistoryNoChanges)
+	if unified != "" {
+		body = context.ColorizeDiff(unified)
+	}
+	if warning := dc.enumChangeWarning(cwd, old, current); warning != "" {
+		body = warning + "\n\n" + body
+	}
+
+	dc.detailsCtx.ShowSchemaHistoryView(header + "\n\n" + body)
+	dc.focusDetails()
+}
+
+// enumChangeWarning explains the provider's caveats for the enum values removed
+// or added between two schemas and lists the columns using each changed enum.
+// Returns "" if no existing enum changed.
+func (dc *DetailsController) enumChangeWarning(cwd, oldSchema, currentSchema string) string {
+	tr := dc.c.GetTranslationSet()
+
+	changes := prisma.DiffEnums(oldSchema, currentSchema)
+	if len(changes) == 0 {
+		return ""
+	}
+
+	provider, _ := prisma.GetProvider(cwd)
+	caveat := tr.EnumChangeCaveatOther
+	switch provider {
+	case "postgresql", "cockroachdb":
+		caveat = tr.EnumChangeCaveatPostgres
+	case "mysql":
+		caveat = tr.EnumChangeCaveatMySQL
+	}
+
+	var b strings.Builder
+	b.WriteString(style.YellowBold("⚠ "+tr.EnumChangeWarningTitle) + "\n")
+	b.WriteString(caveat + "\n")
+
+	for _, change := range changes {
+		b.WriteString("\n" + style.Bold(change.Name))
+		if change.Dropped {
+			b.WriteString("  " + style.Red(tr.EnumChangeDropped))
+		}
+		b.WriteString("\n")
+		if len(change.Added) > 0 {
+			b.WriteString("  " + style.Green("+ "+strings.Join(change.Added, ", ")) + "\n")
+		}
+		if len(change.Removed) > 0 {
+			b.WriteString("  " + style.Red("- "+strings.Join(change.Removed, ", ")) + "\n")
+		}
+
+		// A dropped enum is only referenced by the old schema
+		schema := currentSchema
+		if change.Dropped {
+			schema = oldSchema
+		}
+		usages := prisma.FindEnumUsages(schema, change.Name)
+		if len(usages) == 0 {
+			b.WriteString("  " + style.Gray(tr.EnumChangeNoColumns) + "\n")
+		}
+		for _, usage := range usages {
+			b.WriteString(fmt.Sprintf("  %s %s.%s %s\n",
+				style.Gray(tr.EnumChangeUsedBy), usage.Table, usage.Column,
+				style.Gray(fmt.Sprintf("(%s.%s)", usage.Model, usage.Field))))
+		}
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// readSchemaAt reads schema.prisma at a revision, showing an error modal on failure
+func (dc *DetailsController) readSchemaAt(rev prisma.SchemaRevision) (string, bool) {
+	tr := dc.c.GetTranslationSet()
+
+	cwd, _ := os.Getwd()
+	content, err := prisma.ShowSchemaAt(cwd, rev.Hash)
+	if err != nil {
+		modal := NewMessageModal(dc.g, tr, tr.ModalTitleSchemaHistory,
+			tr.ModalMsgFailedReadSchemaRevision,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		dc.openModal(modal)
+		return "", false
+	}
+	return content, true
+}
+
+// PreviewMigrationSQL shows the SQL the next `migrate dev` would generate from
+// the uncommitted schema changes, without creating a migration
+func (dc *DetailsController) PreviewMigrationSQL() {
+	tr := dc.c.GetTranslationSet()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		modal := NewMessageModal(dc.g, tr, tr.ModalTitleError,
+			tr.ErrorFailedGetWorkingDirectory,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		dc.openModal(modal)
+		return
+	}
+
+	if dc.c.RejectInDemoMode(tr.ModalTitlePreviewMigration) {
+		return
+	}
+
+	if !dc.c.TryStartCommand("Preview Migration") {
+		dc.c.LogCommandBlocked("Preview Migration")
+		return
+	}
+
+	dc.outputCtx.LogAction(tr.LogActionPreviewMigration, tr.LogMsgPreviewingMigration)
+
+	go func() {
+		sql, err := dc.c.PrismaRunner().Diff(cwd)
+
+		dc.c.OnUIThread(func() error {
+			dc.c.FinishCommand()
+
+			if errors.Is(err, prisma.ErrShadowDatabaseRequired) {
+				dc.outputCtx.LogActionRed(tr.LogActionPreviewMigration, err.Error())
+				modal := NewMessageModal(dc.g, tr, tr.ModalTitlePreviewMigration,
+					tr.ModalMsgPreviewNeedsShadowDatabase,
+				).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+				dc.openModal(modal)
+				return nil
+			}
+			if err != nil {
+				dc.outputCtx.LogActionRed(tr.LogActionPreviewMigration, err.Error())
+				modal := NewMessageModal(dc.g, tr, tr.ModalTitlePreviewMigration,
+					tr.ModalMsgPreviewMigrationFailed,
+					err.Error(),
+				).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+				dc.openModal(modal)
+				return nil
+			}
+
+			dc.outputCtx.LogAction(tr.LogActionPreviewMigration, tr.LogMsgMigrationPreviewReady)
+			dc.detailsCtx.ShowMigrationPreview(sql)
+			dc.focusDetails()
+			return nil
+		})
+	}()
+}
+
+// Introspect reads the database's schema with `prisma db pull --print` and
+// shows what it would change in schema.prisma in the Introspection tab,
+// without writing it. Once shown, it offers to apply or discard it instead.
+func (dc *DetailsController) Introspect() {
+	if dc.detailsCtx.GetIntrospectedSchema() != "" {
+		dc.showIntrospectionActions()
+		return
+	}
+	dc.introspect()
+}
+
+// introspect runs db pull --print and shows the result
+func (dc *DetailsController) introspect() {
+	tr := dc.c.GetTranslationSet()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		modal := NewMessageModal(dc.g, tr, tr.ModalTitleError,
+			tr.ErrorFailedGetWorkingDirectory,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		dc.openModal(modal)
+		return
+	}
+
+	if dc.c.RejectInDemoMode(tr.ModalTitleIntrospect) {
+		return
+	}
+
+	if dc.rejectMultiFileSchema(cwd) {
+		return
+	}
+
+	if !dc.c.TryStartCommand("Introspect") {
+		dc.c.LogCommandBlocked("Introspect")
+		return
+	}
+
+	dc.outputCtx.LogAction(tr.LogActionIntrospect, tr.LogMsgIntrospecting)
+
+	go func() {
+		schema, err := dc.c.PrismaRunner().Introspect(cwd)
+
+		dc.c.OnUIThread(func() error {
+			dc.c.FinishCommand()
+
+			if err != nil {
+				dc.outputCtx.LogActionRed(tr.LogActionIntrospect, err.Error())
+				modal := NewMessageModal(dc.g, tr, tr.ModalTitleIntrospect,
+					tr.ModalMsgIntrospectFailed,
+					err.Error(),
+				).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+				dc.openModal(modal)
+				return nil
+			}
+
+			// A missing schema.prisma shows the whole introspected schema as added
+			current, _ := os.ReadFile(prisma.SchemaPath(cwd))
+
+			dc.outputCtx.LogAction(tr.LogActionIntrospect, tr.LogMsgIntrospectionReady)
+			dc.detailsCtx.ShowIntrospection(schema, string(current))
+			dc.focusDetails()
+			return nil
+		})
+	}()
+}
+
+// showIntrospectionActions offers applying the introspected schema to
+// schema.prisma, discarding it or introspecting again
+func (dc *DetailsController) showIntrospectionActions() {
+	tr := dc.c.GetTranslationSet()
+	cwd, _ := os.Getwd()
+
+	var items []ListModalItem
+
+	if !dc.c.IsActionDisabled(config.ActionDBPull) {
+		items = append(items, ListModalItem{
+			Label:       tr.ListItemApplyIntrospected,
+			Description: fmt.Sprintf(tr.ListItemDescApplyIntrospected, relativePath(cwd, prisma.SchemaPath(cwd))),
+			OnSelect: func() error {
+				dc.closeModal()
+				dc.confirmApplyIntrospection(cwd)
+				return nil
+			},
+		})
+	}
+
+	items = append(items,
+		ListModalItem{
+			Label:       tr.ListItemDiscardIntrospected,
+			Description: tr.ListItemDescDiscardIntrospected,
+			OnSelect: func() error {
+				dc.closeModal()
+				dc.detailsCtx.ClearIntrospection()
+				dc.outputCtx.LogAction(tr.LogActionIntrospect, tr.LogMsgIntrospectionDiscarded)
+				return nil
+			},
+		},
+		ListModalItem{
+			Label:       tr.ListItemIntrospectAgain,
+			Description: tr.ListItemDescIntrospectAgain,
+			OnSelect: func() error {
+				dc.closeModal()
+				dc.introspect()
+				return nil
+			},
+		},
+	)
+
+	modal := NewListModal(dc.g, tr, tr.ModalTitleIntrospection, items,
+		func() { dc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+
+	dc.openModal(modal)
+}
+
+// rejectMultiFileSchema shows why introspection is not offered for a schema
+// split across several .prisma files, and returns true if it is: db pull
+// writes a single file, which would duplicate the models of the others
+func (dc *DetailsController) rejectMultiFileSchema(cwd string) bool {
+	if !prisma.IsMultiFileSchema(cwd) {
+		return false
+	}
+
+	tr := dc.c.GetTranslationSet()
+	modal := NewMessageModal(dc.g, tr, tr.ModalTitleIntrospect,
+		fmt.Sprintf(tr.ModalMsgIntrospectMultiFile, relativePath(cwd, prisma.SchemaPath(cwd))),
+	).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+	dc.openModal(modal)
+	return true
+}
+
+// confirmApplyIntrospection asks before overwriting the schema, naming the
+// file and where its current content is kept
+func (dc *DetailsController) confirmApplyIntrospection(cwd string) {
+	tr := dc.c.GetTranslationSet()
+	schemaPath := prisma.SchemaPath(cwd)
+
+	modal := NewConfirmModal(dc.g, tr, tr.ModalTitleIntrospection,
+		fmt.Sprintf(tr.ModalMsgConfirmApplyIntrospect, relativePath(cwd, schemaPath), relativePath(cwd, schemaPath+".bak")),
+		func() {
+			dc.closeModal()
+			dc.applyIntrospection(cwd)
+		},
+		func() {
+			dc.closeModal()
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+	dc.openModal(modal)
+}
+
+// applyIntrospection saves the current schema next to it as a .bak file,
+// writes the introspected schema in its place and reloads the panels showing it
+func (dc *DetailsController) applyIntrospection(cwd string) {
+	tr := dc.c.GetTranslationSet()
+	schemaPath := prisma.SchemaPath(cwd)
+	backupPath := schemaPath + ".bak"
+
+	// The schema may have been split into several files since it was introspected
+	if dc.rejectMultiFileSchema(cwd) {
+		return
+	}
+
+	current, err := os.ReadFile(schemaPath)
+	backedUp := err == nil
+	if err == nil {
+		err = os.WriteFile(backupPath, current, 0o644)
+	} else if os.IsNotExist(err) {
+		err = nil // Nothing to keep
+	}
+	if err != nil {
+		dc.outputCtx.LogActionRed(tr.LogActionIntrospect, err.Error())
+		modal := NewMessageModal(dc.g, tr, tr.ModalTitleIntrospect,
+			tr.ModalMsgFailedBackupSchema,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		dc.openModal(modal)
+		return
+	}
+
+	if err := os.WriteFile(schemaPath, []byte(dc.detailsCtx.GetIntrospectedSchema()), 0o644); err != nil {
+		dc.outputCtx.LogActionRed(tr.LogActionIntrospect, err.Error())
+		modal := NewMessageModal(dc.g, tr, tr.ModalTitleIntrospect,
+			tr.ModalMsgFailedWriteSchema,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		dc.openModal(modal)
+		return
+	}
+
+	dc.detailsCtx.ClearIntrospection()
+	if backedUp {
+		dc.outputCtx.LogAction(tr.LogActionIntrospect, fmt.Sprintf(tr.LogMsgIntrospectionApplied, relativePath(cwd, schemaPath), relativePath(cwd, backupPath)))
+	} else {
+		dc.outputCtx.LogAction(tr.LogActionIntrospect, fmt.Sprintf(tr.LogMsgIntrospectionCreated, relativePath(cwd, schemaPath)))
+	}
+	dc.c.RequestRefresh(types.RefreshSchema | types.RefreshMigrations)
+}
+
+// JumpToSchemaDefinition follows the relation (or enum / composite type) field
+// under the Schema tab's cursor to its declaration
+func (dc *DetailsController) JumpToSchemaDefinition() {
+	dc.detailsCtx.JumpToSchemaDefinition()
+}
+
+// SchemaBack returns to where the last jump in the Schema tab started.
+// Returns false if there was nothing to go back to.
+func (dc *DetailsController) SchemaBack() bool {
+	return dc.detailsCtx.SchemaBack()
+}
+
+// ShowGoToLine asks for a line number and scrolls the Details panel's current
+// tab to it, e.g. to look up a line referenced in an error message
+func (dc *DetailsController) ShowGoToLine() {
+	tr := dc.c.GetTranslationSet()
+
+	modal := NewInputModal(dc.g, tr, tr.ModalTitleGoToLine,
+		func(input string) {
+			dc.closeModal()
+			input = strings.TrimPrefix(strings.TrimSpace(input), ":")
+			line, err := strconv.Atoi(input)
+			if err != nil || line < 1 {
+				errorModal := NewMessageModal(dc.g, tr, tr.ModalTitleGoToLine,
+					fmt.Sprintf(tr.ModalMsgInvalidLineNumber, input),
+				).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+				dc.openModal(errorModal)
+				return
+			}
+			if !dc.detailsCtx.GoToLine(line) {
+				notFound := NewMessageModal(dc.g, tr, tr.ModalTitleGoToLine,
+					fmt.Sprintf(tr.ModalMsgLineNotFound, line),
+				).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+				dc.openModal(notFound)
+				return
+			}
+			dc.focusDetails()
+		},
+		func() {
+			dc.closeModal()
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan}).
+		WithSubtitle(tr.ModalMsgGoToLineHint).
+		WithRequired(true).
+		OnValidationFail(func(reason string) {
+			dc.closeModal()
+			errorModal := NewMessageModal(dc.g, tr, tr.ModalTitleValidationFailed,
+				reason,
+			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+			dc.openModal(errorModal)
+		})
+
+	dc.openModal(modal)
+}
+
+// ShowModelUsage searches every local migration's SQL for the table of the
+// model under the Schema tab's cursor and lists the matching lines per migration.
+// Selecting a migration jumps to it in the migrations panel.
+func (dc *DetailsController) ShowModelUsage() {
+	tr := dc.c.GetTranslationSet()
+
+	block, table, ok := dc.detailsCtx.SelectedSchemaBlock()
+	if !ok || block.Kind == "type" {
+		modal := NewMessageModal(dc.g, tr, tr.ModalTitleModelUsage,
+			tr.ModalMsgModelUsageNoModel,
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		dc.openModal(modal)
+		return
+	}
+
+	usages := prisma.SearchTableUsage(dc.migrationsCtx.GetCategory().Local, table)
+	if len(usages) == 0 {
+		modal := NewMessageModal(dc.g, tr, tr.ModalTitleModelUsage,
+			fmt.Sprintf(tr.ModalMsgModelUsageNotFound, table),
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		dc.openModal(modal)
+		return
+	}
+
+	var items []ListModalItem
+	for _, usage := range usages {
+		var desc strings.Builder
+		for _, match := range usage.Matches {
+			desc.WriteString(fmt.Sprintf("%s %s\n", style.Gray(fmt.Sprintf("%4d │", match.Line)), match.Snippet))
+		}
+
+		items = append(items, ListModalItem{
+			Label:       fmt.Sprintf("%s  %s", usage.Migration, style.Gray(fmt.Sprintf(tr.ModelUsageMatchCount, len(usage.Matches)))),
+			Description: strings.TrimRight(desc.String(), "\n"),
+			OnSelect: func() error {
+				dc.closeModal()
+				if dc.migrationsCtx.SelectMigrationByName(usage.Migration) && dc.focusPanel != nil {
+					dc.focusPanel(dc.migrationsCtx.ID())
+				}
+				return nil
+			},
+		})
+	}
+
+	title := fmt.Sprintf(tr.ModalTitleModelUsageResults, table, len(usages))
+	modal := NewListModal(dc.g, tr, title, items,
+		func() { dc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+
+	dc.openModal(modal)
+}
+
+// ShowModelKeys compares the indexes and foreign keys the model under the
+// Schema tab's cursor declares with those its table has in the database, and
+// lists them with where they diverge
+func (dc *DetailsController) ShowModelKeys() {
+	tr := dc.c.GetTranslationSet()
+
+	block, table, ok := dc.detailsCtx.SelectedSchemaBlock()
+	if !ok || block.Kind != "model" {
+		modal := NewMessageModal(dc.g, tr, tr.ModalTitleModelKeys,
+			tr.ModalMsgModelKeysNoModel,
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		dc.openModal(modal)
+		return
+	}
+
+	if !dc.c.TryStartCommand("Check Keys") {
+		dc.c.LogCommandBlocked("Check Keys")
+		return
+	}
+
+	dc.outputCtx.LogAction(tr.LogActionModelKeys, fmt.Sprintf(tr.LogMsgComparingKeys, table))
+	lines := dc.detailsCtx.SchemaLines()
+
+	go func() {
+		comparison, err := dc.detailsCtx.CompareModelKeys(lines, block)
+
+		dc.c.OnUIThread(func() error {
+			dc.c.FinishCommand()
+
+			if err != nil {
+				dc.outputCtx.LogActionRed(tr.LogActionModelKeys, err.Error())
+				modal := NewMessageModal(dc.g, tr, tr.ModalTitleModelKeys,
+					fmt.Sprintf(tr.ModalMsgModelKeysFailed, table),
+					err.Error(),
+				).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+				dc.openModal(modal)
+				return nil
+			}
+
+			diverged := comparison.Diverged()
+			if diverged > 0 {
+				dc.outputCtx.LogActionRed(tr.LogActionModelKeys, fmt.Sprintf(tr.LogMsgKeysDiverge, table, diverged))
+			} else {
+				dc.outputCtx.LogAction(tr.LogActionModelKeys, fmt.Sprintf(tr.LogMsgKeysInSync, table))
+			}
+			dc.showModelKeys(comparison)
+			return nil
+		})
+	}()
+}
+
+// showModelKeys lists the indexes, then the foreign keys of a comparison;
+// each item's description says how the schema and the database differ
+func (dc *DetailsController) showModelKeys(comparison *drift.Comparison) {
+	tr := dc.c.GetTranslationSet()
+
+	var items []ListModalItem
+	for _, d := range comparison.Indexes {
+		index := d.Schema
+		if index == nil {
+			index = d.Database
+		}
+		var schemaSide, dbSide string
+		if d.Schema != nil {
+			schemaSide = d.Schema.String()
+		}
+		if d.Database != nil {
+			dbSide = d.Database.String()
+		}
+		items = append(items, dc.modelKeyItem(d.Status, modelKeyKind(tr, index.Kind), index.String(), schemaSide, dbSide))
+	}
+	for _, d := range comparison.ForeignKeys {
+		fk := d.Schema
+		if fk == nil {
+			fk = d.Database
+		}
+		var schemaSide, dbSide string
+		if d.Schema != nil {
+			schemaSide = d.Schema.String()
+		}
+		if d.Database != nil {
+			dbSide = d.Database.String()
+		}
+		items = append(items, dc.modelKeyItem(d.Status, tr.ModelKeyKindForeignKey, fk.String(), schemaSide, dbSide))
+	}
+
+	if len(items) == 0 {
+		modal := NewMessageModal(dc.g, tr, fmt.Sprintf(tr.ModalTitleModelKeysResults, comparison.Table),
+			tr.ModalMsgModelKeysNone,
+		).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+		dc.openModal(modal)
+		return
+	}
+
+	title := fmt.Sprintf(tr.ModalTitleModelKeysResults, comparison.Table)
+	color := ColorGreen
+	if diverged := comparison.Diverged(); diverged > 0 {
+		title = fmt.Sprintf(tr.ModalTitleModelKeysDiverged, comparison.Table, diverged)
+		color = ColorYellow
+	}
+
+	modal := NewListModal(dc.g, tr, title, items,
+		func() { dc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: color, BorderColor: color})
+	dc.openModal(modal)
+}
+
+// modelKeyItem returns the list item of one index or foreign key: its status
+// icon, kind and definition, with both sides in the description
+func (dc *DetailsController) modelKeyItem(status drift.Status, kind, definition, schemaSide, dbSide string) ListModalItem {
+	tr := dc.c.GetTranslationSet()
+
+	var icon, explanation string
+	switch status {
+	case drift.StatusMatch:
+		icon, explanation = style.Green("✓"), tr.ModelKeyMatch
+	case drift.StatusRenamed:
+		icon, explanation = style.Yellow("~"), tr.ModelKeyRenamed
+	case drift.StatusChanged:
+		icon, explanation = style.Yellow("≠"), tr.ModelKeyChanged
+	case drift.StatusMissing:
+		icon, explanation = style.Red("−"), tr.ModelKeyMissing
+	case drift.StatusExtra:
+		icon, explanation = style.Orange("+"), tr.ModelKeyExtra
+	}
+
+	description := explanation
+	if schemaSide != "" {
+		description += "\n\n" + fmt.Sprintf(tr.ModelKeySchemaSide, schemaSide)
+	}
+	if dbSide != "" {
+		description += "\n" + fmt.Sprintf(tr.ModelKeyDatabaseSide, dbSide)
+	}
+
+	return ListModalItem{
+		Label:       fmt.Sprintf("%s %-12s %s", icon, kind, definition),
+		Description: description,
+		OnSelect: func() error {
+			dc.closeModal()
+			return nil
+		},
+	}
+}
+
+// modelKeyKind returns the name of an index kind
+func modelKeyKind(tr *i18n.TranslationSet, kind drift.IndexKind) string {
+	switch kind {
+	case drift.IndexPrimary:
+		return tr.ModelKeyKindPrimary
+	case drift.IndexUnique:
+		return tr.ModelKeyKindUnique
+	default:
+		return tr.ModelKeyKindIndex
+	}
+}
+
+// ShowSchemaDefinition opens schema.prisma in the Schema tab with the cursor
+// on a declaration (0-based line) and focuses the details panel
+func (dc *DetailsController) ShowSchemaDefinition(line int) {
+	if dc.detailsCtx.ShowSchemaLine("", line+1) {
+		dc.focusDetails()
+	}
+}
+
+// focusDetails moves focus to the details panel
+func (dc *DetailsController) focusDetails() {
+	if dc.focusPanel != nil {
+		dc.focusPanel(dc.detailsCtx.ID())
+	}
+}
@@ -0,0 +1,296 @@
+package app
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dokadev/lazyprisma/pkg/doctor"
+	"github.com/dokadev/lazyprisma/pkg/gui/context"
+	"github.com/dokadev/lazyprisma/pkg/gui/style"
+	"github.com/dokadev/lazyprisma/pkg/gui/types"
+	"github.com/dokadev/lazyprisma/pkg/i18n"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+	"github.com/jesseduffield/gocui"
+)
+
+// DoctorController runs the toolchain health check and shows the Prisma
+// environment overrides.
+type DoctorController struct {
+	c          types.IControllerHost
+	g          *gocui.Gui
+	outputCtx  *context.OutputContext
+	openModal  func(Modal)
+	closeModal func()
+}
+
+// NewDoctorController creates a new DoctorController.
+func NewDoctorController(
+	c types.IControllerHost,
+	g *gocui.Gui,
+	outputCtx *context.OutputContext,
+	openModal func(Modal),
+	closeModal func(),
+) *DoctorController {
+	return &DoctorController{
+		c:          c,
+		g:          g,
+		outputCtx:  outputCtx,
+		openModal:  openModal,
+		closeModal: closeModal,
+	}
+}
+
+// RunDoctor checks node, prisma, the schema, the datasource, the database and
+// the migrations directory, then shows a checklist with fix suggestions.
+func (dc *DoctorController) RunDoctor() {
+	tr := dc.c.GetTranslationSet()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		modal := NewMessageModal(dc.g, tr, tr.ModalTitleError,
+			tr.ErrorFailedGetWorkingDirectory,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		dc.openModal(modal)
+		return
+	}
+
+	if !dc.c.TryStartCommand("Doctor") {
+		dc.c.LogCommandBlocked("Doctor")
+		return
+	}
+
+	dc.outputCtx.LogAction(tr.LogActionDoctor, tr.LogMsgRunningDoctor)
+
+	go func() {
+		results := doctor.Run(cwd)
+
+		dc.c.OnUIThread(func() error {
+			dc.c.FinishCommand()
+			dc.logResults(results)
+			dc.showResults(results)
+			return nil
+		})
+	}()
+}
+
+// logResults writes the checklist to the output panel
+func (dc *DoctorController) logResults(results []doctor.Result) {
+	tr := dc.c.GetTranslationSet()
+
+	failed := 0
+	for _, r := range results {
+		if r.Status == doctor.StatusFail {
+			failed++
+		}
+	}
+
+	lines := make([]string, 0, len(results))
+	for _, r := range results {
+		line := doctorStatusIcon(r.Status) + " " + doctorCheckTitle(tr, r.Check)
+		if r.Detail != "" {
+			line += ": " + r.Detail
+		}
+		if fix := doctorFix(tr, r.Problem); fix != "" {
+			line += " → " + fix
+		}
+		lines = append(lines, line)
+	}
+
+	if failed > 0 {
+		dc.outputCtx.LogActionRed(tr.LogActionDoctor, append([]string{fmt.Sprintf(tr.LogMsgDoctorFailed, failed)}, lines...)...)
+	} else {
+		dc.outputCtx.LogAction(tr.LogActionDoctor, append([]string{tr.LogMsgDoctorPassed}, lines...)...)
+	}
+}
+
+// showResults opens the checklist modal; each item's description holds the details and fix
+func (dc *DoctorController) showResults(results []doctor.Result) {
+	tr := dc.c.GetTranslationSet()
+
+	worst := doctor.StatusPass
+	items := make([]ListModalItem, 0, len(results))
+	for _, r := range results {
+		if r.Status != doctor.StatusSkip && r.Status > worst {
+			worst = r.Status
+		}
+
+		label := fmt.Sprintf("%s %-22s %s", doctorStatusIcon(r.Status), doctorCheckTitle(tr, r.Check), style.Gray(r.Detail))
+
+		description := doctorStatusText(tr, r.Status)
+		if r.Detail != "" {
+			description += "\n\n" + r.Detail
+		}
+		if fix := doctorFix(tr, r.Problem); fix != "" {
+			description += "\n\n" + tr.DoctorFixLabel + " " + fix
+		}
+
+		items = append(items, ListModalItem{
+			Label:       label,
+			Description: description,
+			OnSelect: func() error {
+				dc.closeModal()
+				return nil
+			},
+		})
+	}
+
+	color := ColorGreen
+	switch worst {
+	case doctor.StatusWarn:
+		color = ColorYellow
+	case doctor.StatusFail:
+		color = ColorRed
+	}
+
+	modal := NewListModal(dc.g, tr, tr.ModalTitleDoctor, items,
+		func() { dc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: color, BorderColor: color})
+
+	dc.openModal(modal)
+}
+
+// ShowEngineOverrides lists the PRISMA_* environment overrides (engine paths,
+// binary targets, CLI behaviour) that prisma commands run with
+func (dc *DoctorController) ShowEngineOverrides() {
+	tr := dc.c.GetTranslationSet()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		modal := NewMessageModal(dc.g, tr, tr.ModalTitleError,
+			tr.ErrorFailedGetWorkingDirectory,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		dc.openModal(modal)
+		return
+	}
+
+	overrides := prisma.EngineOverrides(cwd)
+	if len(overrides) == 0 {
+		modal := NewMessageModal(dc.g, tr, tr.ModalTitleEngineOverrides,
+			tr.ModalMsgNoEngineOverrides,
+		).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+		dc.openModal(modal)
+		return
+	}
+
+	items := make([]ListModalItem, 0, len(overrides))
+	for _, o := range overrides {
+		source := tr.EngineOverrideSourceEnv
+		if o.FromEnvFile() {
+			source = relativePath(cwd, o.Source)
+		}
+
+		items = append(items, ListModalItem{
+			Label: fmt.Sprintf("%-40s %s", o.Name, style.Gray(o.Value)),
+			Description: style.Cyan(o.Name) + "\n\n" +
+				tr.EngineOverrideValue + " " + o.Value + "\n" +
+				tr.EngineOverrideSource + " " + source + "\n\n" +
+				style.Gray(tr.ModalMsgEngineOverridesPassed),
+			OnSelect: func() error {
+				dc.closeModal()
+				return nil
+			},
+		})
+	}
+
+	modal := NewListModal(dc.g, tr, tr.ModalTitleEngineOverrides, items,
+		func() { dc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+
+	dc.openModal(modal)
+}
+
+// doctorStatusIcon returns the coloured checklist marker for a status
+func doctorStatusIcon(status doctor.Status) string {
+	switch status {
+	case doctor.StatusPass:
+		return style.Green("✓")
+	case doctor.StatusWarn:
+		return style.Yellow("⚠")
+	case doctor.StatusFail:
+		return style.Red("✗")
+	default:
+		return style.Gray("-")
+	}
+}
+
+func doctorStatusText(tr *i18n.TranslationSet, status doctor.Status) string {
+	switch status {
+	case doctor.StatusPass:
+		return style.Green(tr.DoctorStatusPass)
+	case doctor.StatusWarn:
+		return style.Yellow(tr.DoctorStatusWarn)
+	case doctor.StatusFail:
+		return style.Red(tr.DoctorStatusFail)
+	default:
+		return style.Gray(tr.DoctorStatusSkip)
+	}
+}
+
+func doctorCheckTitle(tr *i18n.TranslationSet, check doctor.Check) string {
+	switch check {
+	case doctor.CheckNode:
+		return tr.DoctorCheckNode
+	case doctor.CheckPrisma:
+		return tr.DoctorCheckPrisma
+	case doctor.CheckSchema:
+		return tr.DoctorCheckSchema
+	case doctor.CheckEnv:
+		return tr.DoctorCheckEnv
+	case doctor.CheckDatabase:
+		return tr.DoctorCheckDatabase
+	case doctor.CheckShadowDB:
+		return tr.DoctorCheckShadowDB
+	case doctor.CheckMigrations:
+		return tr.DoctorCheckMigrations
+	}
+	return string(check)
+}
+
+// doctorFix returns the fix suggestion for a problem ("" if there is nothing to fix)
+func doctorFix(tr *i18n.TranslationSet, problem doctor.Problem) string {
+	switch problem {
+	case doctor.ProblemNodeMissing:
+		return tr.DoctorFixNodeMissing
+	case doctor.ProblemNodeTooOld:
+		return tr.DoctorFixNodeTooOld
+	case doctor.ProblemNodePinMismatch:
+		return tr.DoctorFixNodePinMismatch
+	case doctor.ProblemPrismaMissing:
+		return tr.DoctorFixPrismaMissing
+	case doctor.ProblemClientMissing:
+		return tr.DoctorFixClientMissing
+	case doctor.ProblemVersionMismatch:
+		return tr.DoctorFixVersionMismatch
+	case doctor.ProblemSchemaInvalid:
+		return tr.DoctorFixSchemaInvalid
+	case doctor.ProblemNoDatasource:
+		return tr.DoctorFixNoDatasource
+	case doctor.ProblemEnvNotSet:
+		return tr.DoctorFixEnvNotSet
+	case doctor.ProblemUnsupportedProvider:
+		return tr.DoctorFixUnsupportedProvider
+	case doctor.ProblemDBUnreachable:
+		return tr.DoctorFixDBUnreachable
+	case doctor.ProblemDriverAdapterOnly:
+		return tr.DoctorFixDriverAdapterOnly
+	case doctor.ProblemShadowUnreachable:
+		return tr.DoctorFixShadowUnreachable
+	case doctor.ProblemNoCreateDB:
+		return tr.DoctorFixNoCreateDB
+	case doctor.ProblemPlanetScaleShadow:
+		return tr.DoctorFixPlanetScaleShadow
+	case doctor.ProblemNoMigrations:
+		return tr.DoctorFixNoMigrations
+	case doctor.ProblemLockMissing:
+		return tr.DoctorFixLockMissing
+	case doctor.ProblemProviderMismatch:
+		return tr.DoctorFixProviderMismatch
+	case doctor.ProblemEmptyMigration:
+		return tr.DoctorFixEmptyMigration
+	case doctor.ProblemBadMigrationName:
+		return tr.DoctorFixBadMigrationName
+	}
+	return ""
+}
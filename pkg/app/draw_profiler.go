@@ -0,0 +1,145 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// slowFrameThreshold is the frame time above which a frame is reported as slow
+// (one frame at 60 fps)
+const slowFrameThreshold = 16 * time.Millisecond
+
+// drawStats accumulates the draw durations of one panel
+type drawStats struct {
+	count int
+	total time.Duration
+	max   time.Duration
+	slow  int // Draws that took the whole frame budget on their own
+}
+
+// panelDraw is one panel's draw within the current frame
+type panelDraw struct {
+	id       string
+	duration time.Duration
+}
+
+// drawProfiler measures how long each layout pass and each panel's Draw takes
+// in debug mode. Slow frames are written to the debug log as they happen, with
+// the panels that caused them; a per-panel summary is written on exit.
+// Only used from the UI thread. A nil profiler records nothing.
+type drawProfiler struct {
+	file    *os.File
+	started time.Time
+
+	frames     int
+	frameTotal time.Duration
+	frameMax   time.Duration
+	slowFrames int
+
+	panels map[string]*drawStats
+	frame  []panelDraw // Draws of the frame in progress
+}
+
+// newDrawProfiler starts a new debug log at path, returning nil if it can't be written
+func newDrawProfiler(path string) *drawProfiler {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return nil
+	}
+	return &drawProfiler{
+		file:    file,
+		started: time.Now(),
+		panels:  make(map[string]*drawStats),
+	}
+}
+
+// RecordDraw records how long the given panel (or modal) took to draw
+func (p *drawProfiler) RecordDraw(id string, duration time.Duration) {
+	if p == nil {
+		return
+	}
+
+	stats, ok := p.panels[id]
+	if !ok {
+		stats = &drawStats{}
+		p.panels[id] = stats
+	}
+	stats.count++
+	stats.total += duration
+	stats.max = max(stats.max, duration)
+	if duration >= slowFrameThreshold {
+		stats.slow++
+	}
+
+	p.frame = append(p.frame, panelDraw{id: id, duration: duration})
+}
+
+// RecordFrame records a whole layout pass and reports it if it was slow
+func (p *drawProfiler) RecordFrame(duration time.Duration) {
+	if p == nil {
+		return
+	}
+
+	p.frames++
+	p.frameTotal += duration
+	p.frameMax = max(p.frameMax, duration)
+
+	if duration >= slowFrameThreshold {
+		p.slowFrames++
+
+		// Slowest panels first
+		sort.SliceStable(p.frame, func(i, j int) bool { return p.frame[i].duration > p.frame[j].duration })
+		parts := make([]string, len(p.frame))
+		for i, draw := range p.frame {
+			parts[i] = fmt.Sprintf("%s %s", draw.id, formatDrawDuration(draw.duration))
+		}
+		fmt.Fprintf(p.file, "%s slow frame %s: %s\n",
+			time.Now().Format("15:04:05.000"), formatDrawDuration(duration), strings.Join(parts, ", "))
+	}
+
+	p.frame = p.frame[:0]
+}
+
+// Close writes the per-panel summary and closes the debug log
+func (p *drawProfiler) Close() {
+	if p == nil {
+		return
+	}
+	defer p.file.Close()
+
+	if p.frames == 0 {
+		return
+	}
+
+	fmt.Fprintf(p.file, "\nsession %s: %d frames, avg %s, max %s, %d slow (>= %s)\n",
+		time.Since(p.started).Round(time.Second), p.frames,
+		formatDrawDuration(p.frameTotal/time.Duration(p.frames)), formatDrawDuration(p.frameMax),
+		p.slowFrames, slowFrameThreshold)
+
+	ids := make([]string, 0, len(p.panels))
+	for id := range p.panels {
+		ids = append(ids, id)
+	}
+	// Most time spent drawing first
+	sort.Slice(ids, func(i, j int) bool { return p.panels[ids[i]].total > p.panels[ids[j]].total })
+
+	for _, id := range ids {
+		stats := p.panels[id]
+		fmt.Fprintf(p.file, "  %-12s %6d draws  avg %-8s max %-8s %d slow\n",
+			id, stats.count,
+			formatDrawDuration(stats.total/time.Duration(stats.count)), formatDrawDuration(stats.max),
+			stats.slow)
+	}
+}
+
+// formatDrawDuration prints a duration in milliseconds with one decimal
+func formatDrawDuration(d time.Duration) string {
+	return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+}
@@ -0,0 +1,599 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dokadev/lazyprisma/pkg/config"
+	"github.com/dokadev/lazyprisma/pkg/database"
+	"github.com/dokadev/lazyprisma/pkg/gui/context"
+	"github.com/dokadev/lazyprisma/pkg/gui/style"
+	"github.com/dokadev/lazyprisma/pkg/gui/types"
+	"github.com/dokadev/lazyprisma/pkg/planetscale"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+	"github.com/jesseduffield/gocui"
+)
+
+// EnvironmentController compares migration state across the named databases
+// configured in the project config and deploys to a chosen one.
+type EnvironmentController struct {
+	c             types.IControllerHost
+	g             *gocui.Gui
+	migrationsCtx *context.MigrationsContext
+	outputCtx     *context.OutputContext
+	openModal     func(Modal)
+	closeModal    func()
+	runStreamCmd  func(AsyncCommandOpts) bool
+}
+
+// NewEnvironmentController creates a new EnvironmentController.
+func NewEnvironmentController(
+	c types.IControllerHost,
+	g *gocui.Gui,
+	migrationsCtx *context.MigrationsContext,
+	outputCtx *context.OutputContext,
+	openModal func(Modal),
+	closeModal func(),
+	runStreamCmd func(AsyncCommandOpts) bool,
+) *EnvironmentController {
+	return &EnvironmentController{
+		c:             c,
+		g:             g,
+		migrationsCtx: migrationsCtx,
+		outputCtx:     outputCtx,
+		openModal:     openModal,
+		closeModal:    closeModal,
+		runStreamCmd:  runStreamCmd,
+	}
+}
+
+// loadEnvironments reads the configured environments, showing a modal if there are none
+func (ec *EnvironmentController) loadEnvironments(cwd string) ([]config.EnvironmentConfig, bool) {
+	tr := ec.c.GetTranslationSet()
+
+	projectCfg, err := config.LoadProject(cwd)
+	if err != nil {
+		modal := NewMessageModal(ec.g, tr, tr.ModalTitleEnvironments,
+			fmt.Sprintf(tr.ModalMsgFailedLoadProjectConfig, config.ProjectConfigFile),
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		ec.openModal(modal)
+		return nil, false
+	}
+
+	if len(projectCfg.Environments) == 0 {
+		modal := NewMessageModal(ec.g, tr, tr.ModalTitleEnvironments,
+			fmt.Sprintf(tr.ModalMsgNoEnvironments, config.ProjectConfigFile),
+			"",
+			"environments:",
+			"  - name: staging",
+			"    urlEnv: STAGING_DATABASE_URL",
+			"  - name: prod",
+			"    urlEnv: PROD_DATABASE_URL",
+			"    protected: true",
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		ec.openModal(modal)
+		return nil, false
+	}
+
+	return projectCfg.Environments, true
+}
+
+// CompareEnvironments queries _prisma_migrations in every configured environment
+// and shows which local migrations are applied where.
+func (ec *EnvironmentController) CompareEnvironments() {
+	tr := ec.c.GetTranslationSet()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		modal := NewMessageModal(ec.g, tr, tr.ModalTitleError,
+			tr.ErrorFailedGetWorkingDirectory,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		ec.openModal(modal)
+		return
+	}
+
+	envs, ok := ec.loadEnvironments(cwd)
+	if !ok {
+		return
+	}
+
+	if !ec.c.TryStartCommand("Environment Status") {
+		ec.c.LogCommandBlocked("Environment Status")
+		return
+	}
+
+	ec.outputCtx.LogAction(tr.LogActionEnvironmentStatus, fmt.Sprintf(tr.LogMsgQueryingEnvironments, len(envs)))
+
+	go func() {
+		statuses := fetchEnvironmentStatuses(cwd, envs)
+		local := ec.migrationsCtx.GetCategory().Local
+
+		ec.c.OnUIThread(func() error {
+			ec.c.FinishCommand()
+			for _, status := range statuses {
+				if status.Err != nil {
+					ec.outputCtx.LogAction(tr.LogActionEnvironmentStatus, ec.summarize(status, local), status.Err.Error())
+				} else {
+					ec.outputCtx.LogAction(tr.LogActionEnvironmentStatus, ec.summarize(status, local))
+				}
+			}
+			ec.showComparison(envs, statuses, local)
+			return nil
+		})
+	}()
+}
+
+// fetchEnvironmentStatuses queries all environments concurrently, preserving config order
+func fetchEnvironmentStatuses(cwd string, envs []config.EnvironmentConfig) []*prisma.EnvironmentStatus {
+	provider, providerErr := prisma.GetProvider(cwd)
+	lookupEnv := func(name string) string { return prisma.ResolveEnvVar(cwd, name) }
+
+	statuses := make([]*prisma.EnvironmentStatus, len(envs))
+	var wg sync.WaitGroup
+	for i, env := range envs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses[i] = fetchEnvironmentStatus(env, provider, providerErr, env.ResolveURL(lookupEnv))
+		}()
+	}
+	wg.Wait()
+
+	return statuses
+}
+
+// fetchEnvironmentStatus connects to one environment and reads its migration history
+func fetchEnvironmentStatus(env config.EnvironmentConfig, provider string, providerErr error, url string) *prisma.EnvironmentStatus {
+	status := &prisma.EnvironmentStatus{Name: env.Name, Protected: env.Protected}
+
+	if providerErr != nil {
+		status.Err = providerErr
+		return status
+	}
+	if url == "" {
+		status.Err = fmt.Errorf("no database URL (set url or urlEnv)")
+		return status
+	}
+
+	client, err := database.NewClientFromDSN(provider, url)
+	if err != nil {
+		status.Err = err
+		return status
+	}
+	defer client.Close()
+
+	rows, err := prisma.GetDBMigrations(client.DB())
+	if err != nil {
+		status.Err = err
+		return status
+	}
+
+	return prisma.NewEnvironmentStatus(env.Name, env.Protected, rows)
+}
+
+// summarize describes an environment's state in one line
+func (ec *EnvironmentController) summarize(status *prisma.EnvironmentStatus, local []prisma.Migration) string {
+	tr := ec.c.GetTranslationSet()
+
+	if status.Err != nil {
+		return fmt.Sprintf(tr.EnvironmentSummaryError, status.Name)
+	}
+	if missing := status.Missing(local); len(missing) > 0 {
+		return fmt.Sprintf(tr.EnvironmentSummaryBehind, status.Name, len(missing))
+	}
+	return fmt.Sprintf(tr.EnvironmentSummaryUpToDate, status.Name)
+}
+
+// showComparison lists each environment's summary followed by a per-migration table
+func (ec *EnvironmentController) showComparison(envs []config.EnvironmentConfig, statuses []*prisma.EnvironmentStatus, local []prisma.Migration) {
+	tr := ec.c.GetTranslationSet()
+
+	var items []ListModalItem
+
+	// Environment summaries; environments that are behind or unreachable stand out.
+	// Selecting one deploys pending migrations to it.
+	for i, status := range statuses {
+		summary := ec.summarize(status, local)
+		missing := status.Missing(local)
+
+		var label, desc string
+		switch {
+		case status.Err != nil:
+			label = style.Red("✗ " + summary)
+			desc = status.Err.Error()
+		case len(missing) > 0:
+			label = style.Yellow("● " + summary)
+			desc = tr.EnvironmentMissingLabel + "\n" + strings.Join(missing, "\n")
+		default:
+			label = style.Green("✓ " + summary)
+			desc = summary
+		}
+		if status.Protected {
+			label += "  " + style.Gray("("+tr.EnvironmentTagProtected+")")
+		}
+
+		// Deploying is hidden when the project disables it
+		canDeploy := !ec.c.IsActionDisabled(config.ActionMigrateDeploy)
+		if canDeploy {
+			desc += "\n\n" + style.Cyan(tr.EnvironmentDeployHint)
+		}
+
+		env := envs[i]
+		items = append(items, ListModalItem{
+			Label:       label,
+			Description: desc,
+			OnSelect: func() error {
+				ec.closeModal()
+				if canDeploy {
+					ec.DeployToEnvironment(env)
+				}
+				return nil
+			},
+		})
+	}
+
+	// Column width fits the longest environment name
+	colWidth := 3
+	nameWidth := 0
+	for _, status := range statuses {
+		colWidth = max(colWidth, len(status.Name))
+	}
+	for _, mig := range local {
+		nameWidth = max(nameWidth, len(mig.Name))
+	}
+
+	header := fmt.Sprintf("  %-*s", nameWidth, tr.EnvironmentColumnMigration)
+	for _, status := range statuses {
+		header += "  " + fmt.Sprintf("%-*s", colWidth, status.Name)
+	}
+	items = append(items, ListModalItem{
+		Label:       style.Bold(header),
+		Description: tr.EnvironmentLegend,
+		OnSelect: func() error {
+			ec.closeModal()
+			return nil
+		},
+	})
+
+	for _, mig := range local {
+		row := fmt.Sprintf("  %-*s", nameWidth, mig.Name)
+		var pendingIn []string
+		for _, status := range statuses {
+			cell := fmt.Sprintf("%-*s", colWidth, "?")
+			switch {
+			case status.Err != nil:
+				cell = style.Gray(cell)
+			case status.Applied[mig.Name]:
+				cell = style.Green(fmt.Sprintf("%-*s", colWidth, "✓"))
+			case status.Failed[mig.Name]:
+				cell = style.Red(fmt.Sprintf("%-*s", colWidth, "✗"))
+				pendingIn = append(pendingIn, status.Name)
+			default:
+				cell = style.Yellow(fmt.Sprintf("%-*s", colWidth, "·"))
+				pendingIn = append(pendingIn, status.Name)
+			}
+			row += "  " + cell
+		}
+
+		desc := fmt.Sprintf(tr.EnvironmentAppliedEverywhere, mig.Name)
+		if len(pendingIn) > 0 {
+			desc = fmt.Sprintf(tr.EnvironmentNotAppliedIn, mig.Name, strings.Join(pendingIn, ", "))
+		}
+
+		items = append(items, ListModalItem{
+			Label:       row,
+			Description: desc,
+			OnSelect: func() error {
+				ec.closeModal()
+				return nil
+			},
+		})
+	}
+
+	modal := NewListModal(ec.g, tr, tr.ModalTitleEnvironments, items,
+		func() { ec.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+
+	ec.openModal(modal)
+}
+
+// DeployToEnvironment runs migrate deploy against an environment by overriding the
+// datasource's environment variable for that command only. Protected environments
+// require typing the environment name to confirm.
+func (ec *EnvironmentController) DeployToEnvironment(env config.EnvironmentConfig) {
+	tr := ec.c.GetTranslationSet()
+
+	if ec.c.RejectDisabledAction(config.ActionMigrateDeploy) {
+		return
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		modal := NewMessageModal(ec.g, tr, tr.ModalTitleError,
+			tr.ErrorFailedGetWorkingDirectory,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		ec.openModal(modal)
+		return
+	}
+
+	// The URL can only be swapped if the datasource reads it from an environment variable
+	envVar, err := prisma.GetEnvVarName(cwd)
+	if err != nil || envVar == "" {
+		modal := NewMessageModal(ec.g, tr, tr.ModalTitleDeployToEnvironment,
+			tr.ModalMsgDatasourceNotFromEnv,
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		ec.openModal(modal)
+		return
+	}
+
+	lookupEnv := func(name string) string { return prisma.ResolveEnvVar(cwd, name) }
+	url := env.ResolveURL(lookupEnv)
+	if url == "" {
+		modal := NewMessageModal(ec.g, tr, tr.ModalTitleDeployToEnvironment,
+			fmt.Sprintf(tr.ModalMsgEnvironmentNoURL, env.Name),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		ec.openModal(modal)
+		return
+	}
+
+	// migrate deploy connects through the directUrl if the datasource has
+	// one, so that has to point at the environment too
+	cmdEnv := []string{envVar + "=" + url}
+	target := url
+	directURL, directEnvVar := prisma.GetDirectURL(cwd)
+	switch {
+	case directEnvVar != "":
+		target = env.ResolveDirectURL(lookupEnv)
+		cmdEnv = append(cmdEnv, directEnvVar+"="+target)
+	case directURL != "":
+		modal := NewMessageModal(ec.g, tr, tr.ModalTitleDeployToEnvironment,
+			tr.ModalMsgDirectURLNotFromEnv,
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		ec.openModal(modal)
+		return
+	}
+
+	message := fmt.Sprintf(tr.ModalMsgConfirmDeployToEnvironment, env.Name, prisma.MaskPassword(target))
+	deploy := func() {
+		ec.runDeploy(env, cmdEnv, target)
+	}
+
+	if !env.Protected {
+		modal := NewConfirmModal(ec.g, tr, tr.ModalTitleDeployToEnvironment, message,
+			func() {
+				ec.closeModal()
+				deploy()
+			},
+			func() {
+				ec.closeModal()
+			},
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		ec.openModal(modal)
+		return
+	}
+
+	modal := NewInputModal(ec.g, tr, fmt.Sprintf(tr.ModalTitleDeployToProtected, env.Name),
+		func(input string) {
+			ec.closeModal()
+			if strings.TrimSpace(input) != env.Name {
+				errorModal := NewMessageModal(ec.g, tr, tr.ModalTitleDeployToEnvironment,
+					tr.ModalMsgProtectedConfirmMismatch,
+				).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+				ec.openModal(errorModal)
+				return
+			}
+			deploy()
+		},
+		func() {
+			ec.closeModal()
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed}).
+		WithSubtitle(message + " " + fmt.Sprintf(tr.ModalMsgTypeEnvironmentToConfirm, env.Name))
+
+	ec.openModal(modal)
+}
+
+// runDeploy streams migrate deploy with the datasource's variables in cmdEnv
+// pointing at the environment's database, which migrations reach at url
+func (ec *EnvironmentController) runDeploy(env config.EnvironmentConfig, cmdEnv []string, url string) {
+	tr := ec.c.GetTranslationSet()
+
+	// Every log entry of this run names the environment
+	stamp := func(action string) string {
+		return fmt.Sprintf("[%s] %s", env.Name, action)
+	}
+
+	var output outputCapture
+	start := time.Now()
+	ec.runStreamCmd(AsyncCommandOpts{
+		Name:          "Migrate Deploy",
+		Env:           cmdEnv,
+		OnOutput:      output.Add,
+		LogAction:     stamp(tr.LogActionMigrateDeploy),
+		LogDetail:     fmt.Sprintf(tr.LogMsgDeployingToEnvironment, env.Name, prisma.MaskPassword(url)),
+		ErrorTitle:    tr.ModalTitleMigrateDeployError,
+		ErrorStartMsg: tr.ModalMsgFailedStartMigrateDeploy,
+		PrismaArgs:    prisma.MigrateDeployArgs(),
+		OnSuccess: func(out *context.OutputContext, cwd string) {
+			ec.c.FinishCommand()
+			out.LogAction(stamp(tr.LogActionMigrateDeployComplete), tr.LogMsgMigrationsAppliedSuccess)
+			ec.c.NotifyWebhooks(deploySummary(env.Name, cmdEnv, output.String(), 0, time.Since(start)))
+			modal := NewMessageModal(ec.g, tr, tr.ModalTitleMigrateDeploySuccess,
+				fmt.Sprintf(tr.ModalMsgDeployedToEnvironment, env.Name),
+			).WithStyle(MessageModalStyle{TitleColor: ColorGreen, BorderColor: ColorGreen})
+			ec.openModal(modal)
+		},
+		OnFailure: func(out *context.OutputContext, cwd string, exitCode int) {
+			ec.c.FinishCommand()
+			out.LogAction(stamp(tr.LogActionMigrateDeployFailed), fmt.Sprintf(tr.LogMsgMigrateDeployFailedCode, exitCode))
+			ec.c.NotifyWebhooks(deploySummary(env.Name, cmdEnv, output.String(), exitCode, time.Since(start)))
+			modal := NewMessageModal(ec.g, tr, tr.ModalTitleMigrateDeployFailed,
+				fmt.Sprintf(tr.ModalMsgMigrateDeployFailedWithCode, exitCode),
+				tr.ModalMsgCheckOutputPanel,
+			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed}).WithViewOutput(ec.c.ShowFailedOutput)
+			ec.openModal(modal)
+		},
+		OnError: func(out *context.OutputContext, cwd string, err error) {
+			ec.c.FinishCommand()
+			out.LogAction(stamp(tr.LogActionMigrateDeployFailed), err.Error())
+			modal := NewMessageModal(ec.g, tr, tr.ModalTitleMigrateDeployError,
+				tr.ModalMsgFailedRunMigrateDeploy,
+				err.Error(),
+			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+			ec.openModal(modal)
+		},
+	})
+}
+
+// ShowPlanetScaleBranches lists the branches of the project's PlanetScale
+// database with the pscale CLI, naming the environment each one is configured
+// as. Selecting a branch that is an environment deploys to it.
+func (ec *EnvironmentController) ShowPlanetScaleBranches() {
+	tr := ec.c.GetTranslationSet()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		modal := NewMessageModal(ec.g, tr, tr.ModalTitleError,
+			tr.ErrorFailedGetWorkingDirectory,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		ec.openModal(modal)
+		return
+	}
+
+	projectCfg, err := config.LoadProject(cwd)
+	if err != nil {
+		modal := NewMessageModal(ec.g, tr, tr.ModalTitlePlanetScaleBranches,
+			fmt.Sprintf(tr.ModalMsgFailedLoadProjectConfig, config.ProjectConfigFile),
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		ec.openModal(modal)
+		return
+	}
+
+	if !planetscale.Available() {
+		modal := NewMessageModal(ec.g, tr, tr.ModalTitlePlanetScaleBranches,
+			tr.ModalMsgPscaleNotFound,
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		ec.openModal(modal)
+		return
+	}
+
+	dbName := projectCfg.PlanetScale.Database
+	if dbName == "" {
+		if ds, err := prisma.GetDatasource(cwd); err == nil {
+			dbName = prisma.PlanetScaleDatabase(ds.URL)
+		}
+	}
+	if dbName == "" {
+		modal := NewMessageModal(ec.g, tr, tr.ModalTitlePlanetScaleBranches,
+			fmt.Sprintf(tr.ModalMsgPlanetScaleNoDatabase, config.ProjectConfigFile),
+			"",
+			"planetscale:",
+			"  database: shop",
+			"  org: acme",
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		ec.openModal(modal)
+		return
+	}
+
+	if !ec.c.TryStartCommand("PlanetScale Branches") {
+		ec.c.LogCommandBlocked("PlanetScale Branches")
+		return
+	}
+
+	ec.outputCtx.LogAction(tr.LogActionPlanetScaleBranches, fmt.Sprintf(tr.LogMsgPlanetScaleListing, dbName))
+
+	go func() {
+		branches, err := planetscale.ListBranches(dbName, projectCfg.PlanetScale.Org)
+
+		ec.c.OnUIThread(func() error {
+			ec.c.FinishCommand()
+			if err != nil {
+				ec.outputCtx.LogActionRed(tr.LogActionPlanetScaleBranches, err.Error())
+				modal := NewMessageModal(ec.g, tr, tr.ModalTitlePlanetScaleBranches,
+					tr.ModalMsgPlanetScaleBranchesFailed,
+					err.Error(),
+				).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+				ec.openModal(modal)
+				return nil
+			}
+			ec.outputCtx.LogAction(tr.LogActionPlanetScaleBranches, fmt.Sprintf(tr.LogMsgPlanetScaleListed, len(branches), dbName))
+			ec.showPlanetScaleBranches(dbName, branches, projectCfg.Environments)
+			return nil
+		})
+	}()
+}
+
+// showPlanetScaleBranches lists the branches, each with the environment it is
+// configured as
+func (ec *EnvironmentController) showPlanetScaleBranches(dbName string, branches []planetscale.Branch, envs []config.EnvironmentConfig) {
+	tr := ec.c.GetTranslationSet()
+
+	if len(branches) == 0 {
+		modal := NewMessageModal(ec.g, tr, tr.ModalTitlePlanetScaleBranches,
+			fmt.Sprintf(tr.ModalMsgPlanetScaleNoBranches, dbName),
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		ec.openModal(modal)
+		return
+	}
+
+	canDeploy := !ec.c.IsActionDisabled(config.ActionMigrateDeploy)
+
+	var items []ListModalItem
+	for _, branch := range branches {
+		var env *config.EnvironmentConfig
+		for i := range envs {
+			if envs[i].Branch == branch.Name {
+				env = &envs[i]
+				break
+			}
+		}
+
+		label := branch.Name
+		if branch.Production {
+			label += "  " + style.Green("("+tr.PlanetScaleTagProduction+")")
+		}
+		if !branch.Ready {
+			label += "  " + style.Gray("("+tr.PlanetScaleTagNotReady+")")
+		}
+
+		parent := branch.ParentBranch
+		if parent == "" {
+			parent = "-"
+		}
+		desc := fmt.Sprintf(tr.PlanetScaleBranchDescription, branch.Name, parent, branch.CreatedAt.Local().Format(time.RFC1123))
+		if env != nil {
+			label += "  " + style.Cyan("→ "+env.Name)
+			if canDeploy {
+				desc += "\n\n" + style.Cyan(fmt.Sprintf(tr.PlanetScaleBranchDeployHint, env.Name))
+			}
+		} else {
+			desc += "\n\n" + fmt.Sprintf(tr.PlanetScaleBranchUnmapped, branch.Name, config.ProjectConfigFile)
+		}
+
+		items = append(items, ListModalItem{
+			Label:       label,
+			Description: desc,
+			OnSelect: func() error {
+				ec.closeModal()
+				if env != nil && canDeploy {
+					ec.DeployToEnvironment(*env)
+				}
+				return nil
+			},
+		})
+	}
+
+	modal := NewListModal(ec.g, tr, fmt.Sprintf(tr.ModalTitlePlanetScaleBranchesOf, dbName), items,
+		func() { ec.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+
+	ec.openModal(modal)
+}
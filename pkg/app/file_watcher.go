@@ -0,0 +1,278 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dokadev/lazyprisma/pkg/gui/context"
+	"github.com/dokadev/lazyprisma/pkg/gui/types"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+	"github.com/jesseduffield/gocui"
+)
+
+// fileWatchMaxDelay caps how long files that keep changing postpone the refresh
+const fileWatchMaxDelay = 10 * time.Second
+
+// fileWatchLoggedFiles is how many changed files the Output panel names
+const fileWatchLoggedFiles = 5
+
+// fileWatcher polls the project's Prisma-related files (the schema, the
+// migrations directory, the .env files and prisma.config.ts) and merges a
+// burst of changes, e.g. a generator writing many files or a git checkout,
+// into a single refresh once the files have stopped changing.
+type fileWatcher struct {
+	dir           string
+	migrationsDir string // Relative to dir, with forward slashes
+	debounce      time.Duration
+
+	mu          sync.Mutex
+	files       map[string]time.Time // Modification time of each file when last polled
+	changed     map[string]bool      // Files changed since the last refresh
+	firstChange time.Time
+	lastChange  time.Time
+
+	// Directory listings of the migrations directory and each migration
+	// folder, read again only when the directory's modification time changes
+	migrationsListing dirListing
+	folderListings    map[string]dirListing
+}
+
+// dirListing is the names of a directory's entries of one kind (folders or
+// files) as of a modification time of the directory
+type dirListing struct {
+	modTime time.Time
+	names   []string
+}
+
+func newFileWatcher(dir string, debounce time.Duration) *fileWatcher {
+	migrationsDir, err := filepath.Rel(dir, prisma.MigrationsDir(dir))
+	if err != nil {
+		migrationsDir = filepath.Join(prisma.SchemaDirName, prisma.MigrationsDirName)
+	}
+	w := &fileWatcher{
+		dir:            dir,
+		migrationsDir:  filepath.ToSlash(migrationsDir),
+		debounce:       debounce,
+		changed:        make(map[string]bool),
+		folderListings: make(map[string]dirListing),
+	}
+	w.files = w.fileTimes()
+	return w
+}
+
+// fileTimes returns the modification time of each Prisma-related file. Only
+// the migration folders added, removed or renamed into since the last poll
+// are listed again; the files of the others are just stat'ed.
+// Must be called with w.mu held, or before the watcher starts.
+func (w *fileWatcher) fileTimes() map[string]time.Time {
+	paths := prisma.ProjectFilePaths(w.dir)
+
+	migrationsDir := filepath.Join(w.dir, filepath.FromSlash(w.migrationsDir))
+	folders, ok := listDir(migrationsDir, &w.migrationsListing, true)
+	listings := make(map[string]dirListing, len(folders))
+	if ok {
+		for _, folder := range folders {
+			listing := w.folderListings[folder]
+			files, ok := listDir(filepath.Join(migrationsDir, folder), &listing, false)
+			if !ok {
+				continue
+			}
+			listings[folder] = listing
+			for _, file := range files {
+				paths = append(paths, filepath.Join(migrationsDir, folder, file))
+			}
+		}
+	}
+	w.folderListings = listings
+
+	times := make(map[string]time.Time)
+	for _, path := range paths {
+		if file, ok := prisma.StatProjectFile(w.dir, path); ok {
+			times[file.Path] = file.ModTime
+		}
+	}
+	return times
+}
+
+// listDir returns the names of the subdirectories (dirs) or files of a
+// directory, reusing the cached listing unless the directory's modification
+// time changed. Returns false if the directory can't be read.
+func listDir(dir string, cached *dirListing, dirs bool) ([]string, bool) {
+	// Stat before reading, so an entry added meanwhile changes the time next poll
+	stat, err := os.Stat(dir)
+	if err != nil || !stat.IsDir() {
+		*cached = dirListing{}
+		return nil, false
+	}
+	if !cached.modTime.IsZero() && cached.modTime.Equal(stat.ModTime()) {
+		return cached.names, true
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		*cached = dirListing{}
+		return nil, false
+	}
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() == dirs {
+			names = append(names, entry.Name())
+		}
+	}
+	*cached = dirListing{modTime: stat.ModTime(), names: names}
+	return names, true
+}
+
+// scan compares the files with the last poll, recording added, modified and
+// removed ones as changed. Must be called with w.mu held.
+func (w *fileWatcher) scan(now time.Time) {
+	files := w.fileTimes()
+	found := false
+	for path, modTime := range files {
+		if previous, ok := w.files[path]; !ok || !previous.Equal(modTime) {
+			w.changed[path] = true
+			found = true
+		}
+	}
+	for path := range w.files {
+		if _, ok := files[path]; !ok {
+			w.changed[path] = true
+			found = true
+		}
+	}
+	w.files = files
+
+	if found {
+		if w.firstChange.IsZero() {
+			w.firstChange = now
+		}
+		w.lastChange = now
+	}
+}
+
+// poll scans the files and, once they have been quiet for the debounce
+// period (or have kept changing for fileWatchMaxDelay), returns the files
+// changed since the last refresh and the panels they affect
+func (w *fileWatcher) poll(now time.Time) ([]string, types.RefreshScope) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.scan(now)
+	if len(w.changed) == 0 {
+		return nil, 0
+	}
+	if now.Sub(w.lastChange) < w.debounce && now.Sub(w.firstChange) < fileWatchMaxDelay {
+		return nil, 0
+	}
+
+	var paths []string
+	var scope types.RefreshScope
+	for path := range w.changed {
+		paths = append(paths, path)
+		scope |= w.refreshScope(path)
+	}
+	sort.Strings(paths)
+	w.reset()
+	return paths, scope
+}
+
+// refreshing is called when a refresh of scope starts: it reads the files
+// anew, so changes to the files it reloads no longer need a refresh of their own
+func (w *fileWatcher) refreshing(scope types.RefreshScope) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.scan(time.Now())
+	for path := range w.changed {
+		if w.refreshScope(path)&^scope == 0 {
+			delete(w.changed, path)
+		}
+	}
+	if len(w.changed) == 0 {
+		w.reset()
+	}
+}
+
+// reset forgets the changed files. Must be called with w.mu held.
+func (w *fileWatcher) reset() {
+	w.changed = make(map[string]bool)
+	w.firstChange = time.Time{}
+	w.lastChange = time.Time{}
+}
+
+// refreshScope returns the panels that show a file
+func (w *fileWatcher) refreshScope(path string) types.RefreshScope {
+	switch {
+	case strings.HasPrefix(path, w.migrationsDir+"/"):
+		return types.RefreshMigrations
+	case strings.HasSuffix(path, ".prisma"):
+		// The schema is validated for the Action-Needed tab
+		return types.RefreshSchema | types.RefreshMigrations
+	default:
+		// .env files and prisma.config.ts can change the database URL
+		return types.RefreshEverything
+	}
+}
+
+// startFileWatcher polls the project's files in the background and refreshes
+// the panels that show the ones that changed, once they have stopped changing
+// for debounce. Changes made while a command runs wait for it to finish.
+func (a *App) startFileWatcher(debounce time.Duration) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return
+	}
+	a.fileWatcher = newFileWatcher(cwd, debounce)
+
+	// Poll a few times within the debounce period, but not too often
+	interval := min(max(debounce/2, 100*time.Millisecond), time.Second)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case now := <-ticker.C:
+				if a.CommandInProgress() {
+					continue
+				}
+				paths, scope := a.fileWatcher.poll(now)
+				if len(paths) == 0 {
+					continue
+				}
+				// Refresh from the UI thread, so the changes are logged first
+				a.g.Update(func(g *gocui.Gui) error {
+					a.logChangedFiles(paths)
+					a.RequestRefresh(scope)
+					return nil
+				})
+			case <-a.stopSpinnerCh:
+				return
+			}
+		}
+	}()
+}
+
+// logChangedFiles tells the user that files changed outside LazyPrisma and
+// the panels are being reloaded
+func (a *App) logChangedFiles(paths []string) {
+	if len(paths) == 1 {
+		a.Toast(fmt.Sprintf(a.Tr.ToastFileChanged, paths[0]))
+	} else {
+		a.Toast(fmt.Sprintf(a.Tr.ToastFilesChanged, len(paths)))
+	}
+
+	detail := strings.Join(paths[:min(len(paths), fileWatchLoggedFiles)], ", ")
+	if len(paths) > fileWatchLoggedFiles {
+		detail += fmt.Sprintf(a.Tr.LogMsgFilesChangedMore, len(paths)-fileWatchLoggedFiles)
+	}
+	if outputPanel, ok := a.panels[ViewOutputs].(*context.OutputContext); ok {
+		outputPanel.LogAction(a.Tr.ActionFilesChanged, detail)
+	}
+}
@@ -2,9 +2,13 @@ package app
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/dokadev/lazyprisma/pkg/config"
 	"github.com/dokadev/lazyprisma/pkg/gui/context"
+	"github.com/dokadev/lazyprisma/pkg/gui/style"
 	"github.com/dokadev/lazyprisma/pkg/gui/types"
 	"github.com/dokadev/lazyprisma/pkg/prisma"
 	"github.com/jesseduffield/gocui"
@@ -15,8 +19,11 @@ type GenerateController struct {
 	c             types.IControllerHost
 	g             *gocui.Gui
 	outputCtx     *context.OutputContext
+	detailsCtx    *context.DetailsContext
 	openModal     func(Modal)
+	closeModal    func()
 	runStreamCmd  func(AsyncCommandOpts) bool
+	focusPanel    func(string)
 }
 
 // NewGenerateController creates a new GenerateController.
@@ -24,29 +31,58 @@ func NewGenerateController(
 	c types.IControllerHost,
 	g *gocui.Gui,
 	outputCtx *context.OutputContext,
+	detailsCtx *context.DetailsContext,
 	openModal func(Modal),
+	closeModal func(),
 	runStreamCmd func(AsyncCommandOpts) bool,
+	focusPanel func(string),
 ) *GenerateController {
 	return &GenerateController{
 		c:            c,
 		g:            g,
 		outputCtx:    outputCtx,
+		detailsCtx:   detailsCtx,
 		openModal:    openModal,
+		closeModal:   closeModal,
 		runStreamCmd: runStreamCmd,
+		focusPanel:   focusPanel,
 	}
 }
 
-// Generate runs prisma generate and shows result in modal
+// Generate runs prisma generate and shows result in modal.
+// Generators skipped with ToggleGenerators are left out. When it fails on
+// schema errors, their locations can be opened in the Schema tab.
 func (gc *GenerateController) Generate() {
 	tr := gc.c.GetTranslationSet()
 
+	var generators []string // All of them unless some are skipped
+	logDetail := tr.LogMsgRunningGenerate
+
+	if cwd, err := os.Getwd(); err == nil {
+		enabled, skipped := gc.selectedGenerators(cwd)
+		if len(skipped) > 0 {
+			if len(enabled) == 0 {
+				modal := NewMessageModal(gc.g, tr, tr.ModalTitleGenerators,
+					tr.ModalMsgAllGeneratorsSkipped,
+				).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+				gc.openModal(modal)
+				return
+			}
+			generators = enabled
+			logDetail = fmt.Sprintf(tr.LogMsgRunningGenerateSkipping, strings.Join(skipped, ", "))
+		}
+	}
+
+	output := &outputCapture{}
+
 	gc.runStreamCmd(AsyncCommandOpts{
 		Name:          "Generate",
-		Args:          []string{"npx", "prisma", "generate"},
+		OnOutput:      output.Add,
 		LogAction:     tr.LogActionGenerate,
-		LogDetail:     tr.LogMsgRunningGenerate,
+		LogDetail:     logDetail,
 		ErrorTitle:    tr.ModalTitleGenerateError,
 		ErrorStartMsg: tr.ModalMsgFailedStartGenerate,
+		PrismaArgs:    prisma.GenerateArgs(generators),
 		OnSuccess: func(out *context.OutputContext, cwd string) {
 			gc.c.FinishCommand() // Finish immediately on success
 			out.LogAction(tr.LogActionGenerateComplete, tr.LogMsgPrismaClientGeneratedSuccess)
@@ -60,13 +96,16 @@ func (gc *GenerateController) Generate() {
 			out.LogAction(tr.LogActionGenerateFailed, tr.LogMsgCheckingSchemaErrors)
 
 			go func() {
-				validateResult, err := prisma.Validate(cwd)
+				validateResult, err := gc.c.PrismaRunner().Validate(cwd)
 
 				gc.c.OnUIThread(func() error {
 					gc.c.FinishCommand() // Finish after validate completes
 
 					if err == nil && !validateResult.Valid {
 						gc.outputCtx.LogAction(tr.LogActionSchemaValidationFailed, fmt.Sprintf(tr.LogMsgFoundSchemaErrors, len(validateResult.Errors)))
+						if gc.showSchemaErrors(cwd, output.String()+"\n"+validateResult.Output) {
+							return nil
+						}
 						modal := NewMessageModal(gc.g, tr, tr.ModalTitleSchemaValidationFailed,
 							tr.ModalMsgGenerateFailedSchemaErrors,
 						).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
@@ -76,7 +115,7 @@ func (gc *GenerateController) Generate() {
 						modal := NewMessageModal(gc.g, tr, tr.ModalTitleGenerateFailed,
 							fmt.Sprintf(tr.ModalMsgGenerateFailedWithCode, exitCode),
 							tr.ModalMsgSchemaValidCheckOutput,
-						).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+						).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed}).WithViewOutput(gc.c.ShowFailedOutput)
 						gc.openModal(modal)
 					}
 					return nil
@@ -90,13 +129,16 @@ func (gc *GenerateController) Generate() {
 				out.LogAction(tr.LogActionGenerateFailed, tr.LogMsgCheckingSchemaErrors)
 
 				go func() {
-					validateResult, validateErr := prisma.Validate(cwd)
+					validateResult, validateErr := gc.c.PrismaRunner().Validate(cwd)
 
 					gc.c.OnUIThread(func() error {
 						gc.c.FinishCommand() // Finish after validate completes
 
 						if validateErr == nil && !validateResult.Valid {
 							gc.outputCtx.LogAction(tr.LogActionSchemaValidationFailed, fmt.Sprintf(tr.LogMsgFoundSchemaErrors, len(validateResult.Errors)))
+							if gc.showSchemaErrors(cwd, output.String()+"\n"+validateResult.Output) {
+								return nil
+							}
 							modal := NewMessageModal(gc.g, tr, tr.ModalTitleSchemaValidationFailed,
 								tr.ModalMsgGenerateFailedSchemaErrors,
 							).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
@@ -106,7 +148,7 @@ func (gc *GenerateController) Generate() {
 							modal := NewMessageModal(gc.g, tr, tr.ModalTitleGenerateFailed,
 								tr.ModalMsgFailedRunGenerate,
 								tr.ModalMsgSchemaValidCheckOutput,
-							).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+							).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed}).WithViewOutput(gc.c.ShowFailedOutput)
 							gc.openModal(modal)
 						}
 						return nil
@@ -125,3 +167,153 @@ func (gc *GenerateController) Generate() {
 		},
 	})
 }
+
+// showSchemaErrors lists the error locations found in the output; selecting one
+// opens the Schema tab at that line. Returns false if no location was found.
+func (gc *GenerateController) showSchemaErrors(cwd, output string) bool {
+	tr := gc.c.GetTranslationSet()
+
+	schemaErrors := prisma.ParseSchemaErrors(output)
+	if len(schemaErrors) == 0 {
+		return false
+	}
+
+	items := make([]ListModalItem, 0, len(schemaErrors))
+	for _, schemaErr := range schemaErrors {
+		path := schemaErr.File
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(cwd, path)
+		}
+		location := fmt.Sprintf("%s:%d", schemaErr.File, schemaErr.Line)
+
+		label := location
+		if schemaErr.Message != "" {
+			label = fmt.Sprintf("%s  %s", location, style.Gray(schemaErr.Message))
+		}
+
+		items = append(items, ListModalItem{
+			Label:       label,
+			Description: schemaErr.Message + "\n\n" + tr.SchemaErrorJumpHint,
+			OnSelect: func() error {
+				gc.closeModal()
+				if !gc.detailsCtx.ShowSchemaLine(path, schemaErr.Line) {
+					gc.outputCtx.LogActionRed(tr.LogActionSchemaValidationFailed, fmt.Sprintf(tr.LogMsgFailedOpenSchemaLocation, location))
+					return nil
+				}
+				gc.focusPanel(gc.detailsCtx.GetViewName())
+				return nil
+			},
+		})
+	}
+
+	modal := NewListModal(gc.g, tr, fmt.Sprintf(tr.ModalTitleSchemaErrors, len(schemaErrors)), items,
+		func() { gc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+
+	gc.openModal(modal)
+	return true
+}
+
+// selectedGenerators splits the schema's generators into those generate runs and
+// those skipped for this project. Both are empty if the schema can't be read.
+func (gc *GenerateController) selectedGenerators(cwd string) (enabled, skipped []string) {
+	generators, err := prisma.GetGenerators(cwd)
+	if err != nil {
+		return nil, nil
+	}
+
+	state, err := config.LoadState()
+	if err != nil {
+		return nil, nil
+	}
+	project := state.Project(cwd)
+
+	for _, gen := range generators {
+		if project.IsGeneratorSkipped(gen.Name) {
+			skipped = append(skipped, gen.Name)
+		} else {
+			enabled = append(enabled, gen.Name)
+		}
+	}
+	return enabled, skipped
+}
+
+// ToggleGenerators lists the schema's generators; selecting one toggles whether
+// generate runs it. The choice is remembered for this project.
+func (gc *GenerateController) ToggleGenerators() {
+	tr := gc.c.GetTranslationSet()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		modal := NewMessageModal(gc.g, tr, tr.ModalTitleError,
+			tr.ErrorFailedGetWorkingDirectory,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		gc.openModal(modal)
+		return
+	}
+
+	generators, err := prisma.GetGenerators(cwd)
+	if err != nil || len(generators) == 0 {
+		lines := []string{tr.ModalMsgNoGenerators}
+		if err != nil {
+			lines = append(lines, err.Error())
+		}
+		modal := NewMessageModal(gc.g, tr, tr.ModalTitleGenerators, lines...).
+			WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		gc.openModal(modal)
+		return
+	}
+
+	state, err := config.LoadState()
+	if err != nil {
+		modal := NewMessageModal(gc.g, tr, tr.ModalTitleGenerators,
+			tr.ModalMsgFailedLoadState,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		gc.openModal(modal)
+		return
+	}
+	project := state.Project(cwd)
+
+	nameWidth := 0
+	for _, gen := range generators {
+		nameWidth = max(nameWidth, len(gen.Name))
+	}
+
+	var modal *ListModal
+	var buildItems func() []ListModalItem
+	buildItems = func() []ListModalItem {
+		items := make([]ListModalItem, 0, len(generators))
+		for _, gen := range generators {
+			skipped := project.IsGeneratorSkipped(gen.Name)
+
+			check := style.Green("[x]")
+			desc := fmt.Sprintf(tr.GeneratorDescEnabled, gen.Name)
+			if skipped {
+				check = style.Gray("[ ]")
+				desc = fmt.Sprintf(tr.GeneratorDescSkipped, gen.Name)
+			}
+
+			items = append(items, ListModalItem{
+				Label:       fmt.Sprintf("%s %-*s  %s", check, nameWidth, gen.Name, style.Gray(gen.Provider)),
+				Description: desc + "\n\n" + tr.GeneratorToggleHint,
+				OnSelect: func() error {
+					project.SetGeneratorSkipped(gen.Name, !skipped)
+					if err := config.SaveState(state); err != nil {
+						gc.outputCtx.LogActionRed(tr.LogActionGenerators, tr.LogMsgFailedSaveState+" "+err.Error())
+					}
+					modal.SetItems(buildItems())
+					return nil
+				},
+			})
+		}
+		return items
+	}
+
+	modal = NewListModal(gc.g, tr, tr.ModalTitleGenerators, buildItems(),
+		func() { gc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+
+	gc.openModal(modal)
+}
@@ -0,0 +1,319 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/dokadev/lazyprisma/pkg/audit"
+	"github.com/dokadev/lazyprisma/pkg/config"
+	"github.com/dokadev/lazyprisma/pkg/gui/context"
+	"github.com/dokadev/lazyprisma/pkg/gui/style"
+	"github.com/dokadev/lazyprisma/pkg/gui/types"
+	"github.com/dokadev/lazyprisma/pkg/stats"
+	"github.com/jesseduffield/gocui"
+)
+
+// HistoryController shows the commands run this session, to copy or run again.
+type HistoryController struct {
+	c            types.IControllerHost
+	g            *gocui.Gui
+	history      *commandHistory
+	clipboard    *ClipboardController
+	openModal    func(Modal)
+	closeModal   func()
+	runStreamCmd func(AsyncCommandOpts) bool
+}
+
+// NewHistoryController creates a new HistoryController.
+func NewHistoryController(
+	c types.IControllerHost,
+	g *gocui.Gui,
+	history *commandHistory,
+	clipboard *ClipboardController,
+	openModal func(Modal),
+	closeModal func(),
+	runStreamCmd func(AsyncCommandOpts) bool,
+) *HistoryController {
+	return &HistoryController{
+		c:            c,
+		g:            g,
+		history:      history,
+		clipboard:    clipboard,
+		openModal:    openModal,
+		closeModal:   closeModal,
+		runStreamCmd: runStreamCmd,
+	}
+}
+
+// ShowHistory lists the commands run this session whose command line contains
+// filter ("" = all), newest first, led by an item to change the filter
+func (hc *HistoryController) ShowHistory(filter string) {
+	tr := hc.c.GetTranslationSet()
+
+	entries := hc.history.Entries(filter)
+	if len(entries) == 0 && filter == "" {
+		modal := NewMessageModal(hc.g, tr, tr.ModalTitleCommandHistory,
+			tr.ModalMsgCommandHistoryEmpty,
+		).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+		hc.openModal(modal)
+		return
+	}
+
+	filterLabel := tr.ListItemHistoryFilter
+	if filter != "" {
+		filterLabel = fmt.Sprintf(tr.ListItemHistoryFiltered, filter, len(entries))
+	}
+	items := []ListModalItem{{
+		Label:       style.Cyan(filterLabel),
+		Description: tr.ListItemDescHistoryFilter,
+		OnSelect: func() error {
+			hc.closeModal()
+			hc.askFilter()
+			return nil
+		},
+	}}
+
+	for _, entry := range entries {
+		var status string
+		switch {
+		case entry.ExitCode == 0:
+			status = style.Green(fmt.Sprintf("%4d", entry.ExitCode))
+		case entry.ExitCode == audit.ExitCodeNotStarted:
+			status = style.Red(fmt.Sprintf("%4s", "-"))
+		default:
+			status = style.Red(fmt.Sprintf("%4d", entry.ExitCode))
+		}
+
+		envNames := "-"
+		if names := entry.EnvNames(); len(names) > 0 {
+			envNames = strings.Join(names, ", ")
+		}
+
+		items = append(items, ListModalItem{
+			Label: fmt.Sprintf("%s %s  %s",
+				style.Gray(entry.Time.Local().Format("15:04:05")), status, entry.CommandLine()),
+			Description: fmt.Sprintf(tr.HistoryEntryDescription,
+				entry.CommandLine(),
+				entry.Time.Local().Format(time.RFC1123),
+				entry.ExitCode,
+				entry.Duration.Round(time.Millisecond),
+				envNames,
+			),
+			OnSelect: func() error {
+				hc.closeModal()
+				hc.showEntryActions(entry, filter)
+				return nil
+			},
+		})
+	}
+
+	title := tr.ModalTitleCommandHistory
+	if filter != "" {
+		title = fmt.Sprintf(tr.ModalTitleCommandHistoryFiltered, filter)
+	}
+	modal := NewListModal(hc.g, tr, title, items,
+		func() { hc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+
+	hc.openModal(modal)
+}
+
+// askFilter asks for the text the listed command lines must contain
+func (hc *HistoryController) askFilter() {
+	tr := hc.c.GetTranslationSet()
+
+	modal := NewInputModal(hc.g, tr, tr.ModalTitleHistoryFilter,
+		func(input string) {
+			hc.closeModal()
+			hc.ShowHistory(strings.TrimSpace(input))
+		},
+		func() {
+			hc.closeModal()
+			hc.ShowHistory("")
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+
+	hc.openModal(modal)
+}
+
+// showEntryActions offers to copy a command's command line or run it again
+func (hc *HistoryController) showEntryActions(entry historyEntry, filter string) {
+	tr := hc.c.GetTranslationSet()
+
+	items := []ListModalItem{
+		{
+			Label:       tr.ListItemHistoryCopy,
+			Description: fmt.Sprintf(tr.ListItemDescHistoryCopy, entry.CommandLine()),
+			OnSelect: func() error {
+				hc.closeModal()
+				hc.clipboard.CopyCommandLine(entry.CommandLine())
+				return nil
+			},
+		},
+		{
+			Label:       tr.ListItemHistoryRerun,
+			Description: tr.ListItemDescHistoryRerun,
+			OnSelect: func() error {
+				hc.closeModal()
+				hc.confirmRerun(entry)
+				return nil
+			},
+		},
+	}
+
+	modal := NewListModal(hc.g, tr, entry.CommandLine(), items,
+		func() {
+			hc.closeModal()
+			hc.ShowHistory(filter)
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+
+	hc.openModal(modal)
+}
+
+// confirmRerun asks before running a command again. A command run against a
+// protected environment requires typing the environment's name, as deploying
+// to it does.
+func (hc *HistoryController) confirmRerun(entry historyEntry) {
+	tr := hc.c.GetTranslationSet()
+
+	action := rerunAction(entry)
+	if action != "" && hc.c.RejectDisabledAction(action) {
+		return
+	}
+	switch action {
+	case config.ActionMigrateDev, config.ActionMigrateDeploy, config.ActionRunScript:
+		if hc.c.RejectOpenTransaction(entry.CommandLine()) {
+			return
+		}
+	}
+
+	message := fmt.Sprintf(tr.ModalMsgConfirmRerun, entry.CommandLine())
+	if env := hc.protectedEnvironment(entry); env != nil {
+		modal := NewInputModal(hc.g, tr, fmt.Sprintf(tr.ModalTitleRerunProtected, env.Name),
+			func(input string) {
+				hc.closeModal()
+				if strings.TrimSpace(input) != env.Name {
+					errorModal := NewMessageModal(hc.g, tr, tr.ModalTitleRerunCommand,
+						tr.ModalMsgProtectedConfirmMismatch,
+					).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+					hc.openModal(errorModal)
+					return
+				}
+				hc.rerun(entry)
+			},
+			func() {
+				hc.closeModal()
+			},
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed}).
+			WithSubtitle(message + " " + fmt.Sprintf(tr.ModalMsgTypeEnvironmentToConfirm, env.Name))
+		hc.openModal(modal)
+		return
+	}
+
+	modal := NewConfirmModal(hc.g, tr, tr.ModalTitleRerunCommand, message,
+		func() {
+			hc.closeModal()
+			hc.rerun(entry)
+		},
+		func() {
+			hc.closeModal()
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+	hc.openModal(modal)
+}
+
+// rerun starts the command again the way it was first started, with the same
+// environment overrides, and reloads the panels once it finishes
+func (hc *HistoryController) rerun(entry historyEntry) {
+	tr := hc.c.GetTranslationSet()
+
+	opts := entry.opts
+	opts.OnSuccess = func(out *context.OutputContext, cwd string) {
+		hc.c.FinishCommand()
+		hc.c.RequestRefresh(types.RefreshEverything)
+	}
+	opts.OnFailure = func(out *context.OutputContext, cwd string, exitCode int) {
+		hc.c.FinishCommand()
+		hc.c.RequestRefresh(types.RefreshEverything)
+		out.LogActionRed(tr.LogActionRerunFailed, fmt.Sprintf(tr.LogMsgRerunFailed, entry.CommandLine(), exitCode))
+		modal := NewMessageModal(hc.g, tr, tr.ModalTitleRerunFailed,
+			fmt.Sprintf(tr.LogMsgRerunFailed, entry.CommandLine(), exitCode),
+			tr.ModalMsgCheckOutputPanel,
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed}).WithViewOutput(hc.c.ShowFailedOutput)
+		hc.openModal(modal)
+	}
+	opts.OnError = func(out *context.OutputContext, cwd string, err error) {
+		// A non-zero exit is reported again through OnFailure
+		if _, isExit := err.(*exec.ExitError); isExit {
+			return
+		}
+		hc.c.FinishCommand()
+		out.LogActionRed(tr.LogActionRerunFailed, err.Error())
+		modal := NewMessageModal(hc.g, tr, tr.ModalTitleRerunFailed,
+			tr.ModalMsgFailedStartRerun,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		hc.openModal(modal)
+	}
+	opts.ErrorTitle = tr.ModalTitleRerunFailed
+	opts.ErrorStartMsg = tr.ModalMsgFailedStartRerun
+
+	hc.runStreamCmd(opts)
+}
+
+// protectedEnvironment returns the protected environment whose database URL
+// the command was run against, or nil
+func (hc *HistoryController) protectedEnvironment(entry historyEntry) *config.EnvironmentConfig {
+	if len(entry.Env) == 0 {
+		return nil
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	projectCfg, err := config.LoadProject(cwd)
+	if err != nil {
+		return nil
+	}
+
+	for _, env := range projectCfg.Environments {
+		if !env.Protected {
+			continue
+		}
+		url := env.ResolveURL(os.Getenv)
+		if url == "" {
+			continue
+		}
+		for _, kv := range entry.Env {
+			if _, value, _ := strings.Cut(kv, "="); value == url {
+				return &env
+			}
+		}
+	}
+	return nil
+}
+
+// rerunAction returns the project config action that running the command
+// again takes, so that a masked action can't be run from the history
+func rerunAction(entry historyEntry) string {
+	if entry.opts.Command != nil {
+		return config.ActionRunScript
+	}
+	switch stats.CommandAction(entry.Args) {
+	case "migrate dev":
+		return config.ActionMigrateDev
+	case "migrate deploy":
+		return config.ActionMigrateDeploy
+	case "migrate resolve":
+		return config.ActionMigrateResolve
+	case "generate":
+		return config.ActionGenerate
+	case "db execute":
+		return config.ActionRunScript
+	}
+	return ""
+}
@@ -0,0 +1,220 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dokadev/lazyprisma/pkg/gui/context"
+	"github.com/dokadev/lazyprisma/pkg/gui/style"
+	"github.com/dokadev/lazyprisma/pkg/gui/types"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+	"github.com/jesseduffield/gocui"
+)
+
+// ImpactController shows which migrations touched each table and answers
+// "blame" lookups for tables and columns.
+type ImpactController struct {
+	c             types.IControllerHost
+	g             *gocui.Gui
+	migrationsCtx *context.MigrationsContext
+	openModal     func(Modal)
+	closeModal    func()
+	focusPanel    func(viewID string)
+}
+
+// NewImpactController creates a new ImpactController.
+func NewImpactController(
+	c types.IControllerHost,
+	g *gocui.Gui,
+	migrationsCtx *context.MigrationsContext,
+	openModal func(Modal),
+	closeModal func(),
+	focusPanel func(viewID string),
+) *ImpactController {
+	return &ImpactController{
+		c:             c,
+		g:             g,
+		migrationsCtx: migrationsCtx,
+		openModal:     openModal,
+		closeModal:    closeModal,
+		focusPanel:    focusPanel,
+	}
+}
+
+// ShowImpact opens a list of tables with the chronological list of migrations
+// that touched each one. Tables changed by the selected migration are listed first.
+func (ic *ImpactController) ShowImpact() {
+	tr := ic.c.GetTranslationSet()
+
+	idx := ic.migrationsCtx.GetImpactIndex()
+	if idx == nil || len(idx.Tables()) == 0 {
+		modal := NewMessageModal(ic.g, tr, tr.ModalTitleMigrationImpact,
+			tr.ModalMsgNoTablesInMigrations,
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		ic.openModal(modal)
+		return
+	}
+
+	// Tables touched by the selected migration come first
+	var touched []string
+	touchedSet := make(map[string]bool)
+	if selected := ic.migrationsCtx.GetSelectedMigration(); selected != nil {
+		touched = idx.TablesTouchedBy(selected.Name)
+		for _, table := range touched {
+			touchedSet[table] = true
+		}
+	}
+
+	var rest []string
+	for _, table := range idx.Tables() {
+		if !touchedSet[table] {
+			rest = append(rest, table)
+		}
+	}
+
+	var items []ListModalItem
+	for _, table := range append(touched, rest...) {
+		history := idx.History(table)
+
+		marker := " "
+		if touchedSet[table] {
+			marker = "●"
+		}
+
+		var desc strings.Builder
+		desc.WriteString(fmt.Sprintf(tr.ImpactHistoryHeader, table))
+		desc.WriteString("\n\n")
+		for _, change := range history {
+			desc.WriteString(fmt.Sprintf("%-6s  %s\n", change.Op, change.Migration))
+		}
+
+		items = append(items, ListModalItem{
+			Label:       fmt.Sprintf("%s %s (%d)", marker, table, len(history)),
+			Description: strings.TrimRight(desc.String(), "\n"),
+			OnSelect: func() error {
+				ic.closeModal()
+				ic.showBlameResults(table, "", history)
+				return nil
+			},
+		})
+	}
+
+	modal := NewListModal(ic.g, tr, tr.ModalTitleMigrationImpact, items,
+		func() { ic.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+
+	ic.openModal(modal)
+}
+
+// Blame asks for a table or table.column and lists the migrations that created or changed it
+func (ic *ImpactController) Blame() {
+	tr := ic.c.GetTranslationSet()
+
+	modal := NewInputModal(ic.g, tr, tr.ModalTitleBlame,
+		func(input string) {
+			ic.closeModal()
+			ic.blame(strings.TrimSpace(input))
+		},
+		func() {
+			ic.closeModal()
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan}).
+		WithSubtitle(tr.ModalMsgBlameInputHint).
+		WithRequired(true).
+		OnValidationFail(func(reason string) {
+			ic.closeModal()
+			errorModal := NewMessageModal(ic.g, tr, tr.ModalTitleValidationFailed,
+				reason,
+			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+			ic.openModal(errorModal)
+		})
+
+	ic.openModal(modal)
+}
+
+// blame resolves a "table" or "table.column" query against the impact index
+func (ic *ImpactController) blame(query string) {
+	tr := ic.c.GetTranslationSet()
+
+	table, column := query, ""
+	if dot := strings.LastIndex(query, "."); dot != -1 {
+		table, column = query[:dot], query[dot+1:]
+	}
+	table = strings.Trim(table, "\"`")
+	column = strings.Trim(column, "\"`")
+
+	var changes []prisma.TableChange
+	if idx := ic.migrationsCtx.GetImpactIndex(); idx != nil {
+		if column != "" {
+			changes = idx.ColumnHistory(table, column)
+		} else {
+			changes = idx.History(table)
+		}
+	}
+
+	if len(changes) == 0 {
+		modal := NewMessageModal(ic.g, tr, tr.ModalTitleBlame,
+			fmt.Sprintf(tr.ModalMsgBlameNotFound, query),
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		ic.openModal(modal)
+		return
+	}
+
+	ic.showBlameResults(table, column, changes)
+}
+
+// showBlameResults lists matching changes newest first; selecting one jumps to the migration
+func (ic *ImpactController) showBlameResults(table, column string, changes []prisma.TableChange) {
+	tr := ic.c.GetTranslationSet()
+
+	target := table
+	if column != "" {
+		target = table + "." + column
+	}
+
+	var items []ListModalItem
+	for i := len(changes) - 1; i >= 0; i-- {
+		change := changes[i]
+
+		label := fmt.Sprintf("%-6s  %s", change.Op, change.Migration)
+		switch i {
+		case 0:
+			label += "  " + style.Green("("+tr.BlameTagIntroduced+")")
+		case len(changes) - 1:
+			label += "  " + style.Yellow("("+tr.BlameTagLastChange+")")
+		}
+
+		items = append(items, ListModalItem{
+			Label:       label,
+			Description: change.Statement,
+			OnSelect: func() error {
+				ic.closeModal()
+				ic.jumpToMigration(change.Migration)
+				return nil
+			},
+		})
+	}
+
+	modal := NewListModal(ic.g, tr, fmt.Sprintf(tr.ModalTitleBlameResults, target), items,
+		func() { ic.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+
+	ic.openModal(modal)
+}
+
+// jumpToMigration selects a migration in the migrations panel and focuses it
+func (ic *ImpactController) jumpToMigration(name string) {
+	tr := ic.c.GetTranslationSet()
+
+	if !ic.migrationsCtx.SelectMigrationByName(name) {
+		modal := NewMessageModal(ic.g, tr, tr.ModalTitleBlame,
+			fmt.Sprintf(tr.ModalMsgMigrationNotInList, name),
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		ic.openModal(modal)
+		return
+	}
+
+	if ic.focusPanel != nil {
+		ic.focusPanel(ic.migrationsCtx.ID())
+	}
+}
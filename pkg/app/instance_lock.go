@@ -0,0 +1,38 @@
+package app
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dokadev/lazyprisma/pkg/config"
+	"github.com/dokadev/lazyprisma/pkg/i18n"
+)
+
+// InstanceChoice is how to go on when another instance has the project open
+type InstanceChoice int
+
+const (
+	InstanceReadOnly InstanceChoice = iota // Open the project without running commands
+	InstanceContinue                       // Open it as usual
+	InstanceQuit                           // Don't open it
+)
+
+// AskInstanceChoice warns before the TUI starts that holder has the project
+// open and asks on in how to go on; read-only is the default.
+func AskInstanceChoice(tr *i18n.TranslationSet, holder *config.InstanceInfo, in io.Reader, out io.Writer) InstanceChoice {
+	fmt.Fprintf(out, tr.InstanceActive, holder.PID, holder.Host, holder.StartedAt.Format("2006-01-02 15:04:05"))
+	fmt.Fprint(out, tr.InstanceConflictRisk)
+	fmt.Fprint(out, tr.InstancePrompt)
+
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "c", "continue":
+		return InstanceContinue
+	case "q", "quit":
+		return InstanceQuit
+	default:
+		return InstanceReadOnly
+	}
+}
@@ -1,6 +1,8 @@
 package app
 
 import (
+	"github.com/dokadev/lazyprisma/pkg/config"
+	"github.com/dokadev/lazyprisma/pkg/gui/context"
 	"github.com/dokadev/lazyprisma/pkg/gui/types"
 	"github.com/jesseduffield/gocui"
 )
@@ -15,7 +17,7 @@ func (a *App) RegisterKeybindings() error {
 				return nil
 			}
 		} else {
-			return gocui.ErrQuit
+			return a.quit()
 		}
 		return nil
 	}); err != nil {
@@ -24,17 +26,20 @@ func (a *App) RegisterKeybindings() error {
 
 	// Ctrl+C to quit
 	if err := a.g.SetKeybinding("", gocui.KeyCtrlC, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
-		return gocui.ErrQuit
+		return a.quit()
 	}); err != nil {
 		return err
 	}
 
-	// ESC also closes modal
+	// ESC also closes modal, or goes back after a jump in the Schema tab
 	if err := a.g.SetKeybinding("", gocui.KeyEsc, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
 		if a.HasActiveModal() {
 			a.CloseModal()
 			return nil
 		}
+		if a.currentPanelIs(ViewDetails) {
+			a.detailsController.SchemaBack()
+		}
 		return nil
 	}); err != nil {
 		return err
@@ -129,7 +134,9 @@ func (a *App) RegisterKeybindings() error {
 		return err
 	}
 
-	// Enter key for modal
+	// Enter key for modal, jump to a field's type in the Schema tab, the
+	// Workspace panel's quick actions, the selected migration's actions, or
+	// open the Schema panel's selected entry (fold its group on a header)
 	if err := a.g.SetKeybinding("", gocui.KeyEnter, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
 		if a.HasActiveModal() {
 			// Modals that close on Enter (e.g. MessageModal) are dismissed directly
@@ -140,6 +147,24 @@ func (a *App) RegisterKeybindings() error {
 			// Other modals: pass Enter to HandleKey (InputModal, ListModal, etc.)
 			return a.activeModal.HandleKey(gocui.KeyEnter, gocui.ModNone)
 		}
+		if a.currentPanelIs(ViewDetails) {
+			a.detailsController.JumpToSchemaDefinition()
+		}
+		if a.currentPanelIs(ViewWorkspace) {
+			a.workspaceController.ShowActions()
+		}
+		if a.currentPanelIs(ViewMigrations) {
+			a.ShowMigrationActions()
+		}
+		if a.currentPanelIs(ViewSchema) {
+			if schemaCtx, ok := a.panels[ViewSchema].(*context.SchemaContext); ok {
+				if entry := schemaCtx.SelectedEntry(); entry != nil {
+					a.detailsController.ShowSchemaDefinition(entry.Line)
+				} else {
+					schemaCtx.ToggleSelectedGroup()
+				}
+			}
+		}
 		return nil
 	}); err != nil {
 		return err
@@ -180,7 +205,10 @@ func (a *App) RegisterKeybindings() error {
 		if a.HasActiveModal() {
 			return nil
 		}
-		a.RefreshAll()
+		if a.CommandInProgress() {
+			a.logRefreshQueued()
+		}
+		a.RequestRefresh(types.RefreshEverything)
 		return nil
 	}); err != nil {
 		return err
@@ -191,18 +219,24 @@ func (a *App) RegisterKeybindings() error {
 		if a.HasActiveModal() {
 			return nil
 		}
+		if a.rejectDisabledAction(config.ActionMigrateDev) {
+			return nil
+		}
 		a.migrationsController.MigrateDev()
 		return nil
 	}); err != nil {
 		return err
 	}
 
-	// 'D' key - migrate deploy
+	// 'D' key - deploy menu (migrate deploy, simulate deploy)
 	if err := a.g.SetKeybinding("", 'D', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
 		if a.HasActiveModal() {
 			return nil
 		}
-		a.migrationsController.MigrateDeploy()
+		if a.rejectDisabledAction(config.ActionMigrateDeploy) {
+			return nil
+		}
+		a.migrationsController.DeployMenu()
 		return nil
 	}); err != nil {
 		return err
@@ -213,6 +247,9 @@ func (a *App) RegisterKeybindings() error {
 		if a.HasActiveModal() {
 			return nil
 		}
+		if a.rejectDisabledAction(config.ActionGenerate) {
+			return nil
+		}
 		a.generateController.Generate()
 		return nil
 	}); err != nil {
@@ -224,6 +261,9 @@ func (a *App) RegisterKeybindings() error {
 		if a.HasActiveModal() {
 			return nil
 		}
+		if a.rejectDisabledAction(config.ActionMigrateResolve) {
+			return nil
+		}
 		a.migrationsController.MigrateResolve()
 		return nil
 	}); err != nil {
@@ -235,6 +275,9 @@ func (a *App) RegisterKeybindings() error {
 		if a.HasActiveModal() {
 			return nil
 		}
+		if a.rejectDisabledAction(config.ActionStudio) {
+			return nil
+		}
 		a.studioController.Studio()
 		return nil
 	}); err != nil {
@@ -252,11 +295,420 @@ func (a *App) RegisterKeybindings() error {
 		return err
 	}
 
+	// 'm' key - reveal / mask the database URL in workspace panel
+	if err := a.g.SetKeybinding("", 'm', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		a.toggleURLMask()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'M' key - rename or remove the migrations a git merge duplicated or reordered
+	if err := a.g.SetKeybinding("", 'M', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		// Fix Merge removes and renames migration folders
+		if a.rejectDisabledAction(config.ActionDeleteMigration) {
+			return nil
+		}
+		a.migrationsController.FixMerge()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'C' key - copy the focused panel's content
+	if err := a.g.SetKeybinding("", 'C', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		if panel := a.GetCurrentPanel(); panel != nil {
+			if copyablePanel, ok := panel.(types.ICopyableContext); ok {
+				a.clipboardController.CopyPanelContent(copyablePanel)
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'i' key - show table impact of migrations
+	if err := a.g.SetKeybinding("", 'i', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		a.impactController.ShowImpact()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'T' key - peek at the data of the tables the selected migration creates
+	if err := a.g.SetKeybinding("", 'T', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		a.peekController.PeekData()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'O' key - open the project in a GUI editor or the file manager
+	if err := a.g.SetKeybinding("", 'O', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		a.workspaceController.OpenProject()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'Q' key - SQL console
+	if err := a.g.SetKeybinding("", 'Q', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		a.consoleController.OpenConsole()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'b' key - blame a table or column
+	if err := a.g.SetKeybinding("", 'b', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		a.impactController.Blame()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'f' key - toggle formatted SQL in details panel
+	if err := a.g.SetKeybinding("", 'f', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		a.detailsController.ToggleSQLFormat()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'h' key - cycle SQL highlighting in details panel
+	if err := a.g.SetKeybinding("", 'h', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		a.detailsController.CycleSQLHighlight()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// ':' key - go to a line in details panel
+	if err := a.g.SetKeybinding("", ':', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		a.detailsController.ShowGoToLine()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'F' key - write formatted SQL to the selected pending migration
+	if err := a.g.SetKeybinding("", 'F', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		if a.rejectDisabledAction(config.ActionSaveFormattedSQL) {
+			return nil
+		}
+		a.detailsController.SaveFormattedSQL()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'H' key - schema.prisma history
+	if err := a.g.SetKeybinding("", 'H', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		a.detailsController.ShowSchemaHistory()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'B' key - compare migrations with another branch
+	if err := a.g.SetKeybinding("", 'B', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		a.branchController.CompareBranches()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'E' key - compare migration status across configured environments
+	if err := a.g.SetKeybinding("", 'E', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		a.envController.CompareEnvironments()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'A' key - view the audit trail of executed commands
+	if err := a.g.SetKeybinding("", 'A', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		a.auditController.ShowAuditLog()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'R' key - browse the commands run this session, to copy or run one again
+	if err := a.g.SetKeybinding("", 'R', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		a.historyController.ShowHistory("")
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'U' key - show local usage statistics
+	if err := a.g.SetKeybinding("", 'U', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		a.statsController.ShowStats()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// '!' key - run the doctor health check
+	if err := a.g.SetKeybinding("", '!', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		a.doctorController.RunDoctor()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'e' key - show the PRISMA_* environment overrides
+	if err := a.g.SetKeybinding("", 'e', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		a.doctorController.ShowEngineOverrides()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'G' key - choose which generators generate runs
+	if err := a.g.SetKeybinding("", 'G', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		a.generateController.ToggleGenerators()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'x' key - list and run maintenance scripts
+	if err := a.g.SetKeybinding("", 'x', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		if a.rejectDisabledAction(config.ActionRunScript) {
+			return nil
+		}
+		a.scriptsController.ShowScripts()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'w' key - search migrations for the model under the Schema tab's cursor
+	if err := a.g.SetKeybinding("", 'w', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		a.detailsController.ShowModelUsage()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'K' key - compare the indexes and foreign keys of the model under the
+	// Schema tab's cursor with the database
+	if err := a.g.SetKeybinding("", 'K', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		a.detailsController.ShowModelKeys()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'p' key - preview the SQL of the next migration
+	if err := a.g.SetKeybinding("", 'p', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		a.detailsController.PreviewMigrationSQL()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'I' key - introspect the database and preview the schema db pull would write
+	if err := a.g.SetKeybinding("", 'I', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		a.detailsController.Introspect()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'P' key - split the previewed migration into several migrations
+	if err := a.g.SetKeybinding("", 'P', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		if a.rejectDisabledAction(config.ActionMigrateDev) {
+			return nil
+		}
+		a.migrationsController.SplitMigration()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'v' key - review the selected pending migration statement by statement
+	if err := a.g.SetKeybinding("", 'v', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		a.reviewController.ReviewMigration()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'V' key - verify every applied migration's checksum against the database
+	if err := a.g.SetKeybinding("", 'V', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		a.migrationsController.VerifyChecksums()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'X' key - delete the selected migration's _prisma_migrations row
+	if err := a.g.SetKeybinding("", 'X', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		a.migrationsController.DeleteHistoryRow()
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'W' key - toggle wrapping / truncating long lines in the focused panel
+	if err := a.g.SetKeybinding("", 'W', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		if panel := a.GetCurrentPanel(); panel != nil {
+			if wrappablePanel, ok := panel.(types.IWrappableContext); ok {
+				wrappablePanel.ToggleWrap()
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// 'z' key - show or hide the output of finished commands in the Output panel
+	if err := a.g.SetKeybinding("", 'z', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		if out, ok := a.panels[ViewOutputs].(*context.OutputContext); ok {
+			out.ToggleFolds()
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// '<' key - scroll truncated lines to the left
+	if err := a.g.SetKeybinding("", '<', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		if panel := a.GetCurrentPanel(); panel != nil {
+			if wrappablePanel, ok := panel.(types.IWrappableContext); ok {
+				wrappablePanel.ScrollLeft()
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// '>' key - scroll truncated lines to the right
+	if err := a.g.SetKeybinding("", '>', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return nil
+		}
+		if panel := a.GetCurrentPanel(); panel != nil {
+			if wrappablePanel, ok := panel.(types.IWrappableContext); ok {
+				wrappablePanel.ScrollRight()
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
 	// Delete key - delete pending migration
 	deleteHandler := func(g *gocui.Gui, v *gocui.View) error {
 		if a.HasActiveModal() {
 			return nil
 		}
+		if a.rejectDisabledAction(config.ActionDeleteMigration) {
+			return nil
+		}
 		a.migrationsController.DeleteMigration()
 		return nil
 	}
@@ -282,6 +734,16 @@ func (a *App) RegisterKeybindings() error {
 		return err
 	}
 
+	// 'o' key - pass to MessageModal to view the failed command's output
+	if err := a.g.SetKeybinding("", 'o', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		if a.HasActiveModal() {
+			return a.activeModal.HandleKey('o', gocui.ModNone)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
 	// 'n' key - pass to ConfirmModal for No
 	if err := a.g.SetKeybinding("", 'n', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
 		if a.HasActiveModal() {
@@ -1,11 +1,16 @@
 package app
 
 import (
+	"time"
+
 	"github.com/jesseduffield/gocui"
 	"github.com/jesseduffield/lazycore/pkg/boxlayout"
 )
 
 func (a *App) layoutManager(g *gocui.Gui) error {
+	frameStart := time.Now()
+	defer func() { a.drawProfiler.RecordFrame(time.Since(frameStart)) }()
+
 	width, height := g.Size()
 
 	root := &boxlayout.Box{
@@ -25,6 +30,10 @@ func (a *App) layoutManager(g *gocui.Gui) error {
 							},
 							{
 								Window: ViewMigrations,
+								Weight: 2,
+							},
+							{
+								Window: ViewSchema,
 								Weight: 1,
 							},
 						},
@@ -58,15 +67,18 @@ func (a *App) layoutManager(g *gocui.Gui) error {
 	// Render each panel
 	for id, dim := range dimensionMap {
 		if panel, ok := a.panels[id]; ok {
+			drawStart := time.Now()
 			if err := panel.Draw(dim); err != nil {
 				return err
 			}
+			a.drawProfiler.RecordDraw(id, time.Since(drawStart))
 		}
 	}
 
 	// Render modal if active (modal is rendered on top of panels)
 	if a.activeModal != nil {
 		// Modal uses full screen dimensions for positioning
+		drawStart := time.Now()
 		if err := a.activeModal.Draw(boxlayout.Dimensions{
 			X0: 0,
 			Y0: 0,
@@ -75,12 +87,19 @@ func (a *App) layoutManager(g *gocui.Gui) error {
 		}); err != nil {
 			return err
 		}
+		a.drawProfiler.RecordDraw(a.activeModal.ID(), time.Since(drawStart))
 
 		// Set focus to modal
 		_, err := g.SetCurrentView(a.activeModal.ID())
 		if err != nil && err.Error() != "unknown view" {
 			// Ignore "unknown view" error
 		}
+		// Modals without a view of their ID (e.g. ListModal) get keys through
+		// the global bindings; keep them from going to the view of a modal
+		// closed before, e.g. the editor of an InputModal
+		if err != nil && len(a.focusOrder) > 0 && a.currentFocus < len(a.focusOrder) {
+			_, _ = g.SetCurrentView(a.focusOrder[a.currentFocus])
+		}
 	} else {
 		// Set current view for keyboard input (after views are created)
 		// This ensures gocui can route keyboard events properly
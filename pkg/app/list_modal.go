@@ -45,6 +45,22 @@ func (m *ListModal) WithStyle(style MessageModalStyle) *ListModal {
 	return m
 }
 
+// SetItems replaces the items (e.g. after toggling one), keeping the selection in range
+func (m *ListModal) SetItems(items []ListModalItem) {
+	m.items = items
+	if m.selectedIdx >= len(items) {
+		m.selectedIdx = max(len(items)-1, 0)
+	}
+	m.g.Update(func(g *gocui.Gui) error {
+		return nil
+	})
+}
+
+// SetTitle replaces the title (e.g. to show progress after toggling an item)
+func (m *ListModal) SetTitle(title string) {
+	m.title = title
+}
+
 // listViewID returns the list view ID
 func (m *ListModal) listViewID() string {
 	return "list_modal_list"
@@ -22,6 +22,7 @@ type MessageModal struct {
 	lines        []string // Wrapped content lines
 	width        int
 	height       int
+	onViewOutput func() // Run by 'o' (nil = no output to view)
 }
 
 // NewMessageModal creates a new message modal
@@ -39,6 +40,13 @@ func (m *MessageModal) WithStyle(style MessageModalStyle) *MessageModal {
 	return m
 }
 
+// WithViewOutput lets 'o' run onViewOutput, e.g. to show the output of the
+// command that failed
+func (m *MessageModal) WithViewOutput(onViewOutput func()) *MessageModal {
+	m.onViewOutput = onViewOutput
+	return m
+}
+
 // ClosesOnEnter returns true because MessageModal is dismissed with Enter.
 func (m *MessageModal) ClosesOnEnter() bool { return true }
 
@@ -56,8 +64,13 @@ func (m *MessageModal) Draw(dim boxlayout.Dimensions) error {
 	// Center the modal
 	x0, y0, x1, y1 := m.CenterBox(m.width, m.height)
 
+	footer := m.tr.ModalFooterMessageClose
+	if m.onViewOutput != nil {
+		footer = m.tr.ModalFooterMessageViewOutput
+	}
+
 	// Create modal view
-	v, _, err := m.SetupView(m.ID(), x0, y0, x1, y1, 0, " "+m.title+" ", m.tr.ModalFooterMessageClose)
+	v, _, err := m.SetupView(m.ID(), x0, y0, x1, y1, 0, " "+m.title+" ", footer)
 	if err != nil {
 		return err
 	}
@@ -98,6 +111,9 @@ func (m *MessageModal) HandleKey(key any, mod gocui.Modifier) error {
 		// Modal will be closed by App
 		return nil
 	}
+	if key == 'o' && m.onViewOutput != nil {
+		m.onViewOutput()
+	}
 
 	return nil
 }
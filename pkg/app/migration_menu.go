@@ -0,0 +1,164 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dokadev/lazyprisma/pkg/config"
+	"github.com/dokadev/lazyprisma/pkg/gui/context"
+	"github.com/dokadev/lazyprisma/pkg/gui/types"
+)
+
+// ShowMigrationActions opens the menu of what can be done with the selected
+// migration in its state, so each action doesn't need its key remembered.
+// Every item does the same as the key its description names.
+func (a *App) ShowMigrationActions() {
+	migrationsCtx, ok := a.panels[ViewMigrations].(*context.MigrationsContext)
+	if !ok {
+		return
+	}
+	selected := migrationsCtx.GetSelectedMigration()
+	if selected == nil {
+		return
+	}
+	mig := *selected
+	tr := a.Tr
+
+	connected := migrationsCtx.IsDBConnected()
+	applied := mig.AppliedAt != nil
+	local := mig.Path != ""
+	pending := false
+	for _, m := range migrationsCtx.GetCategory().Pending {
+		if m.Name == mig.Name {
+			pending = true
+			break
+		}
+	}
+
+	// run closes the menu before the action, which may open a modal of its own
+	run := func(action func()) func() error {
+		return func() error {
+			a.CloseModal()
+			action()
+			return nil
+		}
+	}
+
+	items := []ListModalItem{
+		{
+			Label:       tr.ListItemViewMigration,
+			Description: tr.ListItemDescViewMigration,
+			OnSelect: run(func() {
+				if detailsCtx, ok := a.panels[ViewDetails].(*context.DetailsContext); ok {
+					detailsCtx.ShowDetailsTab()
+				}
+				a.focusPanel(ViewDetails)
+			}),
+		},
+	}
+
+	if local {
+		cwd, _ := os.Getwd()
+		sqlPath := filepath.Join(mig.Path, "migration.sql")
+		description := fmt.Sprintf(tr.ListItemDescEditMigrationSQL, relativePath(cwd, sqlPath))
+		if applied {
+			description += "\n\n" + tr.ListItemDescEditAppliedSQL
+		}
+		items = append(items, ListModalItem{
+			Label:       tr.ListItemEditMigrationSQL,
+			Description: description,
+			OnSelect:    run(func() { a.editMigrationFile(sqlPath) }),
+		})
+
+		if mig.HasDownSQL {
+			downPath := filepath.Join(mig.Path, "down.sql")
+			items = append(items, ListModalItem{
+				Label:       tr.ListItemEditDownSQL,
+				Description: fmt.Sprintf(tr.ListItemDescEditDownSQL, relativePath(cwd, downPath)),
+				OnSelect:    run(func() { a.editMigrationFile(downPath) }),
+			})
+		}
+
+		if applied {
+			items = append(items, ListModalItem{
+				Label:       tr.ListItemPeekMigrationData,
+				Description: tr.ListItemDescPeekMigrationData,
+				OnSelect:    run(a.peekController.PeekData),
+			})
+		} else {
+			items = append(items, ListModalItem{
+				Label:       tr.ListItemReviewMigration,
+				Description: tr.ListItemDescReviewMigration,
+				OnSelect:    run(a.reviewController.ReviewMigration),
+			})
+		}
+	}
+
+	// migrate resolve picks the fix for the migration's state
+	resolve := run(func() {
+		if !a.rejectDisabledAction(config.ActionMigrateResolve) {
+			a.migrationsController.MigrateResolve()
+		}
+	})
+	switch {
+	case mig.IsFailed:
+		items = append(items, ListModalItem{
+			Label:       tr.ListItemResolveFailed,
+			Description: tr.ListItemDescResolveFailed,
+			OnSelect:    resolve,
+		})
+	case mig.ChecksumMismatch:
+		items = append(items, ListModalItem{
+			Label:       tr.ListItemFixChecksum,
+			Description: tr.ListItemDescFixChecksum,
+			OnSelect:    resolve,
+		})
+	}
+
+	if local && !(connected && applied) {
+		items = append(items, ListModalItem{
+			Label:       tr.ListItemDeleteMigration,
+			Description: tr.ListItemDescDeleteMigration,
+			OnSelect: run(func() {
+				if !a.rejectDisabledAction(config.ActionDeleteMigration) {
+					a.migrationsController.DeleteMigration()
+				}
+			}),
+		})
+	}
+	if connected && !pending {
+		items = append(items, ListModalItem{
+			Label:       tr.ListItemDeleteHistoryRow,
+			Description: tr.ListItemDescRemoveHistoryRow,
+			OnSelect:    run(a.migrationsController.DeleteHistoryRow),
+		})
+	}
+
+	items = append(items, ListModalItem{
+		Label:       tr.ListItemCopyMigration,
+		Description: tr.ListItemDescCopyMigration,
+		OnSelect:    run(a.clipboardController.CopyMigrationInfo),
+	})
+
+	modal := NewListModal(a.g, tr, fmt.Sprintf(tr.ModalTitleMigrationActions, mig.Name), items,
+		func() { a.CloseModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+	a.OpenModal(modal)
+}
+
+// editMigrationFile opens a file of a migration in the user's editor and
+// reloads the migrations once it exits, e.g. to pick up a changed checksum
+func (a *App) editMigrationFile(path string) {
+	if err := OpenInEditor(a.g, path); err != nil {
+		modal := NewMessageModal(a.g, a.Tr, a.Tr.ModalTitleEditorFailed,
+			fmt.Sprintf(a.Tr.ModalMsgFailedOpenEditor, filepath.Base(path)),
+			err.Error(),
+			a.Tr.ModalMsgSetEditor,
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		a.OpenModal(modal)
+		return
+	}
+
+	a.RequestRefresh(types.RefreshMigrations)
+}
@@ -1,13 +1,23 @@
 package app
 
 import (
+	"database/sql"
 	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/dokadev/lazyprisma/pkg/config"
+	"github.com/dokadev/lazyprisma/pkg/database"
+	"github.com/dokadev/lazyprisma/pkg/drift"
+	"github.com/dokadev/lazyprisma/pkg/git"
 	"github.com/dokadev/lazyprisma/pkg/gui/context"
+	"github.com/dokadev/lazyprisma/pkg/gui/style"
 	"github.com/dokadev/lazyprisma/pkg/gui/types"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+	"github.com/dokadev/lazyprisma/pkg/simulate"
 	"github.com/jesseduffield/gocui"
 )
 
@@ -17,9 +27,12 @@ type MigrationsController struct {
 	g             *gocui.Gui
 	migrationsCtx *context.MigrationsContext
 	outputCtx     *context.OutputContext
+	detailsCtx    *context.DetailsContext
 	openModal     func(Modal)
 	closeModal    func()
 	runStreamCmd  func(AsyncCommandOpts) bool
+
+	deployCountdown time.Duration // Length of the "Deploy after countdown" option (0 = hidden)
 }
 
 // NewMigrationsController creates a new MigrationsController.
@@ -28,18 +41,23 @@ func NewMigrationsController(
 	g *gocui.Gui,
 	migrationsCtx *context.MigrationsContext,
 	outputCtx *context.OutputContext,
+	detailsCtx *context.DetailsContext,
 	openModal func(Modal),
 	closeModal func(),
 	runStreamCmd func(AsyncCommandOpts) bool,
+	deployCountdown time.Duration,
 ) *MigrationsController {
 	return &MigrationsController{
 		c:             c,
 		g:             g,
 		migrationsCtx: migrationsCtx,
 		outputCtx:     outputCtx,
+		detailsCtx:    detailsCtx,
 		openModal:     openModal,
 		closeModal:    closeModal,
 		runStreamCmd:  runStreamCmd,
+
+		deployCountdown: deployCountdown,
 	}
 }
 
@@ -47,6 +65,15 @@ func NewMigrationsController(
 func (mc *MigrationsController) MigrateDeploy() {
 	tr := mc.c.GetTranslationSet()
 
+	if edge := mc.migrationsCtx.EdgeDatabase(); edge != nil {
+		mc.showEdgeDeploy(edge)
+		return
+	}
+
+	if mc.c.RejectOpenTransaction("Migrate Deploy") {
+		return
+	}
+
 	// Try to start command - if another command is running, block
 	if !mc.c.TryStartCommand("Migrate Deploy") {
 		mc.c.LogCommandBlocked("Migrate Deploy")
@@ -56,7 +83,7 @@ func (mc *MigrationsController) MigrateDeploy() {
 	// Run everything in background to avoid blocking UI during refresh/checks
 	go func() {
 		// 1. Refresh first to ensure DB connection is current
-		mc.c.RefreshPanels()
+		mc.c.RefreshPanels(types.RefreshMigrations)
 
 		// 2. Check DB connection
 		if !mc.migrationsCtx.IsDBConnected() {
@@ -73,18 +100,23 @@ func (mc *MigrationsController) MigrateDeploy() {
 		}
 
 		// Pre-flight checks passed -- run the streaming command
+		mc.c.TrackDeployProgress(len(mc.migrationsCtx.GetCategory().Pending))
+		var output outputCapture
+		start := time.Now()
 		mc.runStreamCmd(AsyncCommandOpts{
 			Name:          "Migrate Deploy",
 			SkipTryStart:  true, // already called above
-			Args:          []string{"npx", "prisma", "migrate", "deploy"},
+			OnOutput:      output.Add,
 			LogAction:     tr.LogActionMigrateDeploy,
 			LogDetail:     tr.LogMsgRunningMigrateDeploy,
 			ErrorTitle:    tr.ModalTitleMigrateDeployError,
 			ErrorStartMsg: tr.ModalMsgFailedStartMigrateDeploy,
+			PrismaArgs:    prisma.MigrateDeployArgs(),
 			OnSuccess: func(out *context.OutputContext, cwd string) {
 				mc.c.FinishCommand()
 				out.LogAction(tr.LogActionMigrateDeployComplete, tr.LogMsgMigrationsAppliedSuccess)
-				mc.c.RefreshAll()
+				mc.c.NotifyWebhooks(deploySummary("", nil, output.String(), 0, time.Since(start)))
+				mc.c.RequestRefresh(types.RefreshMigrations)
 				modal := NewMessageModal(mc.g, tr, tr.ModalTitleMigrateDeploySuccess,
 					tr.ModalMsgMigrationsAppliedSuccess,
 				).WithStyle(MessageModalStyle{TitleColor: ColorGreen, BorderColor: ColorGreen})
@@ -93,11 +125,15 @@ func (mc *MigrationsController) MigrateDeploy() {
 			OnFailure: func(out *context.OutputContext, cwd string, exitCode int) {
 				mc.c.FinishCommand()
 				out.LogAction(tr.LogActionMigrateDeployFailed, fmt.Sprintf(tr.LogMsgMigrateDeployFailedCode, exitCode))
-				mc.c.RefreshAll()
+				mc.c.NotifyWebhooks(deploySummary("", nil, output.String(), exitCode, time.Since(start)))
+				if mc.offerResolveForFailure(out, output.String()) {
+					return
+				}
+				mc.c.RequestRefresh(types.RefreshMigrations)
 				modal := NewMessageModal(mc.g, tr, tr.ModalTitleMigrateDeployFailed,
 					fmt.Sprintf(tr.ModalMsgMigrateDeployFailedWithCode, exitCode),
 					tr.ModalMsgCheckOutputPanel,
-				).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+				).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed}).WithViewOutput(mc.c.ShowFailedOutput)
 				mc.openModal(modal)
 			},
 			OnError: func(out *context.OutputContext, cwd string, err error) {
@@ -113,76 +149,260 @@ func (mc *MigrationsController) MigrateDeploy() {
 	}()
 }
 
-// MigrateDev opens a list modal to choose migration type
-func (mc *MigrationsController) MigrateDev() {
+// DeployMenu opens the deploy options: deploy the pending migrations now,
+// after a countdown or at a scheduled time, or simulate deploying them on a
+// scratch database
+func (mc *MigrationsController) DeployMenu() {
 	tr := mc.c.GetTranslationSet()
 
+	if edge := mc.migrationsCtx.EdgeDatabase(); edge != nil {
+		mc.showEdgeDeploy(edge)
+		return
+	}
+
 	items := []ListModalItem{
 		{
-			Label:       tr.ListItemSchemaDiffMigration,
-			Description: tr.ListItemDescSchemaDiffMigration,
+			Label:       tr.ListItemDeploy,
+			Description: tr.ListItemDescDeploy,
 			OnSelect: func() error {
 				mc.closeModal()
-				mc.SchemaDiffMigration()
+				mc.MigrateDeploy()
 				return nil
 			},
 		},
-		{
-			Label:       tr.ListItemManualMigration,
-			Description: tr.ListItemDescManualMigration,
+	}
+
+	// Pending migrations that rely on an extension the database lacks fail
+	// half-way; offer to create it first
+	if missing := mc.missingExtensions(); len(missing) > 0 {
+		items = append(mc.extensionItems(missing), items...)
+	}
+
+	if mc.deployCountdown > 0 {
+		items = append(items, ListModalItem{
+			Label:       fmt.Sprintf(tr.ListItemDeployCountdown, mc.deployCountdown),
+			Description: tr.ListItemDescDeployCountdown,
 			OnSelect: func() error {
 				mc.closeModal()
-				mc.showManualMigrationInput()
+				mc.DeployAt(time.Now().Add(mc.deployCountdown), false)
+				return nil
+			},
+		})
+	}
+	items = append(items,
+		ListModalItem{
+			Label:       tr.ListItemScheduleDeploy,
+			Description: tr.ListItemDescScheduleDeploy,
+			OnSelect: func() error {
+				mc.closeModal()
+				mc.showDeployTimeInput()
 				return nil
 			},
 		},
+	)
+	// Simulating needs a scratch database, which PlanetScale can't create
+	if !mc.onPlanetScale() {
+		items = append(items, ListModalItem{
+			Label:       tr.ListItemSimulateDeploy,
+			Description: tr.ListItemDescSimulateDeploy,
+			OnSelect: func() error {
+				mc.closeModal()
+				mc.SimulateDeploy()
+				return nil
+			},
+		})
 	}
+	items = append(items,
+		ListModalItem{
+			Label:       tr.ListItemExportPendingSQL,
+			Description: tr.ListItemDescExportPendingSQL,
+			OnSelect: func() error {
+				mc.closeModal()
+				mc.ExportPendingSQL()
+				return nil
+			},
+		},
+	)
 
-	modal := NewListModal(mc.g, tr, tr.ModalTitleMigrateDev, items,
+	modal := NewListModal(mc.g, tr, tr.ModalTitleDeploy, items,
 		func() {
 			mc.closeModal()
 		},
 	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+	mc.openModal(modal)
+}
+
+// showEdgeDeploy explains that migrations to an edge database are applied
+// with its own CLI, and how to apply the selected one
+func (mc *MigrationsController) showEdgeDeploy(edge *prisma.EdgeDatabase) {
+	tr := mc.c.GetTranslationSet()
+
+	lines := []string{fmt.Sprintf(tr.ModalMsgEdgeDeploy, edge.Adapter.Name, edge.CLI)}
+	if selected := mc.migrationsCtx.GetSelectedMigration(); selected != nil && selected.Path != "" {
+		sqlPath := filepath.Join(selected.Path, "migration.sql")
+		if cwd, err := os.Getwd(); err == nil {
+			sqlPath = relativePath(cwd, sqlPath)
+		}
+		lines = append(lines, "", tr.ModalMsgEdgeApplySelected, style.Cyan(edge.ApplyCommand(sqlPath)))
+	}
+	if edge.CLI == "wrangler" {
+		database := edge.Database
+		if database == "" {
+			database = "<database>"
+		}
+		lines = append(lines, "", tr.ModalMsgEdgeD1Migrations, style.Cyan("npx wrangler d1 migrations apply "+database+" --remote"))
+	}
 
+	modal := NewMessageModal(mc.g, tr, fmt.Sprintf(tr.ModalTitleEdgeDeploy, edge.Adapter.Name), lines...).
+		WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
 	mc.openModal(modal)
 }
 
-// executeCreateMigration runs npx prisma migrate dev --name <name> --create-only
-func (mc *MigrationsController) executeCreateMigration(migrationName string) {
+// onPlanetScale reports whether the project's database is on PlanetScale,
+// where databases can't be created over a connection
+func (mc *MigrationsController) onPlanetScale() bool {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return false
+	}
+	ds, err := prisma.GetDatasource(cwd)
+	if err != nil {
+		return false
+	}
+	return prisma.IsPlanetScale(cwd, ds.URL)
+}
+
+// missingExtensions returns the extensions pending migrations use that the
+// database doesn't have (only known for PostgreSQL)
+func (mc *MigrationsController) missingExtensions() []prisma.MigrationExtensionUse {
+	installed, ok := mc.migrationsCtx.InstalledExtensions()
+	if !ok {
+		return nil
+	}
+	return prisma.MissingMigrationExtensions(mc.migrationsCtx.GetCategory().Pending, installed)
+}
+
+// extensionItems offers to create the missing extensions in a migration of
+// their own, applied with the next deploy or right away
+func (mc *MigrationsController) extensionItems(missing []prisma.MigrationExtensionUse) []ListModalItem {
+	tr := mc.c.GetTranslationSet()
+
+	names := make([]string, len(missing))
+	var details []string
+	for i, use := range missing {
+		names[i] = use.Name
+		details = append(details, fmt.Sprintf(tr.ListItemDescMissingExtension, use.Name, use.Migration.Name), "  "+style.Gray(use.Reason))
+	}
+	list := strings.Join(names, ", ")
+	summary := strings.Join(details, "\n")
+
+	items := []ListModalItem{
+		{
+			Label:       fmt.Sprintf(tr.ListItemCreateExtMigration, list),
+			Description: summary + "\n\n" + fmt.Sprintf(tr.ListItemDescCreateExtMigration, missing[0].Migration.Name),
+			OnSelect: func() error {
+				mc.closeModal()
+				mc.createExtensionMigration(missing, false)
+				return nil
+			},
+		},
+	}
+	if !mc.c.IsActionDisabled(config.ActionCreateExtension) {
+		items = append(items, ListModalItem{
+			Label:       fmt.Sprintf(tr.ListItemCreateExtNow, list),
+			Description: summary + "\n\n" + tr.ListItemDescCreateExtNow,
+			OnSelect: func() error {
+				mc.closeModal()
+				mc.createExtensionMigration(missing, true)
+				return nil
+			},
+		})
+	}
+	return items
+}
+
+// createExtensionMigration writes a migration that creates the missing
+// extensions, timestamped just before the first migration that needs one so
+// deploy applies it first. With execute, it is run right away with db execute
+// and marked as applied, so it still shows up in the migration history.
+func (mc *MigrationsController) createExtensionMigration(missing []prisma.MigrationExtensionUse, execute bool) {
 	tr := mc.c.GetTranslationSet()
 
+	if execute && (mc.c.RejectDisabledAction(config.ActionCreateExtension) || mc.c.RejectOpenTransaction("Create Extensions")) {
+		return
+	}
+
+	names := make([]string, len(missing))
+	for i, use := range missing {
+		names[i] = use.Name
+	}
+
+	at := time.Now()
+	first := missing[0].Migration.Name
+	if len(first) > 14 {
+		if t, err := time.Parse("20060102150405", first[:14]); err == nil {
+			at = t.Add(-time.Second)
+		}
+	}
+
+	folderName, migrationFolder, ok := mc.writeMigrationFolderAt(at, "create_extensions", func(string) string {
+		return prisma.CreateExtensionsSQL(names)
+	})
+	if !ok {
+		return
+	}
+
+	if !execute {
+		mc.c.RequestRefresh(types.RefreshMigrations)
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleMigrationCreated,
+			fmt.Sprintf(tr.ModalMsgManualMigrationCreated, folderName),
+			fmt.Sprintf(tr.ModalMsgManualMigrationLocation, migrationFolder),
+			"",
+			fmt.Sprintf(tr.ModalMsgExtensionMigrationDeploy, first),
+		).WithStyle(MessageModalStyle{TitleColor: ColorGreen, BorderColor: ColorGreen})
+		mc.openModal(modal)
+		return
+	}
+
+	list := strings.Join(names, ", ")
+	sqlPath := filepath.Join(migrationFolder, "migration.sql")
+	cwd, _ := os.Getwd()
+
 	mc.runStreamCmd(AsyncCommandOpts{
-		Name:          "Create Migration",
-		Args:          []string{"npx", "prisma", "migrate", "dev", "--name", migrationName, "--create-only"},
-		LogAction:     tr.LogActionMigrateDev,
-		LogDetail:     fmt.Sprintf(tr.LogMsgCreatingMigration, migrationName),
-		ErrorTitle:    tr.ModalTitleMigrationError,
-		ErrorStartMsg: tr.ModalMsgFailedStartMigrateDeploy,
+		Name:          "Create Extensions",
+		LogAction:     tr.LogActionCreateExtensions,
+		LogDetail:     fmt.Sprintf(tr.LogMsgCreatingExtensions, list),
+		ErrorTitle:    tr.ModalTitleCreateExtensionsFailed,
+		ErrorStartMsg: tr.ModalMsgFailedStartCreateExts,
+		PrismaArgs:    prisma.DBExecuteArgs(sqlPath, prisma.SchemaArg(cwd)),
 		OnSuccess: func(out *context.OutputContext, cwd string) {
 			mc.c.FinishCommand()
-			mc.c.RefreshAll()
-			out.LogAction(tr.LogActionMigrateComplete, tr.LogMsgMigrationCreatedSuccess)
-			modal := NewMessageModal(mc.g, tr, tr.ModalTitleMigrationCreated,
-				fmt.Sprintf(tr.ModalMsgMigrationCreatedSuccess, migrationName),
-				tr.ModalMsgMigrationCreatedDetail,
-			).WithStyle(MessageModalStyle{TitleColor: ColorGreen, BorderColor: ColorGreen})
-			mc.openModal(modal)
+			out.LogAction(tr.LogActionCreateExtensions, fmt.Sprintf(tr.LogMsgExtensionsCreated, list))
+			mc.markExecutedMigrationApplied(folderName, tr.ModalTitleExtensionsCreated,
+				fmt.Sprintf(tr.ModalMsgExtensionsCreated, list, folderName),
+			)
 		},
 		OnFailure: func(out *context.OutputContext, cwd string, exitCode int) {
 			mc.c.FinishCommand()
-			mc.c.RefreshAll()
-			out.LogAction(tr.LogActionMigrateFailed, fmt.Sprintf(tr.LogMsgMigrationCreationFailedCode, exitCode))
-			modal := NewMessageModal(mc.g, tr, tr.ModalTitleMigrationFailed,
-				fmt.Sprintf(tr.ModalMsgMigrationFailedWithCode, exitCode),
+			mc.c.RequestRefresh(types.RefreshMigrations)
+			out.LogActionRed(tr.LogActionCreateExtensions, fmt.Sprintf(tr.ModalMsgCreateExtensionsFailed, list, exitCode))
+			modal := NewMessageModal(mc.g, tr, tr.ModalTitleCreateExtensionsFailed,
+				fmt.Sprintf(tr.ModalMsgCreateExtensionsFailed, list, exitCode),
+				fmt.Sprintf(tr.ModalMsgExtensionMigrationKept, folderName),
 				tr.ModalMsgCheckOutputPanel,
-			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed}).WithViewOutput(mc.c.ShowFailedOutput)
 			mc.openModal(modal)
 		},
 		OnError: func(out *context.OutputContext, cwd string, err error) {
+			// A non-zero exit is reported again through OnFailure
+			if _, isExit := err.(*exec.ExitError); isExit {
+				return
+			}
 			mc.c.FinishCommand()
-			out.LogAction(tr.LogActionMigrationError, err.Error())
-			modal := NewMessageModal(mc.g, tr, tr.ModalTitleMigrationError,
-				tr.ModalMsgFailedRunMigrateDeploy,
+			mc.c.RequestRefresh(types.RefreshMigrations)
+			out.LogActionRed(tr.LogActionCreateExtensions, err.Error())
+			modal := NewMessageModal(mc.g, tr, tr.ModalTitleCreateExtensionsFailed,
+				tr.ModalMsgFailedStartCreateExts,
 				err.Error(),
 			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
 			mc.openModal(modal)
@@ -190,267 +410,2054 @@ func (mc *MigrationsController) executeCreateMigration(migrationName string) {
 	})
 }
 
-// SchemaDiffMigration performs schema diff-based migration with validation checks
-func (mc *MigrationsController) SchemaDiffMigration() {
+// DeployAt counts down to deadline in a modal, then runs MigrateDeploy.
+// Closing the modal first aborts the deploy. scheduled shows the deadline's
+// time of day, for a deploy timed to a maintenance window.
+func (mc *MigrationsController) DeployAt(deadline time.Time, scheduled bool) {
 	tr := mc.c.GetTranslationSet()
 
-	// 1. Refresh first (with callback to ensure data is loaded before checking)
-	started := mc.c.RefreshAll(func() {
-		// 2. Check DB connection
-		if !mc.migrationsCtx.IsDBConnected() {
-			modal := NewMessageModal(mc.g, tr, tr.ModalTitleDBConnectionRequired,
-				tr.ErrorNoDBConnectionDetected,
-				tr.ErrorEnsureDBAccessible,
-			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
-			mc.openModal(modal)
-			return
-		}
+	pending := len(mc.migrationsCtx.GetCategory().Pending)
+	if pending == 0 {
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleDeploy,
+			tr.ModalMsgDeployNoPending,
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		mc.openModal(modal)
+		return
+	}
 
-		// 3. Check for DB-Only migrations
-		if len(mc.migrationsCtx.GetCategory().DBOnly) > 0 {
-			modal := NewMessageModal(mc.g, tr, tr.ModalTitleDBOnlyMigrationsDetected,
-				tr.ModalMsgCannotCreateWithDBOnly,
-				tr.ModalMsgResolveDBOnlyFirst,
-			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
-			mc.openModal(modal)
-			return
-		}
+	target := ""
+	if cwd, err := os.Getwd(); err == nil {
+		target = commandTargetBanner(tr, cwd, nil)
+	}
+	at := deadline.Format("2006-01-02 15:04:05")
 
-		// 4. Check for Checksum Mismatch
-		for _, m := range mc.migrationsCtx.GetCategory().Local {
-			if m.ChecksumMismatch {
-				modal := NewMessageModal(mc.g, tr, tr.ModalTitleChecksumMismatchDetected,
-					tr.ModalMsgCannotCreateWithMismatch,
-					fmt.Sprintf(tr.ModalMsgMigrationModifiedLocally, m.Name),
-				).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
-				mc.openModal(modal)
-				return
-			}
-		}
+	title := tr.ModalTitleDeployCountdown
+	if scheduled {
+		title = tr.ModalTitleScheduledDeploy
+		mc.outputCtx.LogAction(tr.LogActionDeployScheduled, fmt.Sprintf(tr.LogMsgDeployScheduledAt, pending, at), target)
+	} else {
+		mc.outputCtx.LogAction(tr.LogActionDeployCountdown, fmt.Sprintf(tr.LogMsgDeployCountdownStarted, pending, countdownRemaining(deadline)), target)
+	}
 
-		// 5. Check for Pending migrations
-		if len(mc.migrationsCtx.GetCategory().Pending) > 0 {
-			// Check if any pending migration is empty
-			for _, m := range mc.migrationsCtx.GetCategory().Pending {
-				if m.IsEmpty {
-					modal := NewMessageModal(mc.g, tr, tr.ModalTitleEmptyPendingDetected,
-						tr.ModalMsgCannotCreateWithEmpty,
-						fmt.Sprintf(tr.ModalMsgMigrationPendingEmpty, m.Name),
-						tr.ModalMsgDeleteOrAddContent,
-					).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
-					mc.openModal(modal)
-					return
-				}
+	modal := NewCountdownModal(mc.g, tr, title, deadline,
+		func(remaining time.Duration) []string {
+			lines := []string{}
+			if scheduled {
+				lines = append(lines, fmt.Sprintf(tr.ModalMsgDeployScheduled, pending, at, remaining), tr.ModalMsgDeployScheduledKeepOpen)
+			} else {
+				lines = append(lines, style.Bold(fmt.Sprintf(tr.ModalMsgDeployCountdown, pending, remaining)))
 			}
+			if target != "" {
+				lines = append(lines, target)
+			}
+			return append(lines, "", tr.ModalMsgDeployAbortHint)
+		},
+		func() {
+			mc.closeModal()
+			mc.MigrateDeploy()
+		},
+	).WithOnAbort(func() {
+		mc.outputCtx.LogActionRed(tr.LogActionDeployAborted, tr.LogMsgDeployAbortedBeforeStart)
+	}).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+	mc.openModal(modal)
+}
 
-			// Show confirmation modal for normal pending migrations
-			modal := NewConfirmModal(mc.g, tr, tr.ModalTitlePendingMigrationsDetected,
-				tr.ModalMsgPendingMigrationsWarning,
-				func() {
-					// Yes - proceed with migration name input
-					mc.closeModal()
-					mc.showMigrationNameInput()
-				},
-				func() {
-					// No - cancel
-					mc.closeModal()
-				},
-			).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
-			mc.openModal(modal)
-			return
-		}
-
-		// All checks passed - show migration name input
-		mc.showMigrationNameInput()
-	})
+// showDeployTimeInput asks for the time to deploy at
+func (mc *MigrationsController) showDeployTimeInput() {
+	tr := mc.c.GetTranslationSet()
 
-	if !started {
-		// If refresh failed to start (e.g., another command running), show error
-		modal := NewMessageModal(mc.g, tr, tr.ModalTitleOperationBlocked,
-			tr.ModalMsgAnotherOperationRunning,
-			tr.ModalMsgWaitComplete,
+	showError := func(msg string) {
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleInvalidDeployTime,
+			msg,
 		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
 		mc.openModal(modal)
 	}
+
+	modal := NewInputModal(mc.g, tr, tr.ModalTitleEnterDeployTime,
+		func(input string) {
+			mc.closeModal()
+
+			input = strings.TrimSpace(input)
+			now := time.Now()
+			deadline, ok := parseDeployTime(input, now)
+			if !ok {
+				showError(fmt.Sprintf(tr.ModalMsgInvalidDeployTime, input))
+				return
+			}
+			if !deadline.After(now) {
+				showError(fmt.Sprintf(tr.ModalMsgDeployTimePassed, input))
+				return
+			}
+			mc.DeployAt(deadline, true)
+		},
+		func() {
+			mc.closeModal()
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan}).
+		WithSubtitle(tr.ModalMsgDeployTimeHint).
+		WithRequired(true).
+		OnValidationFail(func(reason string) {
+			mc.closeModal()
+			showError(reason)
+		})
+
+	mc.openModal(modal)
 }
 
-// createManualMigration creates a manual migration folder and file
-func (mc *MigrationsController) createManualMigration(migrationName string) {
-	tr := mc.c.GetTranslationSet()
+// parseDeployTime parses a local time entered for a scheduled deploy: a date
+// and time, or a time of day, which is the next one from now
+func parseDeployTime(input string, now time.Time) (time.Time, bool) {
+	for _, layout := range []string{"2006-01-02 15:04", "2006-01-02 15:04:05"} {
+		if t, err := time.ParseInLocation(layout, input, time.Local); err == nil {
+			return t, true
+		}
+	}
 
-	// Get current working directory
-	cwd, err := os.Getwd()
-	if err != nil {
-		modal := NewMessageModal(mc.g, tr, tr.ModalTitleError,
-			tr.ErrorFailedGetWorkingDir,
-			err.Error(),
-		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
-		mc.openModal(modal)
-		return
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		t, err := time.ParseInLocation(layout, input, time.Local)
+		if err != nil {
+			continue
+		}
+		at := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.Local)
+		if !at.After(now) {
+			at = at.AddDate(0, 0, 1)
+		}
+		return at, true
 	}
 
-	// Generate timestamp (YYYYMMDDHHmmss format) in UTC to match Prisma CLI behavior
-	timestamp := time.Now().UTC().Format("20060102150405")
-	folderName := fmt.Sprintf("%s_%s", timestamp, migrationName)
+	return time.Time{}, false
+}
 
-	// Migration folder path (prisma/migrations/{timestamp}_{name})
-	migrationsDir := fmt.Sprintf("%s/prisma/migrations", cwd)
-	migrationFolder := fmt.Sprintf("%s/%s", migrationsDir, folderName)
+// ExportPendingSQL writes the pending migrations to one ordered .sql file, for
+// a DBA to apply by hand, and offers to mark them applied afterwards
+func (mc *MigrationsController) ExportPendingSQL() {
+	tr := mc.c.GetTranslationSet()
 
-	// Create migration folder
-	if err := os.MkdirAll(migrationFolder, 0755); err != nil {
-		modal := NewMessageModal(mc.g, tr, tr.ModalTitleError,
-			tr.ModalMsgFailedCreateFolder,
-			err.Error(),
-		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+	pending := mc.migrationsCtx.GetCategory().Pending
+	if len(pending) == 0 {
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleExportPendingSQL,
+			tr.ModalMsgExportNoPending,
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
 		mc.openModal(modal)
 		return
 	}
 
-	// Create migration.sql file with initial comment
-	migrationFile := fmt.Sprintf("%s/migration.sql", migrationFolder)
-	initialContent := "-- This migration was manually created via lazyprisma\n\n"
-
-	if err := os.WriteFile(migrationFile, []byte(initialContent), 0644); err != nil {
-		modal := NewMessageModal(mc.g, tr, tr.ModalTitleError,
-			tr.ModalMsgFailedWriteMigrationFile,
-			err.Error(),
-		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
-		mc.openModal(modal)
-		return
+	// The transaction choice is remembered for the project, like the migrate dev flags
+	var project *config.ProjectState
+	state, stateErr := config.LoadState()
+	cwd, cwdErr := os.Getwd()
+	if stateErr == nil && cwdErr == nil {
+		project = state.Project(cwd)
+	} else {
+		project = &config.ProjectState{}
 	}
 
-	// Success - show result and refresh
-	mc.c.RefreshAll()
+	var modal *ListModal
+	var buildItems func() []ListModalItem
+	buildItems = func() []ListModalItem {
+		check := style.Gray("[ ]")
+		if project.ExportTransaction {
+			check = style.Green("[x]")
+		}
 
-	modal := NewMessageModal(mc.g, tr, tr.ModalTitleMigrationCreated,
-		fmt.Sprintf(tr.ModalMsgManualMigrationCreated, folderName),
-		fmt.Sprintf(tr.ModalMsgManualMigrationLocation, migrationFolder),
-	).WithStyle(MessageModalStyle{TitleColor: ColorGreen, BorderColor: ColorGreen})
+		items := []ListModalItem{
+			{
+				Label:       fmt.Sprintf(tr.ListItemExportCount, len(pending)),
+				Description: tr.ListItemDescExportCount,
+				OnSelect: func() error {
+					mc.closeModal()
+					mc.showExportPathInput(pending, project.ExportTransaction)
+					return nil
+				},
+			},
+			{
+				Label:       check + " " + tr.ListItemExportTransaction,
+				Description: tr.ListItemDescExportTransaction + "\n\n" + tr.MigrateDevToggleHint,
+				OnSelect: func() error {
+					project.ExportTransaction = !project.ExportTransaction
+					if stateErr == nil && cwdErr == nil {
+						if err := config.SaveState(state); err != nil {
+							mc.outputCtx.LogActionRed(tr.LogActionExportSQL, tr.LogMsgFailedSaveState+" "+err.Error())
+						}
+					}
+					modal.SetItems(buildItems())
+					return nil
+				},
+			},
+		}
+		for _, mig := range pending {
+			items = append(items, ListModalItem{
+				Label:       "  " + mig.Name,
+				Description: tr.ListItemDescWillExport,
+			})
+		}
+		return items
+	}
+
+	modal = NewListModal(mc.g, tr, tr.ModalTitleExportPendingSQL, buildItems(),
+		func() {
+			mc.closeModal()
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
 	mc.openModal(modal)
 }
 
-// showMigrationNameInput shows input modal for migration name
-func (mc *MigrationsController) showMigrationNameInput() {
+// showExportPathInput asks where to write the exported SQL
+func (mc *MigrationsController) showExportPathInput(pending []prisma.Migration, transaction bool) {
 	tr := mc.c.GetTranslationSet()
 
-	modal := NewInputModal(mc.g, tr, tr.ModalTitleEnterMigrationName,
-		func(input string) {
-			// Replace spaces with underscores
-			migrationName := strings.ReplaceAll(strings.TrimSpace(input), " ", "_")
+	now := time.Now()
+	defaultPath := "pending_" + now.Format("20060102150405") + ".sql"
 
-			// Close input modal
+	modal := NewInputModal(mc.g, tr, tr.ModalTitleEnterExportPath,
+		func(input string) {
 			mc.closeModal()
 
-			// Execute actual migration creation
-			mc.executeCreateMigration(migrationName)
+			path := strings.TrimSpace(input)
+			if path == "" {
+				path = defaultPath
+			}
+			mc.writeExport(pending, path, prisma.ExportOpts{Transaction: transaction, Time: now})
 		},
 		func() {
-			// Cancel - just close modal
 			mc.closeModal()
 		},
 	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan}).
-		WithSubtitle(tr.ModalMsgSpacesReplaced).
-		WithRequired(true).
-		OnValidationFail(func(reason string) {
-			// Validation failed - show error
+		WithSubtitle(fmt.Sprintf(tr.ModalMsgExportPathHint, defaultPath))
+
+	mc.openModal(modal)
+}
+
+// writeExport writes the exported SQL to path (relative to the project root)
+// without overwriting an existing file
+func (mc *MigrationsController) writeExport(pending []prisma.Migration, path string, opts prisma.ExportOpts) {
+	tr := mc.c.GetTranslationSet()
+
+	showError := func(lines ...string) {
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleExportSQLFailed, lines...).
+			WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		mc.openModal(modal)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		showError(tr.ErrorFailedGetWorkingDirectory, err.Error())
+		return
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(cwd, path)
+	}
+	opts.Provider, _ = prisma.GetProvider(cwd)
+
+	content, err := prisma.ExportSQL(pending, opts)
+	if err != nil {
+		showError(err.Error())
+		return
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			showError(fmt.Sprintf(tr.ModalMsgExportFileExists, relativePath(cwd, path)))
+		} else {
+			showError(err.Error())
+		}
+		return
+	}
+	_, err = file.WriteString(content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		showError(err.Error())
+		return
+	}
+
+	exported := fmt.Sprintf(tr.ModalMsgExportedSQL, len(pending), relativePath(cwd, path))
+	mc.outputCtx.LogAction(tr.LogActionExportSQL, fmt.Sprintf(tr.LogMsgExportedSQL, len(pending), relativePath(cwd, path)))
+
+	if mc.c.IsActionDisabled(config.ActionMigrateResolve) || mc.c.IsActionDisabled(config.ActionBulkResolve) {
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleExportedSQL,
+			exported,
+			tr.ModalMsgExportMarkAppliedLater,
+		).WithStyle(MessageModalStyle{TitleColor: ColorGreen, BorderColor: ColorGreen})
+		mc.openModal(modal)
+		return
+	}
+
+	names := make([]string, len(pending))
+	for i, mig := range pending {
+		names[i] = mig.Name
+	}
+	modal := NewConfirmModal(mc.g, tr, tr.ModalTitleExportedSQL,
+		exported+" "+tr.ModalMsgExportMarkApplied,
+		func() {
 			mc.closeModal()
-			errorModal := NewMessageModal(mc.g, tr, tr.ModalTitleValidationFailed,
-				reason,
-			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
-			mc.openModal(errorModal)
+			mc.markAppliedInOrder(names, 0)
+		},
+		func() {
+			mc.closeModal()
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorGreen, BorderColor: ColorGreen})
+	mc.openModal(modal)
+}
+
+// SimulateDeploy applies the pending migrations to a scratch database, after
+// replaying the applied ones to recreate the target's schema, and reports the
+// result of each. Prisma has no dry-run for migrate deploy.
+func (mc *MigrationsController) SimulateDeploy() {
+	tr := mc.c.GetTranslationSet()
+
+	if !mc.c.TryStartCommand("Simulate Deploy") {
+		mc.c.LogCommandBlocked("Simulate Deploy")
+		return
+	}
+
+	go func() {
+		mc.c.RefreshPanels(types.RefreshMigrations)
+
+		showError := func(title string, lines ...string) {
+			mc.c.FinishCommand()
+			mc.c.OnUIThread(func() error {
+				modal := NewMessageModal(mc.g, tr, title, lines...).
+					WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+				mc.openModal(modal)
+				return nil
+			})
+		}
+
+		if !mc.migrationsCtx.IsDBConnected() {
+			showError(tr.ModalTitleDBConnectionRequired, tr.ErrorNoDBConnectionDetected, tr.ErrorEnsureDBAccessible)
+			return
+		}
+
+		cwd, err := os.Getwd()
+		if err != nil {
+			showError(tr.ModalTitleSimulateDeployError, tr.ErrorFailedGetWorkingDir, err.Error())
+			return
+		}
+		ds, err := prisma.GetDatasource(cwd)
+		if err != nil {
+			showError(tr.ModalTitleSimulateDeployError, tr.ModalMsgSimulateSetupFailed, err.Error())
+			return
+		}
+
+		// Deploy replays nothing past a failed migration, and neither does the simulation
+		category := mc.migrationsCtx.GetCategory()
+		var applied []prisma.Migration
+		for _, mig := range category.Local {
+			if mig.IsFailed {
+				showError(tr.ModalTitleSimulateDeployFailed, fmt.Sprintf(tr.ModalMsgSimulateFailedMigration, mig.Name))
+				return
+			}
+			if mig.AppliedAt != nil {
+				applied = append(applied, mig)
+			}
+		}
+		if len(category.Pending) == 0 {
+			mc.c.FinishCommand()
+			mc.c.OnUIThread(func() error {
+				modal := NewMessageModal(mc.g, tr, tr.ModalTitleDeploy, tr.ModalMsgSimulateNoPending).
+					WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+				mc.openModal(modal)
+				return nil
+			})
+			return
+		}
+
+		// Keep the scratch database off the target's server when a shadow database is configured
+		if shadowURL, _ := prisma.GetShadowDatabaseURL(cwd); shadowURL != "" {
+			mc.runSimulation(ds.Provider, shadowURL, applied, category.Pending, len(category.DBOnly))
+			return
+		}
+
+		// Otherwise it goes on the target's own server, which is only done when asked
+		mc.c.FinishCommand()
+		mc.c.OnUIThread(func() error {
+			modal := NewConfirmModal(mc.g, tr, tr.ModalTitleSimulateOnTarget,
+				fmt.Sprintf(tr.ModalMsgSimulateOnTarget, prisma.DBFingerprint(ds.URL)),
+				func() {
+					mc.closeModal()
+					if !mc.c.TryStartCommand("Simulate Deploy") {
+						mc.c.LogCommandBlocked("Simulate Deploy")
+						return
+					}
+					go mc.runSimulation(ds.Provider, ds.URL, applied, category.Pending, len(category.DBOnly))
+				},
+				func() {
+					mc.closeModal()
+				},
+			).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+			mc.openModal(modal)
+			return nil
 		})
+	}()
+}
+
+// runSimulation runs the simulation for SimulateDeploy with its scratch
+// database on serverURL's server. It is called off the UI thread, with the
+// command started.
+func (mc *MigrationsController) runSimulation(provider, serverURL string, applied, pending []prisma.Migration, dbOnlyCount int) {
+	tr := mc.c.GetTranslationSet()
+
+	mc.c.OnUIThread(func() error {
+		mc.outputCtx.LogAction(tr.LogActionSimulateDeploy,
+			fmt.Sprintf(tr.LogMsgSimulateScratch, prisma.DBFingerprint(serverURL)),
+			fmt.Sprintf(tr.LogMsgSimulateSteps, len(applied), len(pending)),
+		)
+		return nil
+	})
+
+	result, err := simulate.Run(simulate.Options{
+		Provider:  provider,
+		ServerURL: serverURL,
+		Applied:   applied,
+		Pending:   pending,
+		OnStep: func(step simulate.Step) {
+			mc.c.OnUIThread(func() error {
+				mc.outputCtx.AppendOutput("  " + simulateStepLine(step))
+				return nil
+			})
+		},
+	})
+
+	mc.c.FinishCommand()
+	mc.c.OnUIThread(func() error {
+		if err != nil {
+			mc.outputCtx.LogActionRed(tr.LogActionSimulateDeploy, tr.ModalMsgSimulateSetupFailed+" "+err.Error())
+			modal := NewMessageModal(mc.g, tr, tr.ModalTitleSimulateDeployError,
+				tr.ModalMsgSimulateSetupFailed,
+				err.Error(),
+			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+			mc.openModal(modal)
+			return nil
+		}
+		mc.showSimulateResult(result, len(pending), dbOnlyCount)
+		return nil
+	})
+}
 
+// showSimulateResult logs the outcome of a simulated deploy and shows it in a modal
+func (mc *MigrationsController) showSimulateResult(result *simulate.Result, pendingCount, dbOnlyCount int) {
+	tr := mc.c.GetTranslationSet()
+
+	var lines []string
+	failed := result.Failed()
+	if failed == nil {
+		mc.outputCtx.LogAction(tr.LogActionSimulateDeploy, tr.LogMsgSimulatePassed)
+		lines = append(lines, fmt.Sprintf(tr.ModalMsgSimulateDeploySuccess, pendingCount))
+	} else {
+		mc.outputCtx.LogActionRed(tr.LogActionSimulateDeploy, fmt.Sprintf(tr.LogMsgSimulateFailed, failed.Migration))
+		if failed.Replay {
+			lines = append(lines, fmt.Sprintf(tr.ModalMsgSimulateReplayFailed, failed.Migration))
+		} else {
+			lines = append(lines, fmt.Sprintf(tr.ModalMsgSimulatePendingFailed, failed.Migration))
+		}
+		lines = append(lines, style.Red(failed.Err.Error()))
+		if len(result.Skipped) > 0 {
+			lines = append(lines, "", fmt.Sprintf(tr.ModalMsgSimulateSkipped, strings.Join(result.Skipped, ", ")))
+		}
+	}
+
+	// The pending migrations, as they ran
+	lines = append(lines, "")
+	for _, step := range result.Steps {
+		if !step.Replay {
+			lines = append(lines, simulateStepLine(step))
+		}
+	}
+
+	if dbOnlyCount > 0 {
+		lines = append(lines, "", style.Yellow(fmt.Sprintf(tr.ModalMsgSimulateDBOnlyNote, dbOnlyCount)))
+	}
+	lines = append(lines, "", style.Gray(tr.ModalMsgSimulateTargetUnchanged))
+
+	title, color := tr.ModalTitleSimulateDeploySuccess, ColorGreen
+	if failed != nil {
+		title, color = tr.ModalTitleSimulateDeployFailed, ColorRed
+	}
+	modal := NewMessageModal(mc.g, tr, title, lines...).
+		WithStyle(MessageModalStyle{TitleColor: color, BorderColor: color})
 	mc.openModal(modal)
 }
 
-// showManualMigrationInput shows input modal for manual migration name
-func (mc *MigrationsController) showManualMigrationInput() {
+// simulateStepLine renders one migration of a simulated deploy, e.g. "✓ 20240101_init (12ms)"
+func simulateStepLine(step simulate.Step) string {
+	name := step.Migration
+	if step.Replay {
+		name = style.Gray(name)
+	}
+	if step.Err != nil {
+		return fmt.Sprintf("%s %s: %s", style.Red("✗"), name, style.Red(step.Err.Error()))
+	}
+	return fmt.Sprintf("%s %s %s", style.Green("✓"), name, style.Gray("("+step.Duration.Round(time.Millisecond).String()+")"))
+}
+
+// MigrateDev opens a list modal to choose migration type
+func (mc *MigrationsController) MigrateDev() {
+	tr := mc.c.GetTranslationSet()
+
+	items := []ListModalItem{
+		{
+			Label:       tr.ListItemSchemaDiffMigration,
+			Description: tr.ListItemDescSchemaDiffMigration,
+			OnSelect: func() error {
+				mc.closeModal()
+				mc.SchemaDiffMigration()
+				return nil
+			},
+		},
+		{
+			Label:       tr.ListItemManualMigration,
+			Description: tr.ListItemDescManualMigration,
+			OnSelect: func() error {
+				mc.closeModal()
+				mc.showManualMigrationInput()
+				return nil
+			},
+		},
+		{
+			Label:       tr.ListItemFindDeadColumns,
+			Description: tr.ListItemDescFindDeadColumns,
+			OnSelect: func() error {
+				mc.closeModal()
+				mc.FindDeadColumns()
+				return nil
+			},
+		},
+	}
+
+	// CREATE INDEX CONCURRENTLY is PostgreSQL-only
+	if cwd, err := os.Getwd(); err == nil {
+		if provider, err := prisma.GetProvider(cwd); err == nil && provider == "postgresql" {
+			items = append(items, ListModalItem{
+				Label:       tr.ListItemConcurrentIndex,
+				Description: tr.ListItemDescConcurrentIndex,
+				OnSelect: func() error {
+					mc.closeModal()
+					mc.showConcurrentIndexTableInput()
+					return nil
+				},
+			})
+		}
+	}
+
+	// Flags for schema diff migrations, remembered for the project
+	state, stateErr := config.LoadState()
+	cwd, cwdErr := os.Getwd()
+	if stateErr != nil || cwdErr != nil {
+		modal := NewListModal(mc.g, tr, tr.ModalTitleMigrateDev, items,
+			func() {
+				mc.closeModal()
+			},
+		).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+		mc.openModal(modal)
+		return
+	}
+	project := state.Project(cwd)
+
+	var modal *ListModal
+	var buildItems func() []ListModalItem
+	buildItems = func() []ListModalItem {
+		toggle := func(label, desc string, enabled *bool) ListModalItem {
+			check := style.Gray("[ ]")
+			if *enabled {
+				check = style.Green("[x]")
+			}
+			return ListModalItem{
+				Label:       check + " " + label,
+				Description: desc + "\n\n" + tr.MigrateDevToggleHint,
+				OnSelect: func() error {
+					*enabled = !*enabled
+					if err := config.SaveState(state); err != nil {
+						mc.outputCtx.LogActionRed(tr.LogActionMigrateDev, tr.LogMsgFailedSaveState+" "+err.Error())
+					}
+					modal.SetItems(buildItems())
+					return nil
+				},
+			}
+		}
+		return append(append([]ListModalItem(nil), items...),
+			toggle(tr.ListItemSkipGenerate, tr.ListItemDescSkipGenerate, &project.MigrateSkipGenerate),
+			toggle(tr.ListItemSkipSeed, tr.ListItemDescSkipSeed, &project.MigrateSkipSeed),
+		)
+	}
+
+	modal = NewListModal(mc.g, tr, tr.ModalTitleMigrateDev, buildItems(),
+		func() {
+			mc.closeModal()
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+
+	mc.openModal(modal)
+}
+
+// executeCreateMigration runs npx prisma migrate dev --name <name> --create-only,
+// with the --skip-generate / --skip-seed flags toggled in MigrateDev
+func (mc *MigrationsController) executeCreateMigration(migrationName string) {
+	tr := mc.c.GetTranslationSet()
+
+	if mc.c.RejectOpenTransaction("Create Migration") {
+		return
+	}
+
+	devOpts := mc.migrateDevOpts()
+	logDetail := fmt.Sprintf(tr.LogMsgCreatingMigration, migrationName)
+	if flags := devOpts.Flags(); len(flags) > 0 {
+		logDetail = fmt.Sprintf(tr.LogMsgCreatingMigrationFlags, migrationName, strings.Join(flags, " "))
+	}
+
+	var output outputCapture
+	mc.runStreamCmd(AsyncCommandOpts{
+		Name:          "Create Migration",
+		OnOutput:      output.Add,
+		LogAction:     tr.LogActionMigrateDev,
+		LogDetail:     logDetail,
+		ErrorTitle:    tr.ModalTitleMigrationError,
+		ErrorStartMsg: tr.ModalMsgFailedStartMigrateDeploy,
+		PrismaArgs:    prisma.MigrateDevArgs(migrationName, devOpts),
+		OnSuccess: func(out *context.OutputContext, cwd string) {
+			mc.c.FinishCommand()
+			mc.c.RequestRefresh(types.RefreshMigrations)
+			out.LogAction(tr.LogActionMigrateComplete, tr.LogMsgMigrationCreatedSuccess)
+			modal := NewMessageModal(mc.g, tr, tr.ModalTitleMigrationCreated,
+				fmt.Sprintf(tr.ModalMsgMigrationCreatedSuccess, migrationName),
+				fmt.Sprintf(tr.ModalMsgMigrationCreatedDetail, relativePath(cwd, prisma.MigrationsDir(cwd))),
+			).WithStyle(MessageModalStyle{TitleColor: ColorGreen, BorderColor: ColorGreen})
+			mc.openModal(modal)
+		},
+		OnFailure: func(out *context.OutputContext, cwd string, exitCode int) {
+			mc.c.FinishCommand()
+			out.LogAction(tr.LogActionMigrateFailed, fmt.Sprintf(tr.LogMsgMigrationCreationFailedCode, exitCode))
+			if mc.offerResolveForFailure(out, output.String()) {
+				return
+			}
+			mc.c.RequestRefresh(types.RefreshMigrations)
+			modal := NewMessageModal(mc.g, tr, tr.ModalTitleMigrationFailed,
+				fmt.Sprintf(tr.ModalMsgMigrationFailedWithCode, exitCode),
+				tr.ModalMsgCheckOutputPanel,
+			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed}).WithViewOutput(mc.c.ShowFailedOutput)
+			mc.openModal(modal)
+		},
+		OnError: func(out *context.OutputContext, cwd string, err error) {
+			mc.c.FinishCommand()
+			out.LogAction(tr.LogActionMigrationError, err.Error())
+			modal := NewMessageModal(mc.g, tr, tr.ModalTitleMigrationError,
+				tr.ModalMsgFailedRunMigrateDeploy,
+				err.Error(),
+			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+			mc.openModal(modal)
+		},
+	})
+}
+
+// migrateDevOpts returns the migrate dev flags remembered for the project
+func (mc *MigrationsController) migrateDevOpts() prisma.MigrateDevOpts {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return prisma.MigrateDevOpts{}
+	}
+	state, err := config.LoadState()
+	if err != nil {
+		return prisma.MigrateDevOpts{}
+	}
+	project := state.Project(cwd)
+	return prisma.MigrateDevOpts{
+		SkipGenerate: project.MigrateSkipGenerate,
+		SkipSeed:     project.MigrateSkipSeed,
+	}
+}
+
+// SchemaDiffMigration performs schema diff-based migration with validation checks
+func (mc *MigrationsController) SchemaDiffMigration() {
+	tr := mc.c.GetTranslationSet()
+
+	// Another command may change what the checks see; a running refresh is fine
+	if mc.c.CommandInProgress() {
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleOperationBlocked,
+			tr.ModalMsgAnotherOperationRunning,
+			tr.ModalMsgWaitComplete,
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		mc.openModal(modal)
+		return
+	}
+
+	// 1. Refresh first (with callback to ensure data is loaded before checking)
+	mc.c.RequestRefresh(types.RefreshMigrations, func() {
+		// 2. Check DB connection
+		if !mc.migrationsCtx.IsDBConnected() {
+			modal := NewMessageModal(mc.g, tr, tr.ModalTitleDBConnectionRequired,
+				tr.ErrorNoDBConnectionDetected,
+				tr.ErrorEnsureDBAccessible,
+			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+			mc.openModal(modal)
+			return
+		}
+
+		// 3. Check for DB-Only migrations
+		if len(mc.migrationsCtx.GetCategory().DBOnly) > 0 {
+			modal := NewMessageModal(mc.g, tr, tr.ModalTitleDBOnlyMigrationsDetected,
+				tr.ModalMsgCannotCreateWithDBOnly,
+				tr.ModalMsgResolveDBOnlyFirst,
+			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+			mc.openModal(modal)
+			return
+		}
+
+		// 4. Check for Checksum Mismatch
+		for _, m := range mc.migrationsCtx.GetCategory().Local {
+			if m.ChecksumMismatch {
+				modal := NewMessageModal(mc.g, tr, tr.ModalTitleChecksumMismatchDetected,
+					tr.ModalMsgCannotCreateWithMismatch,
+					fmt.Sprintf(tr.ModalMsgMigrationModifiedLocally, m.Name),
+				).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+				mc.openModal(modal)
+				return
+			}
+		}
+
+		// 5. Check for Pending migrations
+		if len(mc.migrationsCtx.GetCategory().Pending) > 0 {
+			// Check if any pending migration is empty
+			for _, m := range mc.migrationsCtx.GetCategory().Pending {
+				if m.IsEmpty {
+					modal := NewMessageModal(mc.g, tr, tr.ModalTitleEmptyPendingDetected,
+						tr.ModalMsgCannotCreateWithEmpty,
+						fmt.Sprintf(tr.ModalMsgMigrationPendingEmpty, m.Name),
+						tr.ModalMsgDeleteOrAddContent,
+					).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+					mc.openModal(modal)
+					return
+				}
+			}
+
+			// Show confirmation modal for normal pending migrations
+			modal := NewConfirmModal(mc.g, tr, tr.ModalTitlePendingMigrationsDetected,
+				tr.ModalMsgPendingMigrationsWarning,
+				func() {
+					// Yes - proceed with migration name input
+					mc.closeModal()
+					mc.showMigrationNameInput()
+				},
+				func() {
+					// No - cancel
+					mc.closeModal()
+				},
+			).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+			mc.openModal(modal)
+			return
+		}
+
+		// All checks passed - show migration name input
+		mc.showMigrationNameInput()
+	})
+}
+
+// createManualMigration creates a manual migration folder and file
+func (mc *MigrationsController) createManualMigration(migrationName string) {
+	tr := mc.c.GetTranslationSet()
+
+	folderName, migrationFolder, ok := mc.writeMigrationFolder(migrationName, func(string) string {
+		return "-- This migration was manually created via lazyprisma\n\n"
+	})
+	if !ok {
+		return
+	}
+
+	// Success - show result and refresh
+	mc.c.RequestRefresh(types.RefreshMigrations)
+
+	modal := NewMessageModal(mc.g, tr, tr.ModalTitleMigrationCreated,
+		fmt.Sprintf(tr.ModalMsgManualMigrationCreated, folderName),
+		fmt.Sprintf(tr.ModalMsgManualMigrationLocation, migrationFolder),
+	).WithStyle(MessageModalStyle{TitleColor: ColorGreen, BorderColor: ColorGreen})
+	mc.openModal(modal)
+}
+
+// writeMigrationFolder creates {migrations dir}/{timestamp}_{name}/migration.sql.
+// content receives the folder name (the migration's name in Prisma). On failure
+// an error modal is shown and ok is false.
+func (mc *MigrationsController) writeMigrationFolder(migrationName string, content func(folderName string) string) (folderName, migrationFolder string, ok bool) {
+	return mc.writeMigrationFolderAt(time.Now(), migrationName, content)
+}
+
+// writeMigrationFolderAt is writeMigrationFolder with the folder's timestamp given
+func (mc *MigrationsController) writeMigrationFolderAt(at time.Time, migrationName string, content func(folderName string) string) (folderName, migrationFolder string, ok bool) {
+	tr := mc.c.GetTranslationSet()
+
+	// Get current working directory
+	cwd, err := os.Getwd()
+	if err != nil {
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleError,
+			tr.ErrorFailedGetWorkingDir,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		mc.openModal(modal)
+		return "", "", false
+	}
+
+	// Generate timestamp (YYYYMMDDHHmmss format) in UTC to match Prisma CLI behavior
+	timestamp := at.UTC().Format("20060102150405")
+	folderName = fmt.Sprintf("%s_%s", timestamp, migrationName)
+
+	// Migration folder path ({migrations dir}/{timestamp}_{name})
+	migrationFolder = filepath.Join(prisma.MigrationsDir(cwd), folderName)
+
+	// Create migration folder
+	if err := os.MkdirAll(migrationFolder, 0755); err != nil {
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleError,
+			tr.ModalMsgFailedCreateFolder,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		mc.openModal(modal)
+		return "", "", false
+	}
+
+	// Create migration.sql file
+	migrationFile := filepath.Join(migrationFolder, "migration.sql")
+	if err := os.WriteFile(migrationFile, []byte(content(folderName)), 0644); err != nil {
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleError,
+			tr.ModalMsgFailedWriteMigrationFile,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		mc.openModal(modal)
+		return "", "", false
+	}
+
+	return folderName, migrationFolder, true
+}
+
+// showMigrationNameInput shows input modal for migration name
+func (mc *MigrationsController) showMigrationNameInput() {
+	tr := mc.c.GetTranslationSet()
+
+	modal := NewInputModal(mc.g, tr, tr.ModalTitleEnterMigrationName,
+		func(input string) {
+			// Replace spaces with underscores
+			migrationName := strings.ReplaceAll(strings.TrimSpace(input), " ", "_")
+
+			// Close input modal
+			mc.closeModal()
+
+			// Execute actual migration creation
+			mc.executeCreateMigration(migrationName)
+		},
+		func() {
+			// Cancel - just close modal
+			mc.closeModal()
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan}).
+		WithSubtitle(tr.ModalMsgSpacesReplaced).
+		WithRequired(true).
+		OnValidationFail(func(reason string) {
+			// Validation failed - show error
+			mc.closeModal()
+			errorModal := NewMessageModal(mc.g, tr, tr.ModalTitleValidationFailed,
+				reason,
+			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+			mc.openModal(errorModal)
+		})
+
+	mc.openModal(modal)
+}
+
+// showManualMigrationInput shows input modal for manual migration name
+func (mc *MigrationsController) showManualMigrationInput() {
+	tr := mc.c.GetTranslationSet()
+
+	modal := NewInputModal(mc.g, tr, tr.ModalTitleEnterMigrationName,
+		func(input string) {
+			// Replace spaces with underscores
+			migrationName := strings.ReplaceAll(strings.TrimSpace(input), " ", "_")
+
+			// Close input modal
+			mc.closeModal()
+
+			// Create manual migration
+			mc.createManualMigration(migrationName)
+		},
+		func() {
+			// Cancel - just close modal
+			mc.closeModal()
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan}).
+		WithSubtitle(tr.ModalMsgSpacesReplaced).
+		WithRequired(true).
+		OnValidationFail(func(reason string) {
+			// Validation failed - show error
+			mc.closeModal()
+			errorModal := NewMessageModal(mc.g, tr, tr.ModalTitleValidationFailed,
+				reason,
+			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+			mc.openModal(errorModal)
+		})
+
+	mc.openModal(modal)
+}
+
+// showConcurrentIndexTableInput asks for the table of a concurrent index
+func (mc *MigrationsController) showConcurrentIndexTableInput() {
+	tr := mc.c.GetTranslationSet()
+
+	modal := NewInputModal(mc.g, tr, tr.ModalTitleConcurrentIndexTable,
+		func(input string) {
+			mc.closeModal()
+			mc.showConcurrentIndexColumnsInput(strings.TrimSpace(input))
+		},
+		func() {
+			mc.closeModal()
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan}).
+		WithSubtitle(tr.ModalMsgConcurrentIndexTableHint).
+		WithRequired(true).
+		OnValidationFail(func(reason string) {
+			mc.closeModal()
+			errorModal := NewMessageModal(mc.g, tr, tr.ModalTitleValidationFailed,
+				reason,
+			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+			mc.openModal(errorModal)
+		})
+
+	mc.openModal(modal)
+}
+
+// showConcurrentIndexColumnsInput asks for the indexed columns
+func (mc *MigrationsController) showConcurrentIndexColumnsInput(table string) {
+	tr := mc.c.GetTranslationSet()
+
+	modal := NewInputModal(mc.g, tr, fmt.Sprintf(tr.ModalTitleConcurrentIndexColumns, table),
+		func(input string) {
+			var columns []string
+			for _, col := range strings.Split(input, ",") {
+				if col = strings.TrimSpace(col); col != "" {
+					columns = append(columns, col)
+				}
+			}
+
+			mc.closeModal()
+			if len(columns) == 0 {
+				return
+			}
+			mc.confirmConcurrentIndex(prisma.ConcurrentIndex{Table: table, Columns: columns})
+		},
+		func() {
+			mc.closeModal()
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan}).
+		WithSubtitle(tr.ModalMsgConcurrentIndexColumnsHint).
+		WithRequired(true).
+		OnValidationFail(func(reason string) {
+			mc.closeModal()
+			errorModal := NewMessageModal(mc.g, tr, tr.ModalTitleValidationFailed,
+				reason,
+			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+			mc.openModal(errorModal)
+		})
+
+	mc.openModal(modal)
+}
+
+// confirmConcurrentIndex explains how Prisma applies the migration before creating it
+func (mc *MigrationsController) confirmConcurrentIndex(idx prisma.ConcurrentIndex) {
+	tr := mc.c.GetTranslationSet()
+
+	modal := NewConfirmModal(mc.g, tr, tr.ModalTitleConcurrentIndex,
+		idx.Statement()+"\n\n"+tr.ModalMsgConcurrentIndexWarning,
+		func() {
+			mc.closeModal()
+			mc.createConcurrentIndexMigration(idx)
+		},
+		func() {
+			mc.closeModal()
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+
+	mc.openModal(modal)
+}
+
+// createConcurrentIndexMigration writes a migration holding only the CREATE INDEX CONCURRENTLY statement
+func (mc *MigrationsController) createConcurrentIndexMigration(idx prisma.ConcurrentIndex) {
+	tr := mc.c.GetTranslationSet()
+
+	folderName, migrationFolder, ok := mc.writeMigrationFolder("add_"+strings.ReplaceAll(strings.ToLower(idx.Name()), " ", "_"), idx.MigrationSQL)
+	if !ok {
+		return
+	}
+
+	mc.c.RequestRefresh(types.RefreshMigrations)
+
+	modal := NewMessageModal(mc.g, tr, tr.ModalTitleMigrationCreated,
+		fmt.Sprintf(tr.ModalMsgManualMigrationCreated, folderName),
+		fmt.Sprintf(tr.ModalMsgManualMigrationLocation, migrationFolder),
+		"",
+		fmt.Sprintf(tr.ModalMsgConcurrentIndexSchemaHint, strings.Join(idx.Columns, ", "), idx.Name()),
+		tr.ModalMsgConcurrentIndexRecovery,
+	).WithStyle(MessageModalStyle{TitleColor: ColorGreen, BorderColor: ColorGreen})
+	mc.openModal(modal)
+}
+
+// FindDeadColumns lists the columns of the database's tables that no field of
+// the schema maps to, and offers to write a migration that drops them
+func (mc *MigrationsController) FindDeadColumns() {
+	tr := mc.c.GetTranslationSet()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleError,
+			tr.ErrorFailedGetWorkingDir,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		mc.openModal(modal)
+		return
+	}
+
+	content, err := os.ReadFile(prisma.SchemaPath(cwd))
+	if err != nil {
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleError,
+			tr.ModalMsgFailedReadSchema,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		mc.openModal(modal)
+		return
+	}
+	lines := strings.Split(string(content), "\n")
+
+	if !mc.c.TryStartCommand("Dead Columns") {
+		mc.c.LogCommandBlocked("Dead Columns")
+		return
+	}
+
+	mc.outputCtx.LogAction(tr.LogActionDeadColumns, tr.LogMsgFindingDeadColumns)
+
+	go func() {
+		dead, provider, err := mc.migrationsCtx.DeadColumns(lines)
+
+		mc.c.OnUIThread(func() error {
+			mc.c.FinishCommand()
+
+			if err != nil {
+				mc.outputCtx.LogActionRed(tr.LogActionDeadColumns, err.Error())
+				modal := NewMessageModal(mc.g, tr, tr.ModalTitleDeadColumns,
+					tr.ModalMsgDeadColumnsFailed,
+					err.Error(),
+				).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+				mc.openModal(modal)
+				return nil
+			}
+
+			if len(dead) == 0 {
+				mc.outputCtx.LogAction(tr.LogActionDeadColumns, tr.ModalMsgNoDeadColumns)
+				modal := NewMessageModal(mc.g, tr, tr.ModalTitleDeadColumns,
+					tr.ModalMsgNoDeadColumns,
+				).WithStyle(MessageModalStyle{TitleColor: ColorGreen, BorderColor: ColorGreen})
+				mc.openModal(modal)
+				return nil
+			}
+
+			names := make([]string, 0, len(dead))
+			for _, col := range dead {
+				names = append(names, col.Table+"."+col.Column)
+			}
+			mc.outputCtx.LogAction(tr.LogActionDeadColumns,
+				fmt.Sprintf(tr.LogMsgDeadColumnsFound, len(dead), strings.Join(names, ", ")))
+			mc.showDeadColumns(dead, provider)
+			return nil
+		})
+	}()
+}
+
+// showDeadColumns lists the dead columns, after an item that drops them all;
+// selecting a column drops only that one
+func (mc *MigrationsController) showDeadColumns(dead []drift.DeadColumn, provider string) {
+	tr := mc.c.GetTranslationSet()
+
+	// A pending migration may drop some of them already
+	var pendingNote string
+	if pending := len(mc.migrationsCtx.GetCategory().Pending); pending > 0 {
+		pendingNote = "\n\n" + fmt.Sprintf(tr.ModalMsgDeadColumnsPending, pending)
+	}
+
+	items := []ListModalItem{
+		{
+			Label:       fmt.Sprintf(tr.ListItemDropDeadColumns, len(dead)),
+			Description: tr.ListItemDescDropDeadColumns + pendingNote + "\n\n" + drift.DropColumnsSQL(provider, dead),
+			OnSelect: func() error {
+				mc.closeModal()
+				mc.confirmDropColumns(dead, provider)
+				return nil
+			},
+		},
+	}
+	for _, col := range dead {
+		columns := []drift.DeadColumn{col}
+		items = append(items, ListModalItem{
+			Label:       fmt.Sprintf("%s.%s", col.Table, style.Orange(col.Column)),
+			Description: fmt.Sprintf(tr.ListItemDescDeadColumn, col.Column, col.Table) + pendingNote + "\n\n" + drift.DropColumnsSQL(provider, columns),
+			OnSelect: func() error {
+				mc.closeModal()
+				mc.confirmDropColumns(columns, provider)
+				return nil
+			},
+		})
+	}
+
+	modal := NewListModal(mc.g, tr, fmt.Sprintf(tr.ModalTitleDeadColumnsFound, len(dead)), items,
+		func() { mc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+	mc.openModal(modal)
+}
+
+// confirmDropColumns warns that dropping the columns loses their data before
+// writing the migration
+func (mc *MigrationsController) confirmDropColumns(columns []drift.DeadColumn, provider string) {
+	tr := mc.c.GetTranslationSet()
+
+	modal := NewConfirmModal(mc.g, tr, tr.ModalTitleDropDeadColumns,
+		drift.DropColumnsSQL(provider, columns)+"\n"+tr.ModalMsgDropDeadColumnsWarning,
+		func() {
+			mc.closeModal()
+			mc.createDropColumnsMigration(columns, provider)
+		},
+		func() {
+			mc.closeModal()
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+
+	mc.openModal(modal)
+}
+
+// createDropColumnsMigration writes a migration that drops the columns, for
+// review before it is deployed
+func (mc *MigrationsController) createDropColumnsMigration(columns []drift.DeadColumn, provider string) {
+	tr := mc.c.GetTranslationSet()
+
+	name := "drop_dead_columns"
+	if len(columns) == 1 {
+		name = "drop_" + strings.ToLower(columns[0].Table+"_"+columns[0].Column)
+	}
+
+	folderName, migrationFolder, ok := mc.writeMigrationFolder(name, func(string) string {
+		return drift.DropColumnsSQL(provider, columns)
+	})
+	if !ok {
+		return
+	}
+
+	mc.outputCtx.LogAction(tr.LogActionDeadColumns, fmt.Sprintf(tr.ModalMsgManualMigrationCreated, folderName))
+	mc.c.RequestRefresh(types.RefreshMigrations)
+
+	modal := NewMessageModal(mc.g, tr, tr.ModalTitleMigrationCreated,
+		fmt.Sprintf(tr.ModalMsgManualMigrationCreated, folderName),
+		fmt.Sprintf(tr.ModalMsgManualMigrationLocation, migrationFolder),
+		"",
+		tr.ModalMsgDropColumnsReview,
+	).WithStyle(MessageModalStyle{TitleColor: ColorGreen, BorderColor: ColorGreen})
+	mc.openModal(modal)
+}
+
+// MigrateResolve resolves a failed migration
+func (mc *MigrationsController) MigrateResolve() {
+	tr := mc.c.GetTranslationSet()
+
+	// Nothing can be resolved before migration tracking is set up
+	if mc.migrationsCtx.IsMigrationTableMissing() {
+		mc.showInitTrackingOptions()
+		return
+	}
+
+	// Get selected migration
+	selectedMigration := mc.migrationsCtx.GetSelectedMigration()
+	if selectedMigration == nil {
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleNoMigrationSelected,
+			tr.ModalMsgSelectMigrationResolve,
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		mc.openModal(modal)
+		return
+	}
+
+	// DB-Only and checksum mismatch states have fixes of their own
+	if mc.migrationsCtx.GetCurrentTabName() == tr.TabDBOnly {
+		mc.showDBOnlyResolveOptions(*selectedMigration)
+		return
+	}
+	if selectedMigration.ChecksumMismatch {
+		mc.showChecksumMismatchOptions(*selectedMigration)
+		return
+	}
+	if mc.migrationsCtx.GetCurrentTabName() == tr.TabPending {
+		mc.MarkAllPendingApplied()
+		return
+	}
+
+	// Otherwise only In-Transaction (failed) migrations can be resolved
+	if !selectedMigration.IsFailed {
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleCannotResolveMigration,
+			tr.ModalMsgOnlyInTransactionResolve,
+			fmt.Sprintf(tr.ModalMsgMigrationNotFailed, selectedMigration.Name),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		mc.openModal(modal)
+		return
+	}
+
+	mc.showResolveOptions(*selectedMigration, nil)
+}
+
+// showResolveOptions lists the ways to resolve a failed migration. When the
+// failure was just parsed from command output, the usual fix is listed first
+// and each option explains the failure.
+func (mc *MigrationsController) showResolveOptions(migration prisma.Migration, failure *prisma.MigrateFailure) {
+	tr := mc.c.GetTranslationSet()
+
+	migrationName := migration.Name
+
+	applied := ListModalItem{
+		Label:       tr.ListItemMarkApplied,
+		Description: tr.ListItemDescMarkApplied,
+		OnSelect: func() error {
+			mc.closeModal()
+			mc.executeResolve(migrationName, prisma.ResolveApplied)
+			return nil
+		},
+	}
+	rolledBack := ListModalItem{
+		Label:       tr.ListItemMarkRolledBack,
+		Description: tr.ListItemDescMarkRolledBack,
+		OnSelect: func() error {
+			mc.closeModal()
+			mc.executeResolve(migrationName, prisma.ResolveRolledBack)
+			return nil
+		},
+	}
+
+	items := []ListModalItem{applied, rolledBack}
+	title := fmt.Sprintf(tr.ModalTitleResolveMigration, migrationName)
+
+	if failure != nil {
+		title = fmt.Sprintf(tr.ModalTitleResolveFailedMigration, migrationName)
+
+		if failure.SuggestedResolution() == prisma.ResolveApplied {
+			applied.Label += " " + tr.ListItemRecommended
+			items = []ListModalItem{applied, rolledBack}
+		} else {
+			rolledBack.Label += " " + tr.ListItemRecommended
+			items = []ListModalItem{rolledBack, applied}
+		}
+	}
+
+	// Re-running needs the (fixed) SQL file
+	if migration.Path != "" && !migration.IsEmpty {
+		items = append(items, ListModalItem{
+			Label:       tr.ListItemRetryMigration,
+			Description: tr.ListItemDescRetryMigration,
+			OnSelect: func() error {
+				mc.closeModal()
+				mc.retryFailedMigration(migration)
+				return nil
+			},
+		})
+	}
+
+	if failure != nil {
+		guidance := mc.resolveGuidance(failure)
+		for i := range items {
+			items[i].Description = guidance + "\n\n" + items[i].Description
+		}
+	}
+
+	modal := NewListModal(mc.g, tr, title, items,
+		func() { mc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+
+	mc.openModal(modal)
+}
+
+// showInitTrackingOptions lists the ways to create the missing _prisma_migrations
+// table: deploying every migration, or creating just the table for a database
+// that already has the schema
+func (mc *MigrationsController) showInitTrackingOptions() {
+	tr := mc.c.GetTranslationSet()
+
+	var items []ListModalItem
+
+	if !mc.c.IsActionDisabled(config.ActionMigrateDeploy) {
+		items = append(items, ListModalItem{
+			Label:       tr.ListItemInitTrackingDeploy,
+			Description: tr.ListItemDescInitTrackingDeploy,
+			OnSelect: func() error {
+				mc.closeModal()
+				mc.MigrateDeploy()
+				return nil
+			},
+		})
+	}
+
+	items = append(items, ListModalItem{
+		Label:       tr.ListItemInitTableOnly,
+		Description: tr.ListItemDescInitTableOnly,
+		OnSelect: func() error {
+			mc.closeModal()
+			mc.updateMigrationHistory(prisma.CreateMigrationsTable,
+				tr.LogActionTableCreated, tr.LogMsgTableCreated)
+			return nil
+		},
+	})
+
+	if !mc.c.IsActionDisabled(config.ActionBulkResolve) {
+		items = append(items, ListModalItem{
+			Label:       tr.ListItemInitBaseline,
+			Description: tr.ListItemDescInitBaseline,
+			OnSelect: func() error {
+				mc.closeModal()
+				mc.MarkAllPendingApplied()
+				return nil
+			},
+		})
+	}
+
+	modal := NewListModal(mc.g, tr, tr.ModalTitleInitMigrationTracking, items,
+		func() { mc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+
+	mc.openModal(modal)
+}
+
+// MarkAllPendingApplied lists the pending migrations and marks them all as
+// applied without running them, for a database that already matches them
+// (e.g. one managed outside Prisma until now)
+func (mc *MigrationsController) MarkAllPendingApplied() {
+	tr := mc.c.GetTranslationSet()
+
+	if mc.c.RejectDisabledAction(config.ActionMigrateResolve) || mc.c.RejectDisabledAction(config.ActionBulkResolve) {
+		return
+	}
+
+	pending := mc.migrationsCtx.GetCategory().Pending
+	if len(pending) == 0 {
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleMarkAllApplied,
+			tr.ModalMsgNoPendingToMark,
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		mc.openModal(modal)
+		return
+	}
+
+	names := make([]string, len(pending))
+	for i, m := range pending {
+		names[i] = m.Name
+	}
+
+	items := []ListModalItem{
+		{
+			Label:       fmt.Sprintf(tr.ListItemMarkAllApplied, len(names)),
+			Description: tr.ListItemDescMarkAllApplied,
+			OnSelect: func() error {
+				mc.closeModal()
+				mc.markAppliedInOrder(names, 0)
+				return nil
+			},
+		},
+		{
+			Label:       tr.ListItemImportReceipt,
+			Description: tr.ListItemDescImportReceipt,
+			OnSelect: func() error {
+				mc.closeModal()
+				mc.showReceiptPathInput()
+				return nil
+			},
+		},
+	}
+	for _, name := range names {
+		items = append(items, ListModalItem{
+			Label:       "  " + name,
+			Description: tr.ListItemDescWillMarkApplied,
+		})
+	}
+
+	modal := NewListModal(mc.g, tr, tr.ModalTitleMarkAllApplied, items,
+		func() { mc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+
+	mc.openModal(modal)
+}
+
+// showReceiptPathInput asks for the receipt of migrations applied outside lazyprisma
+func (mc *MigrationsController) showReceiptPathInput() {
+	tr := mc.c.GetTranslationSet()
+
+	modal := NewInputModal(mc.g, tr, tr.ModalTitleEnterReceiptPath,
+		func(input string) {
+			mc.closeModal()
+			mc.ImportReceipt(strings.TrimSpace(input))
+		},
+		func() {
+			mc.closeModal()
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan}).
+		WithSubtitle(tr.ModalMsgReceiptPathHint).
+		WithRequired(true).
+		OnValidationFail(func(reason string) {
+			mc.closeModal()
+			errorModal := NewMessageModal(mc.g, tr, tr.ModalTitleValidationFailed,
+				reason,
+			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+			mc.openModal(errorModal)
+		})
+
+	mc.openModal(modal)
+}
+
+// ImportReceipt reads a receipt of migrations applied outside lazyprisma
+// (path is relative to the project root) and offers to mark the pending ones
+// among them as applied, in local migration order
+func (mc *MigrationsController) ImportReceipt(path string) {
+	tr := mc.c.GetTranslationSet()
+
+	if mc.c.RejectDisabledAction(config.ActionMigrateResolve) || mc.c.RejectDisabledAction(config.ActionBulkResolve) {
+		return
+	}
+
+	showError := func(lines ...string) {
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleImportReceipt, lines...).
+			WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		mc.openModal(modal)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		showError(tr.ErrorFailedGetWorkingDirectory, err.Error())
+		return
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(cwd, path)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		showError(fmt.Sprintf(tr.ModalMsgReceiptReadFailed, relativePath(cwd, path)), err.Error())
+		return
+	}
+	receipt := prisma.ParseReceipt(string(content))
+	if len(receipt) == 0 {
+		showError(fmt.Sprintf(tr.ModalMsgReceiptEmpty, relativePath(cwd, path)))
+		return
+	}
+
+	inReceipt := make(map[string]bool, len(receipt))
+	for _, name := range receipt {
+		inReceipt[name] = true
+	}
+
+	category := mc.migrationsCtx.GetCategory()
+	local := make(map[string]bool, len(category.Local))
+	for _, mig := range category.Local {
+		local[mig.Name] = true
+	}
+
+	// Pending migrations of the receipt, oldest first; pending ones before the
+	// last of them that the receipt leaves out are flagged
+	var names []string
+	last := -1
+	for i, mig := range category.Pending {
+		if inReceipt[mig.Name] {
+			names = append(names, mig.Name)
+			last = i
+		}
+	}
+	if len(names) == 0 {
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleImportReceipt,
+			fmt.Sprintf(tr.ModalMsgReceiptNothingPending, len(receipt)),
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		mc.openModal(modal)
+		return
+	}
+
+	items := []ListModalItem{{
+		Label:       fmt.Sprintf(tr.ListItemMarkReceiptApplied, len(names)),
+		Description: tr.ListItemDescMarkReceipt,
+		OnSelect: func() error {
+			mc.closeModal()
+			mc.markAppliedInOrder(names, 0)
+			return nil
+		},
+	}}
+	for i, mig := range category.Pending[:last+1] {
+		if inReceipt[mig.Name] {
+			items = append(items, ListModalItem{
+				Label:       style.Green("✓") + " " + mig.Name,
+				Description: tr.ListItemDescWillMarkApplied,
+			})
+		} else if i < last {
+			items = append(items, ListModalItem{
+				Label:       style.Yellow("!") + " " + mig.Name,
+				Description: tr.ListItemDescReceiptGap,
+			})
+		}
+	}
+	pending := make(map[string]bool, len(names))
+	for _, name := range names {
+		pending[name] = true
+	}
+	for _, name := range receipt {
+		switch {
+		case pending[name]:
+			// Listed above
+		case local[name]:
+			items = append(items, ListModalItem{
+				Label:       style.Gray("- " + name),
+				Description: tr.ListItemDescReceiptApplied,
+			})
+		default:
+			items = append(items, ListModalItem{
+				Label:       style.Yellow("?") + " " + name,
+				Description: tr.ListItemDescReceiptUnknown,
+			})
+		}
+	}
+
+	modal := NewListModal(mc.g, tr, tr.ModalTitleImportReceipt, items,
+		func() { mc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+
+	mc.openModal(modal)
+}
+
+// markAppliedInOrder runs migrate resolve --applied for names[done:], one at a
+// time, stopping at the first failure
+func (mc *MigrationsController) markAppliedInOrder(names []string, done int) {
+	tr := mc.c.GetTranslationSet()
+
+	name := names[done]
+	stopped := func(out *context.OutputContext, reason string) {
+		mc.c.FinishCommand()
+		mc.c.RequestRefresh(types.RefreshMigrations)
+		out.LogAction(tr.LogActionMigrateResolveFailed, reason)
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleMigrateResolveFailed,
+			fmt.Sprintf(tr.ModalMsgMarkAllStopped, name, done, len(names)),
+			tr.ModalMsgCheckOutputPanel,
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed}).WithViewOutput(mc.c.ShowFailedOutput)
+		mc.openModal(modal)
+	}
+
+	mc.runStreamCmd(AsyncCommandOpts{
+		Name:          "Migrate Resolve",
+		LogAction:     tr.LogActionMigrateResolve,
+		LogDetail:     fmt.Sprintf(tr.LogMsgMarkingAppliedProgress, done+1, len(names), name),
+		ErrorTitle:    tr.ModalTitleMigrateResolveError,
+		ErrorStartMsg: tr.ModalMsgFailedStartMigrateResolve,
+		PrismaArgs:    prisma.MigrateResolveArgs(name, prisma.ResolveApplied),
+		OnSuccess: func(out *context.OutputContext, cwd string) {
+			mc.c.FinishCommand()
+			if done+1 < len(names) {
+				mc.markAppliedInOrder(names, done+1)
+				return
+			}
+			mc.c.RequestRefresh(types.RefreshMigrations)
+			out.LogAction(tr.LogActionMigrateResolveComplete, fmt.Sprintf(tr.ModalMsgMarkedAllApplied, len(names)))
+			modal := NewMessageModal(mc.g, tr, tr.ModalTitleMigrateResolveSuccess,
+				fmt.Sprintf(tr.ModalMsgMarkedAllApplied, len(names)),
+			).WithStyle(MessageModalStyle{TitleColor: ColorGreen, BorderColor: ColorGreen})
+			mc.openModal(modal)
+		},
+		OnFailure: func(out *context.OutputContext, cwd string, exitCode int) {
+			stopped(out, fmt.Sprintf(tr.LogMsgMigrateResolveFailedCode, exitCode))
+		},
+		OnError: func(out *context.OutputContext, cwd string, err error) {
+			stopped(out, err.Error())
+		},
+	})
+}
+
+// showDBOnlyResolveOptions lists the fixes for a migration that is recorded in
+// the database but missing from the migrations folder
+func (mc *MigrationsController) showDBOnlyResolveOptions(migration prisma.Migration) {
+	tr := mc.c.GetTranslationSet()
+
+	migrationName := migration.Name
+	var items []ListModalItem
+
+	// migrate resolve only rolls back failed migrations
+	if migration.IsFailed {
+		items = append(items, ListModalItem{
+			Label:       tr.ListItemMarkRolledBack,
+			Description: tr.ListItemDescMarkRolledBack,
+			OnSelect: func() error {
+				mc.closeModal()
+				mc.executeResolve(migrationName, prisma.ResolveRolledBack)
+				return nil
+			},
+		})
+	}
+
+	if !mc.c.IsActionDisabled(config.ActionDeleteHistoryRow) {
+		items = append(items, ListModalItem{
+			Label:       tr.ListItemDeleteHistoryRow,
+			Description: tr.ListItemDescDeleteHistoryRow,
+			OnSelect: func() error {
+				mc.closeModal()
+				mc.confirmDeleteHistoryRow(migration)
+				return nil
+			},
+		})
+	}
+
+	modal := NewListModal(mc.g, tr, fmt.Sprintf(tr.ModalTitleResolveDBOnlyMigration, migrationName), items,
+		func() { mc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+
+	mc.openModal(modal)
+}
+
+// VerifyChecksums reads _prisma_migrations again and recomputes the checksum
+// of every applied migration's migration.sql the way Prisma does, listing each
+// one that doesn't match, whatever the Migrations panel flagged when it loaded
+func (mc *MigrationsController) VerifyChecksums() {
+	tr := mc.c.GetTranslationSet()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleError,
+			tr.ErrorFailedGetWorkingDir,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		mc.openModal(modal)
+		return
+	}
+
+	if !mc.c.TryStartCommand("Verify Checksums") {
+		mc.c.LogCommandBlocked("Verify Checksums")
+		return
+	}
+
+	mc.outputCtx.LogAction(tr.LogActionVerifyChecksums, tr.LogMsgVerifyingChecksums)
+
+	go func() {
+		history, err := mc.migrationsCtx.MigrationHistory()
+		var report *prisma.ChecksumReport
+		if err == nil {
+			report = prisma.VerifyChecksums(cwd, history)
+		}
+
+		mc.c.OnUIThread(func() error {
+			mc.c.FinishCommand()
+
+			if err != nil {
+				mc.outputCtx.LogActionRed(tr.LogActionVerifyChecksums, err.Error())
+				modal := NewMessageModal(mc.g, tr, tr.ModalTitleVerifyChecksums,
+					tr.ModalMsgVerifyChecksumsFailed,
+					err.Error(),
+				).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+				mc.openModal(modal)
+				return nil
+			}
+
+			mc.outputCtx.LogAction(tr.LogActionVerifyChecksums, fmt.Sprintf(tr.LogMsgChecksumsVerified,
+				len(report.Checks), report.Count(prisma.ChecksumMismatch), report.Count(prisma.ChecksumMissing)))
+			mc.showChecksumReport(cwd, report)
+
+			// The panel's flags may predate an edit; reload them to match
+			mc.c.RequestRefresh(types.RefreshMigrations)
+			return nil
+		})
+	}()
+}
+
+// showChecksumReport lists the applied migrations whose migration.sql was
+// edited or removed; selecting an edited one offers its fixes
+func (mc *MigrationsController) showChecksumReport(cwd string, report *prisma.ChecksumReport) {
+	tr := mc.c.GetTranslationSet()
+
+	var lineEndings string
+	if n := report.Count(prisma.ChecksumLineEndings); n > 0 {
+		lineEndings = fmt.Sprintf(tr.ModalMsgChecksumsLineEndings, n)
+	}
+
+	problems := report.Problems()
+	if len(problems) == 0 {
+		lines := []string{fmt.Sprintf(tr.ModalMsgChecksumsAllMatch, len(report.Checks))}
+		if lineEndings != "" {
+			lines = append(lines, lineEndings)
+		}
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleVerifyChecksums,
+			lines...,
+		).WithStyle(MessageModalStyle{TitleColor: ColorGreen, BorderColor: ColorGreen})
+		mc.openModal(modal)
+		return
+	}
+
+	var items []ListModalItem
+	for _, check := range problems {
+		if check.Status == prisma.ChecksumMissing {
+			items = append(items, ListModalItem{
+				Label:       check.Name + " " + style.Red(tr.ChecksumStatusMissing),
+				Description: tr.ListItemDescChecksumMissing + "\n\n" + tr.DetailsHistoryChecksumLabel + check.Recorded,
+				OnSelect: func() error {
+					mc.closeModal()
+					return nil
+				},
+			})
+			continue
+		}
+
+		migration := prisma.Migration{
+			Name:       check.Name,
+			Path:       filepath.Join(prisma.MigrationsDir(cwd), check.Name),
+			Checksum:   check.Local,
+			DBChecksum: check.Recorded,
+		}
+		description := tr.ListItemDescChecksumEdited + "\n\n" +
+			tr.DetailsLocalChecksumLabel + check.Local + "\n" +
+			tr.DetailsHistoryChecksumLabel + check.Recorded
+		if lineEndings != "" {
+			description += "\n\n" + lineEndings
+		}
+		items = append(items, ListModalItem{
+			Label:       check.Name + " " + style.Orange(tr.ChecksumStatusEdited),
+			Description: description,
+			OnSelect: func() error {
+				mc.closeModal()
+				mc.showChecksumMismatchOptions(migration)
+				return nil
+			},
+		})
+	}
+
+	modal := NewListModal(mc.g, tr, fmt.Sprintf(tr.ModalTitleChecksumReport, len(problems), len(report.Checks)), items,
+		func() { mc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+	mc.openModal(modal)
+}
+
+// showChecksumMismatchOptions lists the fixes for an applied migration whose
+// migration.sql was edited afterwards
+func (mc *MigrationsController) showChecksumMismatchOptions(migration prisma.Migration) {
+	tr := mc.c.GetTranslationSet()
+
+	sqlPath := filepath.Join(migration.Path, "migration.sql")
+	var items []ListModalItem
+
+	// Only an uncommitted edit can be undone from git
+	if cwd, err := os.Getwd(); err == nil && git.IsFileModified(cwd, sqlPath) {
+		items = append(items, ListModalItem{
+			Label:       tr.ListItemRestoreMigration,
+			Description: tr.ListItemDescRestoreMigration,
+			OnSelect: func() error {
+				mc.closeModal()
+				mc.restoreMigrationFile(migration.Name, sqlPath)
+				return nil
+			},
+		})
+	}
+
+	items = append(items, ListModalItem{
+		Label:       tr.ListItemAcceptLocalChecksum,
+		Description: tr.ListItemDescAcceptLocalChecksum,
+		OnSelect: func() error {
+			mc.closeModal()
+			mc.confirmAcceptLocalChecksum(migration)
+			return nil
+		},
+	})
+
+	modal := NewListModal(mc.g, tr, fmt.Sprintf(tr.ModalTitleResolveChecksumMismatch, migration.Name), items,
+		func() { mc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+
+	mc.openModal(modal)
+}
+
+// restoreMigrationFile discards the uncommitted edit of an applied migration
+func (mc *MigrationsController) restoreMigrationFile(migrationName, sqlPath string) {
+	tr := mc.c.GetTranslationSet()
+
+	cwd, err := os.Getwd()
+	if err == nil {
+		err = git.RestoreFile(cwd, sqlPath)
+	}
+	if err != nil {
+		mc.outputCtx.LogActionRed(tr.ModalTitleRestoreMigrationFailed, err.Error())
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleRestoreMigrationFailed,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		mc.openModal(modal)
+		return
+	}
+
+	mc.outputCtx.LogAction(tr.LogActionMigrationRestored, fmt.Sprintf(tr.LogMsgMigrationRestored, migrationName))
+	mc.c.RequestRefresh(types.RefreshMigrations)
+}
+
+// confirmAcceptLocalChecksum asks before recording the edited file's checksum as applied
+func (mc *MigrationsController) confirmAcceptLocalChecksum(migration prisma.Migration) {
+	tr := mc.c.GetTranslationSet()
+
+	modal := NewConfirmModal(mc.g, tr, tr.ModalTitleAcceptChecksum,
+		fmt.Sprintf(tr.ModalMsgConfirmAcceptChecksum, migration.Name),
+		func() {
+			mc.closeModal()
+			mc.updateMigrationHistory(func(db *sql.DB, provider string) error {
+				return prisma.UpdateMigrationChecksum(db, provider, migration.Name, migration.Checksum)
+			}, tr.LogActionChecksumAccepted, fmt.Sprintf(tr.LogMsgChecksumAccepted, migration.Name))
+		},
+		func() {
+			mc.closeModal()
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+
+	mc.openModal(modal)
+}
+
+// FixMerge shows how the migrations a git merge duplicated or reordered would
+// be renamed or removed, and applies that after confirming
+func (mc *MigrationsController) FixMerge() {
+	tr := mc.c.GetTranslationSet()
+
+	// Without the applied migrations, an applied one could be renamed
+	if !mc.migrationsCtx.IsDBConnected() {
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleDBConnectionRequired,
+			tr.ErrorNoDBConnectionDetected,
+			tr.ModalMsgFixMergeNeedsDB,
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		mc.openModal(modal)
+		return
+	}
+
+	plan := mc.migrationsCtx.MergeFixPlan()
+	if plan == nil {
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleFixMerge,
+			tr.ModalMsgFixMergeNothing,
+		).WithStyle(MessageModalStyle{TitleColor: ColorGreen, BorderColor: ColorGreen})
+		mc.openModal(modal)
+		return
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleError,
+			tr.ErrorFailedGetWorkingDir,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		mc.openModal(modal)
+		return
+	}
+
+	lines := []string{tr.ModalMsgFixMergePlan, ""}
+	for _, fix := range plan.Fixes {
+		lines = append(lines, "• "+context.DescribeMergeFix(fix), "  "+context.MergeFixReason(tr, fix))
+	}
+	lines = append(lines, "", tr.ModalMsgFixMergeWarning)
+
+	modal := NewConfirmModal(mc.g, tr, tr.ModalTitleFixMerge, strings.Join(lines, "\n"),
+		func() {
+			mc.closeModal()
+			done, err := prisma.FixMerge(cwd, plan)
+			for _, fix := range plan.Fixes[:done] {
+				mc.outputCtx.LogAction(tr.LogActionFixMerge, context.DescribeMergeFix(fix))
+			}
+			mc.c.RequestRefresh(types.RefreshMigrations)
+			if err != nil {
+				mc.outputCtx.LogActionRed(tr.LogActionFixMerge, err.Error())
+				modal := NewMessageModal(mc.g, tr, tr.ModalTitleFixMergeFailed,
+					fmt.Sprintf(tr.ModalMsgFixMergeFailed, done, len(plan.Fixes)),
+					err.Error(),
+				).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+				mc.openModal(modal)
+			}
+		},
+		func() { mc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+	mc.openModal(modal)
+}
+
+// DeleteHistoryRow removes the selected migration's row from _prisma_migrations,
+// for orphaned history entries that migrate resolve can't clean up
+func (mc *MigrationsController) DeleteHistoryRow() {
+	tr := mc.c.GetTranslationSet()
+
+	if mc.c.RejectDisabledAction(config.ActionDeleteHistoryRow) {
+		return
+	}
+
+	selected := mc.migrationsCtx.GetSelectedMigration()
+	if selected == nil {
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleNoMigrationSelected,
+			tr.ModalMsgSelectHistoryRow,
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		mc.openModal(modal)
+		return
+	}
+
+	if !mc.migrationsCtx.IsDBConnected() {
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleDBConnectionRequired,
+			tr.ErrorNoDBConnectionDetected,
+			tr.ErrorEnsureDBAccessible,
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		mc.openModal(modal)
+		return
+	}
+
+	for _, m := range mc.migrationsCtx.GetCategory().Pending {
+		if m.Name == selected.Name {
+			modal := NewMessageModal(mc.g, tr, tr.ModalTitleDeleteHistoryRow,
+				fmt.Sprintf(tr.ModalMsgNoHistoryRow, selected.Name),
+			).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+			mc.openModal(modal)
+			return
+		}
+	}
+
+	mc.confirmDeleteHistoryRow(*selected)
+}
+
+// confirmDeleteHistoryRow warns about what deleting a migration's history row
+// does and asks for its name to be typed before removing it
+func (mc *MigrationsController) confirmDeleteHistoryRow(migration prisma.Migration) {
 	tr := mc.c.GetTranslationSet()
 
-	modal := NewInputModal(mc.g, tr, tr.ModalTitleEnterMigrationName,
-		func(input string) {
-			// Replace spaces with underscores
-			migrationName := strings.ReplaceAll(strings.TrimSpace(input), " ", "_")
+	if mc.c.RejectDisabledAction(config.ActionDeleteHistoryRow) {
+		return
+	}
 
-			// Close input modal
-			mc.closeModal()
+	migrationName := migration.Name
+	warning := fmt.Sprintf(tr.ModalMsgDeleteHistoryRowWarning, migrationName)
+	if migration.Path != "" {
+		// Still in the migrations folder, so deploy picks it up again
+		warning += " " + tr.ModalMsgDeleteHistoryRowLocal
+	} else {
+		warning += " " + tr.ModalMsgDeleteHistoryRowDBOnly
+	}
 
-			// Create manual migration
-			mc.createManualMigration(migrationName)
+	modal := NewInputModal(mc.g, tr, tr.ModalTitleDeleteHistoryRow,
+		func(input string) {
+			mc.closeModal()
+			if strings.TrimSpace(input) != migrationName {
+				errorModal := NewMessageModal(mc.g, tr, tr.ModalTitleDeleteHistoryRow,
+					tr.ModalMsgHistoryRowConfirmMismatch,
+				).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+				mc.openModal(errorModal)
+				return
+			}
+			mc.updateMigrationHistory(func(db *sql.DB, provider string) error {
+				return prisma.DeleteMigrationRow(db, provider, migrationName)
+			}, tr.LogActionHistoryRowDeleted, fmt.Sprintf(tr.LogMsgHistoryRowDeleted, migrationName))
 		},
 		func() {
-			// Cancel - just close modal
 			mc.closeModal()
 		},
-	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan}).
-		WithSubtitle(tr.ModalMsgSpacesReplaced).
-		WithRequired(true).
-		OnValidationFail(func(reason string) {
-			// Validation failed - show error
-			mc.closeModal()
-			errorModal := NewMessageModal(mc.g, tr, tr.ModalTitleValidationFailed,
-				reason,
-			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
-			mc.openModal(errorModal)
-		})
+	).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed}).
+		WithSubtitle(warning + " " + fmt.Sprintf(tr.ModalMsgTypeMigrationToConfirm, migrationName))
 
 	mc.openModal(modal)
 }
 
-// MigrateResolve resolves a failed migration
-func (mc *MigrationsController) MigrateResolve() {
+// updateMigrationHistory applies a change to _prisma_migrations through a
+// direct database connection, for fixes migrate resolve can't express
+func (mc *MigrationsController) updateMigrationHistory(update func(db *sql.DB, provider string) error, logAction, logDetail string) {
 	tr := mc.c.GetTranslationSet()
 
-	// Get selected migration
-	selectedMigration := mc.migrationsCtx.GetSelectedMigration()
-	if selectedMigration == nil {
-		modal := NewMessageModal(mc.g, tr, tr.ModalTitleNoMigrationSelected,
-			tr.ModalMsgSelectMigrationResolve,
-		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
-		mc.openModal(modal)
+	if mc.c.RejectOpenTransaction("Update Migration History") {
 		return
 	}
 
-	// Check if migration is failed (only In-Transaction migrations can be resolved)
-	if !selectedMigration.IsFailed {
-		modal := NewMessageModal(mc.g, tr, tr.ModalTitleCannotResolveMigration,
-			tr.ModalMsgOnlyInTransactionResolve,
-			fmt.Sprintf(tr.ModalMsgMigrationNotFailed, selectedMigration.Name),
-		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
-		mc.openModal(modal)
+	if !mc.c.TryStartCommand("Update Migration History") {
+		mc.c.LogCommandBlocked("Update Migration History")
 		return
 	}
 
-	// Show ListModal with resolve options
-	migrationName := selectedMigration.Name
+	go func() {
+		err := func() error {
+			cwd, err := os.Getwd()
+			if err != nil {
+				return err
+			}
+			ds, err := prisma.GetDatasource(cwd)
+			if err != nil {
+				return err
+			}
+			if ds.URL == "" {
+				return fmt.Errorf("no database URL configured")
+			}
+			client, err := database.NewClientFromDSN(ds.Provider, ds.URL)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			return update(client.DB(), ds.Provider)
+		}()
 
-	items := []ListModalItem{
-		{
-			Label:       tr.ListItemMarkApplied,
-			Description: tr.ListItemDescMarkApplied,
-			OnSelect: func() error {
-				mc.closeModal()
-				mc.executeResolve(migrationName, "applied")
-				return nil
-			},
-		},
-		{
-			Label:       tr.ListItemMarkRolledBack,
-			Description: tr.ListItemDescMarkRolledBack,
-			OnSelect: func() error {
-				mc.closeModal()
-				mc.executeResolve(migrationName, "rolled-back")
+		mc.c.OnUIThread(func() error {
+			mc.c.FinishCommand()
+			if err != nil {
+				mc.outputCtx.LogActionRed(tr.ModalTitleHistoryUpdateFailed, err.Error())
+				modal := NewMessageModal(mc.g, tr, tr.ModalTitleHistoryUpdateFailed,
+					err.Error(),
+				).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+				mc.openModal(modal)
 				return nil
-			},
-		},
+			}
+
+			mc.outputCtx.LogAction(logAction, logDetail)
+			mc.c.RequestRefresh(types.RefreshMigrations)
+			return nil
+		})
+	}()
+}
+
+// resolveGuidance explains a parsed failure and which resolution fits it
+func (mc *MigrationsController) resolveGuidance(failure *prisma.MigrateFailure) string {
+	tr := mc.c.GetTranslationSet()
+
+	var b strings.Builder
+	code := strings.Trim(failure.Code+", "+failure.DBErrorCode, ", ")
+	summary := fmt.Sprintf(tr.ResolveGuidanceFailed, failure.Migration)
+	if code != "" {
+		summary += " (" + code + ")"
 	}
+	b.WriteString(style.Red(summary))
+	if failure.DBError != "" {
+		b.WriteString("\n" + failure.DBError)
+	}
+	b.WriteString("\n\n")
 
-	modal := NewListModal(mc.g, tr, fmt.Sprintf(tr.ModalTitleResolveMigration, migrationName), items,
-		func() { mc.closeModal() },
-	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+	if failure.AlreadyExists {
+		b.WriteString(tr.ResolveGuidanceAlreadyExists)
+	} else {
+		b.WriteString(tr.ResolveGuidanceRolledBack)
+	}
 
-	mc.openModal(modal)
+	// MySQL commits each DDL statement, so a failed migration can be half-applied
+	if cwd, err := os.Getwd(); err == nil {
+		if provider, err := prisma.GetProvider(cwd); err == nil && provider == "mysql" {
+			b.WriteString("\n\n" + style.Yellow(tr.ResolveGuidanceMySQL))
+		}
+	}
+
+	return b.String()
+}
+
+// offerResolveForFailure opens the resolve options for the migration named in a
+// failed command's output, once the panels show the failed state
+func (mc *MigrationsController) offerResolveForFailure(out *context.OutputContext, output string) bool {
+	tr := mc.c.GetTranslationSet()
+
+	failure := prisma.ParseMigrateFailure(output)
+	if failure == nil {
+		return false
+	}
+
+	out.LogActionRed(tr.LogActionFailedMigrationDetected, fmt.Sprintf(tr.LogMsgFailedMigrationDetected, failure.Migration))
+
+	show := func() {
+		migration := prisma.Migration{Name: failure.Migration}
+		for _, m := range mc.migrationsCtx.GetCategory().Local {
+			if m.Name == failure.Migration {
+				migration = m
+				break
+			}
+		}
+		mc.showResolveOptions(migration, failure)
+	}
+
+	mc.c.RequestRefresh(types.RefreshMigrations, show)
+	return true
 }
 
 // executeResolve runs npx prisma migrate resolve with the specified action
@@ -458,20 +2465,20 @@ func (mc *MigrationsController) executeResolve(migrationName string, action stri
 	tr := mc.c.GetTranslationSet()
 
 	actionLabel := tr.ActionLabelApplied
-	if action == "rolled-back" {
+	if action == prisma.ResolveRolledBack {
 		actionLabel = tr.ActionLabelRolledBack
 	}
 
 	mc.runStreamCmd(AsyncCommandOpts{
 		Name:          "Migrate Resolve",
-		Args:          []string{"npx", "prisma", "migrate", "resolve", "--" + action, migrationName},
 		LogAction:     tr.LogActionMigrateResolve,
 		LogDetail:     fmt.Sprintf(tr.LogMsgMarkingMigration, actionLabel, migrationName),
 		ErrorTitle:    tr.ModalTitleMigrateResolveError,
 		ErrorStartMsg: tr.ModalMsgFailedStartMigrateResolve,
+		PrismaArgs:    prisma.MigrateResolveArgs(migrationName, action),
 		OnSuccess: func(out *context.OutputContext, cwd string) {
 			mc.c.FinishCommand()
-			mc.c.RefreshAll()
+			mc.c.RequestRefresh(types.RefreshMigrations)
 			out.LogAction(tr.LogActionMigrateResolveComplete, fmt.Sprintf(tr.LogMsgMigrationMarked, actionLabel))
 			modal := NewMessageModal(mc.g, tr, tr.ModalTitleMigrateResolveSuccess,
 				fmt.Sprintf(tr.ModalMsgMigrationMarkedSuccess, actionLabel),
@@ -480,14 +2487,106 @@ func (mc *MigrationsController) executeResolve(migrationName string, action stri
 		},
 		OnFailure: func(out *context.OutputContext, cwd string, exitCode int) {
 			mc.c.FinishCommand()
-			mc.c.RefreshAll()
+			mc.c.RequestRefresh(types.RefreshMigrations)
 			out.LogAction(tr.LogActionMigrateResolveFailed, fmt.Sprintf(tr.LogMsgMigrateResolveFailedCode, exitCode))
 			modal := NewMessageModal(mc.g, tr, tr.ModalTitleMigrateResolveFailed,
 				fmt.Sprintf(tr.ModalMsgMigrateResolveFailedWithCode, exitCode),
 				tr.ModalMsgCheckOutputPanel,
+			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed}).WithViewOutput(mc.c.ShowFailedOutput)
+			mc.openModal(modal)
+		},
+		OnError: func(out *context.OutputContext, cwd string, err error) {
+			mc.c.FinishCommand()
+			out.LogAction(tr.LogActionMigrateResolveError, err.Error())
+			modal := NewMessageModal(mc.g, tr, tr.ModalTitleMigrateResolveError,
+				tr.ModalMsgFailedRunMigrateResolve,
+				err.Error(),
+			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+			mc.openModal(modal)
+		},
+	})
+}
+
+// retryFailedMigration runs a failed migration's SQL again with db execute and,
+// if it succeeds, marks the migration as applied. Later migrations are left
+// for the next deploy.
+func (mc *MigrationsController) retryFailedMigration(migration prisma.Migration) {
+	tr := mc.c.GetTranslationSet()
+
+	if mc.c.RejectOpenTransaction("Retry Migration") {
+		return
+	}
+
+	sqlPath := filepath.Join(migration.Path, "migration.sql")
+	cwd, _ := os.Getwd()
+
+	mc.runStreamCmd(AsyncCommandOpts{
+		Name:          "Retry Migration",
+		LogAction:     tr.LogActionRetryMigration,
+		LogDetail:     fmt.Sprintf(tr.LogMsgRetryingMigration, migration.Name),
+		ErrorTitle:    tr.ModalTitleRetryMigrationFailed,
+		ErrorStartMsg: tr.ModalMsgFailedStartRetryMigration,
+		PrismaArgs:    prisma.DBExecuteArgs(sqlPath, prisma.SchemaArg(cwd)),
+		OnSuccess: func(out *context.OutputContext, cwd string) {
+			mc.c.FinishCommand()
+			out.LogAction(tr.LogActionRetryMigration, fmt.Sprintf(tr.LogMsgRetriedMigrationSQL, migration.Name))
+			mc.markExecutedMigrationApplied(migration.Name, tr.ModalTitleRetryMigrationSuccess,
+				fmt.Sprintf(tr.ModalMsgRetryMigrationSuccess, migration.Name),
+				tr.ModalMsgRetryMigrationDeployRest,
+			)
+		},
+		OnFailure: func(out *context.OutputContext, cwd string, exitCode int) {
+			mc.c.FinishCommand()
+			out.LogActionRed(tr.LogActionRetryMigrationFailed, fmt.Sprintf(tr.LogMsgRetryMigrationFailedCode, migration.Name, exitCode))
+			modal := NewMessageModal(mc.g, tr, tr.ModalTitleRetryMigrationFailed,
+				fmt.Sprintf(tr.ModalMsgRetryMigrationFailed, migration.Name),
+				tr.ModalMsgCheckOutputPanel,
+			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed}).WithViewOutput(mc.c.ShowFailedOutput)
+			mc.openModal(modal)
+		},
+		OnError: func(out *context.OutputContext, cwd string, err error) {
+			mc.c.FinishCommand()
+			out.LogActionRed(tr.LogActionRetryMigrationFailed, err.Error())
+			modal := NewMessageModal(mc.g, tr, tr.ModalTitleRetryMigrationFailed,
+				tr.ModalMsgFailedStartRetryMigration,
+				err.Error(),
 			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
 			mc.openModal(modal)
 		},
+	})
+}
+
+// markExecutedMigrationApplied records a migration whose SQL ran with db execute
+// as applied, then shows successLines
+func (mc *MigrationsController) markExecutedMigrationApplied(migrationName, successTitle string, successLines ...string) {
+	tr := mc.c.GetTranslationSet()
+
+	mc.runStreamCmd(AsyncCommandOpts{
+		Name:          "Migrate Resolve",
+		LogAction:     tr.LogActionMigrateResolve,
+		LogDetail:     fmt.Sprintf(tr.LogMsgMarkingMigration, tr.ActionLabelApplied, migrationName),
+		ErrorTitle:    tr.ModalTitleMigrateResolveError,
+		ErrorStartMsg: tr.ModalMsgFailedStartMigrateResolve,
+		PrismaArgs:    prisma.MigrateResolveArgs(migrationName, prisma.ResolveApplied),
+		OnSuccess: func(out *context.OutputContext, cwd string) {
+			mc.c.FinishCommand()
+			mc.c.RequestRefresh(types.RefreshMigrations)
+			out.LogAction(tr.LogActionMigrateResolveComplete, fmt.Sprintf(tr.LogMsgMigrationMarked, tr.ActionLabelApplied))
+			modal := NewMessageModal(mc.g, tr, successTitle,
+				successLines...,
+			).WithStyle(MessageModalStyle{TitleColor: ColorGreen, BorderColor: ColorGreen})
+			mc.openModal(modal)
+		},
+		OnFailure: func(out *context.OutputContext, cwd string, exitCode int) {
+			mc.c.FinishCommand()
+			mc.c.RequestRefresh(types.RefreshMigrations)
+			out.LogAction(tr.LogActionMigrateResolveFailed, fmt.Sprintf(tr.LogMsgMigrateResolveFailedCode, exitCode))
+			modal := NewMessageModal(mc.g, tr, tr.ModalTitleMigrateResolveFailed,
+				fmt.Sprintf(tr.ModalMsgRetryMigrationResolveFailed, migrationName),
+				tr.ModalMsgCheckOutputPanel,
+			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed}).WithViewOutput(mc.c.ShowFailedOutput)
+			mc.openModal(modal)
+		},
 		OnError: func(out *context.OutputContext, cwd string, err error) {
 			mc.c.FinishCommand()
 			out.LogAction(tr.LogActionMigrateResolveError, err.Error())
@@ -567,10 +2666,237 @@ func (mc *MigrationsController) executeDeleteMigration(path, name string) {
 	mc.outputCtx.LogAction(tr.LogActionDeleted, fmt.Sprintf(tr.LogMsgMigrationDeleted, name))
 
 	// Refresh to update list
-	mc.c.RefreshAll()
+	mc.c.RequestRefresh(types.RefreshMigrations)
 
 	modal := NewMessageModal(mc.g, tr, tr.ModalTitleDeleted,
 		tr.ModalMsgMigrationDeletedSuccess,
 	).WithStyle(MessageModalStyle{TitleColor: ColorGreen, BorderColor: ColorGreen})
 	mc.openModal(modal)
 }
+
+// SplitMigration writes the SQL shown in the Migration Preview tab as several
+// smaller manual migrations. Tables are picked for each migration in turn, and
+// each one is written to its own timestamped folder.
+func (mc *MigrationsController) SplitMigration() {
+	tr := mc.c.GetTranslationSet()
+
+	groups := prisma.GroupStatementsByTable(mc.detailsCtx.GetMigrationPreview())
+	if len(groups) == 0 {
+		modal := NewMessageModal(mc.g, tr, tr.ModalTitleSplitMigration,
+			tr.ModalMsgSplitNeedsPreview,
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		mc.openModal(modal)
+		return
+	}
+
+	mc.showSplitSelection(groups, nil, nil, time.Time{})
+}
+
+// showSplitSelection lists the statement groups not written yet; the selected
+// ones go into the next migration (nil = none yet). created holds the folders
+// written so far and last the timestamp of the latest one. Statements without
+// a table always go into the first migration.
+func (mc *MigrationsController) showSplitSelection(remaining []prisma.StatementGroup, selected []bool, created []string, last time.Time) {
+	tr := mc.c.GetTranslationSet()
+
+	if selected == nil {
+		selected = make([]bool, len(remaining))
+	}
+	first := len(created) == 0
+	for i, group := range remaining {
+		if first && group.Table == "" {
+			selected[i] = true
+		}
+	}
+
+	var modal *ListModal
+	var buildItems func() []ListModalItem
+	buildItems = func() []ListModalItem {
+		items := make([]ListModalItem, 0, len(remaining)+1)
+		count := 0
+		for i, group := range remaining {
+			check := style.Gray("[ ]")
+			if selected[i] {
+				check = style.Green("[x]")
+				count++
+			}
+
+			hint := tr.SplitMigrationToggleHint
+			locked := first && group.Table == ""
+			if locked {
+				hint = tr.SplitMigrationOtherFirst
+			}
+
+			items = append(items, ListModalItem{
+				Label:       fmt.Sprintf("%s %s  %s", check, mc.splitGroupName(group), style.Gray(fmt.Sprintf(tr.SplitMigrationStatementCount, len(group.Statements)))),
+				Description: strings.Join(group.Statements, "\n\n") + "\n\n" + hint,
+				OnSelect: func() error {
+					if !locked {
+						selected[i] = !selected[i]
+						modal.SetItems(buildItems())
+					}
+					return nil
+				},
+			})
+		}
+
+		items = append(items, ListModalItem{
+			Label:       style.Cyan(fmt.Sprintf(tr.ListItemCreateSplitMigration, count)),
+			Description: tr.ListItemDescCreateSplitMigration,
+			OnSelect: func() error {
+				var chosen, rest []prisma.StatementGroup
+				for i, group := range remaining {
+					if selected[i] {
+						chosen = append(chosen, group)
+					} else {
+						rest = append(rest, group)
+					}
+				}
+				if len(chosen) == 0 {
+					return nil
+				}
+				mc.closeModal()
+				if refs := prisma.LaterReferences(chosen, rest); len(refs) > 0 {
+					mc.confirmSplitReferences(remaining, selected, refs, created, last)
+					return nil
+				}
+				mc.showSplitNameInput(remaining, chosen, rest, created, last)
+				return nil
+			},
+		})
+		return items
+	}
+
+	modal = NewListModal(mc.g, tr, fmt.Sprintf(tr.ModalTitleSplitMigrationPart, len(created)+1), buildItems(),
+		func() {
+			mc.closeModal()
+			mc.finishSplit(created, false)
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+
+	mc.openModal(modal)
+}
+
+// confirmSplitReferences lists the foreign keys of the selection that point to
+// tables not selected yet and offers to add those tables to it
+func (mc *MigrationsController) confirmSplitReferences(remaining []prisma.StatementGroup, selected []bool, refs []prisma.SplitReference, created []string, last time.Time) {
+	tr := mc.c.GetTranslationSet()
+
+	lines := []string{tr.ModalMsgSplitReferencesLater}
+	for _, ref := range refs {
+		lines = append(lines, "  "+ref.Table+" → "+ref.References)
+	}
+	lines = append(lines, "", tr.ModalMsgSplitAddReferenced)
+
+	modal := NewConfirmModal(mc.g, tr, tr.ModalTitleSplitMigration, strings.Join(lines, "\n"),
+		func() {
+			mc.closeModal()
+			for i, group := range remaining {
+				for _, ref := range refs {
+					if group.Creates && strings.EqualFold(group.Table, ref.References) {
+						selected[i] = true
+					}
+				}
+			}
+			mc.showSplitSelection(remaining, selected, created, last)
+		},
+		func() {
+			mc.closeModal()
+			mc.showSplitSelection(remaining, selected, created, last)
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+
+	mc.openModal(modal)
+}
+
+// showSplitNameInput asks for the name of the migration holding the chosen groups.
+// Cancelling returns to the selection.
+func (mc *MigrationsController) showSplitNameInput(remaining, chosen, rest []prisma.StatementGroup, created []string, last time.Time) {
+	tr := mc.c.GetTranslationSet()
+
+	names := make([]string, 0, len(chosen))
+	for _, group := range chosen {
+		names = append(names, mc.splitGroupName(group))
+	}
+
+	modal := NewInputModal(mc.g, tr, tr.ModalTitleEnterMigrationName,
+		func(input string) {
+			migrationName := strings.ReplaceAll(strings.TrimSpace(input), " ", "_")
+			mc.closeModal()
+
+			// Folders sort by timestamp, so each part needs a later one than the previous
+			at := time.Now().UTC().Truncate(time.Second)
+			if !at.After(last) {
+				at = last.Add(time.Second)
+			}
+
+			folderName, _, ok := mc.writeMigrationFolderAt(at, migrationName, func(string) string {
+				header := fmt.Sprintf(tr.SplitMigrationFileHeader, len(created)+1, strings.Join(names, ", "))
+				return header + "\n\n" + prisma.JoinStatementGroups(chosen)
+			})
+			if !ok {
+				if len(created) > 0 {
+					mc.c.RequestRefresh(types.RefreshMigrations)
+				}
+				return
+			}
+
+			created = append(created, folderName)
+			mc.outputCtx.LogAction(tr.LogActionSplitMigration, fmt.Sprintf(tr.LogMsgSplitMigrationWritten, folderName, strings.Join(names, ", ")))
+
+			if len(rest) == 0 {
+				mc.finishSplit(created, true)
+				return
+			}
+			mc.showSplitSelection(rest, nil, created, at)
+		},
+		func() {
+			mc.closeModal()
+			mc.showSplitSelection(remaining, nil, created, last)
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan}).
+		WithSubtitle(fmt.Sprintf(tr.ModalMsgSplitMigrationTables, strings.Join(names, ", "))).
+		WithRequired(true).
+		OnValidationFail(func(reason string) {
+			mc.closeModal()
+			errorModal := NewMessageModal(mc.g, tr, tr.ModalTitleValidationFailed,
+				reason,
+			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+			mc.openModal(errorModal)
+		})
+
+	mc.openModal(modal)
+}
+
+// finishSplit refreshes the migrations and summarises the migrations written.
+// The preview is cleared since the migrations now contain (some of) its SQL.
+func (mc *MigrationsController) finishSplit(created []string, complete bool) {
+	tr := mc.c.GetTranslationSet()
+
+	if len(created) == 0 {
+		return
+	}
+
+	mc.detailsCtx.ClearMigrationPreview()
+	mc.c.RequestRefresh(types.RefreshMigrations)
+
+	lines := []string{fmt.Sprintf(tr.ModalMsgSplitMigrationCreated, len(created))}
+	lines = append(lines, created...)
+	color := ColorGreen
+	if !complete {
+		lines = append(lines, "", tr.ModalMsgSplitMigrationIncomplete)
+		color = ColorYellow
+	}
+
+	modal := NewMessageModal(mc.g, tr, tr.ModalTitleSplitMigration, lines...).
+		WithStyle(MessageModalStyle{TitleColor: color, BorderColor: color})
+	mc.openModal(modal)
+}
+
+// splitGroupName is the table of a statement group, or a label for statements without one
+func (mc *MigrationsController) splitGroupName(group prisma.StatementGroup) string {
+	if group.Table == "" {
+		return mc.c.GetTranslationSet().SplitMigrationOtherStatements
+	}
+	return group.Table
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dokadev/lazyprisma/pkg/i18n"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+)
+
+// onboardEnvVar is the variable prisma init wires the new datasource to
+const onboardEnvVar = "DATABASE_URL"
+
+// CanOnboard reports whether dir has no Prisma schema yet but DATABASE_URL
+// points at a database that can be introspected into one.
+func CanOnboard(dir string) bool {
+	if prisma.IsWorkspace(dir) {
+		return false
+	}
+	return prisma.ProviderFromURL(prisma.ResolveEnvVar(dir, onboardEnvVar)) != ""
+}
+
+// RunOnboardWizard sets up Prisma for an existing database before the TUI starts:
+// prisma init, db pull, generate, and a baseline migration marked as applied.
+// It asks for confirmation on in, runs the steps through runner and reports
+// progress on out; a failed step stops the wizard, leaving whatever the
+// earlier steps created.
+func RunOnboardWizard(tr *i18n.TranslationSet, runner prisma.Runner, dir string, in io.Reader, out io.Writer) {
+	dbURL := prisma.ResolveEnvVar(dir, onboardEnvVar)
+	provider := prisma.ProviderFromURL(dbURL)
+
+	fmt.Fprintf(out, tr.OnboardNoSchema, dir)
+	fmt.Fprintf(out, tr.OnboardDatabaseFound, onboardEnvVar, provider, prisma.DBFingerprint(dbURL))
+	fmt.Fprint(out, tr.OnboardStepsIntro)
+	fmt.Fprint(out, tr.OnboardStepInit)
+	fmt.Fprint(out, tr.OnboardStepPull)
+	fmt.Fprint(out, tr.OnboardStepGenerate)
+	baseline := provider != "mongodb" // MongoDB has no migrations
+	if baseline {
+		fmt.Fprintf(out, tr.OnboardStepBaseline, prisma.BaselineMigrationName)
+	}
+	fmt.Fprint(out, tr.OnboardConfirm)
+
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	if answer != "y" && answer != "yes" {
+		return
+	}
+
+	run := func(title string, args ...string) bool {
+		fmt.Fprintf(out, "\n==> %s\n", title)
+		if err := runner.Interactive(dir, args); err != nil {
+			fmt.Fprintf(out, tr.OnboardStepFailed, strings.Join(prisma.Command(dir, args...), " "), err)
+			return false
+		}
+		return true
+	}
+
+	// Don't pass --url: the URL already resolves from the environment or .env,
+	// and prisma init would otherwise copy credentials into a new .env file
+	if !run(tr.OnboardRunningInit, "init", "--datasource-provider", provider) {
+		return
+	}
+	if !run(tr.OnboardRunningPull, "db", "pull") {
+		return
+	}
+	if !run(tr.OnboardRunningGenerate, "generate") {
+		return
+	}
+
+	if baseline {
+		migrationsDir := prisma.MigrationsDir(dir)
+		if _, err := os.Stat(migrationsDir); err == nil {
+			fmt.Fprintf(out, tr.OnboardBaselineSkipped, migrationsDir)
+		} else {
+			fmt.Fprintf(out, "\n==> %s\n", fmt.Sprintf(tr.OnboardRunningBaseline, prisma.BaselineMigrationName))
+			path, err := prisma.WriteBaselineMigration(dir)
+			if err != nil {
+				fmt.Fprintf(out, tr.OnboardBaselineFailed, err)
+				return
+			}
+			fmt.Fprintf(out, tr.OnboardBaselineWritten, path)
+
+			if !run(fmt.Sprintf(tr.OnboardRunningResolve, prisma.BaselineMigrationName),
+				"migrate", "resolve", "--applied", prisma.BaselineMigrationName) {
+				return
+			}
+		}
+	}
+
+	fmt.Fprint(out, tr.OnboardDone)
+}
@@ -0,0 +1,144 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dokadev/lazyprisma/pkg/gui/context"
+	"github.com/dokadev/lazyprisma/pkg/gui/types"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+	"github.com/jesseduffield/gocui"
+)
+
+// peekRowLimit is how many rows of a table the Data tab shows
+const peekRowLimit = 10
+
+// PeekController shows the first rows of the tables a migration creates, read
+// from the live database, to confirm what the migration did.
+type PeekController struct {
+	c             types.IControllerHost
+	g             *gocui.Gui
+	migrationsCtx *context.MigrationsContext
+	detailsCtx    *context.DetailsContext
+	outputCtx     *context.OutputContext
+	openModal     func(Modal)
+	closeModal    func()
+	focusPanel    func(viewID string)
+}
+
+// NewPeekController creates a new PeekController.
+func NewPeekController(
+	c types.IControllerHost,
+	g *gocui.Gui,
+	migrationsCtx *context.MigrationsContext,
+	detailsCtx *context.DetailsContext,
+	outputCtx *context.OutputContext,
+	openModal func(Modal),
+	closeModal func(),
+	focusPanel func(viewID string),
+) *PeekController {
+	return &PeekController{
+		c:             c,
+		g:             g,
+		migrationsCtx: migrationsCtx,
+		detailsCtx:    detailsCtx,
+		outputCtx:     outputCtx,
+		openModal:     openModal,
+		closeModal:    closeModal,
+		focusPanel:    focusPanel,
+	}
+}
+
+// PeekData shows the data of the table the selected migration creates in the
+// Details panel's Data tab. If it creates several, the table is picked from a list.
+func (pc *PeekController) PeekData() {
+	tr := pc.c.GetTranslationSet()
+
+	mig := pc.migrationsCtx.GetSelectedMigration()
+	if mig == nil || mig.Path == "" {
+		pc.showNotice(tr.ModalMsgPeekSelectMigration)
+		return
+	}
+
+	var tables []string
+	if content, err := os.ReadFile(filepath.Join(mig.Path, "migration.sql")); err == nil {
+		tables = prisma.CreatedTables(string(content))
+	}
+	if len(tables) == 0 {
+		pc.showNotice(fmt.Sprintf(tr.ModalMsgPeekNoTables, mig.Name))
+		return
+	}
+	if mig.AppliedAt == nil {
+		pc.showNotice(fmt.Sprintf(tr.ModalMsgPeekPending, mig.Name))
+		return
+	}
+
+	if len(tables) == 1 {
+		pc.peek(tables[0])
+		return
+	}
+
+	var items []ListModalItem
+	for _, table := range tables {
+		items = append(items, ListModalItem{
+			Label:       table,
+			Description: fmt.Sprintf(tr.ListItemDescPeekTable, table),
+			OnSelect: func() error {
+				pc.closeModal()
+				pc.peek(table)
+				return nil
+			},
+		})
+	}
+
+	modal := NewListModal(pc.g, tr, fmt.Sprintf(tr.ModalTitlePeekDataTables, mig.Name), items,
+		func() { pc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+	pc.openModal(modal)
+}
+
+// peek reads the first rows of table and shows them in the Data tab
+func (pc *PeekController) peek(table string) {
+	tr := pc.c.GetTranslationSet()
+
+	if !pc.c.TryStartCommand("Peek Data") {
+		pc.c.LogCommandBlocked("Peek Data")
+		return
+	}
+
+	pc.outputCtx.LogAction(tr.LogActionPeekData, fmt.Sprintf(tr.LogMsgPeekingTable, table))
+
+	go func() {
+		peek, err := pc.migrationsCtx.PeekTable(table, peekRowLimit)
+
+		pc.c.OnUIThread(func() error {
+			pc.c.FinishCommand()
+
+			if err != nil {
+				pc.outputCtx.LogActionRed(tr.LogActionPeekData, err.Error())
+				modal := NewMessageModal(pc.g, tr, tr.ModalTitlePeekData,
+					fmt.Sprintf(tr.ModalMsgPeekFailed, table),
+					err.Error(),
+				).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+				pc.openModal(modal)
+				return nil
+			}
+
+			pc.outputCtx.LogAction(tr.LogActionPeekData, fmt.Sprintf(tr.LogMsgTablePeeked, table, peek.Total))
+			pc.detailsCtx.ShowTablePeek(table, peek)
+			if pc.focusPanel != nil {
+				pc.focusPanel(pc.detailsCtx.ID())
+			}
+			return nil
+		})
+	}()
+}
+
+// showNotice explains why there is nothing to peek at
+func (pc *PeekController) showNotice(msg string) {
+	tr := pc.c.GetTranslationSet()
+	modal := NewMessageModal(pc.g, tr, tr.ModalTitlePeekData, msg).
+		WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+	pc.openModal(modal)
+}
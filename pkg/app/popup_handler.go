@@ -1,6 +1,8 @@
 package app
 
 import (
+	"time"
+
 	"github.com/dokadev/lazyprisma/pkg/gui/context"
 	"github.com/dokadev/lazyprisma/pkg/gui/types"
 	"github.com/dokadev/lazyprisma/pkg/i18n"
@@ -85,10 +87,28 @@ func (a *App) Menu(opts types.MenuOpts) error {
 	return nil
 }
 
-// Toast shows a brief message. Currently delegates to Alert as there is
-// no auto-dismiss toast system yet.
+// toastDuration is how long a toast stays in the status bar
+const toastDuration = 4 * time.Second
+
+// toastMessage is a brief message shown in the status bar until it expires
+type toastMessage struct {
+	text  string
+	until time.Time
+}
+
+// Toast shows a brief message in the status bar, replacing the previous one.
+// It disappears by itself after toastDuration.
 func (a *App) Toast(message string) {
-	a.Alert("", message)
+	a.toast.Store(&toastMessage{text: message, until: time.Now().Add(toastDuration)})
+
+	redraw := func() {
+		a.g.Update(func(g *gocui.Gui) error {
+			// Status bar will be redrawn by layout manager
+			return nil
+		})
+	}
+	redraw()
+	time.AfterFunc(toastDuration, redraw)
 }
 
 // ErrorHandler shows an error modal with red styling.
@@ -118,9 +138,8 @@ func (a *App) LogAction(action string, detail ...string) {
 }
 
 // Refresh triggers a data refresh and re-render of all contexts.
-// This is the controller-facing API; it ignores the return value of RefreshAll.
 func (a *App) Refresh() {
-	a.RefreshAll()
+	a.RequestRefresh(types.RefreshEverything)
 }
 
 // OnUIThread schedules a function to run on the UI thread.
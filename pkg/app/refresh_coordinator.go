@@ -0,0 +1,130 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/dokadev/lazyprisma/pkg/gui/context"
+	"github.com/dokadev/lazyprisma/pkg/gui/types"
+	"github.com/jesseduffield/gocui"
+)
+
+// refreshQueue collects refresh requests until a refresh picks them up. At
+// most one refresh runs at a time; everything requested meanwhile is merged
+// into the next one.
+type refreshQueue struct {
+	mu        sync.Mutex
+	running   bool               // A refresh goroutine holds the command lock
+	scope     types.RefreshScope // Requested since the last refresh started
+	callbacks []func()           // Run once the next refresh has finished
+}
+
+// RequestRefresh reloads the panels of scope in the background. While a
+// refresh or another command is running, the request waits for it and is
+// merged with any other waiting requests.
+func (a *App) RequestRefresh(scope types.RefreshScope, onComplete ...func()) {
+	a.refreshQueue.mu.Lock()
+	a.refreshQueue.scope |= scope
+	a.refreshQueue.callbacks = append(a.refreshQueue.callbacks, onComplete...)
+	a.refreshQueue.mu.Unlock()
+
+	a.startQueuedRefresh()
+}
+
+// CommandInProgress reports whether a command other than a refresh is running
+func (a *App) CommandInProgress() bool {
+	a.refreshQueue.mu.Lock()
+	defer a.refreshQueue.mu.Unlock()
+	return a.commandRunning.Load() && !a.refreshQueue.running
+}
+
+// startQueuedRefresh starts a refresh if one was requested and nothing is
+// running. Otherwise the request waits: a running refresh picks it up when
+// it finishes, and FinishCommand calls this again for any other command.
+func (a *App) startQueuedRefresh() {
+	a.refreshQueue.mu.Lock()
+	defer a.refreshQueue.mu.Unlock()
+
+	if a.refreshQueue.running || a.refreshQueue.scope == 0 {
+		return
+	}
+	if !a.TryStartCommand(refreshCommandName(a.refreshQueue.scope)) {
+		return
+	}
+	a.refreshQueue.running = true
+
+	go a.runQueuedRefreshes()
+}
+
+// runQueuedRefreshes refreshes until no more requests are waiting, then
+// releases the command lock
+func (a *App) runQueuedRefreshes() {
+	for {
+		a.refreshQueue.mu.Lock()
+		scope, callbacks := a.refreshQueue.scope, a.refreshQueue.callbacks
+		a.refreshQueue.scope, a.refreshQueue.callbacks = 0, nil
+		if scope == 0 {
+			a.refreshQueue.running = false
+			a.refreshQueue.mu.Unlock()
+			break
+		}
+		a.refreshQueue.mu.Unlock()
+
+		a.runningCommandName.Store(refreshCommandName(scope))
+		if a.fileWatcher != nil {
+			a.fileWatcher.refreshing(scope)
+		}
+		a.RefreshPanels(scope)
+
+		// Update UI on main thread (thread-safe)
+		a.g.Update(func(g *gocui.Gui) error {
+			if outputPanel, ok := a.panels[ViewOutputs].(*context.OutputContext); ok {
+				outputPanel.LogAction(a.Tr.ActionRefresh, a.refreshedMessage(scope))
+			}
+
+			for _, callback := range callbacks {
+				callback()
+			}
+			return nil
+		})
+	}
+
+	a.FinishCommand()
+}
+
+// logRefreshQueued tells the user that the refresh they asked for waits for
+// the running command
+func (a *App) logRefreshQueued() {
+	name, _ := a.runningCommandName.Load().(string)
+	if outputPanel, ok := a.panels[ViewOutputs].(*context.OutputContext); ok {
+		outputPanel.LogAction(a.Tr.ActionRefresh, fmt.Sprintf(a.Tr.LogMsgRefreshQueued, name))
+	}
+}
+
+// refreshCommandName is the name a refresh of scope runs under
+func refreshCommandName(scope types.RefreshScope) string {
+	if scope == types.RefreshEverything {
+		return "Refresh All"
+	}
+	return "Refresh"
+}
+
+// refreshedMessage describes a finished refresh of scope for the output panel
+func (a *App) refreshedMessage(scope types.RefreshScope) string {
+	if scope == types.RefreshEverything {
+		return a.Tr.SuccessAllPanelsRefreshed
+	}
+
+	var parts []string
+	if scope&types.RefreshWorkspace != 0 {
+		parts = append(parts, a.Tr.RefreshScopeWorkspace)
+	}
+	if scope&types.RefreshMigrations != 0 {
+		parts = append(parts, a.Tr.RefreshScopeMigrations)
+	}
+	if scope&types.RefreshSchema != 0 {
+		parts = append(parts, a.Tr.RefreshScopeSchema)
+	}
+	return fmt.Sprintf(a.Tr.SuccessPanelsRefreshed, strings.Join(parts, ", "))
+}
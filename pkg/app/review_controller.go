@@ -0,0 +1,235 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dokadev/lazyprisma/pkg/gui/context"
+	"github.com/dokadev/lazyprisma/pkg/gui/style"
+	"github.com/dokadev/lazyprisma/pkg/gui/types"
+	"github.com/dokadev/lazyprisma/pkg/i18n"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+	"github.com/jesseduffield/gocui"
+)
+
+// reviewSummaryLength caps the statement summary shown in the checklist
+const reviewSummaryLength = 70
+
+// ReviewController shows a pending migration as a checklist of its statements.
+type ReviewController struct {
+	c             types.IControllerHost
+	g             *gocui.Gui
+	migrationsCtx *context.MigrationsContext
+	openModal     func(Modal)
+	closeModal    func()
+	reviewed      map[string]map[int]bool // "name:checksum" -> statements ticked off this session
+}
+
+// NewReviewController creates a new ReviewController.
+func NewReviewController(
+	c types.IControllerHost,
+	g *gocui.Gui,
+	migrationsCtx *context.MigrationsContext,
+	openModal func(Modal),
+	closeModal func(),
+) *ReviewController {
+	return &ReviewController{
+		c:             c,
+		g:             g,
+		migrationsCtx: migrationsCtx,
+		openModal:     openModal,
+		closeModal:    closeModal,
+		reviewed:      make(map[string]map[int]bool),
+	}
+}
+
+// ReviewMigration lists the statements of the selected pending migration.
+// Destructive ones are highlighted and, on PostgreSQL and MySQL, statements are
+// annotated with the lock they take. Selecting a statement ticks it off. Ticks
+// are kept until the app exits or the migration's SQL changes.
+func (rc *ReviewController) ReviewMigration() {
+	tr := rc.c.GetTranslationSet()
+
+	mig := rc.migrationsCtx.GetSelectedMigration()
+	if mig == nil || mig.AppliedAt != nil || mig.Path == "" {
+		modal := NewMessageModal(rc.g, tr, tr.ModalTitleReviewMigration,
+			tr.ModalMsgReviewSelectPending,
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		rc.openModal(modal)
+		return
+	}
+
+	content, err := os.ReadFile(filepath.Join(mig.Path, "migration.sql"))
+	if err != nil {
+		modal := NewMessageModal(rc.g, tr, tr.ModalTitleReviewMigration,
+			tr.ModalMsgFailedReadMigrationFile,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		rc.openModal(modal)
+		return
+	}
+
+	stmts := prisma.ParseStatements(string(content))
+	if len(stmts) == 0 {
+		modal := NewMessageModal(rc.g, tr, tr.ModalTitleReviewMigration,
+			fmt.Sprintf(tr.ModalMsgReviewNoStatements, mig.Name),
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		rc.openModal(modal)
+		return
+	}
+
+	provider := ""
+	if cwd, err := os.Getwd(); err == nil {
+		provider, _ = prisma.GetProvider(cwd)
+	}
+
+	key := mig.Name + ":" + mig.Checksum
+	if rc.reviewed[key] == nil {
+		rc.reviewed[key] = make(map[int]bool)
+	}
+	reviewed := rc.reviewed[key]
+
+	destructive := 0
+	for _, stmt := range stmts {
+		if stmt.IsDestructive() {
+			destructive++
+		}
+	}
+
+	title := func() string {
+		return fmt.Sprintf(tr.ModalTitleReviewMigrationProgress, mig.Name, len(reviewed), len(stmts), destructive)
+	}
+
+	var modal *ListModal
+	var buildItems func() []ListModalItem
+	buildItems = func() []ListModalItem {
+		items := make([]ListModalItem, 0, len(stmts))
+		for i, stmt := range stmts {
+			check := style.Gray("[ ]")
+			if reviewed[i] {
+				check = style.Green("[x]")
+			}
+
+			summary := reviewSummary(stmt.Text)
+			line := style.Gray(fmt.Sprintf("L%-4d", stmt.Line))
+			var desc strings.Builder
+			if stmt.IsDestructive() {
+				summary = style.Red("⚠ " + summary)
+				desc.WriteString(style.RedBold(statementRiskText(tr, stmt.Risk)) + "\n\n")
+			}
+			lockBadge := ""
+			if lock, ok := prisma.EstimateLock(provider, stmt.Text); ok {
+				if lock.BlocksReads || lock.BlocksWrites {
+					lockBadge = " " + style.Orange("["+lock.Lock+"]")
+				}
+				desc.WriteString(lockDescription(tr, lock) + "\n\n")
+			}
+			desc.WriteString(stmt.Text + "\n\n" + tr.ReviewToggleHint)
+
+			items = append(items, ListModalItem{
+				Label:       fmt.Sprintf("%s %s %s%s", check, line, summary, lockBadge),
+				Description: desc.String(),
+				OnSelect: func() error {
+					if reviewed[i] {
+						delete(reviewed, i)
+					} else {
+						reviewed[i] = true
+					}
+					modal.SetTitle(title())
+					modal.SetItems(buildItems())
+					return nil
+				},
+			})
+		}
+		return items
+	}
+
+	modal = NewListModal(rc.g, tr, title(), buildItems(),
+		func() { rc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+
+	rc.openModal(modal)
+}
+
+// reviewSummary is the statement on one line without comments, shortened for the list
+func reviewSummary(text string) string {
+	summary := prisma.NormalizeStatement(text)
+	if runes := []rune(summary); len(runes) > reviewSummaryLength {
+		summary = string(runes[:reviewSummaryLength]) + "…"
+	}
+	return summary
+}
+
+// lockDescription describes the estimated lock of a statement with a tip to avoid it
+func lockDescription(tr *i18n.TranslationSet, lock prisma.LockInfo) string {
+	blocks := tr.LockBlocksNothing
+	switch {
+	case lock.BlocksReads:
+		blocks = tr.LockBlocksReadsWrites
+	case lock.BlocksWrites:
+		blocks = tr.LockBlocksWrites
+	}
+
+	held := tr.LockHeldBriefly
+	if lock.Rewrite {
+		held = tr.LockHeldWhileScanning
+	}
+
+	text := fmt.Sprintf(tr.LockEstimate, lock.Lock, blocks, held)
+	if lock.BlocksReads || lock.BlocksWrites {
+		text = style.Orange(text)
+	} else {
+		text = style.Gray(text)
+	}
+
+	if tip := lockTipText(tr, lock.Tip); tip != "" {
+		text += "\n" + tip
+	}
+	return text
+}
+
+// lockTipText explains how to avoid a lock
+func lockTipText(tr *i18n.TranslationSet, tip prisma.LockTip) string {
+	switch tip {
+	case prisma.LockTipConcurrently:
+		return tr.LockTipConcurrently
+	case prisma.LockTipNotValid:
+		return tr.LockTipNotValid
+	case prisma.LockTipCheckNotNull:
+		return tr.LockTipCheckNotNull
+	case prisma.LockTipMetadataLock:
+		return tr.LockTipMetadataLock
+	case prisma.LockTipBatchRows:
+		return tr.LockTipBatchRows
+	case prisma.LockTipNewColumnCopy:
+		return tr.LockTipNewColumnCopy
+	}
+	return ""
+}
+
+// statementRiskText explains a statement risk
+func statementRiskText(tr *i18n.TranslationSet, risk prisma.StatementRisk) string {
+	switch risk {
+	case prisma.RiskDropTable:
+		return tr.StatementRiskDropTable
+	case prisma.RiskDropColumn:
+		return tr.StatementRiskDropColumn
+	case prisma.RiskDropObject:
+		return tr.StatementRiskDropObject
+	case prisma.RiskDeleteRows:
+		return tr.StatementRiskDeleteRows
+	case prisma.RiskUpdateRows:
+		return tr.StatementRiskUpdateRows
+	case prisma.RiskAlterType:
+		return tr.StatementRiskAlterType
+	case prisma.RiskSetNotNull:
+		return tr.StatementRiskSetNotNull
+	case prisma.RiskRename:
+		return tr.StatementRiskRename
+	case prisma.RiskAddUniqueness:
+		return tr.StatementRiskAddUniqueness
+	}
+	return ""
+}
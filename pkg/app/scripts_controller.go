@@ -0,0 +1,419 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dokadev/lazyprisma/pkg/config"
+	"github.com/dokadev/lazyprisma/pkg/gui/context"
+	"github.com/dokadev/lazyprisma/pkg/gui/style"
+	"github.com/dokadev/lazyprisma/pkg/gui/types"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+	"github.com/dokadev/lazyprisma/pkg/scripts"
+	"github.com/jesseduffield/gocui"
+)
+
+// scriptTimeFormat is how last-run times are shown
+const scriptTimeFormat = "2006-01-02 15:04"
+
+// ScriptsController lists one-off maintenance scripts and runs them against
+// the default database or a configured environment, keeping the last run of
+// each script per environment.
+type ScriptsController struct {
+	c            types.IControllerHost
+	g            *gocui.Gui
+	outputCtx    *context.OutputContext
+	openModal    func(Modal)
+	closeModal   func()
+	runStreamCmd func(AsyncCommandOpts) bool
+}
+
+// NewScriptsController creates a new ScriptsController.
+func NewScriptsController(
+	c types.IControllerHost,
+	g *gocui.Gui,
+	outputCtx *context.OutputContext,
+	openModal func(Modal),
+	closeModal func(),
+	runStreamCmd func(AsyncCommandOpts) bool,
+) *ScriptsController {
+	return &ScriptsController{
+		c:            c,
+		g:            g,
+		outputCtx:    outputCtx,
+		openModal:    openModal,
+		closeModal:   closeModal,
+		runStreamCmd: runStreamCmd,
+	}
+}
+
+// scriptTarget is the database a script runs against
+type scriptTarget struct {
+	name      string
+	protected bool
+	env       []string // Datasource override ("VAR=url"); empty for the default database
+	url       string   // Shown (masked) in the confirmation
+}
+
+// ShowScripts lists the scripts in the configured directory with their last runs
+func (sc *ScriptsController) ShowScripts() {
+	tr := sc.c.GetTranslationSet()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		modal := NewMessageModal(sc.g, tr, tr.ModalTitleError,
+			tr.ErrorFailedGetWorkingDirectory,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		sc.openModal(modal)
+		return
+	}
+
+	projectCfg, err := config.LoadProject(cwd)
+	if err != nil {
+		modal := NewMessageModal(sc.g, tr, tr.ModalTitleScripts,
+			fmt.Sprintf(tr.ModalMsgFailedLoadProjectConfig, config.ProjectConfigFile),
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		sc.openModal(modal)
+		return
+	}
+
+	dir := projectCfg.Scripts.ResolveDir(cwd)
+	list, err := scripts.List(dir)
+	if err != nil {
+		modal := NewMessageModal(sc.g, tr, tr.ModalTitleScripts,
+			tr.ModalMsgFailedReadScripts,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		sc.openModal(modal)
+		return
+	}
+
+	if len(list) == 0 {
+		modal := NewMessageModal(sc.g, tr, tr.ModalTitleScripts,
+			fmt.Sprintf(tr.ModalMsgNoScripts, relativePath(cwd, dir)),
+			"",
+			fmt.Sprintf(tr.ModalMsgScriptsDirHint, config.ProjectConfigFile),
+			"",
+			"scripts:",
+			"  dir: db/maintenance",
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		sc.openModal(modal)
+		return
+	}
+
+	history, err := scripts.LoadHistory(dir)
+	if err != nil {
+		modal := NewMessageModal(sc.g, tr, tr.ModalTitleScripts,
+			tr.ModalMsgFailedReadScriptHistory,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		sc.openModal(modal)
+		return
+	}
+
+	envNames := []string{scripts.DefaultEnvironment}
+	for _, env := range projectCfg.Environments {
+		envNames = append(envNames, env.Name)
+	}
+
+	nameWidth := 0
+	for _, script := range list {
+		nameWidth = max(nameWidth, len(script.Name))
+	}
+
+	items := make([]ListModalItem, 0, len(list))
+	for _, script := range list {
+		label := fmt.Sprintf("%-*s  %s  %s", nameWidth, script.Name,
+			style.Gray(fmt.Sprintf("%-4s", script.Kind)), sc.latestRun(history, script.Name, envNames))
+
+		var desc strings.Builder
+		desc.WriteString(relativePath(cwd, script.Path))
+		desc.WriteString("\n\n")
+		desc.WriteString(tr.ScriptsLastRunsLabel)
+		for _, envName := range envNames {
+			fmt.Fprintf(&desc, "\n  %s: %s", envName, sc.runSummary(history, script.Name, envName))
+			if _, ok := history.Last(script.Name, envName); ok {
+				fmt.Fprintf(&desc, "\n    %s", style.Gray(relativePath(cwd, scripts.LogPath(dir, script.Name, envName))))
+			}
+		}
+
+		items = append(items, ListModalItem{
+			Label:       label,
+			Description: desc.String(),
+			OnSelect: func() error {
+				sc.closeModal()
+				sc.chooseTarget(cwd, dir, script, projectCfg.Environments, history)
+				return nil
+			},
+		})
+	}
+
+	modal := NewListModal(sc.g, tr, fmt.Sprintf("%s (%s)", tr.ModalTitleScripts, relativePath(cwd, dir)), items,
+		func() { sc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+
+	sc.openModal(modal)
+}
+
+// latestRun describes the most recent run of a script in any environment
+func (sc *ScriptsController) latestRun(history *scripts.History, script string, envNames []string) string {
+	tr := sc.c.GetTranslationSet()
+
+	var latest scripts.Run
+	latestEnv := ""
+	for _, envName := range envNames {
+		if run, ok := history.Last(script, envName); ok && run.At.After(latest.At) {
+			latest = run
+			latestEnv = envName
+		}
+	}
+
+	if latestEnv == "" {
+		return style.Gray(tr.ScriptsNeverRun)
+	}
+
+	text := fmt.Sprintf("%s %s", latestEnv, latest.At.Local().Format(scriptTimeFormat))
+	if latest.Succeeded() {
+		return style.Green("✓ " + text)
+	}
+	return style.Red("✗ " + text)
+}
+
+// runSummary describes a script's last run in one environment
+func (sc *ScriptsController) runSummary(history *scripts.History, script, envName string) string {
+	tr := sc.c.GetTranslationSet()
+
+	run, ok := history.Last(script, envName)
+	if !ok {
+		return style.Gray(tr.ScriptsNeverRun)
+	}
+
+	duration := (time.Duration(run.DurationMs) * time.Millisecond).Round(100 * time.Millisecond)
+	text := fmt.Sprintf("%s (%s)", run.At.Local().Format(scriptTimeFormat), duration)
+	if run.Succeeded() {
+		return style.Green("✓ " + text)
+	}
+	return style.Red(fmt.Sprintf("✗ %s, "+tr.ScriptsExitCode, text, run.ExitCode))
+}
+
+// chooseTarget asks which database to run the script against when environments are configured
+func (sc *ScriptsController) chooseTarget(cwd, dir string, script scripts.Script, envs []config.EnvironmentConfig, history *scripts.History) {
+	tr := sc.c.GetTranslationSet()
+
+	if len(envs) == 0 {
+		sc.confirmRun(cwd, dir, script, scriptTarget{name: scripts.DefaultEnvironment, url: sc.defaultURL(cwd)})
+		return
+	}
+
+	items := []ListModalItem{{
+		Label:       fmt.Sprintf("%s  %s", scripts.DefaultEnvironment, sc.runSummary(history, script.Name, scripts.DefaultEnvironment)),
+		Description: tr.ScriptsDefaultEnvironmentDesc,
+		OnSelect: func() error {
+			sc.closeModal()
+			sc.confirmRun(cwd, dir, script, scriptTarget{name: scripts.DefaultEnvironment, url: sc.defaultURL(cwd)})
+			return nil
+		},
+	}}
+
+	for _, env := range envs {
+		label := fmt.Sprintf("%s  %s", env.Name, sc.runSummary(history, script.Name, env.Name))
+		if env.Protected {
+			label += "  " + style.Gray("("+tr.EnvironmentTagProtected+")")
+		}
+
+		items = append(items, ListModalItem{
+			Label:       label,
+			Description: fmt.Sprintf(tr.ScriptsEnvironmentDesc, env.Name),
+			OnSelect: func() error {
+				sc.closeModal()
+				if target, ok := sc.environmentTarget(cwd, env); ok {
+					sc.confirmRun(cwd, dir, script, target)
+				}
+				return nil
+			},
+		})
+	}
+
+	modal := NewListModal(sc.g, tr, fmt.Sprintf(tr.ModalTitleRunScriptIn, script.Name), items,
+		func() { sc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+
+	sc.openModal(modal)
+}
+
+// defaultURL returns the datasource URL used without overrides, if it can be resolved
+func (sc *ScriptsController) defaultURL(cwd string) string {
+	envVar, err := prisma.GetEnvVarName(cwd)
+	if err != nil || envVar == "" {
+		return ""
+	}
+	return prisma.ResolveEnvVar(cwd, envVar)
+}
+
+// environmentTarget points the datasource's environment variable at a configured
+// environment, the same way deploying to an environment does
+func (sc *ScriptsController) environmentTarget(cwd string, env config.EnvironmentConfig) (scriptTarget, bool) {
+	tr := sc.c.GetTranslationSet()
+
+	envVar, err := prisma.GetEnvVarName(cwd)
+	if err != nil || envVar == "" {
+		modal := NewMessageModal(sc.g, tr, tr.ModalTitleScripts,
+			tr.ModalMsgDatasourceNotFromEnv,
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		sc.openModal(modal)
+		return scriptTarget{}, false
+	}
+
+	url := env.ResolveURL(func(name string) string { return prisma.ResolveEnvVar(cwd, name) })
+	if url == "" {
+		modal := NewMessageModal(sc.g, tr, tr.ModalTitleScripts,
+			fmt.Sprintf(tr.ModalMsgEnvironmentNoURL, env.Name),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		sc.openModal(modal)
+		return scriptTarget{}, false
+	}
+
+	return scriptTarget{
+		name:      env.Name,
+		protected: env.Protected,
+		env:       []string{envVar + "=" + url},
+		url:       url,
+	}, true
+}
+
+// confirmRun asks before running; protected environments require typing their name
+func (sc *ScriptsController) confirmRun(cwd, dir string, script scripts.Script, target scriptTarget) {
+	tr := sc.c.GetTranslationSet()
+
+	message := fmt.Sprintf(tr.ModalMsgConfirmRunScript, script.Name, target.name)
+	if target.url != "" {
+		message += " (" + prisma.MaskPassword(target.url) + ")"
+	}
+
+	if !target.protected {
+		modal := NewConfirmModal(sc.g, tr, tr.ModalTitleRunScript, message,
+			func() {
+				sc.closeModal()
+				sc.runScript(cwd, dir, script, target)
+			},
+			func() {
+				sc.closeModal()
+			},
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		sc.openModal(modal)
+		return
+	}
+
+	modal := NewInputModal(sc.g, tr, fmt.Sprintf(tr.ModalTitleRunScriptProtected, target.name),
+		func(input string) {
+			sc.closeModal()
+			if strings.TrimSpace(input) != target.name {
+				errorModal := NewMessageModal(sc.g, tr, tr.ModalTitleRunScript,
+					tr.ModalMsgRunScriptConfirmMismatch,
+				).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+				sc.openModal(errorModal)
+				return
+			}
+			sc.runScript(cwd, dir, script, target)
+		},
+		func() {
+			sc.closeModal()
+		},
+	).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed}).
+		WithSubtitle(message + " " + fmt.Sprintf(tr.ModalMsgTypeEnvironmentToConfirm, target.name))
+
+	sc.openModal(modal)
+}
+
+// runScript streams the script's output to the output panel, then saves it as
+// the run's log and records the run in the scripts directory's history
+func (sc *ScriptsController) runScript(cwd, dir string, script scripts.Script, target scriptTarget) {
+	tr := sc.c.GetTranslationSet()
+
+	stamp := func(action string) string {
+		return fmt.Sprintf("[%s] %s", target.name, action)
+	}
+
+	var output outputCapture
+	startedAt := time.Now()
+
+	// finish records the run; the output lines have all arrived once the command has exited
+	finish := func(out *context.OutputContext, exitCode int) {
+		sc.c.FinishCommand()
+
+		run := scripts.Run{At: startedAt, ExitCode: exitCode, DurationMs: time.Since(startedAt).Milliseconds()}
+		logPath, logErr := scripts.WriteLog(dir, script.Name, target.name, output.String())
+		history, err := scripts.LoadHistory(dir)
+		if err == nil {
+			err = history.Record(script.Name, target.name, run)
+		}
+		if logErr != nil {
+			out.LogActionRed(stamp(tr.LogActionRunScript), tr.LogMsgScriptLogFailed+" "+logErr.Error())
+		}
+		if err != nil {
+			out.LogActionRed(stamp(tr.LogActionRunScript), tr.LogMsgScriptHistoryFailed+" "+err.Error())
+		}
+
+		if exitCode == 0 {
+			out.LogAction(stamp(tr.LogActionRunScriptComplete), fmt.Sprintf(tr.LogMsgScriptSucceeded, script.Name))
+			if logErr == nil {
+				out.AppendOutput("  " + fmt.Sprintf(tr.LogMsgScriptLogSaved, relativePath(cwd, logPath)))
+			}
+			return
+		}
+
+		out.LogActionRed(stamp(tr.LogActionRunScriptFailed), fmt.Sprintf(tr.LogMsgScriptFailed, script.Name, exitCode))
+		lines := []string{fmt.Sprintf(tr.LogMsgScriptFailed, script.Name, exitCode)}
+		if logErr == nil {
+			lines = append(lines, fmt.Sprintf(tr.LogMsgScriptLogSaved, relativePath(cwd, logPath)))
+		}
+		modal := NewMessageModal(sc.g, tr, tr.ModalTitleRunScriptFailed, lines...).
+			WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		sc.openModal(modal)
+	}
+
+	sc.runStreamCmd(AsyncCommandOpts{
+		Name:       "Run Script",
+		Command:    script.Command(cwd),
+		PrismaArgs: script.PrismaArgs(cwd),
+		Env:        target.env,
+		LogAction:  stamp(tr.LogActionRunScript),
+		LogDetail:  fmt.Sprintf(tr.LogMsgRunningScript, script.Name, target.name),
+		OnOutput:   output.Add,
+		OnSuccess: func(out *context.OutputContext, cwd string) {
+			finish(out, 0)
+		},
+		OnFailure: func(out *context.OutputContext, cwd string, exitCode int) {
+			finish(out, exitCode)
+		},
+		OnError: func(out *context.OutputContext, cwd string, err error) {
+			// A non-zero exit is reported again through OnFailure
+			if _, isExit := err.(*exec.ExitError); isExit {
+				return
+			}
+			sc.c.FinishCommand()
+			out.LogActionRed(stamp(tr.LogActionRunScriptFailed), err.Error())
+			modal := NewMessageModal(sc.g, tr, tr.ModalTitleRunScriptFailed,
+				tr.ModalMsgFailedStartScript,
+				err.Error(),
+			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+			sc.openModal(modal)
+		},
+		ErrorTitle:    tr.ModalTitleRunScriptFailed,
+		ErrorStartMsg: tr.ModalMsgFailedStartScript,
+	})
+}
+
+// relativePath shows path relative to the project when it is inside it
+func relativePath(base, path string) string {
+	if rel, err := filepath.Rel(base, path); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return path
+}
@@ -0,0 +1,145 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dokadev/lazyprisma/pkg/gui/style"
+	"github.com/dokadev/lazyprisma/pkg/gui/types"
+	"github.com/dokadev/lazyprisma/pkg/stats"
+	"github.com/jesseduffield/gocui"
+)
+
+const (
+	statsTopActions = 6  // Actions listed in the "most used" chart
+	statsBarWidth   = 10 // Width of the longest bar in the chart
+)
+
+// StatsController shows local usage statistics.
+type StatsController struct {
+	c          types.IControllerHost
+	g          *gocui.Gui
+	store      *stats.Store // nil if statistics are disabled
+	openModal  func(Modal)
+	closeModal func()
+}
+
+// NewStatsController creates a new StatsController.
+func NewStatsController(
+	c types.IControllerHost,
+	g *gocui.Gui,
+	store *stats.Store,
+	openModal func(Modal),
+	closeModal func(),
+) *StatsController {
+	return &StatsController{
+		c:          c,
+		g:          g,
+		store:      store,
+		openModal:  openModal,
+		closeModal: closeModal,
+	}
+}
+
+// ShowStats opens the usage statistics modal
+func (sc *StatsController) ShowStats() {
+	tr := sc.c.GetTranslationSet()
+
+	if sc.store == nil {
+		modal := NewMessageModal(sc.g, tr, tr.ModalTitleUsageStats,
+			tr.ModalMsgUsageStatsDisabled,
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		sc.openModal(modal)
+		return
+	}
+
+	st, err := sc.store.Load()
+	if err != nil {
+		modal := NewMessageModal(sc.g, tr, tr.ModalTitleUsageStats,
+			tr.ModalMsgFailedReadUsageStats,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		sc.openModal(modal)
+		return
+	}
+
+	if st.TotalRuns() == 0 {
+		modal := NewMessageModal(sc.g, tr, tr.ModalTitleUsageStats,
+			tr.ModalMsgUsageStatsEmpty,
+			"",
+			style.Gray(fmt.Sprintf(tr.ModalMsgUsageStatsLocal, sc.store.Path())),
+		).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+		sc.openModal(modal)
+		return
+	}
+
+	total := st.TotalRuns()
+	successRate := 100 * (total - st.TotalFailures()) / total
+	days := int(time.Since(st.Since).Hours()/24) + 1
+
+	lines := []string{
+		style.CyanBold(usageRank(tr.UsageStatsRanks, total)),
+		"",
+		fmt.Sprintf(tr.UsageStatsSince, st.Since.Local().Format("2006-01-02"), days),
+		fmt.Sprintf(tr.UsageStatsCommandsRun, total, successRate),
+		"",
+		style.Bold(tr.UsageStatsMostUsed),
+	}
+
+	top := st.Top()
+	if len(top) > statsTopActions {
+		top = top[:statsTopActions]
+	}
+	highest := top[0].Stats.Runs
+	for _, action := range top {
+		bar := max(1, action.Stats.Runs*statsBarWidth/highest)
+		lines = append(lines, fmt.Sprintf("%-16s %s %d",
+			action.Name, style.Cyan(strings.Repeat("█", bar)), action.Stats.Runs))
+	}
+
+	lines = append(lines, "", style.Bold(tr.UsageStatsDeploy))
+	if deploy := st.Action(stats.ActionDeploy); deploy.Successes() > 0 {
+		lines = append(lines, fmt.Sprintf(tr.UsageStatsDeployDurations,
+			formatStatsDuration(deploy.Average()),
+			formatStatsDuration(time.Duration(deploy.MinMs)*time.Millisecond),
+			formatStatsDuration(time.Duration(deploy.MaxMs)*time.Millisecond),
+			deploy.Successes(),
+		))
+	} else {
+		lines = append(lines, style.Gray(tr.UsageStatsNoDeploys))
+	}
+
+	lines = append(lines, "", style.Gray(fmt.Sprintf(tr.ModalMsgUsageStatsLocal, sc.store.Path())))
+
+	modal := NewMessageModal(sc.g, tr, tr.ModalTitleUsageStats, lines...).
+		WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+	sc.openModal(modal)
+}
+
+// usageRank picks a light-hearted title for the number of commands run.
+// ranks is a "|"-separated list, one title per threshold in usageRankThresholds.
+func usageRank(ranks string, total int) string {
+	names := strings.Split(ranks, "|")
+	rank := 0
+	for i, threshold := range usageRankThresholds {
+		if total >= threshold {
+			rank = i
+		}
+	}
+	if rank >= len(names) {
+		rank = len(names) - 1
+	}
+	return names[rank]
+}
+
+// usageRankThresholds are the command counts at which each rank is reached
+var usageRankThresholds = []int{1, 10, 50, 200, 1000}
+
+// formatStatsDuration rounds a duration for display
+func formatStatsDuration(d time.Duration) string {
+	if d < time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(100 * time.Millisecond).String()
+}
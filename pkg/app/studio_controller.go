@@ -1,24 +1,47 @@
 package app
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
 	"sync/atomic"
 	"time"
 
+	"github.com/dokadev/lazyprisma/pkg/audit"
 	"github.com/dokadev/lazyprisma/pkg/commands"
+	"github.com/dokadev/lazyprisma/pkg/config"
 	"github.com/dokadev/lazyprisma/pkg/gui/context"
 	"github.com/dokadev/lazyprisma/pkg/gui/types"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
 	"github.com/jesseduffield/gocui"
 )
 
+// studioKillGrace is how long an orphaned Studio gets to exit after SIGTERM before SIGKILL
+const studioKillGrace = 3 * time.Second
+
+// studioInstance is a Prisma Studio process serving one project
+type studioInstance struct {
+	projectDir string
+	port       int
+	cmd        prisma.Process // Set if started by this session
+	adoptedPID int            // Set if adopted from a previous session
+}
+
 // StudioController handles Prisma Studio toggle operations.
+// In a monorepo with several Prisma projects it manages one Studio per project,
+// each on its own port.
 type StudioController struct {
 	c             types.IControllerHost
 	g             *gocui.Gui
 	outputCtx     *context.OutputContext
 	openModal     func(Modal)
-	studioCmd     *commands.Command // Running studio command
-	studioRunning atomic.Bool       // True if studio is running
+	closeModal    func()
+	port          int               // Port for the current project; other projects get the next free ports
+	scanCfg       config.ScanConfig // Settings for discovering sibling projects
+	root          string            // Monorepo or git root the projects were found under
+	projects      []string          // Discovered Prisma projects (lazily scanned)
+	instances     map[string]*studioInstance
+	studioRunning atomic.Bool // True if any studio instance is running
 }
 
 // NewStudioController creates a new StudioController.
@@ -27,12 +50,19 @@ func NewStudioController(
 	g *gocui.Gui,
 	outputCtx *context.OutputContext,
 	openModal func(Modal),
+	closeModal func(),
+	port int,
+	scanCfg config.ScanConfig,
 ) *StudioController {
 	return &StudioController{
-		c:         c,
-		g:         g,
-		outputCtx: outputCtx,
-		openModal: openModal,
+		c:          c,
+		g:          g,
+		outputCtx:  outputCtx,
+		openModal:  openModal,
+		closeModal: closeModal,
+		port:       port,
+		scanCfg:    scanCfg,
+		instances:  make(map[string]*studioInstance),
 	}
 }
 
@@ -41,82 +71,385 @@ func (sc *StudioController) IsStudioRunning() bool {
 	return sc.studioRunning.Load()
 }
 
-// GetStudioCmd returns the running studio command (for cleanup on app exit).
-func (sc *StudioController) GetStudioCmd() *commands.Command {
-	return sc.studioCmd
+// Cleanup kills the Studio processes started by this session (called on app exit).
+// Adopted processes are left running since this session did not start them.
+func (sc *StudioController) Cleanup() {
+	for _, inst := range sc.instances {
+		if inst.cmd != nil {
+			inst.cmd.Kill()
+		}
+	}
 }
 
-// Studio toggles Prisma Studio
+// Studio toggles Prisma Studio for the current project, or opens the
+// Studio manager when the working directory contains several Prisma projects
 func (sc *StudioController) Studio() {
 	tr := sc.c.GetTranslationSet()
 
-	// Check if Studio is already running
-	if sc.studioRunning.Load() {
-		// Stop Studio
-		if sc.studioCmd != nil {
-			if err := sc.studioCmd.Kill(); err != nil {
-				sc.outputCtx.LogAction(tr.LogActionStudio, tr.ModalMsgFailedStopStudio+" "+err.Error())
-				modal := NewMessageModal(sc.g, tr, tr.ModalTitleStudioError,
-					tr.ModalMsgFailedStopStudio,
-					err.Error(),
-				).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
-				sc.openModal(modal)
-				return
+	cwd, err := os.Getwd()
+	if err != nil {
+		sc.outputCtx.LogAction(tr.LogActionStudio, tr.ErrorFailedGetWorkingDir+" "+err.Error())
+		modal := NewMessageModal(sc.g, tr, tr.ModalTitleStudioError,
+			tr.ErrorFailedGetWorkingDir,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		sc.openModal(modal)
+		return
+	}
+
+	if len(sc.getProjects(cwd)) > 1 {
+		sc.showManager(cwd)
+		return
+	}
+
+	sc.toggle(cwd, cwd)
+}
+
+// getProjects returns the Prisma projects of the monorepo or git repository
+// cwd is in, with cwd's first, scanning on first use
+func (sc *StudioController) getProjects(cwd string) []string {
+	if sc.projects == nil {
+		sc.root = prisma.MonorepoRoot(cwd)
+		found, err := prisma.FindWorkspaces(sc.root, sc.scanCfg.MaxDepth, sc.scanCfg.ExcludeDirs)
+		if err != nil {
+			found = nil
+		}
+
+		projects := []string{cwd}
+		for _, dir := range found {
+			if dir != cwd {
+				projects = append(projects, dir)
 			}
-			sc.studioCmd = nil
 		}
-		sc.studioRunning.Store(false)
+		sc.projects = projects
+	}
+	return sc.projects
+}
+
+// isMultiProject reports whether more than one project is being managed
+func (sc *StudioController) isMultiProject() bool {
+	return len(sc.projects) > 1
+}
+
+// projectLabel returns a short display name for a project directory
+func (sc *StudioController) projectLabel(cwd, projectDir string) string {
+	root := sc.root
+	if root == "" {
+		root = cwd
+	}
+	rel, err := filepath.Rel(root, projectDir)
+	if err != nil || rel == "." {
+		return filepath.Base(projectDir)
+	}
+	return rel
+}
+
+// toggle starts or stops Studio for a single project
+func (sc *StudioController) toggle(cwd, projectDir string) {
+	if _, ok := sc.instances[projectDir]; ok {
+		sc.stopInstance(cwd, projectDir)
+		return
+	}
+
+	// A Studio from a crashed session may still own the port
+	if projectDir == cwd && commands.IsPortInUse(sc.port) {
+		sc.handlePortConflict(cwd)
+		return
+	}
+
+	sc.startStudio(cwd, projectDir, sc.assignPort(cwd, projectDir))
+}
+
+// assignPort picks the port for a project. The current project always uses the
+// configured port; other projects get the next port that is neither used by
+// another instance nor by some other process.
+func (sc *StudioController) assignPort(cwd, projectDir string) int {
+	if projectDir == cwd {
+		return sc.port
+	}
+
+	used := make(map[int]bool)
+	for _, inst := range sc.instances {
+		used[inst.port] = true
+	}
+
+	port := sc.port + 1
+	for used[port] || commands.IsPortInUse(port) {
+		port++
+	}
+	return port
+}
+
+// showManager opens a list of all projects with their Studio status
+func (sc *StudioController) showManager(cwd string) {
+	tr := sc.c.GetTranslationSet()
+
+	var items []ListModalItem
+	for _, dir := range sc.projects {
+		projectDir := dir
+		label := sc.projectLabel(cwd, projectDir)
+
+		item := ListModalItem{
+			Label:       fmt.Sprintf(tr.ListItemStudioInstanceStopped, label),
+			Description: fmt.Sprintf(tr.ListItemDescStudioStopped, projectDir),
+			OnSelect: func() error {
+				sc.closeModal()
+				sc.toggle(cwd, projectDir)
+				return nil
+			},
+		}
+		if inst, ok := sc.instances[projectDir]; ok {
+			item.Label = fmt.Sprintf(tr.ListItemStudioInstanceRunning, label, inst.port)
+			item.Description = fmt.Sprintf(tr.ListItemDescStudioRunning, projectDir, inst.port)
+		}
+		items = append(items, item)
+	}
+
+	if len(sc.instances) > 0 {
+		items = append(items, ListModalItem{
+			Label:       tr.ListItemStudioStopAll,
+			Description: tr.ListItemDescStudioStopAll,
+			OnSelect: func() error {
+				sc.closeModal()
+				sc.stopAll(cwd)
+				return nil
+			},
+		})
+	}
+
+	modal := NewListModal(sc.g, tr, tr.ModalTitleStudioManager, items,
+		func() { sc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+
+	sc.openModal(modal)
+}
+
+// killInstance terminates the process behind an instance
+func (sc *StudioController) killInstance(inst *studioInstance) error {
+	if inst.cmd != nil {
+		return inst.cmd.Kill()
+	}
+	if inst.adoptedPID > 0 {
+		return commands.KillProcess(inst.adoptedPID, studioKillGrace)
+	}
+	return nil
+}
+
+// stopInstance stops the Studio serving projectDir
+func (sc *StudioController) stopInstance(cwd, projectDir string) {
+	tr := sc.c.GetTranslationSet()
+
+	if err := sc.killInstance(sc.instances[projectDir]); err != nil {
+		sc.outputCtx.LogAction(tr.LogActionStudio, tr.ModalMsgFailedStopStudio+" "+err.Error())
+		modal := NewMessageModal(sc.g, tr, tr.ModalTitleStudioError,
+			tr.ModalMsgFailedStopStudio,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		sc.openModal(modal)
+		return
+	}
+	delete(sc.instances, projectDir)
+	sc.studioRunning.Store(len(sc.instances) > 0)
+
+	if sc.isMultiProject() {
+		sc.outputCtx.LogAction(tr.LogActionStudioStopped, fmt.Sprintf(tr.LogMsgStudioProjectStopped, sc.projectLabel(cwd, projectDir)))
+	} else {
 		sc.outputCtx.LogAction(tr.LogActionStudioStopped, tr.LogMsgStudioHasStopped)
+	}
+	sc.updateSubtitle()
+
+	// Update UI
+	sc.c.OnUIThread(func() error {
+		// Trigger redraw of status bar
+		return nil
+	})
 
-		// Clear subtitle
+	modal := NewMessageModal(sc.g, tr, tr.ModalTitleStudioStopped,
+		tr.ModalMsgStudioStopped,
+	).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+	sc.openModal(modal)
+}
+
+// stopAll stops every managed Studio instance
+func (sc *StudioController) stopAll(cwd string) {
+	tr := sc.c.GetTranslationSet()
+
+	for projectDir, inst := range sc.instances {
+		if err := sc.killInstance(inst); err != nil {
+			sc.outputCtx.LogAction(tr.LogActionStudio, tr.ModalMsgFailedStopStudio+" "+err.Error())
+			continue
+		}
+		delete(sc.instances, projectDir)
+		sc.outputCtx.LogAction(tr.LogActionStudioStopped, fmt.Sprintf(tr.LogMsgStudioProjectStopped, sc.projectLabel(cwd, projectDir)))
+	}
+	sc.studioRunning.Store(len(sc.instances) > 0)
+	sc.updateSubtitle()
+
+	modal := NewMessageModal(sc.g, tr, tr.ModalTitleStudioStopped,
+		tr.ModalMsgStudioStopped,
+	).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+	sc.openModal(modal)
+}
+
+// updateSubtitle shows the Studio URL (or instance count) in the output panel title
+func (sc *StudioController) updateSubtitle() {
+	tr := sc.c.GetTranslationSet()
+
+	switch len(sc.instances) {
+	case 0:
 		sc.outputCtx.SetSubtitle("")
+	case 1:
+		for _, inst := range sc.instances {
+			sc.outputCtx.SetSubtitle(fmt.Sprintf(tr.LogMsgStudioListeningAt, inst.port))
+		}
+	default:
+		sc.outputCtx.SetSubtitle(fmt.Sprintf(tr.LogMsgStudioInstancesRunning, len(sc.instances)))
+	}
+}
 
-		// Update UI
-		sc.c.OnUIThread(func() error {
-			// Trigger redraw of status bar
-			return nil
-		})
+// handlePortConflict identifies the process holding the Studio port and,
+// if it is an orphaned Prisma Studio, offers to kill or adopt it
+func (sc *StudioController) handlePortConflict(cwd string) {
+	tr := sc.c.GetTranslationSet()
 
-		modal := NewMessageModal(sc.g, tr, tr.ModalTitleStudioStopped,
-			tr.ModalMsgStudioStopped,
+	proc, err := commands.FindListeningProcess(sc.port)
+	if err != nil || proc == nil {
+		modal := NewMessageModal(sc.g, tr, tr.ModalTitleStudioPortInUse,
+			fmt.Sprintf(tr.ModalMsgStudioPortOwnerUnknown, sc.port),
+			tr.ModalMsgStudioChangePort,
 		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
 		sc.openModal(modal)
 		return
 	}
 
-	// Start Studio
-	// Try to start command - if another command is running, block
+	if !prisma.IsStudioProcess(proc.Command) {
+		modal := NewMessageModal(sc.g, tr, tr.ModalTitleStudioPortInUse,
+			fmt.Sprintf(tr.ModalMsgStudioPortInUse, sc.port),
+			fmt.Sprintf("PID %d: %s", proc.PID, proc.Command),
+			tr.ModalMsgStudioChangePort,
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		sc.openModal(modal)
+		return
+	}
+
+	pid := proc.PID
+	items := []ListModalItem{
+		{
+			Label:       tr.ListItemKillRestartStudio,
+			Description: fmt.Sprintf(tr.ModalMsgStudioAlreadyRunning, pid, sc.port) + "\n\n" + tr.ListItemDescKillRestartStudio,
+			OnSelect: func() error {
+				sc.closeModal()
+				sc.killAndRestart(cwd, pid)
+				return nil
+			},
+		},
+		{
+			Label:       tr.ListItemAdoptStudio,
+			Description: fmt.Sprintf(tr.ModalMsgStudioAlreadyRunning, pid, sc.port) + "\n\n" + tr.ListItemDescAdoptStudio,
+			OnSelect: func() error {
+				sc.closeModal()
+				sc.adoptStudio(cwd, pid)
+				return nil
+			},
+		},
+	}
+
+	modal := NewListModal(sc.g, tr, tr.ModalTitleStudioAlreadyRunning, items,
+		func() { sc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+
+	sc.openModal(modal)
+}
+
+// killAndRestart terminates an orphaned Studio and starts a fresh one once the port is free
+func (sc *StudioController) killAndRestart(cwd string, pid int) {
+	tr := sc.c.GetTranslationSet()
+
 	if !sc.c.TryStartCommand("Start Studio") {
 		sc.c.LogCommandBlocked("Start Studio")
 		return
 	}
 
-	// Get current working directory
-	cwd, err := os.Getwd()
-	if err != nil {
-		sc.c.FinishCommand()
-		sc.outputCtx.LogAction(tr.LogActionStudio, tr.ErrorFailedGetWorkingDir+" "+err.Error())
-		modal := NewMessageModal(sc.g, tr, tr.ModalTitleStudioError,
-			tr.ErrorFailedGetWorkingDir,
-			err.Error(),
-		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
-		sc.openModal(modal)
-		return
+	sc.outputCtx.LogAction(tr.LogActionStudio, fmt.Sprintf(tr.LogMsgKillingOrphanedStudio, pid))
+
+	go func() {
+		err := commands.KillProcess(pid, studioKillGrace)
+
+		// Wait for the port to be released
+		if err == nil {
+			deadline := time.Now().Add(studioKillGrace)
+			for commands.IsPortInUse(sc.port) && time.Now().Before(deadline) {
+				time.Sleep(100 * time.Millisecond)
+			}
+		}
+
+		sc.c.OnUIThread(func() error {
+			sc.c.FinishCommand()
+			if err != nil {
+				sc.outputCtx.LogAction(tr.LogActionStudio, tr.ModalMsgFailedKillStudio+" "+err.Error())
+				modal := NewMessageModal(sc.g, tr, tr.ModalTitleStudioError,
+					tr.ModalMsgFailedKillStudio,
+					err.Error(),
+				).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+				sc.openModal(modal)
+				return nil
+			}
+			sc.startStudio(cwd, cwd, sc.port)
+			return nil
+		})
+	}()
+}
+
+// adoptStudio takes over an orphaned Studio so it can be stopped from this session
+func (sc *StudioController) adoptStudio(cwd string, pid int) {
+	tr := sc.c.GetTranslationSet()
+
+	sc.instances[cwd] = &studioInstance{projectDir: cwd, port: sc.port, adoptedPID: pid}
+	sc.studioRunning.Store(true)
+
+	sc.outputCtx.LogAction(tr.LogActionStudioStarted, fmt.Sprintf(tr.LogMsgAdoptedStudio, pid))
+	sc.updateSubtitle()
+
+	modal := NewMessageModal(sc.g, tr, tr.ModalTitleStudioStarted,
+		fmt.Sprintf(tr.ModalMsgStudioRunningAt, sc.port),
+		sc.stopHint(),
+	).WithStyle(MessageModalStyle{TitleColor: ColorGreen, BorderColor: ColorGreen})
+	sc.openModal(modal)
+}
+
+// stopHint returns the hint shown after Studio starts
+func (sc *StudioController) stopHint() string {
+	tr := sc.c.GetTranslationSet()
+	if sc.isMultiProject() {
+		return tr.ModalMsgPressManageStudio
 	}
+	return tr.ModalMsgPressStopStudio
+}
 
-	// Log action start
-	sc.outputCtx.LogAction(tr.LogActionStudio, tr.LogMsgStartingStudio)
+// startStudio launches prisma studio for a project on the given port
+func (sc *StudioController) startStudio(cwd, projectDir string, port int) {
+	tr := sc.c.GetTranslationSet()
 
-	// Create command builder
-	builder := commands.NewCommandBuilder(commands.NewPlatform())
+	// Try to start command - if another command is running, block
+	if !sc.c.TryStartCommand("Start Studio") {
+		sc.c.LogCommandBlocked("Start Studio")
+		return
+	}
 
-	// Build prisma studio command
-	studioCmd := builder.New("npx", "prisma", "studio").
-		WithWorkingDir(cwd)
+	// Log action start
+	sc.outputCtx.LogAction(tr.LogActionStudio, commandTargetBanner(tr, projectDir, nil), tr.LogMsgStartingStudio)
 
-	// Start async
-	if err := studioCmd.RunAsync(); err != nil {
+	// Start prisma studio through the app's Runner, unless node or npx has gone missing
+	var args []string
+	studioProc, err := sc.c.PrismaRunner().Studio(projectDir, port, prisma.StreamOpts{
+		OnStart: func(commandLine []string) { args = commandLine },
+	})
+	if err != nil {
+		sc.c.RecordCommand(args, nil, audit.ExitCodeNotStarted, 0)
 		sc.c.FinishCommand()
+		if sc.c.ShowMissingTool("Start Studio", err) {
+			return
+		}
 		sc.outputCtx.LogAction(tr.LogActionStudio, tr.ModalMsgFailedStartStudio+" "+err.Error())
 		modal := NewMessageModal(sc.g, tr, tr.ModalTitleStudioError,
 			tr.ModalMsgFailedStartStudio,
@@ -126,9 +459,12 @@ func (sc *StudioController) Studio() {
 		return
 	}
 
+	// Studio keeps running, so it is recorded when started
+	sc.c.RecordCommand(args, nil, 0, 0)
+
 	// Mark studio as running immediately to prevent double-start
+	sc.instances[projectDir] = &studioInstance{projectDir: projectDir, port: port, cmd: studioProc}
 	sc.studioRunning.Store(true)
-	sc.studioCmd = studioCmd
 
 	// Wait a bit to ensure it started, then finish the "starting" command
 	go func() {
@@ -136,13 +472,17 @@ func (sc *StudioController) Studio() {
 		sc.c.OnUIThread(func() error {
 			sc.c.FinishCommand() // Finish "starting" command
 
-			sc.outputCtx.LogAction(tr.LogActionStudioStarted, tr.LogMsgStudioListeningAt)
-			sc.outputCtx.SetSubtitle(tr.LogMsgStudioListeningAt)
+			if sc.isMultiProject() {
+				sc.outputCtx.LogAction(tr.LogActionStudioStarted, fmt.Sprintf(tr.LogMsgStudioProjectListeningAt, sc.projectLabel(cwd, projectDir), port))
+			} else {
+				sc.outputCtx.LogAction(tr.LogActionStudioStarted, fmt.Sprintf(tr.LogMsgStudioListeningAt, port))
+			}
+			sc.updateSubtitle()
 
 			// Show info modal
 			modal := NewMessageModal(sc.g, tr, tr.ModalTitleStudioStarted,
-				tr.ModalMsgStudioRunningAt,
-				tr.ModalMsgPressStopStudio,
+				fmt.Sprintf(tr.ModalMsgStudioRunningAt, port),
+				sc.stopHint(),
 			).WithStyle(MessageModalStyle{TitleColor: ColorGreen, BorderColor: ColorGreen})
 			sc.openModal(modal)
 			return nil
@@ -0,0 +1,223 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/dokadev/lazyprisma/pkg/gui/context"
+	"github.com/dokadev/lazyprisma/pkg/gui/style"
+	"github.com/dokadev/lazyprisma/pkg/gui/types"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+	"github.com/dokadev/lazyprisma/pkg/supabase"
+	"github.com/jesseduffield/gocui"
+)
+
+// SupabaseController helps with a Supabase CLI project: it shows whether the
+// local stack is running, opens or copies its URLs, and switches the session
+// between the local database and the remote project.
+type SupabaseController struct {
+	c          types.IControllerHost
+	g          *gocui.Gui
+	outputCtx  *context.OutputContext
+	clipboard  *ClipboardController
+	openModal  func(Modal)
+	closeModal func()
+
+	// Process environment replaced while targeting the local database, to
+	// restore when targeting the remote one again (nil = not replaced; a nil
+	// value means the variable wasn't set)
+	replaced map[string]*string
+}
+
+// NewSupabaseController creates a new SupabaseController.
+func NewSupabaseController(
+	c types.IControllerHost,
+	g *gocui.Gui,
+	outputCtx *context.OutputContext,
+	clipboard *ClipboardController,
+	openModal func(Modal),
+	closeModal func(),
+) *SupabaseController {
+	return &SupabaseController{
+		c:          c,
+		g:          g,
+		outputCtx:  outputCtx,
+		clipboard:  clipboard,
+		openModal:  openModal,
+		closeModal: closeModal,
+	}
+}
+
+// ShowActions opens the Supabase menu: the local stack's status, targeting
+// the local or the remote database, and the Studio, API and dashboard links
+func (sc *SupabaseController) ShowActions() {
+	tr := sc.c.GetTranslationSet()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		modal := NewMessageModal(sc.g, tr, tr.ModalTitleError,
+			tr.ErrorFailedGetWorkingDirectory,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		sc.openModal(modal)
+		return
+	}
+
+	project := supabase.Detect(cwd)
+	if project == nil {
+		modal := NewMessageModal(sc.g, tr, tr.ModalTitleSupabase,
+			fmt.Sprintf(tr.ModalMsgNoSupabaseProject, supabase.ConfigFile),
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		sc.openModal(modal)
+		return
+	}
+
+	running := project.LocalRunning()
+	status := style.Green(tr.SupabaseLocalRunning)
+	statusDesc := fmt.Sprintf(tr.SupabaseLocalRunningDesc, project.DBPort)
+	if !running {
+		status = style.Yellow(tr.SupabaseLocalStopped)
+		statusDesc = fmt.Sprintf(tr.SupabaseLocalStoppedDesc, project.DBPort)
+	}
+
+	var items []ListModalItem
+	items = append(items, ListModalItem{
+		Label:       status,
+		Description: statusDesc,
+		OnSelect: func() error {
+			sc.closeModal()
+			return nil
+		},
+	})
+
+	envVars := sc.urlEnvVars(cwd)
+	localURL := project.LocalDatabaseURL()
+	if len(envVars) > 0 {
+		if sc.replaced == nil {
+			items = append(items, ListModalItem{
+				Label:       tr.ListItemSupabaseLocal,
+				Description: fmt.Sprintf(tr.ListItemDescSupabaseLocal, strings.Join(envVars, ", "), localURL),
+				OnSelect: func() error {
+					sc.closeModal()
+					sc.targetLocal(envVars, localURL)
+					return nil
+				},
+			})
+		} else {
+			items = append(items, ListModalItem{
+				Label:       tr.ListItemSupabaseRemote,
+				Description: tr.ListItemDescSupabaseRemote,
+				OnSelect: func() error {
+					sc.closeModal()
+					sc.targetRemote()
+					return nil
+				},
+			})
+		}
+	}
+
+	items = append(items,
+		sc.linkItem(tr.ListItemSupabaseStudio, project.StudioURL()),
+		sc.linkItem(tr.ListItemSupabaseAPI, project.APIURL()),
+	)
+	if dashboard := project.DashboardURL(); dashboard != "" {
+		items = append(items, sc.linkItem(fmt.Sprintf(tr.ListItemSupabaseDashboard, project.Ref), dashboard))
+	}
+	items = append(items, ListModalItem{
+		Label:       tr.ListItemSupabaseCopyLocalURL,
+		Description: localURL,
+		OnSelect: func() error {
+			sc.closeModal()
+			sc.clipboard.CopyLink(localURL)
+			return nil
+		},
+	})
+
+	title := tr.ModalTitleSupabase
+	if project.ID != "" {
+		title = fmt.Sprintf(tr.ModalTitleSupabaseProject, project.ID)
+	}
+	modal := NewListModal(sc.g, tr, title, items,
+		func() { sc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+	sc.openModal(modal)
+}
+
+// linkItem opens link in the browser
+func (sc *SupabaseController) linkItem(label, link string) ListModalItem {
+	tr := sc.c.GetTranslationSet()
+
+	return ListModalItem{
+		Label:       label,
+		Description: fmt.Sprintf(tr.ListItemDescSupabaseLink, link),
+		OnSelect: func() error {
+			sc.closeModal()
+			args := BrowserCommand(link)
+			if err := StartDetached(args); err != nil {
+				sc.outputCtx.LogActionRed(tr.LogActionSupabase, err.Error())
+				// Still useful where no browser can be started, e.g. over SSH
+				sc.clipboard.CopyLink(link)
+				return nil
+			}
+			sc.outputCtx.LogAction(tr.LogActionSupabase, fmt.Sprintf(tr.LogMsgSupabaseOpened, link))
+			return nil
+		},
+	}
+}
+
+// urlEnvVars returns the environment variables the datasource's url and
+// directUrl are read from
+func (sc *SupabaseController) urlEnvVars(cwd string) []string {
+	var names []string
+	if name, err := prisma.GetEnvVarName(cwd); err == nil && name != "" {
+		names = append(names, name)
+	}
+	if _, name := prisma.GetDirectURL(cwd); name != "" && (len(names) == 0 || names[0] != name) {
+		names = append(names, name)
+	}
+	return names
+}
+
+// targetLocal points the datasource's variables at the local database for
+// the rest of the session. The process environment wins over .env files, so
+// every panel and every command started from now on uses it; nothing is
+// written to disk.
+func (sc *SupabaseController) targetLocal(envVars []string, localURL string) {
+	tr := sc.c.GetTranslationSet()
+
+	sc.replaced = make(map[string]*string)
+	for _, name := range envVars {
+		if value, ok := os.LookupEnv(name); ok {
+			sc.replaced[name] = &value
+		} else {
+			sc.replaced[name] = nil
+		}
+		os.Setenv(name, localURL)
+	}
+
+	sc.outputCtx.LogAction(tr.LogActionSupabase, fmt.Sprintf(tr.LogMsgSupabaseTargetLocal, strings.Join(envVars, ", "), localURL))
+	sc.c.RequestRefresh(types.RefreshEverything)
+}
+
+// targetRemote undoes targetLocal, so the variables resolve from the .env
+// files (or the environment lazyprisma was started in) again
+func (sc *SupabaseController) targetRemote() {
+	tr := sc.c.GetTranslationSet()
+
+	var names []string
+	for name, value := range sc.replaced {
+		if value != nil {
+			os.Setenv(name, *value)
+		} else {
+			os.Unsetenv(name)
+		}
+		names = append(names, name)
+	}
+	sc.replaced = nil
+	sort.Strings(names)
+
+	sc.outputCtx.LogAction(tr.LogActionSupabase, fmt.Sprintf(tr.LogMsgSupabaseTargetRemote, strings.Join(names, ", ")))
+	sc.c.RequestRefresh(types.RefreshEverything)
+}
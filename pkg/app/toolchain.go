@@ -0,0 +1,171 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/dokadev/lazyprisma/pkg/gui/context"
+	"github.com/dokadev/lazyprisma/pkg/gui/types"
+	"github.com/dokadev/lazyprisma/pkg/node"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+	"github.com/jesseduffield/gocui"
+)
+
+// ShowMissingTool explains that commandName could not start because node or
+// npx is gone, and returns true, if err is a *node.MissingToolError. Other
+// errors are left to the caller.
+func (a *App) ShowMissingTool(commandName string, err error) bool {
+	var missing *node.MissingToolError
+	if !errors.As(err, &missing) {
+		return false
+	}
+
+	message := fmt.Sprintf(a.Tr.ModalMsgToolMissing, commandName, missing.Tool)
+	a.g.Update(func(g *gocui.Gui) error {
+		if outputPanel, ok := a.panels[ViewOutputs].(*context.OutputContext); ok {
+			outputPanel.LogActionRed(a.Tr.LogActionToolchain, message)
+		}
+		a.openMissingToolModal(commandName, message)
+		return nil
+	})
+	return true
+}
+
+// openMissingToolModal shows the remediation steps, with re-detecting the
+// toolchain as the way out
+func (a *App) openMissingToolModal(commandName, message string) {
+	description := strings.Join([]string{
+		message,
+		a.Tr.ModalMsgToolchainFixVersionManager,
+		a.Tr.ModalMsgToolchainFixInstall,
+		a.Tr.ListItemDescRedetectToolchain,
+	}, "\n\n")
+
+	items := []ListModalItem{
+		{
+			Label:       a.Tr.ListItemRedetectToolchain,
+			Description: description,
+			OnSelect: func() error {
+				a.CloseModal()
+				a.redetectToolchain(commandName)
+				return nil
+			},
+		},
+	}
+
+	modal := NewListModal(a.g, a.Tr, a.Tr.ModalTitleToolchainMissing, items,
+		func() { a.CloseModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+	a.OpenModal(modal)
+}
+
+// redetectToolchain reloads the Workspace panel (which shows the Node.js
+// version) and looks for what prisma commands need once more
+func (a *App) redetectToolchain(commandName string) {
+	a.RequestRefresh(types.RefreshWorkspace, func() {
+		cwd, _ := os.Getwd()
+		if err := node.CheckToolchain(prisma.Command(cwd)); err != nil {
+			a.ShowMissingTool(commandName, err)
+			return
+		}
+
+		if outputPanel, ok := a.panels[ViewOutputs].(*context.OutputContext); ok {
+			path, _ := exec.LookPath("node")
+			outputPanel.LogAction(a.Tr.LogActionToolchain, fmt.Sprintf(a.Tr.LogMsgToolchainFound, path))
+		}
+	})
+}
+
+// ShowPrismaMissing explains that the Prisma CLI could not be run and offers
+// to install it. commandName is the command that needed it ("" at startup).
+func (a *App) ShowPrismaMissing(commandName string) {
+	cwd, _ := os.Getwd()
+	install := strings.Join(prisma.InstallCommand(cwd), " ")
+
+	lines := []string{a.Tr.ModalMsgPrismaMissing}
+	if commandName != "" {
+		lines = append([]string{fmt.Sprintf(a.Tr.ModalMsgPrismaMissingCommand, commandName)}, lines...)
+	}
+	intro := strings.Join(lines, "\n\n")
+
+	items := []ListModalItem{
+		{
+			Label:       a.Tr.ListItemInstallPrisma,
+			Description: intro + "\n\n" + fmt.Sprintf(a.Tr.ListItemDescInstallPrisma, install),
+			OnSelect: func() error {
+				a.CloseModal()
+				a.InstallPrisma()
+				return nil
+			},
+		},
+		{
+			Label:       a.Tr.ListItemContinueLimited,
+			Description: intro + "\n\n" + a.Tr.ListItemDescContinueLimited,
+			OnSelect: func() error {
+				a.CloseModal()
+				return nil
+			},
+		},
+	}
+
+	modal := NewListModal(a.g, a.Tr, a.Tr.ModalTitlePrismaMissing, items,
+		func() { a.CloseModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+	a.OpenModal(modal)
+}
+
+// InstallPrisma adds the Prisma CLI to the project with its package manager,
+// streaming the output, and leaves limited mode once the panels have been
+// reloaded with it
+func (a *App) InstallPrisma() {
+	cwd, _ := os.Getwd()
+	command := prisma.InstallCommand(cwd)
+
+	a.RunStreamingCommand(AsyncCommandOpts{
+		Name:          "Install Prisma",
+		Command:       command,
+		LogAction:     a.Tr.LogActionInstallPrisma,
+		LogDetail:     fmt.Sprintf(a.Tr.LogMsgInstallingPrisma, strings.Join(command, " ")),
+		ErrorTitle:    a.Tr.ModalTitleInstallPrismaFailed,
+		ErrorStartMsg: a.Tr.ModalMsgFailedStartInstallPrisma,
+		OnSuccess: func(out *context.OutputContext, cwd string) {
+			a.FinishCommand()
+			out.LogAction(a.Tr.LogActionInstallPrisma, a.Tr.LogMsgPrismaInstalled)
+			a.RequestRefresh(types.RefreshEverything, func() {
+				if a.prismaMissing.Load() {
+					a.ShowPrismaMissing("")
+					return
+				}
+				modal := NewMessageModal(a.g, a.Tr, a.Tr.ModalTitleInstallPrismaSuccess,
+					a.Tr.ModalMsgInstallPrismaSuccess,
+				).WithStyle(MessageModalStyle{TitleColor: ColorGreen, BorderColor: ColorGreen})
+				a.OpenModal(modal)
+			})
+		},
+		OnFailure: func(out *context.OutputContext, cwd string, exitCode int) {
+			a.FinishCommand()
+			out.LogActionRed(a.Tr.LogActionInstallPrisma, fmt.Sprintf(a.Tr.ModalMsgInstallPrismaFailed, exitCode))
+			modal := NewMessageModal(a.g, a.Tr, a.Tr.ModalTitleInstallPrismaFailed,
+				fmt.Sprintf(a.Tr.ModalMsgInstallPrismaFailed, exitCode),
+				a.Tr.ModalMsgCheckOutputPanel,
+			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed}).WithViewOutput(a.ShowFailedOutput)
+			a.OpenModal(modal)
+		},
+		OnError: func(out *context.OutputContext, cwd string, err error) {
+			// A non-zero exit is reported again through OnFailure
+			if _, isExit := err.(*exec.ExitError); isExit {
+				return
+			}
+			a.FinishCommand()
+			out.LogActionRed(a.Tr.LogActionInstallPrisma, err.Error())
+			modal := NewMessageModal(a.g, a.Tr, a.Tr.ModalTitleInstallPrismaFailed,
+				a.Tr.ModalMsgFailedStartInstallPrisma,
+				err.Error(),
+			).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+			a.OpenModal(modal)
+		},
+	})
+}
@@ -2,9 +2,12 @@ package app
 
 import (
 	"fmt"
+	"os"
 	"os/exec"
 	"runtime"
 	"strings"
+
+	"github.com/jesseduffield/gocui"
 )
 
 // CopyToClipboard copies text to the system clipboard
@@ -32,3 +35,105 @@ func CopyToClipboard(text string) error {
 	cmd.Stdin = strings.NewReader(text)
 	return cmd.Run()
 }
+
+// EditorCommand returns the command line that opens path in the user's
+// editor: $VISUAL, then $EDITOR (which may include arguments, e.g.
+// "code --wait"), falling back to vi (notepad on Windows)
+func EditorCommand(path string) []string {
+	editor := os.Getenv("VISUAL")
+	if strings.TrimSpace(editor) == "" {
+		editor = os.Getenv("EDITOR")
+	}
+
+	fields := strings.Fields(editor)
+	if len(fields) == 0 {
+		if runtime.GOOS == "windows" {
+			fields = []string{"notepad"}
+		} else {
+			fields = []string{"vi"}
+		}
+	}
+	return append(fields, path)
+}
+
+// OpenInEditor suspends the TUI, runs the user's editor on path in the
+// terminal and resumes once it exits
+func OpenInEditor(g *gocui.Gui, path string) error {
+	args := EditorCommand(path)
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := g.Suspend(); err != nil {
+		return err
+	}
+	runErr := cmd.Run()
+	if err := g.Resume(); err != nil {
+		return err
+	}
+	return runErr
+}
+
+// guiEditors are tried in order when no GUI editor is configured
+var guiEditors = []string{"code", "cursor", "zed", "subl", "idea"}
+
+// GUIEditorCommand returns the command line that opens dir in a GUI editor:
+// the configured command (which may include arguments, e.g. "code -n"), or
+// the first of guiEditors found on PATH. ok is false if there is none.
+func GUIEditorCommand(configured, dir string) (args []string, ok bool) {
+	fields := strings.Fields(configured)
+	if len(fields) == 0 {
+		for _, editor := range guiEditors {
+			if _, err := exec.LookPath(editor); err == nil {
+				fields = []string{editor}
+				break
+			}
+		}
+	}
+	if len(fields) == 0 {
+		return nil, false
+	}
+	return append(fields, dir), true
+}
+
+// FileManagerCommand returns the command line that shows dir in a file
+// manager: the configured command, or the system's
+func FileManagerCommand(configured, dir string) []string {
+	fields := strings.Fields(configured)
+	if len(fields) == 0 {
+		switch runtime.GOOS {
+		case "darwin":
+			fields = []string{"open"}
+		case "windows":
+			fields = []string{"explorer"}
+		default:
+			fields = []string{"xdg-open"}
+		}
+	}
+	return append(fields, dir)
+}
+
+// BrowserCommand returns the command line that opens link in the default
+// browser
+func BrowserCommand(link string) []string {
+	switch runtime.GOOS {
+	case "darwin":
+		return []string{"open", link}
+	case "windows":
+		return []string{"rundll32", "url.dll,FileProtocolHandler", link}
+	default:
+		return []string{"xdg-open", link}
+	}
+}
+
+// StartDetached starts a GUI program without waiting for it; its output is
+// discarded so it can't draw over the TUI
+func StartDetached(args []string) error {
+	cmd := exec.Command(args[0], args[1:]...)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
@@ -4,6 +4,7 @@ package app
 const (
 	ViewWorkspace  = "workspace"
 	ViewMigrations = "migrations"
+	ViewSchema     = "schema"
 	ViewDetails    = "details"
 	ViewOutputs    = "outputs"
 	ViewStatusbar  = "statusbar"
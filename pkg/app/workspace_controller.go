@@ -0,0 +1,645 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dokadev/lazyprisma/pkg/config"
+	"github.com/dokadev/lazyprisma/pkg/database"
+	"github.com/dokadev/lazyprisma/pkg/gui/context"
+	"github.com/dokadev/lazyprisma/pkg/gui/style"
+	"github.com/dokadev/lazyprisma/pkg/gui/types"
+	"github.com/dokadev/lazyprisma/pkg/i18n"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+	"github.com/dokadev/lazyprisma/pkg/supabase"
+	"github.com/jesseduffield/gocui"
+)
+
+// connectionTestPings is how many round trips a connection test measures
+const connectionTestPings = 5
+
+// WorkspaceController handles the quick actions of the Workspace panel.
+type WorkspaceController struct {
+	c             types.IControllerHost
+	g             *gocui.Gui
+	workspaceCtx  *context.WorkspaceContext
+	outputCtx     *context.OutputContext
+	clipboard     *ClipboardController
+	environments  *EnvironmentController
+	supabase      *SupabaseController
+	openModal     func(Modal)
+	closeModal    func()
+	toggleURLMask func()
+	open          config.OpenConfig
+}
+
+// NewWorkspaceController creates a new WorkspaceController.
+func NewWorkspaceController(
+	c types.IControllerHost,
+	g *gocui.Gui,
+	workspaceCtx *context.WorkspaceContext,
+	outputCtx *context.OutputContext,
+	clipboard *ClipboardController,
+	environments *EnvironmentController,
+	supabase *SupabaseController,
+	openModal func(Modal),
+	closeModal func(),
+	toggleURLMask func(),
+	open config.OpenConfig,
+) *WorkspaceController {
+	return &WorkspaceController{
+		c:             c,
+		g:             g,
+		workspaceCtx:  workspaceCtx,
+		outputCtx:     outputCtx,
+		clipboard:     clipboard,
+		environments:  environments,
+		supabase:      supabase,
+		openModal:     openModal,
+		closeModal:    closeModal,
+		toggleURLMask: toggleURLMask,
+		open:          open,
+	}
+}
+
+// ShowActions opens the menu of things to do with the workspace: edit its
+// .env file or schema, reload versions, reveal, copy or test the database
+// URL, inspect its parameters, add a directUrl when migrations would go
+// through a pooler, open the Supabase helper, and list the branches of a
+// PlanetScale database
+func (wc *WorkspaceController) ShowActions() {
+	tr := wc.c.GetTranslationSet()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		modal := NewMessageModal(wc.g, tr, tr.ModalTitleError,
+			tr.ErrorFailedGetWorkingDirectory,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		wc.openModal(modal)
+		return
+	}
+
+	envFile := envFileToEdit(cwd, wc.workspaceCtx.URLSourceFile())
+	schemaFile := prisma.SchemaPath(cwd)
+
+	items := []ListModalItem{
+		{
+			Label:       fmt.Sprintf(tr.ListItemEditEnvFile, relativePath(cwd, envFile)),
+			Description: fmt.Sprintf(tr.ListItemDescEditEnvFile, relativePath(cwd, envFile)),
+			OnSelect: func() error {
+				wc.closeModal()
+				wc.edit(envFile, types.RefreshWorkspace)
+				return nil
+			},
+		},
+		{
+			Label:       tr.ListItemOpenSchema,
+			Description: fmt.Sprintf(tr.ListItemDescOpenSchema, relativePath(cwd, schemaFile)),
+			OnSelect: func() error {
+				wc.closeModal()
+				// The datasource is in the schema too
+				wc.edit(schemaFile, types.RefreshEverything)
+				return nil
+			},
+		},
+		{
+			Label:       tr.ListItemRedetectVersions,
+			Description: tr.ListItemDescRedetectVersions,
+			OnSelect: func() error {
+				wc.closeModal()
+				wc.c.RequestRefresh(types.RefreshWorkspace)
+				return nil
+			},
+		},
+	}
+
+	if wc.workspaceCtx.CanRevealURL() {
+		label := tr.ListItemRevealURL
+		if !wc.workspaceCtx.IsURLMasked() {
+			label = tr.ListItemMaskURL
+		}
+		items = append(items, ListModalItem{
+			Label:       label,
+			Description: tr.ListItemDescToggleURLMask,
+			OnSelect: func() error {
+				wc.closeModal()
+				wc.toggleURLMask()
+				return nil
+			},
+		})
+	}
+
+	if wc.workspaceCtx.DatabaseURL(true) != "" {
+		items = append(items, ListModalItem{
+			Label:       tr.ListItemCopyURLMasked,
+			Description: tr.ListItemDescCopyURLMasked,
+			OnSelect: func() error {
+				wc.closeModal()
+				wc.clipboard.CopyDatabaseURL(wc.workspaceCtx, true)
+				return nil
+			},
+		})
+		// Copying the password is only allowed where showing it is
+		if wc.workspaceCtx.CanRevealURL() {
+			items = append(items, ListModalItem{
+				Label:       tr.ListItemCopyURL,
+				Description: tr.ListItemDescCopyURL,
+				OnSelect: func() error {
+					wc.closeModal()
+					wc.clipboard.CopyDatabaseURL(wc.workspaceCtx, false)
+					return nil
+				},
+			})
+		}
+	}
+
+	items = append(items, ListModalItem{
+		Label:       tr.ListItemTestConnection,
+		Description: tr.ListItemDescTestConnection,
+		OnSelect: func() error {
+			wc.closeModal()
+			wc.testConnection()
+			return nil
+		},
+	})
+
+	// A TLS error some URL parameters work around; only a .env file is edited
+	if params, editable := wc.workspaceCtx.TLSFix(); params != nil && editable {
+		envVar, _ := prisma.GetEnvVarName(cwd)
+		envFile := wc.workspaceCtx.URLSourceFile()
+		items = append(items, ListModalItem{
+			Label:       tr.ListItemFixTLS,
+			Description: fmt.Sprintf(tr.ListItemDescFixTLS, database.FormatTLSParams(params), envVar, relativePath(cwd, envFile)),
+			OnSelect: func() error {
+				wc.closeModal()
+				wc.fixTLS(cwd, envFile, envVar, params)
+				return nil
+			},
+		})
+	}
+
+	if report := wc.workspaceCtx.URLParams(); report != nil && len(report.Params) > 0 {
+		items = append(items, ListModalItem{
+			Label:       tr.ListItemURLParams,
+			Description: fmt.Sprintf(tr.ListItemDescURLParams, len(report.Params), report.Problems()),
+			OnSelect: func() error {
+				wc.closeModal()
+				wc.showURLParams(report)
+				return nil
+			},
+		})
+	}
+
+	// Migrations through a transaction-mode pooler fail; offer a directUrl
+	if ds, err := prisma.GetDatasource(cwd); err == nil {
+		if pooled := prisma.CheckPooledMigrations(cwd, ds); pooled != nil && pooled.Setting == "url" {
+			items = append(items, ListModalItem{
+				Label:       tr.ListItemAddDirectURL,
+				Description: fmt.Sprintf(tr.ListItemDescAddDirectURL, pooled.Pooler),
+				OnSelect: func() error {
+					wc.closeModal()
+					wc.addDirectURL(cwd, pooled)
+					return nil
+				},
+			})
+		}
+	}
+
+	if supabase.Detect(cwd) != nil {
+		items = append(items, ListModalItem{
+			Label:       tr.ListItemSupabase,
+			Description: tr.ListItemDescSupabase,
+			OnSelect: func() error {
+				wc.closeModal()
+				wc.supabase.ShowActions()
+				return nil
+			},
+		})
+	}
+
+	if wc.workspaceCtx.IsPlanetScale() {
+		items = append(items, ListModalItem{
+			Label:       tr.ListItemPlanetScaleBranches,
+			Description: fmt.Sprintf(tr.ListItemDescPlanetScaleBranches, config.ProjectConfigFile),
+			OnSelect: func() error {
+				wc.closeModal()
+				wc.environments.ShowPlanetScaleBranches()
+				return nil
+			},
+		})
+	}
+
+	modal := NewListModal(wc.g, tr, tr.ModalTitleWorkspaceActions, items,
+		func() { wc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+	wc.openModal(modal)
+}
+
+// showURLParams lists the database URL's parameters with what each does, the
+// problematic ones first
+func (wc *WorkspaceController) showURLParams(report *prisma.URLParamsReport) {
+	tr := wc.c.GetTranslationSet()
+	closeItem := func() error {
+		wc.closeModal()
+		return nil
+	}
+
+	var items, problems []ListModalItem
+	if report.PgBouncerWithoutDirectURL {
+		problems = append(problems, ListModalItem{
+			Label:       style.Orange(tr.URLParamPgBouncerNoDirect),
+			Description: tr.URLParamPgBouncerNoDirectDesc,
+			OnSelect:    closeItem,
+		})
+	}
+
+	provider := database.GetProviderDisplayName(report.Provider)
+	for _, param := range report.Params {
+		value := param.Value
+		if prisma.SecretURLParams[strings.ToLower(param.Name)] {
+			value = "****"
+		}
+		label := param.Name + " = " + value
+
+		var description []string
+		if hint := urlParamHint(tr, strings.ToLower(param.Name)); hint != "" {
+			description = append(description, hint)
+		}
+		switch param.Problem {
+		case prisma.URLParamUnknown:
+			description = append(description, fmt.Sprintf(tr.URLParamUnknown, param.Name, provider))
+			if param.Suggestion != "" {
+				description = append(description, fmt.Sprintf(tr.URLParamDidYouMean, param.Suggestion))
+			}
+		case prisma.URLParamInvalid:
+			if values := prisma.AcceptedURLParamValues(param.Name); values != nil {
+				description = append(description, fmt.Sprintf(tr.URLParamInvalidValues, strings.Join(values, ", ")))
+			} else {
+				description = append(description, tr.URLParamInvalidNumber)
+			}
+		case prisma.URLParamDuplicate:
+			description = append(description, tr.URLParamDuplicate)
+		}
+
+		item := ListModalItem{Label: label, Description: strings.Join(description, " "), OnSelect: closeItem}
+		if param.Problem != prisma.URLParamOK {
+			item.Label = style.Orange("⚠ " + label)
+			problems = append(problems, item)
+		} else {
+			items = append(items, item)
+		}
+	}
+
+	modal := NewListModal(wc.g, tr, tr.ModalTitleURLParams, append(problems, items...),
+		func() { wc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+	wc.openModal(modal)
+}
+
+// addDirectURL adds directUrl = env("DIRECT_URL") to the schema and, unless
+// DIRECT_URL is already set, the unpooled URL to the .env file: the one
+// derived from the pooled URL after confirming, or one asked for
+func (wc *WorkspaceController) addDirectURL(cwd string, pooled *prisma.PooledMigrations) {
+	tr := wc.c.GetTranslationSet()
+	schemaFile := relativePath(cwd, prisma.SchemaPath(cwd))
+	envFile := envFileToEdit(cwd, wc.workspaceCtx.URLSourceFile())
+
+	if prisma.ResolveEnvVar(cwd, prisma.DirectURLEnvVar) != "" {
+		modal := NewConfirmModal(wc.g, tr, tr.ModalTitleAddDirectURL,
+			fmt.Sprintf(tr.ModalMsgAddDirectURLSchemaOnly, schemaFile, prisma.DirectURLEnvVar),
+			func() {
+				wc.closeModal()
+				wc.writeDirectURL(cwd, envFile, "")
+			},
+			func() { wc.closeModal() },
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		wc.openModal(modal)
+		return
+	}
+
+	if pooled.DirectURL != "" {
+		modal := NewConfirmModal(wc.g, tr, tr.ModalTitleAddDirectURL,
+			fmt.Sprintf(tr.ModalMsgAddDirectURL, schemaFile, prisma.DirectURLEnvVar, prisma.MaskPassword(pooled.DirectURL), relativePath(cwd, envFile)),
+			func() {
+				wc.closeModal()
+				wc.writeDirectURL(cwd, envFile, pooled.DirectURL)
+			},
+			func() { wc.closeModal() },
+		).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+		wc.openModal(modal)
+		return
+	}
+
+	modal := NewInputModal(wc.g, tr, tr.ModalTitleAddDirectURL,
+		func(input string) {
+			wc.closeModal()
+			wc.writeDirectURL(cwd, envFile, strings.TrimSpace(input))
+		},
+		func() { wc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan}).
+		WithSubtitle(fmt.Sprintf(tr.ModalMsgEnterDirectURL, pooled.Pooler, prisma.DirectURLEnvVar, relativePath(cwd, envFile))).
+		WithRequired(true)
+	wc.openModal(modal)
+}
+
+// writeDirectURL edits the schema and appends directURL to envFile ("" = leave
+// the .env file alone)
+func (wc *WorkspaceController) writeDirectURL(cwd, envFile, directURL string) {
+	tr := wc.c.GetTranslationSet()
+
+	showError := func(err error) {
+		wc.outputCtx.LogActionRed(tr.LogActionAddDirectURL, err.Error())
+		modal := NewMessageModal(wc.g, tr, tr.ModalTitleAddDirectURLFailed,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		wc.openModal(modal)
+	}
+
+	if err := prisma.AddDirectURL(cwd); err != nil {
+		showError(err)
+		return
+	}
+	edited := []string{relativePath(cwd, prisma.SchemaPath(cwd))}
+	if directURL != "" {
+		if err := prisma.AppendEnvVar(envFile, prisma.DirectURLEnvVar, directURL); err != nil {
+			showError(err)
+			return
+		}
+		edited = append(edited, relativePath(cwd, envFile))
+	}
+
+	wc.outputCtx.LogAction(tr.LogActionAddDirectURL, fmt.Sprintf(tr.LogMsgDirectURLAdded, strings.Join(edited, ", ")))
+	wc.c.RequestRefresh(types.RefreshEverything)
+	modal := NewMessageModal(wc.g, tr, tr.ModalTitleDirectURLAdded,
+		fmt.Sprintf(tr.ModalMsgDirectURLAdded, prisma.DirectURLEnvVar),
+	).WithStyle(MessageModalStyle{TitleColor: ColorGreen, BorderColor: ColorGreen})
+	wc.openModal(modal)
+}
+
+// fixTLS adds params to the database URL in envFile after confirming, warning
+// first if they turn off certificate verification
+func (wc *WorkspaceController) fixTLS(cwd, envFile, envVar string, params [][2]string) {
+	tr := wc.c.GetTranslationSet()
+	provider, _ := prisma.GetProvider(cwd)
+	formatted := database.FormatTLSParams(params)
+
+	message := fmt.Sprintf(tr.ModalMsgFixTLS, formatted, envVar, relativePath(cwd, envFile))
+	if database.DisablesCertVerification(params) {
+		message += "\n\n" + tr.ModalMsgFixTLSInsecure
+	}
+
+	modal := NewConfirmModal(wc.g, tr, tr.ModalTitleFixTLS, message,
+		func() {
+			wc.closeModal()
+			err := prisma.UpdateEnvVar(envFile, envVar, func(value string) string {
+				return prisma.SetURLParams(provider, value, params)
+			})
+			if err != nil {
+				wc.outputCtx.LogActionRed(tr.LogActionFixTLS, err.Error())
+				modal := NewMessageModal(wc.g, tr, tr.ModalTitleFixTLSFailed,
+					err.Error(),
+				).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+				wc.openModal(modal)
+				return
+			}
+			wc.outputCtx.LogAction(tr.LogActionFixTLS,
+				fmt.Sprintf(tr.LogMsgTLSParamsAdded, formatted, envVar, relativePath(cwd, envFile)))
+			wc.c.RequestRefresh(types.RefreshEverything)
+		},
+		func() { wc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+	wc.openModal(modal)
+}
+
+// urlParamHint returns what a connection URL parameter does ("" = no hint)
+func urlParamHint(tr *i18n.TranslationSet, name string) string {
+	switch name {
+	case "schema":
+		return tr.URLParamHintSchema
+	case "host":
+		return tr.URLParamHintHost
+	case "sslmode":
+		return tr.URLParamHintSSLMode
+	case "sslcert":
+		return tr.URLParamHintSSLCert
+	case "sslrootcert":
+		return tr.URLParamHintSSLRootCert
+	case "sslidentity":
+		return tr.URLParamHintSSLIdentity
+	case "sslpassword":
+		return tr.URLParamHintSSLPassword
+	case "sslaccept":
+		return tr.URLParamHintSSLAccept
+	case "connection_limit", "connectionlimit":
+		return tr.URLParamHintConnectionLimit
+	case "connect_timeout", "connecttimeout":
+		return tr.URLParamHintConnectTimeout
+	case "pool_timeout", "pooltimeout":
+		return tr.URLParamHintPoolTimeout
+	case "socket_timeout", "sockettimeout":
+		return tr.URLParamHintSocketTimeout
+	case "max_connection_lifetime":
+		return tr.URLParamHintMaxLifetime
+	case "max_idle_connection_lifetime":
+		return tr.URLParamHintMaxIdleLifetime
+	case "statement_cache_size":
+		return tr.URLParamHintStatementCache
+	case "pgbouncer":
+		return tr.URLParamHintPgBouncer
+	case "application_name", "applicationname":
+		return tr.URLParamHintApplicationName
+	case "channel_binding":
+		return tr.URLParamHintChannelBinding
+	case "options":
+		return tr.URLParamHintOptions
+	case "socket":
+		return tr.URLParamHintSocket
+	case "database", "initial catalog":
+		return tr.URLParamHintDatabase
+	case "encrypt":
+		return tr.URLParamHintEncrypt
+	case "trustservercertificate":
+		return tr.URLParamHintTrustServerCert
+	}
+	return ""
+}
+
+// OpenProject opens the menu to open the project root or its prisma directory
+// in a GUI editor or the file manager, for edits beyond the schema and .env
+func (wc *WorkspaceController) OpenProject() {
+	tr := wc.c.GetTranslationSet()
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		modal := NewMessageModal(wc.g, tr, tr.ModalTitleError,
+			tr.ErrorFailedGetWorkingDirectory,
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		wc.openModal(modal)
+		return
+	}
+
+	dirs := []string{cwd}
+	if info, err := os.Stat(filepath.Join(cwd, prisma.SchemaDirName)); err == nil && info.IsDir() {
+		dirs = append(dirs, filepath.Join(cwd, prisma.SchemaDirName))
+	}
+
+	var items []ListModalItem
+	for _, dir := range dirs {
+		name := filepath.Base(dir) + string(filepath.Separator)
+
+		editorArgs, hasEditor := GUIEditorCommand(wc.open.Editor, dir)
+		editorLabel := fmt.Sprintf(tr.ListItemOpenInEditor, name, tr.GUIEditorUnknown)
+		editorDesc := tr.ModalMsgNoGUIEditor
+		if hasEditor {
+			editorLabel = fmt.Sprintf(tr.ListItemOpenInEditor, name, filepath.Base(editorArgs[0]))
+			editorDesc = fmt.Sprintf(tr.ListItemDescOpenInEditor, strings.Join(editorArgs, " "))
+		}
+		items = append(items, ListModalItem{
+			Label:       editorLabel,
+			Description: editorDesc,
+			OnSelect: func() error {
+				wc.closeModal()
+				if !hasEditor {
+					modal := NewMessageModal(wc.g, tr, tr.ModalTitleOpenProject,
+						tr.ModalMsgNoGUIEditor,
+					).WithStyle(MessageModalStyle{TitleColor: ColorYellow, BorderColor: ColorYellow})
+					wc.openModal(modal)
+					return nil
+				}
+				wc.start(editorArgs, name)
+				return nil
+			},
+		})
+
+		fileManagerArgs := FileManagerCommand(wc.open.FileManager, dir)
+		items = append(items, ListModalItem{
+			Label:       fmt.Sprintf(tr.ListItemOpenInFileManager, name),
+			Description: fmt.Sprintf(tr.ListItemDescOpenInFileManager, strings.Join(fileManagerArgs, " ")),
+			OnSelect: func() error {
+				wc.closeModal()
+				wc.start(fileManagerArgs, name)
+				return nil
+			},
+		})
+	}
+
+	modal := NewListModal(wc.g, tr, tr.ModalTitleOpenProject, items,
+		func() { wc.closeModal() },
+	).WithStyle(MessageModalStyle{TitleColor: ColorCyan, BorderColor: ColorCyan})
+	wc.openModal(modal)
+}
+
+// start runs a GUI program on the directory called name, without waiting for it
+func (wc *WorkspaceController) start(args []string, name string) {
+	tr := wc.c.GetTranslationSet()
+
+	if err := StartDetached(args); err != nil {
+		wc.outputCtx.LogActionRed(tr.LogActionOpenProject, err.Error())
+		modal := NewMessageModal(wc.g, tr, tr.ModalTitleOpenProject,
+			fmt.Sprintf(tr.ModalMsgOpenProjectFailed, args[0]),
+			err.Error(),
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		wc.openModal(modal)
+		return
+	}
+	wc.outputCtx.LogAction(tr.LogActionOpenProject, fmt.Sprintf(tr.LogMsgOpenedProject, name, strings.Join(args, " ")))
+}
+
+// edit opens path in the user's editor and reloads the panels of scope once it exits
+func (wc *WorkspaceController) edit(path string, scope types.RefreshScope) {
+	tr := wc.c.GetTranslationSet()
+
+	if err := OpenInEditor(wc.g, path); err != nil {
+		modal := NewMessageModal(wc.g, tr, tr.ModalTitleEditorFailed,
+			fmt.Sprintf(tr.ModalMsgFailedOpenEditor, filepath.Base(path)),
+			err.Error(),
+			tr.ModalMsgSetEditor,
+		).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+		wc.openModal(modal)
+		return
+	}
+
+	wc.c.RequestRefresh(scope)
+}
+
+// testConnection resolves the database URL again, measures the latency to the
+// database and reports it with the server version
+func (wc *WorkspaceController) testConnection() {
+	tr := wc.c.GetTranslationSet()
+
+	if !wc.c.TryStartCommand("Test Connection") {
+		wc.c.LogCommandBlocked("Test Connection")
+		return
+	}
+
+	wc.outputCtx.LogAction(tr.LogActionTestConnection,
+		fmt.Sprintf(tr.LogMsgTestingConnection, wc.workspaceCtx.DatabaseURL(true), connectionTestPings))
+	wc.workspaceCtx.StartLoading(tr.RefreshStepDatabase)
+
+	go func() {
+		probe, err := wc.workspaceCtx.TestConnection(connectionTestPings)
+
+		wc.c.OnUIThread(func() error {
+			wc.workspaceCtx.FinishLoading()
+			wc.c.FinishCommand()
+
+			url := wc.workspaceCtx.DatabaseURL(true)
+			if err != nil {
+				wc.outputCtx.LogActionRed(tr.LogActionTestConnection, err.Error())
+
+				lines := []string{err.Error()}
+				if url != "" {
+					lines = append([]string{fmt.Sprintf(tr.ModalMsgConnectionFailed, url)}, lines...)
+				}
+				if problem := wc.workspaceCtx.TLSProblem(); problem != database.TLSOK {
+					lines = append(lines, "", context.DescribeTLSProblem(tr, problem))
+					if params, editable := wc.workspaceCtx.TLSFix(); params != nil && editable {
+						lines = append(lines, fmt.Sprintf(tr.WorkspaceTLSFix, database.FormatTLSParams(params)))
+					} else if params != nil {
+						lines = append(lines, fmt.Sprintf(tr.WorkspaceTLSFixManual, database.FormatTLSParams(params)))
+					}
+				}
+				modal := NewMessageModal(wc.g, tr, tr.ModalTitleConnectionTest,
+					lines...,
+				).WithStyle(MessageModalStyle{TitleColor: ColorRed, BorderColor: ColorRed})
+				wc.openModal(modal)
+				return nil
+			}
+
+			avg := context.FormatLatency(probe.Avg())
+			low := context.FormatLatency(probe.Min())
+			high := context.FormatLatency(probe.Max())
+			wc.outputCtx.LogAction(tr.LogActionTestConnection,
+				fmt.Sprintf(tr.LogMsgConnectionTested, url, avg, low, high))
+
+			lines := []string{fmt.Sprintf(tr.ModalMsgConnectionOK, url)}
+			if probe.ServerVersion != "" {
+				lines = append(lines, fmt.Sprintf(tr.WorkspaceServerVersion, probe.ServerVersion))
+			}
+			lines = append(lines, fmt.Sprintf(tr.ModalMsgConnectionLatency, len(probe.Latencies), avg, low, high))
+			modal := NewMessageModal(wc.g, tr, tr.ModalTitleConnectionTest,
+				lines...,
+			).WithStyle(MessageModalStyle{TitleColor: ColorGreen, BorderColor: ColorGreen})
+			wc.openModal(modal)
+			return nil
+		})
+	}()
+}
+
+// envFileToEdit returns the .env file the database URL was read from, or else
+// the first of the project's .env files that exists, or else .env in cwd
+func envFileToEdit(cwd, urlSourceFile string) string {
+	if urlSourceFile != "" && strings.HasPrefix(filepath.Base(urlSourceFile), ".env") {
+		return urlSourceFile
+	}
+	for _, path := range prisma.EnvFiles(cwd) {
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(cwd, ".env")
+}
@@ -0,0 +1,177 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Audit file formats
+const (
+	FormatText  = "text"  // Tab-separated, one command per line
+	FormatJSONL = "jsonl" // One JSON object per line
+)
+
+// ExitCodeNotStarted marks a command that could not be started or was aborted
+const ExitCodeNotStarted = -1
+
+// Entry is one executed command
+type Entry struct {
+	Time       time.Time `json:"time"`
+	User       string    `json:"user"`
+	Dir        string    `json:"dir"`
+	DBTarget   string    `json:"dbTarget"` // host:port/database of the target database (no credentials)
+	Command    string    `json:"command"`
+	ExitCode   int       `json:"exitCode"`
+	DurationMs int64     `json:"durationMs"`
+}
+
+// Log is an append-only audit file
+type Log struct {
+	path   string
+	format string
+	mu     sync.Mutex
+}
+
+// NewLog creates an audit log writing to path in the given format
+func NewLog(path, format string) *Log {
+	if format != FormatJSONL {
+		format = FormatText
+	}
+	return &Log{path: path, format: format}
+}
+
+// Path returns the audit file path
+func (l *Log) Path() string {
+	return l.path
+}
+
+// Append writes an entry to the end of the audit file, creating it if needed
+func (l *Log) Append(e Entry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var line string
+	if l.format == FormatJSONL {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		line = string(data)
+	} else {
+		line = strings.Join([]string{
+			e.Time.Format(time.RFC3339),
+			e.User,
+			e.DBTarget,
+			strconv.Itoa(e.ExitCode),
+			strconv.FormatInt(e.DurationMs, 10),
+			e.Dir,
+			e.Command,
+		}, "\t")
+	}
+
+	_, err = f.WriteString(line + "\n")
+	return err
+}
+
+// Read returns up to limit entries, newest first (0 = all).
+// Lines of either format are accepted; unparseable lines are skipped.
+func (l *Log) Read(limit int) ([]Entry, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.Open(l.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if entry, ok := parseLine(scanner.Text()); ok {
+			entries = append(entries, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// Newest first
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+// parseLine parses a text or JSON lines entry
+func parseLine(line string) (Entry, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return Entry{}, false
+	}
+
+	if strings.HasPrefix(line, "{") {
+		var e Entry
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			return Entry{}, false
+		}
+		return e, true
+	}
+
+	fields := strings.SplitN(line, "\t", 7)
+	if len(fields) != 7 {
+		return Entry{}, false
+	}
+	t, err := time.Parse(time.RFC3339, fields[0])
+	if err != nil {
+		return Entry{}, false
+	}
+	exitCode, _ := strconv.Atoi(fields[3])
+	durationMs, _ := strconv.ParseInt(fields[4], 10, 64)
+
+	return Entry{
+		Time:       t,
+		User:       fields[1],
+		DBTarget:   fields[2],
+		ExitCode:   exitCode,
+		DurationMs: durationMs,
+		Dir:        fields[5],
+		Command:    fields[6],
+	}, true
+}
+
+// CurrentUser returns the name of the user running lazyprisma
+func CurrentUser() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return fmt.Sprintf("uid:%d", os.Getuid())
+}
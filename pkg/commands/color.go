@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"os"
+	"strings"
+)
+
+// ColorMode says whether spawned commands colour their output
+type ColorMode int
+
+const (
+	ColorAuto   ColorMode = iota // Leave NO_COLOR and FORCE_COLOR as they are
+	ColorNever                   // NO_COLOR=1 and FORCE_COLOR=0
+	ColorAlways                  // FORCE_COLOR=1, without NO_COLOR
+)
+
+// colorMode applies to every command started afterwards
+var colorMode = ColorAuto
+
+// SetColorMode sets the NO_COLOR / FORCE_COLOR environment commands get, so
+// their output matches lazyprisma's own styling
+func SetColorMode(mode ColorMode) {
+	colorMode = mode
+}
+
+// ColorEnv returns the variables the colour mode sets on every command, as
+// "KEY=value" pairs (none in ColorAuto)
+func ColorEnv() []string {
+	switch colorMode {
+	case ColorNever:
+		return []string{"NO_COLOR=1", "FORCE_COLOR=0"}
+	case ColorAlways:
+		return []string{"FORCE_COLOR=1"}
+	}
+	return nil
+}
+
+// environ returns the environment a command starts with: the process
+// environment, adjusted for the colour mode
+func environ() []string {
+	if colorMode == ColorAuto {
+		return os.Environ()
+	}
+
+	var env []string
+	for _, kv := range os.Environ() {
+		if strings.HasPrefix(kv, "NO_COLOR=") || strings.HasPrefix(kv, "FORCE_COLOR=") {
+			continue
+		}
+		env = append(env, kv)
+	}
+	return append(env, ColorEnv()...)
+}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"context"
+	"os"
 	"os/exec"
 	"syscall"
 	"time"
@@ -42,13 +43,26 @@ func (c *Command) WithWorkingDir(dir string) *Command {
 	return c
 }
 
-// WithEnv adds environment variables
+// WithEnv adds environment variables on top of the current process environment.
+// Later values override earlier ones with the same name.
 func (c *Command) WithEnv(vars ...string) *Command {
+	if c.cmd.Env == nil {
+		c.cmd.Env = environ()
+	}
 	c.envVars = append(c.envVars, vars...)
 	c.cmd.Env = append(c.cmd.Env, vars...)
 	return c
 }
 
+// Interactive connects the command to the terminal's stdin, stdout and stderr.
+// Only use it while the TUI is not running.
+func (c *Command) Interactive() *Command {
+	c.cmd.Stdin = os.Stdin
+	c.cmd.Stdout = os.Stdout
+	c.cmd.Stderr = os.Stderr
+	return c
+}
+
 // StreamOutput enables real-time output streaming
 func (c *Command) StreamOutput() *Command {
 	c.streamOutput = true
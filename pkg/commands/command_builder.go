@@ -36,6 +36,7 @@ func (b *CommandBuilder) NewWithContext(ctx context.Context, args ...string) *Co
 	
 	// Create a new process group for process management (Kill via -PID)
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Env = environ()
 
 	return &Command{
 		cmd:    cmd,
@@ -52,6 +53,7 @@ func (b *CommandBuilder) NewShell(ctx context.Context, cmdStr string) *Command {
 	
 	// Create a new process group for process management (Kill via -PID)
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Env = environ()
 
 	return &Command{
 		cmd:    cmd,
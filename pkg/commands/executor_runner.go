@@ -133,6 +133,7 @@ func (r *commandRunner) RunAndStream(cmd *Command) error {
 	go func() {
 		defer close(stdoutDone)
 		scanner := bufio.NewScanner(stdoutPipe)
+		scanner.Split(scanLines)
 		for scanner.Scan() {
 			line := scanner.Text()
 			if cmd.onStdout != nil {
@@ -145,6 +146,7 @@ func (r *commandRunner) RunAndStream(cmd *Command) error {
 	go func() {
 		defer close(stderrDone)
 		scanner := bufio.NewScanner(stderrPipe)
+		scanner.Split(scanLines)
 		for scanner.Scan() {
 			line := scanner.Text()
 			if cmd.onStderr != nil {
@@ -179,3 +181,33 @@ func (r *commandRunner) RunAndStream(cmd *Command) error {
 
 	return err
 }
+
+// scanLines is bufio.ScanLines that also ends a line at a lone carriage return,
+// so progress bars that redraw themselves with '\r' stream every update
+func scanLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		if data[i] == '\n' {
+			return i + 1, data[:i], nil
+		}
+		// '\r': need the next byte to tell "\r\n" from a lone '\r'
+		if i+1 < len(data) {
+			if data[i+1] == '\n' {
+				return i + 2, data[:i], nil
+			}
+			return i + 1, data[:i], nil
+		}
+		if atEOF {
+			return i + 1, data[:i], nil
+		}
+		return 0, nil, nil
+	}
+
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
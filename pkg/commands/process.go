@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"fmt"
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ProcessInfo describes an OS process found on the local machine
+type ProcessInfo struct {
+	PID     int
+	Command string // Full command line (may be empty if it could not be read)
+}
+
+// IsPortInUse reports whether something is accepting TCP connections on localhost:port
+func IsPortInUse(port int) bool {
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("localhost:%d", port), 300*time.Millisecond)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// FindListeningProcess returns the process listening on the given TCP port.
+// Returns nil (without error) if no listener could be identified.
+func FindListeningProcess(port int) (*ProcessInfo, error) {
+	if _, err := exec.LookPath("lsof"); err != nil {
+		return nil, fmt.Errorf("lsof not found: %w", err)
+	}
+
+	builder := NewCommandBuilder(NewPlatform())
+	result, err := builder.New("lsof", "-nP", fmt.Sprintf("-iTCP:%d", port), "-sTCP:LISTEN", "-t").RunWithOutput()
+	if err != nil {
+		// lsof exits with 1 when nothing matches
+		if result != nil && result.ExitCode == 1 {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for _, line := range strings.Split(result.Stdout, "\n") {
+		pid, err := strconv.Atoi(strings.TrimSpace(line))
+		if err != nil {
+			continue
+		}
+		return &ProcessInfo{
+			PID:     pid,
+			Command: processCommandLine(builder, pid),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// processCommandLine returns the full command line of a process via ps
+func processCommandLine(builder *CommandBuilder, pid int) string {
+	result, err := builder.New("ps", "-o", "command=", "-p", strconv.Itoa(pid)).RunWithOutput()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(result.Stdout)
+}
+
+// KillProcess terminates a process (and its process group when it leads one).
+// SIGTERM is sent first; SIGKILL follows if the process is still alive after the grace period.
+func KillProcess(pid int, grace time.Duration) error {
+	if pid <= 0 {
+		return fmt.Errorf("invalid pid: %d", pid)
+	}
+
+	// Signal the whole group if the process is a group leader, otherwise just the process
+	target := pid
+	if pgid, err := syscall.Getpgid(pid); err == nil && pgid == pid {
+		target = -pid
+	}
+
+	if err := syscall.Kill(target, syscall.SIGTERM); err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(grace)
+	for time.Now().Before(deadline) {
+		if !IsProcessAlive(pid) {
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	if err := syscall.Kill(target, syscall.SIGKILL); err != nil && err != syscall.ESRCH {
+		return err
+	}
+	return nil
+}
+
+// IsProcessAlive reports whether a process with the given PID exists
+func IsProcessAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	err := syscall.Kill(pid, 0)
+	return err == nil || err == syscall.EPERM
+}
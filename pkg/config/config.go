@@ -15,8 +15,17 @@ const (
 
 // Config holds application configuration
 type Config struct {
-	Scan     ScanConfig `yaml:"scan"`
-	Language string     `yaml:"language"`
+	Scan     ScanConfig    `yaml:"scan"`
+	Studio   StudioConfig  `yaml:"studio"`
+	Audit    AuditConfig   `yaml:"audit"`
+	Stats    StatsConfig   `yaml:"stats"`
+	Display  DisplayConfig `yaml:"display"`
+	Output   OutputConfig  `yaml:"output"`
+	Deploy   DeployConfig  `yaml:"deploy"`
+	Node     NodeConfig    `yaml:"node"`
+	Open     OpenConfig    `yaml:"open"`
+	Watch    WatchConfig   `yaml:"watch"`
+	Language string        `yaml:"language"`
 }
 
 // ScanConfig holds project scanning settings
@@ -25,6 +34,68 @@ type ScanConfig struct {
 	ExcludeDirs []string `yaml:"excludeDirs"`
 }
 
+// StudioConfig holds Prisma Studio settings
+type StudioConfig struct {
+	Port int `yaml:"port"`
+}
+
+// AuditConfig holds settings for the audit trail of executed commands
+type AuditConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Path    string `yaml:"path"`   // Audit file (default: audit.log in the config directory)
+	Format  string `yaml:"format"` // "text" (tab-separated) or "jsonl"
+}
+
+// StatsConfig holds settings for local usage statistics
+type StatsConfig struct {
+	Enabled bool `yaml:"enabled"` // Stored in stats.json in the config directory; never sent anywhere
+}
+
+// DisplayConfig holds settings for how SQL and the database URL are shown
+type DisplayConfig struct {
+	SQLHighlight      string `yaml:"sqlHighlight"`      // "chroma" (full syntax highlighting), "keywords" or "off"
+	HighlightMaxLines int    `yaml:"highlightMaxLines"` // Longer SQL only gets keyword coloring (0 = no limit)
+	RevealURL         bool   `yaml:"revealURL"`         // Allow revealing the masked database URL (off for shared screens)
+	RevealSeconds     int    `yaml:"revealSeconds"`     // A revealed URL is masked again after this long (0 = never)
+	StaleWarnMinutes  int    `yaml:"staleWarnMinutes"`  // Panel footers turn amber when the data is this old (0 = never)
+	StaleAlertMinutes int    `yaml:"staleAlertMinutes"` // ... and red when it is this old (0 = never)
+}
+
+// OutputConfig holds settings for the Output panel
+type OutputConfig struct {
+	MaxLines int    `yaml:"maxLines"` // Oldest lines are dropped from the panel beyond this (0 = no limit)
+	LogPath  string `yaml:"logPath"`  // Full output of the session (default: output.log in the config directory)
+	// Log the exact command line, working directory and environment overrides
+	// of each command before it runs (also on with --debug)
+	ShowInvocation bool `yaml:"showInvocation"`
+}
+
+// DeployConfig holds settings for deploying migrations
+type DeployConfig struct {
+	CountdownSeconds int `yaml:"countdownSeconds"` // Length of the "Deploy after countdown" option (0 = hide it)
+}
+
+// NodeConfig holds settings for how Node.js is run
+type NodeConfig struct {
+	// Run commands through "nvm", "fnm" or "volta" with the version the project
+	// pins, "auto" (the installed one that fits the pin) or "off"
+	VersionManager string `yaml:"versionManager"`
+}
+
+// OpenConfig holds the programs the project is opened in outside the terminal
+type OpenConfig struct {
+	Editor      string `yaml:"editor"`      // GUI editor command, e.g. "code" or "idea" ("" = the first one found on PATH)
+	FileManager string `yaml:"fileManager"` // File manager command ("" = the system's: open, xdg-open or explorer)
+}
+
+// WatchConfig holds settings for reloading the panels when project files change
+type WatchConfig struct {
+	Enabled bool `yaml:"enabled"` // Poll the schema, migrations, .env files and prisma.config.ts
+	// Changes are merged into one refresh once none have been seen for this
+	// long, so a generator or git checkout writing many files refreshes once
+	DebounceMillis int `yaml:"debounceMillis"`
+}
+
 // Default returns the default configuration
 func Default() *Config {
 	return &Config{
@@ -32,6 +103,37 @@ func Default() *Config {
 			MaxDepth:    10,
 			ExcludeDirs: []string{}, // Additional excludes (defaults are in prisma.DefaultExcludeDirs)
 		},
+		Studio: StudioConfig{
+			Port: 5555,
+		},
+		Audit: AuditConfig{
+			Enabled: true,
+			Format:  "text",
+		},
+		Stats: StatsConfig{
+			Enabled: true,
+		},
+		Display: DisplayConfig{
+			SQLHighlight:      "chroma",
+			HighlightMaxLines: 5000,
+			RevealURL:         true,
+			RevealSeconds:     10,
+			StaleWarnMinutes:  5,
+			StaleAlertMinutes: 15,
+		},
+		Output: OutputConfig{
+			MaxLines: 5000,
+		},
+		Deploy: DeployConfig{
+			CountdownSeconds: 10,
+		},
+		Node: NodeConfig{
+			VersionManager: "off",
+		},
+		Watch: WatchConfig{
+			Enabled:        true,
+			DebounceMillis: 1500,
+		},
 		Language: "auto",
 	}
 }
@@ -45,6 +147,48 @@ func ConfigDir() (string, error) {
 	return filepath.Join(home, ".config", ConfigDirName), nil
 }
 
+// AuditPath returns the configured audit file path, defaulting to the config directory
+func (c *Config) AuditPath() (string, error) {
+	if c.Audit.Path != "" {
+		return c.Audit.Path, nil
+	}
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "audit.log"), nil
+}
+
+// OutputLogPath returns the configured output log path, defaulting to the config directory
+func (c *Config) OutputLogPath() (string, error) {
+	if c.Output.LogPath != "" {
+		return c.Output.LogPath, nil
+	}
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "output.log"), nil
+}
+
+// DebugLogPath returns the path of the log written in debug mode
+func DebugLogPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "debug.log"), nil
+}
+
+// StatsPath returns the usage statistics file path
+func StatsPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "stats.json"), nil
+}
+
 // ConfigPath returns the full path to the config file
 func ConfigPath() (string, error) {
 	dir, err := ConfigDir()
@@ -130,7 +274,51 @@ scan:
     # - /full/path/to/exclude
     # - dirname-to-exclude
 
-# Language setting ("auto" for system detection, or a language code like "en", "de")
+studio:
+  # Port Prisma Studio listens on
+  port: 5555
+
+audit:
+  # Record every executed prisma command (time, user, target DB, exit code)
+  enabled: true
+  # Audit file (empty = audit.log next to this config file)
+  path: ""
+  # "text" (tab-separated) or "jsonl" (one JSON object per line)
+  format: text
+
+stats:
+  # Keep local usage statistics (stats.json next to this config file, never uploaded)
+  enabled: true
+
+display:
+  # SQL highlighting in the Details panel: "chroma" (full syntax highlighting),
+  # "keywords" (simple keyword coloring) or "off" (plain text). Toggle with h.
+  sqlHighlight: chroma
+  # SQL longer than this many lines only gets keyword coloring (0 = no limit)
+  highlightMaxLines: 5000
+  # Allow revealing the database password in the Workspace panel with m
+  # (set to false when sharing your screen)
+  revealURL: true
+  # Mask the password again after this many seconds (0 = only when toggled back)
+  revealSeconds: 10
+  # Panel footers say when the data was last refreshed; they turn amber and then
+  # red once it is this many minutes old (0 = never)
+  staleWarnMinutes: 5
+  staleAlertMinutes: 15
+
+output:
+  # Lines kept in the Output panel; older lines are dropped (0 = no limit)
+  maxLines: 5000
+  # The whole session's output is written here (empty = output.log next to this config file)
+  logPath: ""
+
+node:
+  # Run prisma commands and scripts through your Node.js version manager, with
+  # the version pinned in .nvmrc, .node-version or package.json (volta):
+  # "off", "auto" (the installed manager that fits the pin), "nvm", "fnm" or "volta"
+  versionManager: off
+
+# Language setting ("auto" for system detection, or a language code like "en", "de", "ko")
 language: auto
 `
 		return os.WriteFile(path, []byte(defaultConfig), 0644)
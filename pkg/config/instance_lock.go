@@ -0,0 +1,143 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"syscall"
+	"time"
+)
+
+// LocksDirName is the directory in the config directory holding a lock file
+// for each project a lazyprisma instance has open
+const LocksDirName = "locks"
+
+// InstanceInfo describes the lazyprisma instance holding a project's lock
+type InstanceInfo struct {
+	PID       int       `json:"pid"`
+	Host      string    `json:"host"`
+	StartedAt time.Time `json:"startedAt"`
+}
+
+// InstanceLock is held by the instance that has a project open, so a second
+// instance can warn before both run commands or write state files
+type InstanceLock struct {
+	path string
+}
+
+// InstanceLockPath returns the lock file of the project in projectDir
+func InstanceLockPath(projectDir string) (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	if abs, err := filepath.Abs(projectDir); err == nil {
+		projectDir = abs
+	}
+	sum := sha256.Sum256([]byte(projectDir))
+	return filepath.Join(dir, LocksDirName, hex.EncodeToString(sum[:8])+".lock"), nil
+}
+
+// AcquireInstanceLock takes the lock of the project in projectDir. If another
+// running instance holds it, the lock is not taken and that instance is
+// returned instead. A lock left behind by an instance that is no longer
+// running is replaced.
+func AcquireInstanceLock(projectDir string) (*InstanceLock, *InstanceInfo, error) {
+	path, err := InstanceLockPath(projectDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, nil, err
+	}
+
+	host, _ := os.Hostname()
+	data, err := json.Marshal(InstanceInfo{PID: os.Getpid(), Host: host, StartedAt: time.Now()})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The lock is written to a temporary file and linked into place, so
+	// another instance never reads it before its content is written. Linking
+	// fails if the lock exists, which decides which of two instances starting
+	// at the same time gets it.
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		err := os.Link(tmp.Name(), path)
+		if err == nil {
+			return &InstanceLock{path: path}, nil, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, nil, err
+		}
+
+		if holder := readInstanceLock(path); holder != nil && holder.isRunning(host) {
+			return nil, holder, nil
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, nil, err
+		}
+	}
+	return nil, nil, errors.New("the instance lock was taken while it was being replaced")
+}
+
+// Release removes the lock file
+func (l *InstanceLock) Release() {
+	if l != nil {
+		os.Remove(l.path)
+	}
+}
+
+// readInstanceLock returns the instance recorded in a lock file, or nil if it
+// can't be read
+func readInstanceLock(path string) *InstanceInfo {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var info InstanceInfo
+	if err := json.Unmarshal(data, &info); err != nil || info.PID <= 0 {
+		return nil
+	}
+	return &info
+}
+
+// isRunning reports whether the instance is still running. An instance on
+// another host (a project on a shared drive) can't be checked and is assumed
+// to be running.
+func (i *InstanceInfo) isRunning(host string) bool {
+	if i.Host != host {
+		return true
+	}
+	if i.PID == os.Getpid() {
+		return false
+	}
+
+	process, err := os.FindProcess(i.PID)
+	if err != nil {
+		return false
+	}
+	// On Windows, FindProcess only succeeds for a running process
+	if runtime.GOOS == "windows" {
+		process.Release()
+		return true
+	}
+	err = process.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
@@ -0,0 +1,171 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectConfigFile is the per-project configuration file in the project root
+const ProjectConfigFile = ".lazyprisma.yaml"
+
+// ProjectConfig holds settings that belong to a single project and are
+// usually committed alongside it
+type ProjectConfig struct {
+	Environments    []EnvironmentConfig `yaml:"environments"`
+	DisabledActions []string            `yaml:"disabledActions"` // Action names that are not allowed in this project
+	Scripts         ScriptsConfig       `yaml:"scripts"`
+	Webhooks        []WebhookConfig     `yaml:"webhooks"`
+	PlanetScale     PlanetScaleConfig   `yaml:"planetscale"`
+}
+
+// PlanetScaleConfig names the PlanetScale database whose branches are listed
+// with the pscale CLI
+type PlanetScaleConfig struct {
+	Database string `yaml:"database"` // Database name (default: the one in the database URL)
+	Org      string `yaml:"org"`      // Organization (default: the one pscale is switched to)
+}
+
+// ScriptsConfig holds settings for one-off maintenance scripts
+type ScriptsConfig struct {
+	Dir string `yaml:"dir"` // Directory of .sql and node scripts, relative to the project (default: prisma/scripts)
+}
+
+// DefaultScriptsDir is the scripts directory used when the project config doesn't set one
+const DefaultScriptsDir = "prisma/scripts"
+
+// ResolveDir returns the absolute scripts directory for projectDir
+func (s ScriptsConfig) ResolveDir(projectDir string) string {
+	dir := s.Dir
+	if dir == "" {
+		dir = DefaultScriptsDir
+	}
+	if filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(projectDir, dir)
+}
+
+// Action names usable in disabledActions
+const (
+	ActionMigrateDev       = "migrate-dev"
+	ActionMigrateDeploy    = "migrate-deploy" // Also covers deploying to a named environment
+	ActionMigrateResolve   = "migrate-resolve"
+	ActionGenerate         = "generate"
+	ActionStudio           = "studio"
+	ActionDeleteMigration  = "delete-migration" // Also covers fixing the migrations a git merge duplicated
+	ActionSaveFormattedSQL = "save-formatted-sql"
+	ActionRunScript        = "run-script"
+	ActionDBPull           = "db-pull"            // Applying an introspected schema to schema.prisma
+	ActionConsoleWrite     = "console-write"      // Writes from the SQL console, including committing them
+	ActionDeleteHistoryRow = "delete-history-row" // Deleting a migration's _prisma_migrations row
+	ActionBulkResolve      = "bulk-resolve"       // Marking every pending migration, or a receipt's, as applied
+	ActionCreateExtension  = "create-extension"   // Creating missing extensions right away from the deploy menu
+)
+
+// IsActionDisabled reports whether the project config disables the given action
+func (p *ProjectConfig) IsActionDisabled(action string) bool {
+	for _, disabled := range p.DisabledActions {
+		if disabled == action {
+			return true
+		}
+	}
+	return false
+}
+
+// EnvironmentConfig is a named database (e.g. dev, staging, prod)
+type EnvironmentConfig struct {
+	Name      string `yaml:"name"`
+	URL       string `yaml:"url"`       // Literal database URL
+	URLEnv    string `yaml:"urlEnv"`    // Environment variable holding the URL (preferred over url)
+	Protected bool   `yaml:"protected"` // Require confirmation for destructive actions
+	Branch    string `yaml:"branch"`    // PlanetScale branch the database is on
+
+	// The environment's unpooled URL, for a datasource with a directUrl
+	// (default: the environment's URL)
+	DirectURL    string `yaml:"directUrl"`
+	DirectURLEnv string `yaml:"directUrlEnv"`
+}
+
+// ResolveURL returns the environment's database URL, looking up URLEnv with the given function
+func (e EnvironmentConfig) ResolveURL(lookupEnv func(name string) string) string {
+	if e.URLEnv != "" {
+		if url := lookupEnv(e.URLEnv); url != "" {
+			return url
+		}
+	}
+	return e.URL
+}
+
+// ResolveDirectURL returns the URL migrations connect to the environment
+// through when the datasource has a directUrl: DirectURLEnv or DirectURL if
+// set, otherwise the environment's URL
+func (e EnvironmentConfig) ResolveDirectURL(lookupEnv func(name string) string) string {
+	if e.DirectURLEnv != "" {
+		if url := lookupEnv(e.DirectURLEnv); url != "" {
+			return url
+		}
+	}
+	if e.DirectURL != "" {
+		return e.DirectURL
+	}
+	return e.ResolveURL(lookupEnv)
+}
+
+// WebhookConfig is a URL that is posted the result of a deploy, e.g. a Slack
+// incoming webhook
+type WebhookConfig struct {
+	URL      string   `yaml:"url"`      // Literal webhook URL
+	URLEnv   string   `yaml:"urlEnv"`   // Environment variable holding the URL (preferred over url)
+	Events   []string `yaml:"events"`   // Events to post (default: all)
+	Template string   `yaml:"template"` // Go template of the request body (default: Slack-style {"text": ...})
+}
+
+// ResolveURL returns the webhook URL, looking up URLEnv with the given function
+func (w WebhookConfig) ResolveURL(lookupEnv func(name string) string) string {
+	if w.URLEnv != "" {
+		if url := lookupEnv(w.URLEnv); url != "" {
+			return url
+		}
+	}
+	return w.URL
+}
+
+// Notifies reports whether the webhook is posted for event
+func (w WebhookConfig) Notifies(event string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// ProjectConfigPath returns the path of the project config file in projectDir
+func ProjectConfigPath(projectDir string) string {
+	return filepath.Join(projectDir, ProjectConfigFile)
+}
+
+// LoadProject loads the project config from projectDir.
+// Returns an empty config if the file doesn't exist.
+func LoadProject(projectDir string) (*ProjectConfig, error) {
+	cfg := &ProjectConfig{}
+
+	data, err := os.ReadFile(ProjectConfigPath(projectDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
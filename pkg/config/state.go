@@ -0,0 +1,127 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// StateFile keeps per-project preferences that are local to this machine
+const StateFile = "state.json"
+
+// State holds preferences remembered between sessions, keyed by project directory
+type State struct {
+	Projects map[string]*ProjectState `json:"projects"`
+}
+
+// ProjectState holds one project's remembered preferences. Unlike
+// .lazyprisma.yaml, it is never shared with the rest of the team.
+type ProjectState struct {
+	SkippedGenerators   []string `json:"skippedGenerators,omitempty"`   // Generators left out of prisma generate
+	MigrateSkipGenerate bool     `json:"migrateSkipGenerate,omitempty"` // Create migrations with --skip-generate
+	MigrateSkipSeed     bool     `json:"migrateSkipSeed,omitempty"`     // Create migrations with --skip-seed
+	ExportTransaction   bool     `json:"exportTransaction,omitempty"`   // Wrap exported pending SQL in BEGIN/COMMIT
+}
+
+// IsGeneratorSkipped reports whether generate should leave out the named generator
+func (p *ProjectState) IsGeneratorSkipped(name string) bool {
+	for _, skipped := range p.SkippedGenerators {
+		if skipped == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SetGeneratorSkipped adds the generator to or removes it from the skipped list
+func (p *ProjectState) SetGeneratorSkipped(name string, skipped bool) {
+	kept := p.SkippedGenerators[:0]
+	for _, existing := range p.SkippedGenerators {
+		if existing != name {
+			kept = append(kept, existing)
+		}
+	}
+	if skipped {
+		kept = append(kept, name)
+	}
+	p.SkippedGenerators = kept
+}
+
+// StatePath returns the path of the state file in the config directory
+func StatePath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, StateFile), nil
+}
+
+// LoadState reads the state file. Returns an empty state if it doesn't exist.
+func LoadState() (*State, error) {
+	state := &State{Projects: map[string]*ProjectState{}}
+
+	path, err := StatePath()
+	if err != nil {
+		return state, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return state, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	if state.Projects == nil {
+		state.Projects = map[string]*ProjectState{}
+	}
+	return state, nil
+}
+
+// stateReadOnly is set while another instance has the project open, which
+// keeps the two from overwriting each other's state file
+var stateReadOnly bool
+
+// SetStateReadOnly makes SaveState leave the state file alone (changes are
+// kept for the session only)
+func SetStateReadOnly(readOnly bool) {
+	stateReadOnly = readOnly
+}
+
+// SaveState writes the state file, creating the config directory if needed.
+// It does nothing after SetStateReadOnly(true).
+func SaveState(state *State) error {
+	if stateReadOnly {
+		return nil
+	}
+
+	path, err := StatePath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Project returns the state of the project in dir, adding an empty one if there is none
+func (s *State) Project(dir string) *ProjectState {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	if s.Projects[dir] == nil {
+		s.Projects[dir] = &ProjectState{}
+	}
+	return s.Projects[dir]
+}
@@ -108,6 +108,11 @@ func convertPostgresURL(prismaURL string) (string, error) {
 	// Remove unsupported "schema" parameter
 	query.Del("schema")
 
+	// Remove Prisma's "sslaccept": lib/pq would send it to the server as a
+	// setting. Its sslmode=require skips verifying the certificate anyway,
+	// unless a root certificate is installed in ~/.postgresql.
+	query.Del("sslaccept")
+
 	// Handle sslmode
 	sslmode := query.Get("sslmode")
 	if sslmode == "prefer" || sslmode == "" {
@@ -174,8 +179,8 @@ func convertMySQLURL(prismaURL string) (string, error) {
 	}
 
 	// Add query params
-	if u.RawQuery != "" {
-		dsn += "?" + u.RawQuery
+	if query := convertMySQLParams(u.Query()); len(query) > 0 {
+		dsn += "?" + query.Encode()
 	} else {
 		// Default params for proper datetime parsing
 		dsn += "?parseTime=true"
@@ -184,6 +189,29 @@ func convertMySQLURL(prismaURL string) (string, error) {
 	return dsn, nil
 }
 
+// prismaOnlyMySQLParams are URL parameters only Prisma's engines understand;
+// go-sql-driver would send them to the server as session variables
+var prismaOnlyMySQLParams = []string{
+	"connection_limit", "pool_timeout", "connect_timeout", "socket_timeout",
+	"sslcert", "sslidentity", "sslpassword", "socket", "statement_cache_size",
+}
+
+// convertMySQLParams turns Prisma's sslaccept into go-sql-driver's tls, as
+// PlanetScale URLs require, and drops the other Prisma-only parameters
+func convertMySQLParams(query url.Values) url.Values {
+	switch query.Get("sslaccept") {
+	case "strict":
+		query.Set("tls", "true")
+	case "accept_invalid_certs":
+		query.Set("tls", "skip-verify")
+	}
+	query.Del("sslaccept")
+	for _, name := range prismaOnlyMySQLParams {
+		query.Del(name)
+	}
+	return query
+}
+
 // Connect connects to the database
 func (c *Client) Connect(cfg *Config) error {
 	c.config = cfg
@@ -0,0 +1,295 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrExplainUnsupported is returned for providers whose plans can't be read
+var ErrExplainUnsupported = errors.New("query plans are supported on PostgreSQL and MySQL")
+
+// ScanKind says how a plan node reads its table
+type ScanKind int
+
+const (
+	ScanNone  ScanKind = iota // Not a table scan (join, sort, ...)
+	ScanFull                  // Reads every row of the table
+	ScanIndex                 // Reads the table through an index
+)
+
+// PlanNode is one step of a query plan
+type PlanNode struct {
+	Operation string   // e.g. "Index Scan using Order_status_idx on Order"
+	Table     string   // Table the node reads, if any
+	Index     string   // Index the node uses, if any
+	Scan      ScanKind // How it reads Table
+	Estimate  string   // Planner estimate, e.g. "cost=0.29..8.31 rows=12"
+	Actual    string   // Measured by ANALYZE, e.g. "time=0.031 ms rows=12 loops=1"
+	Details   []string // Conditions and other properties, e.g. "Filter: (id > 5)"
+	Children  []*PlanNode
+}
+
+// QueryPlan is the plan the database chose for a query
+type QueryPlan struct {
+	Root          *PlanNode
+	Analyzed      bool   // The query was run (EXPLAIN ANALYZE)
+	PlanningTime  string // e.g. "0.112 ms" ("" if not reported)
+	ExecutionTime string // ANALYZE only
+}
+
+// Walk calls fn for every node of the plan, parents first
+func (p *QueryPlan) Walk(fn func(node *PlanNode, depth int)) {
+	var walk func(node *PlanNode, depth int)
+	walk = func(node *PlanNode, depth int) {
+		fn(node, depth)
+		for _, child := range node.Children {
+			walk(child, depth+1)
+		}
+	}
+	if p.Root != nil {
+		walk(p.Root, 0)
+	}
+}
+
+// Indexes returns the indexes the plan uses, in plan order
+func (p *QueryPlan) Indexes() []string {
+	var names []string
+	seen := make(map[string]bool)
+	p.Walk(func(node *PlanNode, _ int) {
+		if node.Index != "" && !seen[node.Index] {
+			seen[node.Index] = true
+			names = append(names, node.Index)
+		}
+	})
+	return names
+}
+
+// FullScans returns the tables the plan reads in full, in plan order
+func (p *QueryPlan) FullScans() []string {
+	var tables []string
+	seen := make(map[string]bool)
+	p.Walk(func(node *PlanNode, _ int) {
+		if node.Scan == ScanFull && !seen[node.Table] {
+			seen[node.Table] = true
+			tables = append(tables, node.Table)
+		}
+	})
+	return tables
+}
+
+// explain asks the database for the plan of query in tx; with analyze the
+// query is run to measure it
+func explain(tx *sql.Tx, provider, query string, analyze bool) (*QueryPlan, error) {
+	var prefix string
+	switch provider {
+	case "postgresql", "postgres":
+		prefix = "EXPLAIN (FORMAT JSON) "
+		if analyze {
+			prefix = "EXPLAIN (ANALYZE, FORMAT JSON) "
+		}
+	case "mysql":
+		prefix = "EXPLAIN FORMAT=TREE "
+		if analyze {
+			prefix = "EXPLAIN ANALYZE "
+		}
+	default:
+		return nil, ErrExplainUnsupported
+	}
+
+	var output string
+	if err := tx.QueryRow(prefix + strings.TrimRight(strings.TrimSpace(query), ";")).Scan(&output); err != nil {
+		return nil, err
+	}
+
+	var plan *QueryPlan
+	var err error
+	if provider == "mysql" {
+		plan, err = ParseMySQLPlan(output)
+	} else {
+		plan, err = ParsePostgresPlan(output)
+	}
+	if err != nil {
+		return nil, err
+	}
+	plan.Analyzed = analyze
+	return plan, nil
+}
+
+// pgPlanNode is a node of PostgreSQL's EXPLAIN (FORMAT JSON) output
+type pgPlanNode struct {
+	NodeType        string       `json:"Node Type"`
+	JoinType        string       `json:"Join Type"`
+	RelationName    string       `json:"Relation Name"`
+	Alias           string       `json:"Alias"`
+	IndexName       string       `json:"Index Name"`
+	StartupCost     float64      `json:"Startup Cost"`
+	TotalCost       float64      `json:"Total Cost"`
+	PlanRows        float64      `json:"Plan Rows"`
+	ActualTotalTime *float64     `json:"Actual Total Time"`
+	ActualRows      *float64     `json:"Actual Rows"`
+	ActualLoops     *float64     `json:"Actual Loops"`
+	IndexCond       string       `json:"Index Cond"`
+	RecheckCond     string       `json:"Recheck Cond"`
+	HashCond        string       `json:"Hash Cond"`
+	MergeCond       string       `json:"Merge Cond"`
+	JoinFilter      string       `json:"Join Filter"`
+	Filter          string       `json:"Filter"`
+	RowsRemoved     *float64     `json:"Rows Removed by Filter"`
+	SortKey         []string     `json:"Sort Key"`
+	Plans           []pgPlanNode `json:"Plans"`
+}
+
+// ParsePostgresPlan reads the output of PostgreSQL's EXPLAIN (FORMAT JSON)
+func ParsePostgresPlan(output string) (*QueryPlan, error) {
+	var explained []struct {
+		Plan          pgPlanNode `json:"Plan"`
+		PlanningTime  *float64   `json:"Planning Time"`
+		ExecutionTime *float64   `json:"Execution Time"`
+	}
+	if err := json.Unmarshal([]byte(output), &explained); err != nil {
+		return nil, err
+	}
+	if len(explained) == 0 {
+		return nil, errors.New("EXPLAIN returned no plan")
+	}
+
+	plan := &QueryPlan{Root: explained[0].Plan.node()}
+	if t := explained[0].PlanningTime; t != nil {
+		plan.PlanningTime = fmt.Sprintf("%.3f ms", *t)
+	}
+	if t := explained[0].ExecutionTime; t != nil {
+		plan.ExecutionTime = fmt.Sprintf("%.3f ms", *t)
+	}
+	return plan, nil
+}
+
+// node converts a PostgreSQL plan node, labelled as EXPLAIN's text format does
+func (n pgPlanNode) node() *PlanNode {
+	node := &PlanNode{
+		Operation: n.NodeType,
+		Table:     n.RelationName,
+		Index:     n.IndexName,
+		Estimate:  fmt.Sprintf("cost=%.2f..%.2f rows=%.0f", n.StartupCost, n.TotalCost, n.PlanRows),
+	}
+
+	if n.JoinType != "" && n.JoinType != "Inner" && strings.HasSuffix(n.NodeType, " Join") {
+		node.Operation = strings.TrimSuffix(n.NodeType, "Join") + n.JoinType + " Join"
+	}
+	if n.IndexName != "" {
+		node.Operation += " using " + n.IndexName
+	}
+	if n.RelationName != "" {
+		node.Operation += " on " + n.RelationName
+		if n.Alias != "" && n.Alias != n.RelationName {
+			node.Operation += " " + n.Alias
+		}
+	}
+
+	switch n.NodeType {
+	case "Seq Scan":
+		node.Scan = ScanFull
+	case "Index Scan", "Index Only Scan", "Bitmap Heap Scan", "Bitmap Index Scan":
+		node.Scan = ScanIndex
+	}
+
+	if n.ActualTotalTime != nil && n.ActualRows != nil && n.ActualLoops != nil {
+		node.Actual = fmt.Sprintf("time=%.3f ms rows=%.0f loops=%.0f", *n.ActualTotalTime, *n.ActualRows, *n.ActualLoops)
+	}
+
+	for _, detail := range []struct{ label, value string }{
+		{"Index Cond", n.IndexCond},
+		{"Recheck Cond", n.RecheckCond},
+		{"Hash Cond", n.HashCond},
+		{"Merge Cond", n.MergeCond},
+		{"Join Filter", n.JoinFilter},
+		{"Filter", n.Filter},
+		{"Sort Key", strings.Join(n.SortKey, ", ")},
+	} {
+		if detail.value != "" {
+			node.Details = append(node.Details, detail.label+": "+detail.value)
+		}
+	}
+	if n.RowsRemoved != nil {
+		node.Details = append(node.Details, fmt.Sprintf("Rows Removed by Filter: %.0f", *n.RowsRemoved))
+	}
+
+	for _, child := range n.Plans {
+		node.Children = append(node.Children, child.node())
+	}
+	return node
+}
+
+var (
+	// mysqlEstimateRegex and mysqlActualRegex match the trailing figures of a
+	// MySQL tree plan line: (cost=1.25 rows=10) (actual time=0.02..0.03 rows=10 loops=1)
+	mysqlEstimateRegex = regexp.MustCompile(`\s*\((cost=[^)]*)\)`)
+	mysqlActualRegex   = regexp.MustCompile(`\s*\(actual ([^)]*)\)`)
+	mysqlTableRegex    = regexp.MustCompile(`\bon (\S+)`)
+	mysqlIndexRegex    = regexp.MustCompile(`\busing (\S+)`)
+)
+
+// ParseMySQLPlan reads the output of MySQL's EXPLAIN FORMAT=TREE and EXPLAIN
+// ANALYZE: one "-> operation" line per node, indented 4 spaces per level
+func ParseMySQLPlan(output string) (*QueryPlan, error) {
+	plan := &QueryPlan{}
+	var stack []*PlanNode
+
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if !strings.HasPrefix(trimmed, "-> ") {
+			continue
+		}
+		depth := (len(line) - len(trimmed)) / 4
+		text := strings.TrimPrefix(trimmed, "-> ")
+
+		node := &PlanNode{}
+		if m := mysqlActualRegex.FindStringSubmatch(text); m != nil {
+			node.Actual = m[1]
+			text = strings.Replace(text, m[0], "", 1)
+		}
+		if m := mysqlEstimateRegex.FindStringSubmatch(text); m != nil {
+			node.Estimate = m[1]
+			text = strings.Replace(text, m[0], "", 1)
+		}
+		node.Operation = strings.TrimSpace(text)
+
+		switch {
+		case strings.HasPrefix(node.Operation, "Table scan on "):
+			node.Scan = ScanFull
+		case strings.Contains(node.Operation, "index ") || strings.Contains(node.Operation, "Index "):
+			node.Scan = ScanIndex
+		}
+		if node.Scan != ScanNone {
+			if m := mysqlTableRegex.FindStringSubmatch(node.Operation); m != nil {
+				node.Table = m[1]
+			}
+			if m := mysqlIndexRegex.FindStringSubmatch(node.Operation); m != nil {
+				node.Index = m[1]
+			}
+		}
+
+		if depth > len(stack) {
+			depth = len(stack)
+		}
+		stack = stack[:depth]
+		if depth == 0 {
+			if plan.Root != nil {
+				return nil, errors.New("EXPLAIN returned more than one plan")
+			}
+			plan.Root = node
+		} else {
+			parent := stack[depth-1]
+			parent.Children = append(parent.Children, node)
+		}
+		stack = append(stack, node)
+	}
+
+	if plan.Root == nil {
+		return nil, errors.New("EXPLAIN returned no plan")
+	}
+	return plan, nil
+}
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TablePeek is the first rows of a table and how many rows it has
+type TablePeek struct {
+	Columns []string
+	Rows    [][]string // Values as text; nil values are "NULL"
+	Total   int64
+}
+
+// PeekTable connects to the database at dbURL and reads the first limit rows
+// of table and its row count
+func PeekTable(provider, dbURL, table string, limit int) (*TablePeek, error) {
+	client, err := NewClientFromDSN(provider, dbURL)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	name := qualifiedTableName(provider, dbURL, table)
+
+	peek := &TablePeek{}
+	if err := client.QueryRow("SELECT COUNT(*) FROM " + name).Scan(&peek.Total); err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("SELECT * FROM %s LIMIT %d", name, limit)
+	if provider == "sqlserver" {
+		query = fmt.Sprintf("SELECT TOP %d * FROM %s", limit, name)
+	}
+	rows, err := client.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	peek.Columns, peek.Rows, _, err = scanRows(rows, limit)
+	return peek, err
+}
+
+// qualifiedTableName quotes table for the provider, prefixed with the schema
+// a PostgreSQL URL selects with ?schema= (the connection itself ignores it)
+func qualifiedTableName(provider, dbURL, table string) string {
+	switch provider {
+	case "mysql":
+		return "`" + strings.ReplaceAll(table, "`", "``") + "`"
+	case "sqlserver":
+		return "[" + strings.ReplaceAll(table, "]", "]]") + "]"
+	}
+
+	quote := func(name string) string { return `"` + strings.ReplaceAll(name, `"`, `""`) + `"` }
+	if provider == "postgresql" || provider == "postgres" {
+		if u, err := url.Parse(dbURL); err == nil {
+			if schema := u.Query().Get("schema"); schema != "" {
+				return quote(schema) + "." + quote(table)
+			}
+		}
+	}
+	return quote(table)
+}
+
+// scanRows reads up to limit rows as text; more is true if there were more
+func scanRows(rows *sql.Rows, limit int) (columns []string, values [][]string, more bool, err error) {
+	if columns, err = rows.Columns(); err != nil {
+		return nil, nil, false, err
+	}
+	for rows.Next() {
+		if len(values) == limit {
+			more = true
+			break
+		}
+		scanned := make([]any, len(columns))
+		pointers := make([]any, len(scanned))
+		for i := range scanned {
+			pointers[i] = &scanned[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, nil, false, err
+		}
+
+		row := make([]string, len(scanned))
+		for i, value := range scanned {
+			row[i] = formatValue(value)
+		}
+		values = append(values, row)
+	}
+	return columns, values, more, rows.Err()
+}
+
+// formatValue returns a scanned column value as text
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "NULL"
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05")
+	default:
+		return fmt.Sprint(v)
+	}
+}
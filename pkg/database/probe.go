@@ -0,0 +1,67 @@
+package database
+
+import "time"
+
+// ProbeResult is what a connection test measured
+type ProbeResult struct {
+	Latencies     []time.Duration // Round trip of each ping
+	ServerVersion string          // As the server reports it ("" if it couldn't be queried)
+}
+
+// Probe connects to the database at url, pings it attempts times and asks
+// for its version. The connection is opened before the first ping, so the
+// latencies are round trips only.
+func Probe(provider, url string, attempts int) (*ProbeResult, error) {
+	client, err := NewClientFromDSN(provider, url)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	result := &ProbeResult{}
+	for i := 0; i < attempts; i++ {
+		start := time.Now()
+		if err := client.Ping(); err != nil {
+			return nil, err
+		}
+		result.Latencies = append(result.Latencies, time.Since(start))
+	}
+
+	if version, err := client.ServerVersion(); err == nil {
+		result.ServerVersion = version
+	}
+
+	return result, nil
+}
+
+// Min returns the fastest round trip
+func (r *ProbeResult) Min() time.Duration {
+	var fastest time.Duration
+	for i, d := range r.Latencies {
+		if i == 0 || d < fastest {
+			fastest = d
+		}
+	}
+	return fastest
+}
+
+// Max returns the slowest round trip
+func (r *ProbeResult) Max() time.Duration {
+	var slowest time.Duration
+	for _, d := range r.Latencies {
+		slowest = max(slowest, d)
+	}
+	return slowest
+}
+
+// Avg returns the mean round trip
+func (r *ProbeResult) Avg() time.Duration {
+	if len(r.Latencies) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, d := range r.Latencies {
+		total += d
+	}
+	return total / time.Duration(len(r.Latencies))
+}
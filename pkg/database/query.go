@@ -0,0 +1,255 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// QueryResult is what a statement run from the SQL console returned
+type QueryResult struct {
+	Columns      []string
+	Rows         [][]string // Values as text; nil values are "NULL"
+	More         bool       // The statement returned more rows than were read
+	RowsAffected int64      // For statements that return no rows
+}
+
+// rowKeywords are the first keywords of statements that return rows
+var rowKeywords = []string{"SELECT", "WITH", "SHOW", "VALUES", "TABLE", "EXPLAIN", "DESCRIBE", "DESC", "PRAGMA"}
+
+// ReturnsRows reports whether query is a statement that returns rows, judged
+// by its first keyword
+func ReturnsRows(query string) bool {
+	fields := strings.Fields(strings.TrimLeft(query, "( \t\r\n"))
+	if len(fields) == 0 {
+		return false
+	}
+	first := strings.ToUpper(fields[0])
+	for _, keyword := range rowKeywords {
+		if first == keyword {
+			return true
+		}
+	}
+	return false
+}
+
+// writeKeywords are the data-modifying statements a WITH query can contain,
+// e.g. WITH gone AS (DELETE FROM ... RETURNING *) SELECT * FROM gone
+var writeKeywords = []string{"INSERT", "UPDATE", "DELETE", "MERGE"}
+
+// IsWrite reports whether query may change data: a statement that returns no
+// rows, or a WITH query containing a data-modifying statement
+func IsWrite(query string) bool {
+	if !ReturnsRows(query) {
+		return true
+	}
+	words := sqlWords(query)
+	if len(words) == 0 || words[0] != "WITH" {
+		return false
+	}
+	for i, word := range words {
+		// SELECT ... FOR UPDATE only locks rows
+		if slices.Contains(writeKeywords, word) && !(word == "UPDATE" && i > 0 && words[i-1] == "FOR") {
+			return true
+		}
+	}
+	return false
+}
+
+// sqlWords returns the keywords and unquoted identifiers of query in upper
+// case, leaving out string literals, quoted identifiers and comments
+func sqlWords(query string) []string {
+	var words []string
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			end := strings.IndexByte(query[i+1:], c)
+			if end < 0 {
+				return words
+			}
+			i += end + 2
+		case strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				return words
+			}
+			i += end + 1
+		case strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return words
+			}
+			i += end + 4
+		case isWordByte(c):
+			start := i
+			for i < len(query) && isWordByte(query[i]) {
+				i++
+			}
+			words = append(words, strings.ToUpper(query[start:i]))
+		default:
+			i++
+		}
+	}
+	return words
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// ErrImplicitCommit is returned for a statement MySQL would commit at once,
+// together with the writes of the console's transaction
+var ErrImplicitCommit = errors.New("MySQL commits this statement immediately, with the open transaction")
+
+// implicitCommitKeywords are the first keywords of the statements MySQL
+// commits implicitly (DDL, and those that manage transactions or locks)
+var implicitCommitKeywords = []string{"CREATE", "ALTER", "DROP", "TRUNCATE", "RENAME", "GRANT", "REVOKE", "LOCK", "UNLOCK", "BEGIN", "START", "COMMIT"}
+
+// CommitsImplicitly reports whether the provider's database commits query as
+// soon as it runs, so it can't be part of a transaction
+func CommitsImplicitly(provider, query string) bool {
+	if provider != "mysql" {
+		return false
+	}
+	words := sqlWords(query)
+	return len(words) > 0 && slices.Contains(implicitCommitKeywords, words[0])
+}
+
+// Session is the transaction the SQL console runs statements in. Nothing it
+// changes is committed until Commit is called.
+type Session interface {
+	// Run runs a single statement and reads up to limit of the rows it returns
+	Run(query string, limit int) (*QueryResult, error)
+	// Explain returns the plan of query; with analyze the query is run to
+	// measure it and its changes are undone
+	Explain(query string, analyze bool) (*QueryPlan, error)
+	// Commit commits the transaction and closes the session
+	Commit() error
+	// Rollback rolls the transaction back and closes the session
+	Rollback() error
+}
+
+// txSession is a Session on a database connection
+type txSession struct {
+	client   *Client
+	tx       *sql.Tx
+	provider string
+}
+
+// OpenSession connects to the database at dbURL and starts the transaction
+// of a console session
+func OpenSession(provider, dbURL string) (Session, error) {
+	client, err := NewClientFromDSN(provider, dbURL)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := beginTx(client, provider, dbURL)
+	if err != nil {
+		client.Close()
+		return nil, err
+	}
+	return &txSession{client: client, tx: tx, provider: provider}, nil
+}
+
+// Run runs query under a savepoint, so a failing statement leaves the
+// transaction usable (PostgreSQL aborts it otherwise) and keeps earlier changes.
+// Statements MySQL would commit immediately are refused.
+func (s *txSession) Run(query string, limit int) (*QueryResult, error) {
+	if CommitsImplicitly(s.provider, query) {
+		return nil, ErrImplicitCommit
+	}
+	var result *QueryResult
+	err := s.withSavepoint(false, func() error {
+		var err error
+		result, err = runStatement(s.tx, query, limit)
+		return err
+	})
+	return result, err
+}
+
+// Explain returns the plan of query. With analyze, the changes the query
+// makes while it is measured are rolled back to a savepoint.
+func (s *txSession) Explain(query string, analyze bool) (*QueryPlan, error) {
+	var plan *QueryPlan
+	err := s.withSavepoint(analyze, func() error {
+		var err error
+		plan, err = explain(s.tx, s.provider, query, analyze)
+		return err
+	})
+	return plan, err
+}
+
+// withSavepoint runs fn after a savepoint and rolls back to it if fn fails or
+// undo is set
+func (s *txSession) withSavepoint(undo bool, fn func() error) error {
+	if _, err := s.tx.Exec("SAVEPOINT lazyprisma_console"); err != nil {
+		return err
+	}
+	if err := fn(); err != nil {
+		s.tx.Exec("ROLLBACK TO SAVEPOINT lazyprisma_console")
+		return err
+	}
+	if undo {
+		_, err := s.tx.Exec("ROLLBACK TO SAVEPOINT lazyprisma_console")
+		return err
+	}
+	_, err := s.tx.Exec("RELEASE SAVEPOINT lazyprisma_console")
+	return err
+}
+
+// Commit commits the transaction and closes the connection
+func (s *txSession) Commit() error {
+	defer s.client.Close()
+	return s.tx.Commit()
+}
+
+// Rollback rolls the transaction back and closes the connection
+func (s *txSession) Rollback() error {
+	defer s.client.Close()
+	return s.tx.Rollback()
+}
+
+// beginTx starts a transaction; on PostgreSQL its search_path is the schema
+// the URL selects with ?schema=, as Prisma does
+func beginTx(client *Client, provider, dbURL string) (*sql.Tx, error) {
+	tx, err := client.DB().Begin()
+	if err != nil {
+		return nil, err
+	}
+	if provider == "postgresql" || provider == "postgres" {
+		if u, err := url.Parse(dbURL); err == nil {
+			if schema := u.Query().Get("schema"); schema != "" {
+				if _, err := tx.Exec(`SET LOCAL search_path TO "` + strings.ReplaceAll(schema, `"`, `""`) + `"`); err != nil {
+					tx.Rollback()
+					return nil, err
+				}
+			}
+		}
+	}
+	return tx, nil
+}
+
+// runStatement runs query in tx
+func runStatement(tx *sql.Tx, query string, limit int) (*QueryResult, error) {
+	result := &QueryResult{}
+	if !ReturnsRows(query) {
+		res, err := tx.Exec(query)
+		if err != nil {
+			return nil, err
+		}
+		result.RowsAffected, _ = res.RowsAffected()
+		return result, nil
+	}
+
+	rows, err := tx.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result.Columns, result.Rows, result.More, err = scanRows(rows, limit)
+	return result, err
+}
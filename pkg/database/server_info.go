@@ -0,0 +1,55 @@
+package database
+
+import "strings"
+
+// serverVersionQuery returns the query that reports the server version of a provider
+func serverVersionQuery(provider string) string {
+	switch provider {
+	case "sqlite":
+		return "SELECT sqlite_version()"
+	case "sqlserver":
+		return "SELECT @@VERSION"
+	default:
+		// PostgreSQL, CockroachDB and MySQL/MariaDB
+		return "SELECT version()"
+	}
+}
+
+// ServerVersion asks the database for its version, e.g. "PostgreSQL 16.2"
+func (c *Client) ServerVersion() (string, error) {
+	var version string
+	if err := c.QueryRow(serverVersionQuery(c.driverName)).Scan(&version); err != nil {
+		return "", err
+	}
+
+	// PostgreSQL's includes the compiler and platform after a comma
+	version, _, _ = strings.Cut(version, ",")
+	// PostgreSQL's also names the build platform: "PostgreSQL 16.2 on x86_64-pc-linux-gnu"
+	version, _, _ = strings.Cut(version, " on ")
+	return strings.TrimSpace(version), nil
+}
+
+// HasExtensions reports whether the provider has PostgreSQL extensions
+func HasExtensions(provider string) bool {
+	return provider == "postgresql" || provider == "postgres"
+}
+
+// Extensions returns the names of the extensions installed in a PostgreSQL
+// database, sorted
+func (c *Client) Extensions() ([]string, error) {
+	rows, err := c.Query("SELECT extname FROM pg_extension ORDER BY extname")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, rows.Err()
+}
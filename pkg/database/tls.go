@@ -0,0 +1,114 @@
+package database
+
+import "strings"
+
+// TLSProblem is why a connection failed during the TLS handshake, or because
+// one side wanted TLS and the other didn't
+type TLSProblem string
+
+const (
+	TLSOK          TLSProblem = ""            // Not a TLS error
+	TLSSelfSigned  TLSProblem = "self-signed" // The certificate isn't signed by a trusted authority
+	TLSRequired    TLSProblem = "required"    // The server only accepts encrypted connections
+	TLSUnsupported TLSProblem = "unsupported" // TLS was asked for, but the server doesn't speak it
+	TLSHostname    TLSProblem = "hostname"    // The certificate is for another host name
+	TLSExpired     TLSProblem = "expired"     // The certificate has expired or isn't valid yet
+)
+
+// tlsErrorPatterns are lowercased fragments of the errors lib/pq, the MySQL
+// driver and Prisma's engines (P1011) report, per problem. They are checked
+// in order: a handshake error often ends with a generic "tls:" part.
+var tlsErrorPatterns = []struct {
+	problem   TLSProblem
+	fragments []string
+}{
+	{TLSSelfSigned, []string{
+		"certificate signed by unknown authority", "self signed certificate", "self-signed certificate",
+		"unable to get local issuer certificate", "unable to verify the first certificate",
+		"certificate verify failed", "unknownissuer",
+	}},
+	{TLSHostname, []string{
+		"certificate is valid for", "certificate is not valid for", "legacy common name field",
+		"hostname mismatch", "does not match certificate", "certnotvalidforname",
+	}},
+	{TLSExpired, []string{"certificate has expired", "certificate is not yet valid", "certexpired"}},
+	{TLSUnsupported, []string{
+		"ssl is not enabled on the server", "server does not support ssl", "server does not support tls",
+		"server doesn't support tls",
+	}},
+	{TLSRequired, []string{
+		"no encryption", "ssl off", "ssl connection is required", "connection requires ssl",
+		"ssl/tls required", "ssl required", "insecure transport are prohibited",
+		"require_secure_transport",
+	}},
+}
+
+// ClassifyTLSError returns the TLS problem a connection error describes, or
+// TLSOK if it isn't about TLS
+func ClassifyTLSError(msg string) TLSProblem {
+	msg = strings.ToLower(msg)
+	for _, pattern := range tlsErrorPatterns {
+		for _, fragment := range pattern.fragments {
+			if strings.Contains(msg, fragment) {
+				return pattern.problem
+			}
+		}
+	}
+	return TLSOK
+}
+
+// TLSFixParams returns the connection URL parameters, as name/value pairs,
+// that make provider's connection work around problem, or nil if no
+// parameter can: a certificate for another host or an expired one has to be
+// fixed on the server, or connected to by the name it was issued for.
+func TLSFixParams(provider string, problem TLSProblem) [][2]string {
+	switch strings.ToLower(provider) {
+	case "postgresql", "postgres", "cockroachdb":
+		switch problem {
+		case TLSSelfSigned:
+			return [][2]string{{"sslmode", "require"}, {"sslaccept", "accept_invalid_certs"}}
+		case TLSRequired:
+			return [][2]string{{"sslmode", "require"}}
+		case TLSUnsupported:
+			return [][2]string{{"sslmode", "disable"}}
+		}
+	case "mysql":
+		switch problem {
+		case TLSSelfSigned:
+			return [][2]string{{"sslaccept", "accept_invalid_certs"}}
+		case TLSRequired:
+			return [][2]string{{"sslaccept", "strict"}}
+		}
+	case "sqlserver":
+		switch problem {
+		case TLSSelfSigned:
+			return [][2]string{{"encrypt", "true"}, {"trustServerCertificate", "true"}}
+		case TLSRequired:
+			return [][2]string{{"encrypt", "true"}}
+		case TLSUnsupported:
+			return [][2]string{{"encrypt", "false"}}
+		}
+	}
+	return nil
+}
+
+// DisablesCertVerification reports whether params turn off the check that
+// the server's certificate is signed by a trusted authority
+func DisablesCertVerification(params [][2]string) bool {
+	for _, param := range params {
+		if (param[0] == "sslaccept" && param[1] == "accept_invalid_certs") ||
+			(strings.EqualFold(param[0], "trustServerCertificate") && param[1] == "true") {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatTLSParams lists params for display, e.g. "sslmode=require, sslaccept=strict"
+func FormatTLSParams(params [][2]string) string {
+	parts := make([]string, len(params))
+	for i, param := range params {
+		parts[i] = param[0] + "=" + param[1]
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,2 @@
+-- AlterTable
+ALTER TABLE "Order" DROP COLUMN "notes";
@@ -0,0 +1,209 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Op is the kind of a diff edit
+type Op int
+
+const (
+	OpEqual Op = iota
+	OpDelete
+	OpInsert
+)
+
+// Edit is a single line in a line-level diff
+type Edit struct {
+	Op   Op
+	Text string
+}
+
+// Lines computes a line-level diff turning a into b (Myers' algorithm)
+func Lines(a, b []string) []Edit {
+	n, m := len(a), len(b)
+	total := n + m
+	if total == 0 {
+		return nil
+	}
+
+	offset := total
+	v := make([]int, 2*total+2)
+	var trace [][]int
+
+	found := false
+	for d := 0; d <= total && !found; d++ {
+		snapshot := make([]int, len(v))
+		copy(snapshot, v)
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[k-1+offset] < v[k+1+offset]) {
+				x = v[k+1+offset] // Move down (insertion)
+			} else {
+				x = v[k-1+offset] + 1 // Move right (deletion)
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[k+offset] = x
+			if x >= n && y >= m {
+				found = true
+				break
+			}
+		}
+	}
+
+	// Backtrack through the trace to recover the edit script
+	var edits []Edit
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		v := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[k-1+offset] < v[k+1+offset]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[prevK+offset]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			edits = append(edits, Edit{Op: OpEqual, Text: a[x-1]})
+			x--
+			y--
+		}
+		if x == prevX {
+			edits = append(edits, Edit{Op: OpInsert, Text: b[y-1]})
+			y--
+		} else {
+			edits = append(edits, Edit{Op: OpDelete, Text: a[x-1]})
+			x--
+		}
+	}
+	for x > 0 && y > 0 {
+		edits = append(edits, Edit{Op: OpEqual, Text: a[x-1]})
+		x--
+		y--
+	}
+
+	// Reverse into forward order
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+// SplitLines splits text into lines, ignoring a trailing newline and CRLF endings
+func SplitLines(text string) []string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return nil
+	}
+	return strings.Split(text, "\n")
+}
+
+// Unified renders a unified diff between two texts with the given number of context lines.
+// Returns an empty string if the texts are identical.
+func Unified(fromName, toName, from, to string, context int) string {
+	a, b := SplitLines(from), SplitLines(to)
+	edits := Lines(a, b)
+
+	changed := false
+	for _, e := range edits {
+		if e.Op != OpEqual {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+
+	for _, h := range hunks(edits, context) {
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", hunkRange(h.aStart, h.aLen), hunkRange(h.bStart, h.bLen))
+		for _, e := range h.edits {
+			switch e.Op {
+			case OpEqual:
+				sb.WriteString(" " + e.Text + "\n")
+			case OpDelete:
+				sb.WriteString("-" + e.Text + "\n")
+			case OpInsert:
+				sb.WriteString("+" + e.Text + "\n")
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// hunk is a group of edits shown together in a unified diff
+type hunk struct {
+	aStart, aLen int
+	bStart, bLen int
+	edits        []Edit
+}
+
+// hunks groups edits into hunks surrounded by up to context unchanged lines
+func hunks(edits []Edit, context int) []hunk {
+	// Line positions in a and b before each edit
+	aPos := make([]int, len(edits)+1)
+	bPos := make([]int, len(edits)+1)
+	for i, e := range edits {
+		aPos[i+1], bPos[i+1] = aPos[i], bPos[i]
+		if e.Op != OpInsert {
+			aPos[i+1]++
+		}
+		if e.Op != OpDelete {
+			bPos[i+1]++
+		}
+	}
+
+	// Merge the context windows around each change into [start, end) ranges
+	type span struct{ start, end int }
+	var spans []span
+	for i, e := range edits {
+		if e.Op == OpEqual {
+			continue
+		}
+		start, end := max(i-context, 0), min(i+context+1, len(edits))
+		if n := len(spans); n > 0 && start <= spans[n-1].end {
+			spans[n-1].end = end
+		} else {
+			spans = append(spans, span{start, end})
+		}
+	}
+
+	result := make([]hunk, 0, len(spans))
+	for _, sp := range spans {
+		result = append(result, hunk{
+			aStart: aPos[sp.start],
+			aLen:   aPos[sp.end] - aPos[sp.start],
+			bStart: bPos[sp.start],
+			bLen:   bPos[sp.end] - bPos[sp.start],
+			edits:  edits[sp.start:sp.end],
+		})
+	}
+	return result
+}
+
+// hunkRange formats a 0-based start and length as a unified diff range
+func hunkRange(start, length int) string {
+	if length == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if length == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, length)
+}
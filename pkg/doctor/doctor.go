@@ -0,0 +1,390 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/dokadev/lazyprisma/pkg/database"
+	"github.com/dokadev/lazyprisma/pkg/node"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+)
+
+// Status is the outcome of a check
+type Status int
+
+const (
+	StatusPass Status = iota
+	StatusWarn
+	StatusFail
+	StatusSkip // Not applicable, or depends on a check that failed
+)
+
+// Check identifies a health check
+type Check string
+
+const (
+	CheckNode       Check = "node"
+	CheckPrisma     Check = "prisma"
+	CheckSchema     Check = "schema"
+	CheckEnv        Check = "env"
+	CheckDatabase   Check = "database"
+	CheckShadowDB   Check = "shadow-db"
+	CheckMigrations Check = "migrations"
+)
+
+// Problem identifies why a check did not pass; the UI maps it to a fix suggestion
+type Problem string
+
+const (
+	ProblemNone                Problem = ""
+	ProblemNodeMissing         Problem = "node-missing"
+	ProblemNodeTooOld          Problem = "node-too-old"
+	ProblemNodePinMismatch     Problem = "node-pin-mismatch"
+	ProblemPrismaMissing       Problem = "prisma-missing"
+	ProblemClientMissing       Problem = "client-missing"
+	ProblemVersionMismatch     Problem = "version-mismatch"
+	ProblemSchemaInvalid       Problem = "schema-invalid"
+	ProblemNoDatasource        Problem = "no-datasource"
+	ProblemEnvNotSet           Problem = "env-not-set"
+	ProblemUnsupportedProvider Problem = "unsupported-provider"
+	ProblemDBUnreachable       Problem = "db-unreachable"
+	ProblemDriverAdapterOnly   Problem = "driver-adapter-only"
+	ProblemShadowUnreachable   Problem = "shadow-unreachable"
+	ProblemNoCreateDB          Problem = "no-createdb"
+	ProblemPlanetScaleShadow   Problem = "planetscale-shadow"
+	ProblemNoMigrations        Problem = "no-migrations"
+	ProblemLockMissing         Problem = "lock-missing"
+	ProblemProviderMismatch    Problem = "provider-mismatch"
+	ProblemEmptyMigration      Problem = "empty-migration"
+	ProblemBadMigrationName    Problem = "bad-migration-name"
+)
+
+// Result is the outcome of one check
+type Result struct {
+	Check   Check
+	Status  Status
+	Problem Problem
+	Detail  string // Versions, names or the error behind the result
+}
+
+// minNodeVersions is the oldest Node.js release each Prisma major version supports
+var minNodeVersions = map[int][3]int{
+	4: {14, 17, 0},
+	5: {16, 13, 0},
+	6: {18, 18, 0},
+	7: {20, 19, 0},
+}
+
+// migrationNameRe matches the folder names prisma migrate dev creates
+var migrationNameRe = regexp.MustCompile(`^\d{14}_`)
+
+// Run performs every check against the project in projectDir.
+// It runs prisma and connects to the database, so call it off the UI thread.
+func Run(projectDir string) []Result {
+	var results []Result
+
+	prismaVersion := ""
+	if info, err := prisma.GetVersion(projectDir); err == nil && info != nil {
+		prismaVersion = info.Version
+	}
+
+	results = append(results, checkNode(projectDir, prismaVersion))
+	results = append(results, checkPrisma(projectDir, prismaVersion))
+	results = append(results, checkSchema(projectDir, prismaVersion))
+
+	envResult, ds := checkEnv(projectDir)
+	results = append(results, envResult)
+
+	dbResult, client := checkDatabase(projectDir, ds)
+	results = append(results, dbResult)
+	results = append(results, checkShadowDB(projectDir, ds, client))
+	if client != nil {
+		client.Close()
+	}
+
+	results = append(results, checkMigrations(projectDir, ds))
+	return results
+}
+
+func checkNode(projectDir, prismaVersion string) Result {
+	info, err := node.GetProjectVersion(projectDir)
+	if err != nil || info.Version == "" {
+		return Result{Check: CheckNode, Status: StatusFail, Problem: ProblemNodeMissing}
+	}
+
+	major := parseVersion(prismaVersion)[0]
+	if minimum, ok := minNodeVersions[major]; ok && compareVersions(parseVersion(info.Version), minimum) < 0 {
+		return Result{
+			Check:   CheckNode,
+			Status:  StatusFail,
+			Problem: ProblemNodeTooOld,
+			Detail:  fmt.Sprintf("v%s < v%d.%d.%d (prisma %d)", info.Version, minimum[0], minimum[1], minimum[2], major),
+		}
+	}
+
+	// Prisma runs with a different Node.js than the project pins
+	if pin := node.FindPin(projectDir); pin != nil {
+		if matches, comparable := pin.Matches(info.Version); comparable && !matches {
+			return Result{
+				Check:   CheckNode,
+				Status:  StatusWarn,
+				Problem: ProblemNodePinMismatch,
+				Detail:  fmt.Sprintf("v%s, %s pins %s", info.Version, pin.Source, pin.Version),
+			}
+		}
+	}
+
+	return Result{Check: CheckNode, Status: StatusPass, Detail: "v" + info.Version}
+}
+
+func checkPrisma(projectDir, cliVersion string) Result {
+	if cliVersion == "" {
+		return Result{Check: CheckPrisma, Status: StatusFail, Problem: ProblemPrismaMissing}
+	}
+
+	clientVersion := prisma.GetClientVersion(projectDir)
+	detail := fmt.Sprintf("prisma %s, @prisma/client %s", cliVersion, clientVersion)
+	switch {
+	case clientVersion == "":
+		return Result{Check: CheckPrisma, Status: StatusWarn, Problem: ProblemClientMissing, Detail: "prisma " + cliVersion}
+	case clientVersion != cliVersion:
+		return Result{Check: CheckPrisma, Status: StatusFail, Problem: ProblemVersionMismatch, Detail: detail}
+	}
+	return Result{Check: CheckPrisma, Status: StatusPass, Detail: detail}
+}
+
+func checkSchema(projectDir, prismaVersion string) Result {
+	if prismaVersion == "" {
+		return Result{Check: CheckSchema, Status: StatusSkip}
+	}
+
+	result, err := prisma.Validate(projectDir)
+	if err != nil {
+		return Result{Check: CheckSchema, Status: StatusFail, Problem: ProblemSchemaInvalid, Detail: err.Error()}
+	}
+	if !result.Valid {
+		detail := ""
+		if len(result.Errors) > 0 {
+			detail = result.Errors[0]
+		}
+		return Result{Check: CheckSchema, Status: StatusFail, Problem: ProblemSchemaInvalid, Detail: detail}
+	}
+	return Result{Check: CheckSchema, Status: StatusPass}
+}
+
+// checkEnv verifies the datasource URL resolves, returning the datasource if it does
+func checkEnv(projectDir string) (Result, *prisma.Datasource) {
+	envVar, _ := prisma.GetEnvVarName(projectDir)
+
+	ds, err := prisma.GetDatasource(projectDir)
+	if err != nil || ds.URL == "" {
+		if envVar != "" {
+			return Result{Check: CheckEnv, Status: StatusFail, Problem: ProblemEnvNotSet, Detail: envVar}, nil
+		}
+		detail := ""
+		if err != nil {
+			detail = err.Error()
+		}
+		return Result{Check: CheckEnv, Status: StatusFail, Problem: ProblemNoDatasource, Detail: detail}, nil
+	}
+
+	if ds.IsHardcoded {
+		return Result{Check: CheckEnv, Status: StatusPass, Detail: prisma.MaskPassword(ds.URL)}, ds
+	}
+
+	source := "environment"
+	if _, path := prisma.ResolveEnvVarSource(projectDir, ds.EnvVarName); path != "" {
+		if rel, err := filepath.Rel(projectDir, path); err == nil {
+			path = rel
+		}
+		source = path
+	}
+	return Result{Check: CheckEnv, Status: StatusPass, Detail: fmt.Sprintf("%s (%s)", ds.EnvVarName, source)}, ds
+}
+
+// checkDatabase connects to the datasource, returning the open client on success
+func checkDatabase(projectDir string, ds *prisma.Datasource) (Result, *database.Client) {
+	if ds == nil {
+		return Result{Check: CheckDatabase, Status: StatusSkip}, nil
+	}
+	if !prisma.CanConnectDirectly(ds.URL) {
+		if adapter := prisma.DetectDriverAdapter(projectDir, ds.URL); adapter != nil {
+			return Result{Check: CheckDatabase, Status: StatusSkip, Problem: ProblemDriverAdapterOnly, Detail: adapter.Package}, nil
+		}
+	}
+	if !supportsProvider(ds.Provider) {
+		return Result{Check: CheckDatabase, Status: StatusSkip, Problem: ProblemUnsupportedProvider, Detail: ds.Provider}, nil
+	}
+
+	// NewClientFromDSN pings the database
+	client, err := database.NewClientFromDSN(ds.Provider, ds.URL)
+	if err != nil {
+		return Result{Check: CheckDatabase, Status: StatusFail, Problem: ProblemDBUnreachable, Detail: err.Error()}, nil
+	}
+
+	return Result{Check: CheckDatabase, Status: StatusPass, Detail: prisma.DBFingerprint(ds.URL)}, client
+}
+
+// checkShadowDB verifies migrate dev can use a shadow database: either the
+// configured shadowDatabaseUrl is reachable, or the user may create databases
+func checkShadowDB(projectDir string, ds *prisma.Datasource, client *database.Client) Result {
+	if ds == nil || !supportsProvider(ds.Provider) {
+		return Result{Check: CheckShadowDB, Status: StatusSkip}
+	}
+
+	if shadowURL, envVar := prisma.GetShadowDatabaseURL(projectDir); shadowURL != "" || envVar != "" {
+		if shadowURL == "" {
+			return Result{Check: CheckShadowDB, Status: StatusFail, Problem: ProblemEnvNotSet, Detail: envVar}
+		}
+		shadow, err := database.NewClientFromDSN(ds.Provider, shadowURL)
+		if err != nil {
+			return Result{Check: CheckShadowDB, Status: StatusFail, Problem: ProblemShadowUnreachable, Detail: err.Error()}
+		}
+		shadow.Close()
+		return Result{Check: CheckShadowDB, Status: StatusPass, Detail: "shadowDatabaseUrl " + prisma.DBFingerprint(shadowURL)}
+	}
+
+	// PlanetScale databases can't be created over a connection
+	if prisma.IsPlanetScale(projectDir, ds.URL) {
+		return Result{Check: CheckShadowDB, Status: StatusSkip, Problem: ProblemPlanetScaleShadow, Detail: "PlanetScale"}
+	}
+
+	if client == nil {
+		return Result{Check: CheckShadowDB, Status: StatusSkip}
+	}
+
+	canCreate, err := canCreateDatabase(client)
+	if err != nil {
+		return Result{Check: CheckShadowDB, Status: StatusWarn, Problem: ProblemNoCreateDB, Detail: err.Error()}
+	}
+	if !canCreate {
+		return Result{Check: CheckShadowDB, Status: StatusWarn, Problem: ProblemNoCreateDB}
+	}
+	return Result{Check: CheckShadowDB, Status: StatusPass, Detail: "CREATE DATABASE"}
+}
+
+// supportsProvider reports whether lazyprisma can connect to the provider's databases
+func supportsProvider(provider string) bool {
+	switch provider {
+	case "postgresql", "postgres", "cockroachdb", "mysql":
+		return true
+	}
+	return false
+}
+
+// canCreateDatabase reports whether the connected user may create databases
+func canCreateDatabase(client *database.Client) (bool, error) {
+	switch client.DriverName() {
+	case "postgresql", "postgres", "cockroachdb":
+		var canCreate bool
+		err := client.QueryRow(`SELECT rolcreatedb OR rolsuper FROM pg_roles WHERE rolname = current_user`).Scan(&canCreate)
+		return canCreate, err
+
+	case "mysql":
+		rows, err := client.Query(`SHOW GRANTS FOR CURRENT_USER()`)
+		if err != nil {
+			return false, err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var grant string
+			if err := rows.Scan(&grant); err != nil {
+				return false, err
+			}
+			grant = strings.ToUpper(grant)
+			if strings.Contains(grant, " ON *.* ") &&
+				(strings.Contains(grant, "ALL PRIVILEGES") || strings.Contains(grant, "CREATE")) {
+				return true, nil
+			}
+		}
+		return false, rows.Err()
+	}
+
+	return false, fmt.Errorf("unsupported driver %s", client.DriverName())
+}
+
+func checkMigrations(projectDir string, ds *prisma.Datasource) Result {
+	migrationsPath := prisma.MigrationsDir(projectDir)
+
+	entries, err := os.ReadDir(migrationsPath)
+	if err != nil {
+		return Result{Check: CheckMigrations, Status: StatusWarn, Problem: ProblemNoMigrations}
+	}
+
+	count := 0
+	emptyMigration := "" // First migration whose migration.sql is empty
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		count++
+
+		if !migrationNameRe.MatchString(entry.Name()) {
+			return Result{Check: CheckMigrations, Status: StatusWarn, Problem: ProblemBadMigrationName, Detail: entry.Name()}
+		}
+
+		// Prisma can't apply a folder without migration.sql, but applies an empty one as a no-op
+		stat, err := os.Stat(filepath.Join(migrationsPath, entry.Name(), "migration.sql"))
+		if err != nil {
+			return Result{Check: CheckMigrations, Status: StatusFail, Problem: ProblemEmptyMigration, Detail: entry.Name()}
+		}
+		if stat.Size() == 0 && emptyMigration == "" {
+			emptyMigration = entry.Name()
+		}
+	}
+
+	if count == 0 {
+		return Result{Check: CheckMigrations, Status: StatusWarn, Problem: ProblemNoMigrations}
+	}
+
+	lock, err := os.ReadFile(filepath.Join(migrationsPath, "migration_lock.toml"))
+	if err != nil {
+		return Result{Check: CheckMigrations, Status: StatusFail, Problem: ProblemLockMissing}
+	}
+
+	if ds != nil {
+		if m := regexp.MustCompile(`provider\s*=\s*"([^"]+)"`).FindSubmatch(lock); m != nil && string(m[1]) != ds.Provider {
+			return Result{
+				Check:   CheckMigrations,
+				Status:  StatusFail,
+				Problem: ProblemProviderMismatch,
+				Detail:  fmt.Sprintf("migration_lock.toml: %s, schema: %s", m[1], ds.Provider),
+			}
+		}
+	}
+
+	if emptyMigration != "" {
+		return Result{Check: CheckMigrations, Status: StatusWarn, Problem: ProblemEmptyMigration, Detail: emptyMigration}
+	}
+	return Result{Check: CheckMigrations, Status: StatusPass, Detail: strconv.Itoa(count)}
+}
+
+// parseVersion parses "20.11.1" (or "v20.11.1") into its numeric parts
+func parseVersion(version string) [3]int {
+	var parts [3]int
+	fields := strings.SplitN(strings.TrimPrefix(strings.TrimSpace(version), "v"), ".", 3)
+	for i, field := range fields {
+		// Drop pre-release suffixes like "0-dev.1"
+		field, _, _ = strings.Cut(field, "-")
+		parts[i], _ = strconv.Atoi(field)
+	}
+	return parts
+}
+
+// compareVersions returns -1, 0 or 1 as a is older, equal or newer than b
+func compareVersions(a, b [3]int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,140 @@
+package drift
+
+import (
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/dokadev/lazyprisma/pkg/database"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+)
+
+// DeadColumn is a column of a model's table that no field of the model maps
+// to, usually left behind by a field removed from the schema without a migration
+type DeadColumn struct {
+	Table  string
+	Column string
+}
+
+// SchemaColumns returns the columns the models in lines map their fields to,
+// keyed by table. Relation fields have no column and are left out.
+func SchemaColumns(lines []string) map[string][]string {
+	blocks := prisma.FindSchemaBlocks(lines)
+	models := make(map[string]bool)
+	for _, block := range blocks {
+		if block.Kind == "model" || block.Kind == "view" {
+			models[block.Name] = true
+		}
+	}
+
+	tables := make(map[string][]string)
+	for _, block := range blocks {
+		if block.Kind != "model" {
+			continue
+		}
+		table := block.DatabaseName(lines)
+		columns := []string{}
+		for _, f := range modelFields(lines, block) {
+			if !models[f.typ] {
+				columns = append(columns, f.column)
+			}
+		}
+		tables[table] = columns
+	}
+	return tables
+}
+
+// DatabaseColumns returns the columns of every table in the database at
+// dbURL, keyed by table, in column order
+func DatabaseColumns(provider, dbURL string) (map[string][]string, error) {
+	client, err := openClient(provider, dbURL)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	query := `SELECT c.table_name, c.column_name
+FROM information_schema.columns c
+JOIN information_schema.tables t ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+WHERE t.table_type = 'BASE TABLE' AND c.table_schema = $1
+ORDER BY c.table_name, c.ordinal_position`
+	args := []any{postgresSchema(dbURL)}
+	if provider == "mysql" {
+		query = `SELECT c.TABLE_NAME, c.COLUMN_NAME
+FROM information_schema.COLUMNS c
+JOIN information_schema.TABLES t ON t.TABLE_SCHEMA = c.TABLE_SCHEMA AND t.TABLE_NAME = c.TABLE_NAME
+WHERE t.TABLE_TYPE = 'BASE TABLE' AND c.TABLE_SCHEMA = DATABASE()
+ORDER BY c.TABLE_NAME, c.ORDINAL_POSITION`
+		args = nil
+	}
+
+	return queryColumns(client, query, args...)
+}
+
+// queryColumns collects the (table, column) rows of query by table
+func queryColumns(client *database.Client, query string, args ...any) (map[string][]string, error) {
+	rows, err := client.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tables := make(map[string][]string)
+	for rows.Next() {
+		var table, column string
+		if err := rows.Scan(&table, &column); err != nil {
+			return nil, err
+		}
+		tables[table] = append(tables[table], column)
+	}
+	return tables, rows.Err()
+}
+
+// DeadColumns returns the database columns of the schema's tables that no
+// field maps to, sorted by table and then in column order. Tables without a
+// model (Prisma's own _prisma_migrations among them) are not looked at.
+func DeadColumns(schema, db map[string][]string) []DeadColumn {
+	tables := make([]string, 0, len(schema))
+	for table := range schema {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	var dead []DeadColumn
+	for _, table := range tables {
+		for _, column := range db[table] {
+			if !slices.Contains(schema[table], column) {
+				dead = append(dead, DeadColumn{Table: table, Column: column})
+			}
+		}
+	}
+	return dead
+}
+
+// DropColumnsSQL returns the content of a migration that drops the columns,
+// one ALTER TABLE per table as `prisma migrate dev` writes them
+func DropColumnsSQL(provider string, columns []DeadColumn) string {
+	quote := func(name string) string { return `"` + strings.ReplaceAll(name, `"`, `""`) + `"` }
+	if provider == "mysql" {
+		quote = func(name string) string { return "`" + strings.ReplaceAll(name, "`", "``") + "`" }
+	}
+
+	var b strings.Builder
+	b.WriteString("-- Drops columns no field of the schema maps to.\n")
+	b.WriteString("-- Created with the lazyprisma dead column detector; the data in them is lost.\n")
+
+	for i, col := range columns {
+		if i > 0 && columns[i-1].Table == col.Table {
+			b.WriteString(",\nDROP COLUMN " + quote(col.Column))
+			continue
+		}
+		if i > 0 {
+			b.WriteString(";\n")
+		}
+		b.WriteString("\n-- AlterTable\nALTER TABLE " + quote(col.Table) + " DROP COLUMN " + quote(col.Column))
+	}
+	if len(columns) > 0 {
+		b.WriteString(";\n")
+	}
+	return b.String()
+}
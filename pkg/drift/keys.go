@@ -0,0 +1,579 @@
+// Package drift compares what the schema declares for a model with what its
+// table has in the live database: a focused drift check that needs neither the
+// Prisma CLI nor a shadow database.
+package drift
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/dokadev/lazyprisma/pkg/database"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+)
+
+// ErrUnsupportedProvider is returned for providers whose catalog lazyprisma can't read
+var ErrUnsupportedProvider = errors.New("drift checks support PostgreSQL and MySQL")
+
+// IndexKind is what an index enforces
+type IndexKind int
+
+const (
+	IndexPlain IndexKind = iota
+	IndexUnique
+	IndexPrimary
+)
+
+// Index is an index of a table: its primary key, a unique constraint or a plain index
+type Index struct {
+	Name    string
+	Kind    IndexKind
+	Columns []string // In index order
+}
+
+// ForeignKey is a foreign key constraint of a table
+type ForeignKey struct {
+	Name       string
+	Columns    []string
+	RefTable   string
+	RefColumns []string
+}
+
+// Keys are the indexes and foreign keys of a table
+type Keys struct {
+	Indexes     []Index
+	ForeignKeys []ForeignKey
+}
+
+// Status is how a key in the schema compares to the database
+type Status int
+
+const (
+	StatusMatch   Status = iota
+	StatusRenamed        // Same definition, different name
+	StatusChanged        // Same name, different definition
+	StatusMissing        // Declared in the schema, not in the database
+	StatusExtra          // In the database, not declared in the schema
+)
+
+// IndexDiff pairs an index of the schema with the database's (either is nil
+// if the other side doesn't have it)
+type IndexDiff struct {
+	Schema   *Index
+	Database *Index
+	Status   Status
+}
+
+// ForeignKeyDiff pairs a foreign key of the schema with the database's
+type ForeignKeyDiff struct {
+	Schema   *ForeignKey
+	Database *ForeignKey
+	Status   Status
+}
+
+// Comparison is the outcome of comparing a model's keys with its table's
+type Comparison struct {
+	Table       string
+	Indexes     []IndexDiff
+	ForeignKeys []ForeignKeyDiff
+}
+
+// Diverged returns the number of indexes and foreign keys that don't match
+func (c *Comparison) Diverged() int {
+	count := 0
+	for _, d := range c.Indexes {
+		if d.Status != StatusMatch {
+			count++
+		}
+	}
+	for _, d := range c.ForeignKeys {
+		if d.Status != StatusMatch {
+			count++
+		}
+	}
+	return count
+}
+
+var (
+	// "  authorId  Int  @map("author_id")"
+	fieldLineRegex = regexp.MustCompile(`^\s*(\w+)\s+(\w+)(?:\[\])?\??(.*)$`)
+	// @@id([a, b]), @@unique([a, b], map: "x"), @@index(fields: [a, b(sort: Desc)])
+	blockAttrRegex = regexp.MustCompile(`^\s*@@(id|unique|index)\((.*)\)\s*$`)
+	// @map("author_id")
+	fieldMapRegex = regexp.MustCompile(`@map\(\s*(?:name:\s*)?"([^"]+)"`)
+	// @id and @unique, with their optional (map: "x")
+	fieldIDRegex     = regexp.MustCompile(`@id\b(?:\(([^)]*)\))?`)
+	fieldUniqueRegex = regexp.MustCompile(`@unique\b(?:\(([^)]*)\))?`)
+	// @relation("Name", fields: [a], references: [b], map: "x")
+	relationRegex  = regexp.MustCompile(`@relation\(([^)]*)\)`)
+	fieldsArgRegex = regexp.MustCompile(`\bfields\s*:\s*\[([^\]]*)\]`)
+	refsArgRegex   = regexp.MustCompile(`\breferences\s*:\s*\[([^\]]*)\]`)
+	mapArgRegex    = regexp.MustCompile(`\bmap\s*:\s*"([^"]+)"`)
+	fieldListRegex = regexp.MustCompile(`\[([^\]]*)\]`)
+	fieldArgsRegex = regexp.MustCompile(`\([^)]*\)`)
+	commentRegex   = regexp.MustCompile(`//.*$`)
+	// relationMode = "prisma": relations are emulated, without foreign keys
+	relationModeRegex = regexp.MustCompile(`(?m)^\s*relationMode\s*=\s*"prisma"`)
+)
+
+// field is a field of a model as the schema declares it
+type field struct {
+	name   string
+	typ    string
+	column string // @map name, or the field name
+	attrs  string // Everything after the type
+}
+
+// modelFields returns the fields of block, skipping comments and block attributes
+func modelFields(lines []string, block prisma.SchemaBlock) []field {
+	var fields []field
+	for i := block.Line + 1; i < block.EndLine && i < len(lines); i++ {
+		line := commentRegex.ReplaceAllString(lines[i], "")
+		if strings.HasPrefix(strings.TrimSpace(line), "@@") {
+			continue
+		}
+		match := fieldLineRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		f := field{name: match[1], typ: match[2], column: match[1], attrs: match[3]}
+		if m := fieldMapRegex.FindStringSubmatch(f.attrs); m != nil {
+			f.column = m[1]
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// columnsOf maps field names to their columns in block
+func columnsOf(fields []field, names []string) []string {
+	columns := make([]string, 0, len(names))
+	for _, name := range names {
+		column := name
+		for _, f := range fields {
+			if f.name == name {
+				column = f.column
+				break
+			}
+		}
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+// splitFieldList splits "a, b(sort: Desc)" into ["a", "b"]
+func splitFieldList(list string) []string {
+	list = fieldArgsRegex.ReplaceAllString(list, "")
+	var names []string
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// SchemaKeys returns the indexes and foreign keys the schema declares for the
+// model block, named the way Prisma names them in the database unless the
+// schema maps them to another name. With relationMode = "prisma" relations
+// have no foreign keys.
+func SchemaKeys(lines []string, block prisma.SchemaBlock, provider string) Keys {
+	table := block.DatabaseName(lines)
+	fields := modelFields(lines, block)
+	emulated := relationModeRegex.MatchString(strings.Join(lines, "\n"))
+	var keys Keys
+
+	for _, f := range fields {
+		if m := fieldIDRegex.FindStringSubmatch(f.attrs); m != nil {
+			keys.Indexes = append(keys.Indexes, Index{
+				Name:    keyName(provider, table, nil, "pkey", mapArg(m[1])),
+				Kind:    IndexPrimary,
+				Columns: []string{f.column},
+			})
+		}
+		if m := fieldUniqueRegex.FindStringSubmatch(f.attrs); m != nil {
+			keys.Indexes = append(keys.Indexes, Index{
+				Name:    keyName(provider, table, []string{f.column}, "key", mapArg(m[1])),
+				Kind:    IndexUnique,
+				Columns: []string{f.column},
+			})
+		}
+		if m := relationRegex.FindStringSubmatch(f.attrs); m != nil && !emulated {
+			keys.ForeignKeys = append(keys.ForeignKeys, relationForeignKey(lines, provider, table, fields, f, m[1])...)
+		}
+	}
+
+	for i := block.Line + 1; i < block.EndLine && i < len(lines); i++ {
+		line := commentRegex.ReplaceAllString(lines[i], "")
+		match := blockAttrRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		list := fieldListRegex.FindStringSubmatch(match[2])
+		if list == nil {
+			continue
+		}
+		columns := columnsOf(fields, splitFieldList(list[1]))
+		args := strings.Replace(match[2], list[0], "", 1)
+
+		index := Index{Columns: columns}
+		switch match[1] {
+		case "id":
+			index.Kind = IndexPrimary
+			index.Name = keyName(provider, table, nil, "pkey", mapArg(args))
+		case "unique":
+			index.Kind = IndexUnique
+			index.Name = keyName(provider, table, columns, "key", mapArg(args))
+		default:
+			index.Name = keyName(provider, table, columns, "idx", mapArg(args))
+		}
+		keys.Indexes = append(keys.Indexes, index)
+	}
+
+	return keys
+}
+
+// relationForeignKey returns the foreign key of a relation field that holds
+// the relation's fields (the other side of a relation has none)
+func relationForeignKey(lines []string, provider, table string, fields []field, f field, args string) []ForeignKey {
+	fieldsMatch := fieldsArgRegex.FindStringSubmatch(args)
+	refsMatch := refsArgRegex.FindStringSubmatch(args)
+	if fieldsMatch == nil || refsMatch == nil {
+		return nil
+	}
+
+	columns := columnsOf(fields, splitFieldList(fieldsMatch[1]))
+	refTable := f.typ
+	refColumns := splitFieldList(refsMatch[1])
+	if ref, ok := prisma.FindSchemaBlock(lines, f.typ); ok {
+		refTable = ref.DatabaseName(lines)
+		refColumns = columnsOf(modelFields(lines, ref), refColumns)
+	}
+
+	return []ForeignKey{{
+		Name:       keyName(provider, table, columns, "fkey", mapArg(args)),
+		Columns:    columns,
+		RefTable:   refTable,
+		RefColumns: refColumns,
+	}}
+}
+
+// mapArg returns the map: "name" argument of an attribute, or ""
+func mapArg(args string) string {
+	if m := mapArgRegex.FindStringSubmatch(args); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// keyName returns mapped if set, otherwise Prisma's default constraint name:
+// {table}_{columns}_{suffix}, shortened to the provider's identifier limit.
+// MySQL always calls the primary key PRIMARY.
+func keyName(provider, table string, columns []string, suffix, mapped string) string {
+	if suffix == "pkey" && provider == "mysql" {
+		return "PRIMARY"
+	}
+	if mapped != "" {
+		return mapped
+	}
+
+	limit := 63
+	if provider == "mysql" {
+		limit = 64
+	}
+	base := strings.Join(append([]string{table}, columns...), "_")
+	if len(base)+len(suffix)+1 > limit {
+		base = base[:limit-len(suffix)-1]
+	}
+	return base + "_" + suffix
+}
+
+// DatabaseKeys reads the indexes and foreign keys of table from the database
+// at dbURL
+func DatabaseKeys(provider, dbURL, table string) (Keys, error) {
+	client, err := openClient(provider, dbURL)
+	if err != nil {
+		return Keys{}, err
+	}
+	defer client.Close()
+
+	if provider == "mysql" {
+		return mysqlKeys(client, table)
+	}
+	return postgresKeys(client, postgresSchema(dbURL), table)
+}
+
+// openClient connects to the database at dbURL if its catalog can be read
+func openClient(provider, dbURL string) (*database.Client, error) {
+	switch provider {
+	case "postgresql", "postgres", "mysql":
+		return database.NewClientFromDSN(provider, dbURL)
+	default:
+		return nil, ErrUnsupportedProvider
+	}
+}
+
+// postgresSchema returns the schema a PostgreSQL URL selects with ?schema=,
+// public by default
+func postgresSchema(dbURL string) string {
+	if u, err := url.Parse(dbURL); err == nil {
+		if schema := u.Query().Get("schema"); schema != "" {
+			return schema
+		}
+	}
+	return "public"
+}
+
+// postgresIndexesQuery lists the indexes of a table with their columns in
+// index order (expression columns are left out)
+const postgresIndexesQuery = `
+SELECT i.relname, ix.indisunique, ix.indisprimary,
+       array_to_string(ARRAY(
+           SELECT a.attname
+           FROM unnest(ix.indkey::int2[]) WITH ORDINALITY AS k(attnum, ord)
+           JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = k.attnum
+           ORDER BY k.ord), ',')
+FROM pg_index ix
+JOIN pg_class t ON t.oid = ix.indrelid
+JOIN pg_class i ON i.oid = ix.indexrelid
+JOIN pg_namespace n ON n.oid = t.relnamespace
+WHERE n.nspname = $1 AND t.relname = $2
+ORDER BY i.relname`
+
+// postgresForeignKeysQuery lists the foreign keys of a table with the columns
+// on both sides, in constraint order
+const postgresForeignKeysQuery = `
+SELECT c.conname, rt.relname,
+       array_to_string(ARRAY(
+           SELECT a.attname
+           FROM unnest(c.conkey) WITH ORDINALITY AS k(attnum, ord)
+           JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum
+           ORDER BY k.ord), ','),
+       array_to_string(ARRAY(
+           SELECT a.attname
+           FROM unnest(c.confkey) WITH ORDINALITY AS k(attnum, ord)
+           JOIN pg_attribute a ON a.attrelid = c.confrelid AND a.attnum = k.attnum
+           ORDER BY k.ord), ',')
+FROM pg_constraint c
+JOIN pg_class t ON t.oid = c.conrelid
+JOIN pg_class rt ON rt.oid = c.confrelid
+JOIN pg_namespace n ON n.oid = t.relnamespace
+WHERE c.contype = 'f' AND n.nspname = $1 AND t.relname = $2
+ORDER BY c.conname`
+
+// postgresKeys reads a table's keys from the PostgreSQL catalog
+func postgresKeys(client *database.Client, schema, table string) (Keys, error) {
+	var keys Keys
+
+	rows, err := client.Query(postgresIndexesQuery, schema, table)
+	if err != nil {
+		return Keys{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var index Index
+		var unique, primary bool
+		var columns string
+		if err := rows.Scan(&index.Name, &unique, &primary, &columns); err != nil {
+			return Keys{}, err
+		}
+		index.Kind = indexKind(unique, primary)
+		index.Columns = splitColumns(columns)
+		keys.Indexes = append(keys.Indexes, index)
+	}
+	if err := rows.Err(); err != nil {
+		return Keys{}, err
+	}
+
+	fkRows, err := client.Query(postgresForeignKeysQuery, schema, table)
+	if err != nil {
+		return Keys{}, err
+	}
+	defer fkRows.Close()
+	for fkRows.Next() {
+		var fk ForeignKey
+		var columns, refColumns string
+		if err := fkRows.Scan(&fk.Name, &fk.RefTable, &columns, &refColumns); err != nil {
+			return Keys{}, err
+		}
+		fk.Columns = splitColumns(columns)
+		fk.RefColumns = splitColumns(refColumns)
+		keys.ForeignKeys = append(keys.ForeignKeys, fk)
+	}
+	return keys, fkRows.Err()
+}
+
+// mysqlKeys reads a table's keys from MySQL's information_schema
+func mysqlKeys(client *database.Client, table string) (Keys, error) {
+	var keys Keys
+
+	rows, err := client.Query(`SELECT INDEX_NAME, NON_UNIQUE, COLUMN_NAME
+FROM information_schema.STATISTICS
+WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND COLUMN_NAME IS NOT NULL
+ORDER BY INDEX_NAME, SEQ_IN_INDEX`, table)
+	if err != nil {
+		return Keys{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name, column string
+		var nonUnique int
+		if err := rows.Scan(&name, &nonUnique, &column); err != nil {
+			return Keys{}, err
+		}
+		if n := len(keys.Indexes); n > 0 && keys.Indexes[n-1].Name == name {
+			keys.Indexes[n-1].Columns = append(keys.Indexes[n-1].Columns, column)
+			continue
+		}
+		keys.Indexes = append(keys.Indexes, Index{
+			Name:    name,
+			Kind:    indexKind(nonUnique == 0, name == "PRIMARY"),
+			Columns: []string{column},
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return Keys{}, err
+	}
+
+	fkRows, err := client.Query(`SELECT CONSTRAINT_NAME, COLUMN_NAME, REFERENCED_TABLE_NAME, REFERENCED_COLUMN_NAME
+FROM information_schema.KEY_COLUMN_USAGE
+WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND REFERENCED_TABLE_NAME IS NOT NULL
+ORDER BY CONSTRAINT_NAME, ORDINAL_POSITION`, table)
+	if err != nil {
+		return Keys{}, err
+	}
+	defer fkRows.Close()
+	for fkRows.Next() {
+		var name, column, refTable, refColumn string
+		if err := fkRows.Scan(&name, &column, &refTable, &refColumn); err != nil {
+			return Keys{}, err
+		}
+		if n := len(keys.ForeignKeys); n > 0 && keys.ForeignKeys[n-1].Name == name {
+			fk := &keys.ForeignKeys[n-1]
+			fk.Columns = append(fk.Columns, column)
+			fk.RefColumns = append(fk.RefColumns, refColumn)
+			continue
+		}
+		keys.ForeignKeys = append(keys.ForeignKeys, ForeignKey{
+			Name:       name,
+			Columns:    []string{column},
+			RefTable:   refTable,
+			RefColumns: []string{refColumn},
+		})
+	}
+	if err := fkRows.Err(); err != nil {
+		return Keys{}, err
+	}
+
+	// MySQL backs every foreign key with an index of the same name, which
+	// the schema doesn't declare
+	keys.Indexes = slices.DeleteFunc(keys.Indexes, func(index Index) bool {
+		return slices.ContainsFunc(keys.ForeignKeys, func(fk ForeignKey) bool {
+			return fk.Name == index.Name && slices.Equal(fk.Columns, index.Columns)
+		})
+	})
+	return keys, nil
+}
+
+// indexKind returns the kind of an index from the catalog's flags
+func indexKind(unique, primary bool) IndexKind {
+	switch {
+	case primary:
+		return IndexPrimary
+	case unique:
+		return IndexUnique
+	default:
+		return IndexPlain
+	}
+}
+
+// splitColumns splits the comma-separated column list the catalog queries return
+func splitColumns(columns string) []string {
+	if columns == "" {
+		return nil
+	}
+	return strings.Split(columns, ",")
+}
+
+// Compare pairs the keys declared in the schema with those in the database:
+// first by definition (kind and columns, or columns and referenced table),
+// then by name. What is left over is missing from one side.
+func Compare(table string, schema, db Keys) *Comparison {
+	result := &Comparison{Table: table}
+
+	usedIndexes := make([]bool, len(db.Indexes))
+	for i := range schema.Indexes {
+		s := &schema.Indexes[i]
+		diff := IndexDiff{Schema: s, Status: StatusMissing}
+		if j := findUnused(usedIndexes, len(db.Indexes), func(j int) bool {
+			return db.Indexes[j].Kind == s.Kind && slices.Equal(db.Indexes[j].Columns, s.Columns)
+		}); j >= 0 {
+			diff.Database, diff.Status = &db.Indexes[j], StatusMatch
+			if db.Indexes[j].Name != s.Name {
+				diff.Status = StatusRenamed
+			}
+		} else if j := findUnused(usedIndexes, len(db.Indexes), func(j int) bool {
+			return db.Indexes[j].Name == s.Name
+		}); j >= 0 {
+			diff.Database, diff.Status = &db.Indexes[j], StatusChanged
+		}
+		result.Indexes = append(result.Indexes, diff)
+	}
+	for j, used := range usedIndexes {
+		if !used {
+			result.Indexes = append(result.Indexes, IndexDiff{Database: &db.Indexes[j], Status: StatusExtra})
+		}
+	}
+
+	usedFKs := make([]bool, len(db.ForeignKeys))
+	for i := range schema.ForeignKeys {
+		s := &schema.ForeignKeys[i]
+		diff := ForeignKeyDiff{Schema: s, Status: StatusMissing}
+		if j := findUnused(usedFKs, len(db.ForeignKeys), func(j int) bool {
+			d := db.ForeignKeys[j]
+			return d.RefTable == s.RefTable && slices.Equal(d.Columns, s.Columns) && slices.Equal(d.RefColumns, s.RefColumns)
+		}); j >= 0 {
+			diff.Database, diff.Status = &db.ForeignKeys[j], StatusMatch
+			if db.ForeignKeys[j].Name != s.Name {
+				diff.Status = StatusRenamed
+			}
+		} else if j := findUnused(usedFKs, len(db.ForeignKeys), func(j int) bool {
+			return db.ForeignKeys[j].Name == s.Name
+		}); j >= 0 {
+			diff.Database, diff.Status = &db.ForeignKeys[j], StatusChanged
+		}
+		result.ForeignKeys = append(result.ForeignKeys, diff)
+	}
+	for j, used := range usedFKs {
+		if !used {
+			result.ForeignKeys = append(result.ForeignKeys, ForeignKeyDiff{Database: &db.ForeignKeys[j], Status: StatusExtra})
+		}
+	}
+
+	return result
+}
+
+// findUnused returns the first of n candidates that isn't used yet and
+// matches, marking it used, or -1
+func findUnused(used []bool, n int, matches func(int) bool) int {
+	for j := 0; j < n; j++ {
+		if !used[j] && matches(j) {
+			used[j] = true
+			return j
+		}
+	}
+	return -1
+}
+
+// String returns the index as it would be declared: "Order_status_idx (status)"
+func (i Index) String() string {
+	return fmt.Sprintf("%s (%s)", i.Name, strings.Join(i.Columns, ", "))
+}
+
+// String returns the foreign key as "Order_userId_fkey (userId) → User(id)"
+func (fk ForeignKey) String() string {
+	return fmt.Sprintf("%s (%s) → %s(%s)", fk.Name, strings.Join(fk.Columns, ", "), fk.RefTable, strings.Join(fk.RefColumns, ", "))
+}
@@ -0,0 +1,72 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ListBranches returns local and remote-tracking branch names, local branches first
+func ListBranches(dir string) ([]string, error) {
+	gitRoot := findGitRoot(dir)
+	if gitRoot == "" {
+		return nil, fmt.Errorf("not a git repository: %s", dir)
+	}
+
+	result, err := cmdBuilder.New("git", "for-each-ref", "--format=%(refname:short)", "refs/heads", "refs/remotes").
+		WithWorkingDir(gitRoot).RunWithOutput()
+	if err != nil {
+		return nil, fmt.Errorf("git for-each-ref: %w", err)
+	}
+
+	var branches []string
+	for _, line := range strings.Split(result.Stdout, "\n") {
+		name := strings.TrimSpace(line)
+		// Skip symbolic refs such as origin/HEAD
+		if name == "" || strings.HasSuffix(name, "/HEAD") {
+			continue
+		}
+		branches = append(branches, name)
+	}
+
+	return branches, nil
+}
+
+// CurrentBranch returns the checked-out branch name, or "" if dir is not in a git repository
+func CurrentBranch(dir string) string {
+	gitRoot := findGitRoot(dir)
+	if gitRoot == "" {
+		return ""
+	}
+	return getCurrentBranch(gitRoot)
+}
+
+// ListTreeDirs returns the names of subdirectories of dirPath at the given revision,
+// without checking anything out. A missing directory yields an empty list.
+func ListTreeDirs(dir, rev, dirPath string) ([]string, error) {
+	gitRoot, relPath, err := repoRelativePath(dir, dirPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify the revision exists so a typo is not reported as "no migrations"
+	if _, err := cmdBuilder.New("git", "rev-parse", "--verify", "--quiet", rev+"^{commit}").
+		WithWorkingDir(gitRoot).RunWithOutput(); err != nil {
+		return nil, fmt.Errorf("unknown revision: %s", rev)
+	}
+
+	result, err := cmdBuilder.New("git", "ls-tree", "-d", "--name-only", rev+":"+relPath).
+		WithWorkingDir(gitRoot).RunWithOutput()
+	if err != nil {
+		// The directory does not exist at this revision
+		return nil, nil
+	}
+
+	var dirs []string
+	for _, line := range strings.Split(result.Stdout, "\n") {
+		if name := strings.TrimSpace(line); name != "" {
+			dirs = append(dirs, name)
+		}
+	}
+
+	return dirs, nil
+}
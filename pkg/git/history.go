@@ -0,0 +1,141 @@
+package git
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Revision describes a commit that touched a file
+type Revision struct {
+	Hash    string
+	Author  string
+	Date    time.Time
+	Subject string
+}
+
+// ShortHash returns the abbreviated commit hash
+func (r Revision) ShortHash() string {
+	if len(r.Hash) > 7 {
+		return r.Hash[:7]
+	}
+	return r.Hash
+}
+
+// FileRevisions returns the commits that touched filePath, or any file in it
+// if it is a directory, newest first. limit caps the number of revisions
+// returned (0 = no limit).
+func FileRevisions(dir, filePath string, limit int) ([]Revision, error) {
+	gitRoot, relPath, err := repoRelativePath(dir, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"git", "log", "--format=%H%x1f%an%x1f%aI%x1f%s"}
+	// Renames can only be followed for a single file
+	if stat, err := os.Stat(filepath.Join(gitRoot, relPath)); err == nil && !stat.IsDir() {
+		args = append(args, "--follow")
+	}
+	if limit > 0 {
+		args = append(args, "-n", strconv.Itoa(limit))
+	}
+	args = append(args, "--", relPath)
+
+	result, err := cmdBuilder.New(args...).WithWorkingDir(gitRoot).RunWithOutput()
+	if err != nil {
+		return nil, fmt.Errorf("git log: %w", err)
+	}
+
+	var revisions []Revision
+	for _, line := range strings.Split(result.Stdout, "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) != 4 {
+			continue
+		}
+		date, _ := time.Parse(time.RFC3339, fields[2])
+		revisions = append(revisions, Revision{
+			Hash:    fields[0],
+			Author:  fields[1],
+			Date:    date,
+			Subject: fields[3],
+		})
+	}
+
+	return revisions, nil
+}
+
+// ShowFile returns the contents of filePath at the given revision.
+// An empty rev reads the version staged in the index.
+func ShowFile(dir, rev, filePath string) ([]byte, error) {
+	gitRoot, relPath, err := repoRelativePath(dir, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := cmdBuilder.New("git", "show", rev+":"+relPath).WithWorkingDir(gitRoot).RunWithOutput()
+	if err != nil {
+		return nil, fmt.Errorf("git show %s:%s: %w", rev, relPath, err)
+	}
+
+	return []byte(result.Stdout), nil
+}
+
+// ListTreeFiles returns the names of the entries directly in dirPath at the
+// given revision, without checking anything out
+func ListTreeFiles(dir, rev, dirPath string) ([]string, error) {
+	gitRoot, relPath, err := repoRelativePath(dir, dirPath)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := cmdBuilder.New("git", "ls-tree", "--name-only", rev+":"+relPath).
+		WithWorkingDir(gitRoot).RunWithOutput()
+	if err != nil {
+		return nil, fmt.Errorf("git ls-tree %s:%s: %w", rev, relPath, err)
+	}
+
+	var files []string
+	for _, line := range strings.Split(result.Stdout, "\n") {
+		if name := strings.TrimSpace(line); name != "" {
+			files = append(files, name)
+		}
+	}
+	return files, nil
+}
+
+// HeadHash returns the commit HEAD points to, or "" outside a repository or
+// before the first commit
+func HeadHash(dir string) string {
+	gitRoot := findGitRoot(dir)
+	if gitRoot == "" {
+		return ""
+	}
+	result, err := cmdBuilder.New("git", "rev-parse", "--verify", "--quiet", "HEAD").
+		WithWorkingDir(gitRoot).RunWithOutput()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(result.Stdout)
+}
+
+// repoRelativePath resolves the git root for dir and filePath relative to it (slash-separated)
+func repoRelativePath(dir, filePath string) (string, string, error) {
+	gitRoot := findGitRoot(dir)
+	if gitRoot == "" {
+		return "", "", fmt.Errorf("not a git repository: %s", dir)
+	}
+
+	if !filepath.IsAbs(filePath) {
+		filePath = filepath.Join(dir, filePath)
+	}
+
+	relPath, err := filepath.Rel(gitRoot, filePath)
+	if err != nil {
+		return "", "", err
+	}
+
+	return gitRoot, filepath.ToSlash(relPath), nil
+}
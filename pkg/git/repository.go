@@ -1,6 +1,7 @@
 package git
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -142,3 +143,17 @@ func IsFileModified(dir, filePath string) bool {
 	// If output is not empty, file has changes
 	return strings.TrimSpace(result.Stdout) != ""
 }
+
+// RestoreFile discards the staged and unstaged changes to a file, restoring
+// the committed version
+func RestoreFile(dir, filePath string) error {
+	gitRoot, relPath, err := repoRelativePath(dir, filePath)
+	if err != nil {
+		return err
+	}
+
+	if _, err := cmdBuilder.New("git", "checkout", "HEAD", "--", relPath).WithWorkingDir(gitRoot).RunWithOutput(); err != nil {
+		return fmt.Errorf("git checkout %s: %w", relPath, err)
+	}
+	return nil
+}
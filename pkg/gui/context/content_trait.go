@@ -0,0 +1,28 @@
+package context
+
+import (
+	"github.com/jesseduffield/gocui"
+)
+
+// ContentTrait rewrites a panel's view only when what it shows changed.
+// Panels are drawn on every layout pass, but most passes (a key press in
+// another panel, a spinner tick, a footer's clock) draw the same content;
+// clearing and re-printing it makes gocui parse and wrap every line again and
+// flickers on some terminals.
+type ContentTrait struct {
+	view    *gocui.View // View the content was last written to
+	written string
+	wrap    bool // Whether the view wrapped long lines when it was written
+}
+
+// renderContent writes content to v, replacing what it showed, unless v
+// already shows it. The view's frame, title, footer and colours are drawn
+// around the content and may still be changed every pass.
+func (self *ContentTrait) renderContent(v *gocui.View, content string) {
+	if v == self.view && v.Wrap == self.wrap && content == self.written {
+		return
+	}
+
+	v.SetContent(content)
+	self.view, self.written, self.wrap = v, content, v.Wrap
+}
@@ -5,17 +5,26 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/alecthomas/chroma/v2/formatters"
 	"github.com/alecthomas/chroma/v2/lexers"
 	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/dokadev/lazyprisma/pkg/database"
+	"github.com/dokadev/lazyprisma/pkg/demo"
+	"github.com/dokadev/lazyprisma/pkg/diff"
+	"github.com/dokadev/lazyprisma/pkg/drift"
 	"github.com/dokadev/lazyprisma/pkg/gui/style"
 	"github.com/dokadev/lazyprisma/pkg/gui/types"
 	"github.com/dokadev/lazyprisma/pkg/i18n"
 	"github.com/dokadev/lazyprisma/pkg/prisma"
 	"github.com/jesseduffield/gocui"
 	"github.com/jesseduffield/lazycore/pkg/boxlayout"
+	"github.com/rivo/uniseg"
 )
 
 // DetailsContext is the context-based replacement for DetailsPanel.
@@ -24,6 +33,8 @@ type DetailsContext struct {
 	*SimpleContext
 	*ScrollableTrait
 	*TabbedTrait
+	*LoadingTrait
+	*ContentTrait
 
 	g  *gocui.Gui
 	tr *i18n.TranslationSet
@@ -31,24 +42,107 @@ type DetailsContext struct {
 	// Content fields
 	content              string
 	currentMigrationName string
+	currentMigration     *prisma.Migration // Last migration shown (for re-rendering)
+	currentTabName       string            // Migrations tab the migration was selected from
+	formatSQL            bool              // Pretty-print SQL before highlighting
+	backslashEscapes     bool              // Backslashes escape quotes in the project's SQL (MySQL)
+	sqlHighlight         SQLHighlight      // How SQL is coloured
+	highlightMaxLines    int               // Longer SQL only gets keyword coloring (0 = no limit)
+	appliedDiffs         map[string]string // Rendered diffs against the applied version, keyed by migration and checksums
+	appliedDiffsLoading  map[string]bool   // Diffs being looked up in the background
+	appliedDiffsMu       sync.Mutex        // Guards both; content is also built on the refresh goroutine
 
 	// Action-needed data
 	actionNeededMigrations []prisma.Migration
+	migrationTableMissing  bool
+	edgeDatabase           *prisma.EdgeDatabase // Migrations are applied with its own CLI (nil = none)
 	validationResult       *prisma.ValidateResult
+	envConflictVar         string                    // Database URL variable defined with different values
+	envConflicts           []prisma.EnvVarDefinition // Its definitions, in resolution order
+	pooledMigrations       *prisma.PooledMigrations  // Migrations would run through a connection pooler (nil = no)
+	mergePlan              *prisma.MergeFixPlan      // Repairs for migrations a git merge duplicated or reordered (nil = none)
+	demo                   bool // Demo mode: there is no Prisma CLI to validate with
+
+	// Schema history data
+	schemaHistory     []prisma.SchemaRevision
+	schemaHistoryHead string // HEAD the schema history was loaded at ("" = not loaded)
+	schemaHistoryView string // Schema or diff shown instead of the timeline ("" = timeline)
+
+	// SQL the next migration would contain ("" = no preview)
+	migrationPreview   string
+	migrationPreviewAt time.Time
+	tablePeek          *database.TablePeek // Shown in the Data tab (nil = no tab)
+	tablePeekName      string
+	tablePeekAt        time.Time
+	queryText          string                // Statement shown in the Query tab ("" = no tab)
+	queryResult        *database.QueryResult // Its rows, or
+	queryPlan          *database.QueryPlan   // its plan
+	queryAt            time.Time
+	queryUncommitted   int // Writes of the console's open transaction
+
+	// Schema db pull would write, and schema.prisma when it was read ("" = no Introspection tab)
+	introspectedSchema string
+	introspectedBase   string
+	introspectedAt     time.Time
+
+	// Schema viewer data
+	schemaPath         string   // File shown in the Schema tab
+	schemaLines        []string // Its lines
+	schemaCursor       int      // Selected line (0-based)
+	schemaFollowCursor bool     // Scroll the cursor into view on the next draw
+	schemaBackStack    []schemaPosition
+
+	// Schema panel selection, shown in the Details tab instead of the migration
+	schemaOutline    *prisma.SchemaOutline
+	schemaEntryKind  string              // Group of the selection
+	schemaEntry      *prisma.SchemaEntry // Selected entry (nil = the group's header)
+	schemaEntryShown bool
 
 	// Callback-based decoupling (replaces direct App reference)
 	hasActiveModal func() bool
 	onPanelClick   func(viewID string)
 }
 
+// SQLHighlight is how SQL is coloured in the Details panel
+type SQLHighlight string
+
+const (
+	SQLHighlightChroma   SQLHighlight = "chroma"   // Full syntax highlighting
+	SQLHighlightKeywords SQLHighlight = "keywords" // Keywords and comments only; cheap on slow terminals
+	SQLHighlightOff      SQLHighlight = "off"      // Plain text
+)
+
+// sqlHighlightCycle is the order the highlight toggle steps through
+var sqlHighlightCycle = []SQLHighlight{SQLHighlightChroma, SQLHighlightKeywords, SQLHighlightOff}
+
+// schemaHistoryLimit caps the number of schema.prisma revisions loaded
+const schemaHistoryLimit = 30
+
+// schemaJumpContext is how many lines are kept above a line jumped to in the Schema tab
+const schemaJumpContext = 5
+
+// schemaPosition is a place in the Schema tab to return to
+type schemaPosition struct {
+	path    string
+	cursor  int
+	originY int
+}
+
 var _ types.Context = &DetailsContext{}
 var _ types.IScrollableContext = &DetailsContext{}
+var _ types.ICopyableContext = &DetailsContext{}
 
 // DetailsContextOpts holds the options for creating a DetailsContext.
 type DetailsContextOpts struct {
 	Gui      *gocui.Gui
 	Tr       *i18n.TranslationSet
 	ViewName string
+
+	SQLHighlight      SQLHighlight // Defaults to SQLHighlightChroma
+	HighlightMaxLines int          // 0 = no limit
+	Demo              *demo.State  // Skip schema validation, which needs the Prisma CLI
+
+	Staleness StaleThresholds // Footer turns amber/red when the data is this old
 }
 
 // NewDetailsContext creates a new DetailsContext.
@@ -68,11 +162,21 @@ func NewDetailsContext(opts DetailsContextOpts) *DetailsContext {
 	dc := &DetailsContext{
 		SimpleContext:          simpleCtx,
 		ScrollableTrait:        &ScrollableTrait{},
+		LoadingTrait:           newLoadingTrait(opts.Staleness),
+		ContentTrait:           &ContentTrait{},
 		TabbedTrait:            &tabbedTrait,
 		g:                      opts.Gui,
 		tr:                     opts.Tr,
 		content:                opts.Tr.DetailsPanelInitialPlaceholder,
 		actionNeededMigrations: []prisma.Migration{},
+		appliedDiffs:           make(map[string]string),
+		appliedDiffsLoading:    make(map[string]bool),
+		sqlHighlight:           SQLHighlightChroma,
+		highlightMaxLines:      opts.HighlightMaxLines,
+		demo:                   opts.Demo != nil,
+	}
+	if opts.SQLHighlight == SQLHighlightKeywords || opts.SQLHighlight == SQLHighlightOff {
+		dc.sqlHighlight = opts.SQLHighlight
 	}
 
 	return dc
@@ -89,6 +193,18 @@ func (d *DetailsContext) SetModalCallbacks(hasActiveModal func() bool, onPanelCl
 	d.onPanelClick = onPanelClick
 }
 
+// CopyText returns the current tab's content as drawn, without line numbers so
+// that copied SQL and schema can be pasted as is.
+func (d *DetailsContext) CopyText() string {
+	lines := strings.Split(d.ScrollableTrait.PlainText(), "\n")
+	for i, line := range lines {
+		if loc := gutterLineRegex.FindStringIndex(line); loc != nil {
+			lines[i] = strings.TrimPrefix(line[loc[1]:], " ")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 // Draw renders the details panel (implements Panel interface from app package).
 func (d *DetailsContext) Draw(dim boxlayout.Dimensions) error {
 	v, err := d.g.SetView(d.GetViewName(), dim.X0, dim.Y0, dim.X1, dim.Y1, 0)
@@ -100,10 +216,9 @@ func (d *DetailsContext) Draw(dim boxlayout.Dimensions) error {
 	d.SetView(v)                  // BaseContext
 	d.ScrollableTrait.SetView(v)  // ScrollableTrait
 
-	v.Clear()
 	v.Frame = true
 	v.FrameRunes = style.DefaultFrameRunes
-	v.Wrap = true // Enable word wrap for long lines
+	v.Wrap = d.IsWrapping() // Wrap long lines unless truncation was toggled on
 
 	// Set tabs from TabbedTrait
 	v.Tabs = d.TabbedTrait.GetTabs()
@@ -129,13 +244,55 @@ func (d *DetailsContext) Draw(dim boxlayout.Dimensions) error {
 		}
 	}
 
+	// Indicate pretty-printed SQL
+	v.Subtitle = ""
+	if d.formatSQL {
+		v.Subtitle = d.tr.DetailsSQLFormattedIndicator
+	}
+
+	// Age of the data, coloured when it may be outdated
+	v.Footer = ""
+	d.applyUpdatedFooter(v, d.tr)
+
+	// Show what a background refresh is loading instead of stale content
+	if d.renderLoadingPlaceholder(v, d.ContentTrait) {
+		v.Subtitle = ""
+		return nil
+	}
+
 	// Render content based on current tab
+	var content string
 	currentTab := d.TabbedTrait.GetCurrentTab()
 	if currentTab == d.tr.TabActionNeeded {
-		fmt.Fprint(v, d.buildActionNeededContent())
+		content = d.buildActionNeededContent()
+	} else if currentTab == d.tr.TabSchema {
+		// One view line per schema line, so the cursor maps directly to a view line
+		v.Wrap = false
+		v.Subtitle = detailsGetRelativePath(d.schemaPath)
+		if len(d.schemaBackStack) > 0 {
+			v.Subtitle += " · " + fmt.Sprintf(d.tr.DetailsSchemaBackIndicator, len(d.schemaBackStack))
+		}
+		content = d.buildSchemaContent()
+		d.followSchemaCursor(v)
+	} else if currentTab == d.tr.TabPreview {
+		content = d.buildMigrationPreviewContent()
+	} else if currentTab == d.tr.TabIntrospection {
+		content = d.buildIntrospectionContent()
+	} else if currentTab == d.tr.TabData {
+		// Rows stay on one line so the columns line up
+		v.Wrap = false
+		content = d.buildTablePeekContent()
+	} else if currentTab == d.tr.TabQuery {
+		v.Wrap = false
+		content = d.buildQueryContent()
+	} else if currentTab == d.tr.TabSchemaHistory {
+		content = d.buildSchemaHistoryContent()
+	} else if d.schemaEntryShown {
+		content = d.buildSchemaEntryContent()
 	} else {
-		fmt.Fprint(v, d.content)
+		content = d.content
 	}
+	d.renderContent(v, content)
 
 	// Adjust scroll and apply origin
 	d.ScrollableTrait.AdjustScroll()
@@ -169,6 +326,9 @@ func (d *DetailsContext) UpdateFromMigration(migration *prisma.Migration, tabNam
 		d.currentMigrationName = ""
 	}
 
+	d.currentMigration = migration
+	d.currentTabName = tabName
+
 	if migration == nil {
 		d.content = d.tr.DetailsPanelInitialPlaceholder
 		return
@@ -177,6 +337,58 @@ func (d *DetailsContext) UpdateFromMigration(migration *prisma.Migration, tabNam
 	d.content = d.buildMigrationDetailContent(migration, tabName)
 }
 
+// ToggleSQLFormat switches between the raw and pretty-printed SQL display and
+// returns the new state. The file on disk is not touched.
+func (d *DetailsContext) ToggleSQLFormat() bool {
+	d.formatSQL = !d.formatSQL
+	if d.currentMigration != nil {
+		d.content = d.buildMigrationDetailContent(d.currentMigration, d.currentTabName)
+	}
+	return d.formatSQL
+}
+
+// IsSQLFormatted returns whether SQL is shown pretty-printed.
+func (d *DetailsContext) IsSQLFormatted() bool {
+	return d.formatSQL
+}
+
+// CycleSQLHighlight steps through full, keyword-only and no SQL highlighting
+// and returns the new mode.
+func (d *DetailsContext) CycleSQLHighlight() SQLHighlight {
+	next := SQLHighlightChroma
+	for i, mode := range sqlHighlightCycle {
+		if mode == d.sqlHighlight {
+			next = sqlHighlightCycle[(i+1)%len(sqlHighlightCycle)]
+			break
+		}
+	}
+	d.sqlHighlight = next
+
+	if d.currentMigration != nil {
+		d.content = d.buildMigrationDetailContent(d.currentMigration, d.currentTabName)
+	}
+	return d.sqlHighlight
+}
+
+// renderSQL formats (if enabled) and highlights SQL for display.
+func (d *DetailsContext) renderSQL(code string) string {
+	if d.formatSQL {
+		opts := prisma.DefaultFormatSQLOpts()
+		opts.BackslashEscapes = d.backslashEscapes
+		code = prisma.FormatSQL(code, opts)
+	}
+
+	switch {
+	case d.sqlHighlight == SQLHighlightOff:
+		return detailsNumberLines(code)
+	case d.sqlHighlight == SQLHighlightKeywords,
+		d.highlightMaxLines > 0 && strings.Count(code, "\n") >= d.highlightMaxLines:
+		// Tokenizing very large files with chroma is slow
+		return detailsNumberLines(detailsHighlightSQLKeywords(code))
+	}
+	return detailsHighlightSQL(code)
+}
+
 // buildMigrationDetailContent builds the detail content for a given migration.
 func (d *DetailsContext) buildMigrationDetailContent(migration *prisma.Migration, tabName string) string {
 	// Handle different cases (priority: Failed > DB-Only > Checksum Mismatch > Empty)
@@ -239,7 +451,7 @@ func (d *DetailsContext) buildFailedMigrationContent(migration *prisma.Migration
 		sqlPath := filepath.Join(migration.Path, "migration.sql")
 		content, err := os.ReadFile(sqlPath)
 		if err == nil {
-			highlightedSQL := detailsHighlightSQL(string(content))
+			highlightedSQL := d.renderSQL(string(content))
 			result := header + "\n\n" + highlightedSQL
 
 			// Show down.sql if available
@@ -247,7 +459,7 @@ func (d *DetailsContext) buildFailedMigrationContent(migration *prisma.Migration
 				downSQLPath := filepath.Join(migration.Path, "down.sql")
 				downContent, err := os.ReadFile(downSQLPath)
 				if err == nil {
-					highlightedDownSQL := detailsHighlightSQL(string(downContent))
+					highlightedDownSQL := d.renderSQL(string(downContent))
 					result += "\n\n" + style.Yellow(d.tr.DetailsDownMigrationSQLLabel) + "\n\n" + highlightedDownSQL
 				}
 			}
@@ -302,7 +514,9 @@ func (d *DetailsContext) buildChecksumMismatchContent(migration *prisma.Migratio
 	sqlPath := filepath.Join(migration.Path, "migration.sql")
 	content, err := os.ReadFile(sqlPath)
 	if err == nil {
-		highlightedSQL := detailsHighlightSQL(string(content))
+		header += "\n" + d.appliedVersionDiff(migration, string(content)) + "\n"
+
+		highlightedSQL := d.renderSQL(string(content))
 		result := header + "\n" + highlightedSQL
 
 		// Show down.sql if available
@@ -310,7 +524,7 @@ func (d *DetailsContext) buildChecksumMismatchContent(migration *prisma.Migratio
 			downSQLPath := filepath.Join(migration.Path, "down.sql")
 			downContent, err := os.ReadFile(downSQLPath)
 			if err == nil {
-				highlightedDownSQL := detailsHighlightSQL(string(downContent))
+				highlightedDownSQL := d.renderSQL(string(downContent))
 				result += "\n\n" + style.Yellow(d.tr.DetailsDownMigrationSQLLabel) + "\n\n" + highlightedDownSQL
 			}
 		}
@@ -320,6 +534,53 @@ func (d *DetailsContext) buildChecksumMismatchContent(migration *prisma.Migratio
 	return header
 }
 
+// appliedVersionDiff renders a unified diff between the version of migration.sql recorded
+// in the database (looked up in git history by checksum) and the local file.
+// The lookup runs git once per revision, so it runs in the background and the
+// migration is re-rendered once it finishes; results are cached.
+func (d *DetailsContext) appliedVersionDiff(migration *prisma.Migration, local string) string {
+	key := migration.Name + "\x00" + migration.Checksum + "\x00" + migration.DBChecksum
+
+	d.appliedDiffsMu.Lock()
+	defer d.appliedDiffsMu.Unlock()
+	if cached, ok := d.appliedDiffs[key]; ok {
+		return cached
+	}
+	if !d.appliedDiffsLoading[key] {
+		d.appliedDiffsLoading[key] = true
+		go d.loadAppliedVersionDiff(*migration, local, key)
+	}
+	return style.Gray(d.tr.DetailsChecksumDiffLoading)
+}
+
+// loadAppliedVersionDiff looks up and renders the diff for appliedVersionDiff,
+// then re-renders the migration if it is still shown
+func (d *DetailsContext) loadAppliedVersionDiff(migration prisma.Migration, local, key string) {
+	rendered := style.Gray(d.tr.DetailsChecksumDiffUnavailable)
+	applied, err := prisma.FindAppliedVersion(migration)
+	if err == nil && applied != nil {
+		source := d.tr.DetailsAppliedVersionStaged
+		if applied.Revision.Hash != "" {
+			source = fmt.Sprintf(d.tr.DetailsAppliedVersionCommit,
+				applied.Revision.ShortHash(), applied.Revision.Date.Format("2006-01-02"))
+		}
+		unified := diff.Unified("applied/migration.sql", "local/migration.sql", applied.Content, local, 3)
+		rendered = style.Yellow(fmt.Sprintf(d.tr.DetailsChecksumDiffLabel, source)) + "\n\n" + ColorizeDiff(unified)
+	}
+
+	d.appliedDiffsMu.Lock()
+	d.appliedDiffs[key] = rendered
+	delete(d.appliedDiffsLoading, key)
+	d.appliedDiffsMu.Unlock()
+
+	d.g.Update(func(*gocui.Gui) error {
+		if d.currentMigration != nil && d.currentMigration.Name == migration.Name {
+			d.content = d.buildMigrationDetailContent(d.currentMigration, d.currentTabName)
+		}
+		return nil
+	})
+}
+
 // buildEmptyMigrationContent builds content for empty migrations.
 func (d *DetailsContext) buildEmptyMigrationContent(migration *prisma.Migration) string {
 	timestamp, name := detailsParseMigrationName(migration.Name)
@@ -372,7 +633,12 @@ func (d *DetailsContext) buildNormalMigrationContent(migration *prisma.Migration
 		if migration.Path != "" {
 			header += fmt.Sprintf(d.tr.DetailsPathLabel, detailsGetRelativePath(migration.Path))
 		}
-		header += fmt.Sprintf(d.tr.DetailsStatusLabel+"%s\n", style.Yellow(d.tr.MigrationStatusPending))
+		if d.edgeDatabase != nil {
+			header += fmt.Sprintf(d.tr.DetailsStatusLabel+"%s\n", style.Gray(fmt.Sprintf(d.tr.MigrationStatusUntracked, d.edgeDatabase.CLI)))
+			header += fmt.Sprintf(d.tr.DetailsApplyWithLabel+"%s\n", d.edgeDatabase.ApplyCommand(detailsGetRelativePath(sqlPath)))
+		} else {
+			header += fmt.Sprintf(d.tr.DetailsStatusLabel+"%s\n", style.Yellow(d.tr.MigrationStatusPending))
+		}
 	}
 
 	// Show down migration availability
@@ -383,7 +649,7 @@ func (d *DetailsContext) buildNormalMigrationContent(migration *prisma.Migration
 	}
 
 	// Apply syntax highlighting to SQL content
-	highlightedSQL := detailsHighlightSQL(string(content))
+	highlightedSQL := d.renderSQL(string(content))
 
 	result := header + "\n" + highlightedSQL
 
@@ -392,7 +658,7 @@ func (d *DetailsContext) buildNormalMigrationContent(migration *prisma.Migration
 		downSQLPath := filepath.Join(migration.Path, "down.sql")
 		downContent, err := os.ReadFile(downSQLPath)
 		if err == nil {
-			highlightedDownSQL := detailsHighlightSQL(string(downContent))
+			highlightedDownSQL := d.renderSQL(string(downContent))
 			result += "\n\n" + style.Yellow(d.tr.DetailsDownMigrationSQLLabel) + "\n\n" + highlightedDownSQL
 		}
 	}
@@ -406,11 +672,30 @@ func (d *DetailsContext) SetActionNeededMigrations(migrations []prisma.Migration
 	d.actionNeededMigrations = migrations
 }
 
+// SetMigrationTableMissing records whether the database has no _prisma_migrations table yet
+func (d *DetailsContext) SetMigrationTableMissing(missing bool) {
+	d.migrationTableMissing = missing
+}
+
+// SetMergePlan records how to repair the migrations a git merge duplicated
+// or reordered (nil = nothing to repair)
+func (d *DetailsContext) SetMergePlan(plan *prisma.MergeFixPlan) {
+	d.mergePlan = plan
+}
+
+// SetEdgeDatabase records the edge database migrations are applied to with its
+// own CLI, so their applied state is unknown (nil = none)
+func (d *DetailsContext) SetEdgeDatabase(edge *prisma.EdgeDatabase) {
+	d.edgeDatabase = edge
+}
+
 // LoadActionNeededData loads action-needed data using the internal migrations list and validates schema.
 func (d *DetailsContext) LoadActionNeededData() {
-	// Run schema validation
+	// Run schema validation (the demo's fixture schema is valid)
 	cwd, err := os.Getwd()
-	if err == nil {
+	if d.demo {
+		d.validationResult = nil
+	} else if err == nil {
 		validateResult, err := prisma.Validate(cwd)
 		if err == nil {
 			d.validationResult = validateResult
@@ -421,6 +706,24 @@ func (d *DetailsContext) LoadActionNeededData() {
 		d.validationResult = nil
 	}
 
+	// Check for a database URL variable defined with different values
+	d.envConflictVar, d.envConflicts = "", nil
+	if err == nil {
+		if envVar, err := prisma.GetEnvVarName(cwd); err == nil {
+			if conflicts := prisma.EnvVarConflicts(cwd, envVar); conflicts != nil {
+				d.envConflictVar, d.envConflicts = envVar, conflicts
+			}
+		}
+	}
+
+	// Check for migrations running through a transaction-mode pooler
+	d.pooledMigrations = nil
+	if err == nil {
+		if ds, err := prisma.GetDatasource(cwd); err == nil {
+			d.pooledMigrations = prisma.CheckPooledMigrations(cwd, ds)
+		}
+	}
+
 	d.updateTabs()
 }
 
@@ -430,14 +733,861 @@ func (d *DetailsContext) updateTabs() {
 	newTabs := []string{d.tr.TabDetails}
 
 	// Add Action-Needed tab if there are migration issues or validation errors
-	hasIssues := len(d.actionNeededMigrations) > 0
+	hasIssues := len(d.actionNeededMigrations) > 0 || d.migrationTableMissing || len(d.envConflicts) > 0 || d.pooledMigrations != nil || d.mergePlan != nil
 	hasValidationErrors := d.validationResult != nil && !d.validationResult.Valid
 
 	if hasIssues || hasValidationErrors {
 		newTabs = append(newTabs, d.tr.TabActionNeeded)
 	}
 
+	// Add Schema tab if the schema could be read
+	if len(d.schemaLines) > 0 {
+		newTabs = append(newTabs, d.tr.TabSchema)
+	}
+
+	// Add Migration Preview tab once a preview was generated
+	if d.migrationPreview != "" {
+		newTabs = append(newTabs, d.tr.TabPreview)
+	}
+
+	// Add Introspection tab once the database was introspected
+	if d.introspectedSchema != "" {
+		newTabs = append(newTabs, d.tr.TabIntrospection)
+	}
+
+	// Add Data tab once a table was peeked at
+	if d.tablePeek != nil {
+		newTabs = append(newTabs, d.tr.TabData)
+	}
+
+	// Add Query tab once the SQL console ran a statement
+	if d.queryText != "" {
+		newTabs = append(newTabs, d.tr.TabQuery)
+	}
+
+	// Add Schema History tab if schema.prisma has git history
+	if len(d.schemaHistory) > 0 {
+		newTabs = append(newTabs, d.tr.TabSchemaHistory)
+	}
+
+	// Keep the current tab selected if it still exists
+	currentTab := d.TabbedTrait.GetCurrentTab()
 	d.TabbedTrait.SetTabs(newTabs)
+	for i, tab := range newTabs {
+		if tab == currentTab {
+			d.TabbedTrait.SetCurrentTabIdx(i)
+			break
+		}
+	}
+}
+
+// LoadSchemaHistory reads the git history of the schema for the Schema History tab.
+// It runs git once per revision and touches no state, so it can run off the UI thread
+// when the history is opened.
+func (d *DetailsContext) LoadSchemaHistory() []prisma.SchemaRevision {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	history, err := prisma.SchemaHistory(cwd, schemaHistoryLimit)
+	if err != nil {
+		return nil
+	}
+	return history
+}
+
+// SetSchemaHistory stores the schema history loaded at the given HEAD commit.
+func (d *DetailsContext) SetSchemaHistory(history []prisma.SchemaRevision, head string) {
+	d.schemaHistory = history
+	d.schemaHistoryHead = head
+	d.updateTabs()
+}
+
+// SchemaHistoryHead returns the HEAD commit the schema history was loaded at, or "" if it was not loaded.
+func (d *DetailsContext) SchemaHistoryHead() string {
+	return d.schemaHistoryHead
+}
+
+// GetSchemaHistory returns the loaded schema.prisma revisions, newest first.
+func (d *DetailsContext) GetSchemaHistory() []prisma.SchemaRevision {
+	return d.schemaHistory
+}
+
+// ShowSchemaHistoryView replaces the timeline with the given content and switches to the Schema History tab.
+func (d *DetailsContext) ShowSchemaHistoryView(content string) {
+	d.schemaHistoryView = content
+	d.switchToTab(d.tr.TabSchemaHistory)
+	d.ScrollableTrait.SetOriginY(0)
+}
+
+// ShowSchemaHistoryTimeline shows the timeline in the Schema History tab.
+func (d *DetailsContext) ShowSchemaHistoryTimeline() {
+	d.ShowSchemaHistoryView("")
+}
+
+// buildSchemaHistoryContent builds the content for the Schema History tab.
+func (d *DetailsContext) buildSchemaHistoryContent() string {
+	if d.schemaHistoryView != "" {
+		return d.schemaHistoryView
+	}
+
+	var content strings.Builder
+	content.WriteString(style.YellowBold(d.tr.SchemaHistoryTitle) + "\n")
+	content.WriteString(style.Gray(d.tr.SchemaHistoryHint) + "\n\n")
+
+	for _, rev := range d.schemaHistory {
+		content.WriteString(fmt.Sprintf("%s  %s  %s\n",
+			style.Yellow(rev.ShortHash()),
+			style.Gray(rev.Date.Format("2006-01-02")),
+			rev.Subject))
+		content.WriteString(style.Gray("         "+rev.Author) + "\n")
+		if len(rev.ModelsAdded) > 0 {
+			content.WriteString("         " + style.Green("+ "+strings.Join(rev.ModelsAdded, ", ")) + "\n")
+		}
+		if len(rev.ModelsRemoved) > 0 {
+			content.WriteString("         " + style.Red("- "+strings.Join(rev.ModelsRemoved, ", ")) + "\n")
+		}
+		content.WriteString("\n")
+	}
+
+	return content.String()
+}
+
+// ShowDetailsTab switches to the Details tab, which shows the selected migration.
+func (d *DetailsContext) ShowDetailsTab() {
+	d.switchToTab(d.tr.TabDetails)
+}
+
+// ShowSchemaEntry shows an entry selected in the Schema panel in the Details
+// tab instead of the selected migration. entry is nil for the header of
+// kind's group, which lists the group's entries.
+func (d *DetailsContext) ShowSchemaEntry(outline *prisma.SchemaOutline, kind string, entry *prisma.SchemaEntry) {
+	changed := !d.schemaEntryShown || d.schemaEntryKind != kind ||
+		(d.schemaEntry == nil) != (entry == nil) ||
+		(entry != nil && d.schemaEntry.Name != entry.Name)
+
+	d.schemaOutline = outline
+	d.schemaEntryKind = kind
+	d.schemaEntry = entry
+	d.schemaEntryShown = true
+	d.switchToTab(d.tr.TabDetails)
+	if changed {
+		d.ScrollableTrait.SetOriginY(0)
+	}
+}
+
+// ShowMigration goes back to showing the selected migration in the Details
+// tab after ShowSchemaEntry.
+func (d *DetailsContext) ShowMigration() {
+	if !d.schemaEntryShown {
+		return
+	}
+	d.schemaEntryShown = false
+	if d.TabbedTrait.GetCurrentTab() == d.tr.TabDetails {
+		d.ScrollableTrait.SetOriginY(0)
+	}
+}
+
+// buildSchemaEntryContent builds the Details tab content for the Schema
+// panel's selection: an entry's fields, relations and attributes, or the
+// entries of a group.
+func (d *DetailsContext) buildSchemaEntryContent() string {
+	var content strings.Builder
+	outline := d.schemaOutline
+
+	if d.schemaEntry == nil {
+		var rows [][]string
+		for _, entry := range outline.Entries {
+			if entry.Kind == d.schemaEntryKind {
+				rows = append(rows, []string{entry.Name, SchemaEntrySummary(d.tr, entry)})
+			}
+		}
+		content.WriteString(style.CyanBold(fmt.Sprintf("%s (%d)", SchemaGroupLabel(d.tr, d.schemaEntryKind), len(rows))) + "\n\n")
+		content.WriteString(schemaColumns(rows, nil, style.Gray))
+		content.WriteString("\n" + style.Gray(d.tr.DetailsSchemaGroupHint))
+		return content.String()
+	}
+
+	entry := d.schemaEntry
+	content.WriteString(fmt.Sprintf(d.tr.DetailsSchemaEntryTitle, SchemaKindLabel(d.tr, entry.Kind), style.Cyan(entry.Name)))
+	if entry.Kind != "generator" && entry.DatabaseName() != entry.Name {
+		content.WriteString(fmt.Sprintf(d.tr.DetailsSchemaDatabaseNameLabel, entry.DatabaseName()))
+	}
+	if cwd, err := os.Getwd(); err == nil {
+		content.WriteString(fmt.Sprintf(d.tr.DetailsSchemaDefinedAtLabel, detailsGetRelativePath(prisma.SchemaPath(cwd)), entry.Line+1))
+	}
+	if entry.Doc != "" {
+		content.WriteString("\n" + style.Gray(entry.Doc) + "\n")
+	}
+
+	section := func(label, body string) {
+		if body != "" {
+			content.WriteString("\n" + style.Yellow(label) + "\n" + body)
+		}
+	}
+
+	switch entry.Kind {
+	case "enum":
+		var rows [][]string
+		for _, value := range entry.Fields {
+			rows = append(rows, []string{value.Name, value.Attributes})
+		}
+		section(d.tr.DetailsSchemaValuesLabel, schemaColumns(rows, nil, style.Gray))
+	case "generator":
+		var rows [][]string
+		for _, setting := range entry.Fields {
+			rows = append(rows, []string{setting.Name, "= " + setting.Type})
+		}
+		section(d.tr.DetailsSchemaSettingsLabel, schemaColumns(rows, nil, nil))
+	default:
+		var fields, relations [][]string
+		for _, field := range entry.Fields {
+			fields = append(fields, []string{field.Name, field.TypeString(), field.Attributes})
+			if outline.IsRelation(field) {
+				relations = append(relations, []string{field.Name, "→ " + field.Type, schemaRelationCardinality(d.tr, field)})
+			}
+		}
+		section(d.tr.DetailsSchemaFieldsLabel, schemaColumns(fields, nil, style.Cyan, style.Gray))
+		section(d.tr.DetailsSchemaRelationsLabel, schemaColumns(relations, nil, style.Cyan, style.Gray))
+
+		var referencedBy [][]string
+		for _, relation := range outline.BackRelations(entry.Name) {
+			referencedBy = append(referencedBy, []string{relation.Model + "." + relation.Field.Name, schemaRelationCardinality(d.tr, relation.Field)})
+		}
+		section(d.tr.DetailsSchemaReferencedByLabel, schemaColumns(referencedBy, style.Cyan, style.Gray))
+	}
+
+	if len(entry.Attributes) > 0 {
+		section(d.tr.DetailsSchemaAttributesLabel, "  "+strings.Join(entry.Attributes, "\n  ")+"\n")
+	}
+
+	content.WriteString("\n" + style.Gray(d.tr.DetailsSchemaEntryHint))
+	return content.String()
+}
+
+// schemaRelationCardinality describes how many records a relation field holds
+func schemaRelationCardinality(tr *i18n.TranslationSet, field prisma.SchemaField) string {
+	switch {
+	case field.List:
+		return tr.SchemaRelationMany
+	case field.Optional:
+		return tr.SchemaRelationOptional
+	default:
+		return tr.SchemaRelationOne
+	}
+}
+
+// schemaColumns lays out rows as indented, aligned columns, one line per row.
+// Each column is coloured by its function in colors (nil = uncoloured); the
+// last one isn't padded, so lines have no trailing spaces.
+func schemaColumns(rows [][]string, colors ...func(string) string) string {
+	var widths []int
+	for _, row := range rows {
+		for i, value := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[i] = max(widths[i], uniseg.StringWidth(value))
+		}
+	}
+
+	var content strings.Builder
+	for _, row := range rows {
+		// Drop empty trailing cells, e.g. a field without attributes
+		for len(row) > 0 && row[len(row)-1] == "" {
+			row = row[:len(row)-1]
+		}
+		cells := make([]string, len(row))
+		for i, value := range row {
+			if i < len(row)-1 {
+				value += strings.Repeat(" ", widths[i]-uniseg.StringWidth(value))
+			}
+			if i < len(colors) && colors[i] != nil {
+				value = colors[i](value)
+			}
+			cells[i] = value
+		}
+		content.WriteString("  " + strings.Join(cells, "  ") + "\n")
+	}
+	return content.String()
+}
+
+// ShowMigrationPreview shows the SQL the next migration would contain in the Migration Preview tab.
+func (d *DetailsContext) ShowMigrationPreview(sql string) {
+	d.migrationPreview = sql
+	d.migrationPreviewAt = time.Now()
+	d.updateTabs()
+	d.switchToTab(d.tr.TabPreview)
+	d.ScrollableTrait.SetOriginY(0)
+}
+
+// ClearMigrationPreview removes the Migration Preview tab.
+func (d *DetailsContext) ClearMigrationPreview() {
+	d.migrationPreview = ""
+	d.updateTabs()
+}
+
+// GetMigrationPreview returns the SQL shown in the Migration Preview tab ("" if none).
+func (d *DetailsContext) GetMigrationPreview() string {
+	return d.migrationPreview
+}
+
+// buildMigrationPreviewContent builds the content for the Migration Preview tab.
+func (d *DetailsContext) buildMigrationPreviewContent() string {
+	var content strings.Builder
+	content.WriteString(style.YellowBold(fmt.Sprintf(d.tr.MigrationPreviewTitle, d.migrationPreviewAt.Format("15:04:05"))) + "\n")
+	content.WriteString(style.Gray(d.tr.MigrationPreviewHint) + "\n\n")
+
+	if strings.TrimSpace(d.migrationPreview) == "" || !strings.Contains(d.migrationPreview, ";") {
+		content.WriteString(style.Green(d.tr.MigrationPreviewNoChanges))
+		return content.String()
+	}
+
+	content.WriteString(d.renderSQL(d.migrationPreview))
+	return content.String()
+}
+
+// ShowIntrospection shows the schema db pull would write, diffed against
+// schema.prisma (current), in the Introspection tab and switches to it.
+func (d *DetailsContext) ShowIntrospection(introspected, current string) {
+	d.introspectedSchema = introspected
+	d.introspectedBase = current
+	d.introspectedAt = time.Now()
+	d.updateTabs()
+	d.switchToTab(d.tr.TabIntrospection)
+	d.ScrollableTrait.SetOriginY(0)
+}
+
+// ClearIntrospection removes the Introspection tab.
+func (d *DetailsContext) ClearIntrospection() {
+	d.introspectedSchema = ""
+	d.introspectedBase = ""
+	d.updateTabs()
+}
+
+// GetIntrospectedSchema returns the schema shown in the Introspection tab ("" if none).
+func (d *DetailsContext) GetIntrospectedSchema() string {
+	return d.introspectedSchema
+}
+
+// buildIntrospectionContent builds the content for the Introspection tab: the
+// changes db pull would make to schema.prisma
+func (d *DetailsContext) buildIntrospectionContent() string {
+	var content strings.Builder
+	content.WriteString(style.YellowBold(fmt.Sprintf(d.tr.IntrospectionTitle, d.introspectedAt.Format("15:04:05"))) + "\n")
+	content.WriteString(style.Gray(d.tr.IntrospectionHint) + "\n\n")
+
+	unified := diff.Unified("schema.prisma", "db pull", d.introspectedBase, d.introspectedSchema, 3)
+	if unified == "" {
+		content.WriteString(style.Green(d.tr.IntrospectionNoChanges))
+		return content.String()
+	}
+
+	added, removed := 0, 0
+	for _, edit := range diff.Lines(diff.SplitLines(d.introspectedBase), diff.SplitLines(d.introspectedSchema)) {
+		switch edit.Op {
+		case diff.OpInsert:
+			added++
+		case diff.OpDelete:
+			removed++
+		}
+	}
+	content.WriteString(fmt.Sprintf(d.tr.IntrospectionLineCount, added, removed) + "\n\n")
+	content.WriteString(ColorizeDiff(unified))
+	return content.String()
+}
+
+// ShowTablePeek shows the first rows of a table in the Data tab and switches to it.
+func (d *DetailsContext) ShowTablePeek(table string, peek *database.TablePeek) {
+	d.tablePeek = peek
+	d.tablePeekName = table
+	d.tablePeekAt = time.Now()
+	d.updateTabs()
+	d.switchToTab(d.tr.TabData)
+	d.ScrollableTrait.SetOriginY(0)
+}
+
+// buildTablePeekContent builds the content for the Data tab: the rows as a
+// grid, with the row count
+func (d *DetailsContext) buildTablePeekContent() string {
+	var content strings.Builder
+	content.WriteString(style.YellowBold(fmt.Sprintf(d.tr.TablePeekTitle, d.tablePeekName, d.tablePeekAt.Format("15:04:05"))) + "\n")
+	content.WriteString(style.Gray(fmt.Sprintf(d.tr.TablePeekRowCount, len(d.tablePeek.Rows), d.tablePeek.Total)) + "\n\n")
+
+	if len(d.tablePeek.Rows) == 0 {
+		content.WriteString(style.Gray(d.tr.TablePeekEmpty))
+		return content.String()
+	}
+	content.WriteString(buildGrid(d.tablePeek.Columns, d.tablePeek.Rows))
+	return content.String()
+}
+
+// ShowQueryResult shows what a statement run from the SQL console returned in
+// the Query tab and switches to it. uncommitted is the number of writes its
+// transaction holds.
+func (d *DetailsContext) ShowQueryResult(query string, result *database.QueryResult, uncommitted int) {
+	d.queryText = query
+	d.queryUncommitted = uncommitted
+	d.queryResult = result
+	d.queryPlan = nil
+	d.queryAt = time.Now()
+	d.updateTabs()
+	d.switchToTab(d.tr.TabQuery)
+	d.ScrollableTrait.SetOriginY(0)
+}
+
+// ShowQueryPlan shows the plan of a query from the SQL console in the Query
+// tab and switches to it.
+func (d *DetailsContext) ShowQueryPlan(query string, plan *database.QueryPlan, uncommitted int) {
+	d.queryText = query
+	d.queryUncommitted = uncommitted
+	d.queryResult = nil
+	d.queryPlan = plan
+	d.queryAt = time.Now()
+	d.updateTabs()
+	d.switchToTab(d.tr.TabQuery)
+	d.ScrollableTrait.SetOriginY(0)
+}
+
+// SetQueryUncommitted updates the number of uncommitted writes the Query tab
+// reports, after the console's transaction was committed or rolled back
+func (d *DetailsContext) SetQueryUncommitted(uncommitted int) {
+	d.queryUncommitted = uncommitted
+}
+
+// buildQueryContent builds the content for the Query tab: the statement,
+// then its rows or its plan
+func (d *DetailsContext) buildQueryContent() string {
+	var content strings.Builder
+	at := d.queryAt.Format("15:04:05")
+	if d.queryPlan != nil {
+		explain := "EXPLAIN"
+		if d.queryPlan.Analyzed {
+			explain = "EXPLAIN ANALYZE"
+		}
+		content.WriteString(style.YellowBold(fmt.Sprintf(d.tr.QueryPlanTitle, explain, at)) + "\n")
+	} else {
+		content.WriteString(style.YellowBold(fmt.Sprintf(d.tr.QueryResultTitle, at)) + "\n")
+	}
+	for _, line := range strings.Split(d.queryText, "\n") {
+		content.WriteString(style.Gray(line) + "\n")
+	}
+	if d.queryUncommitted > 0 {
+		content.WriteString(style.OrangeBold(fmt.Sprintf(d.tr.QueryUncommitted, d.queryUncommitted)) + "\n")
+	}
+	content.WriteString("\n")
+
+	if d.queryPlan != nil {
+		content.WriteString(d.buildQueryPlan())
+		return content.String()
+	}
+
+	result := d.queryResult
+	if result.Columns == nil {
+		content.WriteString(style.Green(fmt.Sprintf(d.tr.QueryRowsAffected, result.RowsAffected)))
+		return content.String()
+	}
+	if len(result.Rows) == 0 {
+		content.WriteString(style.Gray(d.tr.QueryNoRows))
+		return content.String()
+	}
+	content.WriteString(buildGrid(result.Columns, result.Rows) + "\n")
+	if result.More {
+		content.WriteString(style.Gray(fmt.Sprintf(d.tr.QueryRowsMore, len(result.Rows))))
+	} else {
+		content.WriteString(style.Gray(fmt.Sprintf(d.tr.QueryRowCount, len(result.Rows))))
+	}
+	return content.String()
+}
+
+// buildQueryPlan renders a plan as an indented tree, full table scans in
+// yellow and index scans in green, followed by the timings and a summary of
+// the indexes used
+func (d *DetailsContext) buildQueryPlan() string {
+	var content strings.Builder
+	plan := d.queryPlan
+
+	plan.Walk(func(node *database.PlanNode, depth int) {
+		indent := strings.Repeat("    ", depth)
+		operation := style.Bold(node.Operation)
+		switch node.Scan {
+		case database.ScanFull:
+			operation = style.YellowBold(node.Operation)
+		case database.ScanIndex:
+			operation = style.GreenBold(node.Operation)
+		}
+		content.WriteString(indent + "→ " + operation)
+		if node.Estimate != "" {
+			content.WriteString("  " + style.Gray("("+node.Estimate+")"))
+		}
+		content.WriteString("\n")
+		if node.Actual != "" {
+			content.WriteString(indent + "    " + style.Cyan("actual "+node.Actual) + "\n")
+		}
+		for _, detail := range node.Details {
+			content.WriteString(indent + "    " + style.Gray(detail) + "\n")
+		}
+	})
+	content.WriteString("\n")
+
+	if plan.PlanningTime != "" {
+		content.WriteString(fmt.Sprintf(d.tr.QueryPlanPlanning, plan.PlanningTime) + "\n")
+	}
+	if plan.ExecutionTime != "" {
+		content.WriteString(fmt.Sprintf(d.tr.QueryPlanExecution, plan.ExecutionTime) + "\n")
+	}
+	if indexes := plan.Indexes(); len(indexes) > 0 {
+		content.WriteString(style.Green(fmt.Sprintf(d.tr.QueryPlanIndexes, strings.Join(indexes, ", "))) + "\n")
+	} else {
+		content.WriteString(style.Gray(d.tr.QueryPlanNoIndexes) + "\n")
+	}
+	if scans := plan.FullScans(); len(scans) > 0 {
+		content.WriteString(style.Yellow(fmt.Sprintf(d.tr.QueryPlanFullScans, strings.Join(scans, ", "))) + "\n")
+	}
+	if plan.Analyzed {
+		content.WriteString(style.Gray(d.tr.QueryPlanRolledBack) + "\n")
+	}
+	return content.String()
+}
+
+// gridCellWidth caps the width of a column in the Data and Query tabs
+const gridCellWidth = 32
+
+// buildGrid lays out rows under their column names, one line per row
+func buildGrid(columns []string, rows [][]string) string {
+	cell := func(value string) string {
+		value = strings.ReplaceAll(value, "\n", " ")
+		if uniseg.StringWidth(value) <= gridCellWidth {
+			return value
+		}
+		var b strings.Builder
+		width := 0
+		for _, r := range value {
+			w := uniseg.StringWidth(string(r))
+			if width+w > gridCellWidth-1 {
+				break
+			}
+			b.WriteRune(r)
+			width += w
+		}
+		return b.String() + "…"
+	}
+
+	widths := make([]int, len(columns))
+	for i, column := range columns {
+		widths[i] = uniseg.StringWidth(cell(column))
+	}
+	for _, row := range rows {
+		for i, value := range row {
+			widths[i] = max(widths[i], uniseg.StringWidth(cell(value)))
+		}
+	}
+
+	// The last column isn't padded, so lines have no trailing spaces
+	pad := func(value string, i int) string {
+		if i == len(widths)-1 {
+			return value
+		}
+		return value + strings.Repeat(" ", widths[i]-uniseg.StringWidth(value))
+	}
+
+	var content strings.Builder
+	var header, rule []string
+	for i, column := range columns {
+		header = append(header, style.Bold(pad(cell(column), i)))
+		rule = append(rule, strings.Repeat("─", widths[i]))
+	}
+	content.WriteString(strings.Join(header, " │ ") + "\n")
+	content.WriteString(style.Gray(strings.Join(rule, "─┼─")) + "\n")
+
+	for _, row := range rows {
+		var cells []string
+		for i, value := range row {
+			text := pad(cell(value), i)
+			if value == "NULL" {
+				text = style.Gray(text)
+			}
+			cells = append(cells, text)
+		}
+		content.WriteString(strings.Join(cells, " │ ") + "\n")
+	}
+	return content.String()
+}
+
+// LoadSchema (re)reads the file shown in the Schema tab, schema.prisma by default.
+func (d *DetailsContext) LoadSchema() {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return
+	}
+	d.backslashEscapes = prisma.ProjectFormatSQLOpts(cwd).BackslashEscapes
+
+	path := d.schemaPath
+	if path == "" {
+		path = prisma.SchemaPath(cwd)
+	}
+
+	d.loadSchemaFile(path)
+	d.updateTabs()
+}
+
+// loadSchemaFile reads a schema file into the Schema tab, keeping the cursor in range
+func (d *DetailsContext) loadSchemaFile(path string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		d.schemaLines = nil
+		return false
+	}
+
+	d.schemaPath = path
+	d.schemaLines = strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	d.schemaCursor = max(min(d.schemaCursor, len(d.schemaLines)-1), 0)
+	return true
+}
+
+// ShowSchemaLine opens path (schema.prisma if empty) in the Schema tab with the
+// cursor on the given 1-based line. Returns false if the file can't be read.
+func (d *DetailsContext) ShowSchemaLine(path string, line int) bool {
+	if path == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return false
+		}
+		path = prisma.SchemaPath(cwd)
+	}
+
+	if !d.loadSchemaFile(path) {
+		return false
+	}
+	d.updateTabs()
+
+	d.schemaBackStack = nil
+	d.schemaCursor = max(min(line-1, len(d.schemaLines)-1), 0)
+	d.switchToTab(d.tr.TabSchema)
+	d.ScrollableTrait.SetOriginY(max(d.schemaCursor-schemaJumpContext, 0))
+	d.schemaFollowCursor = true
+	return true
+}
+
+// GoToLine scrolls the current tab so that the given line (as numbered in its
+// gutter) is near the top; in the Schema tab the cursor moves to it. Lines are
+// looked up in the last drawn content, so the first numbered block (e.g. the
+// migration.sql before down.sql) wins. Returns false if no line has that number.
+func (d *DetailsContext) GoToLine(line int) bool {
+	if line < 1 {
+		return false
+	}
+
+	if d.IsSchemaTabActive() {
+		if line > len(d.schemaLines) {
+			return false
+		}
+		d.schemaCursor = line - 1
+		d.ScrollableTrait.SetOriginY(max(d.schemaCursor-schemaJumpContext, 0))
+		d.schemaFollowCursor = true
+		return true
+	}
+
+	v := d.GetView()
+	if v == nil {
+		return false
+	}
+	for i, viewLine := range v.ViewBufferLines() {
+		if m := gutterLineRegex.FindStringSubmatch(viewLine); m != nil && m[1] == strconv.Itoa(line) {
+			d.ScrollableTrait.SetOriginY(max(i-schemaJumpContext, 0))
+			return true
+		}
+	}
+	return false
+}
+
+// JumpToSchemaDefinition moves the Schema tab's cursor from a field to the
+// declaration of its type (the related model, enum or composite type).
+// The current position is pushed so SchemaBack can return to it.
+func (d *DetailsContext) JumpToSchemaDefinition() bool {
+	if !d.IsSchemaTabActive() || d.schemaCursor >= len(d.schemaLines) {
+		return false
+	}
+
+	fieldType := prisma.SchemaFieldType(d.schemaLines[d.schemaCursor])
+	if fieldType == "" {
+		return false
+	}
+	block, ok := prisma.FindSchemaBlock(d.schemaLines, fieldType)
+	if !ok {
+		return false
+	}
+
+	d.schemaBackStack = append(d.schemaBackStack, schemaPosition{
+		path:    d.schemaPath,
+		cursor:  d.schemaCursor,
+		originY: d.ScrollableTrait.GetOriginY(),
+	})
+	d.schemaCursor = block.Line
+	d.ScrollableTrait.SetOriginY(max(block.Line-schemaJumpContext, 0))
+	d.schemaFollowCursor = true
+	return true
+}
+
+// SchemaBack returns to the position before the last JumpToSchemaDefinition.
+// Returns false if there is nothing to go back to.
+func (d *DetailsContext) SchemaBack() bool {
+	if !d.IsSchemaTabActive() || len(d.schemaBackStack) == 0 {
+		return false
+	}
+
+	pos := d.schemaBackStack[len(d.schemaBackStack)-1]
+	d.schemaBackStack = d.schemaBackStack[:len(d.schemaBackStack)-1]
+
+	if pos.path != d.schemaPath && !d.loadSchemaFile(pos.path) {
+		return false
+	}
+	d.schemaCursor = max(min(pos.cursor, len(d.schemaLines)-1), 0)
+	d.ScrollableTrait.SetOriginY(pos.originY)
+	d.schemaFollowCursor = true
+	return true
+}
+
+// SelectedSchemaBlock returns the model, view, enum or composite type the
+// Schema tab's cursor is in, with its database name (@@map or schema name).
+func (d *DetailsContext) SelectedSchemaBlock() (prisma.SchemaBlock, string, bool) {
+	if !d.IsSchemaTabActive() {
+		return prisma.SchemaBlock{}, "", false
+	}
+	block, ok := prisma.SchemaBlockAt(d.schemaLines, d.schemaCursor)
+	if !ok {
+		return prisma.SchemaBlock{}, "", false
+	}
+	return block, block.DatabaseName(d.schemaLines), true
+}
+
+// SchemaLines returns a copy of the lines shown in the Schema tab.
+func (d *DetailsContext) SchemaLines() []string {
+	return append([]string(nil), d.schemaLines...)
+}
+
+// CompareModelKeys compares the indexes and foreign keys lines declare for the
+// model block with those of its table in the database. It connects to the
+// database, so call it off the UI thread.
+func (d *DetailsContext) CompareModelKeys(lines []string, block prisma.SchemaBlock) (*drift.Comparison, error) {
+	table := block.DatabaseName(lines)
+
+	if d.demo {
+		// The demo's fake database has what the schema declares, and the hotfix index
+		declared := drift.SchemaKeys(lines, block, "postgresql")
+		return drift.Compare(table, declared, demo.DatabaseKeys(table, declared)), nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	ds, err := prisma.GetDatasource(cwd)
+	if err != nil {
+		return nil, err
+	}
+	if ds.URL == "" {
+		return nil, fmt.Errorf(d.tr.ModalMsgDriftNoURL, ds.EnvVarName)
+	}
+
+	declared := drift.SchemaKeys(lines, block, ds.Provider)
+	actual, err := drift.DatabaseKeys(ds.Provider, ds.URL, table)
+	if err != nil {
+		return nil, err
+	}
+	return drift.Compare(table, declared, actual), nil
+}
+
+// IsSchemaTabActive reports whether the Schema tab is shown.
+func (d *DetailsContext) IsSchemaTabActive() bool {
+	return d.TabbedTrait.GetCurrentTab() == d.tr.TabSchema
+}
+
+// buildSchemaContent renders the schema with line numbers and the cursor line highlighted.
+func (d *DetailsContext) buildSchemaContent() string {
+	var content strings.Builder
+	width := gutterWidth(len(d.schemaLines))
+	for i, line := range d.schemaLines {
+		if i == d.schemaCursor {
+			content.WriteString(style.YellowBold(fmt.Sprintf("%*d ▶", width, i+1)) + " " + style.Bold(line) + "\n")
+		} else {
+			content.WriteString(gutter(i+1, width) + " " + line + "\n")
+		}
+	}
+	return content.String()
+}
+
+// followSchemaCursor scrolls the cursor into view after it moved.
+func (d *DetailsContext) followSchemaCursor(v *gocui.View) {
+	if !d.schemaFollowCursor {
+		return
+	}
+	d.schemaFollowCursor = false
+
+	_, h := v.Size()
+	innerHeight := max(h-2, 1) // Exclude frame, as ScrollableTrait does
+
+	originY := d.ScrollableTrait.GetOriginY()
+	if d.schemaCursor < originY {
+		originY = d.schemaCursor
+	} else if d.schemaCursor >= originY+innerHeight {
+		originY = d.schemaCursor - innerHeight + 1
+	}
+	d.ScrollableTrait.SetOriginY(originY)
+}
+
+// moveSchemaCursor moves the Schema tab's cursor by delta lines.
+func (d *DetailsContext) moveSchemaCursor(delta int) {
+	d.schemaCursor = max(min(d.schemaCursor+delta, len(d.schemaLines)-1), 0)
+	d.schemaFollowCursor = true
+}
+
+// ScrollUp moves the cursor in the Schema tab and scrolls the other tabs.
+func (d *DetailsContext) ScrollUp() {
+	if d.IsSchemaTabActive() {
+		d.moveSchemaCursor(-1)
+		return
+	}
+	d.ScrollableTrait.ScrollUp()
+}
+
+// ScrollDown moves the cursor in the Schema tab and scrolls the other tabs.
+func (d *DetailsContext) ScrollDown() {
+	if d.IsSchemaTabActive() {
+		d.moveSchemaCursor(1)
+		return
+	}
+	d.ScrollableTrait.ScrollDown()
+}
+
+// ScrollToTop also moves the Schema tab's cursor to the first line.
+func (d *DetailsContext) ScrollToTop() {
+	if d.IsSchemaTabActive() {
+		d.schemaCursor = 0
+	}
+	d.ScrollableTrait.ScrollToTop()
+}
+
+// ScrollToBottom also moves the Schema tab's cursor to the last line.
+func (d *DetailsContext) ScrollToBottom() {
+	if d.IsSchemaTabActive() {
+		d.schemaCursor = max(len(d.schemaLines)-1, 0)
+	}
+	d.ScrollableTrait.ScrollToBottom()
+}
+
+// switchToTab activates the named tab with scroll state save/restore.
+func (d *DetailsContext) switchToTab(name string) {
+	for i, tab := range d.TabbedTrait.GetTabs() {
+		if tab == name {
+			if i != d.TabbedTrait.GetCurrentTabIdx() {
+				d.TabbedTrait.SaveTabOriginY(d.ScrollableTrait.GetOriginY())
+				d.TabbedTrait.SetCurrentTabIdx(i)
+				d.ScrollableTrait.SetOriginY(d.TabbedTrait.RestoreTabOriginY())
+			}
+			return
+		}
+	}
 }
 
 // buildActionNeededContent builds the content for the Action-Needed tab.
@@ -467,7 +1617,27 @@ func (d *DetailsContext) buildActionNeededContent() string {
 		}
 	}
 
-	totalCount := emptyCount + mismatchCount + validationErrorCount
+	trackingCount := 0
+	if d.migrationTableMissing {
+		trackingCount = 1
+	}
+
+	envConflictCount := 0
+	if len(d.envConflicts) > 0 {
+		envConflictCount = 1
+	}
+
+	pooledCount := 0
+	if d.pooledMigrations != nil {
+		pooledCount = 1
+	}
+
+	mergeCount := 0
+	if d.mergePlan != nil {
+		mergeCount = len(d.mergePlan.Fixes)
+	}
+
+	totalCount := emptyCount + mismatchCount + validationErrorCount + trackingCount + envConflictCount + pooledCount + mergeCount
 
 	if totalCount == 0 {
 		return d.tr.ActionNeededNoIssuesMessage
@@ -482,6 +1652,89 @@ func (d *DetailsContext) buildActionNeededContent() string {
 	}
 	content.WriteString(")\n\n")
 
+	// Missing Migration Table Section
+	if d.migrationTableMissing {
+		content.WriteString(strings.Repeat("━", 40) + "\n")
+		content.WriteString(style.Yellow(d.tr.ActionNeededNoMigrationTableHeader) + "\n")
+		content.WriteString(strings.Repeat("━", 40) + "\n\n")
+
+		content.WriteString(d.tr.ActionNeededNoMigrationTableDesc)
+
+		content.WriteString(d.tr.ActionNeededRecommendedLabel)
+		content.WriteString(d.tr.ActionNeededInitializeTracking)
+	}
+
+	// Conflicting Database URL Section
+	if envConflictCount > 0 {
+		content.WriteString(strings.Repeat("━", 40) + "\n")
+		content.WriteString(style.Red(fmt.Sprintf(d.tr.ActionNeededEnvConflictHeader, d.envConflictVar)) + "\n")
+		content.WriteString(strings.Repeat("━", 40) + "\n\n")
+
+		content.WriteString(fmt.Sprintf(d.tr.ActionNeededEnvConflictDesc, d.envConflictVar))
+
+		cwd, _ := os.Getwd()
+		content.WriteString(d.tr.ActionNeededEnvConflictSourcesLabel)
+		for i, def := range d.envConflicts {
+			source := d.tr.WorkspaceURLSourceProcessEnv
+			if def.Source != "" {
+				source = def.Source
+				if rel, err := filepath.Rel(cwd, def.Source); err == nil {
+					source = rel
+				}
+			}
+			line := fmt.Sprintf("  • %s: %s", style.Yellow(source), prisma.MaskPassword(def.Value))
+			if i == 0 {
+				line += style.Green(d.tr.ActionNeededEnvConflictInUse)
+			}
+			content.WriteString(line + "\n")
+		}
+
+		content.WriteString("\n" + d.tr.ActionNeededRecommendedLabel)
+		content.WriteString(d.tr.ActionNeededEnvConflictAlign)
+	}
+
+	// Migrations Through a Pooler Section
+	if pooled := d.pooledMigrations; pooled != nil {
+		source := pooled.Setting
+		if pooled.EnvVar != "" {
+			source = fmt.Sprintf("%s (%s)", pooled.Setting, pooled.EnvVar)
+		}
+
+		content.WriteString(strings.Repeat("━", 40) + "\n")
+		content.WriteString(style.Orange(d.tr.ActionNeededPooledHeader) + "\n")
+		content.WriteString(strings.Repeat("━", 40) + "\n\n")
+
+		content.WriteString(fmt.Sprintf(d.tr.ActionNeededPooledDesc, source, pooled.Pooler))
+
+		content.WriteString(d.tr.ActionNeededRecommendedLabel)
+		if pooled.Setting == "url" {
+			content.WriteString(d.tr.ActionNeededPooledAddDirectURL)
+		} else {
+			content.WriteString(fmt.Sprintf(d.tr.ActionNeededPooledFixDirectURL, source))
+		}
+		if pooled.DirectURL != "" {
+			content.WriteString(fmt.Sprintf(d.tr.ActionNeededPooledUnpooled, prisma.MaskPassword(pooled.DirectURL)))
+		}
+		content.WriteString("\n")
+	}
+
+	// Migrations Duplicated or Reordered by a Merge Section
+	if mergeCount > 0 {
+		content.WriteString(strings.Repeat("━", 40) + "\n")
+		content.WriteString(fmt.Sprintf("%s (%d)\n", style.Orange(d.tr.ActionNeededMergeHeader), mergeCount))
+		content.WriteString(strings.Repeat("━", 40) + "\n\n")
+
+		content.WriteString(d.tr.ActionNeededMergeDesc)
+
+		content.WriteString(d.tr.ActionNeededAffectedLabel)
+		for _, fix := range d.mergePlan.Fixes {
+			content.WriteString(fmt.Sprintf("  • %s\n    %s\n", style.Orange(DescribeMergeFix(fix)), style.Gray(MergeFixReason(d.tr, fix))))
+		}
+
+		content.WriteString("\n" + d.tr.ActionNeededRecommendedLabel)
+		content.WriteString(d.tr.ActionNeededMergeFix)
+	}
+
 	// Empty Migrations Section
 	if emptyCount > 0 {
 		content.WriteString(strings.Repeat("━", 40) + "\n")
@@ -631,6 +1884,50 @@ func (d *DetailsContext) ScrollDownByWheel() {
 	d.ScrollableTrait.ScrollDownByWheel()
 }
 
+// NumberLines prefixes each line with a gray line number.
+func NumberLines(text string) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	width := gutterWidth(len(lines))
+	for i, line := range lines {
+		lines[i] = gutter(i+1, width) + " " + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// minGutterWidth is the narrowest line number column, so short files line up with each other
+const minGutterWidth = 4
+
+// gutterWidth returns the width of the line number column for a text of lineCount lines.
+func gutterWidth(lineCount int) int {
+	return max(len(strconv.Itoa(lineCount)), minGutterWidth)
+}
+
+// gutter renders a line number in the gray line number column.
+func gutter(line, width int) string {
+	return style.Gray(fmt.Sprintf("%*d │", width, line))
+}
+
+// gutterLineRegex matches the line number at the start of a rendered view line
+var gutterLineRegex = regexp.MustCompile(`^\s*(\d+) [│▶]`)
+
+// ColorizeDiff colours a unified diff: removals red, additions green, hunk headers cyan.
+func ColorizeDiff(unified string) string {
+	lines := strings.Split(strings.TrimRight(unified, "\n"), "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+			lines[i] = style.Bold(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = style.Cyan(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = style.Red(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = style.Green(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 // ============================================================================
 // Private helpers
 // ============================================================================
@@ -677,17 +1974,41 @@ func detailsHighlightSQL(code string) string {
 		return code // Return original if highlighting fails
 	}
 
-	// Add line numbers
-	highlighted := buf.String()
-	lines := strings.Split(highlighted, "\n")
+	return detailsNumberLines(buf.String())
+}
+
+// sqlKeywordRegex matches the SQL keywords coloured without chroma
+var sqlKeywordRegex = regexp.MustCompile(`(?i)\b(?:ADD|ALTER|AND|AS|BEGIN|BY|CASCADE|CHECK|COLUMN|COMMIT|CONCURRENTLY|CONSTRAINT|CREATE|DEFAULT|DELETE|DROP|ENUM|EXISTS|FOREIGN|FROM|IF|INDEX|INSERT|INTO|KEY|NOT|NULL|ON|OR|PRIMARY|REFERENCES|RENAME|RESTRICT|SELECT|SET|TABLE|TO|TYPE|UNIQUE|UPDATE|USING|VALUES|VIEW|WHERE)\b`)
+
+// detailsHighlightSQLKeywords colours SQL keywords and line comments only.
+// String literals and quoted identifiers are not recognised.
+func detailsHighlightSQLKeywords(code string) string {
+	lines := strings.Split(code, "\n")
+	for i, line := range lines {
+		text, comment := line, ""
+		if idx := strings.Index(line, "--"); idx != -1 {
+			text, comment = line[:idx], line[idx:]
+		}
+		text = sqlKeywordRegex.ReplaceAllStringFunc(text, style.Magenta)
+		if comment != "" {
+			comment = style.Gray(comment)
+		}
+		lines[i] = text + comment
+	}
+	return strings.Join(lines, "\n")
+}
+
+// detailsNumberLines prefixes every line with its line number.
+func detailsNumberLines(text string) string {
+	lines := strings.Split(text, "\n")
+	width := gutterWidth(len(lines))
 	var result strings.Builder
 
 	for i, line := range lines {
 		if i > 0 {
 			result.WriteString("\n")
 		}
-		// Line number in gray color, right-aligned to 4 digits
-		result.WriteString(style.Gray(fmt.Sprintf("%4d │", i+1)) + " " + line)
+		result.WriteString(gutter(i+1, width) + " " + line)
 	}
 
 	return result.String()
@@ -737,3 +2058,23 @@ func detailsGetRelativePath(absPath string) string {
 
 	return relPath
 }
+
+// DescribeMergeFix shows what a merge fix does to the migration's folder:
+// "old → new" for a rename, "✗ name" for a removal
+func DescribeMergeFix(fix prisma.MergeFix) string {
+	if fix.NewName == "" {
+		return "✗ " + fix.Name
+	}
+	return fix.Name + " → " + fix.NewName
+}
+
+// MergeFixReason explains why a merge fix is needed
+func MergeFixReason(tr *i18n.TranslationSet, fix prisma.MergeFix) string {
+	switch fix.Reason {
+	case prisma.MergeDuplicateSQL:
+		return fmt.Sprintf(tr.MergeFixDuplicateSQL, fix.DuplicateOf)
+	case prisma.MergeDuplicateTimestamp:
+		return fmt.Sprintf(tr.MergeFixDuplicateTimestamp, fix.DuplicateOf)
+	}
+	return tr.MergeFixOutOfOrder
+}
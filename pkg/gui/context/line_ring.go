@@ -0,0 +1,42 @@
+package context
+
+// lineRing keeps the most recent lines of text, dropping the oldest once it
+// holds maxLines of them. A maxLines of 0 keeps every line.
+type lineRing struct {
+	lines    []string
+	start    int // Index of the oldest line once the ring is full
+	maxLines int
+	dropped  int // Lines dropped so far
+}
+
+func newLineRing(maxLines int) *lineRing {
+	return &lineRing{maxLines: max(maxLines, 0)}
+}
+
+// Push appends a line, dropping the oldest one if the ring is full.
+func (r *lineRing) Push(line string) {
+	if r.maxLines == 0 || len(r.lines) < r.maxLines {
+		r.lines = append(r.lines, line)
+		return
+	}
+	r.lines[r.start] = line
+	r.start = (r.start + 1) % r.maxLines
+	r.dropped++
+}
+
+// Len returns the number of lines held.
+func (r *lineRing) Len() int {
+	return len(r.lines)
+}
+
+// Dropped returns how many lines have been dropped to make room.
+func (r *lineRing) Dropped() int {
+	return r.dropped
+}
+
+// Lines returns the lines held, oldest first.
+func (r *lineRing) Lines() []string {
+	ordered := make([]string, 0, len(r.lines))
+	ordered = append(ordered, r.lines[r.start:]...)
+	return append(ordered, r.lines[:r.start]...)
+}
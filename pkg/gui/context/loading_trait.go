@@ -0,0 +1,116 @@
+package context
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dokadev/lazyprisma/pkg/gui/style"
+	"github.com/dokadev/lazyprisma/pkg/i18n"
+	"github.com/jesseduffield/gocui"
+)
+
+// loadingPlaceholderDelay is how long a reloading panel keeps its previous
+// content before the placeholder replaces it, so quick refreshes don't flicker
+const loadingPlaceholderDelay = 300 * time.Millisecond
+
+// StaleThresholds are the data ages at which a panel's footer turns amber
+// (Warn) and red (Alert); zero never colours it
+type StaleThresholds struct {
+	Warn  time.Duration
+	Alert time.Duration
+}
+
+// LoadingTrait tracks the background reloads of a panel. While one runs, the
+// panel shows what it is waiting for instead of stale content; otherwise its
+// footer says how long ago the data was loaded.
+type LoadingTrait struct {
+	mu        sync.Mutex
+	message   string    // e.g. "Loading migrations..." ("" = not loading)
+	since     time.Time // When the reload started
+	loadedAt  time.Time // When the data was last loaded
+	staleness StaleThresholds
+}
+
+// newLoadingTrait returns a LoadingTrait for a panel whose data has just been
+// loaded
+func newLoadingTrait(staleness StaleThresholds) *LoadingTrait {
+	return &LoadingTrait{loadedAt: time.Now(), staleness: staleness}
+}
+
+// StartLoading marks the panel as reloading, or updates the message of a
+// reload in progress
+func (self *LoadingTrait) StartLoading(message string) {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	if self.message == "" {
+		self.since = time.Now()
+	}
+	self.message = message
+}
+
+// FinishLoading marks the reload as complete and the data as fresh
+func (self *LoadingTrait) FinishLoading() {
+	self.mu.Lock()
+	defer self.mu.Unlock()
+
+	self.message = ""
+	self.loadedAt = time.Now()
+}
+
+// renderLoadingPlaceholder writes the loading message to v through the
+// panel's content once the reload has taken longer than
+// loadingPlaceholderDelay, and reports whether it did. The panel's own scroll
+// position is kept for when the content returns.
+func (self *LoadingTrait) renderLoadingPlaceholder(v *gocui.View, content *ContentTrait) bool {
+	self.mu.Lock()
+	message, since := self.message, self.since
+	self.mu.Unlock()
+
+	if message == "" || time.Since(since) < loadingPlaceholderDelay {
+		return false
+	}
+
+	content.renderContent(v, style.Gray(message)+"\n")
+	v.SetOrigin(0, 0)
+	return true
+}
+
+// applyUpdatedFooter appends the age of the data to the view's footer and,
+// past the staleness thresholds, colours the title and footer amber or red.
+// Call it after the focus colours are set.
+func (self *LoadingTrait) applyUpdatedFooter(v *gocui.View, tr *i18n.TranslationSet) {
+	self.mu.Lock()
+	age := time.Since(self.loadedAt)
+	self.mu.Unlock()
+
+	updated := fmt.Sprintf(tr.PanelUpdatedAgo, formatAge(age))
+	if age < time.Minute {
+		updated = tr.PanelUpdatedJustNow
+	}
+	if v.Footer != "" {
+		v.Footer += " · "
+	}
+	v.Footer += updated
+
+	switch {
+	case self.staleness.Alert > 0 && age >= self.staleness.Alert:
+		v.TitleColor = style.OutdatedTitleColor
+	case self.staleness.Warn > 0 && age >= self.staleness.Warn:
+		v.TitleColor = style.StaleTitleColor
+	}
+}
+
+// formatAge formats a duration of at least a minute compactly, e.g. "4m",
+// "2h" or "3d"
+func formatAge(age time.Duration) string {
+	switch {
+	case age < time.Hour:
+		return fmt.Sprintf("%dm", int(age/time.Minute))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(age/time.Hour))
+	default:
+		return fmt.Sprintf("%dd", int(age/(24*time.Hour)))
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/dokadev/lazyprisma/pkg/database"
+	"github.com/dokadev/lazyprisma/pkg/demo"
+	"github.com/dokadev/lazyprisma/pkg/drift"
 	"github.com/dokadev/lazyprisma/pkg/gui/style"
 	"github.com/dokadev/lazyprisma/pkg/gui/types"
 	"github.com/dokadev/lazyprisma/pkg/i18n"
@@ -19,17 +21,25 @@ type MigrationsContext struct {
 	*SimpleContext
 	*ScrollableTrait
 	*TabbedTrait
+	*LoadingTrait
+	*ContentTrait
 
 	g  *gocui.Gui
 	tr *i18n.TranslationSet
 
 	// Data
 	category    prisma.MigrationCategory // Categorised migrations
+	impactIndex *prisma.ImpactIndex      // Table -> migrations index (built on load)
 	items       []string                 // Current tab's rendered display strings
 	selected    int                      // Selected item index in current tab
 	dbClient    *database.Client         // Database connection
+	extensions  []string                 // Installed PostgreSQL extensions
+	extsRead    bool                     // extensions could be listed
 	dbConnected bool                     // True if connected to database
 	tableExists bool                     // True if _prisma_migrations table exists
+	edge        *prisma.EdgeDatabase     // Database migrations are applied to with its own CLI (nil = none)
+	mergePlan   *prisma.MergeFixPlan     // Repairs for migrations a git merge duplicated or reordered (nil = none)
+	demo        *demo.State              // Fake database in demo mode (nil otherwise)
 
 	// Per-tab state preservation
 	tabSelectedMap map[string]int // Last selected index per tab (keyed by tab name)
@@ -37,16 +47,21 @@ type MigrationsContext struct {
 
 	// Callbacks (replace direct panel/app references)
 	onSelectionChanged func(migration *prisma.Migration, tabName string)
+	onFocus            func()
 	hasActiveModal     func() bool
 	onPanelClick       func(viewID string)
 }
 
 var _ types.Context = &MigrationsContext{}
+var _ types.ICopyableContext = &MigrationsContext{}
 
 type MigrationsContextOpts struct {
 	Gui      *gocui.Gui
 	Tr       *i18n.TranslationSet
 	ViewName string
+	Demo     *demo.State // Read migration history from the demo's fake database
+
+	Staleness StaleThresholds // Footer turns amber/red when the migrations are this old
 }
 
 func NewMigrationsContext(opts MigrationsContextOpts) *MigrationsContext {
@@ -62,12 +77,15 @@ func NewMigrationsContext(opts MigrationsContextOpts) *MigrationsContext {
 	mc := &MigrationsContext{
 		SimpleContext:  simpleCtx,
 		ScrollableTrait: &ScrollableTrait{},
+		LoadingTrait:   newLoadingTrait(opts.Staleness),
+		ContentTrait:   &ContentTrait{},
 		g:              opts.Gui,
 		tr:             opts.Tr,
 		items:          []string{},
 		selected:       0,
 		tabSelectedMap: make(map[string]int),
 		tabOriginYMap:  make(map[string]int),
+		demo:           opts.Demo,
 	}
 
 	// Initialise TabbedTrait with empty tabs (loadMigrations will populate)
@@ -88,6 +106,12 @@ func (m *MigrationsContext) SetOnSelectionChanged(cb func(*prisma.Migration, str
 	m.onSelectionChanged = cb
 }
 
+// SetOnFocus registers a callback invoked whenever the panel gains focus,
+// e.g. to show the selected migration again after another panel's selection.
+func (m *MigrationsContext) SetOnFocus(cb func()) {
+	m.onFocus = cb
+}
+
 // SetModalCallbacks registers callbacks for modal and panel-click checks
 // (replaces the old SetApp coupling).
 func (m *MigrationsContext) SetModalCallbacks(hasActiveModal func() bool, onPanelClick func(string)) {
@@ -104,6 +128,14 @@ func (m *MigrationsContext) ID() string {
 	return m.GetViewName()
 }
 
+// OnFocus focuses the panel and invokes the onFocus callback if set.
+func (m *MigrationsContext) OnFocus() {
+	m.BaseContext.OnFocus()
+	if m.onFocus != nil {
+		m.onFocus()
+	}
+}
+
 // GetSelectedMigration returns the currently selected migration, or nil.
 func (m *MigrationsContext) GetSelectedMigration() *prisma.Migration {
 	tabName := m.TabbedTrait.GetCurrentTab()
@@ -119,6 +151,16 @@ func (m *MigrationsContext) GetSelectedMigration() *prisma.Migration {
 	return nil
 }
 
+// CopyText returns the current tab's list as drawn, including the items
+// scrolled out of view.
+func (m *MigrationsContext) CopyText() string {
+	lines := make([]string, len(m.items))
+	for i, item := range m.items {
+		lines[i] = style.Strip(item)
+	}
+	return plainLines(lines)
+}
+
 // GetCurrentTabName returns the name of the active tab.
 func (m *MigrationsContext) GetCurrentTabName() string {
 	return m.TabbedTrait.GetCurrentTab()
@@ -129,11 +171,135 @@ func (m *MigrationsContext) GetCategory() prisma.MigrationCategory {
 	return m.category
 }
 
+// GetImpactIndex returns the table impact index built from local migrations.
+func (m *MigrationsContext) GetImpactIndex() *prisma.ImpactIndex {
+	return m.impactIndex
+}
+
+// EdgeDatabase returns the edge database migrations are applied to with its
+// own CLI, or nil
+func (m *MigrationsContext) EdgeDatabase() *prisma.EdgeDatabase {
+	return m.edge
+}
+
+// MergeFixPlan returns how to repair the migrations a git merge duplicated or
+// reordered, or nil. It needs the applied migrations, so it is nil while the
+// database is not connected.
+func (m *MigrationsContext) MergeFixPlan() *prisma.MergeFixPlan {
+	return m.mergePlan
+}
+
 // IsDBConnected returns whether the database connection is active.
 func (m *MigrationsContext) IsDBConnected() bool {
 	return m.dbConnected
 }
 
+// InstalledExtensions returns the extensions installed in the database, and
+// false if they couldn't be listed (not PostgreSQL, or not connected)
+func (m *MigrationsContext) InstalledExtensions() ([]string, bool) {
+	return m.extensions, m.extsRead
+}
+
+// DeadColumns returns the columns of the database's tables that no field of
+// the schema in lines maps to, and the provider. It connects to the database,
+// so call it off the UI thread.
+func (m *MigrationsContext) DeadColumns(lines []string) ([]drift.DeadColumn, string, error) {
+	declared := drift.SchemaColumns(lines)
+
+	if m.demo != nil {
+		return drift.DeadColumns(declared, demo.DatabaseColumns(declared)), "postgresql", nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, "", err
+	}
+	ds, err := prisma.GetDatasource(cwd)
+	if err != nil {
+		return nil, "", err
+	}
+	if ds.URL == "" {
+		return nil, ds.Provider, fmt.Errorf(m.tr.ModalMsgDriftNoURL, ds.EnvVarName)
+	}
+
+	actual, err := drift.DatabaseColumns(ds.Provider, ds.URL)
+	if err != nil {
+		return nil, ds.Provider, err
+	}
+	return drift.DeadColumns(declared, actual), ds.Provider, nil
+}
+
+// PeekTable reads the first limit rows of table and its row count from the
+// database. It connects to the database, so call it off the UI thread.
+func (m *MigrationsContext) PeekTable(table string, limit int) (*database.TablePeek, error) {
+	if m.demo != nil {
+		return demo.PeekTable(table, limit)
+	}
+	ds, err := m.queryDatasource()
+	if err != nil {
+		return nil, err
+	}
+	return database.PeekTable(ds.Provider, ds.URL, table, limit)
+}
+
+// OpenSession connects to the database and starts the transaction the SQL
+// console runs statements in. Call it off the UI thread.
+func (m *MigrationsContext) OpenSession() (database.Session, error) {
+	if m.demo != nil {
+		return demo.OpenSession(), nil
+	}
+	ds, err := m.queryDatasource()
+	if err != nil {
+		return nil, err
+	}
+	return database.OpenSession(ds.Provider, ds.URL)
+}
+
+// MigrationHistory reads _prisma_migrations from the database again, rather
+// than returning what the panel loaded. It connects to the database, so call
+// it off the UI thread.
+func (m *MigrationsContext) MigrationHistory() ([]prisma.DBMigration, error) {
+	if m.edge != nil {
+		return nil, fmt.Errorf(m.tr.ModalMsgEdgeNoHistory, m.edge.CLI)
+	}
+	if m.demo != nil {
+		return m.demo.DBMigrations, nil
+	}
+	ds, err := m.queryDatasource()
+	if err != nil {
+		return nil, err
+	}
+	client, err := database.NewClientFromDSN(ds.Provider, ds.URL)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	return prisma.GetDBMigrations(client.DB())
+}
+
+// queryDatasource returns the project's datasource, or an error if its URL is
+// not set
+func (m *MigrationsContext) queryDatasource() (*prisma.Datasource, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	ds, err := prisma.GetDatasource(cwd)
+	if err != nil {
+		return nil, err
+	}
+	if ds.URL == "" {
+		return nil, fmt.Errorf(m.tr.ModalMsgQueryNoURL, ds.EnvVarName)
+	}
+	return ds, nil
+}
+
+// IsMigrationTableMissing returns true if the database is reachable but has no
+// _prisma_migrations table yet
+func (m *MigrationsContext) IsMigrationTableMissing() bool {
+	return m.dbConnected && !m.tableExists
+}
+
 // ---------------------------------------------------------------------------
 // Draw
 // ---------------------------------------------------------------------------
@@ -149,7 +315,6 @@ func (m *MigrationsContext) Draw(dim boxlayout.Dimensions) error {
 	m.BaseContext.SetView(v)
 	m.ScrollableTrait.SetView(v)
 
-	v.Clear()
 	v.Frame = true
 	v.FrameRunes = style.DefaultFrameRunes
 
@@ -182,21 +347,37 @@ func (m *MigrationsContext) Draw(dim boxlayout.Dimensions) error {
 		}
 	}
 
+	// Age of the migrations, coloured when they may be outdated
+	m.applyUpdatedFooter(v, m.tr)
+
 	// Enable highlight for selection
 	v.Highlight = true
 	v.SelBgColor = style.SelectionBgColor
 
-	// Render items
-	for _, item := range m.items {
-		fmt.Fprintln(v, item)
+	// Show what a background refresh is loading instead of the stale list
+	if m.renderLoadingPlaceholder(v, m.ContentTrait) {
+		v.Highlight = false
+		return nil
 	}
 
 	// Adjust origin to ensure it's within valid bounds
 	m.adjustOrigin(v)
 
+	// Render only the items scrolled into view, so a project with thousands
+	// of migrations draws as fast as a small one. The view itself is never
+	// scrolled: its first line is the item at originY.
+	originY := m.ScrollableTrait.GetOriginY()
+	_, viewHeight := v.Size()
+	end := min(originY+max(viewHeight-2, 1), len(m.items))
+	var content strings.Builder
+	for _, item := range m.items[originY:end] {
+		content.WriteString(item + "\n")
+	}
+	m.renderContent(v, content.String())
+
 	// Set cursor position to selected item
-	v.SetCursor(0, m.selected-m.ScrollableTrait.GetOriginY())
-	v.SetOrigin(0, m.ScrollableTrait.GetOriginY())
+	v.SetCursor(0, m.selected-originY)
+	v.SetOrigin(0, 0)
 
 	return nil
 }
@@ -207,7 +388,7 @@ func (m *MigrationsContext) adjustOrigin(v *gocui.View) {
 		return
 	}
 
-	contentLines := len(v.ViewBufferLines())
+	contentLines := len(m.items)
 	_, viewHeight := v.Size()
 	innerHeight := viewHeight - 2
 
@@ -234,6 +415,16 @@ func (m *MigrationsContext) buildFooter() string {
 // Selection
 // ---------------------------------------------------------------------------
 
+// GetSelectedIdx returns the index of the selected item in the current tab.
+func (m *MigrationsContext) GetSelectedIdx() int {
+	return m.selected
+}
+
+// GetItemCount returns the number of items in the current tab.
+func (m *MigrationsContext) GetItemCount() int {
+	return len(m.items)
+}
+
 // SelectNext moves the selection down by one.
 func (m *MigrationsContext) SelectNext() {
 	if len(m.items) == 0 {
@@ -276,6 +467,40 @@ func (m *MigrationsContext) SelectPrev() {
 	}
 }
 
+// SelectMigrationByName switches to the tab containing the named migration and
+// selects it. Returns false if no tab contains it.
+func (m *MigrationsContext) SelectMigrationByName(name string) bool {
+	for tabIdx, tabName := range m.TabbedTrait.GetTabs() {
+		for i, mig := range m.migrationsForTab(tabName) {
+			if mig.Name != name {
+				continue
+			}
+
+			m.saveCurrentTabState()
+			m.TabbedTrait.SetCurrentTabIdx(tabIdx)
+			m.loadItemsForCurrentTab()
+			m.selected = i
+
+			// Scroll so the selection is visible
+			originY := m.ScrollableTrait.GetOriginY()
+			innerHeight := 0
+			if v := m.BaseContext.GetView(); v != nil {
+				_, h := v.Size()
+				innerHeight = h - 2
+			}
+			if m.selected < originY || innerHeight <= 0 {
+				m.ScrollableTrait.SetOriginY(m.selected)
+			} else if m.selected-originY >= innerHeight {
+				m.ScrollableTrait.SetOriginY(m.selected - innerHeight + 1)
+			}
+
+			m.notifySelectionChanged()
+			return true
+		}
+	}
+	return false
+}
+
 // ---------------------------------------------------------------------------
 // Scroll overrides (list-aware: also update selection)
 // ---------------------------------------------------------------------------
@@ -412,7 +637,8 @@ func (m *MigrationsContext) HandleListClick(y int) error {
 		return nil
 	}
 
-	clickedIndex := y
+	// Only the visible items are drawn, starting at the scroll origin
+	clickedIndex := m.ScrollableTrait.GetOriginY() + y
 	if clickedIndex < 0 || clickedIndex >= len(m.items) {
 		return nil
 	}
@@ -531,19 +757,42 @@ func (m *MigrationsContext) loadMigrations() {
 		return
 	}
 
+	// Index which tables each migration touched
+	m.impactIndex = prisma.BuildImpactIndex(localMigrations)
+
 	// Try to connect to database
 	ds, err := prisma.GetDatasource(cwd)
 	var dbMigrations []prisma.DBMigration
 	m.dbConnected = false
+	m.extensions, m.extsRead = nil, false
 	tableExists := false
 
-	if err == nil && ds.URL != "" {
+	// An edge database's applied migrations are tracked by its own CLI, so
+	// there is nothing to read
+	m.edge = nil
+	if err == nil {
+		m.edge = prisma.DetectEdgeDatabase(cwd, ds.URL)
+	}
+
+	if m.demo != nil && m.edge == nil {
+		// The demo's fake database is always reachable
+		dbMigrations = m.demo.DBMigrations
+		m.dbConnected = true
+		tableExists = true
+		m.extensions, m.extsRead = demo.Extensions, true
+	} else if m.edge == nil && err == nil && ds.URL != "" && (prisma.CanConnectDirectly(ds.URL) || prisma.DetectDriverAdapter(cwd, ds.URL) == nil) {
+		// A URL only reachable through a driver adapter is left alone
 		client, err := database.NewClientFromDSN(ds.Provider, ds.URL)
 		if err == nil {
 			if m.dbClient != nil {
 				m.dbClient.Close()
 			}
 			m.dbClient = client
+			if database.HasExtensions(ds.Provider) {
+				if names, err := client.Extensions(); err == nil {
+					m.extensions, m.extsRead = names, true
+				}
+			}
 			dbMigrations, err = prisma.GetDBMigrations(client.DB())
 			if err == nil {
 				m.dbConnected = true
@@ -559,8 +808,10 @@ func (m *MigrationsContext) loadMigrations() {
 		}
 	}
 
+	m.mergePlan = nil
 	if m.dbConnected {
 		m.category = prisma.CompareMigrations(localMigrations, dbMigrations)
+		m.mergePlan = prisma.PlanMergeFix(m.category)
 
 		tabs := []string{m.tr.TabLocal}
 		if len(m.category.Pending) > 0 {
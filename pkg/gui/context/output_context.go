@@ -2,6 +2,9 @@ package context
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/dokadev/lazyprisma/pkg/gui/style"
@@ -14,21 +17,38 @@ import (
 type OutputContext struct {
 	*SimpleContext
 	*ScrollableTrait
+	*ContentTrait
 
 	g        *gocui.Gui
 	tr       *i18n.TranslationSet
-	content  string
+	lines    *lineRing // Most recent lines shown in the panel
+	logFile  *os.File  // Full output of the session, without colours (nil if it can't be written)
+	logPath  string
 	subtitle string
 	autoScrollToBottom bool
+	folds    []outputFold // Finished commands' output, oldest first
+	reveal   int          // Line to scroll to the top on the next draw (-1 = none)
+}
+
+// outputFold is the raw output of a finished command, shown under its summary
+// line and hidden while collapsed. Lines are numbered from the start of the
+// session, counting the ones the panel has dropped.
+type outputFold struct {
+	start, end int // Lines [start, end) of the output
+	summary    string
+	collapsed  bool
 }
 
 var _ types.Context = &OutputContext{}
 var _ types.IScrollableContext = &OutputContext{}
+var _ types.ICopyableContext = &OutputContext{}
 
 type OutputContextOpts struct {
 	Gui          *gocui.Gui
 	Tr           *i18n.TranslationSet
 	ViewName     string
+	MaxLines     int    // Lines kept in the panel (0 = no limit)
+	LogPath      string // File receiving the session's full output ("" = none)
 }
 
 func NewOutputContext(opts OutputContextOpts) *OutputContext {
@@ -45,19 +65,45 @@ func NewOutputContext(opts OutputContextOpts) *OutputContext {
 	oc := &OutputContext{
 		SimpleContext:  simpleCtx,
 		ScrollableTrait: &ScrollableTrait{},
+		ContentTrait:   &ContentTrait{},
 		g:              opts.Gui,
 		tr:             opts.Tr,
-		content:        "",
+		lines:          newLineRing(opts.MaxLines),
+		reveal:         -1,
+	}
+
+	if opts.LogPath != "" {
+		oc.openLog(opts.LogPath)
 	}
 
 	return oc
 }
 
+// openLog starts a new session log at path. Without it, the panel still works
+// but dropped lines are gone for good.
+func (o *OutputContext) openLog(path string) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return
+	}
+	o.logFile = file
+	o.logPath = path
+}
+
 // ID returns the view identifier (implements Panel interface from app package)
 func (o *OutputContext) ID() string {
 	return o.GetViewName()
 }
 
+// CopyText returns the output lines currently scrolled into view; the whole
+// log is rarely what is wanted.
+func (o *OutputContext) CopyText() string {
+	return o.ScrollableTrait.VisibleText()
+}
+
 // Draw renders the output panel (implements Panel interface from app package)
 func (o *OutputContext) Draw(dim boxlayout.Dimensions) error {
 	v, err := o.g.SetView(o.GetViewName(), dim.X0, dim.Y0, dim.X1, dim.Y1, 0)
@@ -70,7 +116,7 @@ func (o *OutputContext) Draw(dim boxlayout.Dimensions) error {
 	o.ScrollableTrait.SetView(v) // ScrollableTrait
 	o.setupView(v)
 
-	// Frame colours based on focus (set after Clear to persist through render)
+	// Frame colours based on focus
 	if o.IsFocused() {
 		v.FrameColor = style.FocusedFrameColor
 		v.TitleColor = style.FocusedTitleColor
@@ -80,8 +126,30 @@ func (o *OutputContext) Draw(dim boxlayout.Dimensions) error {
 	}
 
 	v.Subtitle = o.subtitle
-	v.Wrap = true
-	fmt.Fprint(v, o.content)
+	v.Wrap = o.IsWrapping()
+	var content strings.Builder
+	if dropped := o.lines.Dropped(); dropped > 0 {
+		if o.logPath != "" {
+			content.WriteString(style.Gray(fmt.Sprintf(o.tr.OutputLinesDroppedLogPath, dropped, o.logPath)) + "\n")
+		} else {
+			content.WriteString(style.Gray(fmt.Sprintf(o.tr.OutputLinesDropped, dropped)) + "\n")
+		}
+	}
+	revealAt := o.writeFoldedLines(&content)
+
+	// The row of the line to reveal depends on how the lines before it wrap
+	revealRow := -1
+	if revealAt >= 0 {
+		o.renderContent(v, content.String()[:revealAt])
+		revealRow = len(v.ViewBufferLines())
+	}
+	o.renderContent(v, content.String())
+
+	if revealRow >= 0 {
+		o.ScrollableTrait.SetOriginY(revealRow)
+		o.autoScrollToBottom = false
+		o.reveal = -1
+	}
 
 	// Auto-scroll to bottom if flagged
 	if o.autoScrollToBottom {
@@ -102,9 +170,124 @@ func (o *OutputContext) Draw(dim boxlayout.Dimensions) error {
 	return nil
 }
 
+// writeFoldedLines writes the lines held to content, each fold led by its
+// summary line and its lines left out while it is collapsed. Returns the
+// offset in content of the line to reveal, or -1 if none is pending.
+func (o *OutputContext) writeFoldedLines(content *strings.Builder) int {
+	dropped := o.lines.Dropped()
+	folds := o.folds
+	revealAt := -1
+	if o.reveal >= 0 && o.reveal < dropped {
+		revealAt = 0 // Dropped; show what is left of it
+	}
+	for i, line := range o.lines.Lines() {
+		n := dropped + i
+		// The line to reveal, skipping the blank line before a command's header
+		if revealAt < 0 && o.reveal >= 0 && n >= o.reveal && line != "" {
+			revealAt = content.Len()
+		}
+		for len(folds) > 0 && folds[0].end <= n {
+			if folds[0].start == n {
+				content.WriteString(o.foldSummaryLine(folds[0]) + "\n") // No output of its own
+			}
+			folds = folds[1:]
+		}
+		if len(folds) == 0 || n < folds[0].start {
+			content.WriteString(line + "\n")
+			continue
+		}
+
+		fold := folds[0]
+		if n == fold.start || i == 0 {
+			content.WriteString(o.foldSummaryLine(fold) + "\n")
+		}
+		if !fold.collapsed {
+			content.WriteString(line + "\n")
+		}
+	}
+
+	// Commands without output after the last line
+	for _, fold := range folds {
+		if fold.start == fold.end {
+			content.WriteString(o.foldSummaryLine(fold) + "\n")
+		}
+	}
+	return revealAt
+}
+
+// foldSummaryLine returns the line leading a fold: its summary and, while it
+// is collapsed, how many lines it hides
+func (o *OutputContext) foldSummaryLine(fold outputFold) string {
+	if fold.start == fold.end {
+		return "  " + style.Bold(fold.summary)
+	}
+	if !fold.collapsed {
+		return "  ▾ " + style.Bold(fold.summary)
+	}
+	return "  ▸ " + style.Bold(fold.summary) + "  " + style.Gray(fmt.Sprintf(o.tr.OutputFoldHidden, fold.end-fold.start))
+}
+
+// MarkOutput returns the position of the next line written, where a
+// command's output starts (see FoldOutput)
+func (o *OutputContext) MarkOutput() int {
+	return o.lines.Dropped() + o.lines.Len()
+}
+
+// FoldOutput puts the lines written since mark under a summary line, hidden
+// if collapsed. The session log gets the summary after the lines.
+func (o *OutputContext) FoldOutput(mark int, summary string, collapsed bool) {
+	end := o.MarkOutput()
+	if mark > end {
+		mark = end
+	}
+	o.folds = append(o.folds, outputFold{start: mark, end: end, summary: summary, collapsed: collapsed})
+	if o.logFile != nil {
+		fmt.Fprintln(o.logFile, "  "+style.Strip(summary))
+	}
+	o.autoScrollToBottom = true
+}
+
+// ToggleFolds shows the output of every finished command if any is hidden,
+// and hides it all otherwise. Returns false if there is nothing to fold.
+func (o *OutputContext) ToggleFolds() bool {
+	dropped := o.lines.Dropped()
+	kept := o.folds[:0]
+	collapse := true
+	for _, fold := range o.folds {
+		if fold.end <= dropped && fold.start != fold.end {
+			continue // All of its lines are gone
+		}
+		kept = append(kept, fold)
+		if fold.collapsed && fold.start != fold.end {
+			collapse = false
+		}
+	}
+	o.folds = kept
+
+	toggled := false
+	for i := range o.folds {
+		if o.folds[i].start != o.folds[i].end {
+			o.folds[i].collapsed = collapse
+			toggled = true
+		}
+	}
+	return toggled
+}
+
+// RevealOutput scrolls the panel so that line (see MarkOutput) is at the top
+// on the next draw, and expands the output of the command written from there
+func (o *OutputContext) RevealOutput(line int) {
+	for i := range o.folds {
+		if o.folds[i].end > line {
+			o.folds[i].collapsed = false
+			break
+		}
+	}
+	o.reveal = line
+}
+
 // setupView configures the view with common settings (replaces BasePanel.SetupView)
 func (o *OutputContext) setupView(v *gocui.View) {
-	v.Clear()
 	v.Frame = true
 	v.Title = o.tr.PanelTitleOutput
 	v.FrameRunes = style.DefaultFrameRunes
@@ -112,23 +295,34 @@ func (o *OutputContext) setupView(v *gocui.View) {
 
 // AppendOutput appends text to the output buffer and flags auto-scroll
 func (o *OutputContext) AppendOutput(text string) {
-	o.content += text + "\n"
+	o.writeLines(text)
 	o.autoScrollToBottom = true
 }
 
+// writeLines adds text to the panel, dropping the oldest lines beyond the
+// limit, and to the session log
+func (o *OutputContext) writeLines(text string) {
+	for _, line := range strings.Split(text, "\n") {
+		o.lines.Push(line)
+		if o.logFile != nil {
+			fmt.Fprintln(o.logFile, style.Strip(line))
+		}
+	}
+}
+
 // LogAction logs an action with timestamp and optional details
 func (o *OutputContext) LogAction(action string, details ...string) {
 	timestamp := time.Now().Format("15:04:05")
 
-	if o.content != "" {
-		o.content += "\n"
+	if o.lines.Len() > 0 {
+		o.writeLines("")
 	}
 
 	header := fmt.Sprintf("%s %s", style.Gray(timestamp), style.CyanBold(action))
-	o.content += header + "\n"
+	o.writeLines(header)
 
 	for _, detail := range details {
-		o.content += "  " + detail + "\n"
+		o.writeLines("  " + detail)
 	}
 
 	o.autoScrollToBottom = true
@@ -138,15 +332,15 @@ func (o *OutputContext) LogAction(action string, details ...string) {
 func (o *OutputContext) LogActionRed(action string, details ...string) {
 	timestamp := time.Now().Format("15:04:05")
 
-	if o.content != "" {
-		o.content += "\n"
+	if o.lines.Len() > 0 {
+		o.writeLines("")
 	}
 
 	header := fmt.Sprintf("%s %s", style.Gray(timestamp), style.RedBold(action))
-	o.content += header + "\n"
+	o.writeLines(header)
 
 	for _, detail := range details {
-		o.content += "  " + style.Red(detail) + "\n"
+		o.writeLines("  " + style.Red(detail))
 	}
 
 	o.autoScrollToBottom = true
@@ -0,0 +1,451 @@
+package context
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dokadev/lazyprisma/pkg/gui/style"
+	"github.com/dokadev/lazyprisma/pkg/gui/types"
+	"github.com/dokadev/lazyprisma/pkg/i18n"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazycore/pkg/boxlayout"
+)
+
+// SchemaContext lists the models, views, enums, composite types and
+// generators of schema.prisma as a tree grouped by kind. The selected entry
+// is shown in the Details panel.
+type SchemaContext struct {
+	*SimpleContext
+	*ScrollableTrait
+	*ContentTrait
+
+	g  *gocui.Gui
+	tr *i18n.TranslationSet
+
+	outline   *prisma.SchemaOutline // nil if the schema can't be read
+	rows      []schemaRow           // Rendered tree: group headers and the entries under them
+	selected  int                   // Selected row
+	collapsed map[string]bool       // Kinds whose group is folded
+
+	// Callbacks (replace direct panel/app references)
+	onSelectionChanged func(outline *prisma.SchemaOutline, kind string, entry *prisma.SchemaEntry)
+	hasActiveModal     func() bool
+	onPanelClick       func(viewID string)
+}
+
+// schemaRow is a line of the tree: a group header (entry -1) or an entry
+type schemaRow struct {
+	kind  string
+	entry int // Index in the outline's entries
+}
+
+var _ types.IListContext = &SchemaContext{}
+var _ types.ICopyableContext = &SchemaContext{}
+
+type SchemaContextOpts struct {
+	Gui      *gocui.Gui
+	Tr       *i18n.TranslationSet
+	ViewName string
+}
+
+func NewSchemaContext(opts SchemaContextOpts) *SchemaContext {
+	baseCtx := NewBaseContext(BaseContextOpts{
+		Key:       types.ContextKey(opts.ViewName),
+		Kind:      types.SIDE_CONTEXT,
+		ViewName:  opts.ViewName,
+		Focusable: true,
+		Title:     opts.Tr.PanelTitleSchema,
+	})
+
+	sc := &SchemaContext{
+		SimpleContext:   NewSimpleContext(baseCtx),
+		ScrollableTrait: &ScrollableTrait{},
+		ContentTrait:    &ContentTrait{},
+		g:               opts.Gui,
+		tr:              opts.Tr,
+		collapsed:       make(map[string]bool),
+	}
+	sc.Refresh()
+	return sc
+}
+
+// SetOnSelectionChanged registers a callback invoked whenever the selected
+// row changes, and when the panel gains focus. entry is nil for a group header.
+func (s *SchemaContext) SetOnSelectionChanged(cb func(*prisma.SchemaOutline, string, *prisma.SchemaEntry)) {
+	s.onSelectionChanged = cb
+}
+
+// SetModalCallbacks registers callbacks for modal and panel-click checks.
+func (s *SchemaContext) SetModalCallbacks(hasActiveModal func() bool, onPanelClick func(string)) {
+	s.hasActiveModal = hasActiveModal
+	s.onPanelClick = onPanelClick
+}
+
+// ID returns the view identifier (Panel interface compatibility).
+func (s *SchemaContext) ID() string {
+	return s.GetViewName()
+}
+
+// OnFocus focuses the panel and shows its selection in the Details panel.
+func (s *SchemaContext) OnFocus() {
+	s.BaseContext.OnFocus()
+	s.notifySelectionChanged()
+}
+
+// Refresh re-reads schema.prisma, keeping the selected entry (or group) selected
+func (s *SchemaContext) Refresh() {
+	var selectedKind, selectedName string
+	if s.selected < len(s.rows) {
+		row := s.rows[s.selected]
+		selectedKind = row.kind
+		if row.entry >= 0 {
+			selectedName = s.outline.Entries[row.entry].Name
+		}
+	}
+
+	s.outline = nil
+	if cwd, err := os.Getwd(); err == nil {
+		if data, err := os.ReadFile(prisma.SchemaPath(cwd)); err == nil {
+			s.outline = prisma.ParseSchemaOutline(strings.Split(string(data), "\n"))
+		}
+	}
+	s.buildRows()
+
+	s.selected = 0
+	for i, row := range s.rows {
+		if row.kind != selectedKind {
+			continue
+		}
+		if row.entry < 0 && selectedName == "" {
+			s.selected = i
+			break
+		}
+		if row.entry >= 0 && s.outline.Entries[row.entry].Name == selectedName {
+			s.selected = i
+			break
+		}
+	}
+
+	if s.IsFocused() {
+		s.notifySelectionChanged()
+	}
+}
+
+// buildRows lays out the tree: a header per kind the schema has, followed by
+// its entries unless the group is folded
+func (s *SchemaContext) buildRows() {
+	s.rows = nil
+	if s.outline == nil {
+		return
+	}
+	for _, kind := range prisma.SchemaEntryKinds {
+		var entries []int
+		for i, entry := range s.outline.Entries {
+			if entry.Kind == kind {
+				entries = append(entries, i)
+			}
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		s.rows = append(s.rows, schemaRow{kind: kind, entry: -1})
+		if s.collapsed[kind] {
+			continue
+		}
+		for _, i := range entries {
+			s.rows = append(s.rows, schemaRow{kind: kind, entry: i})
+		}
+	}
+}
+
+// Outline returns the parsed schema, or nil if it couldn't be read
+func (s *SchemaContext) Outline() *prisma.SchemaOutline {
+	return s.outline
+}
+
+// SelectedEntry returns the selected entry, or nil if a group header (or
+// nothing) is selected
+func (s *SchemaContext) SelectedEntry() *prisma.SchemaEntry {
+	if s.selected >= len(s.rows) || s.rows[s.selected].entry < 0 {
+		return nil
+	}
+	return &s.outline.Entries[s.rows[s.selected].entry]
+}
+
+// ToggleSelectedGroup folds or unfolds the group whose header is selected.
+// Returns false if an entry is selected.
+func (s *SchemaContext) ToggleSelectedGroup() bool {
+	if s.selected >= len(s.rows) || s.rows[s.selected].entry >= 0 {
+		return false
+	}
+	kind := s.rows[s.selected].kind
+	s.collapsed[kind] = !s.collapsed[kind]
+	s.buildRows()
+	return true
+}
+
+// SelectEntryByName selects the named entry, unfolding its group. Returns
+// false if the schema has no such entry.
+func (s *SchemaContext) SelectEntryByName(name string) bool {
+	if s.outline == nil {
+		return false
+	}
+	entry := s.outline.Entry(name)
+	if entry == nil {
+		return false
+	}
+	s.collapsed[entry.Kind] = false
+	s.buildRows()
+	for i, row := range s.rows {
+		if row.entry >= 0 && s.outline.Entries[row.entry].Name == name {
+			s.selected = i
+			s.scrollToSelected()
+			s.notifySelectionChanged()
+			return true
+		}
+	}
+	return false
+}
+
+// CopyText returns the tree as drawn.
+func (s *SchemaContext) CopyText() string {
+	return s.ScrollableTrait.PlainText()
+}
+
+// Draw renders the schema panel (Panel interface compatibility).
+func (s *SchemaContext) Draw(dim boxlayout.Dimensions) error {
+	v, err := s.g.SetView(s.GetViewName(), dim.X0, dim.Y0, dim.X1, dim.Y1, 0)
+	if err != nil && err.Error() != "unknown view" {
+		return err
+	}
+
+	s.BaseContext.SetView(v)
+	s.ScrollableTrait.SetView(v)
+
+	v.Frame = true
+	v.FrameRunes = style.DefaultFrameRunes
+	v.Title = s.tr.PanelTitleSchema
+	v.Footer = ""
+	if len(s.rows) > 0 {
+		v.Footer = fmt.Sprintf(s.tr.MigrationsFooterFormat, s.selected+1, len(s.rows))
+	}
+
+	if s.IsFocused() {
+		v.FrameColor = style.FocusedFrameColor
+		v.TitleColor = style.FocusedTitleColor
+	} else {
+		v.FrameColor = style.PrimaryFrameColor
+		v.TitleColor = style.PrimaryTitleColor
+	}
+
+	if len(s.rows) == 0 {
+		v.Highlight = false
+		if s.outline == nil {
+			s.renderContent(v, style.Gray(s.tr.SchemaPanelNoSchema)+"\n")
+		} else {
+			s.renderContent(v, style.Gray(s.tr.SchemaPanelEmpty)+"\n")
+		}
+		return nil
+	}
+
+	v.Highlight = true
+	v.SelBgColor = style.SelectionBgColor
+	var content strings.Builder
+	for _, row := range s.rows {
+		content.WriteString(s.renderRow(row) + "\n")
+	}
+	s.renderContent(v, content.String())
+
+	// Keep the origin in range, e.g. after a group was folded
+	_, viewHeight := v.Size()
+	maxOrigin := max(len(s.rows)-(viewHeight-2), 0)
+	if s.ScrollableTrait.GetOriginY() > maxOrigin {
+		s.ScrollableTrait.SetOriginY(maxOrigin)
+	}
+
+	v.SetCursor(0, s.selected-s.ScrollableTrait.GetOriginY())
+	v.SetOrigin(0, s.ScrollableTrait.GetOriginY())
+	return nil
+}
+
+// renderRow returns the line of a group header or an entry
+func (s *SchemaContext) renderRow(row schemaRow) string {
+	if row.entry < 0 {
+		count := 0
+		for _, entry := range s.outline.Entries {
+			if entry.Kind == row.kind {
+				count++
+			}
+		}
+		marker := "▾"
+		if s.collapsed[row.kind] {
+			marker = "▸"
+		}
+		return fmt.Sprintf("%s %s %s", marker, style.YellowBold(SchemaGroupLabel(s.tr, row.kind)), style.Gray(fmt.Sprintf("(%d)", count)))
+	}
+
+	entry := s.outline.Entries[row.entry]
+	return "  " + entry.Name + "  " + style.Gray(SchemaEntrySummary(s.tr, entry))
+}
+
+// SchemaEntrySummary returns what an entry holds in a few words: its number
+// of fields or values, or a generator's provider
+func SchemaEntrySummary(tr *i18n.TranslationSet, entry prisma.SchemaEntry) string {
+	switch entry.Kind {
+	case "enum":
+		return fmt.Sprintf(tr.SchemaValueCount, len(entry.Fields))
+	case "generator":
+		for _, setting := range entry.Fields {
+			if setting.Name == "provider" {
+				return strings.Trim(setting.Type, `"`)
+			}
+		}
+		return ""
+	default:
+		return fmt.Sprintf(tr.SchemaFieldCount, len(entry.Fields))
+	}
+}
+
+// SchemaKindLabel returns the name of a kind of entry
+func SchemaKindLabel(tr *i18n.TranslationSet, kind string) string {
+	switch kind {
+	case "model":
+		return tr.SchemaKindModel
+	case "view":
+		return tr.SchemaKindView
+	case "enum":
+		return tr.SchemaKindEnum
+	case "type":
+		return tr.SchemaKindType
+	default:
+		return tr.SchemaKindGenerator
+	}
+}
+
+// SchemaGroupLabel returns the name of the group a kind of entry is listed under
+func SchemaGroupLabel(tr *i18n.TranslationSet, kind string) string {
+	switch kind {
+	case "model":
+		return tr.SchemaGroupModels
+	case "view":
+		return tr.SchemaGroupViews
+	case "enum":
+		return tr.SchemaGroupEnums
+	case "type":
+		return tr.SchemaGroupTypes
+	default:
+		return tr.SchemaGroupGenerators
+	}
+}
+
+// GetSelectedIdx returns the index of the selected row.
+func (s *SchemaContext) GetSelectedIdx() int {
+	return s.selected
+}
+
+// GetItemCount returns the number of rows.
+func (s *SchemaContext) GetItemCount() int {
+	return len(s.rows)
+}
+
+// SelectNext moves the selection down by one.
+func (s *SchemaContext) SelectNext() {
+	if s.selected < len(s.rows)-1 {
+		s.selected++
+		s.scrollToSelected()
+		s.notifySelectionChanged()
+	}
+}
+
+// SelectPrev moves the selection up by one.
+func (s *SchemaContext) SelectPrev() {
+	if s.selected > 0 {
+		s.selected--
+		s.scrollToSelected()
+		s.notifySelectionChanged()
+	}
+}
+
+// ScrollToTop selects the first row.
+func (s *SchemaContext) ScrollToTop() {
+	if len(s.rows) == 0 {
+		return
+	}
+	s.selected = 0
+	s.ScrollableTrait.SetOriginY(0)
+	s.notifySelectionChanged()
+}
+
+// ScrollToBottom selects the last row.
+func (s *SchemaContext) ScrollToBottom() {
+	if len(s.rows) == 0 {
+		return
+	}
+	s.selected = len(s.rows) - 1
+	s.scrollToSelected()
+	s.notifySelectionChanged()
+}
+
+// ScrollUpByWheel scrolls the view up by 2 lines (mouse wheel).
+func (s *SchemaContext) ScrollUpByWheel() {
+	s.ScrollableTrait.ScrollUpByWheel()
+}
+
+// ScrollDownByWheel scrolls the view down by 2 lines (mouse wheel).
+func (s *SchemaContext) ScrollDownByWheel() {
+	v := s.BaseContext.GetView()
+	if v == nil {
+		return
+	}
+	_, viewHeight := v.Size()
+	maxOrigin := max(len(s.rows)-(viewHeight-2), 0)
+	s.ScrollableTrait.SetOriginY(min(s.ScrollableTrait.GetOriginY()+2, maxOrigin))
+}
+
+// HandleListClick selects the clicked row; clicking a selected group header
+// folds or unfolds it.
+func (s *SchemaContext) HandleListClick(y int) error {
+	if s.hasActiveModal != nil && s.hasActiveModal() {
+		return nil
+	}
+
+	if y >= 0 && y < len(s.rows) {
+		if y == s.selected && s.IsFocused() {
+			s.ToggleSelectedGroup()
+		} else {
+			s.selected = y
+			s.notifySelectionChanged()
+		}
+	}
+
+	// Switch focus to this panel if not already focused
+	if s.onPanelClick != nil {
+		s.onPanelClick(s.GetViewName())
+	}
+	return nil
+}
+
+// scrollToSelected scrolls so that the selected row is visible
+func (s *SchemaContext) scrollToSelected() {
+	originY := s.ScrollableTrait.GetOriginY()
+	if s.selected < originY {
+		s.ScrollableTrait.SetOriginY(s.selected)
+		return
+	}
+	if v := s.BaseContext.GetView(); v != nil {
+		_, h := v.Size()
+		if innerHeight := h - 2; innerHeight > 0 && s.selected-originY >= innerHeight {
+			s.ScrollableTrait.SetOriginY(s.selected - innerHeight + 1)
+		}
+	}
+}
+
+// notifySelectionChanged invokes the onSelectionChanged callback if set.
+func (s *SchemaContext) notifySelectionChanged() {
+	if s.onSelectionChanged == nil || s.selected >= len(s.rows) {
+		return
+	}
+	s.onSelectionChanged(s.outline, s.rows[s.selected].kind, s.SelectedEntry())
+}
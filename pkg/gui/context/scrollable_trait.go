@@ -1,17 +1,26 @@
 package context
 
 import (
+	"strings"
+
 	"github.com/jesseduffield/gocui"
 )
 
 const wheelScrollLines = 2
 
+// horizontalScrollColumns is how far ScrollLeft / ScrollRight move
+const horizontalScrollColumns = 8
+
 // ScrollableTrait provides shared vertical scroll logic.
 // It tracks originY manually and applies it to the gocui view,
 // replicating the exact behaviour used across all existing panels.
+// Panels that truncate long lines instead of wrapping them also scroll
+// horizontally.
 type ScrollableTrait struct {
-	view    *gocui.View
-	originY int
+	view     *gocui.View
+	originY  int
+	originX  int
+	truncate bool // Truncate long lines instead of wrapping them
 }
 
 // SetView assigns (or reassigns) the underlying gocui view.
@@ -74,6 +83,39 @@ func (self *ScrollableTrait) ScrollDownByWheel() {
 	}
 }
 
+// IsWrapping returns whether long lines wrap (the default) rather than being truncated.
+func (self *ScrollableTrait) IsWrapping() bool {
+	return !self.truncate
+}
+
+// ToggleWrap switches between wrapping and truncating long lines and returns
+// whether they now wrap.
+func (self *ScrollableTrait) ToggleWrap() bool {
+	self.truncate = !self.truncate
+	self.originX = 0
+	return !self.truncate
+}
+
+// ScrollLeft scrolls truncated lines to the left.
+func (self *ScrollableTrait) ScrollLeft() {
+	self.originX -= horizontalScrollColumns
+	if self.originX < 0 {
+		self.originX = 0
+	}
+}
+
+// ScrollRight scrolls truncated lines to the right, clamping to the longest line.
+func (self *ScrollableTrait) ScrollRight() {
+	if self.view == nil || self.view.Wrap {
+		return
+	}
+
+	self.originX += horizontalScrollColumns
+	if maxOriginX := self.maxOriginX(); self.originX > maxOriginX {
+		self.originX = maxOriginX
+	}
+}
+
 // ScrollToTop scrolls to the very top.
 func (self *ScrollableTrait) ScrollToTop() {
 	self.originY = 0
@@ -104,7 +146,44 @@ func (self *ScrollableTrait) AdjustScroll() {
 		self.originY = 0
 	}
 
-	self.view.SetOrigin(0, self.originY)
+	// Wrapped views have nothing to scroll sideways
+	if self.view.Wrap {
+		self.originX = 0
+	} else if maxOriginX := self.maxOriginX(); self.originX > maxOriginX {
+		self.originX = maxOriginX
+	}
+
+	self.view.SetOrigin(self.originX, self.originY)
+}
+
+// PlainText returns everything drawn in the view, without colours or
+// trailing padding.
+func (self *ScrollableTrait) PlainText() string {
+	if self.view == nil {
+		return ""
+	}
+	return plainLines(self.view.BufferLines())
+}
+
+// VisibleText returns the lines currently scrolled into view, without colours
+// or trailing padding.
+func (self *ScrollableTrait) VisibleText() string {
+	if self.view == nil {
+		return ""
+	}
+	lines := self.view.ViewBufferLines()
+	start := min(self.originY, len(lines))
+	end := min(start+self.view.InnerHeight(), len(lines))
+	return plainLines(lines[start:end])
+}
+
+// plainLines joins view lines, trimming trailing padding and empty lines.
+func plainLines(lines []string) string {
+	trimmed := make([]string, len(lines))
+	for i, line := range lines {
+		trimmed[i] = strings.TrimRight(line, " ")
+	}
+	return strings.TrimRight(strings.Join(trimmed, "\n"), "\n")
 }
 
 // maxOrigin calculates the maximum valid originY based on content and view size.
@@ -119,3 +198,15 @@ func (self *ScrollableTrait) maxOrigin() int {
 	}
 	return max
 }
+
+// maxOriginX calculates the maximum valid originX based on the longest line and view width.
+func (self *ScrollableTrait) maxOriginX() int {
+	longest := 0
+	for _, line := range self.view.ViewBufferLines() {
+		longest = max(longest, len([]rune(line)))
+	}
+	viewWidth, _ := self.view.Size()
+	innerWidth := viewWidth - 2 // Exclude frame (left + right)
+
+	return max(longest-innerWidth, 0)
+}
@@ -2,12 +2,14 @@ package context
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/dokadev/lazyprisma/pkg/gui/style"
 	"github.com/dokadev/lazyprisma/pkg/gui/types"
 	"github.com/dokadev/lazyprisma/pkg/i18n"
 	"github.com/jesseduffield/gocui"
 	"github.com/jesseduffield/lazycore/pkg/boxlayout"
+	"github.com/rivo/uniseg"
 )
 
 // StatusBarState provides callbacks for accessing App state without direct dependency.
@@ -16,6 +18,27 @@ type StatusBarState struct {
 	GetSpinnerFrame  func() uint32
 	IsStudioRunning  func() bool
 	GetCommandName   func() string
+	GetCommandStep   func() string // Sub-step of the running command, if it reports any
+	IsOffline        func() bool
+	IsDemo           func() bool
+	IsReadOnly       func() bool // Another instance has the project open
+	IsPrismaMissing  func() bool // The Prisma CLI could not be run (limited mode)
+
+	// GetUncommittedWrites returns how many statements the SQL console's open
+	// transaction holds (0 = none)
+	GetUncommittedWrites func() int
+
+	// GetEngineDownload returns the Prisma engine download progress
+	// (percent is -1 if unknown) and whether a download is in progress
+	GetEngineDownload func() (percent int, active bool)
+
+	// GetDeployProgress returns the migration migrate deploy is applying
+	// (1-based current of total) and whether progress is being tracked
+	GetDeployProgress func() (current, total int, name string, active bool)
+
+	// GetToast returns a brief message to show, e.g. that changed files are
+	// being reloaded ("" = none)
+	GetToast func() string
 }
 
 // StatusBarConfig holds static configuration for the status bar display.
@@ -26,6 +49,9 @@ type StatusBarConfig struct {
 
 var spinnerFrames = []rune{'|', '/', '-', '\\'}
 
+// engineProgressWidth is the width of the engine download progress bar
+const engineProgressWidth = 10
+
 // SpinnerFrameCount returns the number of spinner animation frames.
 func SpinnerFrameCount() uint32 {
 	return uint32(len(spinnerFrames))
@@ -33,6 +59,7 @@ func SpinnerFrameCount() uint32 {
 
 type StatusBarContext struct {
 	*BaseContext
+	*ContentTrait
 
 	g      *gocui.Gui
 	tr     *i18n.TranslationSet
@@ -58,11 +85,12 @@ func NewStatusBarContext(opts StatusBarContextOpts) *StatusBarContext {
 	})
 
 	return &StatusBarContext{
-		BaseContext: baseCtx,
-		g:           opts.Gui,
-		tr:          opts.Tr,
-		state:       opts.State,
-		config:      opts.Config,
+		BaseContext:  baseCtx,
+		ContentTrait: &ContentTrait{},
+		g:            opts.Gui,
+		tr:           opts.Tr,
+		state:        opts.State,
+		config:       opts.Config,
 	}
 }
 
@@ -86,23 +114,51 @@ func (s *StatusBarContext) Draw(dim boxlayout.Dimensions) error {
 	}
 
 	s.SetView(v)
-	v.Clear()
 	v.Frame = false
 
 	// Build status bar content
 	var leftContent string
 	var visibleLen int
 
-	// Show spinner if command is running
-	if s.state.IsCommandRunning() {
+	// Show engine download progress instead of the spinner: the first prisma
+	// command can spend a long time downloading engines without other output
+	downloadPercent, downloading := 0, false
+	if s.state.GetEngineDownload != nil {
+		downloadPercent, downloading = s.state.GetEngineDownload()
+	}
+
+	if s.state.IsCommandRunning() && downloading {
+		label := s.tr.StatusDownloadingEngines
+		if downloadPercent >= 0 {
+			filled := downloadPercent * engineProgressWidth / 100
+			bar := strings.Repeat("█", filled) + strings.Repeat("░", engineProgressWidth-filled)
+			percentText := fmt.Sprintf("%3d%%", downloadPercent)
+			leftContent = fmt.Sprintf(" %s %s %s ", style.Gray(label), style.Cyan(bar), style.Cyan(percentText))
+			visibleLen += 1 + uniseg.StringWidth(label) + 1 + engineProgressWidth + 1 + len(percentText) + 1
+		} else {
+			frameIndex := s.state.GetSpinnerFrame() % uint32(len(spinnerFrames))
+			spinner := string(spinnerFrames[frameIndex])
+			leftContent = fmt.Sprintf(" %s %s ", style.Cyan(spinner), style.Gray(label))
+			visibleLen += 1 + 1 + 1 + uniseg.StringWidth(label) + 1
+		}
+	} else if current, total, name, applying := s.deployProgress(); s.state.IsCommandRunning() && applying {
+		frameIndex := s.state.GetSpinnerFrame() % uint32(len(spinnerFrames))
+		spinner := string(spinnerFrames[frameIndex])
+		label := fmt.Sprintf(s.tr.StatusApplyingMigration, current, total, name)
+		leftContent = fmt.Sprintf(" %s %s ", style.Cyan(spinner), style.Gray(label))
+		visibleLen += 1 + 1 + 1 + uniseg.StringWidth(label) + 1
+	} else if s.state.IsCommandRunning() {
 		frameIndex := s.state.GetSpinnerFrame() % uint32(len(spinnerFrames))
 		spinner := string(spinnerFrames[frameIndex])
 
-		// Get running task name
+		// Get running task name, and the step it is at
 		taskName := s.state.GetCommandName()
+		if step := s.commandStep(); step != "" {
+			taskName = fmt.Sprintf(s.tr.StatusCommandStep, taskName, step)
+		}
 
 		leftContent = fmt.Sprintf(" %s %s ", style.Cyan(spinner), style.Gray(taskName))
-		visibleLen += 1 + 1 + 1 + len(taskName) + 1 // " " + spinner + " " + taskName + " "
+		visibleLen += 1 + 1 + 1 + uniseg.StringWidth(taskName) + 1 // " " + spinner + " " + taskName + " "
 	} else {
 		leftContent = " " // Single space when not running
 		visibleLen += 1
@@ -112,7 +168,50 @@ func (s *StatusBarContext) Draw(dim boxlayout.Dimensions) error {
 	if s.state.IsStudioRunning() {
 		studioMsg := s.tr.StatusStudioOn
 		leftContent += fmt.Sprintf("%s ", style.Green(studioMsg))
-		visibleLen += len(studioMsg) + 1
+		visibleLen += uniseg.StringWidth(studioMsg) + 1
+	}
+
+	// Show offline status (the npx fallback runs with --offline)
+	if s.state.IsOffline != nil && s.state.IsOffline() {
+		offlineMsg := s.tr.StatusOffline
+		leftContent += fmt.Sprintf("%s ", style.Yellow(offlineMsg))
+		visibleLen += uniseg.StringWidth(offlineMsg) + 1
+	}
+
+	// Show limited mode (no Prisma CLI, commands offer to install it)
+	if s.state.IsPrismaMissing != nil && s.state.IsPrismaMissing() {
+		missingMsg := s.tr.StatusNoPrisma
+		leftContent += fmt.Sprintf("%s ", style.Red(missingMsg))
+		visibleLen += uniseg.StringWidth(missingMsg) + 1
+	}
+
+	// Show demo mode (fixture project, commands are not run)
+	if s.state.IsDemo != nil && s.state.IsDemo() {
+		demoMsg := s.tr.StatusDemo
+		leftContent += fmt.Sprintf("%s ", style.Magenta(demoMsg))
+		visibleLen += uniseg.StringWidth(demoMsg) + 1
+	}
+
+	// Show read-only mode (another instance has the project open)
+	if s.state.IsReadOnly != nil && s.state.IsReadOnly() {
+		readOnlyMsg := s.tr.StatusReadOnly
+		leftContent += fmt.Sprintf("%s ", style.Orange(readOnlyMsg))
+		visibleLen += uniseg.StringWidth(readOnlyMsg) + 1
+	}
+
+	// Show the SQL console's open transaction, which quitting rolls back
+	if s.state.GetUncommittedWrites != nil {
+		if count := s.state.GetUncommittedWrites(); count > 0 {
+			txMsg := fmt.Sprintf(s.tr.StatusUncommittedWrites, count)
+			leftContent += fmt.Sprintf("%s ", style.Red(txMsg))
+			visibleLen += uniseg.StringWidth(txMsg) + 1
+		}
+	}
+
+	// Show a toast (brief message that expires by itself)
+	if toast := s.toast(); toast != "" {
+		leftContent += fmt.Sprintf("%s ", style.Cyan(toast))
+		visibleLen += uniseg.StringWidth(toast) + 1
 	}
 
 	// Helper to format key binding: [k]ey -> [Cyan(k)]Gray(ey)
@@ -121,7 +220,7 @@ func (s *StatusBarContext) Draw(dim boxlayout.Dimensions) error {
 		// Style: [key]desc
 		styled := fmt.Sprintf("[%s]%s", style.Cyan(key), style.Gray(desc))
 		// Visible: [key]desc
-		vLen := 1 + len(key) + 1 + len(desc)
+		vLen := 1 + uniseg.StringWidth(key) + 1 + uniseg.StringWidth(desc)
 
 		leftContent += styled + " "
 		visibleLen += vLen + 1
@@ -152,11 +251,35 @@ func (s *StatusBarContext) Draw(dim boxlayout.Dimensions) error {
 		padding += " "
 	}
 
-	fmt.Fprint(v, leftContent+padding+styledRight)
+	s.renderContent(v, leftContent+padding+styledRight)
 
 	return nil
 }
 
+// commandStep returns the sub-step of the running command, if any
+func (s *StatusBarContext) commandStep() string {
+	if s.state.GetCommandStep == nil {
+		return ""
+	}
+	return s.state.GetCommandStep()
+}
+
+// toast returns the brief message to show, if any
+func (s *StatusBarContext) toast() string {
+	if s.state.GetToast == nil {
+		return ""
+	}
+	return s.state.GetToast()
+}
+
+// deployProgress returns the migrate deploy progress, if any
+func (s *StatusBarContext) deployProgress() (current, total int, name string, active bool) {
+	if s.state.GetDeployProgress == nil {
+		return 0, 0, "", false
+	}
+	return s.state.GetDeployProgress()
+}
+
 // OnFocus is a no-op for the status bar (not focusable)
 func (s *StatusBarContext) OnFocus() {}
 
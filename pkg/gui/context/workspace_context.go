@@ -1,18 +1,22 @@
 package context
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/dokadev/lazyprisma/pkg/database"
+	"github.com/dokadev/lazyprisma/pkg/demo"
 	"github.com/dokadev/lazyprisma/pkg/git"
 	"github.com/dokadev/lazyprisma/pkg/gui/style"
 	"github.com/dokadev/lazyprisma/pkg/gui/types"
 	"github.com/dokadev/lazyprisma/pkg/i18n"
 	"github.com/dokadev/lazyprisma/pkg/node"
 	"github.com/dokadev/lazyprisma/pkg/prisma"
+	"github.com/dokadev/lazyprisma/pkg/supabase"
 	"github.com/jesseduffield/gocui"
 	"github.com/jesseduffield/lazycore/pkg/boxlayout"
 )
@@ -20,34 +24,72 @@ import (
 type WorkspaceContext struct {
 	*SimpleContext
 	*ScrollableTrait
+	*LoadingTrait
+	*ContentTrait
 
 	g             *gocui.Gui
 	tr            *i18n.TranslationSet
 	nodeVersion    string
+	nodePin        *node.Pin    // Version the project pins (nil = none)
+	nodeManager    node.Manager // Version manager commands run through ("" = none)
 	prismaVersion  string
 	prismaGlobal   bool
+	prismaFound    bool // The Prisma CLI could be run
 	gitRepoName    string // Git repository name
 	gitBranch      string // Git branch name
 	isGitRepo      bool   // True if current directory is a git repository
 	schemaModified bool   // True if schema.prisma has git changes
+	recentFiles    []prisma.ProjectFile // Most recently modified Prisma-related files, newest first
 	unmaskedURL    string
 	maskedURL      string
 	showMasked     bool
+	revealURL      bool          // Revealing the masked URL is allowed
+	revealPeriod   time.Duration // A revealed URL is masked again after this long (0 = never)
+	remaskTimer    *time.Timer
+	demo           *demo.State // Fake versions and database in demo mode (nil otherwise)
 	dbProvider     string
 	dbConnected    bool
 	dbError        string
 	dbConfigError  bool
 	envVarName     string // Environment variable name (e.g., "DATABASE_URL")
 	isHardcoded    bool   // True if URL is hardcoded in schema/config
+	urlSource      string // Where the URL came from, e.g. ".env.local (DATABASE_URL)"
+	urlSourceFile  string // File the URL was read from ("" for the process environment)
+	probe          *database.ProbeResult   // Last connection test of this URL (nil = none)
+	serverVersion  string                  // Reported by the connected server ("" = unknown)
+	extensionsRead bool                    // The installed extensions could be listed (PostgreSQL)
+	extensions     []string                // Installed extensions the schema needs or KnownExtensions names
+	missingExts    []prisma.ExtensionUse   // Extensions the schema needs that are not installed
+	adapter        *prisma.DriverAdapter   // Driver adapter the app connects through (nil = none)
+	viaAdapter     bool                    // The URL is only reachable through the adapter, so no connection was attempted
+	edge           *prisma.EdgeDatabase    // Database migrations are applied to with its own CLI (nil = none)
+	relationMode   string                  // Datasource relationMode ("" = not set)
+	planetScale    bool                    // The database is on PlanetScale
+	supabase       *supabase.Project       // Supabase CLI project (nil = none)
+	supabaseUp     bool                    // The local Supabase stack is running
+	supabaseTarget supabase.Target         // Which Supabase database the URL points at
+	urlParams      *prisma.URLParamsReport // Parameters of the database URL (nil = no URL)
 }
 
+const (
+	// recentFilesLimit is how many recently modified files the panel lists
+	recentFilesLimit = 5
+	// recentFileHighlight is how recent a change must be to be highlighted
+	recentFileHighlight = time.Hour
+)
+
 var _ types.Context = &WorkspaceContext{}
 var _ types.IScrollableContext = &WorkspaceContext{}
+var _ types.ICopyableContext = &WorkspaceContext{}
 
 type WorkspaceContextOpts struct {
-	Gui      *gocui.Gui
-	Tr       *i18n.TranslationSet
-	ViewName string
+	Gui          *gocui.Gui
+	Tr           *i18n.TranslationSet
+	ViewName     string
+	RevealURL    bool
+	RevealPeriod time.Duration
+	Demo         *demo.State
+	Staleness    StaleThresholds // Footer turns amber/red when the information is this old
 }
 
 func NewWorkspaceContext(opts WorkspaceContextOpts) *WorkspaceContext {
@@ -64,12 +106,18 @@ func NewWorkspaceContext(opts WorkspaceContextOpts) *WorkspaceContext {
 	wc := &WorkspaceContext{
 		SimpleContext:   simpleCtx,
 		ScrollableTrait: &ScrollableTrait{},
+		LoadingTrait:    newLoadingTrait(opts.Staleness),
+		ContentTrait:    &ContentTrait{},
 		g:               opts.Gui,
 		tr:              opts.Tr,
 		showMasked:      true, // Default to masked
+		revealURL:       opts.RevealURL,
+		revealPeriod:    opts.RevealPeriod,
+		demo:            opts.Demo,
 	}
 
 	wc.loadVersionInfo()
+	wc.loadDatabaseInfo()
 
 	return wc
 }
@@ -79,6 +127,156 @@ func (w *WorkspaceContext) ID() string {
 	return w.GetViewName()
 }
 
+// CopyText returns the workspace summary as drawn, so the database URL stays
+// masked unless it is currently revealed.
+func (w *WorkspaceContext) CopyText() string {
+	return w.ScrollableTrait.PlainText()
+}
+
+// ToggleURLMask reveals the password in the database URL, or masks it again.
+// A revealed URL is masked again automatically once the reveal period is over.
+// Returns false if revealing is disabled in the config.
+func (w *WorkspaceContext) ToggleURLMask() bool {
+	if !w.revealURL {
+		return false
+	}
+
+	if w.remaskTimer != nil {
+		w.remaskTimer.Stop()
+		w.remaskTimer = nil
+	}
+
+	w.showMasked = !w.showMasked
+	if !w.showMasked && w.revealPeriod > 0 {
+		var timer *time.Timer
+		timer = time.AfterFunc(w.revealPeriod, func() {
+			w.g.Update(func(g *gocui.Gui) error {
+				// A later toggle replaced this timer
+				if w.remaskTimer == timer {
+					w.showMasked = true
+					w.remaskTimer = nil
+				}
+				return nil
+			})
+		})
+		w.remaskTimer = timer
+	}
+	return true
+}
+
+// CanRevealURL reports whether the unmasked database URL may be shown or copied
+func (w *WorkspaceContext) CanRevealURL() bool {
+	return w.revealURL
+}
+
+// IsURLMasked reports whether the database URL is currently drawn masked
+func (w *WorkspaceContext) IsURLMasked() bool {
+	return w.showMasked
+}
+
+// DatabaseURL returns the resolved database URL, with the password masked if
+// masked is set ("" if it couldn't be resolved)
+func (w *WorkspaceContext) DatabaseURL(masked bool) string {
+	if masked {
+		return w.maskedURL
+	}
+	return w.unmaskedURL
+}
+
+// IsPlanetScale reports whether the database is on PlanetScale
+func (w *WorkspaceContext) IsPlanetScale() bool {
+	return w.planetScale
+}
+
+// URLParams returns the parameters of the database URL and their problems,
+// or nil if there is no URL
+func (w *WorkspaceContext) URLParams() *prisma.URLParamsReport {
+	return w.urlParams
+}
+
+// TLSProblem returns why connecting failed if it is about TLS, or
+// database.TLSOK if it connected or failed for another reason
+func (w *WorkspaceContext) TLSProblem() database.TLSProblem {
+	if w.dbConnected || w.dbConfigError || w.dbError == "" {
+		return database.TLSOK
+	}
+	return database.ClassifyTLSError(w.dbError)
+}
+
+// TLSFix returns the URL parameters that work around the TLS problem (nil =
+// none do) and whether they can be added to the .env file the URL is read from
+func (w *WorkspaceContext) TLSFix() (params [][2]string, editable bool) {
+	params = database.TLSFixParams(w.dbProvider, w.TLSProblem())
+	editable = w.envVarName != "" && w.urlSourceFile != "" &&
+		strings.HasPrefix(filepath.Base(w.urlSourceFile), ".env")
+	return params, editable
+}
+
+// DescribeTLSProblem explains a TLS problem and what causes it ("" for TLSOK)
+func DescribeTLSProblem(tr *i18n.TranslationSet, problem database.TLSProblem) string {
+	switch problem {
+	case database.TLSSelfSigned:
+		return tr.TLSProblemSelfSigned
+	case database.TLSRequired:
+		return tr.TLSProblemRequired
+	case database.TLSUnsupported:
+		return tr.TLSProblemUnsupported
+	case database.TLSHostname:
+		return tr.TLSProblemHostname
+	case database.TLSExpired:
+		return tr.TLSProblemExpired
+	}
+	return ""
+}
+
+// URLSourceFile returns the file the database URL was read from: a .env file,
+// or the schema/config it is hardcoded in ("" for the process environment)
+func (w *WorkspaceContext) URLSourceFile() string {
+	return w.urlSourceFile
+}
+
+// DatabaseStatus returns whether the last connection attempt succeeded, and
+// why it didn't otherwise
+func (w *WorkspaceContext) DatabaseStatus() (connected bool, errMsg string) {
+	return w.dbConnected, w.dbError
+}
+
+// TestConnection resolves the database URL again and, if it can connect,
+// pings it attempts times and asks for the server version. The result is
+// shown in the panel until the URL changes.
+func (w *WorkspaceContext) TestConnection(attempts int) (*database.ProbeResult, error) {
+	w.loadDatabaseInfo()
+	w.probe = nil
+	if w.viaAdapter {
+		return nil, errors.New(w.tr.WorkspaceAdapterNoDirect)
+	}
+	if !w.dbConnected {
+		return nil, errors.New(w.dbError)
+	}
+
+	// The demo's fake database answers instantly, as far as it is concerned
+	if w.demo != nil {
+		latencies := make([]time.Duration, attempts)
+		for i := range latencies {
+			latencies[i] = demo.Latency
+		}
+		w.probe = &database.ProbeResult{Latencies: latencies, ServerVersion: demo.ServerVersion}
+		return w.probe, nil
+	}
+
+	probe, err := database.Probe(w.dbProvider, w.unmaskedURL, attempts)
+	if err != nil {
+		w.dbConnected = false
+		w.dbError = err.Error()
+		return nil, err
+	}
+	w.probe = probe
+	if probe.ServerVersion != "" {
+		w.serverVersion = probe.ServerVersion
+	}
+	return probe, nil
+}
+
 // Draw renders the workspace panel (implements Panel interface from app package)
 func (w *WorkspaceContext) Draw(dim boxlayout.Dimensions) error {
 	v, err := w.g.SetView(w.GetViewName(), dim.X0, dim.Y0, dim.X1, dim.Y1, 0)
@@ -91,7 +289,7 @@ func (w *WorkspaceContext) Draw(dim boxlayout.Dimensions) error {
 	w.ScrollableTrait.SetView(v) // ScrollableTrait
 	w.setupView(v)
 
-	// Frame colours based on focus (set after Clear to persist through render)
+	// Frame colours based on focus
 	if w.IsFocused() {
 		v.FrameColor = style.FocusedFrameColor
 		v.TitleColor = style.FocusedTitleColor
@@ -100,13 +298,22 @@ func (w *WorkspaceContext) Draw(dim boxlayout.Dimensions) error {
 		v.TitleColor = style.PrimaryTitleColor
 	}
 
+	// Age of the information, coloured when it may be outdated
+	v.Footer = ""
+	w.applyUpdatedFooter(v, w.tr)
+
 	v.Wrap = true // Enable word wrap
 
+	// Show what a background refresh is loading instead of stale content
+	if w.renderLoadingPlaceholder(v, w.ContentTrait) {
+		return nil
+	}
+
 	// Build content from fields
 	var lines []string
 
 	// Node and Prisma version on one line
-	nodeVersionStyled := style.YellowBold(w.nodeVersion)
+	nodeVersionStyled := style.YellowBold(w.nodeVersion) + w.nodePinIndicator()
 	prismaVersionStyled := style.YellowBold(w.prismaVersion)
 	versionLine := fmt.Sprintf(w.tr.WorkspaceVersionLine, nodeVersionStyled, prismaVersionStyled)
 	if w.prismaGlobal {
@@ -134,12 +341,17 @@ func (w *WorkspaceContext) Draw(dim boxlayout.Dimensions) error {
 	lines = append(lines, "")
 	lines = append(lines, w.buildDatabaseLines()...)
 
+	if len(w.recentFiles) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, w.buildRecentFileLines()...)
+	}
+
 	content := ""
 	for _, line := range lines {
 		content += line + "\n"
 	}
 
-	fmt.Fprint(v, content)
+	w.renderContent(v, content)
 
 	// Adjust scroll and apply origin
 	w.ScrollableTrait.AdjustScroll()
@@ -147,25 +359,78 @@ func (w *WorkspaceContext) Draw(dim boxlayout.Dimensions) error {
 	return nil
 }
 
+// nodePinIndicator says whether the Node.js version is the one the project
+// pins, and which version manager commands run through
+func (w *WorkspaceContext) nodePinIndicator() string {
+	if w.nodePin == nil {
+		return ""
+	}
+
+	var indicator string
+	switch matches, comparable := w.nodePin.Matches(w.nodeVersion); {
+	case !comparable:
+		indicator = " " + style.Gray(fmt.Sprintf(w.tr.WorkspaceNodePinned, w.nodePin.Source, w.nodePin.Version))
+	case matches:
+		indicator = " " + style.Green(fmt.Sprintf(w.tr.WorkspaceNodePinMatch, w.nodePin.Source))
+	default:
+		indicator = " " + style.Red(fmt.Sprintf(w.tr.WorkspaceNodePinMismatch, w.nodePin.Source, w.nodePin.Version))
+	}
+
+	if w.nodeManager != node.ManagerNone {
+		indicator += " " + style.Gray(fmt.Sprintf(w.tr.WorkspaceNodeViaManager, w.nodeManager))
+	}
+	return indicator
+}
+
+// buildRecentFileLines lists the recently modified Prisma-related files with
+// how long ago they changed; changes within recentFileHighlight stand out
+func (w *WorkspaceContext) buildRecentFileLines() []string {
+	width := 0
+	for _, file := range w.recentFiles {
+		if len(file.Path) > width {
+			width = len(file.Path)
+		}
+	}
+
+	lines := []string{w.tr.WorkspaceRecentFiles}
+	for _, file := range w.recentFiles {
+		age := time.Since(file.ModTime)
+		ageText := w.tr.WorkspaceRecentFileJustNow
+		if age >= time.Minute {
+			ageText = fmt.Sprintf(w.tr.WorkspaceRecentFileAge, formatAge(age))
+		}
+
+		path := fmt.Sprintf("%-*s", width, file.Path)
+		if age < recentFileHighlight {
+			lines = append(lines, "  "+style.Orange(path)+"  "+style.Orange(ageText))
+		} else {
+			lines = append(lines, "  "+path+"  "+style.Gray(ageText))
+		}
+	}
+	return lines
+}
+
 // setupView configures the view with common settings (replaces BasePanel.SetupView)
 func (w *WorkspaceContext) setupView(v *gocui.View) {
-	v.Clear()
 	v.Frame = true
 	v.Title = w.tr.PanelTitleWorkspace
 	v.FrameRunes = style.DefaultFrameRunes
 }
 
-// Refresh reloads all workspace information
-func (w *WorkspaceContext) Refresh() {
-	// Save current scroll position
-	currentOriginY := w.ScrollableTrait.GetOriginY()
+// HasPrismaCLI reports whether the Prisma CLI could be run when the versions
+// were last loaded
+func (w *WorkspaceContext) HasPrismaCLI() bool {
+	return w.prismaFound
+}
 
-	// Reload information
+// RefreshVersions reloads the Node and Prisma versions and the git status
+func (w *WorkspaceContext) RefreshVersions() {
 	w.loadVersionInfo()
-	w.loadDatabaseInfo()
+}
 
-	// Restore scroll position (will be adjusted by AdjustScroll in Draw if needed)
-	w.ScrollableTrait.SetOriginY(currentOriginY)
+// RefreshDatabase reloads the datasource and pings the database
+func (w *WorkspaceContext) RefreshDatabase() {
+	w.loadDatabaseInfo()
 }
 
 // ScrollUpByWheel scrolls up by wheel increment (delegates to ScrollableTrait)
@@ -181,20 +446,32 @@ func (w *WorkspaceContext) ScrollDownByWheel() {
 func (w *WorkspaceContext) loadVersionInfo() {
 	cwd, _ := os.Getwd()
 
-	// Node version
-	if nodeVer, err := node.GetVersion(); err == nil {
-		w.nodeVersion = nodeVer.Version
+	// Node version, and the one the project pins
+	w.nodePin, w.nodeManager = node.FindPin(cwd), node.ManagerNone
+	if w.demo != nil {
+		w.nodeVersion = demo.NodeVersion
 	} else {
-		w.nodeVersion = w.tr.WorkspaceVersionNotFound
+		w.nodeManager, _ = node.ExecManagerFor(cwd)
+		if nodeVer, err := node.GetProjectVersion(cwd); err == nil {
+			w.nodeVersion = nodeVer.Version
+		} else {
+			w.nodeVersion = w.tr.WorkspaceVersionNotFound
+		}
 	}
 
 	// Prisma version
-	if prismaVer, err := prisma.GetVersion(cwd); err == nil {
+	if w.demo != nil {
+		w.prismaVersion = demo.PrismaVersion
+		w.prismaGlobal = false
+		w.prismaFound = true
+	} else if prismaVer, err := prisma.GetVersion(cwd); err == nil && prismaVer != nil {
 		w.prismaVersion = prismaVer.Version
 		w.prismaGlobal = prismaVer.IsGlobal
+		w.prismaFound = true
 	} else {
 		w.prismaVersion = w.tr.WorkspaceVersionNotFound
 		w.prismaGlobal = false
+		w.prismaFound = false
 	}
 
 	// Git info
@@ -205,17 +482,19 @@ func (w *WorkspaceContext) loadVersionInfo() {
 
 	// Check schema.prisma modification status (only if git repo)
 	if w.isGitRepo {
-		schemaPath := filepath.Join(cwd, prisma.SchemaDirName, prisma.SchemaFileName)
+		schemaPath := prisma.SchemaPath(cwd)
 		w.schemaModified = git.IsFileModified(cwd, schemaPath)
 	} else {
 		w.schemaModified = false
 	}
 
-	// Load database info
-	w.loadDatabaseInfo()
+	w.recentFiles = prisma.RecentFiles(cwd, recentFilesLimit)
 }
 
 func (w *WorkspaceContext) loadDatabaseInfo() {
+	// A connection test stays valid as long as the URL does
+	previousURL := w.unmaskedURL
+
 	// Reset fields
 	w.dbProvider = ""
 	w.unmaskedURL = ""
@@ -225,12 +504,29 @@ func (w *WorkspaceContext) loadDatabaseInfo() {
 	w.dbConfigError = false
 	w.envVarName = ""
 	w.isHardcoded = false
+	w.urlSource = ""
+	w.urlSourceFile = ""
+	w.serverVersion = ""
+	w.extensionsRead = false
+	w.extensions = nil
+	w.missingExts = nil
+	w.adapter = nil
+	w.viaAdapter = false
+	w.edge = nil
+	w.relationMode = ""
+	w.planetScale = false
+	w.supabaseTarget = supabase.TargetNone
+	w.urlParams = nil
 
 	cwd, err := os.Getwd()
 	if err != nil {
 		w.dbError = w.tr.WorkspaceErrorGetWorkingDirectory
 		return
 	}
+	w.adapter = prisma.DetectDriverAdapter(cwd, "")
+	w.relationMode = prisma.ProjectRelationMode(cwd)
+	w.supabase = supabase.Detect(cwd)
+	w.supabaseUp = w.supabase != nil && w.supabase.LocalRunning()
 
 	// Get datasource from schema
 	ds, err := prisma.GetDatasource(cwd)
@@ -270,6 +566,19 @@ func (w *WorkspaceContext) loadDatabaseInfo() {
 	w.maskedURL = prisma.MaskPassword(ds.URL)
 	w.envVarName = ds.EnvVarName
 	w.isHardcoded = ds.IsHardcoded
+	w.urlSource = w.describeURLSource(cwd, ds)
+	w.urlSourceFile = ds.URLSource
+	w.adapter = prisma.DetectDriverAdapter(cwd, ds.URL)
+	w.edge = prisma.DetectEdgeDatabase(cwd, ds.URL)
+	w.planetScale = prisma.IsPlanetScale(cwd, ds.URL)
+	if w.supabase != nil {
+		w.supabaseTarget = w.supabase.TargetOf(ds.URL)
+	}
+	directURL, _ := prisma.GetDirectURL(cwd)
+	w.urlParams = prisma.InspectURLParams(ds.Provider, ds.URL, directURL)
+	if ds.URL != previousURL {
+		w.probe = nil
+	}
 
 	// Try to connect to database
 	if ds.URL == "" {
@@ -282,6 +591,25 @@ func (w *WorkspaceContext) loadDatabaseInfo() {
 		return
 	}
 
+	// An edge database behind a driver adapter can't be reached from here;
+	// that is how it is meant to be used, not a connection failure
+	if w.adapter != nil && !prisma.CanConnectDirectly(ds.URL) {
+		w.viaAdapter = true
+		return
+	}
+
+	// The demo's fake database is reachable unless a test refuses connections
+	if w.demo != nil {
+		if w.demo.ConnectionError != "" {
+			w.dbError = w.demo.ConnectionError
+			return
+		}
+		w.dbConnected = true
+		w.serverVersion = demo.ServerVersion
+		w.checkExtensions(cwd, demo.Extensions)
+		return
+	}
+
 	// Attempt connection
 	client, err := database.NewClientFromDSN(ds.Provider, ds.URL)
 	if err != nil {
@@ -298,6 +626,40 @@ func (w *WorkspaceContext) loadDatabaseInfo() {
 
 	// Connection successful
 	w.dbConnected = true
+
+	if version, err := client.ServerVersion(); err == nil {
+		w.serverVersion = version
+	}
+	if database.HasExtensions(ds.Provider) {
+		if installed, err := client.Extensions(); err == nil {
+			w.checkExtensions(cwd, installed)
+		}
+	}
+}
+
+// checkExtensions keeps the installed extensions worth showing and the ones
+// the schema needs but the database lacks
+func (w *WorkspaceContext) checkExtensions(cwd string, installed []string) {
+	var required []prisma.ExtensionUse
+	if schema, err := os.ReadFile(prisma.SchemaPath(cwd)); err == nil {
+		required = prisma.RequiredExtensions(string(schema))
+	}
+
+	relevant := make(map[string]bool)
+	for _, name := range prisma.KnownExtensions {
+		relevant[name] = true
+	}
+	for _, use := range required {
+		relevant[use.Name] = true
+	}
+
+	w.extensionsRead = true
+	for _, name := range installed {
+		if relevant[name] {
+			w.extensions = append(w.extensions, name)
+		}
+	}
+	w.missingExts = prisma.MissingExtensions(required, installed)
 }
 
 func (w *WorkspaceContext) buildDatabaseLines() []string {
@@ -312,6 +674,9 @@ func (w *WorkspaceContext) buildDatabaseLines() []string {
 	if w.dbConnected {
 		statusStyled := style.GreenBold(w.tr.WorkspaceConnected)
 		providerLine = fmt.Sprintf(w.tr.WorkspaceProviderLine, providerName, statusStyled)
+	} else if w.viaAdapter {
+		statusStyled := style.YellowBold(w.tr.WorkspaceViaAdapter)
+		providerLine = fmt.Sprintf(w.tr.WorkspaceProviderLine, providerName, statusStyled)
 	} else if w.dbError != "" {
 		if w.isConfigurationError() {
 			statusStyled := style.RedBold(w.tr.WorkspaceNotConfigured)
@@ -339,6 +704,12 @@ func (w *WorkspaceContext) buildDatabaseLines() []string {
 		} else {
 			lines = append(lines, displayURL)
 		}
+		if w.urlSource != "" {
+			lines = append(lines, style.Gray(fmt.Sprintf(w.tr.WorkspaceURLSource, w.urlSource)))
+		}
+		if w.urlParams != nil && w.urlParams.Problems() > 0 {
+			lines = append(lines, style.Orange(fmt.Sprintf(w.tr.WorkspaceURLParamProblems, w.urlParams.Problems())))
+		}
 	} else if w.dbError != "" && w.isConfigurationError() {
 		// Only show error in URL field if it's a configuration issue
 		// Apply styling: bold+red env var name, red "not configured"
@@ -352,15 +723,108 @@ func (w *WorkspaceContext) buildDatabaseLines() []string {
 		lines = append(lines, w.tr.WorkspaceNotSet)
 	}
 
+	// Driver adapter the app connects through
+	if w.adapter != nil {
+		lines = append(lines, style.Gray(fmt.Sprintf(w.tr.WorkspaceDriverAdapter, w.adapter.Name, w.adapter.Package)))
+		if w.viaAdapter {
+			lines = append(lines, style.Gray(w.tr.WorkspaceAdapterNoDirect))
+		}
+		if w.edge != nil {
+			lines = append(lines, style.Gray(fmt.Sprintf(w.tr.WorkspaceEdgeMigrations, w.edge.CLI)))
+		}
+	}
+
+	// Relations without foreign keys, as PlanetScale-style databases need
+	if w.relationMode == "prisma" {
+		lines = append(lines, style.Gray(w.tr.WorkspaceRelationModePrisma))
+	}
+	if w.planetScale {
+		lines = append(lines, style.Gray(w.tr.WorkspacePlanetScale))
+	}
+
+	// Supabase's local stack, and whether the URL is the local or the remote database
+	if w.supabase != nil {
+		if w.supabaseUp {
+			lines = append(lines, style.Gray(fmt.Sprintf(w.tr.WorkspaceSupabaseRunning, w.supabase.StudioURL())))
+		} else {
+			lines = append(lines, style.Gray(w.tr.WorkspaceSupabaseStopped))
+		}
+		switch w.supabaseTarget {
+		case supabase.TargetLocal:
+			lines = append(lines, style.Gray(w.tr.WorkspaceSupabaseTargetLocal))
+		case supabase.TargetRemote:
+			lines = append(lines, style.Gray(w.tr.WorkspaceSupabaseTargetRemote))
+		}
+	}
+
+	// Result of the last connection test
+	if w.dbConnected && w.probe != nil {
+		lines = append(lines, style.Gray(fmt.Sprintf(w.tr.WorkspaceLatency,
+			FormatLatency(w.probe.Avg()), FormatLatency(w.probe.Min()), FormatLatency(w.probe.Max()), len(w.probe.Latencies))))
+	}
+
+	// Server version and, for PostgreSQL, the extensions that matter
+	if w.dbConnected {
+		if w.serverVersion != "" {
+			lines = append(lines, style.Gray(fmt.Sprintf(w.tr.WorkspaceServerVersion, w.serverVersion)))
+		}
+		if w.extensionsRead {
+			installed := w.tr.WorkspaceExtensionsNone
+			if len(w.extensions) > 0 {
+				installed = strings.Join(w.extensions, ", ")
+			}
+			lines = append(lines, style.Gray(fmt.Sprintf(w.tr.WorkspaceExtensions, installed)))
+		}
+		for _, use := range w.missingExts {
+			lines = append(lines, style.Orange(fmt.Sprintf(w.tr.WorkspaceExtensionMissing, use.Name, use.Reason)))
+		}
+	}
+
 	// Show detailed error message if disconnected (not configuration error)
 	if !w.dbConnected && w.dbError != "" && !w.isConfigurationError() {
 		lines = append(lines, style.Red(fmt.Sprintf(w.tr.WorkspaceErrorFormat, w.dbError)))
 	}
 
+	// What a TLS error likely means, and the URL parameters that fix it
+	if problem := w.TLSProblem(); problem != database.TLSOK {
+		lines = append(lines, style.Orange(DescribeTLSProblem(w.tr, problem)))
+		if params, editable := w.TLSFix(); params != nil {
+			hint := fmt.Sprintf(w.tr.WorkspaceTLSFixManual, database.FormatTLSParams(params))
+			if editable {
+				hint = fmt.Sprintf(w.tr.WorkspaceTLSFix, database.FormatTLSParams(params))
+			}
+			lines = append(lines, style.Gray(hint))
+		}
+	}
+
 	return lines
 }
 
+// describeURLSource names where the database URL was read from: the process
+// environment, a .env file or the schema/config it is hardcoded in
+func (w *WorkspaceContext) describeURLSource(cwd string, ds *prisma.Datasource) string {
+	source := w.tr.WorkspaceURLSourceProcessEnv
+	if ds.URLSource != "" {
+		source = ds.URLSource
+		if rel, err := filepath.Rel(cwd, ds.URLSource); err == nil {
+			source = rel
+		}
+	}
+	if ds.EnvVarName != "" {
+		source += " (" + ds.EnvVarName + ")"
+	}
+	return source
+}
+
 // isConfigurationError checks if the error is a configuration issue
 func (w *WorkspaceContext) isConfigurationError() bool {
 	return w.dbConfigError
 }
+
+// FormatLatency rounds a round trip time for display, e.g. "1.3ms"
+func FormatLatency(d time.Duration) string {
+	if d >= time.Second {
+		return d.Round(10 * time.Millisecond).String()
+	}
+	return d.Round(100 * time.Microsecond).String()
+}
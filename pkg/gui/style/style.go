@@ -2,18 +2,43 @@ package style
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 )
 
+// ansiEscapeRe matches ANSI colour and cursor escape sequences
+var ansiEscapeRe = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// Strip removes ANSI escape sequences, e.g. before writing text to a file.
+func Strip(text string) string {
+	return ansiEscapeRe.ReplaceAllString(text, "")
+}
+
+// colorEnabled is false when colours are turned off (NO_COLOR or --no-color)
+var colorEnabled = true
+
+// SetColorEnabled turns colours on or off. Without colours, text is still
+// made bold and the theme marks focus and selection with attributes only.
+func SetColorEnabled(enabled bool) {
+	colorEnabled = enabled
+	applyTheme(enabled)
+}
+
+// ColorEnabled reports whether colours are on
+func ColorEnabled() bool {
+	return colorEnabled
+}
+
 // Stylize applies combined ANSI styling (foreground colour code + bold flag).
-// fgCode is a raw ANSI colour code such as "31" (red) or "38;5;208" (orange).
+// fgCode is a raw ANSI colour code such as "31" (red) or "38;5;208" (orange);
+// it is left out when colours are off.
 // If both fgCode and bold are empty/false the original text is returned unchanged.
 func Stylize(text string, fgCode string, bold bool) string {
 	if text == "" {
 		return text
 	}
 	codes := make([]string, 0, 2)
-	if fgCode != "" {
+	if fgCode != "" && colorEnabled {
 		codes = append(codes, fgCode)
 	}
 	if bold {
@@ -20,4 +20,35 @@ var (
 
 	// List selection colour
 	SelectionBgColor = gocui.ColorBlue
+
+	// Title and footer of a panel whose data is getting old / is outdated
+	StaleTitleColor    = gocui.ColorYellow
+	OutdatedTitleColor = gocui.ColorRed
 )
+
+// applyTheme sets the theme colours; without colours, focus is shown in bold
+// and the selection in reverse video
+func applyTheme(color bool) {
+	if color {
+		PrimaryFrameColor = gocui.ColorWhite
+		FocusedFrameColor = gocui.ColorGreen
+		PrimaryTitleColor = gocui.ColorWhite | gocui.AttrNone
+		FocusedTitleColor = gocui.ColorGreen | gocui.AttrBold
+		FocusedActiveTabColor = gocui.ColorGreen | gocui.AttrBold
+		PrimaryActiveTabColor = gocui.ColorGreen | gocui.AttrNone
+		SelectionBgColor = gocui.ColorBlue
+		StaleTitleColor = gocui.ColorYellow
+		OutdatedTitleColor = gocui.ColorRed
+		return
+	}
+
+	PrimaryFrameColor = gocui.ColorDefault
+	FocusedFrameColor = gocui.ColorDefault | gocui.AttrBold
+	PrimaryTitleColor = gocui.ColorDefault
+	FocusedTitleColor = gocui.ColorDefault | gocui.AttrBold
+	FocusedActiveTabColor = gocui.ColorDefault | gocui.AttrBold | gocui.AttrUnderline
+	PrimaryActiveTabColor = gocui.ColorDefault | gocui.AttrUnderline
+	SelectionBgColor = gocui.ColorDefault | gocui.AttrReverse
+	StaleTitleColor = gocui.ColorDefault | gocui.AttrUnderline
+	OutdatedTitleColor = gocui.ColorDefault | gocui.AttrBold | gocui.AttrUnderline
+}
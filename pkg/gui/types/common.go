@@ -1,7 +1,11 @@
 package types
 
 import (
+	"time"
+
 	"github.com/dokadev/lazyprisma/pkg/i18n"
+	"github.com/dokadev/lazyprisma/pkg/notify"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
 )
 
 // ConfirmOpts configures a confirmation popup.
@@ -51,6 +55,17 @@ type IPopupHandler interface {
 	ErrorHandler(err error) error
 }
 
+// RefreshScope selects which panels a refresh reloads.
+type RefreshScope uint8
+
+const (
+	RefreshWorkspace  RefreshScope = 1 << iota // Versions and database connection
+	RefreshMigrations                          // Migration list and the action-needed data in Details
+	RefreshSchema                              // Schema and schema history in Details
+
+	RefreshEverything = RefreshWorkspace | RefreshMigrations | RefreshSchema
+)
+
 // IGuiCommon is the common interface available to controllers via dependency injection.
 type IGuiCommon interface {
 	IPopupHandler
@@ -74,8 +89,48 @@ type IControllerHost interface {
 	TryStartCommand(name string) bool
 	LogCommandBlocked(name string)
 	FinishCommand()
+	// TrackDeployProgress shows "Applying n/total" in the status bar while the
+	// running command applies migrations; it is reset by FinishCommand.
+	TrackDeployProgress(total int)
+
+	// RequestRefresh reloads the panels of scope in the background. Requests
+	// made while a refresh or another command is running are merged into a
+	// single follow-up refresh; onComplete runs on the UI thread once a
+	// refresh started after the request has finished.
+	RequestRefresh(scope RefreshScope, onComplete ...func())
+	// RefreshPanels reloads the panels of scope (blocking). Only for commands
+	// that already hold the command lock.
+	RefreshPanels(scope RefreshScope)
+	// CommandInProgress reports whether a command other than a refresh is running
+	CommandInProgress() bool
+
+	// ShowFailedOutput closes the modal, focuses the Output panel and scrolls
+	// it to the start of the last failed command's output
+	ShowFailedOutput()
+
+	// ShowMissingTool explains that a command could not start because node or
+	// npx is missing, and returns true, if err says so
+	ShowMissingTool(commandName string, err error) bool
+
+	// Action masking from the project config
+	IsActionDisabled(action string) bool
+	// RejectDisabledAction explains why action is disabled and returns true
+	// if it is
+	RejectDisabledAction(action string) bool
+	// RejectInDemoMode explains that name can't run in demo mode; returns true in demo mode
+	RejectInDemoMode(name string) bool
+	// RejectInReadOnly explains that name can't run while another instance has
+	// the project open; returns true in a read-only instance
+	RejectInReadOnly(name string) bool
+	// RejectOpenTransaction explains that name would wait for the locks of
+	// the SQL console's open transaction; returns true while it has writes
+	RejectOpenTransaction(name string) bool
+
+	// RecordCommand appends an executed command to the audit trail.
+	RecordCommand(args []string, env []string, exitCode int, duration time.Duration)
+	// NotifyWebhooks posts the result of a command to the project's webhooks.
+	NotifyWebhooks(summary notify.Summary)
 
-	// Full refresh with callbacks
-	RefreshAll(onComplete ...func()) bool
-	RefreshPanels()
+	// PrismaRunner runs Prisma CLI commands whose output is parsed (e.g. validate)
+	PrismaRunner() prisma.Runner
 }
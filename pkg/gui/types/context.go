@@ -76,3 +76,20 @@ type IScrollableContext interface {
 	ScrollToTop()
 	ScrollToBottom()
 }
+
+// IWrappableContext is a context whose long lines can either wrap or be
+// truncated and scrolled horizontally.
+type IWrappableContext interface {
+	Context
+
+	ToggleWrap() bool
+	ScrollLeft()
+	ScrollRight()
+}
+
+// ICopyableContext is a context whose contents can be copied to the clipboard.
+type ICopyableContext interface {
+	Context
+
+	CopyText() string
+}
@@ -5,13 +5,22 @@ type TranslationSet struct {
 	PanelTitleOutput    string
 	PanelTitleWorkspace string
 	PanelTitleDetails   string
+	PanelTitleSchema    string
+	PanelUpdatedJustNow string
+	PanelUpdatedAgo     string
 
 	// Tab Labels
-	TabLocal        string
-	TabPending      string
-	TabDBOnly       string
-	TabDetails      string
-	TabActionNeeded string
+	TabLocal         string
+	TabPending       string
+	TabDBOnly        string
+	TabDetails       string
+	TabActionNeeded  string
+	TabSchema        string
+	TabPreview       string
+	TabIntrospection string
+	TabData          string
+	TabQuery         string
+	TabSchemaHistory string
 
 	// Error Messages (general)
 	ErrorFailedGetWorkingDirectory   string
@@ -27,24 +36,115 @@ type TranslationSet struct {
 	// Modal Titles
 	ModalTitleError                     string
 	ModalTitleDBConnectionRequired      string
+	ModalTitleFixMerge                  string
+	ModalTitleFixMergeFailed            string
 	ModalTitleMigrationError            string
 	ModalTitleMigrationCreated          string
 	ModalTitleMigrationFailed           string
 	ModalTitleMigrateDeploySuccess      string
 	ModalTitleMigrateDeployFailed       string
 	ModalTitleMigrateDeployError        string
+	ModalTitleEdgeDeploy                string
+	ModalTitleDeploy                    string
+	ModalTitleSimulateDeploySuccess     string
+	ModalTitleSimulateDeployFailed      string
+	ModalTitleSimulateDeployError       string
+	ModalTitleSimulateOnTarget          string
+	ModalTitleDeployCountdown           string
+	ModalTitleScheduledDeploy           string
+	ModalTitleEnterDeployTime           string
+	ModalTitleInvalidDeployTime         string
 	ModalTitleGenerateSuccess           string
 	ModalTitleGenerateFailed            string
 	ModalTitleGenerateError             string
 	ModalTitleSchemaValidationFailed    string
+	ModalTitleSchemaErrors              string
 	ModalTitleNoMigrationSelected       string
 	ModalTitleCannotResolveMigration    string
 	ModalTitleMigrateResolveSuccess     string
+	ModalTitleRetryMigrationSuccess     string
+	ModalTitleExtensionsCreated         string
+	ModalTitleCreateExtensionsFailed    string
+	ModalTitleRetryMigrationFailed      string
 	ModalTitleMigrateResolveFailed      string
 	ModalTitleMigrateResolveError       string
 	ModalTitleStudioError               string
+	ModalTitleToolchainMissing          string
+	ModalTitlePrismaMissing             string
+	ModalTitleInstallPrismaSuccess      string
+	ModalTitleInstallPrismaFailed       string
 	ModalTitleStudioStopped             string
 	ModalTitleStudioStarted             string
+	ModalTitleStudioPortInUse           string
+	ModalTitleStudioAlreadyRunning      string
+	ModalTitleStudioManager             string
+	ModalTitleMigrationImpact           string
+	ModalTitleBlame                     string
+	ModalTitleBlameResults              string
+	ModalTitleModelUsage                string
+	ModalTitleModelUsageResults         string
+	ModalTitleModelKeys                 string
+	ModalTitlePeekData                  string
+	ModalTitlePeekDataTables            string
+	ModalTitleSQLConsole                string
+	ModalTitleSQLStatement              string
+	ModalTitleCommitTransaction         string
+	ModalTitleOpenProject               string
+	ModalTitleModelKeysResults          string
+	ModalTitleModelKeysDiverged         string
+	ModalTitleGoToLine                  string
+	ModalTitleFormatSQL                 string
+	ModalTitleSchemaHistory             string
+	ModalTitlePreviewMigration          string
+	ModalTitleIntrospection             string
+	ModalTitleIntrospect                string
+	ModalTitleSplitMigration            string
+	ModalTitleSplitMigrationPart        string
+	ModalTitleSchemaRevision            string
+	ModalTitleCompareBranches           string
+	ModalTitleBranchComparison          string
+	ModalTitleEnvironments              string
+	ModalTitlePlanetScaleBranches       string
+	ModalTitlePlanetScaleBranchesOf     string
+	ModalTitleDeployToEnvironment       string
+	ModalTitleDeployToProtected         string
+	ModalTitleActionDisabled            string
+	ModalTitleDemoMode                  string
+	ModalTitleReadOnly                  string
+	ModalTitleQuitUncommitted           string
+	ModalTitleTransactionOpen           string
+	ModalTitleRevealURLDisabled         string
+	ModalTitleWorkspaceActions          string
+	ModalTitleSupabase                  string
+	ModalTitleSupabaseProject           string
+	ModalTitleURLParams                 string
+	ModalTitleAddDirectURL              string
+	ModalTitleFixTLS                    string
+	ModalTitleFixTLSFailed              string
+	ModalTitleDirectURLAdded            string
+	ModalTitleAddDirectURLFailed        string
+	ModalTitleEditorFailed              string
+	ModalTitleMigrationActions          string
+	ModalTitleConnectionTest            string
+	ModalTitleAuditLog                  string
+	ModalTitleAuditLogPath              string
+	ModalTitleCommandHistory            string
+	ModalTitleCommandHistoryFiltered    string
+	ModalTitleHistoryFilter             string
+	ModalTitleRerunCommand              string
+	ModalTitleRerunProtected            string
+	ModalTitleRerunFailed               string
+	ModalTitleUsageStats                string
+	ModalTitleGenerators                string
+	ModalTitleDoctor                    string
+	ModalTitleEngineOverrides           string
+	ModalTitleScripts                   string
+	ModalTitleReviewMigration           string
+	ModalTitleReviewMigrationProgress   string
+	ModalTitleRunScript                 string
+	ModalTitleRunScriptIn               string
+	ModalTitleRunScriptProtected        string
+	ModalTitleRunScriptFailed           string
 	ModalTitleNoSelection               string
 	ModalTitleCannotDelete              string
 	ModalTitleDeleteError               string
@@ -60,8 +160,31 @@ type TranslationSet struct {
 	ModalTitleValidationFailed          string
 	ModalTitleMigrateDev                string
 	ModalTitleResolveMigration          string
+	ModalTitleResolveDBOnlyMigration    string
+	ModalTitleResolveChecksumMismatch   string
+	ModalTitleDeleteHistoryRow          string
+	ModalTitleAcceptChecksum            string
+	ModalTitleRestoreMigrationFailed    string
+	ModalTitleHistoryUpdateFailed       string
+	ModalTitleInitMigrationTracking     string
+	ModalTitleMarkAllApplied            string
+	ModalTitleExportPendingSQL          string
+	ModalTitleEnterExportPath           string
+	ModalTitleExportedSQL               string
+	ModalTitleExportSQLFailed           string
+	ModalTitleImportReceipt             string
+	ModalTitleEnterReceiptPath          string
+	ModalTitleResolveFailedMigration    string
 	ModalTitleCopyToClipboard           string
 	ModalTitleEnterMigrationName        string
+	ModalTitleConcurrentIndexTable      string
+	ModalTitleConcurrentIndexColumns    string
+	ModalTitleConcurrentIndex           string
+	ModalTitleDeadColumns               string
+	ModalTitleVerifyChecksums           string
+	ModalTitleChecksumReport            string
+	ModalTitleDeadColumnsFound          string
+	ModalTitleDropDeadColumns           string
 
 	// Modal Messages
 	ModalMsgMigrationCreatedSuccess     string
@@ -69,8 +192,40 @@ type TranslationSet struct {
 	ModalMsgMigrationFailedWithCode     string
 	ModalMsgCheckOutputPanel            string
 	ModalMsgMigrationsAppliedSuccess    string
+	ModalMsgSimulateDeploySuccess       string
+	ModalMsgSimulatePendingFailed       string
+	ModalMsgSimulateReplayFailed        string
+	ModalMsgSimulateSkipped             string
+	ModalMsgSimulateDBOnlyNote          string
+	ModalMsgSimulateTargetUnchanged     string
+	ModalMsgSimulateSetupFailed         string
+	ModalMsgSimulateNoPending           string
+	ModalMsgSimulateOnTarget            string
+	ModalMsgSimulateFailedMigration     string
+	ModalMsgDeployCountdown             string
+	ModalMsgDeployScheduled             string
+	ModalMsgDeployAbortHint             string
+	ModalMsgDeployScheduledKeepOpen     string
+	ModalMsgDeployTimeHint              string
+	ModalMsgInvalidDeployTime           string
+	ModalMsgDeployTimePassed            string
+	ModalMsgDeployNoPending             string
+	ModalMsgExportNoPending             string
+	ModalMsgExportPathHint              string
+	ModalMsgExportFileExists            string
+	ModalMsgExportedSQL                 string
+	ModalMsgExportMarkApplied           string
+	ModalMsgExportMarkAppliedLater      string
+	ModalMsgReceiptPathHint             string
+	ModalMsgReceiptReadFailed           string
+	ModalMsgReceiptEmpty                string
+	ModalMsgReceiptNothingPending       string
 	ModalMsgMigrateDeployFailedWithCode string
 	ModalMsgFailedRunMigrateDeploy      string
+	ModalMsgEdgeDeploy                  string
+	ModalMsgEdgeApplySelected           string
+	ModalMsgEdgeD1Migrations            string
+	ModalMsgEdgeNoHistory               string
 	ModalMsgFailedStartMigrateDeploy    string
 	ModalMsgPrismaClientGenerated       string
 	ModalMsgGenerateFailedSchemaErrors  string
@@ -81,15 +236,182 @@ type TranslationSet struct {
 	ModalMsgSelectMigrationResolve      string
 	ModalMsgOnlyInTransactionResolve    string
 	ModalMsgMigrationNotFailed          string
+	ModalMsgConfirmAcceptChecksum       string
+	ModalMsgDeleteHistoryRowWarning     string
+	ModalMsgDeleteHistoryRowLocal       string
+	ModalMsgDeleteHistoryRowDBOnly      string
+	ModalMsgTypeMigrationToConfirm      string
+	ModalMsgHistoryRowConfirmMismatch   string
+	ModalMsgNoHistoryRow                string
+	ModalMsgSelectHistoryRow            string
+	ModalMsgNoPendingToMark             string
+	ModalMsgMarkedAllApplied            string
+	ModalMsgMarkAllStopped              string
 	ModalMsgMigrationMarkedSuccess      string
+	ModalMsgRetryMigrationSuccess       string
+	ModalMsgRetryMigrationDeployRest    string
+	ModalMsgRetryMigrationFailed        string
+	ModalMsgRetryMigrationResolveFailed string
+	ModalMsgExtensionMigrationDeploy    string
+	ModalMsgExtensionsCreated           string
+	ModalMsgCreateExtensionsFailed      string
+	ModalMsgExtensionMigrationKept      string
+	ModalMsgFailedStartCreateExts       string
+	ModalMsgFailedStartRetryMigration   string
 	ModalMsgMigrateResolveFailedWithCode string
 	ModalMsgFailedRunMigrateResolve     string
 	ModalMsgFailedStartMigrateResolve   string
 	ModalMsgFailedStopStudio            string
 	ModalMsgStudioStopped               string
 	ModalMsgFailedStartStudio           string
+	ModalMsgToolMissing                 string
+	ModalMsgToolchainFixVersionManager  string
+	ModalMsgToolchainFixInstall         string
+	ModalMsgPrismaMissing               string
+	ModalMsgPrismaMissingCommand        string
+	ModalMsgInstallPrismaSuccess        string
+	ModalMsgInstallPrismaFailed         string
+	ModalMsgFailedStartInstallPrisma    string
 	ModalMsgStudioRunningAt             string
 	ModalMsgPressStopStudio             string
+	ModalMsgStudioPortInUse             string
+	ModalMsgStudioPortOwnerUnknown      string
+	ModalMsgStudioChangePort            string
+	ModalMsgStudioAlreadyRunning        string
+	ModalMsgFailedKillStudio            string
+	ModalMsgPressManageStudio           string
+	ModalMsgNoTablesInMigrations        string
+	ImpactHistoryHeader                 string
+	ModalMsgBlameInputHint              string
+	ModalMsgGoToLineHint                string
+	ModalMsgInvalidLineNumber           string
+	ModalMsgLineNotFound                string
+	ModalMsgBlameNotFound               string
+	ModalMsgModelUsageNoModel           string
+	ModalMsgModelUsageNotFound          string
+	ModalMsgMigrationNotInList          string
+	BlameTagIntroduced                  string
+	BlameTagLastChange                  string
+	ModelUsageMatchCount                string
+	ModalMsgModelKeysNoModel            string
+	ModalMsgModelKeysFailed             string
+	ModalMsgDriftNoURL                  string
+	ModalMsgModelKeysNone               string
+	ModelKeyKindPrimary                 string
+	ModelKeyKindUnique                  string
+	ModelKeyKindIndex                   string
+	ModelKeyKindForeignKey              string
+	ModelKeyMatch                       string
+	ModelKeyRenamed                     string
+	ModelKeyChanged                     string
+	ModelKeyMissing                     string
+	ModelKeyExtra                       string
+	ModelKeySchemaSide                  string
+	ModelKeyDatabaseSide                string
+	ModalMsgPeekSelectMigration         string
+	ModalMsgPeekNoTables                string
+	ModalMsgPeekPending                 string
+	ModalMsgPeekFailed                  string
+	ModalMsgQueryNoURL                  string
+	TablePeekTitle                      string
+	TablePeekRowCount                   string
+	TablePeekEmpty                      string
+	ModalMsgQueryFailed                 string
+	ModalMsgImplicitCommit              string
+	ModalMsgExplainFailed               string
+	QueryResultTitle                    string
+	QueryPlanTitle                      string
+	QueryRowCount                       string
+	QueryRowsMore                       string
+	QueryRowsAffected                   string
+	QueryNoRows                         string
+	QueryPlanPlanning                   string
+	QueryPlanExecution                  string
+	QueryPlanIndexes                    string
+	QueryPlanNoIndexes                  string
+	QueryPlanFullScans                  string
+	QueryPlanRolledBack                 string
+	ModalMsgCommitTransaction           string
+	ModalMsgTransactionFailed           string
+	ModalMsgQueryFailedTransactionKept  string
+	QueryUncommitted                    string
+	ModalMsgNoGUIEditor                 string
+	ModalMsgOpenProjectFailed           string
+	GUIEditorUnknown                    string
+	ModalMsgSelectMigrationFormat       string
+	ModalMsgCannotFormatNoSQL           string
+	ModalMsgCannotFormatApplied         string
+	ModalMsgFailedReadMigrationFile     string
+	ModalMsgSQLAlreadyFormatted         string
+	ModalMsgFormatChangesLiterals       string
+	ModalMsgConfirmSaveFormatted        string
+	ModalMsgFormattedSQLSaved           string
+	ModalMsgNoSchemaHistory             string
+	ModalMsgFailedReadSchemaRevision    string
+	ModalMsgPreviewNeedsShadowDatabase  string
+	ModalMsgPreviewMigrationFailed      string
+	ModalMsgIntrospectFailed            string
+	ModalMsgIntrospectMultiFile         string
+	ModalMsgConfirmApplyIntrospect      string
+	ModalMsgFailedBackupSchema          string
+	ModalMsgFailedWriteSchema           string
+	ModalMsgSplitNeedsPreview           string
+	ModalMsgSplitMigrationTables        string
+	ModalMsgSplitMigrationCreated       string
+	ModalMsgSplitMigrationIncomplete    string
+	ModalMsgSplitReferencesLater        string
+	ModalMsgSplitAddReferenced          string
+	ModalMsgFailedReadSchema            string
+	ModalMsgFailedListBranches          string
+	ModalMsgNoOtherBranches             string
+	ModalMsgFailedReadBranchMigrations  string
+	ModalMsgBranchesInSync              string
+	ModalMsgFailedLoadProjectConfig     string
+	ModalMsgNoEnvironments              string
+	ModalMsgNoSupabaseProject           string
+	ModalMsgAddDirectURL                string
+	ModalMsgFixTLS                      string
+	ModalMsgFixTLSInsecure              string
+	ModalMsgAddDirectURLSchemaOnly      string
+	ModalMsgEnterDirectURL              string
+	ModalMsgDirectURLAdded              string
+	ModalMsgPscaleNotFound              string
+	ModalMsgPlanetScaleNoDatabase       string
+	ModalMsgPlanetScaleBranchesFailed   string
+	ModalMsgPlanetScaleNoBranches       string
+	ModalMsgDatasourceNotFromEnv        string
+	ModalMsgDirectURLNotFromEnv         string
+	ModalMsgEnvironmentNoURL            string
+	ModalMsgConfirmDeployToEnvironment  string
+	ModalMsgTypeEnvironmentToConfirm    string
+	ModalMsgProtectedConfirmMismatch    string
+	ModalMsgDeployedToEnvironment       string
+	ModalMsgActionDisabled              string
+	ModalMsgActionDisabledHint          string
+	ModalMsgAuditDisabled               string
+	ModalMsgFailedReadAuditLog          string
+	ModalMsgAuditLogEmpty               string
+	ModalMsgCommandHistoryEmpty         string
+	ModalMsgConfirmRerun                string
+	ModalMsgFailedStartRerun            string
+	ModalMsgUsageStatsDisabled          string
+	ModalMsgFailedReadUsageStats        string
+	ModalMsgUsageStatsEmpty             string
+	ModalMsgUsageStatsLocal             string
+	ModalMsgNoEngineOverrides           string
+	ModalMsgEngineOverridesPassed       string
+	ModalMsgNoGenerators                string
+	ModalMsgAllGeneratorsSkipped        string
+	ModalMsgFailedLoadState             string
+	ModalMsgNoScripts                   string
+	ModalMsgReviewSelectPending         string
+	ModalMsgReviewNoStatements          string
+	ModalMsgScriptsDirHint              string
+	ModalMsgFailedReadScripts           string
+	ModalMsgFailedReadScriptHistory     string
+	ModalMsgConfirmRunScript            string
+	ModalMsgRunScriptConfirmMismatch    string
+	ModalMsgFailedStartScript           string
 	ModalMsgSelectMigrationDelete       string
 	ModalMsgMigrationDBOnly             string
 	ModalMsgCannotDeleteNoLocalFile     string
@@ -101,6 +423,22 @@ type TranslationSet struct {
 	ModalMsgMigrationDeletedSuccess     string
 	ModalMsgFailedCopyClipboard         string
 	ModalMsgCopiedToClipboard           string
+	ModalMsgPanelEmpty                  string
+	ModalMsgRevealURLDisabled           string
+	ModalMsgFailedOpenEditor            string
+	ModalMsgSetEditor                   string
+	ModalMsgNoDatabaseURL               string
+	ModalMsgConnectionOK                string
+	ModalMsgConnectionFailed            string
+	ModalMsgConnectionLatency           string
+	ModalMsgDemoActionDisabled          string
+	ModalMsgReadOnlyActionDisabled      string
+	ModalMsgReadOnlyHint                string
+	ModalMsgReadOnlyConsoleWrite        string
+	ModalMsgQuitUncommitted             string
+	ModalMsgTransactionOpenBlocks       string
+	ModalMsgTransactionOpenHint         string
+	ModalMsgOneStatement                string
 	ModalMsgPendingMigrationsWarning    string
 	ModalMsgCannotCreateWithDBOnly      string
 	ModalMsgResolveDBOnlyFirst          string
@@ -116,18 +454,55 @@ type TranslationSet struct {
 	ModalMsgInputRequired               string
 	ModalMsgManualMigrationCreated      string
 	ModalMsgManualMigrationLocation     string
+	ModalMsgConcurrentIndexTableHint    string
+	ModalMsgConcurrentIndexColumnsHint  string
+	ModalMsgConcurrentIndexWarning      string
+	ModalMsgDeadColumnsFailed           string
+	ModalMsgFixMergeNeedsDB             string
+	ModalMsgFixMergeNothing             string
+	ModalMsgFixMergePlan                string
+	ModalMsgFixMergeWarning             string
+	ModalMsgFixMergeFailed              string
+	ModalMsgVerifyChecksumsFailed       string
+	ModalMsgChecksumsAllMatch           string
+	ModalMsgChecksumsLineEndings        string
+	ModalMsgNoDeadColumns               string
+	ModalMsgDeadColumnsPending          string
+	ModalMsgDropDeadColumnsWarning      string
+	ModalMsgDropColumnsReview           string
+	ModalMsgConcurrentIndexSchemaHint   string
+	ModalMsgConcurrentIndexRecovery     string
 	CopyLabelMigrationName              string
 	CopyLabelMigrationPath              string
 	CopyLabelChecksum                   string
+	CopyLabelPanel                      string
+	CopyLabelDatabaseURL                string
+	CopyLabelMaskedURL                  string
+	CopyLabelCommandLine                string
+	CopyLabelLink                       string
 
 	// Modal Footers
 	ModalFooterInputSubmitCancel string
 	ModalFooterListNavigate      string
 	ModalFooterMessageClose      string
 	ModalFooterConfirmYesNo      string
+	ModalFooterMessageViewOutput string
+	ModalFooterCountdownAbort    string
 
 	// Status Bar
 	StatusStudioOn string
+	StatusOffline  string
+	StatusDemo     string
+	StatusReadOnly string
+	StatusNoPrisma string
+	StatusDownloadingEngines string
+	StatusUncommittedWrites  string
+	StatusApplyingMigration  string
+	StatusCommandStep        string
+	RefreshStepVersions      string
+	RefreshStepDatabase      string
+	RefreshStepMigrations    string
+	RefreshStepSchema        string
 	KeyHintRefresh string
 	KeyHintDev     string
 	KeyHintDeploy  string
@@ -143,34 +518,181 @@ type TranslationSet struct {
 	// Log Actions
 	LogActionMigrateDeploy         string
 	LogMsgRunningMigrateDeploy     string
+	LogActionSimulateDeploy        string
+	LogMsgSimulateScratch          string
+	LogMsgSimulateSteps            string
+	LogMsgSimulatePassed           string
+	LogMsgSimulateFailed           string
+	LogActionDeployScheduled       string
+	LogActionDeployCountdown       string
+	LogMsgDeployScheduledAt        string
+	LogMsgDeployCountdownStarted   string
+	LogActionDeployAborted         string
+	LogMsgDeployAbortedBeforeStart string
+	LogActionExportSQL             string
+	LogMsgExportedSQL              string
 	LogActionMigrateDeployComplete string
 	LogMsgMigrationsAppliedSuccess string
 	LogActionMigrateDeployFailed   string
 	LogMsgMigrateDeployFailedCode  string
+	LogActionHistoryRowDeleted     string
+	LogMsgHistoryRowDeleted        string
+	LogActionChecksumAccepted      string
+	LogMsgChecksumAccepted         string
+	LogActionMigrationRestored     string
+	LogMsgMigrationRestored        string
+	LogActionTableCreated          string
+	LogMsgTableCreated             string
 	LogActionMigrateResolve        string
 	LogMsgMarkingMigration         string
+	LogMsgMarkingAppliedProgress   string
+	LogMsgRetryingMigration        string
+	LogMsgRetriedMigrationSQL      string
+	LogMsgCreatingExtensions       string
+	LogMsgExtensionsCreated        string
+	LogMsgRetryMigrationFailedCode string
+	LogMsgFailedMigrationDetected  string
 	LogActionMigrateResolveComplete string
 	LogMsgMigrationMarked          string
 	LogActionMigrateResolveFailed  string
 	LogMsgMigrateResolveFailedCode string
 	LogActionMigrateResolveError   string
+	LogActionRetryMigration        string
+	LogActionCreateExtensions      string
+	LogActionRetryMigrationFailed  string
+	LogActionFailedMigrationDetected string
 	LogActionGenerate              string
+	LogActionGenerators            string
 	LogMsgRunningGenerate          string
+	LogMsgRunningGenerateSkipping  string
+	LogMsgFailedSaveState          string
 	LogActionGenerateComplete      string
 	LogMsgPrismaClientGeneratedSuccess string
 	LogActionGenerateFailed        string
 	LogMsgCheckingSchemaErrors     string
 	LogActionSchemaValidationFailed string
 	LogMsgFoundSchemaErrors        string
+	LogMsgFailedOpenSchemaLocation string
 	LogActionGenerateError         string
 	LogActionStudio                string
+	LogActionToolchain             string
+	LogMsgToolchainFound           string
+	LogActionInstallPrisma         string
+	LogMsgInstallingPrisma         string
+	LogMsgPrismaInstalled          string
 	LogMsgStartingStudio           string
 	LogActionStudioStarted         string
 	LogMsgStudioListeningAt        string
 	LogActionStudioStopped         string
 	LogMsgStudioHasStopped         string
+	LogMsgKillingOrphanedStudio    string
+	LogMsgAdoptedStudio            string
+	LogMsgStudioProjectListeningAt string
+	LogMsgStudioProjectStopped     string
+	LogMsgStudioInstancesRunning   string
+	LogActionFormatSQL             string
+	LogActionSQLHighlight          string
+	LogActionEnvironmentStatus     string
+	LogActionSupabase              string
+	LogActionAddDirectURL          string
+	LogActionFixTLS                string
+	LogActionPlanetScaleBranches   string
+	LogActionAudit                 string
+	LogActionNetworkFailure        string
+	LogActionDemoMode              string
+	LogActionReadOnly              string
+	LogActionDoctor                string
+	LogActionPreviewMigration      string
+	LogActionIntrospect            string
+	LogActionModelKeys             string
+	LogActionSplitMigration        string
+	LogActionRunScript             string
+	LogActionRunScriptComplete     string
+	LogActionRunScriptFailed       string
+	LogActionRerunFailed           string
+	LogMsgQueryingEnvironments     string
+	LogMsgSupabaseOpened           string
+	LogMsgSupabaseTargetLocal      string
+	LogMsgSupabaseTargetRemote     string
+	LogMsgDirectURLAdded           string
+	LogMsgTLSParamsAdded           string
+	LogMsgPlanetScaleListing       string
+	LogMsgPlanetScaleListed        string
+	LogMsgDeployingToEnvironment   string
+	LogMsgAuditWriteFailed         string
+	LogActionWebhook               string
+	LogMsgWebhookSent              string
+	LogMsgWebhookFailed            string
+	LogMsgWebhookNoURL             string
+	LogMsgNetworkFailure           string
+	LogMsgDemoCommandSkipped       string
+	LogMsgReadOnlyCommandSkipped   string
+	LogMsgNetworkFailureOffline    string
+	LogMsgNetworkFailureOnline     string
+	LogMsgRunningDoctor            string
+	LogActionTestConnection        string
+	LogMsgTestingConnection        string
+	LogMsgConnectionTested         string
+	LogMsgPreviewingMigration      string
+	LogMsgComparingKeys            string
+	LogMsgKeysInSync               string
+	LogMsgKeysDiverge              string
+	LogActionPeekData              string
+	LogMsgPeekingTable             string
+	LogMsgTablePeeked              string
+	LogActionSQLConsole            string
+	LogMsgRunningQuery             string
+	LogMsgExplainingQuery          string
+	LogMsgQueryPlanned             string
+	LogMsgWriteUncommitted         string
+	LogMsgTransactionCommitted     string
+	LogMsgTransactionRolledBack    string
+	LogActionOpenProject           string
+	LogMsgOpenedProject            string
+	LogMsgSplitMigrationWritten    string
+	LogMsgMigrationPreviewReady    string
+	LogMsgIntrospecting            string
+	LogMsgIntrospectionReady       string
+	LogMsgIntrospectionApplied     string
+	LogMsgIntrospectionCreated     string
+	LogMsgIntrospectionDiscarded   string
+	LogMsgDoctorPassed             string
+	LogMsgRunningScript            string
+	LogMsgScriptSucceeded          string
+	LogMsgScriptFailed             string
+	LogMsgRerunFailed              string
+	LogMsgScriptLogSaved           string
+	LogMsgScriptLogFailed          string
+	LogMsgScriptHistoryFailed      string
+	LogMsgDoctorFailed             string
+	LogMsgEngineOverrides          string
+	LogMsgCommandSummary           string
+	LogMsgInvocationCommand        string
+	LogMsgInvocationDir            string
+	LogMsgInvocationEnv            string
+	SummaryCommandDone             string
+	SummaryCommandFailed           string
+	SummaryStatusUpToDate          string
+	SummaryStatus                  string
+	SummaryDeployNoPending         string
+	SummaryDeployApplied           string
+	SummaryDeployFailed            string
+	SummaryGenerate                string
+	SummaryGenerateIn              string
+	SummaryValidateValid           string
+	SummaryValidateErrors          string
+	LogMsgCommandTarget            string
+	LogMsgCommandTargetNone        string
 	LogActionMigrateDev            string
+	LogActionDeadColumns           string
+	LogActionFixMerge              string
 	LogMsgCreatingMigration        string
+	LogMsgFindingDeadColumns       string
+	LogActionVerifyChecksums       string
+	LogMsgVerifyingChecksums       string
+	LogMsgChecksumsVerified        string
+	LogMsgDeadColumnsFound         string
+	LogMsgCreatingMigrationFlags   string
 	LogActionMigrateComplete       string
 	LogMsgMigrationCreatedSuccess  string
 	LogActionMigrateFailed         string
@@ -180,28 +702,203 @@ type TranslationSet struct {
 	LogActionDeleted               string
 	LogMsgMigrationDeleted         string
 	SuccessAllPanelsRefreshed      string
+	SuccessPanelsRefreshed         string
+	RefreshScopeWorkspace          string
+	RefreshScopeMigrations         string
+	RefreshScopeSchema             string
+	LogMsgRefreshQueued            string
 	ActionRefresh                  string
+	ActionFilesChanged             string
+	LogMsgFilesChangedMore         string
+	ToastFileChanged               string
+	ToastFilesChanged              string
 
 	// List Modal Items
 	ListItemSchemaDiffMigration     string
 	ListItemDescSchemaDiffMigration string
+	ListItemApplyIntrospected       string
+	ListItemDescApplyIntrospected   string
+	ListItemDiscardIntrospected     string
+	ListItemDescDiscardIntrospected string
+	ListItemIntrospectAgain         string
+	ListItemDescIntrospectAgain     string
+	ListItemDeploy                  string
+	ListItemDescDeploy              string
+	ListItemSimulateDeploy          string
+	ListItemDescSimulateDeploy      string
+	ListItemRedetectToolchain       string
+	ListItemDescRedetectToolchain   string
+	ListItemInstallPrisma           string
+	ListItemDescInstallPrisma       string
+	ListItemContinueLimited         string
+	ListItemDescContinueLimited     string
+	ListItemEditEnvFile             string
+	ListItemDescEditEnvFile         string
+	ListItemOpenSchema              string
+	ListItemDescOpenSchema          string
+	ListItemRedetectVersions        string
+	ListItemDescRedetectVersions    string
+	ListItemRevealURL               string
+	ListItemMaskURL                 string
+	ListItemDescToggleURLMask       string
+	ListItemCopyURLMasked           string
+	ListItemDescCopyURLMasked       string
+	ListItemCopyURL                 string
+	ListItemDescCopyURL             string
+	ListItemTestConnection          string
+	ListItemDescTestConnection      string
+	ListItemPlanetScaleBranches     string
+	ListItemDescPlanetScaleBranches string
+	ListItemSupabase                string
+	ListItemDescSupabase            string
+	SupabaseLocalRunning            string
+	SupabaseLocalRunningDesc        string
+	SupabaseLocalStopped            string
+	SupabaseLocalStoppedDesc        string
+	ListItemSupabaseLocal           string
+	ListItemDescSupabaseLocal       string
+	ListItemSupabaseRemote          string
+	ListItemDescSupabaseRemote      string
+	ListItemSupabaseStudio          string
+	ListItemSupabaseAPI             string
+	ListItemSupabaseDashboard       string
+	ListItemDescSupabaseLink        string
+	ListItemSupabaseCopyLocalURL    string
+	ListItemURLParams               string
+	ListItemAddDirectURL            string
+	ListItemDescAddDirectURL        string
+	ListItemFixTLS                  string
+	ListItemDescFixTLS              string
+	ListItemDescURLParams           string
+	ListItemHistoryFilter           string
+	ListItemHistoryFiltered         string
+	ListItemDescHistoryFilter       string
+	ListItemHistoryCopy             string
+	ListItemDescHistoryCopy         string
+	ListItemHistoryRerun            string
+	ListItemDescHistoryRerun        string
+	ListItemDeployCountdown         string
+	ListItemDescDeployCountdown     string
+	ListItemScheduleDeploy          string
+	ListItemDescScheduleDeploy      string
+	ListItemExportPendingSQL        string
+	ListItemDescExportPendingSQL    string
+	ListItemExportCount             string
+	ListItemDescExportCount         string
+	ListItemExportTransaction       string
+	ListItemDescExportTransaction   string
+	ListItemDescWillExport          string
+	ListItemImportReceipt           string
+	ListItemDescImportReceipt       string
+	ListItemMarkReceiptApplied      string
+	ListItemDescMarkReceipt         string
+	ListItemDescReceiptApplied      string
+	ListItemDescReceiptUnknown      string
+	ListItemDescReceiptGap          string
 	ListItemManualMigration         string
 	ListItemDescManualMigration     string
+	ListItemFindDeadColumns         string
+	ListItemDescFindDeadColumns     string
+	ListItemDropDeadColumns         string
+	ListItemDescDropDeadColumns     string
+	ListItemDescDeadColumn          string
+	ListItemDescPeekTable           string
+	ListItemRunStatement            string
+	ListItemDescRunStatement        string
+	ListItemCommitTransaction       string
+	ListItemDescCommitTransaction   string
+	ListItemRollbackTransaction     string
+	ListItemDescRollbackTransaction string
+	ListItemOpenInEditor            string
+	ListItemDescOpenInEditor        string
+	ListItemOpenInFileManager       string
+	ListItemDescOpenInFileManager   string
+	ListItemDescConsoleHistory      string
+	ListItemExplain                 string
+	ListItemDescExplain             string
+	ListItemAnalyze                 string
+	ListItemDescAnalyze             string
+	ConsoleToggleHint               string
+	ListItemConcurrentIndex         string
+	ListItemDescConcurrentIndex     string
+	ListItemCreateExtMigration      string
+	ListItemDescCreateExtMigration  string
+	ListItemCreateExtNow            string
+	ListItemDescCreateExtNow        string
+	ListItemDescMissingExtension    string
+	ListItemSkipGenerate            string
+	ListItemDescSkipGenerate        string
+	ListItemSkipSeed                string
+	ListItemDescSkipSeed            string
+	MigrateDevToggleHint            string
 	ListItemMarkApplied             string
 	ListItemDescMarkApplied         string
 	ListItemMarkRolledBack          string
+	ListItemRecommended             string
 	ListItemDescMarkRolledBack      string
+	ListItemDeleteHistoryRow        string
+	ListItemDescDeleteHistoryRow    string
+	ListItemRestoreMigration        string
+	ListItemDescRestoreMigration    string
+	ListItemAcceptLocalChecksum     string
+	ListItemDescAcceptLocalChecksum string
+	ListItemDescChecksumEdited      string
+	ListItemDescChecksumMissing     string
+	ListItemInitTrackingDeploy      string
+	ListItemDescInitTrackingDeploy  string
+	ListItemInitTableOnly           string
+	ListItemDescInitTableOnly       string
+	ListItemInitBaseline            string
+	ListItemDescInitBaseline        string
+	ListItemMarkAllApplied          string
+	ListItemDescMarkAllApplied      string
+	ListItemDescWillMarkApplied     string
+	ListItemRetryMigration          string
+	ListItemDescRetryMigration      string
 	ListItemCopyName                string
 	ListItemCopyPath                string
 	ListItemCopyChecksum            string
+	ListItemViewMigration           string
+	ListItemDescViewMigration       string
+	ListItemEditMigrationSQL        string
+	ListItemDescEditMigrationSQL    string
+	ListItemDescEditAppliedSQL      string
+	ListItemEditDownSQL             string
+	ListItemDescEditDownSQL         string
+	ListItemReviewMigration         string
+	ListItemDescReviewMigration     string
+	ListItemPeekMigrationData       string
+	ListItemDescPeekMigrationData   string
+	ListItemResolveFailed           string
+	ListItemDescResolveFailed       string
+	ListItemFixChecksum             string
+	ListItemDescFixChecksum         string
+	ListItemDeleteMigration         string
+	ListItemDescDeleteMigration     string
+	ListItemDescRemoveHistoryRow    string
+	ListItemCopyMigration           string
+	ListItemDescCopyMigration       string
+	ListItemKillRestartStudio       string
+	ListItemDescKillRestartStudio   string
+	ListItemAdoptStudio             string
+	ListItemDescAdoptStudio         string
+	ListItemStudioInstanceRunning   string
+	ListItemStudioInstanceStopped   string
+	ListItemDescStudioRunning       string
+	ListItemDescStudioStopped       string
+	ListItemStudioStopAll           string
+	ListItemDescStudioStopAll       string
 
 	// Details Panel - Migration Status
 	MigrationStatusInTransaction  string
 	MigrationStatusDBOnly         string
 	MigrationStatusChecksumMismatch string
+	ChecksumStatusEdited            string
+	ChecksumStatusMissing           string
 	MigrationStatusApplied        string
 	MigrationStatusEmptyMigration string
 	MigrationStatusPending        string
+	MigrationStatusUntracked      string
 
 	// Details Panel - Labels & Descriptions
 	DetailsPanelInitialPlaceholder      string
@@ -209,6 +906,7 @@ type TranslationSet struct {
 	DetailsTimestampLabel               string
 	DetailsPathLabel                    string
 	DetailsStatusLabel                  string
+	DetailsApplyWithLabel               string
 	DetailsAppliedAtLabel               string
 	DetailsDownMigrationLabel           string
 	DetailsDownMigrationAvailable       string
@@ -223,9 +921,204 @@ type TranslationSet struct {
 	DetailsChecksumIssuesWarning        string
 	DetailsLocalChecksumLabel           string
 	DetailsHistoryChecksumLabel         string
+	DetailsChecksumDiffLabel            string
+	DetailsAppliedVersionCommit         string
+	DetailsAppliedVersionStaged         string
+	DetailsChecksumDiffUnavailable      string
+	DetailsChecksumDiffLoading          string
+	SchemaPanelNoSchema                 string
+	SchemaPanelEmpty                    string
+	SchemaGroupModels                   string
+	SchemaGroupViews                    string
+	SchemaGroupEnums                    string
+	SchemaGroupTypes                    string
+	SchemaGroupGenerators               string
+	SchemaKindModel                     string
+	SchemaKindView                      string
+	SchemaKindEnum                      string
+	SchemaKindType                      string
+	SchemaKindGenerator                 string
+	SchemaFieldCount                    string
+	SchemaValueCount                    string
+	SchemaRelationMany                  string
+	SchemaRelationOptional              string
+	SchemaRelationOne                   string
+	DetailsSchemaEntryTitle             string
+	DetailsSchemaDatabaseNameLabel      string
+	DetailsSchemaDefinedAtLabel         string
+	DetailsSchemaFieldsLabel            string
+	DetailsSchemaValuesLabel            string
+	DetailsSchemaSettingsLabel          string
+	DetailsSchemaRelationsLabel         string
+	DetailsSchemaReferencedByLabel      string
+	DetailsSchemaAttributesLabel        string
+	DetailsSchemaEntryHint              string
+	DetailsSchemaGroupHint              string
+	SchemaHistoryTitle                  string
+	SchemaHistoryHint                   string
+	SchemaHistoryViewTitle              string
+	SchemaHistoryDiffTitle              string
+	SchemaHistoryNoChanges              string
+	MigrationPreviewTitle               string
+	MigrationPreviewHint                string
+	MigrationPreviewNoChanges           string
+	IntrospectionTitle                  string
+	IntrospectionHint                   string
+	IntrospectionNoChanges              string
+	IntrospectionLineCount              string
+	SplitMigrationStatementCount        string
+	SplitMigrationToggleHint            string
+	SplitMigrationOtherStatements       string
+	SplitMigrationOtherFirst            string
+	ReviewToggleHint                    string
+	StatementRiskDropTable              string
+	StatementRiskDropColumn             string
+	StatementRiskDropObject             string
+	StatementRiskDeleteRows             string
+	StatementRiskUpdateRows             string
+	StatementRiskAlterType              string
+	StatementRiskSetNotNull             string
+	StatementRiskRename                 string
+	StatementRiskAddUniqueness          string
+	LockEstimate                        string
+	LockBlocksReadsWrites               string
+	LockBlocksWrites                    string
+	LockBlocksNothing                   string
+	LockHeldBriefly                     string
+	LockHeldWhileScanning               string
+	LockTipConcurrently                 string
+	LockTipNotValid                     string
+	LockTipCheckNotNull                 string
+	LockTipMetadataLock                 string
+	LockTipBatchRows                    string
+	LockTipNewColumnCopy                string
+	SplitMigrationFileHeader            string
+	ListItemCreateSplitMigration        string
+	ListItemDescCreateSplitMigration    string
+	EnumChangeWarningTitle              string
+	EnumChangeCaveatPostgres            string
+	EnumChangeCaveatMySQL               string
+	EnumChangeCaveatOther               string
+	EnumChangeDropped                   string
+	EnumChangeUsedBy                    string
+	EnumChangeNoColumns                 string
+	SchemaHistoryShowTimeline           string
+	SchemaHistoryActionView             string
+	SchemaHistoryActionDiff             string
+	CompareBranchesItemDescription      string
+	CompareBranchesOnlyOn               string
+	CompareBranchesOutOfOrderTag        string
+	CompareBranchesOutOfOrderWarning    string
+	EnvironmentSummaryError             string
+	EnvironmentSummaryBehind            string
+	EnvironmentSummaryUpToDate          string
+	EnvironmentMissingLabel             string
+	EnvironmentTagProtected             string
+	PlanetScaleTagProduction            string
+	PlanetScaleTagNotReady              string
+	PlanetScaleBranchDescription        string
+	PlanetScaleBranchDeployHint         string
+	PlanetScaleBranchUnmapped           string
+	URLParamHintSchema                  string
+	URLParamHintHost                    string
+	URLParamHintSSLMode                 string
+	URLParamHintSSLCert                 string
+	URLParamHintSSLRootCert             string
+	URLParamHintSSLIdentity             string
+	URLParamHintSSLPassword             string
+	URLParamHintSSLAccept               string
+	URLParamHintConnectionLimit         string
+	URLParamHintConnectTimeout          string
+	URLParamHintPoolTimeout             string
+	URLParamHintSocketTimeout           string
+	URLParamHintMaxLifetime             string
+	URLParamHintMaxIdleLifetime         string
+	URLParamHintStatementCache          string
+	URLParamHintPgBouncer               string
+	URLParamHintApplicationName         string
+	URLParamHintChannelBinding          string
+	URLParamHintOptions                 string
+	URLParamHintSocket                  string
+	URLParamHintDatabase                string
+	URLParamHintEncrypt                 string
+	URLParamHintTrustServerCert         string
+	URLParamUnknown                     string
+	URLParamDidYouMean                  string
+	URLParamInvalidValues               string
+	URLParamInvalidNumber               string
+	URLParamDuplicate                   string
+	URLParamPgBouncerNoDirect           string
+	URLParamPgBouncerNoDirectDesc       string
+	EnvironmentColumnMigration          string
+	EnvironmentLegend                   string
+	ScriptsLastRunsLabel                string
+	ScriptsNeverRun                     string
+	ScriptsExitCode                     string
+	ScriptsDefaultEnvironmentDesc       string
+	ScriptsEnvironmentDesc              string
+	ResolveGuidanceFailed               string
+	ResolveGuidanceAlreadyExists        string
+	ResolveGuidanceRolledBack           string
+	ResolveGuidanceMySQL                string
+	GeneratorDescEnabled                string
+	GeneratorDescSkipped                string
+	GeneratorToggleHint                 string
+	SchemaErrorJumpHint                 string
+	EnvironmentAppliedEverywhere        string
+	EnvironmentNotAppliedIn             string
+	EnvironmentDeployHint               string
+	AuditEntryDescription               string
+	HistoryEntryDescription             string
+	UsageStatsRanks                     string
+	UsageStatsSince                     string
+	UsageStatsCommandsRun               string
+	UsageStatsMostUsed                  string
+	UsageStatsDeploy                    string
+	UsageStatsDeployDurations           string
+	UsageStatsNoDeploys                 string
+	DoctorStatusPass                    string
+	DoctorStatusWarn                    string
+	DoctorStatusFail                    string
+	DoctorStatusSkip                    string
+	DoctorFixLabel                      string
+	DoctorCheckNode                     string
+	DoctorCheckPrisma                   string
+	DoctorCheckSchema                   string
+	DoctorCheckEnv                      string
+	DoctorCheckDatabase                 string
+	DoctorCheckShadowDB                 string
+	DoctorCheckMigrations               string
+	DoctorFixNodeMissing                string
+	DoctorFixNodeTooOld                 string
+	DoctorFixNodePinMismatch            string
+	DoctorFixPrismaMissing              string
+	DoctorFixClientMissing              string
+	DoctorFixVersionMismatch            string
+	DoctorFixSchemaInvalid              string
+	DoctorFixNoDatasource               string
+	DoctorFixEnvNotSet                  string
+	DoctorFixUnsupportedProvider        string
+	DoctorFixDBUnreachable              string
+	DoctorFixDriverAdapterOnly          string
+	DoctorFixShadowUnreachable          string
+	DoctorFixNoCreateDB                 string
+	DoctorFixPlanetScaleShadow          string
+	DoctorFixNoMigrations               string
+	DoctorFixLockMissing                string
+	DoctorFixProviderMismatch           string
+	DoctorFixEmptyMigration             string
+	DoctorFixBadMigrationName           string
+	EngineOverrideValue                 string
+	EngineOverrideSource                string
+	EngineOverrideSourceEnv             string
 	DetailsEmptyMigrationDescription    string
 	DetailsEmptyMigrationWarning        string
 	DetailsDownMigrationSQLLabel        string
+	DetailsSQLFormattedIndicator        string
+	SQLHighlightChroma                  string
+	SQLHighlightKeywords                string
+	SQLHighlightOff                     string
+	DetailsSchemaBackIndicator          string
 	ErrorReadingMigrationSQL            string
 
 	// Details Panel - Action Needed
@@ -247,6 +1140,25 @@ type TranslationSet struct {
 	ActionNeededRevertLocalChanges              string
 	ActionNeededCreateNewInstead                string
 	ActionNeededContactTeamIfNeeded             string
+	ActionNeededNoMigrationTableHeader          string
+	ActionNeededNoMigrationTableDesc            string
+	ActionNeededInitializeTracking              string
+	ActionNeededEnvConflictHeader               string
+	ActionNeededEnvConflictDesc                 string
+	ActionNeededEnvConflictSourcesLabel         string
+	ActionNeededEnvConflictInUse                string
+	ActionNeededEnvConflictAlign                string
+	ActionNeededPooledHeader                    string
+	ActionNeededPooledDesc                      string
+	ActionNeededPooledAddDirectURL              string
+	ActionNeededPooledFixDirectURL              string
+	ActionNeededPooledUnpooled                  string
+	ActionNeededMergeHeader                     string
+	ActionNeededMergeDesc                       string
+	ActionNeededMergeFix                        string
+	MergeFixDuplicateSQL                        string
+	MergeFixDuplicateTimestamp                  string
+	MergeFixOutOfOrder                          string
 	ActionNeededSchemaValidationErrorsHeader    string
 	ActionNeededSchemaValidationFailedDesc      string
 	ActionNeededFixBeforeMigration              string
@@ -258,17 +1170,49 @@ type TranslationSet struct {
 
 	// Workspace Panel
 	WorkspaceVersionLine             string
+	WorkspaceNodePinned              string
+	WorkspaceNodePinMatch            string
+	WorkspaceNodePinMismatch         string
+	WorkspaceNodeViaManager          string
 	WorkspacePrismaGlobalIndicator   string
 	WorkspaceGitLine                 string
 	WorkspaceSchemaModifiedIndicator string
 	WorkspaceBranchFormat            string
 	WorkspaceNotGitRepository        string
+	WorkspaceRecentFiles             string
+	WorkspaceRecentFileAge           string
+	WorkspaceRecentFileJustNow       string
 	WorkspaceConnected               string
 	WorkspaceNotConfigured           string
 	WorkspaceDisconnected            string
 	WorkspaceProviderLine            string
 	WorkspaceHardcodedIndicator      string
 	WorkspaceNotSet                  string
+	WorkspaceURLSource               string
+	WorkspaceLatency                 string
+	WorkspaceServerVersion           string
+	WorkspaceExtensions              string
+	WorkspaceExtensionsNone          string
+	WorkspaceExtensionMissing        string
+	WorkspaceViaAdapter              string
+	WorkspaceDriverAdapter           string
+	WorkspaceAdapterNoDirect         string
+	WorkspaceEdgeMigrations          string
+	WorkspaceRelationModePrisma      string
+	WorkspacePlanetScale             string
+	WorkspaceSupabaseRunning         string
+	WorkspaceSupabaseStopped         string
+	WorkspaceSupabaseTargetLocal     string
+	WorkspaceSupabaseTargetRemote    string
+	WorkspaceURLParamProblems        string
+	WorkspaceTLSFix                  string
+	WorkspaceTLSFixManual            string
+	TLSProblemSelfSigned             string
+	TLSProblemRequired               string
+	TLSProblemUnsupported            string
+	TLSProblemHostname               string
+	TLSProblemExpired                string
+	WorkspaceURLSourceProcessEnv     string
 	WorkspaceErrorFormat             string
 	WorkspaceErrorGetWorkingDirectory string
 	WorkspaceErrorSchemaNotFound     string
@@ -280,9 +1224,15 @@ type TranslationSet struct {
 	// Migrations Panel
 	MigrationsFooterFormat string
 
+	// Output Panel
+	OutputLinesDropped        string
+	OutputLinesDroppedLogPath string
+	OutputFoldHidden          string
+
 	// main.go strings
 	VersionOutput              string
 	ErrorFailedGetCurrentDir   string
+	ErrorDemoSetup             string
 	ErrorNotPrismaWorkspace    string
 	ErrorExpectedOneOf         string
 	ErrorExpectedConfigV7Plus  string
@@ -290,6 +1240,35 @@ type TranslationSet struct {
 	ErrorFailedCreateApp       string
 	ErrorFailedRegisterKeybindings string
 	ErrorAppRuntime            string
+
+	// Onboarding an existing database (no schema yet)
+	OnboardNoSchema        string
+	OnboardDatabaseFound   string
+	OnboardStepsIntro      string
+	OnboardStepInit        string
+	OnboardStepPull        string
+	OnboardStepGenerate    string
+	OnboardStepBaseline    string
+	OnboardConfirm         string
+	OnboardRunningInit     string
+	OnboardRunningPull     string
+	OnboardRunningGenerate string
+	OnboardRunningBaseline string
+	OnboardRunningResolve  string
+	OnboardStepFailed      string
+	OnboardBaselineSkipped string
+	OnboardBaselineFailed  string
+	OnboardBaselineWritten string
+	OnboardDone            string
+
+	// Another instance with the project open
+	InstanceActive       string
+	InstanceConflictRisk string
+	InstancePrompt       string
+	InstanceLockFailed   string
+
+	// Loading a very large project before the TUI starts
+	StartupLoadingMigrations string
 }
 
 func EnglishTranslationSet() *TranslationSet {
@@ -298,13 +1277,24 @@ func EnglishTranslationSet() *TranslationSet {
 		PanelTitleOutput:    "Output",
 		PanelTitleWorkspace: "Workspace",
 		PanelTitleDetails:   "Details",
+		PanelTitleSchema:    "Schema",
+
+		// Panel Footers
+		PanelUpdatedJustNow: "updated just now",
+		PanelUpdatedAgo:     "updated %s ago",
 
 		// Tab Labels
-		TabLocal:        "Local",
-		TabPending:      "Pending",
-		TabDBOnly:       "DB-Only",
-		TabDetails:      "Details",
-		TabActionNeeded: "Action-Needed",
+		TabLocal:         "Local",
+		TabPending:       "Pending",
+		TabDBOnly:        "DB-Only",
+		TabDetails:       "Details",
+		TabActionNeeded:  "Action-Needed",
+		TabSchema:        "Schema",
+		TabPreview:       "Migration Preview",
+		TabIntrospection: "Introspection",
+		TabData:          "Data",
+		TabQuery:         "Query",
+		TabSchemaHistory: "Schema History",
 
 		// Error Messages (general)
 		ErrorFailedGetWorkingDirectory:   "Error: Failed to get working directory",
@@ -320,24 +1310,115 @@ func EnglishTranslationSet() *TranslationSet {
 		// Modal Titles
 		ModalTitleError:                     "Error",
 		ModalTitleDBConnectionRequired:      "Database Connection Required",
+		ModalTitleFixMerge:                  "Fix Merge",
+		ModalTitleFixMergeFailed:            "Fix Merge Failed",
 		ModalTitleMigrationError:            "Migration Error",
 		ModalTitleMigrationCreated:          "Migration Created",
 		ModalTitleMigrationFailed:           "Migration Failed",
 		ModalTitleMigrateDeploySuccess:      "Migrate Deploy Successful",
 		ModalTitleMigrateDeployFailed:       "Migrate Deploy Failed",
 		ModalTitleMigrateDeployError:        "Migrate Deploy Error",
+		ModalTitleEdgeDeploy:                "Deploy to %s",
+		ModalTitleDeploy:                    "Deploy",
+		ModalTitleSimulateDeploySuccess:     "Simulation Passed",
+		ModalTitleSimulateDeployFailed:      "Simulation Failed",
+		ModalTitleSimulateDeployError:       "Simulation Error",
+		ModalTitleSimulateOnTarget:          "Simulate on the Database Server",
+		ModalTitleDeployCountdown:           "Deploy Countdown",
+		ModalTitleScheduledDeploy:           "Scheduled Deploy",
+		ModalTitleEnterDeployTime:           "Deploy At",
+		ModalTitleInvalidDeployTime:         "Invalid Time",
 		ModalTitleGenerateSuccess:           "Generate Successful",
 		ModalTitleGenerateFailed:            "Generate Failed",
 		ModalTitleGenerateError:             "Generate Error",
 		ModalTitleSchemaValidationFailed:    "Schema Validation Failed",
+		ModalTitleSchemaErrors:              "Schema Errors (%d)",
 		ModalTitleNoMigrationSelected:       "No Migration Selected",
 		ModalTitleCannotResolveMigration:    "Cannot Resolve Migration",
 		ModalTitleMigrateResolveSuccess:     "Migrate Resolve Successful",
+		ModalTitleRetryMigrationSuccess:     "Migration Re-run",
+		ModalTitleExtensionsCreated:         "Extensions Created",
+		ModalTitleCreateExtensionsFailed:    "Create Extensions Failed",
+		ModalTitleRetryMigrationFailed:      "Retry Failed",
 		ModalTitleMigrateResolveFailed:      "Migrate Resolve Failed",
 		ModalTitleMigrateResolveError:       "Migrate Resolve Error",
 		ModalTitleStudioError:               "Studio Error",
+		ModalTitleToolchainMissing:          "Node.js Not Found",
+		ModalTitlePrismaMissing:             "Prisma CLI Not Found",
+		ModalTitleInstallPrismaSuccess:      "Prisma Installed",
+		ModalTitleInstallPrismaFailed:       "Prisma Install Failed",
 		ModalTitleStudioStopped:             "Studio Stopped",
 		ModalTitleStudioStarted:             "Prisma Studio Started",
+		ModalTitleStudioPortInUse:           "Studio Port In Use",
+		ModalTitleStudioAlreadyRunning:      "Prisma Studio Already Running",
+		ModalTitleStudioManager:             "Prisma Studio Manager",
+		ModalTitleMigrationImpact:           "Migration Impact",
+		ModalTitleBlame:                     "Blame",
+		ModalTitleBlameResults:              "Blame: %s",
+		ModalTitleModelUsage:                "Model Usage",
+		ModalTitleModelUsageResults:         "Usage of %s (%d migrations)",
+		ModalTitleModelKeys:                 "Indexes and Foreign Keys",
+		ModalTitlePeekData:                  "Peek Data",
+		ModalTitlePeekDataTables:            "Peek Data: %s",
+		ModalTitleSQLConsole:                "SQL Console",
+		ModalTitleSQLStatement:              "SQL Statement",
+		ModalTitleCommitTransaction:         "Commit Transaction",
+		ModalTitleOpenProject:               "Open Project",
+		ModalTitleModelKeysResults:          "Keys of %s",
+		ModalTitleModelKeysDiverged:         "Keys of %s (%d diverging)",
+		ModalTitleGoToLine:                  "Go to Line",
+		ModalTitleFormatSQL:                 "Format Migration SQL",
+		ModalTitleSchemaHistory:             "Schema History",
+		ModalTitlePreviewMigration:          "Preview Migration",
+		ModalTitleIntrospection:             "Introspected Schema",
+		ModalTitleIntrospect:                "Introspect Database",
+		ModalTitleSplitMigration:            "Split Migration",
+		ModalTitleSplitMigrationPart:        "Split Migration: pick tables for migration %d",
+		ModalTitleSchemaRevision:            "Schema at %s",
+		ModalTitleCompareBranches:           "Compare Migrations with Branch",
+		ModalTitleBranchComparison:          "Only on %s: %d · Only on %s: %d",
+		ModalTitleEnvironments:              "Environment Status",
+		ModalTitlePlanetScaleBranches:       "PlanetScale Branches",
+		ModalTitlePlanetScaleBranchesOf:     "PlanetScale Branches: %s",
+		ModalTitleDeployToEnvironment:       "Deploy to Environment",
+		ModalTitleDeployToProtected:         "Deploy to Protected Environment '%s'",
+		ModalTitleActionDisabled:            "Action Disabled",
+		ModalTitleDemoMode:                  "Demo Mode",
+		ModalTitleReadOnly:                  "Read-only",
+		ModalTitleQuitUncommitted:           "Uncommitted Statements",
+		ModalTitleTransactionOpen:           "Open Transaction",
+		ModalTitleRevealURLDisabled:         "Reveal Disabled",
+		ModalTitleWorkspaceActions:          "Workspace",
+		ModalTitleSupabase:                  "Supabase",
+		ModalTitleSupabaseProject:           "Supabase: %s",
+		ModalTitleURLParams:                 "URL parameters",
+		ModalTitleAddDirectURL:              "Add directUrl",
+		ModalTitleFixTLS:                    "Fix TLS Settings",
+		ModalTitleFixTLSFailed:              "Fix TLS Settings Failed",
+		ModalTitleDirectURLAdded:            "directUrl Added",
+		ModalTitleAddDirectURLFailed:        "Could Not Add directUrl",
+		ModalTitleEditorFailed:              "Editor Error",
+		ModalTitleMigrationActions:          "Migration: %s",
+		ModalTitleConnectionTest:            "Connection Test",
+		ModalTitleAuditLog:                  "Audit Log",
+		ModalTitleAuditLogPath:              "Audit Log (%s)",
+		ModalTitleCommandHistory:            "Command History",
+		ModalTitleCommandHistoryFiltered:    "Command History: %s",
+		ModalTitleHistoryFilter:             "Filter Commands",
+		ModalTitleRerunCommand:              "Run Again",
+		ModalTitleRerunProtected:            "Run Again in Protected Environment '%s'",
+		ModalTitleRerunFailed:               "Command Failed",
+		ModalTitleUsageStats:                "Usage Stats",
+		ModalTitleGenerators:                "Generators",
+		ModalTitleDoctor:                    "Doctor",
+		ModalTitleEngineOverrides:           "Prisma Overrides",
+		ModalTitleScripts:                   "Scripts",
+		ModalTitleReviewMigration:           "Review Migration",
+		ModalTitleReviewMigrationProgress:   "Review %s: %d/%d reviewed, %d destructive",
+		ModalTitleRunScript:                 "Run Script",
+		ModalTitleRunScriptIn:               "Run %s in",
+		ModalTitleRunScriptProtected:        "Run Script in Protected Environment '%s'",
+		ModalTitleRunScriptFailed:           "Script Failed",
 		ModalTitleNoSelection:               "No Selection",
 		ModalTitleCannotDelete:              "Cannot Delete",
 		ModalTitleDeleteError:               "Delete Error",
@@ -353,17 +1434,72 @@ func EnglishTranslationSet() *TranslationSet {
 		ModalTitleValidationFailed:          "Validation Failed",
 		ModalTitleMigrateDev:                "Migrate Dev",
 		ModalTitleResolveMigration:          "Resolve Migration: %s",
+		ModalTitleResolveDBOnlyMigration:    "Resolve DB-Only Migration: %s",
+		ModalTitleResolveChecksumMismatch:   "Resolve Checksum Mismatch: %s",
+		ModalTitleDeleteHistoryRow:          "Delete History Row",
+		ModalTitleAcceptChecksum:            "Accept Edited Migration",
+		ModalTitleRestoreMigrationFailed:    "Failed to Restore Migration",
+		ModalTitleHistoryUpdateFailed:       "Failed to Update Migration History",
+		ModalTitleInitMigrationTracking:     "Initialize Migration Tracking",
+		ModalTitleMarkAllApplied:            "Mark Pending Migrations as Applied",
+		ModalTitleExportPendingSQL:          "Export Pending Migrations as SQL",
+		ModalTitleEnterExportPath:           "Export To",
+		ModalTitleExportedSQL:               "SQL Exported",
+		ModalTitleExportSQLFailed:           "Export Failed",
+		ModalTitleImportReceipt:             "Import Receipt",
+		ModalTitleEnterReceiptPath:          "Receipt File",
+		ModalTitleResolveFailedMigration:    "Migration Failed: %s",
 		ModalTitleCopyToClipboard:           "Copy to Clipboard",
 		ModalTitleEnterMigrationName:        "Enter migration name",
+		ModalTitleConcurrentIndexTable:      "Index table",
+		ModalTitleConcurrentIndexColumns:    "Index columns on %s",
+		ModalTitleConcurrentIndex:           "Create Concurrent Index",
+		ModalTitleDeadColumns:               "Dead Columns",
+		ModalTitleVerifyChecksums:           "Verify Checksums",
+		ModalTitleChecksumReport:            "Checksum Mismatches: %d of %d Applied",
+		ModalTitleDeadColumnsFound:          "Dead Columns (%d)",
+		ModalTitleDropDeadColumns:           "Drop Dead Columns",
 
 		// Modal Messages
 		ModalMsgMigrationCreatedSuccess:      "Migration '%s' created successfully!",
-		ModalMsgMigrationCreatedDetail:       "You can find it in the prisma/migrations directory.",
+		ModalMsgMigrationCreatedDetail:       "You can find it in the %s directory.",
 		ModalMsgMigrationFailedWithCode:      "Prisma migrate dev failed with exit code: %d",
 		ModalMsgCheckOutputPanel:             "Check output panel for details.",
 		ModalMsgMigrationsAppliedSuccess:     "Migrations applied successfully!",
+		ModalMsgSimulateDeploySuccess:        "All %d pending migration(s) applied cleanly to a scratch database.",
+		ModalMsgSimulatePendingFailed:        "Pending migration %s failed:",
+		ModalMsgSimulateReplayFailed:         "Applied migration %s could not be replayed, so the target's state could not be recreated:",
+		ModalMsgSimulateSkipped:              "Not tried: %s",
+		ModalMsgSimulateDBOnlyNote:           "%d applied migration(s) exist only in the database and were not replayed; the scratch schema may differ from the target's.",
+		ModalMsgSimulateTargetUnchanged:      "The target database was not changed.",
+		ModalMsgSimulateSetupFailed:          "Could not set up a scratch database:",
+		ModalMsgSimulateNoPending:            "There are no pending migrations to simulate.",
+		ModalMsgSimulateOnTarget:             "No shadowDatabaseUrl is set, so the scratch database would be created (and dropped afterwards) on the server of the database itself: %s. Create it there?",
+		ModalMsgSimulateFailedMigration:      "Deploy would stop at the failed migration %s. Resolve it first (s).",
+		ModalMsgDeployCountdown:              "Deploying %d pending migration(s) in %s.",
+		ModalMsgDeployScheduled:              "Deploying %d pending migration(s) at %s, in %s.",
+		ModalMsgDeployAbortHint:              "Press ESC or q to abort. Nothing is applied before the deploy starts.",
+		ModalMsgDeployScheduledKeepOpen:      "LazyPrisma must keep running until then.",
+		ModalMsgDeployTimeHint:               "Local time: HH:MM, HH:MM:SS or YYYY-MM-DD HH:MM. A time of day that has already passed means tomorrow.",
+		ModalMsgInvalidDeployTime:            "%q is not a time: use HH:MM, HH:MM:SS or YYYY-MM-DD HH:MM.",
+		ModalMsgDeployTimePassed:             "%s is in the past.",
+		ModalMsgDeployNoPending:              "There are no pending migrations to deploy.",
+		ModalMsgExportNoPending:              "There are no pending migrations to export.",
+		ModalMsgExportPathHint:               "Path of the .sql file, relative to the project root. Leave empty for %s.",
+		ModalMsgExportFileExists:             "%s already exists; choose another path.",
+		ModalMsgExportedSQL:                  "Wrote %d pending migration(s) to %s.",
+		ModalMsgExportMarkApplied:            "Mark them as applied now (migrate resolve --applied)? Only do this once the script has run against the database; it can also be done later with s on the Pending tab.",
+		ModalMsgExportMarkAppliedLater:       "Once the script has run against the database, mark them as applied with s on the Pending tab.",
+		ModalMsgReceiptPathHint:              "Path of the receipt, relative to the project root: one migration name per line, a JSON array, or a script exported with Export pending as SQL.",
+		ModalMsgReceiptReadFailed:            "Failed to read %s:",
+		ModalMsgReceiptEmpty:                 "%s lists no migration names.",
+		ModalMsgReceiptNothingPending:        "None of the %d migration(s) in the receipt are pending.",
 		ModalMsgMigrateDeployFailedWithCode:  "Prisma migrate deploy failed with exit code: %d",
 		ModalMsgFailedRunMigrateDeploy:       "Failed to run prisma migrate deploy:",
+		ModalMsgEdgeDeploy:                   "%s migrations are applied with the %s CLI: prisma migrate deploy can't reach the database, and whether a migration is applied isn't recorded in _prisma_migrations.",
+		ModalMsgEdgeApplySelected:            "Apply the selected migration with:",
+		ModalMsgEdgeD1Migrations:             "Or copy the SQL into wrangler's migrations directory and apply everything not yet applied with:",
+		ModalMsgEdgeNoHistory:                "Migrations applied with %s aren't recorded in _prisma_migrations, so there is no history to read.",
 		ModalMsgFailedStartMigrateDeploy:     "Failed to start migrate deploy:",
 		ModalMsgPrismaClientGenerated:        "Prisma Client generated successfully!",
 		ModalMsgGenerateFailedSchemaErrors:   "Generate failed due to schema errors.",
@@ -372,17 +1508,184 @@ func EnglishTranslationSet() *TranslationSet {
 		ModalMsgFailedRunGenerate:            "Failed to run prisma generate:",
 		ModalMsgFailedStartGenerate:          "Failed to start generate:",
 		ModalMsgSelectMigrationResolve:       "Please select a migration to resolve.",
-		ModalMsgOnlyInTransactionResolve:     "Only migrations in 'In-Transaction' state can be resolved.",
-		ModalMsgMigrationNotFailed:           "Migration '%s' is not in a failed state.",
+		ModalMsgOnlyInTransactionResolve:     "Only failed (In-Transaction), DB-Only and checksum mismatch migrations can be resolved.",
+		ModalMsgMigrationNotFailed:           "Migration '%s' has nothing to resolve.",
+		ModalMsgConfirmAcceptChecksum:        "Record the checksum of the local migration.sql for '%s'? Prisma will treat the edited file as applied without running it.",
+		ModalMsgDeleteHistoryRowWarning:      "Warning: this deletes the _prisma_migrations row for '%s' directly. Prisma will forget that the migration was run.",
+		ModalMsgDeleteHistoryRowLocal:        "The migration is still in the migrations folder, so the next deploy will run it again.",
+		ModalMsgDeleteHistoryRowDBOnly:       "The tables and data it created stay in the database.",
+		ModalMsgTypeMigrationToConfirm:       "Type '%s' to confirm.",
+		ModalMsgHistoryRowConfirmMismatch:    "The name did not match. Nothing was deleted.",
+		ModalMsgNoHistoryRow:                 "Migration '%s' is pending and has no _prisma_migrations row.",
+		ModalMsgSelectHistoryRow:             "Please select a migration to delete its history row.",
+		ModalMsgNoPendingToMark:              "There are no pending migrations to mark as applied.",
+		ModalMsgMarkedAllApplied:             "%d migration(s) marked as applied.",
+		ModalMsgMarkAllStopped:               "Stopped at %s: %d of %d migration(s) were marked as applied.",
 		ModalMsgMigrationMarkedSuccess:       "Migration marked as %s successfully!",
+		ModalMsgRetryMigrationSuccess:        "%s was re-run and marked as applied.",
+		ModalMsgRetryMigrationDeployRest:     "Run Deploy (D) to apply the migrations after it.",
+		ModalMsgRetryMigrationFailed:         "The SQL of %s failed again; nothing was marked as applied.",
+		ModalMsgRetryMigrationResolveFailed:  "The SQL of %s ran, but marking it as applied failed.",
+		ModalMsgExtensionMigrationDeploy:     "Deploy (D) applies it before %s, which needs it.",
+		ModalMsgExtensionsCreated:            "%s created and recorded as the applied migration %s.",
+		ModalMsgCreateExtensionsFailed:       "Creating %s failed (exit code %d). The database user may not be allowed to create extensions.",
+		ModalMsgExtensionMigrationKept:       "The migration %s was kept; deploy applies it once the extensions can be created.",
+		ModalMsgFailedStartCreateExts:        "Failed to start prisma db execute",
+		ModalMsgFailedStartRetryMigration:    "Failed to re-run the migration:",
 		ModalMsgMigrateResolveFailedWithCode: "Prisma migrate resolve failed with exit code: %d",
 		ModalMsgFailedRunMigrateResolve:      "Failed to run prisma migrate resolve:",
 		ModalMsgFailedStartMigrateResolve:    "Failed to start migrate resolve:",
 		ModalMsgFailedStopStudio:             "Failed to stop Prisma Studio:",
 		ModalMsgStudioStopped:                "Prisma Studio has been stopped.",
 		ModalMsgFailedStartStudio:            "Failed to start Prisma Studio:",
-		ModalMsgStudioRunningAt:              "Prisma Studio is running at http://localhost:5555",
+		ModalMsgToolMissing:                  "'%s' could not start: %s was not found on your PATH.",
+		ModalMsgToolchainFixVersionManager:   "If you use nvm, fnm or volta, reinstall or select a Node.js version. lazyprisma keeps the PATH it was started with, so restart it if the new version lives elsewhere.",
+		ModalMsgToolchainFixInstall:          "Otherwise install Node.js (npx comes with it) from https://nodejs.org, or make sure your container image still provides it.",
+		ModalMsgPrismaMissing:                "The Prisma CLI could not be run in this project, so LazyPrisma is in limited mode: migrations and the schema can be browsed, but Prisma commands can't run.",
+		ModalMsgPrismaMissingCommand:         "'%s' needs the Prisma CLI.",
+		ModalMsgInstallPrismaSuccess:         "The Prisma CLI has been installed. All commands are available again.",
+		ModalMsgInstallPrismaFailed:          "The install exited with code %d.",
+		ModalMsgFailedStartInstallPrisma:     "Failed to start the install:",
+		ModalMsgStudioRunningAt:              "Prisma Studio is running at http://localhost:%d",
 		ModalMsgPressStopStudio:              "Press 'S' again to stop it.",
+		ModalMsgStudioPortInUse:              "Port %d is already in use by another process:",
+		ModalMsgStudioPortOwnerUnknown:       "Port %d is already in use, but the owning process could not be identified.",
+		ModalMsgStudioChangePort:             "Stop that process or set studio.port in the config file.",
+		ModalMsgStudioAlreadyRunning:         "A Prisma Studio from a previous session (PID %d) is listening on port %d.",
+		ModalMsgFailedKillStudio:             "Failed to kill the existing Prisma Studio process:",
+		ModalMsgPressManageStudio:            "Press 'S' to manage Studio instances.",
+		ModalMsgNoTablesInMigrations:         "No CREATE/ALTER/DROP TABLE statements were found in local migrations.",
+		ImpactHistoryHeader:                  "Migrations that touched %s (oldest first):",
+		ModalMsgBlameInputHint:               "Table or table.column (e.g. User.email)",
+		ModalMsgGoToLineHint:                 "Line number as shown in the gutter",
+		ModalMsgInvalidLineNumber:            "Not a line number: %s",
+		ModalMsgLineNotFound:                 "Line %d not found in this tab",
+		ModalMsgBlameNotFound:                "No migration created or changed %s.",
+		ModalMsgModelUsageNoModel:            "Open the Schema tab in the Details panel and move the cursor into a model, view or enum to search the migrations for its table.",
+		ModalMsgModelUsageNotFound:           "No migration mentions %s.",
+		ModalMsgMigrationNotInList:           "Migration '%s' is not in the migrations list.",
+		BlameTagIntroduced:                   "introduced",
+		BlameTagLastChange:                   "last change",
+		ModelUsageMatchCount:                 "%d matching lines",
+		ModalMsgModelKeysNoModel:             "Open the Schema tab in the Details panel and move the cursor into a model to compare its indexes and foreign keys with the database.",
+		ModalMsgModelKeysFailed:              "Could not read the indexes and foreign keys of %s from the database:",
+		ModalMsgDriftNoURL:                   "%s is not set, so there is no database to compare with.",
+		ModalMsgModelKeysNone:                "Neither the schema nor the database has indexes or foreign keys on this table.",
+		ModelKeyKindPrimary:                  "primary key",
+		ModelKeyKindUnique:                   "unique",
+		ModelKeyKindIndex:                    "index",
+		ModelKeyKindForeignKey:               "foreign key",
+		ModelKeyMatch:                        "In sync: the schema declares it and the database has it.",
+		ModelKeyRenamed:                      "The database has it under another name. The next migrate dev renames it.",
+		ModelKeyChanged:                      "The database has a key of this name with a different definition.",
+		ModelKeyMissing:                      "Declared in the schema but not in the database. A migration that creates it may be pending, or it was dropped by hand.",
+		ModelKeyExtra:                        "In the database but not declared in the schema, e.g. created by hand or by a migration the schema doesn't reflect. The next migrate dev drops it.",
+		ModelKeySchemaSide:                   "Schema:   %s",
+		ModelKeyDatabaseSide:                 "Database: %s",
+		ModalMsgPeekSelectMigration:          "Select a migration to peek at the data of the tables it creates.",
+		ModalMsgPeekNoTables:                 "%s does not create a table, so there is no data to peek at.",
+		ModalMsgPeekPending:                  "%s is not applied yet, so the tables it creates are not in the database.",
+		ModalMsgPeekFailed:                   "Could not read the rows of %s.",
+		ModalMsgQueryNoURL:                   "%s is not set, so there is no database to query.",
+		TablePeekTitle:                       "Table %s (read at %s)",
+		TablePeekRowCount:                    "Showing %d of %d rows",
+		TablePeekEmpty:                       "The table has no rows.",
+		ModalMsgQueryFailed:                  "The statement failed.",
+		ModalMsgImplicitCommit:               "MySQL commits this statement immediately, together with the statements of the open transaction, so the console doesn't run it. Make schema changes with a migration.",
+		ModalMsgExplainFailed:                "Could not get the plan of the statement.",
+		QueryResultTitle:                     "Query (run at %s)",
+		QueryPlanTitle:                       "%s (run at %s)",
+		QueryRowCount:                        "%d rows",
+		QueryRowsMore:                        "First %d rows; the statement returned more",
+		QueryRowsAffected:                    "%d rows affected",
+		QueryNoRows:                          "The statement returned no rows.",
+		QueryPlanPlanning:                    "Planning: %s",
+		QueryPlanExecution:                   "Execution: %s",
+		QueryPlanIndexes:                     "Indexes used: %s",
+		QueryPlanNoIndexes:                   "Indexes used: none",
+		QueryPlanFullScans:                   "Full table scans: %s",
+		QueryPlanRolledBack:                  "The statement was run to measure it, then rolled back.",
+		ModalMsgCommitTransaction:            "Commit these %d statements? This can't be undone.",
+		ModalMsgTransactionFailed:            "The transaction could not be ended.",
+		ModalMsgQueryFailedTransactionKept:   "The open transaction keeps its %d earlier statements.",
+		QueryUncommitted:                     "Transaction open: %d statements not committed. Press Q to commit or roll back; quitting rolls them back.",
+		ModalMsgNoGUIEditor:                  "No GUI editor was found on PATH (code, cursor, zed, subl or idea). Set open.editor in the config file, e.g. to idea.",
+		ModalMsgOpenProjectFailed:            "Could not run %s.",
+		GUIEditorUnknown:                     "a GUI editor",
+		ModalMsgSelectMigrationFormat:        "Please select a migration to format.",
+		ModalMsgCannotFormatNoSQL:            "This migration has no migration.sql to format.",
+		ModalMsgCannotFormatApplied:          "Rewriting it would cause a checksum mismatch.",
+		ModalMsgFailedReadMigrationFile:      "Failed to read migration file:",
+		ModalMsgSQLAlreadyFormatted:          "migration.sql is already formatted.",
+		ModalMsgFormatChangesLiterals:        "Formatting would change a string literal (e.g. one with backslash escapes), so migration.sql was left as is.",
+		ModalMsgConfirmSaveFormatted:         "Overwrite %s with the formatted SQL?",
+		ModalMsgFormattedSQLSaved:            "Formatted SQL written to %s",
+		ModalMsgNoSchemaHistory:              "No git history found for prisma/schema.prisma.",
+		ModalMsgFailedReadSchemaRevision:     "Failed to read schema.prisma at this commit",
+		ModalMsgPreviewNeedsShadowDatabase:   "Replaying the migrations needs a shadow database. Set shadowDatabaseUrl in the datasource block of schema.prisma (Prisma 7: in prisma.config.ts) to an empty database you can reset.",
+		ModalMsgPreviewMigrationFailed:       "Failed to preview the migration SQL",
+		ModalMsgIntrospectFailed:             "Failed to introspect the database",
+		ModalMsgIntrospectMultiFile:          "The schema is split across the .prisma files of %s. db pull would write every model into one file next to them and duplicate them; run prisma db pull yourself and move the models into place.",
+		ModalMsgConfirmApplyIntrospect:       "Overwrite %s with the introspected schema? The current one is saved as %s first.",
+		ModalMsgFailedBackupSchema:           "Failed to back up the schema; it was not changed",
+		ModalMsgFailedWriteSchema:            "Failed to write schema.prisma",
+		ModalMsgSplitNeedsPreview:            "Nothing to split. Press p to preview the SQL of the next migration first, then P to split it.",
+		ModalMsgSplitMigrationTables:         "Tables: %s (spaces will be replaced with _)",
+		ModalMsgSplitMigrationCreated:        "Created %d migrations:",
+		ModalMsgSplitMigrationIncomplete:     "The remaining statements were not written. Press p to preview them again.",
+		ModalMsgSplitReferencesLater:         "These foreign keys point to tables created by statements not selected, so the migration would fail to deploy:",
+		ModalMsgSplitAddReferenced:           "Add the referenced tables to this migration?",
+		ModalMsgFailedReadSchema:             "Failed to read schema.prisma",
+		ModalMsgFailedListBranches:           "Failed to list git branches",
+		ModalMsgNoOtherBranches:              "No other branches found to compare with.",
+		ModalMsgFailedReadBranchMigrations:   "Failed to read migrations from branch '%s'",
+		ModalMsgBranchesInSync:               "%s and %s have the same migrations (%d).",
+		ModalMsgFailedLoadProjectConfig:      "Failed to load %s",
+		ModalMsgNoEnvironments:               "No environments configured. Add them to %s in the project root, for example:",
+		ModalMsgNoSupabaseProject:            "No Supabase project found: %s does not exist.",
+		ModalMsgAddDirectURL:                 "Add directUrl = env(\"DIRECT_URL\") to %s, and %s=%s to %s?",
+		ModalMsgFixTLS:                       "Add %s to %s in %s?",
+		ModalMsgFixTLSInsecure:               "accept_invalid_certs and trustServerCertificate turn off certificate verification: use them for a self-signed certificate you trust, not to silence errors on a public network. Pointing sslcert at the CA certificate keeps verification on.",
+		ModalMsgAddDirectURLSchemaOnly:       "Add directUrl = env(\"DIRECT_URL\") to %s? %s is already set.",
+		ModalMsgEnterDirectURL:               "URL of the database without %s, saved as %s in %s",
+		ModalMsgDirectURLAdded:               "Migrations now connect through %s instead of the pooler. Prisma Client keeps using the pooled url.",
+		ModalMsgPscaleNotFound:               "The pscale CLI was not found on the PATH. Install it from https://github.com/planetscale/cli and sign in with pscale auth login.",
+		ModalMsgPlanetScaleNoDatabase:        "The PlanetScale database name could not be read from the database URL. Set it in %s, for example:",
+		ModalMsgPlanetScaleBranchesFailed:    "Failed to list the PlanetScale branches",
+		ModalMsgPlanetScaleNoBranches:        "The PlanetScale database %s has no branches.",
+		ModalMsgDatasourceNotFromEnv:         "The datasource URL is not read from an environment variable, so it cannot be pointed at another environment.",
+		ModalMsgDirectURLNotFromEnv:          "The datasource's directUrl is not read from an environment variable, so migrate deploy would still apply the migrations to it. Use directUrl = env(\"...\") to deploy to another environment.",
+		ModalMsgEnvironmentNoURL:             "Environment '%s' has no database URL. Set url or urlEnv in .lazyprisma.yaml.",
+		ModalMsgConfirmDeployToEnvironment:   "Run migrate deploy against '%s' (%s)?",
+		ModalMsgTypeEnvironmentToConfirm:     "Type '%s' to confirm.",
+		ModalMsgProtectedConfirmMismatch:     "The name did not match. Deploy cancelled.",
+		ModalMsgDeployedToEnvironment:        "Pending migrations were applied to '%s'.",
+		ModalMsgActionDisabled:               "'%s' is disabled for this project.",
+		ModalMsgActionDisabledHint:           "It is listed under disabledActions in %s.",
+		ModalMsgAuditDisabled:                "The audit trail is disabled. Set audit.enabled to true in the config file to record executed commands.",
+		ModalMsgFailedReadAuditLog:           "Failed to read the audit log",
+		ModalMsgAuditLogEmpty:                "No commands recorded yet in %s.",
+		ModalMsgCommandHistoryEmpty:          "No commands have run this session.",
+		ModalMsgConfirmRerun:                 "Run %s again?",
+		ModalMsgFailedStartRerun:             "Failed to start the command:",
+		ModalMsgUsageStatsDisabled:           "Usage statistics are disabled. Set stats.enabled to true in the config file to keep them.",
+		ModalMsgFailedReadUsageStats:         "Failed to read usage statistics",
+		ModalMsgUsageStatsEmpty:              "Nothing to show yet. Run a few commands and come back!",
+		ModalMsgUsageStatsLocal:              "Kept only in %s; never sent anywhere.",
+		ModalMsgNoEngineOverrides:            "No PRISMA_* overrides are set for this project.",
+		ModalMsgEngineOverridesPassed:        "Overrides from the environment and the project's .env files are passed to every prisma command.",
+		ModalMsgNoGenerators:                 "No generator blocks found in schema.prisma.",
+		ModalMsgAllGeneratorsSkipped:         "All generators are skipped for this project. Press G to enable at least one.",
+		ModalMsgFailedLoadState:              "Failed to read the saved project preferences",
+		ModalMsgNoScripts:                    "No .sql or .js scripts found in %s.",
+		ModalMsgReviewSelectPending:          "Select a pending migration in the Migrations panel to review its statements.",
+		ModalMsgReviewNoStatements:           "%s has no SQL statements.",
+		ModalMsgScriptsDirHint:               "Add scripts there, or set another directory in %s:",
+		ModalMsgFailedReadScripts:            "Failed to read the scripts directory",
+		ModalMsgFailedReadScriptHistory:      "Failed to read the script run history",
+		ModalMsgConfirmRunScript:             "Run %s against '%s'?",
+		ModalMsgRunScriptConfirmMismatch:     "The name did not match. Script not run.",
+		ModalMsgFailedStartScript:            "Failed to start the script:",
 		ModalMsgSelectMigrationDelete:        "Please select a migration to delete.",
 		ModalMsgMigrationDBOnly:              "This migration exists only in the database (DB-Only).",
 		ModalMsgCannotDeleteNoLocalFile:      "Cannot delete a migration that has no local file.",
@@ -394,6 +1697,22 @@ func EnglishTranslationSet() *TranslationSet {
 		ModalMsgMigrationDeletedSuccess:      "Migration deleted successfully.",
 		ModalMsgFailedCopyClipboard:          "Failed to copy to clipboard:",
 		ModalMsgCopiedToClipboard:            "%s copied to clipboard!",
+		ModalMsgPanelEmpty:                   "Nothing to copy in this panel.",
+		ModalMsgRevealURLDisabled:            "Revealing the database URL is turned off (display.revealURL in the config file).",
+		ModalMsgFailedOpenEditor:             "Failed to open %s in the editor.",
+		ModalMsgSetEditor:                    "Set $VISUAL or $EDITOR to choose the editor.",
+		ModalMsgNoDatabaseURL:                "There is no resolved database URL to copy.",
+		ModalMsgConnectionOK:                 "Connected to %s.",
+		ModalMsgConnectionFailed:             "Could not connect to %s:",
+		ModalMsgConnectionLatency:            "Latency over %d pings: %s avg, %s min, %s max",
+		ModalMsgDemoActionDisabled:           "%s is not available in demo mode: there is no Prisma CLI or database behind the demo project.",
+		ModalMsgReadOnlyActionDisabled:       "%s is not available: another LazyPrisma has this project open, so this one is read-only.",
+		ModalMsgReadOnlyHint:                 "Quit the other instance and restart LazyPrisma to run commands.",
+		ModalMsgReadOnlyConsoleWrite:         "Writing from the SQL console",
+		ModalMsgQuitUncommitted:              "The SQL console's transaction has %d statements not committed. Quitting rolls them back. Quit anyway?",
+		ModalMsgTransactionOpenBlocks:        "%s would wait for the locks held by the SQL console's open transaction (%d statements not committed).",
+		ModalMsgTransactionOpenHint:          "Commit or roll back the transaction from the SQL console (Q) first.",
+		ModalMsgOneStatement:                 "Run one statement at a time, so each is checked on its own for whether it writes.",
 		ModalMsgPendingMigrationsWarning:     "Prisma automatically applies pending migrations before creating new ones. This may cause unintended behaviour in the future. Do you wish to continue?",
 		ModalMsgCannotCreateWithDBOnly:       "Cannot create new migration whilst DB-Only migrations exist.",
 		ModalMsgResolveDBOnlyFirst:           "Please resolve DB-Only migrations first.",
@@ -409,18 +1728,55 @@ func EnglishTranslationSet() *TranslationSet {
 		ModalMsgInputRequired:                "Input is required",
 		ModalMsgManualMigrationCreated:       "Created: %s",
 		ModalMsgManualMigrationLocation:      "Location: %s",
+		ModalMsgConcurrentIndexTableHint:     "Database table name, e.g. User or public.User",
+		ModalMsgConcurrentIndexColumnsHint:   "Comma-separated database column names, in index order",
+		ModalMsgConcurrentIndexWarning:       "CREATE INDEX CONCURRENTLY cannot run inside a transaction. Prisma applies each migration as one script, so this statement must stay the only one in its migration - do not add other SQL to the file. Create the migration?",
+		ModalMsgDeadColumnsFailed:            "Could not read the columns of the database:",
+		ModalMsgFixMergeNeedsDB:              "Fix merge needs the applied migrations, so that it only renames migrations this database hasn't applied.",
+		ModalMsgFixMergeNothing:              "No migrations share a timestamp or SQL, and none sorts before an applied one.",
+		ModalMsgFixMergePlan:                 "Pending migrations a merge duplicated or put out of order will be renamed after the newest applied migration, keeping their order, or removed:",
+		ModalMsgFixMergeWarning:              "Only migrations this database hasn't applied are changed. One already applied elsewhere (e.g. staging) keeps its old name there, so its next deploy applies it again; mark it applied there with migrate resolve. Apply these changes?",
+		ModalMsgFixMergeFailed:               "%d of %d changes were made before this failed:",
+		ModalMsgVerifyChecksumsFailed:        "Could not read _prisma_migrations:",
+		ModalMsgChecksumsAllMatch:            "All %d applied migrations match the checksums recorded in _prisma_migrations.",
+		ModalMsgChecksumsLineEndings:         "%d differ from their checksum only in line endings (CRLF/LF), which Prisma accepts.",
+		ModalMsgNoDeadColumns:                "Every column of the schema's tables is mapped to a field.",
+		ModalMsgDeadColumnsPending:           "%d migrations are pending; deploy them first if one of them already drops these columns.",
+		ModalMsgDropDeadColumnsWarning:       "Dropping a column deletes its data. Prisma no longer reads these columns, but other applications might. Create the migration?",
+		ModalMsgDropColumnsReview:            "Review the migration, then apply it with Deploy.",
+		ModalMsgConcurrentIndexSchemaHint:    "Declare it in schema.prisma: @@index([%s], map: \"%s\")",
+		ModalMsgConcurrentIndexRecovery:      "If it fails: DROP INDEX CONCURRENTLY, then migrate resolve --rolled-back (see the migration file).",
 		CopyLabelMigrationName:               "Migration Name",
 		CopyLabelMigrationPath:               "Migration Path",
 		CopyLabelChecksum:                    "Checksum",
+		CopyLabelPanel:                       "%s panel",
+		CopyLabelDatabaseURL:                 "Database URL",
+		CopyLabelMaskedURL:                   "Masked database URL",
+		CopyLabelCommandLine:                 "Command line",
+		CopyLabelLink:                        "Link",
 
 		// Modal Footers
 		ModalFooterInputSubmitCancel: "[Enter] Submit [ESC] Cancel",
 		ModalFooterListNavigate:      "[↑/↓] Navigate [Enter] Select [ESC] Cancel",
 		ModalFooterMessageClose:      " [Enter/q/ESC] Close ",
 		ModalFooterConfirmYesNo:      " [Y] Yes [N] No [ESC] Cancel ",
+		ModalFooterMessageViewOutput: " [o] View Output [Enter/q/ESC] Close ",
+		ModalFooterCountdownAbort:    " [ESC/q] Abort ",
 
 		// Status Bar
 		StatusStudioOn:  "[Studio: ON]",
+		StatusOffline:   "[Offline]",
+		StatusDemo:      "[Demo]",
+		StatusReadOnly:  "[Read-only]",
+		StatusNoPrisma:  "[No Prisma CLI]",
+		StatusDownloadingEngines: "Downloading Prisma engines",
+		StatusUncommittedWrites:  "[Transaction: %d uncommitted]",
+		StatusApplyingMigration:  "Applying %d/%d: %s",
+		StatusCommandStep:        "%s · %s",
+		RefreshStepVersions:      "Checking Node, Prisma and git...",
+		RefreshStepDatabase:      "Pinging the database...",
+		RefreshStepMigrations:    "Loading migrations...",
+		RefreshStepSchema:        "Validating the schema...",
 		KeyHintRefresh:  "efresh",
 		KeyHintDev:      "ev",
 		KeyHintDeploy:   "eploy",
@@ -436,34 +1792,181 @@ func EnglishTranslationSet() *TranslationSet {
 		// Log Actions
 		LogActionMigrateDeploy:            "Migrate Deploy",
 		LogMsgRunningMigrateDeploy:        "Running prisma migrate deploy...",
+		LogActionSimulateDeploy:           "Simulate Deploy",
+		LogMsgSimulateScratch:             "Creating a scratch database on %s",
+		LogMsgSimulateSteps:               "Replaying %d applied migration(s), then applying %d pending",
+		LogMsgSimulatePassed:              "All pending migrations applied cleanly; the target was not changed",
+		LogMsgSimulateFailed:              "%s failed; the target was not changed",
+		LogActionDeployScheduled:          "Deploy Scheduled",
+		LogActionDeployCountdown:          "Deploy Countdown",
+		LogMsgDeployScheduledAt:           "Deploying %d pending migration(s) at %s",
+		LogMsgDeployCountdownStarted:      "Deploying %d pending migration(s) in %s",
+		LogActionDeployAborted:            "Deploy Aborted",
+		LogMsgDeployAbortedBeforeStart:    "Aborted before the deploy started; nothing was applied",
+		LogActionExportSQL:                "Export SQL",
+		LogMsgExportedSQL:                 "Wrote %d pending migration(s) to %s",
 		LogActionMigrateDeployComplete:    "Migrate Deploy Complete",
 		LogMsgMigrationsAppliedSuccess:    "Migrations applied successfully",
 		LogActionMigrateDeployFailed:      "Migrate Deploy Failed",
 		LogMsgMigrateDeployFailedCode:     "Migrate deploy failed with exit code: %d",
+		LogActionHistoryRowDeleted:        "History Row Deleted",
+		LogMsgHistoryRowDeleted:           "Removed '%s' from _prisma_migrations",
+		LogActionChecksumAccepted:         "Checksum Updated",
+		LogMsgChecksumAccepted:            "Recorded the local checksum of '%s'",
+		LogActionMigrationRestored:        "Migration Restored",
+		LogMsgMigrationRestored:           "Restored migration.sql of '%s' from git",
+		LogActionTableCreated:             "Migration Table Created",
+		LogMsgTableCreated:                "Created an empty _prisma_migrations table",
 		LogActionMigrateResolve:           "Migrate Resolve",
 		LogMsgMarkingMigration:            "Marking migration as %s: %s",
+		LogMsgMarkingAppliedProgress:      "Marking migration %d/%d as applied: %s",
+		LogMsgRetryingMigration:           "Re-running migration.sql of %s...",
+		LogMsgRetriedMigrationSQL:         "migration.sql of %s ran successfully",
+		LogMsgCreatingExtensions:          "Running CREATE EXTENSION for %s",
+		LogMsgExtensionsCreated:           "Created %s",
+		LogMsgRetryMigrationFailedCode:    "migration.sql of %s failed with exit code %d",
+		LogMsgFailedMigrationDetected:     "%s failed; opening resolve options",
 		LogActionMigrateResolveComplete:   "Migrate Resolve Complete",
 		LogMsgMigrationMarked:             "Migration marked as %s successfully",
 		LogActionMigrateResolveFailed:     "Migrate Resolve Failed",
 		LogMsgMigrateResolveFailedCode:    "Migrate resolve failed with exit code: %d",
 		LogActionMigrateResolveError:      "Migrate Resolve Error",
+		LogActionRetryMigration:           "Retry Migration",
+		LogActionCreateExtensions:         "Create Extensions",
+		LogActionRetryMigrationFailed:     "Retry Migration Failed",
+		LogActionFailedMigrationDetected:  "Failed Migration",
 		LogActionGenerate:                 "Generate",
+		LogActionGenerators:               "Generators",
 		LogMsgRunningGenerate:             "Running prisma generate...",
+		LogMsgRunningGenerateSkipping:     "Running prisma generate, skipping %s...",
+		LogMsgFailedSaveState:             "Failed to save the project preferences:",
 		LogActionGenerateComplete:         "Generate Complete",
 		LogMsgPrismaClientGeneratedSuccess: "Prisma Client generated successfully",
 		LogActionGenerateFailed:           "Generate Failed",
 		LogMsgCheckingSchemaErrors:        "Checking schema for errors...",
 		LogActionSchemaValidationFailed:   "Schema Validation Failed",
 		LogMsgFoundSchemaErrors:           "Found %d schema errors",
+		LogMsgFailedOpenSchemaLocation:    "Failed to open %s",
 		LogActionGenerateError:            "Generate Error",
 		LogActionStudio:                   "Studio",
+		LogActionToolchain:                "Toolchain",
+		LogMsgToolchainFound:              "Found node at %s",
+		LogActionInstallPrisma:            "Install Prisma",
+		LogMsgInstallingPrisma:            "Running %s",
+		LogMsgPrismaInstalled:             "Prisma CLI installed",
 		LogMsgStartingStudio:              "Starting Prisma Studio...",
 		LogActionStudioStarted:            "Studio Started",
-		LogMsgStudioListeningAt:           "Prisma Studio is running at http://localhost:5555",
+		LogMsgStudioListeningAt:           "Prisma Studio is running at http://localhost:%d",
 		LogActionStudioStopped:            "Studio Stopped",
 		LogMsgStudioHasStopped:            "Prisma Studio has been stopped",
+		LogMsgKillingOrphanedStudio:       "Killing orphaned Prisma Studio (PID %d)",
+		LogMsgAdoptedStudio:               "Adopted running Prisma Studio (PID %d)",
+		LogMsgStudioProjectListeningAt:    "%s: Prisma Studio is running at http://localhost:%d",
+		LogMsgStudioProjectStopped:        "%s: Prisma Studio has been stopped",
+		LogMsgStudioInstancesRunning:      "%d Prisma Studio instances running",
+		LogActionFormatSQL:                "Format SQL",
+		LogActionSQLHighlight:             "SQL Highlighting",
+		LogActionEnvironmentStatus:        "Environment Status",
+		LogActionSupabase:                 "Supabase",
+		LogActionAddDirectURL:             "Add directUrl",
+		LogActionFixTLS:                   "Fix TLS settings",
+		LogActionPlanetScaleBranches:      "PlanetScale Branches",
+		LogActionAudit:                    "Audit",
+		LogActionNetworkFailure:           "Network Problem",
+		LogActionDemoMode:                 "Demo Mode",
+		LogActionReadOnly:                 "Read-only",
+		LogActionDoctor:                   "Doctor",
+		LogActionPreviewMigration:         "Preview Migration",
+		LogActionIntrospect:               "Introspect",
+		LogActionModelKeys:                "Check Keys",
+		LogActionSplitMigration:           "Split Migration",
+		LogActionRunScript:                "Run Script",
+		LogActionRunScriptComplete:        "Script Complete",
+		LogActionRunScriptFailed:          "Script Failed",
+		LogActionRerunFailed:              "Run Again Failed",
+		LogMsgQueryingEnvironments:        "Querying _prisma_migrations in %d environment(s)...",
+		LogMsgSupabaseOpened:              "Opened %s",
+		LogMsgSupabaseTargetLocal:         "%s now point at the local database %s for this session",
+		LogMsgSupabaseTargetRemote:        "%s resolve from the .env files again",
+		LogMsgDirectURLAdded:              "Edited %s",
+		LogMsgTLSParamsAdded:              "Added %s to %s in %s",
+		LogMsgPlanetScaleListing:          "Listing the branches of %s with pscale...",
+		LogMsgPlanetScaleListed:           "%d branch(es) of %s",
+		LogMsgDeployingToEnvironment:      "Running prisma migrate deploy against %s (%s)...",
+		LogMsgAuditWriteFailed:            "Failed to write audit log:",
+		LogActionWebhook:                  "Webhook",
+		LogMsgWebhookSent:                 "Posted the %s result to %s",
+		LogMsgWebhookFailed:               "Posting the %s result to %s failed: %s",
+		LogMsgWebhookNoURL:                "A webhook has no URL (%s is not set)",
+		LogMsgNetworkFailure:              "The command failed because a network request failed (npm registry or Prisma engine download).",
+		LogMsgDemoCommandSkipped:          "Not run in demo mode (no Prisma CLI or database): %s",
+		LogMsgReadOnlyCommandSkipped:      "Not run in read-only mode (another LazyPrisma has this project open): %s",
+		LogMsgNetworkFailureOffline:       "This machine appears to be offline. Install prisma in the project (npm install -D prisma) while online so commands no longer need the registry.",
+		LogMsgNetworkFailureOnline:        "The npm registry is reachable now; check proxy settings or retry.",
+		LogMsgRunningDoctor:               "Checking the toolchain, schema, database and migrations...",
+		LogActionTestConnection:           "Test Connection",
+		LogMsgTestingConnection:           "Pinging %s %d times...",
+		LogMsgConnectionTested:            "%s: %s avg, %s min, %s max",
+		LogMsgPreviewingMigration:         "Running prisma migrate diff --from-migrations (nothing is written)...",
+		LogMsgComparingKeys:               "Comparing the indexes and foreign keys of %s with the database...",
+		LogMsgKeysInSync:                  "The indexes and foreign keys of %s match the schema",
+		LogMsgKeysDiverge:                 "%s: %d indexes or foreign keys differ from the schema",
+		LogActionPeekData:                 "Peek Data",
+		LogMsgPeekingTable:                "Reading the first rows of %s",
+		LogMsgTablePeeked:                 "%s has %d rows",
+		LogActionSQLConsole:               "SQL Console",
+		LogMsgRunningQuery:                "Running %s",
+		LogMsgExplainingQuery:             "%s %s",
+		LogMsgQueryPlanned:                "The plan uses %d indexes and %d full table scans",
+		LogMsgWriteUncommitted:            "%d rows affected, not committed (%d statements in the open transaction)",
+		LogMsgTransactionCommitted:        "Committed %d statements",
+		LogMsgTransactionRolledBack:       "Rolled back %d statements",
+		LogActionOpenProject:              "Open Project",
+		LogMsgOpenedProject:               "Opened %s: %s",
+		LogMsgSplitMigrationWritten:       "Created %s (%s)",
+		LogMsgMigrationPreviewReady:       "Preview shown in the Migration Preview tab of the Details panel",
+		LogMsgIntrospecting:               "Running prisma db pull --print (nothing is written)...",
+		LogMsgIntrospectionReady:          "Introspected schema shown in the Introspection tab of the Details panel",
+		LogMsgIntrospectionApplied:        "Wrote the introspected schema to %s (the previous one is in %s)",
+		LogMsgIntrospectionCreated:        "Wrote the introspected schema to %s",
+		LogMsgIntrospectionDiscarded:      "Discarded the introspected schema",
+		LogMsgDoctorPassed:                "All checks passed",
+		LogMsgRunningScript:               "Running %s against '%s'...",
+		LogMsgScriptSucceeded:             "%s finished successfully",
+		LogMsgScriptFailed:                "%s failed with exit code %d",
+		LogMsgRerunFailed:                 "%s exited with code %d",
+		LogMsgScriptLogSaved:              "Log saved to %s",
+		LogMsgScriptLogFailed:             "Failed to save the script log:",
+		LogMsgScriptHistoryFailed:         "Failed to record the script run:",
+		LogMsgDoctorFailed:                "%d check(s) failed",
+		LogMsgEngineOverrides:             "Prisma overrides: %s",
+		LogMsgCommandSummary:              "%s · %s",
+		LogMsgInvocationCommand:           "Command: %s",
+		LogMsgInvocationDir:               "Directory: %s",
+		LogMsgInvocationEnv:               "Environment: %s",
+		SummaryCommandDone:                "Done",
+		SummaryCommandFailed:              "Exit code %d",
+		SummaryStatusUpToDate:             "%d migrations found, the database is up to date",
+		SummaryStatus:                     "%d migrations found: %d pending, %d failed",
+		SummaryDeployNoPending:            "No pending migrations to apply",
+		SummaryDeployApplied:              "%d migrations applied",
+		SummaryDeployFailed:               "%d migrations applied, %s failed",
+		SummaryGenerate:                   "%d generators ran",
+		SummaryGenerateIn:                 "%d generators ran in %s",
+		SummaryValidateValid:              "The schema is valid",
+		SummaryValidateErrors:             "%d schema errors",
+		LogMsgCommandTarget:               "Target: %s %s",
+		LogMsgCommandTargetNone:           "Target: no database URL configured",
 		LogActionMigrateDev:               "Migrate Dev",
+		LogActionDeadColumns:              "Dead Columns",
+		LogActionFixMerge:                 "Fix Merge",
 		LogMsgCreatingMigration:           "Creating migration: %s",
+		LogMsgFindingDeadColumns:          "Comparing the database's columns with the schema...",
+		LogActionVerifyChecksums:          "Verify Checksums",
+		LogMsgVerifyingChecksums:          "Recomputing the checksums of the applied migrations...",
+		LogMsgChecksumsVerified:           "%d applied migration(s) checked: %d edited, %d missing",
+		LogMsgDeadColumnsFound:            "%d columns no field maps to: %s",
+		LogMsgCreatingMigrationFlags:      "Creating migration: %s (%s)",
 		LogActionMigrateComplete:          "Migrate Complete",
 		LogMsgMigrationCreatedSuccess:     "Migration created successfully",
 		LogActionMigrateFailed:            "Migrate Failed",
@@ -473,28 +1976,203 @@ func EnglishTranslationSet() *TranslationSet {
 		LogActionDeleted:                  "Deleted",
 		LogMsgMigrationDeleted:            "Migration '%s' deleted",
 		SuccessAllPanelsRefreshed:         "All panels have been refreshed",
+		SuccessPanelsRefreshed:            "Refreshed %s",
+		RefreshScopeWorkspace:             "workspace",
+		RefreshScopeMigrations:            "migrations",
+		RefreshScopeSchema:                "schema",
+		LogMsgRefreshQueued:               "Refresh will run once '%s' has finished",
 		ActionRefresh:                     "Refresh",
+		ActionFilesChanged:                "Files Changed",
+		LogMsgFilesChangedMore:            ", and %d more",
+		ToastFileChanged:                  "%s changed, refreshing",
+		ToastFilesChanged:                 "%d files changed, refreshing",
 
 		// List Modal Items
 		ListItemSchemaDiffMigration:     "Schema diff-based migration",
 		ListItemDescSchemaDiffMigration: "Create a migration from changes in Prisma schema, apply it to the database, trigger generators (e.g. Prisma Client)",
+		ListItemApplyIntrospected:       "Apply to schema.prisma",
+		ListItemDescApplyIntrospected:   "Replace %s with the introspected schema.\n\nComments and formatting db pull cannot read back from the database are lost; review the diff in the Introspection tab first.",
+		ListItemDiscardIntrospected:     "Discard",
+		ListItemDescDiscardIntrospected: "Close the Introspection tab without changing schema.prisma.",
+		ListItemIntrospectAgain:         "Introspect again",
+		ListItemDescIntrospectAgain:     "Read the database again, e.g. after changing it.",
+		ListItemDeploy:                  "Deploy pending migrations",
+		ListItemDescDeploy:              "Run prisma migrate deploy against the target database.",
+		ListItemSimulateDeploy:          "Simulate deploy",
+		ListItemDescSimulateDeploy:      "Apply the pending migrations to a temporary scratch database and report the result of each one. The target database is not changed.\n\nThe scratch database is created next to the shadow database if one is configured, otherwise on the target's server, and dropped afterwards.",
+		ListItemRedetectToolchain:       "Re-detect toolchain",
+		ListItemDescRedetectToolchain:   "Look for node and npx on the PATH again and reload the Workspace panel.",
+		ListItemInstallPrisma:           "Install Prisma",
+		ListItemDescInstallPrisma:       "Runs %s in the project, streaming its output to the Output panel, and reloads the panels once it is installed.",
+		ListItemContinueLimited:         "Continue in limited mode",
+		ListItemDescContinueLimited:     "Browse migrations and the schema without Prisma. This dialog opens again when a command needs Prisma.",
+		ListItemEditEnvFile:             "Edit %s",
+		ListItemDescEditEnvFile:         "Open %s in your editor ($VISUAL or $EDITOR). The Workspace panel is reloaded when the editor exits.",
+		ListItemOpenSchema:              "Open schema in editor",
+		ListItemDescOpenSchema:          "Open %s in your editor ($VISUAL or $EDITOR). The panels are reloaded when the editor exits.",
+		ListItemRedetectVersions:        "Re-detect versions",
+		ListItemDescRedetectVersions:    "Look up the Node.js and Prisma versions and the Git branch again.",
+		ListItemRevealURL:               "Reveal database URL",
+		ListItemMaskURL:                 "Mask database URL",
+		ListItemDescToggleURLMask:       "Show or hide the password in the database URL (same as m).",
+		ListItemCopyURLMasked:           "Copy database URL (masked)",
+		ListItemDescCopyURLMasked:       "Copy the database URL with its password replaced by ****.",
+		ListItemCopyURL:                 "Copy database URL",
+		ListItemDescCopyURL:             "Copy the database URL including its password.",
+		ListItemTestConnection:          "Test connection",
+		ListItemDescTestConnection:      "Resolve the database URL again, ping the database a few times to measure the latency and ask it for its version.",
+		ListItemPlanetScaleBranches:     "PlanetScale branches",
+		ListItemDescPlanetScaleBranches: "List the database's branches with the pscale CLI and the environments in %s configured with them (branch: ...). Select one to deploy to its environment.",
+		ListItemSupabase:                "Supabase",
+		ListItemDescSupabase:            "Local stack status, Studio and dashboard links, and switching between the local and the remote database.",
+		SupabaseLocalRunning:            "● Local stack running",
+		SupabaseLocalRunningDesc:        "The local database accepts connections on port %d.",
+		SupabaseLocalStopped:            "○ Local stack not running",
+		SupabaseLocalStoppedDesc:        "Nothing accepts connections on port %d. Start the local stack with supabase start.",
+		ListItemSupabaseLocal:           "Use the local database",
+		ListItemDescSupabaseLocal:       "Point %s at %s for the rest of this session. Every panel and every command started from now on uses the local database; the .env files are not changed.",
+		ListItemSupabaseRemote:          "Use the remote database again",
+		ListItemDescSupabaseRemote:      "Stop overriding the database URL, so it resolves from the .env files (usually the remote project) again.",
+		ListItemSupabaseStudio:          "Open local Studio",
+		ListItemSupabaseAPI:             "Open local API",
+		ListItemSupabaseDashboard:       "Open dashboard of %s",
+		ListItemDescSupabaseLink:        "Open %s in the browser (copied to the clipboard if no browser can be started).",
+		ListItemSupabaseCopyLocalURL:    "Copy local database URL",
+		ListItemURLParams:               "URL parameters",
+		ListItemAddDirectURL:            "Add directUrl",
+		ListItemDescAddDirectURL:        "Migrations go through %s. Add directUrl = env(\"DIRECT_URL\") to the schema, set to the database without the pooler, so migrate connects directly.",
+		ListItemFixTLS:                  "Fix TLS settings",
+		ListItemDescFixTLS:              "Add %s to %s in %s, so the connection works around the TLS error.",
+		ListItemDescURLParams:           "The %d parameters of the database URL, what each does, and %d problem(s) with them.",
+		ListItemHistoryFilter:           "Filter…",
+		ListItemHistoryFiltered:         "Filter: %s (%d matching)",
+		ListItemDescHistoryFilter:       "Show only the commands whose command line contains a text, e.g. deploy or --schema.",
+		ListItemHistoryCopy:             "Copy command line",
+		ListItemDescHistoryCopy:         "Copy %s to the clipboard.",
+		ListItemHistoryRerun:            "Run again",
+		ListItemDescHistoryRerun:        "Run the command again, with the same environment overrides. Its output is streamed to the Output panel.",
+		ListItemDeployCountdown:         "Deploy after countdown (%s)",
+		ListItemDescDeployCountdown:     "Count down before deploying the pending migrations, with a last chance to abort with ESC.\n\nSet deploy.countdownSeconds in the config file to change the delay.",
+		ListItemScheduleDeploy:          "Schedule deploy",
+		ListItemDescScheduleDeploy:      "Wait until a time you enter, e.g. the start of a maintenance window, then deploy the pending migrations.\n\nLazyPrisma must keep running until then; press ESC to abort.",
+		ListItemExportPendingSQL:        "Export pending as SQL",
+		ListItemDescExportPendingSQL:    "Concatenate the pending migrations into one ordered .sql file for applying by hand, e.g. by a DBA, with a marker comment before each migration. Optionally mark them as applied afterwards.",
+		ListItemExportCount:             "Export %d migration(s)",
+		ListItemDescExportCount:         "Choose where to write the file.",
+		ListItemExportTransaction:       "Wrap in a transaction (BEGIN/COMMIT)",
+		ListItemDescExportTransaction:   "Put the whole script in one transaction, so that a failure leaves the database unchanged. MySQL commits DDL statements implicitly, so there it only protects data changes.",
+		ListItemDescWillExport:          "Included in the export, in this order.",
+		ListItemImportReceipt:           "Import receipt...",
+		ListItemDescImportReceipt:       "Mark only the migrations reported as applied outside LazyPrisma, e.g. by a DBA running an exported script: read a receipt file listing their names and mark those that are still pending as applied.",
+		ListItemMarkReceiptApplied:      "Mark %d migration(s) from the receipt as applied",
+		ListItemDescMarkReceipt:         "Record the pending migrations of the receipt as applied without running their SQL. They are marked one by one, oldest first, stopping at the first failure.",
+		ListItemDescReceiptApplied:      "Already applied; skipped.",
+		ListItemDescReceiptUnknown:      "Not a local migration; skipped. Check that the receipt belongs to this project and branch.",
+		ListItemDescReceiptGap:          "Pending but not in the receipt, although a later migration is. It stays pending; deploy would apply it after the later one.",
 		ListItemManualMigration:         "Manual migration",
 		ListItemDescManualMigration:     "This tool creates manual migrations for database changes that cannot be expressed through Prisma schema diff. It is used to explicitly record and version control database-specific logic such as triggers, functions, and DML operations that cannot be managed at the Prisma schema level.",
+		ListItemFindDeadColumns:         "Find dead columns",
+		ListItemDescFindDeadColumns:     "Compare the database's columns with the schema and list those no model field maps to, usually left behind by fields removed from the schema without a migration. A migration that drops them can then be generated.",
+		ListItemDropDeadColumns:         "Generate cleanup migration (%d columns)",
+		ListItemDescDropDeadColumns:     "Write a migration that drops every column below. Review it before deploying: the data in them is lost.",
+		ListItemDescDeadColumn:          "%s is a column of %s that no field of its model maps to. Select it to write a migration that drops only this column.",
+		ListItemDescPeekTable:           "Show the first rows of %s and how many rows it has.",
+		ListItemRunStatement:            "Run statement...",
+		ListItemDescRunStatement:        "Enter an SQL statement to run against the project's database. Its rows are shown in the Details panel's Query tab. Writes run in a transaction that stays open, so you can check their effect, until you commit or roll it back from this menu.",
+		ListItemCommitTransaction:       "Commit transaction (%d statements)",
+		ListItemDescCommitTransaction:   "Make the writes run in the open transaction permanent:",
+		ListItemRollbackTransaction:     "Roll back transaction",
+		ListItemDescRollbackTransaction: "Undo the writes run in the open transaction:",
+		ListItemOpenInEditor:            "Open %s in %s",
+		ListItemDescOpenInEditor:        "Runs %s. Set open.editor in the config file to use another editor, e.g. idea.",
+		ListItemOpenInFileManager:       "Show %s in the file manager",
+		ListItemDescOpenInFileManager:   "Runs %s. Set open.fileManager in the config file to use another file manager.",
+		ListItemDescConsoleHistory:      "Run again:\n\n%s",
+		ListItemExplain:                 "EXPLAIN",
+		ListItemDescExplain:             "Show the plan the database chooses for the statement instead of running it: its scans and joins as an indented tree, with the indexes it uses and the tables it reads in full. Use it to check that an index a migration added is picked up (PostgreSQL and MySQL).",
+		ListItemAnalyze:                 "ANALYZE",
+		ListItemDescAnalyze:             "Run the statement to measure each step of its plan (EXPLAIN ANALYZE). It runs in a transaction that is rolled back, so an analyzed write changes nothing.",
+		ConsoleToggleHint:               "Press Enter to toggle. The choice is kept until you quit.",
+		ListItemConcurrentIndex:         "Concurrent index (PostgreSQL)",
+		ListItemDescConcurrentIndex:     "Create a migration that builds an index with CREATE INDEX CONCURRENTLY, without locking the table against writes. The statement must stay alone in its migration; the migration file documents how to retry or apply it by hand.",
+		ListItemCreateExtMigration:      "Add migration creating %s",
+		ListItemDescCreateExtMigration:  "Create a migration that runs CREATE EXTENSION IF NOT EXISTS for each missing extension, ordered just before %s so deploy applies it first.",
+		ListItemCreateExtNow:            "Create %s now",
+		ListItemDescCreateExtNow:        "Create the same migration, run it right away with prisma db execute and mark it as applied, so it is kept in the migration history. The database user must be allowed to create extensions.",
+		ListItemDescMissingExtension:    "%s is not installed; %s needs it:",
+		ListItemSkipGenerate:            "Skip generate (--skip-generate)",
+		ListItemDescSkipGenerate:        "Create migrations without running the generators afterwards, for projects that run prisma generate separately.",
+		ListItemSkipSeed:                "Skip seed (--skip-seed)",
+		ListItemDescSkipSeed:            "Create migrations without running the seed script.",
+		MigrateDevToggleHint:            "Press Enter to toggle. The choice is remembered for this project on this machine.",
 		ListItemMarkApplied:             "Mark as applied",
 		ListItemDescMarkApplied:         "Mark this migration as successfully applied to the database. Use this if you have manually fixed the issue and the migration changes are now present in the database.",
 		ListItemMarkRolledBack:          "Mark as rolled back",
+		ListItemRecommended:             "(recommended)",
 		ListItemDescMarkRolledBack:      "Mark this migration as rolled back (reverted from the database). Use this if you have manually reverted the changes and the migration is no longer applied to the database.",
+		ListItemDeleteHistoryRow:        "Delete history row",
+		ListItemDescDeleteHistoryRow:    "Remove this migration's row from _prisma_migrations. Use this if the migration was removed from the project on purpose. Nothing else in the database is changed.",
+		ListItemRestoreMigration:        "Restore migration.sql from git",
+		ListItemDescRestoreMigration:    "Discard the uncommitted changes to migration.sql so that it matches the committed version again. Use this if the migration was edited by mistake after it was applied.",
+		ListItemAcceptLocalChecksum:     "Accept the edited file",
+		ListItemDescAcceptLocalChecksum: "Record the checksum of the local migration.sql in _prisma_migrations. Use this only if the database already matches the edited SQL (e.g. a comment or formatting change). The edited statements are not run.",
+		ListItemDescChecksumEdited:      "migration.sql was changed after the migration was applied, so migrate dev will want to reset the database. Select it to restore the file from git or record its new checksum.",
+		ListItemDescChecksumMissing:     "The migration is applied, but its migration.sql is not in the migrations directory, so migrate dev will report it as missing. Restore it from git or the branch it was created on.",
+		ListItemInitTrackingDeploy:      "Apply all migrations (migrate deploy)",
+		ListItemDescInitTrackingDeploy:  "Create _prisma_migrations and run every migration in order. Use this on an empty database. It fails if the tables already exist (e.g. created with db push or by hand).",
+		ListItemInitTableOnly:           "Create the table only",
+		ListItemDescInitTableOnly:       "Create an empty _prisma_migrations table with the definition Prisma uses. Nothing else is run and every migration stays pending. Use this for a database that already has the schema, then mark the migrations it already contains as applied. PostgreSQL and MySQL only.",
+		ListItemInitBaseline:            "Mark all migrations as applied",
+		ListItemDescInitBaseline:        "Create _prisma_migrations and record every migration as applied without running its SQL. Use this for a database that already matches the schema, e.g. one managed outside Prisma until now.",
+		ListItemMarkAllApplied:          "Mark all %d as applied",
+		ListItemDescMarkAllApplied:      "Record every migration below as applied without running its SQL. Use this only if the database already contains these changes, e.g. when it was managed outside Prisma. They are marked one by one, oldest first, stopping at the first failure.",
+		ListItemDescWillMarkApplied:     "Will be recorded as applied. Its SQL is not run.",
+		ListItemRetryMigration:          "Re-run and mark applied",
+		ListItemDescRetryMigration:      "Run this migration's migration.sql again with prisma db execute and, if it succeeds, mark it as applied. Use this after fixing the SQL that failed during deploy. The SQL is not run in a transaction, so statements that already succeeded must be safe to repeat. Later migrations are left for the next deploy.",
 		ListItemCopyName:                "Copy Name",
 		ListItemCopyPath:                "Copy Path",
 		ListItemCopyChecksum:            "Copy Checksum",
+		ListItemViewMigration:           "View details",
+		ListItemDescViewMigration:       "Show the migration in the Details tab and move the focus to the Details panel, to scroll through its SQL.",
+		ListItemEditMigrationSQL:        "Edit migration.sql",
+		ListItemDescEditMigrationSQL:    "Open %s in $VISUAL / $EDITOR. The migrations reload when the editor exits.",
+		ListItemDescEditAppliedSQL:      "This migration is already applied: once it is edited, its checksum no longer matches the one in _prisma_migrations and migrate dev will want to reset the database.",
+		ListItemEditDownSQL:             "Edit down.sql",
+		ListItemDescEditDownSQL:         "Open %s, the SQL that reverts this migration, in $VISUAL / $EDITOR.",
+		ListItemReviewMigration:         "Review",
+		ListItemDescReviewMigration:     "Go through this pending migration statement by statement, with what can go wrong and the lock each one takes (same as v).",
+		ListItemPeekMigrationData:       "Peek at data",
+		ListItemDescPeekMigrationData:   "Show the first rows of the tables this migration creates in the Details panel (same as T).",
+		ListItemResolveFailed:           "Resolve",
+		ListItemDescResolveFailed:       "Mark this failed migration as applied or rolled back, or re-run it once its SQL is fixed (same as s).",
+		ListItemFixChecksum:             "Fix checksum mismatch",
+		ListItemDescFixChecksum:         "Restore the edited migration.sql from git or record its new checksum (same as s).",
+		ListItemDeleteMigration:         "Delete",
+		ListItemDescDeleteMigration:     "Delete this pending migration's folder (same as Delete).",
+		ListItemDescRemoveHistoryRow:    "Remove this migration's row from _prisma_migrations without changing anything else in the database (same as X).",
+		ListItemCopyMigration:           "Copy",
+		ListItemDescCopyMigration:       "Copy the migration's name, path or checksum to the clipboard (same as c).",
+		ListItemKillRestartStudio:       "Kill and restart",
+		ListItemDescKillRestartStudio:   "Terminate the existing Prisma Studio process and start a fresh one for this workspace.",
+		ListItemAdoptStudio:             "Adopt",
+		ListItemDescAdoptStudio:         "Keep the existing Prisma Studio running and manage it from here. Pressing 'S' will stop it.",
+		ListItemStudioInstanceRunning:   "● %s  http://localhost:%d",
+		ListItemStudioInstanceStopped:   "○ %s",
+		ListItemDescStudioRunning:       "Project: %s\nURL: http://localhost:%d\n\nPress Enter to stop this instance.",
+		ListItemDescStudioStopped:       "Project: %s\n\nPress Enter to start Prisma Studio for this project.",
+		ListItemStudioStopAll:           "Stop all",
+		ListItemDescStudioStopAll:       "Stop every Prisma Studio instance managed by this session.",
 
 		// Details Panel - Migration Status
 		MigrationStatusInTransaction:    "⚠ In-Transaction",
 		MigrationStatusDBOnly:           "✗ DB Only",
 		MigrationStatusChecksumMismatch: "⚠ Checksum Mismatch",
+		ChecksumStatusEdited:            "edited",
+		ChecksumStatusMissing:           "missing",
 		MigrationStatusApplied:          "✓ Applied",
 		MigrationStatusEmptyMigration:   "⚠ Empty Migration",
 		MigrationStatusPending:          "⚠ Pending",
+		MigrationStatusUntracked:        "Applied with %s (not tracked)",
 
 		// Details Panel - Labels & Descriptions
 		DetailsPanelInitialPlaceholder:       "Details\n\nSelect a migration to view details...",
@@ -502,6 +2180,7 @@ func EnglishTranslationSet() *TranslationSet {
 		DetailsTimestampLabel:                "Timestamp: %s\n",
 		DetailsPathLabel:                     "Path: %s\n",
 		DetailsStatusLabel:                   "Status: ",
+		DetailsApplyWithLabel:                "Apply With: ",
 		DetailsAppliedAtLabel:                "Applied at: %s",
 		DetailsDownMigrationLabel:            "Down Migration: ",
 		DetailsDownMigrationAvailable:        "✓ Available",
@@ -516,9 +2195,204 @@ func EnglishTranslationSet() *TranslationSet {
 		DetailsChecksumIssuesWarning:         "This can cause issues during deployment.\n\n",
 		DetailsLocalChecksumLabel:            "Local Checksum:   ",
 		DetailsHistoryChecksumLabel:          "History Checksum: ",
+		DetailsChecksumDiffLabel:             "Changes since the applied version (%s):",
+		DetailsAppliedVersionCommit:          "commit %s, %s",
+		DetailsAppliedVersionStaged:          "staged in git",
+		DetailsChecksumDiffUnavailable:       "The applied version of migration.sql was not found in git history, so no diff can be shown.",
+		DetailsChecksumDiffLoading:           "Looking up the applied version of migration.sql in git history...",
+		SchemaPanelNoSchema:                  "schema.prisma not found",
+		SchemaPanelEmpty:                     "The schema declares no models, enums or generators yet.",
+		SchemaGroupModels:                    "Models",
+		SchemaGroupViews:                     "Views",
+		SchemaGroupEnums:                     "Enums",
+		SchemaGroupTypes:                     "Composite Types",
+		SchemaGroupGenerators:                "Generators",
+		SchemaKindModel:                      "Model",
+		SchemaKindView:                       "View",
+		SchemaKindEnum:                       "Enum",
+		SchemaKindType:                       "Composite Type",
+		SchemaKindGenerator:                  "Generator",
+		SchemaFieldCount:                     "%d fields",
+		SchemaValueCount:                     "%d values",
+		SchemaRelationMany:                   "many",
+		SchemaRelationOptional:               "optional",
+		SchemaRelationOne:                    "one",
+		DetailsSchemaEntryTitle:              "%s: %s\n",
+		DetailsSchemaDatabaseNameLabel:       "Database Name: %s\n",
+		DetailsSchemaDefinedAtLabel:          "Defined At: %s:%d\n",
+		DetailsSchemaFieldsLabel:             "Fields:",
+		DetailsSchemaValuesLabel:             "Values:",
+		DetailsSchemaSettingsLabel:           "Settings:",
+		DetailsSchemaRelationsLabel:          "Relations:",
+		DetailsSchemaReferencedByLabel:       "Referenced By:",
+		DetailsSchemaAttributesLabel:         "Block Attributes:",
+		DetailsSchemaEntryHint:               "Press Enter in the Schema panel to open this definition in the Schema tab.",
+		DetailsSchemaGroupHint:               "Press Enter in the Schema panel to fold or unfold the group.",
+		SchemaHistoryTitle:                   "Schema History (prisma/schema.prisma)",
+		SchemaHistoryHint:                    "Press H to view a revision or diff it against the current schema.",
+		SchemaHistoryViewTitle:               "schema.prisma at %s (%s, %s)",
+		SchemaHistoryDiffTitle:               "Changes from %s to the current schema.prisma:",
+		SchemaHistoryNoChanges:               "schema.prisma is unchanged since this commit.",
+		MigrationPreviewTitle:                "SQL the next migration would contain (generated at %s)",
+		MigrationPreviewHint:                 "Nothing was written: create the migration with d when it looks right, or split it into several with P. Press p again after editing the schema.",
+		MigrationPreviewNoChanges:            "No changes: the migrations already match schema.prisma.",
+		IntrospectionTitle:                   "Schema introspected from the database (read at %s)",
+		IntrospectionHint:                    "Nothing was written: press I to apply it to schema.prisma or discard it. Red lines are only in schema.prisma, green ones are what db pull would write.",
+		IntrospectionNoChanges:               "No changes: schema.prisma already matches the database.",
+		IntrospectionLineCount:               "%d lines added, %d removed",
+		SplitMigrationStatementCount:         "%d statements",
+		SplitMigrationToggleHint:             "Press Enter to add this table to the next migration or take it out. Tables are listed in the order they first appear, which keeps a table created before it is altered.",
+		SplitMigrationOtherStatements:        "(other statements)",
+		SplitMigrationOtherFirst:             "Statements without a table (types, extensions, ...) always go into the first migration, since the tables may use them.",
+		ReviewToggleHint:                     "Press Enter to tick this statement off as reviewed (kept until you quit or the file changes).",
+		StatementRiskDropTable:               "Drops a table and all of its data.",
+		StatementRiskDropColumn:              "Drops a column and all of its data.",
+		StatementRiskDropObject:              "Drops a database object; anything depending on it breaks or is dropped with it.",
+		StatementRiskDeleteRows:              "Deletes rows.",
+		StatementRiskUpdateRows:              "Rewrites existing rows.",
+		StatementRiskAlterType:               "Changes a column type: fails if existing values can't be cast, and can truncate or lose precision.",
+		StatementRiskSetNotNull:              "Makes a column required: fails if any row holds NULL.",
+		StatementRiskRename:                  "Renames an object: code and queries using the old name break.",
+		StatementRiskAddUniqueness:           "Adds a unique or primary key constraint: fails if existing rows hold duplicates.",
+		LockEstimate:                         "Estimated lock: %s, %s %s.",
+		LockBlocksReadsWrites:                "blocks reads and writes on the table",
+		LockBlocksWrites:                     "blocks writes to the table (reads continue)",
+		LockBlocksNothing:                    "reads and writes continue",
+		LockHeldBriefly:                      "for a moment (catalog change only)",
+		LockHeldWhileScanning:                "for as long as it scans or rewrites the rows",
+		LockTipConcurrently:                  "Tip: build or drop the index CONCURRENTLY (e.g. the concurrent index migration under d) so writes can continue.",
+		LockTipNotValid:                      "Tip: add the constraint NOT VALID first, then VALIDATE CONSTRAINT in a later migration; validating doesn't block writes.",
+		LockTipCheckNotNull:                  "Tip: add CHECK (column IS NOT NULL) NOT VALID and validate it first; PostgreSQL 12+ then skips the full scan for SET NOT NULL.",
+		LockTipMetadataLock:                  "Note: the ALTER waits for a metadata lock behind any open transaction on the table, and queries queue up behind it. Deploy when no long transactions are running.",
+		LockTipBatchRows:                     "Tip: on large tables, update or delete in batches to keep row locks and replication lag short.",
+		LockTipNewColumnCopy:                 "Tip: on large tables, add a new column, backfill it in batches and switch over instead of rewriting the table in place.",
+		SplitMigrationFileHeader:             "-- Part %d of a migration split by lazyprisma\n-- Tables: %s",
+		ListItemCreateSplitMigration:         "→ Create migration from %d selected",
+		ListItemDescCreateSplitMigration:     "Write the selected tables' statements to a new migration folder, then pick the tables for the next one. Foreign keys are written last and must point to tables created in the same or an earlier migration.",
+		EnumChangeWarningTitle:               "Enum values changed — check before creating the migration",
+		EnumChangeCaveatPostgres:             "PostgreSQL: added values become ALTER TYPE ... ADD VALUE; a new value can't be used in the transaction that adds it, and before PostgreSQL 12 the statement can't run in a transaction at all. Removing or renaming a value makes Prisma recreate the type and cast every column below; the migration fails if any row still holds a removed value, so update those rows first.",
+		EnumChangeCaveatMySQL:                "MySQL: enums are part of each column definition, so every change rewrites the columns below with ALTER TABLE ... MODIFY, copying and locking large tables. Rows holding a removed value fail the migration in strict mode or are silently set to '' otherwise; update them first.",
+		EnumChangeCaveatOther:                "This database has no native enum type: values are stored as text and only checked by Prisma Client. Rows still holding a removed value stay in the table and fail to load, so update them first.",
+		EnumChangeDropped:                    "(dropped)",
+		EnumChangeUsedBy:                     "used by",
+		EnumChangeNoColumns:                  "Not used by any column.",
+		SchemaHistoryShowTimeline:            "Show timeline",
+		SchemaHistoryActionView:              "View schema at this commit",
+		SchemaHistoryActionDiff:              "Diff against current schema",
+		CompareBranchesItemDescription:       "Compare the migrations on %s with %s. The branch is read from git; nothing is checked out.",
+		CompareBranchesOnlyOn:                "This migration exists only on %s.",
+		CompareBranchesOutOfOrderTag:         "out of order",
+		CompareBranchesOutOfOrderWarning:     "It is older than the newest local migration. After merging it will sort before migrations that may already be applied, and migrate deploy will apply it out of order.",
+		EnvironmentSummaryError:              "%s: unreachable",
+		EnvironmentSummaryBehind:             "%s: %d migration(s) behind",
+		EnvironmentSummaryUpToDate:           "%s: up to date",
+		EnvironmentMissingLabel:              "Not applied:",
+		EnvironmentTagProtected:              "protected",
+		PlanetScaleTagProduction:             "production",
+		PlanetScaleTagNotReady:               "not ready",
+		PlanetScaleBranchDescription:         "Branch: %s\nParent: %s\nCreated: %s",
+		PlanetScaleBranchDeployHint:          "Press Enter to run migrate deploy against %s.",
+		PlanetScaleBranchUnmapped:            "No environment uses this branch. Add branch: %s to an environment in %s to deploy to it from here.",
+		URLParamHintSchema:                   "PostgreSQL schema Prisma uses (default public).",
+		URLParamHintHost:                     "Directory of the Unix socket to connect through.",
+		URLParamHintSSLMode:                  "Whether to use TLS: disable, prefer (default) or require. Prisma has no verify-full; require with sslaccept=strict verifies the certificate.",
+		URLParamHintSSLCert:                  "Server certificate to trust, relative to the schema file.",
+		URLParamHintSSLRootCert:              "Root certificate chain to trust, relative to the schema file.",
+		URLParamHintSSLIdentity:              "PKCS12 client certificate, relative to the schema file.",
+		URLParamHintSSLPassword:              "Password of the sslidentity file.",
+		URLParamHintSSLAccept:                "strict (default) rejects invalid certificates; accept_invalid_certs skips the check.",
+		URLParamHintConnectionLimit:          "Size of Prisma Client's connection pool (default: CPU cores × 2 + 1). Serverless functions usually want 1.",
+		URLParamHintConnectTimeout:           "Seconds to wait for a new connection (default 5, 0 = no limit).",
+		URLParamHintPoolTimeout:              "Seconds a query waits for a free pool connection (default 10, 0 = no limit).",
+		URLParamHintSocketTimeout:            "Seconds to wait for a query's result (default: no limit).",
+		URLParamHintMaxLifetime:              "Seconds before a pooled connection is replaced.",
+		URLParamHintMaxIdleLifetime:          "Seconds an idle pooled connection is kept.",
+		URLParamHintStatementCache:           "Prepared statements cached per connection (default 100, 0 = none).",
+		URLParamHintPgBouncer:                "true when connecting through PgBouncer in transaction mode: prepared statements are not cached. Migrations need a directUrl that bypasses the pooler.",
+		URLParamHintApplicationName:          "Name the connection has in pg_stat_activity.",
+		URLParamHintChannelBinding:           "SCRAM channel binding: disable, prefer or require.",
+		URLParamHintOptions:                  "Options sent to the server on connecting, e.g. -c search_path=app.",
+		URLParamHintSocket:                   "Unix socket to connect through.",
+		URLParamHintDatabase:                 "Database to connect to.",
+		URLParamHintEncrypt:                  "Whether to use TLS: true, false or strict.",
+		URLParamHintTrustServerCert:          "true skips verifying the server certificate.",
+		URLParamUnknown:                      "Prisma doesn't read %s for %s.",
+		URLParamDidYouMean:                   "Did you mean %s?",
+		URLParamInvalidValues:                "Prisma accepts: %s.",
+		URLParamInvalidNumber:                "Expected a whole number (0 or more).",
+		URLParamDuplicate:                    "Set more than once: only one of the values is used.",
+		URLParamPgBouncerNoDirect:            "⚠ pgbouncer=true without directUrl",
+		URLParamPgBouncerNoDirectDesc:        "Migrations run through the pooler, where migrate's advisory locks and prepared statements fail. Add directUrl = env(\"DIRECT_URL\") to the datasource, set to a connection that bypasses PgBouncer.",
+		EnvironmentColumnMigration:           "Migration",
+		EnvironmentLegend:                    "✓ applied   · not applied   ✗ failed   ? unknown (environment unreachable)",
+		ScriptsLastRunsLabel:                 "Last run per environment:",
+		ScriptsNeverRun:                      "never run",
+		ScriptsExitCode:                      "exit %d",
+		ScriptsDefaultEnvironmentDesc:        "The database the datasource points at, without any override.",
+		ScriptsEnvironmentDesc:               "Run with the datasource pointed at environment '%s' from .lazyprisma.yaml.",
+		ResolveGuidanceFailed:                "%s failed",
+		ResolveGuidanceAlreadyExists:         "The database says the change already exists, so this migration's changes are probably in place already (e.g. made by hand). If all of them are, mark it as applied.",
+		ResolveGuidanceRolledBack:            "Databases with transactional DDL (PostgreSQL, SQL Server, SQLite) keep nothing from a failed migration. Fix migration.sql, then mark it as rolled back and deploy again, or re-run just this migration.",
+		ResolveGuidanceMySQL:                 "MySQL commits each schema change: statements before the failing one are still applied. Undo them or finish the rest by hand before resolving.",
+		GeneratorDescEnabled:                 "Generate runs %s.",
+		GeneratorDescSkipped:                 "Generate skips %s for this project.",
+		GeneratorToggleHint:                  "Press Enter to toggle. The choice is remembered for this project on this machine; the schema is not changed.",
+		SchemaErrorJumpHint:                  "Press Enter to open this line in the Schema tab of the Details panel.",
+		EnvironmentAppliedEverywhere:         "%s is applied in every reachable environment.",
+		EnvironmentNotAppliedIn:              "%s is not applied in: %s",
+		EnvironmentDeployHint:                "Enter: deploy pending migrations to this environment",
+		AuditEntryDescription:                "%s\n\nTime:      %s\nUser:      %s\nDatabase:  %s\nDirectory: %s\nExit code: %d\nDuration:  %s",
+		HistoryEntryDescription:              "%s\n\nTime:      %s\nExit code: %d\nDuration:  %s\nEnv:       %s",
+		UsageStatsRanks:                      "Fresh Schema|Migration Apprentice|Schema Wrangler|Migration Maestro|Prisma Archmage",
+		UsageStatsSince:                      "Tracking since %s (%d days)",
+		UsageStatsCommandsRun:                "Commands run: %d (%d%% succeeded)",
+		UsageStatsMostUsed:                   "Most used",
+		UsageStatsDeploy:                     "Migrate deploy",
+		UsageStatsDeployDurations:            "Average %s, fastest %s, slowest %s over %d runs",
+		UsageStatsNoDeploys:                  "No successful deploys yet",
+		DoctorStatusPass:                     "Passed",
+		DoctorStatusWarn:                     "Warning",
+		DoctorStatusFail:                     "Failed",
+		DoctorStatusSkip:                     "Skipped",
+		DoctorFixLabel:                       "Fix:",
+		DoctorCheckNode:                      "Node.js",
+		DoctorCheckPrisma:                    "Prisma CLI / Client",
+		DoctorCheckSchema:                    "Schema",
+		DoctorCheckEnv:                       "Database URL (.env)",
+		DoctorCheckDatabase:                  "Database connection",
+		DoctorCheckShadowDB:                  "Shadow database",
+		DoctorCheckMigrations:                "Migrations directory",
+		DoctorFixNodeMissing:                 "Install Node.js and make sure node is on your PATH.",
+		DoctorFixNodeTooOld:                  "Upgrade Node.js to the version this Prisma release requires (e.g. with nvm install --lts).",
+		DoctorFixNodePinMismatch:             "Switch to the pinned version (e.g. nvm use or fnm use), or set node.versionManager in the config file to run commands through your version manager.",
+		DoctorFixPrismaMissing:               "Install the Prisma CLI in the project: npm install -D prisma",
+		DoctorFixClientMissing:               "Install the client: npm install @prisma/client",
+		DoctorFixVersionMismatch:             "Install matching versions, e.g. npm install -D prisma@latest @prisma/client@latest, then run generate.",
+		DoctorFixSchemaInvalid:               "Fix the reported error in the schema; run prisma validate for the full list.",
+		DoctorFixNoDatasource:                "Add a datasource block with a provider and url to the schema (or prisma.config.ts).",
+		DoctorFixEnvNotSet:                   "Set the variable in your shell or in a .env file next to the project or schema.",
+		DoctorFixUnsupportedProvider:         "Connection checks are only available for PostgreSQL, CockroachDB and MySQL.",
+		DoctorFixDBUnreachable:               "Check that the database server is running and the host, port, credentials and database name in the URL are correct.",
+		DoctorFixDriverAdapterOnly:           "The app reaches this database through its driver adapter, which lazyprisma can't use; it connects only to postgresql://, mysql://, sqlserver:// and file: URLs. Use prisma commands or the provider's own tools to check it.",
+		DoctorFixShadowUnreachable:           "Check the shadowDatabaseUrl: the database must exist and accept the given credentials.",
+		DoctorFixNoCreateDB:                  "migrate dev needs to create a shadow database. Grant CREATEDB (PostgreSQL) or CREATE ON *.* (MySQL), or set shadowDatabaseUrl.",
+		DoctorFixPlanetScaleShadow:           "PlanetScale databases can't be created over a connection, so migrate dev has no shadow database there. Push schema changes to a development branch with prisma db push, or set shadowDatabaseUrl to a local MySQL database.",
+		DoctorFixNoMigrations:                "No migrations yet. Create the first one with migrate dev (d), or baseline an existing database.",
+		DoctorFixLockMissing:                 "Restore migration_lock.toml from version control, or recreate it with the provider of the datasource.",
+		DoctorFixProviderMismatch:            "The migrations were created for another provider. Reset the migration history or switch the datasource back.",
+		DoctorFixEmptyMigration:              "Add SQL to the migration or delete the empty folder.",
+		DoctorFixBadMigrationName:            "Migration folders should be named <timestamp>_<name>; prisma applies them in name order.",
+		EngineOverrideValue:                  "Value:",
+		EngineOverrideSource:                 "Source:",
+		EngineOverrideSourceEnv:              "process environment",
 		DetailsEmptyMigrationDescription:     "This migration folder is empty or missing migration.sql.\n",
 		DetailsEmptyMigrationWarning:         "This may cause issues during deployment.",
 		DetailsDownMigrationSQLLabel:         "Down Migration SQL:",
+		DetailsSQLFormattedIndicator:         "formatted",
+		SQLHighlightChroma:                   "Full syntax highlighting",
+		SQLHighlightKeywords:                 "Keywords and comments only",
+		SQLHighlightOff:                      "Off (plain text)",
+		DetailsSchemaBackIndicator:           "Esc: back (%d)",
 		ErrorReadingMigrationSQL:             "Error reading migration.sql:\n%v",
 
 		// Details Panel - Action Needed
@@ -540,6 +2414,25 @@ func EnglishTranslationSet() *TranslationSet {
 		ActionNeededRevertLocalChanges:              "  → Revert local changes\n",
 		ActionNeededCreateNewInstead:                "  → Create new migration instead\n",
 		ActionNeededContactTeamIfNeeded:             "  → Contact team if needed\n\n",
+		ActionNeededNoMigrationTableHeader:          "Migration Tracking Not Initialized",
+		ActionNeededNoMigrationTableDesc:            "The database has no _prisma_migrations table,\nso every migration shows as pending.\n\n",
+		ActionNeededInitializeTracking:              "  → Press s to deploy all migrations or\n    create the table for an existing schema\n\n",
+		ActionNeededEnvConflictHeader:               "Conflicting %s Definitions",
+		ActionNeededEnvConflictDesc:                 "%s is defined in more than one place with different values.\nOnly the first one is used, which may not be\nthe database you expect.\n\n",
+		ActionNeededEnvConflictSourcesLabel:         "Definitions (first wins):\n",
+		ActionNeededEnvConflictInUse:                " (in use)",
+		ActionNeededEnvConflictAlign:                "  → Remove the definitions you don't want, or\n    make them agree\n\n",
+		ActionNeededPooledHeader:                    "Migrations Through a Connection Pooler",
+		ActionNeededPooledDesc:                      "Migrations connect through the datasource's %s,\nwhich goes through %s in transaction mode.\nprisma migrate needs a session of its own:\nits advisory lock and prepared statements fail,\nand migrations hang or error out.\n\n",
+		ActionNeededPooledAddDirectURL:              "  → Add a directUrl without the pooler\n    (Enter on Workspace › Add directUrl)\n",
+		ActionNeededPooledFixDirectURL:              "  → Point %s at the database\n    without the pooler\n",
+		ActionNeededPooledUnpooled:                  "    e.g. %s\n",
+		ActionNeededMergeHeader:                     "Migrations Duplicated or Reordered by a Merge",
+		ActionNeededMergeDesc:                       "A git merge brought in migrations that share a\ntimestamp or SQL with another one, or sort before\nmigrations this database already applied.\nA fresh database would apply them in another\norder than this one.\n\n",
+		ActionNeededMergeFix:                        "  → Press M to rename or remove them as listed\n    (Fix merge)\n\n",
+		MergeFixDuplicateSQL:                        "same SQL as %s",
+		MergeFixDuplicateTimestamp:                  "same timestamp as %s",
+		MergeFixOutOfOrder:                          "sorts before a migration that is already applied",
 		ActionNeededSchemaValidationErrorsHeader:    "Schema Validation Errors",
 		ActionNeededSchemaValidationFailedDesc:      "Schema validation failed.\n",
 		ActionNeededFixBeforeMigration:              "Fix these issues before running migrations.\n\n",
@@ -551,17 +2444,49 @@ func EnglishTranslationSet() *TranslationSet {
 
 		// Workspace Panel
 		WorkspaceVersionLine:              "Node: %s | Prisma: %s",
+		WorkspaceNodePinned:               "(%s: %s)",
+		WorkspaceNodePinMatch:             "(✓ %s)",
+		WorkspaceNodePinMismatch:          "(%s wants %s)",
+		WorkspaceNodeViaManager:           "via %s",
 		WorkspacePrismaGlobalIndicator:    " (Global)",
 		WorkspaceGitLine:                  "Git: %s",
 		WorkspaceSchemaModifiedIndicator:  " (schema modified)",
 		WorkspaceBranchFormat:             "(%s)",
 		WorkspaceNotGitRepository:         "Git: Not a git repository",
+		WorkspaceRecentFiles:              "Recently changed:",
+		WorkspaceRecentFileAge:            "%s ago",
+		WorkspaceRecentFileJustNow:        "just now",
 		WorkspaceConnected:                "✓ Connected",
 		WorkspaceNotConfigured:            "✗ Not configured",
 		WorkspaceDisconnected:             "✗ Disconnected",
 		WorkspaceProviderLine:             "Provider: %s  %s",
 		WorkspaceHardcodedIndicator:       " (Hard coded)",
 		WorkspaceNotSet:                   "Not set",
+		WorkspaceURLSource:                "Source: %s",
+		WorkspaceLatency:                  "Latency %s avg (%s–%s over %d pings)",
+		WorkspaceServerVersion:            "Server: %s",
+		WorkspaceExtensions:               "Extensions: %s",
+		WorkspaceExtensionsNone:           "none",
+		WorkspaceExtensionMissing:         "⚠ %s is not installed (needed by %s)",
+		WorkspaceViaAdapter:               "◆ Via driver adapter",
+		WorkspaceDriverAdapter:            "Driver adapter: %s (%s)",
+		WorkspaceAdapterNoDirect:          "Not connected: the URL is only reachable through the driver adapter",
+		WorkspaceEdgeMigrations:           "Migrations are applied with the %s CLI, not prisma migrate deploy",
+		WorkspaceRelationModePrisma:       "Relations: emulated by Prisma, no foreign keys (relationMode = \"prisma\")",
+		WorkspacePlanetScale:              "PlanetScale: no shadow database; branches are in the quick actions (Enter)",
+		WorkspaceSupabaseRunning:          "Supabase: local stack running, Studio at %s",
+		WorkspaceSupabaseStopped:          "Supabase: local stack not running (supabase start)",
+		WorkspaceSupabaseTargetLocal:      "Using the local Supabase database",
+		WorkspaceSupabaseTargetRemote:     "Using the remote Supabase project",
+		WorkspaceURLParamProblems:         "⚠ %d problem(s) with URL parameters (Enter › URL parameters)",
+		WorkspaceTLSFix:                   "Enter › Fix TLS settings adds %s to the URL",
+		WorkspaceTLSFixManual:             "Adding %s to the database URL should fix it",
+		TLSProblemSelfSigned:              "⚠ TLS: the server's certificate isn't signed by a trusted authority (self-signed?)",
+		TLSProblemRequired:                "⚠ TLS: the server only accepts encrypted connections, but the URL doesn't ask for one",
+		TLSProblemUnsupported:             "⚠ TLS: the URL asks for an encrypted connection, but the server doesn't support TLS",
+		TLSProblemHostname:                "⚠ TLS: the certificate is for another host name; connect with the name it was issued for",
+		TLSProblemExpired:                 "⚠ TLS: the server's certificate has expired or isn't valid yet; it has to be renewed on the server",
+		WorkspaceURLSourceProcessEnv:      "environment",
 		WorkspaceErrorFormat:              "Error: %s",
 		WorkspaceErrorGetWorkingDirectory: "Error getting working directory",
 		WorkspaceErrorSchemaNotFound:      "Schema file not found",
@@ -573,9 +2498,15 @@ func EnglishTranslationSet() *TranslationSet {
 		// Migrations Panel
 		MigrationsFooterFormat: "%d of %d",
 
+		// Output Panel
+		OutputLinesDropped:        "... %d earlier lines dropped",
+		OutputLinesDroppedLogPath: "... %d earlier lines dropped (full output in %s)",
+		OutputFoldHidden:          "%d lines hidden · z to show",
+
 		// main.go strings
 		VersionOutput:              "LazyPrisma %s (%s)\n",
 		ErrorFailedGetCurrentDir:   "Error: Failed to get current directory: %v\n",
+		ErrorDemoSetup:             "Error: Failed to set up the demo project: %v\n",
 		ErrorNotPrismaWorkspace:    "Error: Current directory is not a Prisma workspace.\n",
 		ErrorExpectedOneOf:         "\nExpected one of:\n",
 		ErrorExpectedConfigV7Plus:  "  - prisma.config.ts (Prisma v7.0+)\n",
@@ -583,5 +2514,33 @@ func EnglishTranslationSet() *TranslationSet {
 		ErrorFailedCreateApp:       "Failed to create app: %v\n",
 		ErrorFailedRegisterKeybindings: "Failed to register keybindings: %v\n",
 		ErrorAppRuntime:            "App error: %v\n",
+
+		// Onboarding an existing database (no schema yet)
+		OnboardNoSchema:        "No Prisma schema found in %s.\n",
+		OnboardDatabaseFound:   "%s points to a %s database (%s).\n\n",
+		OnboardStepsIntro:      "Set up Prisma from this database? This runs:\n",
+		OnboardStepInit:        "  1. prisma init      create the schema and config\n",
+		OnboardStepPull:        "  2. prisma db pull   introspect the database into the schema\n",
+		OnboardStepGenerate:    "  3. prisma generate  generate Prisma Client\n",
+		OnboardStepBaseline:    "  4. baseline         save the schema as migration %s and mark it applied\n",
+		OnboardConfirm:         "\nContinue? [y/N] ",
+		OnboardRunningInit:     "Creating the Prisma schema",
+		OnboardRunningPull:     "Introspecting the database",
+		OnboardRunningGenerate: "Generating Prisma Client",
+		OnboardRunningBaseline: "Creating baseline migration %s",
+		OnboardRunningResolve:  "Marking %s as applied",
+		OnboardStepFailed:      "\nSetup stopped: %s failed: %v\n",
+		OnboardBaselineSkipped: "\nSkipping the baseline: %s already exists.\n",
+		OnboardBaselineFailed:  "\nSetup stopped: could not create the baseline migration: %v\n",
+		OnboardBaselineWritten: "Wrote %s\n",
+		OnboardDone:            "\nDone. Starting LazyPrisma...\n",
+
+		// Another instance with the project open
+		InstanceActive:       "Another LazyPrisma (PID %d on %s, started %s) has this project open.\n",
+		InstanceConflictRisk: "Running commands or saving preferences from both at once can conflict.\n\n",
+		InstancePrompt:       "[r] Open read-only (default)  [c] Continue anyway  [q] Quit: ",
+		InstanceLockFailed:   "Could not check for another LazyPrisma instance: %v\n",
+
+		StartupLoadingMigrations: "Loading migrations... %d/%d",
 	}
 }
@@ -8,8 +8,6 @@ import (
 	"io/fs"
 	"os"
 	"strings"
-
-	"dario.cat/mergo"
 )
 
 //go:embed translations/*.json
@@ -28,38 +26,33 @@ func NewTranslationSet(language string) *TranslationSet {
 	}
 
 	base := EnglishTranslationSet()
-	overlay, err := loadLanguageJSON(language)
-	if err != nil {
+	if err := loadLanguageJSON(language, base); err != nil {
 		fmt.Printf("warning: failed to load translations for %q: %v\n", language, err)
-		return base
-	}
-
-	if err := mergo.Merge(base, &overlay, mergo.WithOverride); err != nil {
-		fmt.Printf("warning: failed to merge translations for %q: %v\n", language, err)
 		return EnglishTranslationSet()
 	}
 
 	return base
 }
 
-// loadLanguageJSON reads a translation JSON file from the embedded filesystem.
-// If the file does not exist, it returns an empty TranslationSet with nil error.
-func loadLanguageJSON(language string) (TranslationSet, error) {
+// loadLanguageJSON overlays a translation JSON file from the embedded
+// filesystem onto ts. Strings missing from the file keep their English text;
+// strings present are used as is, even when empty (e.g. a plural suffix in a
+// language without plurals). If the file does not exist, ts is left unchanged.
+func loadLanguageJSON(language string, ts *TranslationSet) error {
 	filename := fmt.Sprintf("translations/%s.json", language)
 	data, err := translationsFS.ReadFile(filename)
 	if err != nil {
 		if errors.Is(err, fs.ErrNotExist) {
-			return TranslationSet{}, nil
+			return nil
 		}
-		return TranslationSet{}, fmt.Errorf("reading %s: %w", filename, err)
+		return fmt.Errorf("reading %s: %w", filename, err)
 	}
 
-	var ts TranslationSet
-	if err := json.Unmarshal(data, &ts); err != nil {
-		return TranslationSet{}, fmt.Errorf("invalid JSON in %s: %w", filename, err)
+	if err := json.Unmarshal(data, ts); err != nil {
+		return fmt.Errorf("invalid JSON in %s: %w", filename, err)
 	}
 
-	return ts, nil
+	return nil
 }
 
 // detectSystemLanguage checks LANG, LC_ALL, LC_MESSAGES environment variables.
@@ -0,0 +1,204 @@
+package components
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dokadev/lazyprisma/pkg/app"
+	"github.com/dokadev/lazyprisma/pkg/i18n"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+	"github.com/gdamore/tcell/v2"
+	"github.com/jesseduffield/gocui"
+)
+
+// assertTimeout is how long an assertion waits for the screen to catch up,
+// since key handlers and refreshes update it asynchronously
+const assertTimeout = 3 * time.Second
+
+const assertPollInterval = 20 * time.Millisecond
+
+// testFailure is raised by a failed assertion and recovered by the runner
+type testFailure struct {
+	message string
+}
+
+// TestDriver feeds key presses to the running app and asserts its state
+type TestDriver struct {
+	app     *app.App
+	gui     *gocui.Gui
+	prisma  *prisma.MockRunner
+	project *Project
+	stopped <-chan struct{} // Closed once the app's main loop has exited
+}
+
+// Tr returns the app's translation set, so tests assert on the same strings it shows
+func (t *TestDriver) Tr() *i18n.TranslationSet {
+	return t.app.Tr
+}
+
+// Prisma returns the mock that records the app's Prisma commands; set its
+// Results before triggering a command to script its output and exit code
+func (t *TestDriver) Prisma() *prisma.MockRunner {
+	return t.prisma
+}
+
+// Project returns the project the app runs on, e.g. to change its files
+// behind the app's back
+func (t *TestDriver) Project() *Project {
+	return t.project
+}
+
+// ExpectPrismaCommand waits until the app has run commandLine through the
+// mock, e.g. "prisma migrate deploy"
+func (t *TestDriver) ExpectPrismaCommand(commandLine string) *TestDriver {
+	deadline := time.Now().Add(assertTimeout)
+	for {
+		var ran []string
+		for _, call := range t.prisma.Calls() {
+			if call.String() == commandLine {
+				return t
+			}
+			ran = append(ran, call.String())
+		}
+		if time.Now().After(deadline) {
+			t.Fail("expected %q to run; ran: %q", commandLine, ran)
+		}
+		time.Sleep(assertPollInterval)
+	}
+}
+
+// Press sends a single character key, e.g. 'd' for migrate dev
+func (t *TestDriver) Press(ch rune) *TestDriver {
+	return t.sendKey(tcell.KeyRune, ch)
+}
+
+// Type sends each character of text, e.g. into an input modal
+func (t *TestDriver) Type(text string) *TestDriver {
+	for _, ch := range text {
+		t.Press(ch)
+	}
+	return t
+}
+
+func (t *TestDriver) Enter() *TestDriver  { return t.sendKey(tcell.KeyEnter, 0) }
+func (t *TestDriver) Escape() *TestDriver { return t.sendKey(tcell.KeyEscape, 0) }
+func (t *TestDriver) Tab() *TestDriver    { return t.sendKey(tcell.KeyTab, 0) }
+func (t *TestDriver) Up() *TestDriver     { return t.sendKey(tcell.KeyUp, 0) }
+func (t *TestDriver) Down() *TestDriver   { return t.sendKey(tcell.KeyDown, 0) }
+func (t *TestDriver) Left() *TestDriver   { return t.sendKey(tcell.KeyLeft, 0) }
+func (t *TestDriver) Right() *TestDriver  { return t.sendKey(tcell.KeyRight, 0) }
+func (t *TestDriver) End() *TestDriver    { return t.sendKey(tcell.KeyEnd, 0) }
+
+// Screen asserts on everything currently drawn, including panel and modal titles
+func (t *TestDriver) Screen() *ContentDriver {
+	return &ContentDriver{t: t, name: "screen", read: func(g *gocui.Gui) string {
+		return g.Snapshot()
+	}}
+}
+
+// View asserts on the content of one view, e.g. app.ViewMigrations
+func (t *TestDriver) View(name string) *ContentDriver {
+	return &ContentDriver{t: t, name: "view " + name, read: func(g *gocui.Gui) string {
+		v, err := g.View(name)
+		if err != nil {
+			return ""
+		}
+		return v.Buffer()
+	}}
+}
+
+// Fail stops the test with the given message
+func (t *TestDriver) Fail(format string, args ...any) {
+	panic(testFailure{message: fmt.Sprintf(format, args...)})
+}
+
+func (t *TestDriver) sendKey(key tcell.Key, ch rune) *TestDriver {
+	event := &gocui.TcellKeyEventWrapper{
+		Timestamp: time.Now().UnixMilli(),
+		Key:       key,
+		Ch:        ch,
+	}
+	select {
+	case t.gui.ReplayedEvents.Keys <- event:
+	case <-t.stopped:
+		t.Fail("app exited before key %q was handled", keyName(key, ch))
+	}
+	return t
+}
+
+// waitForStartup waits for the first layout, which focuses a panel; until
+// then gocui has no current view and drops character keys
+func (t *TestDriver) waitForStartup() {
+	deadline := time.Now().Add(assertTimeout)
+	for t.read(func(g *gocui.Gui) string {
+		if g.CurrentView() == nil {
+			return ""
+		}
+		return g.CurrentView().Name()
+	}) == "" {
+		if time.Now().After(deadline) {
+			t.Fail("app did not focus a panel on startup")
+		}
+		time.Sleep(assertPollInterval)
+	}
+}
+
+// read returns what the given function reads from the gui on the UI thread
+func (t *TestDriver) read(fn func(g *gocui.Gui) string) string {
+	result := make(chan string, 1)
+	t.gui.Update(func(g *gocui.Gui) error {
+		result <- fn(g)
+		return nil
+	})
+	select {
+	case content := <-result:
+		return content
+	case <-t.stopped:
+		t.Fail("app exited while reading the screen")
+	}
+	return ""
+}
+
+func keyName(key tcell.Key, ch rune) string {
+	if key == tcell.KeyRune {
+		return string(ch)
+	}
+	return tcell.KeyNames[key]
+}
+
+// ContentDriver asserts on the text of the screen or of a view, retrying
+// until the assertion holds or assertTimeout passes
+type ContentDriver struct {
+	t    *TestDriver
+	name string
+	read func(g *gocui.Gui) string
+}
+
+// Contains waits until the content includes text
+func (c *ContentDriver) Contains(text string) *ContentDriver {
+	c.waitFor(func(content string) bool { return strings.Contains(content, text) },
+		"expected %s to contain %q", c.name, text)
+	return c
+}
+
+// DoesNotContain waits until the content no longer includes text
+func (c *ContentDriver) DoesNotContain(text string) *ContentDriver {
+	c.waitFor(func(content string) bool { return !strings.Contains(content, text) },
+		"expected %s not to contain %q", c.name, text)
+	return c
+}
+
+func (c *ContentDriver) waitFor(ok func(content string) bool, format string, args ...any) {
+	deadline := time.Now().Add(assertTimeout)
+	for {
+		content := c.t.read(c.read)
+		if ok(content) {
+			return
+		}
+		if time.Now().After(deadline) {
+			c.t.Fail(format+"\n\n%s", append(args, content)...)
+		}
+		time.Sleep(assertPollInterval)
+	}
+}
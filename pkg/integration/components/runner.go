@@ -0,0 +1,127 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dokadev/lazyprisma/pkg/app"
+	"github.com/dokadev/lazyprisma/pkg/config"
+	"github.com/dokadev/lazyprisma/pkg/demo"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+	"github.com/jesseduffield/gocui"
+)
+
+// Size of the simulated screen
+const (
+	screenWidth  = 160
+	screenHeight = 50
+)
+
+// quitTimeout is how long the app gets to exit once a test is over
+const quitTimeout = 5 * time.Second
+
+// Run runs the test against a fresh project in a temporary directory,
+// returning why it failed
+func (t *IntegrationTest) Run() (err error) {
+	root, err := os.MkdirTemp("", "lazyprisma-integration-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(root)
+
+	projectDir := filepath.Join(root, "project")
+	project := NewProject(projectDir)
+	if t.setupProject != nil {
+		t.setupProject(project)
+	}
+	if err := project.Err(); err != nil {
+		return fmt.Errorf("setting up project: %w", err)
+	}
+
+	// The app works on the current directory
+	cwd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if err := os.Chdir(projectDir); err != nil {
+		return err
+	}
+	defer os.Chdir(cwd)
+
+	// Keep the user's audit trail, stats and output log out of it, and refresh
+	// only when the test asks to
+	cfg := config.Default()
+	cfg.Audit.Enabled = false
+	cfg.Stats.Enabled = false
+	cfg.Watch.Enabled = false
+	cfg.Output.LogPath = filepath.Join(root, "output.log")
+	if t.setupConfig != nil {
+		t.setupConfig(cfg)
+	}
+
+	tuiApp, err := app.Bootstrap(cfg, app.AppConfig{
+		AppName:   "LazyPrisma",
+		Version:   "test",
+		Developer: "test",
+		Language:  "en",
+		ReadOnly:  t.readOnly,
+		Headless:  true,
+		Width:     screenWidth,
+		Height:    screenHeight,
+	}, &demo.State{
+		Dir:             projectDir,
+		DBMigrations:    project.DBMigrations(),
+		ConnectionError: project.ConnectionError(),
+	})
+	if err != nil {
+		return fmt.Errorf("creating app: %w", err)
+	}
+	if err := tuiApp.RegisterKeybindings(); err != nil {
+		return fmt.Errorf("registering keybindings: %w", err)
+	}
+	tuiApp.RegisterMouseBindings()
+
+	// Prisma commands are recorded instead of run
+	runner := prisma.NewMockRunner()
+	tuiApp.SetPrismaRunner(runner)
+
+	stopped := make(chan struct{})
+	var runErr error
+	go func() {
+		defer close(stopped)
+		runErr = tuiApp.Run()
+	}()
+
+	driver := &TestDriver{app: tuiApp, gui: tuiApp.GetGui(), prisma: runner, project: project, stopped: stopped}
+	err = t.drive(driver)
+
+	tuiApp.GetGui().Update(func(*gocui.Gui) error {
+		return gocui.ErrQuit
+	})
+	select {
+	case <-stopped:
+	case <-time.After(quitTimeout):
+		return errors.Join(err, errors.New("app did not exit"))
+	}
+	return errors.Join(err, runErr)
+}
+
+// drive runs the test's script, turning a failed assertion into an error
+func (t *IntegrationTest) drive(driver *TestDriver) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			failure, ok := r.(testFailure)
+			if !ok {
+				panic(r)
+			}
+			err = errors.New(failure.message)
+		}
+	}()
+
+	driver.waitForStartup()
+	t.run(driver)
+	return nil
+}
@@ -0,0 +1,71 @@
+// Package components is the harness for lazyprisma's integration tests: each
+// test runs the real app headless against a temporary Prisma project and a fake
+// migrations table, feeds it key presses and asserts what ends up on screen.
+package components
+
+import (
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/dokadev/lazyprisma/pkg/config"
+)
+
+// IntegrationTest is one scripted session against a fresh project
+type IntegrationTest struct {
+	name         string
+	description  string
+	setupProject func(project *Project)
+	setupConfig  func(cfg *config.Config)
+	readOnly     bool
+	run          func(t *TestDriver)
+}
+
+type NewIntegrationTestArgs struct {
+	Description string
+	// Writes the project's schema and migrations and records what the fake
+	// database has applied
+	SetupProject func(project *Project)
+	// Adjusts the user config the app starts with (optional)
+	SetupConfig func(cfg *config.Config)
+	// Starts the app read-only, as if another instance had the project open
+	ReadOnly bool
+	// Drives the app and asserts its state
+	Run func(t *TestDriver)
+}
+
+// NewIntegrationTest creates a test named after its file under pkg/integration/tests
+// (e.g. "migrate/schema_diff_db_only")
+func NewIntegrationTest(args NewIntegrationTestArgs) *IntegrationTest {
+	name := "unknown"
+	if _, file, _, ok := runtime.Caller(1); ok {
+		name = testNameFromFile(file)
+	}
+
+	return &IntegrationTest{
+		name:         name,
+		description:  args.Description,
+		setupProject: args.SetupProject,
+		setupConfig:  args.SetupConfig,
+		readOnly:     args.ReadOnly,
+		run:          args.Run,
+	}
+}
+
+func (t *IntegrationTest) Name() string {
+	return t.name
+}
+
+func (t *IntegrationTest) Description() string {
+	return t.description
+}
+
+// testNameFromFile returns the path of a test file relative to the tests
+// directory, without its extension
+func testNameFromFile(file string) string {
+	file = filepath.ToSlash(file)
+	if _, rel, ok := strings.Cut(file, "/integration/tests/"); ok {
+		file = rel
+	}
+	return strings.TrimSuffix(file, ".go")
+}
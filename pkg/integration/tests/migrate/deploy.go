@@ -0,0 +1,27 @@
+package migrate
+
+import (
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var Deploy = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "Deploy from the deploy menu runs migrate deploy for the pending migrations",
+	SetupProject: func(project *components.Project) {
+		project.
+			AddMigration("20240101090000_init", `CREATE TABLE "User" ("id" SERIAL PRIMARY KEY, "email" TEXT NOT NULL);`).
+			ApplyMigration("20240101090000_init").
+			AddMigration("20240115103000_add_name", `ALTER TABLE "User" ADD COLUMN "name" TEXT;`)
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		t.Press('D')
+		t.Screen().
+			Contains(tr.ListItemDeploy).
+			Contains(tr.ListItemSimulateDeploy)
+		t.Enter()
+
+		t.ExpectPrismaCommand("prisma migrate deploy")
+		t.Screen().Contains(tr.ModalTitleMigrateDeploySuccess)
+	},
+})
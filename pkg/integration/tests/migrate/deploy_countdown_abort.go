@@ -0,0 +1,32 @@
+package migrate
+
+import (
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var DeployCountdownAbort = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "Closing the deploy countdown before it ends aborts the deploy",
+	SetupProject: func(project *components.Project) {
+		project.
+			AddMigration("20240101090000_init", `CREATE TABLE "User" ("id" SERIAL PRIMARY KEY, "email" TEXT NOT NULL);`).
+			ApplyMigration("20240101090000_init").
+			AddMigration("20240115103000_add_name", `ALTER TABLE "User" ADD COLUMN "name" TEXT;`)
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		t.Press('D')
+		t.Screen().Contains(tr.ListItemScheduleDeploy)
+		t.Down()
+		t.Enter()
+
+		t.Screen().Contains(tr.ModalTitleDeployCountdown)
+		t.Escape()
+
+		t.View("outputs").Contains(tr.LogMsgDeployAbortedBeforeStart)
+		t.Screen().DoesNotContain(tr.ModalMsgDeployAbortHint)
+		if calls := t.Prisma().Calls(); len(calls) > 0 {
+			t.Fail("expected no prisma command after aborting; ran %q", calls[0].String())
+		}
+	},
+})
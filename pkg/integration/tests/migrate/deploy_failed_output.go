@@ -0,0 +1,42 @@
+package migrate
+
+import (
+	"fmt"
+
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+)
+
+var DeployFailedOutput = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "The failed deploy modal links to the start of the command's output in the Output panel",
+	SetupProject: func(project *components.Project) {
+		project.
+			AddMigration("20240101090000_init", `CREATE TABLE "User" ("id" SERIAL PRIMARY KEY, "email" TEXT NOT NULL);`)
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		// Long enough to scroll the start of the output out of view
+		output := []string{"1 migration found in prisma/migrations", ""}
+		for i := 1; i <= 60; i++ {
+			output = append(output, fmt.Sprintf("  at stack frame %d", i))
+		}
+		t.Prisma().Results["migrate deploy"] = prisma.MockResult{Output: output, ExitCode: 1}
+
+		t.Press('D')
+		t.Screen().Contains(tr.ListItemDeploy)
+		t.Enter()
+
+		t.ExpectPrismaCommand("prisma migrate deploy")
+		t.Screen().
+			Contains(tr.ModalTitleMigrateDeployFailed).
+			Contains("[o] View Output").
+			DoesNotContain("1 migration found in prisma/migrations")
+
+		t.Press('o')
+		t.Screen().
+			DoesNotContain(tr.ModalTitleMigrateDeployFailed).
+			Contains("1 migration found in prisma/migrations").
+			DoesNotContain("at stack frame 60")
+	},
+})
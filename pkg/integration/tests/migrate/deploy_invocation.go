@@ -0,0 +1,35 @@
+package migrate
+
+import (
+	"fmt"
+
+	"github.com/dokadev/lazyprisma/pkg/config"
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var DeployInvocation = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "With output.showInvocation, the exact command line and working directory are logged before the command runs",
+	SetupProject: func(project *components.Project) {
+		project.
+			AddMigration("20240101090000_init", `CREATE TABLE "User" ("id" SERIAL PRIMARY KEY, "email" TEXT NOT NULL);`)
+	},
+	SetupConfig: func(cfg *config.Config) {
+		cfg.Output.ShowInvocation = true
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		t.Press('D')
+		t.Screen().Contains(tr.ListItemDeploy)
+		t.Enter()
+
+		t.ExpectPrismaCommand("prisma migrate deploy")
+		t.Screen().Contains(tr.ModalTitleMigrateDeploySuccess)
+		t.Escape()
+
+		// Logged above the folded output, so it stays visible
+		t.View("outputs").
+			Contains(fmt.Sprintf(tr.LogMsgInvocationCommand, "prisma migrate deploy")).
+			Contains(fmt.Sprintf(tr.LogMsgInvocationDir, ""))
+	},
+})
@@ -0,0 +1,33 @@
+package migrate
+
+import (
+	"fmt"
+
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var DeployMissingExtension = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "The deploy menu offers to create an extension a pending migration needs, as a migration run with db execute and marked applied",
+	SetupProject: func(project *components.Project) {
+		project.
+			AddMigration("20240101090000_init", `CREATE TABLE "User" ("id" SERIAL PRIMARY KEY, "email" TEXT NOT NULL);`).
+			ApplyMigration("20240101090000_init").
+			// The semicolon in the comment's literal doesn't start a CREATE EXTENSION statement
+			AddMigration("20240115103000_citext_email", `COMMENT ON TABLE "User" IS 'see the docs; CREATE EXTENSION citext first';
+ALTER TABLE "User" ALTER COLUMN "email" SET DATA TYPE CITEXT;`)
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		t.Press('D')
+		t.Screen().
+			Contains(fmt.Sprintf(tr.ListItemCreateExtMigration, "citext")).
+			Contains(fmt.Sprintf(tr.ListItemCreateExtNow, "citext")).
+			Contains(tr.ListItemDeploy)
+
+		t.Down().Enter()
+
+		t.ExpectPrismaCommand("prisma migrate resolve --applied 20240115102959_create_extensions")
+		t.Screen().Contains(tr.ModalTitleExtensionsCreated)
+	},
+})
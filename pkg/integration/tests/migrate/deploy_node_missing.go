@@ -0,0 +1,40 @@
+package migrate
+
+import (
+	"fmt"
+
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+	"github.com/dokadev/lazyprisma/pkg/node"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+)
+
+var DeployNodeMissing = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "A deploy that can't start because npx is gone explains how to fix it and offers to re-detect the toolchain",
+	SetupProject: func(project *components.Project) {
+		project.
+			AddMigration("20240101090000_init", `CREATE TABLE "User" ("id" SERIAL PRIMARY KEY, "email" TEXT NOT NULL);`).
+			ApplyMigration("20240101090000_init").
+			AddMigration("20240115103000_add_name", `ALTER TABLE "User" ADD COLUMN "name" TEXT;`)
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		t.Prisma().Results["migrate deploy"] = prisma.MockResult{
+			Err: &node.MissingToolError{Tool: "npx"},
+		}
+
+		t.Press('D')
+		t.Screen().Contains(tr.ListItemDeploy)
+		t.Enter()
+
+		t.ExpectPrismaCommand("prisma migrate deploy")
+		t.Screen().
+			Contains(tr.ModalTitleToolchainMissing).
+			Contains(tr.ListItemRedetectToolchain).
+			DoesNotContain(tr.ModalTitleMigrateDeployError)
+		t.View("outputs").Contains(fmt.Sprintf(tr.ModalMsgToolMissing, "Migrate Deploy", "npx"))
+
+		t.Escape()
+		t.Screen().DoesNotContain(tr.ModalTitleToolchainMissing)
+	},
+})
@@ -0,0 +1,53 @@
+package migrate
+
+import (
+	"fmt"
+
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+)
+
+var DeploySummary = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "A finished deploy is summarised from its parsed output, with the raw output folded underneath",
+	SetupProject: func(project *components.Project) {
+		project.
+			AddMigration("20240101090000_init", `CREATE TABLE "User" ("id" SERIAL PRIMARY KEY, "email" TEXT NOT NULL);`).
+			ApplyMigration("20240101090000_init").
+			AddMigration("20240115103000_add_name", `ALTER TABLE "User" ADD COLUMN "name" TEXT;`).
+			AddMigration("20240120090000_add_role", `ALTER TABLE "User" ADD COLUMN "role" TEXT;`)
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		t.Prisma().Results["migrate deploy"] = prisma.MockResult{
+			Output: []string{
+				"2 migrations found in prisma/migrations",
+				"",
+				"Applying migration `20240115103000_add_name`",
+				"Applying migration `20240120090000_add_role`",
+				"",
+				"All migrations have been successfully applied.",
+			},
+		}
+
+		t.Press('D')
+		t.Screen().Contains(tr.ListItemDeploy)
+		t.Enter()
+
+		t.ExpectPrismaCommand("prisma migrate deploy")
+		t.View("outputs").
+			Contains(fmt.Sprintf(tr.SummaryDeployApplied, 2) + " (20240115103000_add_name, 20240120090000_add_role) · ").
+			Contains(fmt.Sprintf(tr.OutputFoldHidden, 6)).
+			DoesNotContain("All migrations have been successfully applied.")
+
+		// The raw output is folded away until expanded
+		t.Screen().Contains(tr.ModalTitleMigrateDeploySuccess)
+		t.Escape()
+		t.Press('z')
+		t.View("outputs").
+			Contains("All migrations have been successfully applied.").
+			DoesNotContain(fmt.Sprintf(tr.OutputFoldHidden, 6))
+		t.Press('z')
+		t.View("outputs").DoesNotContain("All migrations have been successfully applied.")
+	},
+})
@@ -0,0 +1,74 @@
+package migrate
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+)
+
+// webhookReceiver records the bodies posted to it
+type webhookReceiver struct {
+	mu     sync.Mutex
+	bodies []string
+	server *httptest.Server
+}
+
+func (w *webhookReceiver) start() string {
+	w.server = httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.mu.Lock()
+		w.bodies = append(w.bodies, string(body))
+		w.mu.Unlock()
+	}))
+	return w.server.URL
+}
+
+func (w *webhookReceiver) received() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return strings.Join(w.bodies, "\n")
+}
+
+var deployWebhook webhookReceiver
+
+var DeployWebhook = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "A deploy posts its result to the webhooks of the project config",
+	SetupProject: func(project *components.Project) {
+		project.
+			AddMigration("20240101090000_init", `CREATE TABLE "User" ("id" SERIAL PRIMARY KEY, "email" TEXT NOT NULL);`).
+			ApplyMigration("20240101090000_init").
+			AddMigration("20240115103000_add_name", `ALTER TABLE "User" ADD COLUMN "name" TEXT;`).
+			WriteFile(".lazyprisma.yaml", fmt.Sprintf(`webhooks:
+  - url: %s/hooks/secret-token
+    events: [deploy]
+    template: '{"applied": {{json .Migrations}}, "status": {{json .Status}}}'
+`, deployWebhook.start()))
+	},
+	Run: func(t *components.TestDriver) {
+		defer deployWebhook.server.Close()
+		tr := t.Tr()
+
+		t.Prisma().Results["migrate deploy"] = prisma.MockResult{
+			Output: []string{"Applying migration `20240115103000_add_name`"},
+		}
+
+		t.Press('D')
+		t.Screen().Contains(tr.ListItemDeploy)
+		t.Enter()
+
+		t.ExpectPrismaCommand("prisma migrate deploy")
+		host := strings.TrimPrefix(deployWebhook.server.URL, "http://")
+		t.View("outputs").Contains(fmt.Sprintf(tr.LogMsgWebhookSent, "deploy", host))
+
+		want := `{"applied": ["20240115103000_add_name"], "status": "succeeded"}`
+		if got := deployWebhook.received(); got != want {
+			t.Fail("expected the webhook to receive %s; got %q", want, got)
+		}
+	},
+})
@@ -0,0 +1,31 @@
+package migrate
+
+import (
+	"fmt"
+
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var DeployWebhookFailed = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "A webhook that can't be reached is logged by its host, without the token in its URL",
+	SetupProject: func(project *components.Project) {
+		project.
+			AddMigration("20240101090000_init", `CREATE TABLE "User" ("id" SERIAL PRIMARY KEY, "email" TEXT NOT NULL);`).
+			WriteFile(".lazyprisma.yaml", `webhooks:
+  - url: http://127.0.0.1:1/hooks/secret-token
+    events: [deploy]
+`)
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		t.Press('D')
+		t.Screen().Contains(tr.ListItemDeploy)
+		t.Enter()
+
+		t.ExpectPrismaCommand("prisma migrate deploy")
+		t.View("outputs").
+			Contains(fmt.Sprintf(tr.LogMsgWebhookFailed, "deploy", "127.0.0.1:1", "")).
+			DoesNotContain("secret-token")
+	},
+})
@@ -0,0 +1,40 @@
+package migrate
+
+import (
+	"fmt"
+
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var ExportPendingSQL = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "Exporting the pending migrations as SQL writes one file and offers to mark them applied",
+	SetupProject: func(project *components.Project) {
+		project.
+			AddMigration("20240101090000_init", `CREATE TABLE "User" ("id" SERIAL PRIMARY KEY, "email" TEXT NOT NULL);`).
+			ApplyMigration("20240101090000_init").
+			AddMigration("20240115103000_add_name", `ALTER TABLE "User" ADD COLUMN "name" TEXT;`).
+			AddMigration("20240120080000_add_bio", `ALTER TABLE "User" ADD COLUMN "bio" TEXT;`)
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		t.Press('D')
+		t.Screen().Contains(tr.ListItemExportPendingSQL)
+		t.Down().Down().Down().Down()
+		t.Enter()
+
+		t.Screen().Contains(fmt.Sprintf(tr.ListItemExportCount, 2))
+		t.Enter()
+
+		t.Screen().Contains(tr.ModalTitleEnterExportPath)
+		t.Type("pending.sql")
+		t.Enter()
+
+		t.Screen().Contains(tr.ModalTitleExportedSQL)
+		t.View("outputs").Contains(fmt.Sprintf(tr.LogMsgExportedSQL, 2, "pending.sql"))
+		t.Press('y')
+
+		t.ExpectPrismaCommand("prisma migrate resolve --applied 20240115103000_add_name")
+		t.ExpectPrismaCommand("prisma migrate resolve --applied 20240120080000_add_bio")
+	},
+})
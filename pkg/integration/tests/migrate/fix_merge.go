@@ -0,0 +1,47 @@
+package migrate
+
+import (
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var FixMerge = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "Fix merge removes a migration both branches added and renames the ones a merge put out of order",
+	SetupProject: func(project *components.Project) {
+		project.
+			AddMigration("20240101090000_init", `CREATE TABLE "User" ("id" SERIAL PRIMARY KEY);`).
+			ApplyMigration("20240101090000_init").
+			AddMigration("20240105090000_add_orders", `CREATE TABLE "Order" ("id" SERIAL PRIMARY KEY);`).
+			ApplyMigration("20240105090000_add_orders").
+			// Merged in from another branch
+			AddMigration("20240103090000_add_tags", `CREATE TABLE "Tag" ("id" SERIAL PRIMARY KEY);`).
+			AddMigration("20240105090000_add_sku", `ALTER TABLE "Order" ADD COLUMN "sku" TEXT;`).
+			AddMigration("20240106090000_create_orders", `CREATE TABLE "Order" ("id" SERIAL PRIMARY KEY);`)
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		// Focus the Details panel and switch to its Action-Needed tab
+		t.Right()
+		t.Right()
+		t.Right()
+		t.Tab()
+		t.View("details").
+			Contains(tr.ActionNeededMergeHeader).
+			Contains("20240103090000_add_tags → 20240105090001_add_tags")
+
+		t.Press('M')
+		t.Screen().
+			Contains(tr.ModalTitleFixMerge).
+			Contains("✗ 20240106090000_create_orders").
+			Contains("20240105090000_add_sku → 20240105090002_add_sku")
+		t.Press('y')
+
+		t.View("outputs").
+			Contains("✗ 20240106090000_create_orders").
+			Contains("20240103090000_add_tags → 20240105090001_add_tags").
+			Contains("20240105090000_add_sku → 20240105090002_add_sku")
+
+		t.Press('M')
+		t.Screen().Contains(tr.ModalMsgFixMergeNothing)
+	},
+})
@@ -0,0 +1,36 @@
+package migrate
+
+import (
+	"fmt"
+
+	"github.com/dokadev/lazyprisma/pkg/config"
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var FixMergeDisabled = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "Fix merge leaves the migrations alone when the project disables deleting migrations",
+	SetupProject: func(project *components.Project) {
+		project.
+			WriteFile(config.ProjectConfigFile, "disabledActions:\n  - delete-migration\n").
+			AddMigration("20240101090000_init", `CREATE TABLE "User" ("id" SERIAL PRIMARY KEY);`).
+			ApplyMigration("20240101090000_init").
+			AddMigration("20240105090000_add_orders", `CREATE TABLE "Order" ("id" SERIAL PRIMARY KEY);`).
+			ApplyMigration("20240105090000_add_orders").
+			// Merged in from another branch
+			AddMigration("20240103090000_add_tags", `CREATE TABLE "Tag" ("id" SERIAL PRIMARY KEY);`)
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		t.Press('M')
+		t.Screen().
+			Contains(tr.ModalTitleActionDisabled).
+			Contains(fmt.Sprintf(tr.ModalMsgActionDisabled, config.ActionDeleteMigration)).
+			DoesNotContain(tr.ModalTitleFixMerge)
+		t.Escape()
+
+		if t.Project().ReadFile("prisma/migrations/20240103090000_add_tags/migration.sql") == "" {
+			t.Fail("expected the merged migration to be left in place")
+		}
+	},
+})
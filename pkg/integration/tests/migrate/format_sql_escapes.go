@@ -0,0 +1,32 @@
+package migrate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var FormatSQLEscapes = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "Formatting a pending migration keeps backslash-escaped quotes inside escape strings",
+	SetupProject: func(project *components.Project) {
+		project.
+			AddMigration("20240101090000_init", `CREATE TABLE "Note" ("id" SERIAL PRIMARY KEY, "body" TEXT NOT NULL);`).
+			ApplyMigration("20240101090000_init").
+			AddMigration("20240115103000_seed_notes", `insert into "Note" ("body") values (E'it\'s;   done');`)
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		t.Right().End()
+		t.Press('F')
+		t.Screen().Contains(tr.ModalTitleFormatSQL)
+		t.Press('y')
+		t.Screen().Contains(fmt.Sprintf(tr.ModalMsgFormattedSQLSaved, ""))
+
+		sql := t.Project().ReadFile("prisma/migrations/20240115103000_seed_notes/migration.sql")
+		if !strings.Contains(sql, `E'it\'s;   done'`) || !strings.Contains(sql, "INSERT INTO") {
+			t.Fail("expected the escape string kept and the rest formatted; got %q", sql)
+		}
+	},
+})
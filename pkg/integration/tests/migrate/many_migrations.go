@@ -0,0 +1,48 @@
+package migrate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+// manyMigrationsCount is the size of the migration history of an old project
+const manyMigrationsCount = 1500
+
+func manyMigrationName(i int) string {
+	start := time.Date(2020, 1, 1, 9, 0, 0, 0, time.UTC)
+	return fmt.Sprintf("%s_change_%d", start.Add(time.Duration(i)*time.Hour).Format("20060102150405"), i)
+}
+
+var ManyMigrations = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "A long migration history scrolls to its end, and a migration edited after a refresh is read again",
+	SetupProject: func(project *components.Project) {
+		for i := 1; i <= manyMigrationsCount; i++ {
+			name := manyMigrationName(i)
+			project.
+				AddMigration(name, fmt.Sprintf(`ALTER TABLE "User" ADD COLUMN "field%d" TEXT;`, i)).
+				ApplyMigration(name)
+		}
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+		last := manyMigrationName(manyMigrationsCount)
+
+		t.View("migrations").
+			Contains("change_1\n").
+			DoesNotContain(fmt.Sprintf("change_%d", manyMigrationsCount))
+
+		t.Right().End()
+		t.Screen().Contains(fmt.Sprintf(tr.MigrationsFooterFormat, manyMigrationsCount, manyMigrationsCount))
+		t.View("migrations").
+			Contains(fmt.Sprintf("%d │ change_%d", manyMigrationsCount, manyMigrationsCount)).
+			DoesNotContain("change_1\n")
+		t.View("details").Contains(tr.MigrationStatusApplied)
+
+		// Edited after it was applied: the cached checksum is not reused
+		t.Project().AddMigration(last, `ALTER TABLE "User" ADD COLUMN "renamed" TEXT;`)
+		t.Press('r')
+		t.View("details").Contains(tr.MigrationStatusChecksumMismatch)
+	},
+})
@@ -0,0 +1,49 @@
+package migrate
+
+import (
+	"fmt"
+
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var MigrationActions = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "Enter on a migration lists the actions for its state, and each one does what its key does",
+	SetupProject: func(project *components.Project) {
+		project.
+			AddMigration("20240101090000_init", `CREATE TABLE "User" ("id" SERIAL PRIMARY KEY, "email" TEXT NOT NULL);`).
+			ApplyMigration("20240101090000_init").
+			AddMigration("20240115103000_add_role", `CREATE TYPE "Role" AS ENUM ('USER', 'ADMIN');`).
+			FailMigration("20240115103000_add_role", `ERROR: type "Role" already exists`)
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		// An applied migration can't be deleted or resolved
+		t.Right()
+		t.Enter()
+		t.Screen().
+			Contains(fmt.Sprintf(tr.ModalTitleMigrationActions, "20240101090000_init")).
+			Contains(tr.ListItemPeekMigrationData).
+			Contains(tr.ListItemDeleteHistoryRow).
+			DoesNotContain(tr.ListItemResolveFailed).
+			DoesNotContain(tr.ListItemReviewMigration)
+		t.Escape()
+
+		// A failed one is resolved from its menu
+		t.Down()
+		t.Enter()
+		t.Screen().
+			Contains(fmt.Sprintf(tr.ModalTitleMigrationActions, "20240115103000_add_role")).
+			Contains(tr.ListItemReviewMigration).
+			Contains(tr.ListItemResolveFailed)
+
+		// View details, Edit migration.sql, Review, Resolve
+		t.Down()
+		t.Down()
+		t.Down()
+		t.Enter()
+		t.Screen().
+			Contains(fmt.Sprintf(tr.ModalTitleResolveMigration, "20240115103000_add_role")).
+			Contains(tr.ListItemMarkRolledBack)
+	},
+})
@@ -0,0 +1,53 @@
+package migrate
+
+import (
+	"fmt"
+
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+)
+
+var RerunFromHistory = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "A command run earlier is found in the command history by filtering and run again",
+	SetupProject: func(project *components.Project) {
+		project.
+			AddMigration("20240101090000_init", `CREATE TABLE "User" ("id" SERIAL PRIMARY KEY, "email" TEXT NOT NULL);`)
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		t.Press('D')
+		t.Screen().Contains(tr.ListItemDeploy)
+		t.Enter()
+		t.ExpectPrismaCommand("prisma migrate deploy")
+		t.Screen().Contains(tr.ModalTitleMigrateDeploySuccess)
+		t.Escape()
+
+		// Filter the history down to the deploy
+		t.Press('R')
+		t.Screen().
+			Contains(tr.ModalTitleCommandHistory).
+			Contains("prisma migrate deploy")
+		t.Enter()
+		t.Screen().Contains(tr.ModalTitleHistoryFilter)
+		t.Type("deploy")
+		t.Enter()
+		t.Screen().Contains(fmt.Sprintf(tr.ListItemHistoryFiltered, "deploy", 1))
+
+		// Run it again; this time it fails
+		t.Prisma().Results["migrate deploy"] = prisma.MockResult{
+			Output:   []string{"Error: P1001: Can't reach database server"},
+			ExitCode: 1,
+		}
+		t.Down()
+		t.Enter()
+		t.Screen().Contains(tr.ListItemHistoryRerun)
+		t.Down()
+		t.Enter()
+		t.Screen().Contains(fmt.Sprintf(tr.ModalMsgConfirmRerun, "prisma migrate deploy"))
+		t.Press('y')
+
+		t.Screen().Contains(tr.ModalTitleRerunFailed)
+		t.View("outputs").Contains("Can't reach database server")
+	},
+})
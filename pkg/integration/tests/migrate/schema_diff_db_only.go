@@ -0,0 +1,26 @@
+package migrate
+
+import (
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var SchemaDiffDBOnly = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "Schema diff migration is refused while the database has migrations missing locally",
+	SetupProject: func(project *components.Project) {
+		project.
+			AddMigration("20240101090000_init", `CREATE TABLE "User" ("id" SERIAL PRIMARY KEY, "email" TEXT NOT NULL);`).
+			ApplyMigration("20240101090000_init").
+			AddDBOnlyMigration("20240110120000_hotfix", `CREATE INDEX "User_email_idx" ON "User"("email");`)
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		t.Press('d')
+		t.Screen().Contains(tr.ListItemSchemaDiffMigration)
+		t.Enter()
+
+		t.Screen().
+			Contains(tr.ModalTitleDBOnlyMigrationsDetected).
+			DoesNotContain(tr.ModalTitleEnterMigrationName)
+	},
+})
@@ -0,0 +1,27 @@
+package migrate
+
+import (
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var SimulateOnTarget = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "Without a shadow database, simulating a deploy asks before creating its scratch database on the target's server",
+	SetupProject: func(project *components.Project) {
+		project.
+			AddMigration("20240101090000_init", `CREATE TABLE "User" ("id" SERIAL PRIMARY KEY, "email" TEXT NOT NULL);`)
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		t.Press('D')
+		t.Screen().Contains(tr.ListItemSimulateDeploy)
+		t.Down().Down().Down().Enter()
+
+		t.Screen().
+			Contains(tr.ModalTitleSimulateOnTarget).
+			Contains("localhost:5432/test")
+		t.Press('n')
+		t.Screen().DoesNotContain(tr.ModalTitleSimulateOnTarget)
+		t.View("outputs").DoesNotContain(tr.LogActionSimulateDeploy)
+	},
+})
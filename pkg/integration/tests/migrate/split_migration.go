@@ -0,0 +1,70 @@
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var SplitMigration = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "Splitting a previewed migration keeps types in the first part, adds the tables its foreign keys point to and writes foreign keys last",
+	SetupProject: func(project *components.Project) {
+		project.
+			AddMigration("20240101090000_init", `CREATE TABLE "Tag" ("id" SERIAL PRIMARY KEY);`)
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		t.Prisma().DiffSQL = `-- CreateEnum
+CREATE TYPE "Role" AS ENUM ('USER', 'ADMIN');
+
+-- CreateTable
+CREATE TABLE "Post" ("id" SERIAL NOT NULL, "authorId" INTEGER NOT NULL, CONSTRAINT "Post_pkey" PRIMARY KEY ("id"));
+
+-- CreateTable
+CREATE TABLE "User" ("id" SERIAL NOT NULL, "role" "Role" NOT NULL, CONSTRAINT "User_pkey" PRIMARY KEY ("id"));
+
+-- AddForeignKey
+ALTER TABLE "Post" ADD CONSTRAINT "Post_authorId_fkey" FOREIGN KEY ("authorId") REFERENCES "User"("id") ON DELETE RESTRICT ON UPDATE CASCADE;
+`
+		t.Press('p')
+		t.View("outputs").Contains(tr.LogMsgMigrationPreviewReady)
+
+		t.Press('P')
+		t.Screen().Contains(fmt.Sprintf(tr.ModalTitleSplitMigrationPart, 1))
+
+		// The enum is selected and stays selected; select Post only
+		t.Enter()
+		t.Down().Enter()
+		t.Down().Down().Enter()
+
+		// Post's foreign key points to User, which is not selected
+		t.Screen().
+			Contains(tr.ModalMsgSplitAddReferenced).
+			Contains("Post → User")
+		t.Press('y')
+
+		t.Screen().Contains(fmt.Sprintf(tr.ListItemCreateSplitMigration, 3))
+		t.Down().Down().Down().Enter()
+		t.Screen().Contains(fmt.Sprintf(tr.ModalMsgSplitMigrationTables, tr.SplitMigrationOtherStatements+", Post, User"))
+		t.Type("first")
+		t.Enter()
+		t.Screen().Contains(fmt.Sprintf(tr.ModalMsgSplitMigrationCreated, 1))
+
+		paths, _ := filepath.Glob(filepath.Join(t.Project().Dir, "prisma", "migrations", "*_first", "migration.sql"))
+		if len(paths) != 1 {
+			t.Fail("expected one migration named first; found %q", paths)
+		}
+		content, _ := os.ReadFile(paths[0])
+		sql := string(content)
+		createType := strings.Index(sql, `CREATE TYPE "Role"`)
+		createUser := strings.Index(sql, `CREATE TABLE "User"`)
+		foreignKey := strings.Index(sql, `ALTER TABLE "Post" ADD CONSTRAINT`)
+		if createType == -1 || createUser == -1 || foreignKey < createUser {
+			t.Fail("expected the enum, both tables and then the foreign key; got:\n%s", sql)
+		}
+	},
+})
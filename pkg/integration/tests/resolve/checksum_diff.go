@@ -0,0 +1,24 @@
+package resolve
+
+import (
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var ChecksumDiff = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "The applied version of an edited migration is looked up in the background and then shown",
+	SetupProject: func(project *components.Project) {
+		project.
+			AddMigration("20240101090000_init", "CREATE TABLE \"User\" (\"id\" SERIAL PRIMARY KEY);\n").
+			ApplyMigration("20240101090000_init").
+			// Edited after it was applied, outside of git
+			AddMigration("20240101090000_init", "CREATE TABLE \"User\" (\"id\" SERIAL PRIMARY KEY, \"email\" TEXT);\n")
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		t.Right().End()
+		t.View("details").
+			Contains(tr.DetailsLocalChecksumLabel[:10]).
+			Contains(tr.DetailsChecksumDiffUnavailable[:40])
+	},
+})
@@ -0,0 +1,39 @@
+package resolve
+
+import (
+	"fmt"
+
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+const failedMigration = "20240115103000_add_role"
+
+var FailedMigration = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "Resolving a failed migration offers to mark it applied or rolled back, and runs the chosen one",
+	SetupProject: func(project *components.Project) {
+		project.
+			AddMigration("20240101090000_init", `CREATE TABLE "User" ("id" SERIAL PRIMARY KEY, "email" TEXT NOT NULL);`).
+			ApplyMigration("20240101090000_init").
+			AddMigration(failedMigration, `CREATE TYPE "Role" AS ENUM ('USER', 'ADMIN');`).
+			FailMigration(failedMigration, `ERROR: type "Role" already exists`)
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		// Focus the migrations panel and select the failed migration
+		t.Right()
+		t.Down()
+		t.Press('s')
+
+		t.Screen().
+			Contains(fmt.Sprintf(tr.ModalTitleResolveMigration, failedMigration)).
+			Contains(tr.ListItemMarkApplied).
+			Contains(tr.ListItemMarkRolledBack)
+
+		t.Down()
+		t.Enter()
+
+		t.ExpectPrismaCommand("prisma migrate resolve --rolled-back " + failedMigration)
+		t.Screen().Contains(tr.ModalTitleMigrateResolveSuccess)
+	},
+})
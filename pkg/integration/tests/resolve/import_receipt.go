@@ -0,0 +1,55 @@
+package resolve
+
+import (
+	"fmt"
+
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var ImportReceipt = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "Importing a receipt marks only its pending migrations as applied",
+	SetupProject: func(project *components.Project) {
+		project.
+			AddMigration("20240101090000_init", `CREATE TABLE "User" ("id" SERIAL PRIMARY KEY, "email" TEXT NOT NULL);`).
+			ApplyMigration("20240101090000_init").
+			AddMigration("20240115103000_add_name", `ALTER TABLE "User" ADD COLUMN "name" TEXT;`).
+			AddMigration("20240120080000_add_bio", `ALTER TABLE "User" ADD COLUMN "bio" TEXT;`).
+			AddMigration("20240201120000_add_role", `ALTER TABLE "User" ADD COLUMN "role" TEXT;`).
+			WriteFile("receipt.txt", `# applied by the DBA on 2024-02-02
+20240101090000_init
+20240115103000_add_name
+20240201120000_add_role
+20231231000000_other_project
+`)
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		// Pending tab of the migrations panel
+		t.Right()
+		t.Tab()
+		t.Press('s')
+
+		t.Screen().Contains(tr.ListItemImportReceipt)
+		t.Down()
+		t.Enter()
+
+		t.Screen().Contains(tr.ModalTitleEnterReceiptPath)
+		t.Type("receipt.txt")
+		t.Enter()
+
+		t.Screen().
+			Contains(fmt.Sprintf(tr.ListItemMarkReceiptApplied, 2)).
+			Contains("20231231000000_other_project")
+		t.Enter()
+
+		t.ExpectPrismaCommand("prisma migrate resolve --applied 20240115103000_add_name")
+		t.ExpectPrismaCommand("prisma migrate resolve --applied 20240201120000_add_role")
+		t.Screen().Contains(fmt.Sprintf(tr.ModalMsgMarkedAllApplied, 2))
+		for _, call := range t.Prisma().Calls() {
+			if call.String() == "prisma migrate resolve --applied 20240120080000_add_bio" {
+				t.Fail("expected 20240120080000_add_bio, which is not in the receipt, to stay pending")
+			}
+		}
+	},
+})
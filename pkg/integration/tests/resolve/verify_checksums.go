@@ -0,0 +1,40 @@
+package resolve
+
+import (
+	"fmt"
+
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var VerifyChecksums = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "Verifying checksums lists applied migrations that were edited or removed, ignoring line ending changes",
+	SetupProject: func(project *components.Project) {
+		project.
+			AddMigration("20240101090000_init", "CREATE TABLE \"User\" (\"id\" SERIAL PRIMARY KEY);\n").
+			ApplyMigration("20240101090000_init").
+			AddMigration("20240102090000_add_orders", "CREATE TABLE \"Order\" (\"id\" SERIAL PRIMARY KEY);\n").
+			ApplyMigration("20240102090000_add_orders").
+			AddDBOnlyMigration("20240103090000_hotfix", "CREATE INDEX \"Order_id_idx\" ON \"Order\"(\"id\");\n").
+			// Checked out on Windows: only the line endings differ
+			AddMigration("20240101090000_init", "CREATE TABLE \"User\" (\"id\" SERIAL PRIMARY KEY);\r\n").
+			// Edited after it was applied
+			AddMigration("20240102090000_add_orders", "CREATE TABLE \"Order\" (\"id\" SERIAL PRIMARY KEY, \"total\" INTEGER);\n")
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		t.Press('V')
+		t.Screen().
+			Contains(fmt.Sprintf(tr.ModalTitleChecksumReport, 2, 3)).
+			Contains("20240102090000_add_orders " + tr.ChecksumStatusEdited).
+			Contains("20240103090000_hotfix " + tr.ChecksumStatusMissing).
+			DoesNotContain("20240101090000_init " + tr.ChecksumStatusEdited)
+		t.View("outputs").Contains(fmt.Sprintf(tr.LogMsgChecksumsVerified, 3, 1, 1))
+
+		// The edited migration offers the checksum mismatch fixes
+		t.Enter()
+		t.Screen().
+			Contains(fmt.Sprintf(tr.ModalTitleResolveChecksumMismatch, "20240102090000_add_orders")).
+			Contains(tr.ListItemAcceptLocalChecksum)
+	},
+})
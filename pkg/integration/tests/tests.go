@@ -0,0 +1,69 @@
+// Package tests lists lazyprisma's integration tests; run them with
+// `go run ./cmd/integration_test` (or `make integration-test`).
+package tests
+
+import (
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+	"github.com/dokadev/lazyprisma/pkg/integration/tests/migrate"
+	"github.com/dokadev/lazyprisma/pkg/integration/tests/resolve"
+	"github.com/dokadev/lazyprisma/pkg/integration/tests/workspace"
+)
+
+// Tests is every integration test, in the order they run
+var Tests = []*components.IntegrationTest{
+	migrate.Deploy,
+	migrate.DeployCountdownAbort,
+	migrate.DeployFailedOutput,
+	migrate.DeployInvocation,
+	migrate.DeployMissingExtension,
+	migrate.DeployNodeMissing,
+	migrate.DeadColumns,
+	migrate.DeployEnvironmentDirectURL,
+	migrate.DeployWebhook,
+	migrate.DeployWebhookFailed,
+	migrate.DeploySummary,
+	migrate.ExportPendingSQL,
+	migrate.FixMerge,
+	migrate.FixMergeDisabled,
+	migrate.FormatSQLEscapes,
+	migrate.ManyMigrations,
+	migrate.MigrationActions,
+	migrate.PeekData,
+	migrate.RerunFromHistory,
+	migrate.SimulateOnTarget,
+	migrate.SplitMigration,
+	migrate.SchemaDiffDBOnly,
+	migrate.SchemaDiffPending,
+	resolve.ChecksumDiff,
+	resolve.FailedMigration,
+	resolve.ImportReceipt,
+	resolve.VerifyChecksums,
+	workspace.EnvSource,
+	workspace.EnvConflict,
+	workspace.DriverAdapter,
+	workspace.EdgeDatabase,
+	workspace.URLParams,
+	workspace.PooledURL,
+	workspace.TLSError,
+	workspace.PlanetScale,
+	workspace.Supabase,
+	workspace.NodePin,
+	workspace.RefreshRepeated,
+	workspace.QuickActions,
+	workspace.Extensions,
+	workspace.ExplainQuery,
+	workspace.ConsoleTransaction,
+	workspace.ConsoleReadOnly,
+	workspace.ConsoleWriteCTE,
+	workspace.ConsoleOpenTransaction,
+	workspace.DisabledActions,
+	workspace.RecentFiles,
+	workspace.ModelKeys,
+	workspace.SchemaPanel,
+	workspace.FileChanges,
+	workspace.Introspect,
+	workspace.IntrospectMultiFile,
+	workspace.RelocatedSchema,
+	workspace.SQLScript,
+	workspace.Studio,
+}
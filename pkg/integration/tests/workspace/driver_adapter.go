@@ -0,0 +1,35 @@
+package workspace
+
+import (
+	"fmt"
+
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var DriverAdapter = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "A libSQL database behind its driver adapter is labelled instead of reported as disconnected",
+	SetupProject: func(project *components.Project) {
+		project.
+			WriteSchema(`datasource db {
+  provider = "sqlite"
+  url      = env("LAZYPRISMA_TEST_DATABASE_URL")
+}
+
+model User {
+  id    Int    @id @default(autoincrement())
+  email String @unique
+}
+`).
+			WriteFile(".env", "LAZYPRISMA_TEST_DATABASE_URL=libsql://shop-acme.turso.io\n").
+			WriteFile("package.json", `{"dependencies": {"@prisma/client": "6.2.0", "@prisma/adapter-libsql": "6.2.0"}}`)
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		t.View("workspace").
+			Contains(tr.WorkspaceViaAdapter).
+			Contains(fmt.Sprintf(tr.WorkspaceDriverAdapter, "Turso (libSQL)", "@prisma/adapter-libsql")).
+			Contains(tr.WorkspaceAdapterNoDirect).
+			DoesNotContain(tr.WorkspaceDisconnected)
+	},
+})
@@ -0,0 +1,46 @@
+package workspace
+
+import (
+	"fmt"
+
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var EdgeDatabase = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "A Cloudflare D1 project explains that wrangler applies its migrations instead of deploying",
+	SetupProject: func(project *components.Project) {
+		project.
+			WriteSchema(`datasource db {
+  provider = "sqlite"
+  url      = env("LAZYPRISMA_TEST_DATABASE_URL")
+}
+
+model User {
+  id    Int    @id @default(autoincrement())
+  email String @unique
+}
+`).
+			WriteFile(".env", "LAZYPRISMA_TEST_DATABASE_URL=file:./dev.db\n").
+			WriteFile("package.json", `{"dependencies": {"@prisma/client": "6.2.0", "@prisma/adapter-d1": "6.2.0"}}`).
+			WriteFile("wrangler.toml", "name = \"shop\"\n\n[[d1_databases]]\nbinding = \"DB\"\ndatabase_name = \"shop-db\"\n").
+			AddMigration("20240101090000_init", `CREATE TABLE "User" ("id" INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT, "email" TEXT NOT NULL);`).
+			AddMigration("20240102090000_add_name", `ALTER TABLE "User" ADD COLUMN "name" TEXT;`)
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		t.View("workspace").
+			Contains(fmt.Sprintf(tr.WorkspaceEdgeMigrations, "wrangler"))
+		t.Right()
+		t.Down()
+		t.View("details").
+			Contains(fmt.Sprintf(tr.MigrationStatusUntracked, "wrangler")).
+			DoesNotContain(tr.MigrationStatusPending)
+
+		t.Press('D')
+		t.Screen().
+			Contains(fmt.Sprintf(tr.ModalTitleEdgeDeploy, "Cloudflare D1")).
+			Contains(tr.ModalMsgEdgeApplySelected).
+			DoesNotContain(tr.ListItemScheduleDeploy)
+	},
+})
@@ -0,0 +1,53 @@
+package workspace
+
+import (
+	"fmt"
+
+	"github.com/dokadev/lazyprisma/pkg/config"
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var FileChanges = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "Files changed outside the app in a burst are reloaded with a single refresh once they stop changing, and so are migrations edited in place",
+	SetupProject: func(project *components.Project) {
+		project.
+			AddMigration("20240101090000_init", `CREATE TABLE "User" ("id" SERIAL PRIMARY KEY);`)
+	},
+	SetupConfig: func(cfg *config.Config) {
+		cfg.Watch.Enabled = true
+		cfg.Watch.DebounceMillis = 200
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		t.View("migrations").DoesNotContain("add_posts")
+
+		// e.g. a git checkout bringing in two migrations and the schema they need
+		t.Project().
+			AddMigration("20240102090000_add_posts", `CREATE TABLE "Post" ("id" SERIAL PRIMARY KEY);`).
+			AddMigration("20240103090000_add_tags", `CREATE TABLE "Tag" ("id" SERIAL PRIMARY KEY);`).
+			WriteSchema(`datasource db {
+  provider = "postgresql"
+  url      = env("LAZYPRISMA_TEST_DATABASE_URL")
+}
+
+model Post {
+  id Int @id @default(autoincrement())
+}
+`)
+
+		t.Screen().Contains(fmt.Sprintf(tr.ToastFilesChanged, 3))
+		t.View("migrations").
+			Contains("add_posts").
+			Contains("add_tags")
+		t.View("outputs").
+			Contains(tr.ActionFilesChanged).
+			Contains("prisma/schema.prisma")
+		t.View("schema").Contains("Post")
+
+		// An edit in place leaves the migration folder's listing unchanged
+		t.Project().WriteFile("prisma/migrations/20240101090000_init/migration.sql",
+			`CREATE TABLE "User" ("id" SERIAL PRIMARY KEY, "name" TEXT);`)
+		t.Screen().Contains(fmt.Sprintf(tr.ToastFileChanged, "prisma/migrations/20240101090000_init/migration.sql"))
+	},
+})
@@ -0,0 +1,67 @@
+package workspace
+
+import (
+	"fmt"
+
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+const introspectSchema = `datasource db {
+  provider = "postgresql"
+  url      = env("LAZYPRISMA_TEST_DATABASE_URL")
+}
+
+model User {
+  id Int @id @default(autoincrement())
+}
+`
+
+var Introspect = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "Introspecting shows what db pull would change in schema.prisma, which can be discarded or applied",
+	SetupProject: func(project *components.Project) {
+		project.WriteSchema(introspectSchema)
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		// A table created outside Prisma's migrations
+		t.Prisma().IntrospectSchema = introspectSchema + `
+model Invoice {
+  id    Int @id @default(autoincrement())
+  total Int
+}
+`
+		t.Press('I')
+		t.ExpectPrismaCommand("prisma db pull --print")
+		t.View("details").
+			Contains(tr.IntrospectionHint).
+			Contains(fmt.Sprintf(tr.IntrospectionLineCount, 5, 0)).
+			Contains("+model Invoice {")
+		t.View("schema").DoesNotContain("Invoice")
+
+		// Discarding leaves schema.prisma alone
+		t.Press('I')
+		t.Screen().Contains(tr.ListItemApplyIntrospected)
+		t.Down().Enter()
+		t.View("outputs").Contains(tr.LogMsgIntrospectionDiscarded)
+		t.View("details").DoesNotContain(tr.IntrospectionHint)
+
+		// Introspecting again, then applying it
+		t.Press('I')
+		t.View("details").Contains("+model Invoice {")
+		t.Press('I')
+		t.Enter()
+		t.Screen().Contains("prisma/schema.prisma.bak")
+		t.View("schema").DoesNotContain("Invoice")
+		t.Press('y')
+		t.View("outputs").Contains(fmt.Sprintf(tr.LogMsgIntrospectionApplied, "prisma/schema.prisma", "prisma/schema.prisma.bak"))
+		t.View("schema").Contains("Invoice")
+		if t.Project().ReadFile("prisma/schema.prisma.bak") != introspectSchema {
+			t.Fail("expected the previous schema in prisma/schema.prisma.bak")
+		}
+
+		// Now there is nothing left to pull
+		t.Press('I')
+		t.View("details").Contains(tr.IntrospectionNoChanges)
+	},
+})
@@ -0,0 +1,37 @@
+package workspace
+
+import (
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var IntrospectMultiFile = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "A schema split across several .prisma files is not introspected, since db pull would write all its models into one file",
+	SetupProject: func(project *components.Project) {
+		project.
+			WriteFile("package.json", `{"name": "app", "prisma": {"schema": "prisma/schema"}}`).
+			WriteFile("prisma/schema/schema.prisma", `datasource db {
+  provider = "postgresql"
+  url      = env("LAZYPRISMA_TEST_DATABASE_URL")
+}
+`).
+			WriteFile("prisma/schema/user.prisma", `model User {
+  id Int @id @default(autoincrement())
+}
+`)
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		t.Press('I')
+		t.Screen().
+			Contains(tr.ModalTitleIntrospect).
+			Contains(".prisma files of prisma/schema")
+		if calls := t.Prisma().Calls(); len(calls) > 0 {
+			t.Fail("expected no Prisma command to run; ran %q", calls[0].String())
+		}
+
+		t.Escape()
+		t.Screen().DoesNotContain(tr.ModalTitleIntrospect)
+		t.View("details").DoesNotContain(tr.IntrospectionHint)
+	},
+})
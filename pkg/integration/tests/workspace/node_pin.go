@@ -0,0 +1,22 @@
+package workspace
+
+import (
+	"fmt"
+
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var NodePin = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "The Workspace panel flags a Node.js version other than the one .nvmrc pins",
+	SetupProject: func(project *components.Project) {
+		project.
+			AddMigration("20240101090000_init", `CREATE TABLE "User" ("id" SERIAL PRIMARY KEY, "email" TEXT NOT NULL);`).
+			ApplyMigration("20240101090000_init").
+			WriteFile(".nvmrc", "0.1 # no Node.js this old is installed\n")
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		t.View("workspace").Contains(fmt.Sprintf(tr.WorkspaceNodePinMismatch, ".nvmrc", "0.1"))
+	},
+})
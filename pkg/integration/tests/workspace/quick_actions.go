@@ -0,0 +1,40 @@
+package workspace
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dokadev/lazyprisma/pkg/demo"
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var QuickActions = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "Enter on the Workspace panel opens its quick actions; testing the connection reports latency and server version",
+	SetupProject: func(project *components.Project) {
+		project.
+			AddMigration("20240101090000_init", `CREATE TABLE "User" ("id" SERIAL PRIMARY KEY, "email" TEXT NOT NULL);`).
+			ApplyMigration("20240101090000_init")
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		t.Enter()
+		t.Screen().
+			Contains(tr.ModalTitleWorkspaceActions).
+			Contains(tr.ListItemRedetectVersions).
+			Contains(tr.ListItemCopyURLMasked).
+			Contains(tr.ListItemTestConnection)
+
+		// Edit .env, open schema, re-detect, reveal, copy masked, copy, test connection
+		t.Down().Down().Down().Down().Down().Down().Enter()
+		t.Screen().
+			Contains(tr.ModalTitleConnectionTest).
+			Contains(strings.Split(tr.ModalMsgConnectionOK, "%s")[0]).
+			Contains(fmt.Sprintf(tr.WorkspaceServerVersion, demo.ServerVersion))
+
+		t.Escape()
+		t.View("workspace").
+			Contains(fmt.Sprintf(tr.WorkspaceLatency, "800µs", "800µs", "800µs", 5)).
+			Contains(fmt.Sprintf(tr.WorkspaceServerVersion, demo.ServerVersion))
+	},
+})
@@ -0,0 +1,23 @@
+package workspace
+
+import (
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var RefreshRepeated = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "Refreshing again while a refresh runs is merged into it instead of being blocked",
+	SetupProject: func(project *components.Project) {
+		project.
+			AddMigration("20240101090000_init", `CREATE TABLE "User" ("id" SERIAL PRIMARY KEY, "email" TEXT NOT NULL);`).
+			ApplyMigration("20240101090000_init")
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		t.Press('r').Press('r').Press('r')
+
+		t.View("outputs").
+			Contains(tr.SuccessAllPanelsRefreshed).
+			DoesNotContain(tr.ErrorOperationBlocked)
+	},
+})
@@ -0,0 +1,101 @@
+package workspace
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var SchemaPanel = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "The Schema panel lists models, enums and generators, and the Details panel shows the selected model's fields and relations",
+	SetupProject: func(project *components.Project) {
+		project.
+			WriteSchema(`generator client {
+  provider = "prisma-client-js"
+}
+
+datasource db {
+  provider = "postgresql"
+  url      = env("LAZYPRISMA_TEST_DATABASE_URL")
+}
+
+model User {
+  id    Int    @id @default(autoincrement())
+  email String @unique
+  role  Role   @default(USER)
+  posts Post[]
+}
+
+/// A post, drafts included
+model Post {
+  id       Int    @id @default(autoincrement())
+  title    String
+  authorId Int
+  author   User   @relation(fields: [authorId], references: [id])
+
+  @@map("posts")
+}
+
+enum Role {
+  USER
+  ADMIN
+}
+`).
+			AddMigration("20240101090000_init", `CREATE TABLE "User" ("id" SERIAL PRIMARY KEY);`).
+			AddMigration("20240102090000_add_posts", `CREATE TABLE "posts" ("id" SERIAL PRIMARY KEY);`)
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+		line := func(format string, args ...any) string {
+			return strings.TrimSuffix(fmt.Sprintf(format, args...), "\n")
+		}
+
+		// Grouped by kind, with the group's entries in Details
+		t.Right().Right()
+		t.View("schema").
+			Contains(tr.SchemaGroupModels).
+			Contains(tr.SchemaGroupEnums).
+			Contains(tr.SchemaGroupGenerators).
+			Contains("prisma-client-js")
+		t.View("details").
+			Contains(fmt.Sprintf(tr.SchemaFieldCount, 4)).
+			Contains(tr.DetailsSchemaGroupHint)
+
+		// A model's fields and relations
+		t.Down()
+		t.View("details").
+			Contains(line(tr.DetailsSchemaEntryTitle, tr.SchemaKindModel, "User")).
+			Contains(tr.DetailsSchemaRelationsLabel).
+			Contains("→ Post").
+			Contains(tr.SchemaRelationMany)
+
+		// Its table name, doc comment and the models pointing at it
+		t.Down()
+		t.View("details").
+			Contains(line(tr.DetailsSchemaDatabaseNameLabel, "posts")).
+			Contains("A post, drafts included").
+			Contains(tr.DetailsSchemaReferencedByLabel).
+			Contains("User.posts").
+			Contains(`@@map("posts")`)
+
+		// Enter opens the definition in the Schema tab
+		t.Enter()
+		t.View("details").Contains("model Post {")
+
+		// Folding the Models group
+		t.Left()
+		t.View("details").Contains(tr.DetailsSchemaEntryHint)
+		t.Up().Up()
+		t.Enter()
+		t.View("schema").
+			DoesNotContain("Post").
+			Contains("Role")
+
+		// The Migrations panel shows its selection again
+		t.Left()
+		t.View("details").DoesNotContain(tr.DetailsSchemaGroupHint)
+		t.Down()
+		t.View("details").Contains("add_posts")
+	},
+})
@@ -0,0 +1,26 @@
+package workspace
+
+import (
+	"fmt"
+
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var SQLScript = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "A SQL maintenance script runs through prisma db execute against the project's schema",
+	SetupProject: func(project *components.Project) {
+		project.WriteFile("prisma/scripts/backfill_slugs.sql", `UPDATE "User" SET "email" = lower("email");`)
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		t.Press('x')
+		t.Screen().Contains("backfill_slugs.sql")
+		t.Enter()
+		t.Screen().Contains(fmt.Sprintf(tr.ModalMsgConfirmRunScript, "backfill_slugs.sql", "default"))
+		t.Press('y')
+
+		t.ExpectPrismaCommand("prisma db execute --file prisma/scripts/backfill_slugs.sql --schema prisma/schema.prisma")
+		t.View("outputs").Contains(fmt.Sprintf(tr.LogMsgScriptSucceeded, "backfill_slugs.sql"))
+	},
+})
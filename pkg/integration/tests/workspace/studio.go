@@ -0,0 +1,26 @@
+package workspace
+
+import (
+	"fmt"
+
+	"github.com/dokadev/lazyprisma/pkg/config"
+	"github.com/dokadev/lazyprisma/pkg/integration/components"
+)
+
+var Studio = components.NewIntegrationTest(components.NewIntegrationTestArgs{
+	Description: "Prisma Studio starts on the configured port and stops again",
+	SetupConfig: func(cfg *config.Config) {
+		cfg.Studio.Port = 5599
+	},
+	Run: func(t *components.TestDriver) {
+		tr := t.Tr()
+
+		t.Press('S')
+		t.ExpectPrismaCommand("prisma studio --port 5599")
+		t.Screen().Contains(fmt.Sprintf(tr.ModalMsgStudioRunningAt, 5599))
+		t.Escape()
+
+		t.Press('S')
+		t.Screen().Contains(tr.ModalMsgStudioStopped)
+	},
+})
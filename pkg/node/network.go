@@ -0,0 +1,123 @@
+package node
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultRegistry is the npm registry used when npm_config_registry is not set
+const DefaultRegistry = "https://registry.npmjs.org/"
+
+// networkCheckTimeout bounds the reachability check so an offline machine is
+// detected in about a second instead of waiting for npx to give up
+const networkCheckTimeout = time.Second
+
+var offline atomic.Bool
+
+// IsOffline reports the result of the last CheckNetwork call (false until one has run)
+func IsOffline() bool {
+	return offline.Load()
+}
+
+// CheckNetwork tests whether the npm registry, or the proxy npm reaches it
+// through, can be reached and remembers the result.
+// It returns true if the machine appears to be offline.
+func CheckNetwork() bool {
+	conn, err := net.DialTimeout("tcp", checkAddress(), networkCheckTimeout)
+	if err == nil {
+		conn.Close()
+	}
+	offline.Store(err != nil)
+	return err != nil
+}
+
+// checkAddress returns host:port of the proxy configured for the npm registry
+// or, without one, of the registry itself
+func checkAddress() string {
+	registry := registryURL()
+	if proxy := registryProxy(registry); proxy != nil {
+		return hostPort(proxy)
+	}
+	return hostPort(registry)
+}
+
+// registryURL returns the configured npm registry
+func registryURL() *url.URL {
+	u, err := url.Parse(os.Getenv("npm_config_registry"))
+	if err != nil || u.Hostname() == "" {
+		u, _ = url.Parse(DefaultRegistry)
+	}
+	return u
+}
+
+// registryProxy returns the proxy npm uses for the registry: its own
+// https-proxy or proxy setting, else HTTPS_PROXY / HTTP_PROXY unless NO_PROXY
+// excludes the registry. Returns nil for a direct connection.
+func registryProxy(registry *url.URL) *url.URL {
+	settings := []string{"npm_config_proxy"}
+	if registry.Scheme == "https" {
+		settings = []string{"npm_config_https_proxy", "npm_config_proxy"}
+	}
+	for _, setting := range settings {
+		value := os.Getenv(setting)
+		if value != "" && !strings.Contains(value, "://") {
+			value = "http://" + value
+		}
+		if proxy, err := url.Parse(value); err == nil && proxy.Hostname() != "" {
+			return proxy
+		}
+	}
+
+	proxy, err := http.ProxyFromEnvironment(&http.Request{URL: registry})
+	if err != nil || proxy == nil || proxy.Hostname() == "" {
+		return nil
+	}
+	return proxy
+}
+
+// hostPort returns host:port of a URL, defaulting the port from its scheme
+func hostPort(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		port = "443"
+		if u.Scheme == "http" {
+			port = "80"
+		}
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// networkErrorMarkers are fragments of the errors npm, npx and the Prisma engine
+// downloader print when a request fails because the network is unreachable
+var networkErrorMarkers = []string{
+	"ENOTFOUND",
+	"EAI_AGAIN",
+	"ECONNRESET",
+	"ETIMEDOUT",
+	"ENETUNREACH",
+	"EHOSTUNREACH",
+	"getaddrinfo",
+	"socket hang up",
+	"fetch failed",
+	"network request failed",
+	"request to https://",
+	"binaries.prisma.sh",
+	"npm error network",
+	"npm ERR! network",
+}
+
+// IsNetworkError reports whether command output looks like a failure caused by
+// the network (package resolution or engine download), as opposed to a Prisma error
+func IsNetworkError(output string) bool {
+	for _, marker := range networkErrorMarkers {
+		if strings.Contains(output, marker) {
+			return true
+		}
+	}
+	return false
+}
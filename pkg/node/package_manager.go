@@ -0,0 +1,92 @@
+package node
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PackageManager is the tool a project installs its packages with
+type PackageManager string
+
+const (
+	PackageManagerNpm  PackageManager = "npm"
+	PackageManagerPnpm PackageManager = "pnpm"
+	PackageManagerYarn PackageManager = "yarn"
+	PackageManagerBun  PackageManager = "bun"
+)
+
+// lockFiles maps each package manager's lock file to it
+var lockFiles = []struct {
+	name    string
+	manager PackageManager
+}{
+	{"pnpm-lock.yaml", PackageManagerPnpm},
+	{"yarn.lock", PackageManagerYarn},
+	{"bun.lock", PackageManagerBun},
+	{"bun.lockb", PackageManagerBun},
+	{"package-lock.json", PackageManagerNpm},
+}
+
+// DetectPackageManager returns the package manager of the project in
+// projectDir: the one named by the "packageManager" field of the nearest
+// package.json, or the one whose lock file is found first searching
+// projectDir and its parents (monorepos keep it at the root). Defaults to npm.
+func DetectPackageManager(projectDir string) PackageManager {
+	dir, err := filepath.Abs(projectDir)
+	if err != nil {
+		return PackageManagerNpm
+	}
+
+	for {
+		if manager := readPackageManagerField(filepath.Join(dir, "package.json")); manager != "" {
+			return manager
+		}
+		for _, lock := range lockFiles {
+			if _, err := os.Stat(filepath.Join(dir, lock.name)); err == nil {
+				return lock.manager
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			// Reached root
+			return PackageManagerNpm
+		}
+		dir = parent
+	}
+}
+
+// readPackageManagerField returns the manager of a package.json's
+// "packageManager" field, e.g. "pnpm@9.1.0" -> pnpm
+func readPackageManagerField(path string) PackageManager {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	var pkg struct {
+		PackageManager string `json:"packageManager"`
+	}
+	if json.Unmarshal(data, &pkg) != nil {
+		return ""
+	}
+
+	name, _, _ := strings.Cut(pkg.PackageManager, "@")
+	switch manager := PackageManager(name); manager {
+	case PackageManagerNpm, PackageManagerPnpm, PackageManagerYarn, PackageManagerBun:
+		return manager
+	}
+	return ""
+}
+
+// AddDevCommand returns the command line that adds packages as dev
+// dependencies, e.g. ["pnpm", "add", "-D", "prisma"]
+func (pm PackageManager) AddDevCommand(packages ...string) []string {
+	switch pm {
+	case PackageManagerPnpm, PackageManagerYarn, PackageManagerBun:
+		return append([]string{string(pm), "add", "-D"}, packages...)
+	default:
+		return append([]string{"npm", "install", "-D"}, packages...)
+	}
+}
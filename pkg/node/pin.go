@@ -0,0 +1,102 @@
+package node
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Pin is the Node.js version a project asks for
+type Pin struct {
+	Version string // As written, e.g. "20", "v20.11.0" or "lts/iron"
+	Source  string // Where it is pinned: ".nvmrc", ".node-version" or "package.json" (volta)
+	Dir     string // Directory of the file that pins it
+}
+
+// pinFiles are the files nvm, fnm and most other tools read a pinned version from
+var pinFiles = []string{".nvmrc", ".node-version"}
+
+// FindPin returns the Node.js version pinned in projectDir, or in the nearest
+// parent directory that pins one (monorepos pin it at the root), or nil
+func FindPin(projectDir string) *Pin {
+	dir, err := filepath.Abs(projectDir)
+	if err != nil {
+		return nil
+	}
+
+	for {
+		for _, name := range pinFiles {
+			if version := readPinFile(filepath.Join(dir, name)); version != "" {
+				return &Pin{Version: version, Source: name, Dir: dir}
+			}
+		}
+		if version := readVoltaPin(filepath.Join(dir, "package.json")); version != "" {
+			return &Pin{Version: version, Source: "package.json", Dir: dir}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			// Reached root
+			return nil
+		}
+		dir = parent
+	}
+}
+
+// readPinFile returns the version in an .nvmrc-style file: its first line
+// that isn't empty or a comment
+func readPinFile(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line, _, _ = strings.Cut(line, "#")
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// readVoltaPin returns the "volta": {"node": ...} version of a package.json
+func readVoltaPin(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	var pkg struct {
+		Volta struct {
+			Node string `json:"node"`
+		} `json:"volta"`
+	}
+	if json.Unmarshal(data, &pkg) != nil {
+		return ""
+	}
+	return strings.TrimSpace(pkg.Volta.Node)
+}
+
+// Matches reports whether version (e.g. "20.11.0") is one the pin allows: a
+// pin of "20" allows any 20.x.y, "20.11" any 20.11.y. comparable is false for
+// pins that name an alias or a range (e.g. "lts/*" or ">=18"), which only the
+// version manager can resolve.
+func (p *Pin) Matches(version string) (matches, comparable bool) {
+	want := strings.Split(strings.TrimPrefix(p.Version, "v"), ".")
+	have := strings.Split(strings.TrimPrefix(version, "v"), ".")
+
+	for _, part := range want {
+		if part == "" || strings.Trim(part, "0123456789") != "" {
+			return false, false
+		}
+	}
+	if len(want) > len(have) {
+		return false, true
+	}
+	for i, part := range want {
+		if part != have[i] {
+			return false, true
+		}
+	}
+	return true, true
+}
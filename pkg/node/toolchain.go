@@ -0,0 +1,45 @@
+package node
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// MissingToolError reports that a command could not start because node or npx
+// is not on the PATH, e.g. after the Node.js version it was using was removed
+// with nvm or the container was restarted without it
+type MissingToolError struct {
+	Tool string // "node" or "npx"
+}
+
+func (e *MissingToolError) Error() string {
+	return e.Tool + ": executable file not found in $PATH"
+}
+
+// CheckToolchain returns a *MissingToolError if commandLine needs node or npx
+// and it can't be found. npx and the project's node_modules/.bin/prisma are
+// node scripts, so both need node as well.
+func CheckToolchain(commandLine []string) error {
+	if len(commandLine) == 0 {
+		return nil
+	}
+
+	program := filepath.ToSlash(commandLine[0])
+	name := strings.TrimSuffix(filepath.Base(program), filepath.Ext(program))
+
+	switch {
+	case name == "npx":
+		if _, err := exec.LookPath("npx"); err != nil {
+			return &MissingToolError{Tool: "npx"}
+		}
+	case name == "node", strings.Contains(program, "node_modules/.bin/"):
+	default:
+		return nil
+	}
+
+	if _, err := exec.LookPath("node"); err != nil {
+		return &MissingToolError{Tool: "node"}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package node
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+)
+
+// Manager is a Node.js version manager
+type Manager string
+
+const (
+	ManagerNone  Manager = ""
+	ManagerAuto  Manager = "auto" // Setting only: the installed manager that fits the pin
+	ManagerNvm   Manager = "nvm"
+	ManagerFnm   Manager = "fnm"
+	ManagerVolta Manager = "volta"
+)
+
+// execManager is the manager commands are run through ("" = run them directly)
+var execManager atomic.Value
+
+// SetExecManager makes WrapCommand run node commands through manager, so the
+// Node.js version the project pins is used. "", "off" and unknown names turn
+// it off.
+func SetExecManager(manager Manager) {
+	switch manager {
+	case ManagerAuto, ManagerNvm, ManagerFnm, ManagerVolta:
+	default:
+		manager = ManagerNone
+	}
+	execManager.Store(manager)
+}
+
+// DetectManagers returns the version managers installed on this machine
+func DetectManagers() []Manager {
+	var managers []Manager
+	if nvmScript() != "" {
+		managers = append(managers, ManagerNvm)
+	}
+	if _, err := exec.LookPath("fnm"); err == nil {
+		managers = append(managers, ManagerFnm)
+	}
+	if _, err := exec.LookPath("volta"); err == nil {
+		managers = append(managers, ManagerVolta)
+	}
+	return managers
+}
+
+// nvmScript returns the path of nvm.sh, or "" if nvm is not installed. nvm is
+// a shell function, so commands run through it source this first.
+func nvmScript() string {
+	dir := os.Getenv("NVM_DIR")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".nvm")
+	}
+
+	path := filepath.Join(dir, "nvm.sh")
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+// ExecManagerFor returns the manager commands in projectDir run through, or
+// ManagerNone if that is turned off, nothing is pinned or the manager is not
+// installed
+func ExecManagerFor(projectDir string) (Manager, *Pin) {
+	setting, _ := execManager.Load().(Manager)
+	if setting == ManagerNone {
+		return ManagerNone, nil
+	}
+	pin := FindPin(projectDir)
+	if pin == nil {
+		return ManagerNone, nil
+	}
+
+	installed := DetectManagers()
+	has := func(m Manager) bool {
+		for _, i := range installed {
+			if i == m {
+				return true
+			}
+		}
+		return false
+	}
+
+	if setting != ManagerAuto {
+		if has(setting) {
+			return setting, pin
+		}
+		return ManagerNone, nil
+	}
+
+	// A volta pin is for volta; the .nvmrc-style files work with all of them
+	if pin.Source == "package.json" && has(ManagerVolta) {
+		return ManagerVolta, pin
+	}
+	for _, m := range []Manager{ManagerFnm, ManagerNvm, ManagerVolta} {
+		if has(m) {
+			return m, pin
+		}
+	}
+	return ManagerNone, nil
+}
+
+// WrapCommand returns commandLine run through the version manager set with
+// SetExecManager, using the version projectDir pins, e.g.
+// ["npx", "prisma", "validate"] -> ["fnm", "exec", "--using=20", "--", "npx", "prisma", "validate"].
+// It is returned unchanged when no manager applies.
+func WrapCommand(projectDir string, commandLine []string) []string {
+	manager, pin := ExecManagerFor(projectDir)
+
+	switch manager {
+	case ManagerFnm:
+		return append([]string{"fnm", "exec", "--using=" + pin.Version, "--"}, commandLine...)
+	case ManagerVolta:
+		return append([]string{"volta", "run", "--node", pin.Version}, commandLine...)
+	case ManagerNvm:
+		// $0 is nvm.sh; --no-use keeps it from switching versions while loading
+		script := `. "$0" --no-use && nvm exec --silent "$@"`
+		return append([]string{"bash", "-c", script, nvmScript(), pin.Version}, commandLine...)
+	default:
+		return commandLine
+	}
+}
+
+// GetProjectVersion returns the Node.js version commands in projectDir run
+// with: the pinned one when they go through a version manager, otherwise the
+// one on the PATH
+func GetProjectVersion(projectDir string) (*VersionInfo, error) {
+	cmd := cmdBuilder.New(WrapCommand(projectDir, []string{"node", "--version"})...).WithWorkingDir(projectDir)
+	result, err := cmd.RunWithOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	// Managers may print notices first; the version is the last line
+	lines := strings.Split(strings.TrimSpace(result.Stdout), "\n")
+	version := strings.TrimSpace(lines[len(lines)-1])
+	version = strings.TrimPrefix(version, "v")
+
+	return &VersionInfo{
+		Version: version,
+	}, nil
+}
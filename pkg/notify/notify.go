@@ -0,0 +1,124 @@
+// Package notify posts the result of a deploy to webhooks, e.g. a Slack
+// incoming webhook, so that the team hears about it without being told.
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Events a webhook can be called for
+const (
+	EventDeploy = "deploy"
+)
+
+// DefaultTemplate is the request body sent when a webhook has no template of
+// its own: Slack-style {"text": ...}, which Mattermost and Rocket.Chat also accept
+const DefaultTemplate = `{"text": {{json .Text}}}`
+
+// requestTimeout bounds how long a webhook may take to answer
+const requestTimeout = 10 * time.Second
+
+// Summary is the result of a command that webhooks are told about. Its fields
+// are what a webhook template can use, e.g. {{.Project}} or {{len .Migrations}}.
+type Summary struct {
+	Event       string // e.g. EventDeploy
+	Project     string // Project directory name
+	Environment string // Named environment, or "" for the project's own database
+	Target      string // host:port/database of the target database (no credentials)
+	Success     bool
+	ExitCode    int
+	Migrations  []string // Migrations applied, in order
+	Failed      string   // Migration that failed, if any
+	Duration    time.Duration
+	User        string    // OS user who ran the command
+	Time        time.Time // When the command finished
+}
+
+// Status returns "succeeded" or "failed"
+func (s Summary) Status() string {
+	if s.Success {
+		return "succeeded"
+	}
+	return "failed"
+}
+
+// Destination returns the environment name, or the target database without one
+func (s Summary) Destination() string {
+	if s.Environment != "" {
+		return s.Environment
+	}
+	return s.Target
+}
+
+// Text is a human-readable summary, as posted by DefaultTemplate
+func (s Summary) Text() string {
+	icon := "✅"
+	if !s.Success {
+		icon = "❌"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s: %s to %s %s (%s, by %s)", icon, s.Project, s.Event, s.Destination(), s.Status(),
+		s.Duration.Round(100*time.Millisecond), s.User)
+	fmt.Fprintf(&b, "\n%d migration(s) applied", len(s.Migrations))
+	for _, name := range s.Migrations {
+		b.WriteString("\n• " + name)
+	}
+	if s.Failed != "" {
+		fmt.Fprintf(&b, "\nFailed: %s", s.Failed)
+	} else if !s.Success {
+		fmt.Fprintf(&b, "\nExit code %d", s.ExitCode)
+	}
+	return b.String()
+}
+
+// Render executes a webhook template ("" for DefaultTemplate) for s. Besides
+// the Summary fields, templates can use json (a JSON-encoded value, quotes
+// included) and join (strings joined with a separator).
+func Render(tmpl string, s Summary) ([]byte, error) {
+	if strings.TrimSpace(tmpl) == "" {
+		tmpl = DefaultTemplate
+	}
+
+	t, err := template.New("webhook").Funcs(template.FuncMap{
+		"json": func(v any) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+		"join": func(sep string, items []string) string {
+			return strings.Join(items, sep)
+		},
+	}).Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+
+	var body bytes.Buffer
+	if err := t.Execute(&body, s); err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return body.Bytes(), nil
+}
+
+// Post sends body to url as JSON. A response other than 2xx is an error.
+func Post(url string, body []byte) error {
+	client := &http.Client{Timeout: requestTimeout}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+// Package planetscale lists a PlanetScale database's branches through the
+// pscale CLI, so they can be matched to the project's environments.
+package planetscale
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/dokadev/lazyprisma/pkg/commands"
+)
+
+var cmdBuilder *commands.CommandBuilder
+
+func init() {
+	platform := commands.NewPlatform()
+	cmdBuilder = commands.NewCommandBuilder(platform)
+}
+
+// Branch is a branch of a PlanetScale database
+type Branch struct {
+	Name         string    `json:"name"`
+	ParentBranch string    `json:"parent_branch"`
+	Production   bool      `json:"production"`
+	Ready        bool      `json:"ready"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Available reports whether the pscale CLI is on the PATH
+func Available() bool {
+	_, err := exec.LookPath("pscale")
+	return err == nil
+}
+
+// ListBranches returns the branches of database, production branches first.
+// org may be "" to use the organization pscale is switched to.
+func ListBranches(database, org string) ([]Branch, error) {
+	args := []string{"pscale", "branch", "list", database, "--format", "json"}
+	if org != "" {
+		args = append(args, "--org", org)
+	}
+
+	result, err := cmdBuilder.New(args...).RunWithOutput()
+	if err != nil || result.ExitCode != 0 {
+		msg := strings.TrimSpace(result.Stderr)
+		if msg == "" && err != nil {
+			msg = err.Error()
+		}
+		return nil, fmt.Errorf("pscale branch list: %s", msg)
+	}
+
+	var branches []Branch
+	if err := json.Unmarshal([]byte(result.Stdout), &branches); err != nil {
+		return nil, fmt.Errorf("pscale branch list: %w", err)
+	}
+
+	var production, others []Branch
+	for _, branch := range branches {
+		if branch.Production {
+			production = append(production, branch)
+		} else {
+			others = append(others, branch)
+		}
+	}
+	return append(production, others...), nil
+}
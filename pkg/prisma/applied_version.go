@@ -0,0 +1,49 @@
+package prisma
+
+import (
+	"path/filepath"
+
+	"github.com/dokadev/lazyprisma/pkg/git"
+)
+
+// maxAppliedVersionRevisions limits how far back git history is searched for an applied version
+const maxAppliedVersionRevisions = 50
+
+// AppliedVersion is the content of a migration.sql as it was when it was applied
+type AppliedVersion struct {
+	Content  string
+	Revision git.Revision // Zero value if the content came from the git index
+}
+
+// FindAppliedVersion searches the git index and history of a migration's migration.sql
+// for the revision whose checksum matches the one recorded in the database.
+// Returns nil (without error) if no matching revision exists.
+func FindAppliedVersion(migration Migration) (*AppliedVersion, error) {
+	if migration.Path == "" || migration.DBChecksum == "" {
+		return nil, nil
+	}
+
+	sqlPath := filepath.Join(migration.Path, "migration.sql")
+
+	// The staged version may still match if only the working tree was edited
+	if content, err := git.ShowFile(migration.Path, "", sqlPath); err == nil && Checksum(content) == migration.DBChecksum {
+		return &AppliedVersion{Content: string(content)}, nil
+	}
+
+	revisions, err := git.FileRevisions(migration.Path, sqlPath, maxAppliedVersionRevisions)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rev := range revisions {
+		content, err := git.ShowFile(migration.Path, rev.Hash, sqlPath)
+		if err != nil {
+			continue
+		}
+		if Checksum(content) == migration.DBChecksum {
+			return &AppliedVersion{Content: string(content), Revision: rev}, nil
+		}
+	}
+
+	return nil, nil
+}
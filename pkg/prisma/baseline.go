@@ -0,0 +1,61 @@
+package prisma
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// BaselineMigrationName is the migration that captures an existing database's schema
+const BaselineMigrationName = "0_init"
+
+// WriteBaselineMigration generates SQL that creates the current schema from an
+// empty database and saves it as the 0_init migration. Mark it applied with
+// `prisma migrate resolve --applied 0_init` so it is never run against the
+// database it was introspected from.
+func WriteBaselineMigration(projectDir string) (string, error) {
+	schemaPath := SchemaArg(projectDir)
+
+	args := Command(projectDir, "migrate", "diff", "--from-empty", toSchemaFlag(projectDir), schemaPath, "--script")
+	result, err := cmdBuilder.New(args...).WithWorkingDir(projectDir).WithEnv(EngineOverrideEnv(projectDir)...).RunWithOutput()
+	if err != nil || result.ExitCode != 0 {
+		msg := strings.TrimSpace(result.Stderr)
+		if msg == "" && err != nil {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("prisma migrate diff failed: %s", msg)
+	}
+
+	migrationDir := filepath.Join(MigrationsDir(projectDir), BaselineMigrationName)
+	if err := os.MkdirAll(migrationDir, 0755); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(migrationDir, "migration.sql")
+	if err := os.WriteFile(path, []byte(result.Stdout), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// majorVersion returns the major version of the project's Prisma CLI, or 0 if unknown
+func majorVersion(projectDir string) int {
+	info, err := GetVersion(projectDir)
+	if err != nil || info == nil {
+		return 0
+	}
+	major, _, _ := strings.Cut(info.Version, ".")
+	n, _ := strconv.Atoi(major)
+	return n
+}
+
+// toSchemaFlag returns the migrate diff flag for a schema file target.
+// Prisma 7 renamed --to-schema-datamodel to --to-schema.
+func toSchemaFlag(projectDir string) string {
+	if majorVersion(projectDir) >= 7 {
+		return "--to-schema"
+	}
+	return "--to-schema-datamodel"
+}
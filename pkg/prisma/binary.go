@@ -0,0 +1,68 @@
+package prisma
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/dokadev/lazyprisma/pkg/node"
+)
+
+// LocalBinary returns the path of the prisma executable in the nearest
+// node_modules/.bin, searching projectDir and then each parent directory
+// (monorepos often hoist it to the root), or "" if there is none
+func LocalBinary(projectDir string) string {
+	name := "prisma"
+	if runtime.GOOS == "windows" {
+		name = "prisma.cmd"
+	}
+
+	dir, err := filepath.Abs(projectDir)
+	if err != nil {
+		return ""
+	}
+
+	for {
+		path := filepath.Join(dir, "node_modules", ".bin", name)
+		if stat, err := os.Stat(path); err == nil && !stat.IsDir() {
+			return path
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			// Reached root
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// Command returns the full args to run a prisma subcommand in projectDir,
+// e.g. Command(dir, "migrate", "deploy") -> [".../node_modules/.bin/prisma", "migrate", "deploy"].
+//
+// The local binary is run directly: it starts seconds faster than npx and can
+// never trigger an npx auto-install. npx is only used when prisma is not
+// installed, and is told not to touch the network when the machine is offline
+// (it would otherwise stall trying to reach the registry). Either way it runs
+// through the Node.js version manager if one is set up (see node.WrapCommand).
+func Command(projectDir string, args ...string) []string {
+	if bin := LocalBinary(projectDir); bin != "" {
+		return node.WrapCommand(projectDir, append([]string{bin}, args...))
+	}
+
+	if node.IsOffline() {
+		return node.WrapCommand(projectDir, append([]string{"npx", "--offline", "prisma"}, args...))
+	}
+	return node.WrapCommand(projectDir, append([]string{"npx", "prisma"}, args...))
+}
+
+// InstallCommand returns the command line that adds the Prisma CLI to the
+// project's dev dependencies with its package manager, at the version of
+// @prisma/client if that is installed (the two have to match)
+func InstallCommand(projectDir string) []string {
+	pkg := "prisma"
+	if version := GetClientVersion(projectDir); version != "" {
+		pkg += "@" + version
+	}
+	return node.WrapCommand(projectDir, node.DetectPackageManager(projectDir).AddDevCommand(pkg))
+}
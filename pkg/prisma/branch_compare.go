@@ -0,0 +1,70 @@
+package prisma
+
+import (
+	"sort"
+
+	"github.com/dokadev/lazyprisma/pkg/git"
+)
+
+// BranchComparison describes how the migrations of another branch differ from the local ones
+type BranchComparison struct {
+	Branch     string
+	OnlyLocal  []string // Migrations present locally but not on Branch
+	OnlyBranch []string // Migrations present on Branch but not locally
+	Common     int
+
+	// OutOfOrder lists OnlyBranch migrations older than the newest local migration;
+	// after a merge they would sort before migrations that are already applied.
+	OutOfOrder []string
+}
+
+// InSync reports whether both sides have the same migrations
+func (c *BranchComparison) InSync() bool {
+	return len(c.OnlyLocal) == 0 && len(c.OnlyBranch) == 0
+}
+
+// CompareBranchMigrations compares local migrations with the migrations directory of
+// another branch, read from git without checking it out.
+func CompareBranchMigrations(projectDir, branch string, local []Migration) (*BranchComparison, error) {
+	migrationsPath := MigrationsDir(projectDir)
+
+	branchNames, err := git.ListTreeDirs(projectDir, branch, migrationsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	branchSet := make(map[string]bool, len(branchNames))
+	for _, name := range branchNames {
+		branchSet[name] = true
+	}
+
+	result := &BranchComparison{Branch: branch}
+	localSet := make(map[string]bool, len(local))
+	newestLocal := ""
+	for _, mig := range local {
+		localSet[mig.Name] = true
+		if mig.Name > newestLocal {
+			newestLocal = mig.Name
+		}
+		if branchSet[mig.Name] {
+			result.Common++
+		} else {
+			result.OnlyLocal = append(result.OnlyLocal, mig.Name)
+		}
+	}
+
+	for _, name := range branchNames {
+		if localSet[name] {
+			continue
+		}
+		result.OnlyBranch = append(result.OnlyBranch, name)
+		if name < newestLocal {
+			result.OutOfOrder = append(result.OutOfOrder, name)
+		}
+	}
+
+	sort.Strings(result.OnlyLocal)
+	sort.Strings(result.OnlyBranch)
+	sort.Strings(result.OutOfOrder)
+	return result, nil
+}
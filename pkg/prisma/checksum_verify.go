@@ -0,0 +1,125 @@
+package prisma
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ChecksumStatus is how an applied migration's migration.sql compares with
+// the checksum recorded when it was applied
+type ChecksumStatus string
+
+const (
+	ChecksumOK          ChecksumStatus = "ok"           // Unchanged
+	ChecksumLineEndings ChecksumStatus = "line-endings" // Only its line endings changed, which Prisma accepts
+	ChecksumMismatch    ChecksumStatus = "mismatch"     // Edited after it was applied
+	ChecksumMissing     ChecksumStatus = "missing"      // Its migration.sql is not in the migrations directory
+)
+
+// ChecksumCheck is the result of verifying one applied migration
+type ChecksumCheck struct {
+	Name     string
+	Status   ChecksumStatus
+	Local    string // Checksum of migration.sql as it is now ("" if missing)
+	Recorded string // Checksum in _prisma_migrations
+}
+
+// ChecksumReport is what VerifyChecksums found
+type ChecksumReport struct {
+	Checks []ChecksumCheck // Every applied migration, in the order they were applied
+}
+
+// Count returns how many checks have status
+func (r *ChecksumReport) Count(status ChecksumStatus) int {
+	count := 0
+	for _, check := range r.Checks {
+		if check.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// Problems returns the checks Prisma would complain about: mismatches and
+// missing files
+func (r *ChecksumReport) Problems() []ChecksumCheck {
+	var problems []ChecksumCheck
+	for _, check := range r.Checks {
+		if check.Status == ChecksumMismatch || check.Status == ChecksumMissing {
+			problems = append(problems, check)
+		}
+	}
+	return problems
+}
+
+// VerifyChecksums reads the migration.sql of every applied migration in
+// dbMigrations from disk again and compares it with the recorded checksum,
+// the way Prisma does. Failed and rolled back migrations are skipped, as
+// Prisma doesn't check them.
+func VerifyChecksums(projectDir string, dbMigrations []DBMigration) *ChecksumReport {
+	report := &ChecksumReport{}
+	dir := MigrationsDir(projectDir)
+
+	for _, dbMig := range dbMigrations {
+		if dbMig.FinishedAt == nil || dbMig.RolledBackAt != nil {
+			continue
+		}
+		check := ChecksumCheck{Name: dbMig.Name, Recorded: dbMig.Checksum, Status: ChecksumMissing}
+
+		if script, err := os.ReadFile(filepath.Join(dir, dbMig.Name, "migration.sql")); err == nil {
+			check.Local = rawChecksum(script)
+			switch {
+			case scriptMatchesChecksum(script, dbMig.Checksum, false):
+				check.Status = ChecksumOK
+			case scriptMatchesChecksum(script, dbMig.Checksum, true):
+				check.Status = ChecksumLineEndings
+			default:
+				check.Status = ChecksumMismatch
+			}
+		}
+		report.Checks = append(report.Checks, check)
+	}
+	return report
+}
+
+// scriptMatchesChecksum is the schema engine's script_matches_checksum: the
+// SHA-256 of the script as it is or, if lineEndings, with CRLF replaced by LF
+// or LF by CRLF. Checksums recorded by old engine versions left out the
+// leading zero of each byte, so a checksum shorter than 64 characters is
+// compared in that format.
+func scriptMatchesChecksum(script []byte, checksum string, lineEndings bool) bool {
+	candidates := [][]byte{script}
+	if lineEndings {
+		candidates = append(candidates,
+			bytes.ReplaceAll(script, []byte("\r\n"), []byte("\n")),
+			bytes.ReplaceAll(script, []byte("\n"), []byte("\r\n")))
+	}
+
+	for _, candidate := range candidates {
+		sum := sha256.Sum256(candidate)
+		formatted := hex.EncodeToString(sum[:])
+		if checksum != "" && len(checksum) != len(formatted) {
+			var unpadded strings.Builder
+			for _, b := range sum {
+				fmt.Fprintf(&unpadded, "%x", b)
+			}
+			formatted = unpadded.String()
+		}
+		if formatted == checksum {
+			return true
+		}
+	}
+	return false
+}
+
+// rawChecksum is the SHA-256 of script as Prisma records it, without the line
+// ending normalization Checksum does
+func rawChecksum(script []byte) string {
+	sum := sha256.Sum256(script)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,78 @@
+package prisma
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxIdentifierLength is PostgreSQL's limit on identifier length (NAMEDATALEN - 1)
+const maxIdentifierLength = 63
+
+// ConcurrentIndex describes an index to build with CREATE INDEX CONCURRENTLY
+type ConcurrentIndex struct {
+	Table   string   // Table name, optionally schema-qualified ("public.User")
+	Columns []string // Indexed columns, in order
+}
+
+// Name returns the index name Prisma would generate for @@index on these
+// columns ("{table}_{columns}_idx"), so the schema can refer to it with map:
+func (idx ConcurrentIndex) Name() string {
+	table := idx.Table
+	if i := strings.LastIndex(table, "."); i >= 0 {
+		table = table[i+1:]
+	}
+
+	name := table + "_" + strings.Join(idx.Columns, "_") + "_idx"
+	if len(name) > maxIdentifierLength {
+		name = name[:maxIdentifierLength]
+	}
+	return name
+}
+
+// Statement returns the CREATE INDEX CONCURRENTLY statement
+func (idx ConcurrentIndex) Statement() string {
+	quotedColumns := make([]string, len(idx.Columns))
+	for i, col := range idx.Columns {
+		quotedColumns[i] = quoteIdent(col)
+	}
+
+	parts := strings.Split(idx.Table, ".")
+	for i, part := range parts {
+		parts[i] = quoteIdent(part)
+	}
+
+	return fmt.Sprintf("CREATE INDEX CONCURRENTLY IF NOT EXISTS %s ON %s (%s);",
+		quoteIdent(idx.Name()), strings.Join(parts, "."), strings.Join(quotedColumns, ", "))
+}
+
+// MigrationSQL returns the content of a migration that only builds the index,
+// with instructions for the cases Prisma can't handle on its own
+func (idx ConcurrentIndex) MigrationSQL(migrationName string) string {
+	var b strings.Builder
+	b.WriteString("-- Builds an index without blocking writes to the table.\n")
+	b.WriteString("-- Created with the lazyprisma concurrent index helper.\n")
+	b.WriteString("--\n")
+	b.WriteString("-- CREATE INDEX CONCURRENTLY cannot run inside a transaction block. Prisma sends\n")
+	b.WriteString("-- a migration to PostgreSQL as one script, which runs in a single implicit\n")
+	b.WriteString("-- transaction as soon as it has more than one statement. Keep the statement\n")
+	b.WriteString("-- below as the ONLY statement in this migration.\n")
+	b.WriteString("--\n")
+	b.WriteString("-- If it fails, PostgreSQL may leave an INVALID index behind. To retry:\n")
+	fmt.Fprintf(&b, "--   1. DROP INDEX CONCURRENTLY IF EXISTS %s;\n", quoteIdent(idx.Name()))
+	fmt.Fprintf(&b, "--   2. npx prisma migrate resolve --rolled-back %s\n", migrationName)
+	b.WriteString("--   3. npx prisma migrate deploy\n")
+	b.WriteString("-- To build it by hand instead (e.g. off-peak), run the statement with psql, then:\n")
+	fmt.Fprintf(&b, "--   npx prisma migrate resolve --applied %s\n", migrationName)
+	b.WriteString("--\n")
+	b.WriteString("-- Also declare the index in schema.prisma so migrate dev doesn't drop it:\n")
+	fmt.Fprintf(&b, "--   @@index([%s], map: \"%s\")\n", strings.Join(idx.Columns, ", "), idx.Name())
+	b.WriteString("\n")
+	b.WriteString(idx.Statement())
+	b.WriteString("\n")
+	return b.String()
+}
+
+// quoteIdent double-quotes a PostgreSQL identifier
+func quoteIdent(ident string) string {
+	return `"` + strings.ReplaceAll(ident, `"`, `""`) + `"`
+}
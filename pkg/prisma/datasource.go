@@ -3,6 +3,7 @@ package prisma
 import (
 	"bufio"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -15,6 +16,7 @@ type Datasource struct {
 	URL         string // Database connection URL
 	EnvVarName  string // Environment variable name (e.g., "DATABASE_URL")
 	IsHardcoded bool   // True if URL is hardcoded in schema/config
+	URLSource   string // File the URL was read from: a .env file, or the schema/config if hardcoded ("" for the process environment)
 }
 
 // GetProvider extracts only the provider from schema files
@@ -58,6 +60,9 @@ func GetDatasource(projectDir string) (*Datasource, error) {
 		ds.URL = url
 		ds.EnvVarName = envVar
 		ds.IsHardcoded = isHardcoded
+		if isHardcoded {
+			ds.URLSource = configPath
+		}
 
 		provider, err := extractProviderFromSchema(projectDir)
 		if err != nil {
@@ -74,6 +79,13 @@ func GetDatasource(projectDir string) (*Datasource, error) {
 		ds.URL = url
 		ds.EnvVarName = envVar
 		ds.IsHardcoded = isHardcoded
+		if isHardcoded {
+			ds.URLSource = SchemaPath(projectDir)
+		}
+	}
+
+	if ds.EnvVarName != "" && ds.URL != "" {
+		_, ds.URLSource = ResolveEnvVarSource(projectDir, ds.EnvVarName)
 	}
 
 	if ds.Provider == "" || ds.URL == "" {
@@ -127,14 +139,14 @@ func extractURLFromConfig(projectDir, configPath string) (string, string, bool,
 		// Check for env() usage
 		if match := envRegex.FindStringSubmatch(line); match != nil {
 			envVar := match[1]
-			url := resolveEnvVar(projectDir, envVar)
+			url := ResolveEnvVar(projectDir, envVar)
 			return url, envVar, false, nil
 		}
 
 		// Check for process.env usage
 		if match := processEnvRegex.FindStringSubmatch(line); match != nil {
 			envVar := match[1]
-			url := resolveEnvVar(projectDir, envVar)
+			url := ResolveEnvVar(projectDir, envVar)
 			return url, envVar, false, nil
 		}
 
@@ -154,7 +166,7 @@ func extractURLFromConfig(projectDir, configPath string) (string, string, bool,
 
 // extractEnvVarFromSchema extracts only the env var name from schema.prisma
 func extractEnvVarFromSchema(projectDir string) (string, error) {
-	schemaPath := filepath.Join(projectDir, SchemaDirName, SchemaFileName)
+	schemaPath := SchemaPath(projectDir)
 	if _, err := os.Stat(schemaPath); os.IsNotExist(err) {
 		return "", fmt.Errorf("schema.prisma not found")
 	}
@@ -199,7 +211,7 @@ func extractEnvVarFromSchema(projectDir string) (string, error) {
 
 // extractProviderFromSchema extracts provider from schema.prisma
 func extractProviderFromSchema(projectDir string) (string, error) {
-	schemaPath := filepath.Join(projectDir, SchemaDirName, SchemaFileName)
+	schemaPath := SchemaPath(projectDir)
 	if _, err := os.Stat(schemaPath); os.IsNotExist(err) {
 		return "", fmt.Errorf("schema.prisma not found")
 	}
@@ -243,7 +255,7 @@ func extractProviderFromSchema(projectDir string) (string, error) {
 // extractDatasourceFromSchema extracts both provider and URL from schema.prisma (v7-)
 // Returns: (provider, url, envVarName, isHardcoded, error)
 func extractDatasourceFromSchema(projectDir string) (string, string, string, bool, error) {
-	schemaPath := filepath.Join(projectDir, SchemaDirName, SchemaFileName)
+	schemaPath := SchemaPath(projectDir)
 	if _, err := os.Stat(schemaPath); os.IsNotExist(err) {
 		return "", "", "", false, fmt.Errorf("schema.prisma not found")
 	}
@@ -290,7 +302,7 @@ func extractDatasourceFromSchema(projectDir string) (string, string, string, boo
 				// Check if it's an env variable reference
 				if envMatch := envRegex.FindStringSubmatch(urlValue); envMatch != nil {
 					envVarName = envMatch[1]
-					url = resolveEnvVar(projectDir, envVarName)
+					url = ResolveEnvVar(projectDir, envVarName)
 					isHardcoded = false
 				} else {
 					// Direct URL (hardcoded, remove quotes)
@@ -308,52 +320,81 @@ func extractDatasourceFromSchema(projectDir string) (string, string, string, boo
 	return provider, url, envVarName, isHardcoded, nil
 }
 
-// resolveEnvVar resolves an environment variable following Prisma's resolution order
-func resolveEnvVar(projectDir, envVar string) string {
-	// 1. Check OS environment variables first
-	if val := os.Getenv(envVar); val != "" {
-		return val
-	}
+// ResolveEnvVar resolves an environment variable following Prisma's resolution order
+func ResolveEnvVar(projectDir, envVar string) string {
+	val, _ := ResolveEnvVarSource(projectDir, envVar)
+	return val
+}
 
-	// 2. Check .env in project root
-	if val := readEnvFile(filepath.Join(projectDir, ".env"), envVar); val != "" {
-		return val
-	}
+// ResolveEnvVarSource resolves an environment variable like ResolveEnvVar and
+// also returns where it was found: the .env file path, or "" for the process
+// environment (or when the variable is not set anywhere). The process
+// environment wins; then the dotenv-flow files of EnvFiles are read in order,
+// and ${VAR} references in a value are expanded like dotenv-expand does.
+func ResolveEnvVarSource(projectDir, envVar string) (string, string) {
+	return resolveEnvVar(projectDir, envVar, map[string]bool{})
+}
 
-	// 3. Check .env in schema directory
-	if val := readEnvFile(filepath.Join(projectDir, SchemaDirName, ".env"), envVar); val != "" {
-		return val
-	}
+// GetShadowDatabaseURL returns the shadowDatabaseUrl configured in schema.prisma
+// or prisma.config.ts with its env var name (if any). The URL is "" if no shadow
+// database is configured, in which case migrate dev creates a temporary one.
+func GetShadowDatabaseURL(projectDir string) (string, string) {
+	return getDatasourceURLSetting(projectDir, "shadowDatabaseUrl")
+}
 
-	// 4. Check .env in prisma directory
-	if val := readEnvFile(filepath.Join(projectDir, "prisma", ".env"), envVar); val != "" {
-		return val
+// GetDirectURL returns the directUrl configured in schema.prisma or
+// prisma.config.ts with its env var name (if any). Migrations connect through
+// it instead of the pooled url. The URL is "" if none is configured.
+func GetDirectURL(projectDir string) (string, string) {
+	return getDatasourceURLSetting(projectDir, "directUrl")
+}
+
+// getDatasourceURLSetting returns a URL setting of the datasource, e.g.
+// directUrl, from prisma.config.ts or schema.prisma, with its env var name
+func getDatasourceURLSetting(projectDir, setting string) (string, string) {
+	sources := []string{filepath.Join(projectDir, ConfigFileName), SchemaPath(projectDir)}
+
+	envRegex := regexp.MustCompile(`\b` + setting + `\s*[=:]\s*(?:env\(\s*['"]([^'"]+)['"]\s*\)|process\.env\[['"]([^'"]+)['"]\]|process\.env\.(\w+))`)
+	hardcodedRegex := regexp.MustCompile(`\b` + setting + `\s*[=:]\s*['"]([^'"]+)['"]`)
+
+	for _, path := range sources {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		if match := envRegex.FindStringSubmatch(string(content)); match != nil {
+			envVar := match[1] + match[2] + match[3]
+			return ResolveEnvVar(projectDir, envVar), envVar
+		}
+		if match := hardcodedRegex.FindStringSubmatch(string(content)); match != nil {
+			return match[1], ""
+		}
 	}
 
-	return ""
+	return "", ""
 }
 
-// readEnvFile reads a specific environment variable from a .env file
-func readEnvFile(path, envVar string) string {
-	file, err := os.Open(path)
-	if err != nil {
+// ProviderFromURL guesses the datasource provider from a connection URL's scheme,
+// returning "" if it is not recognised
+func ProviderFromURL(dbURL string) string {
+	scheme, _, ok := strings.Cut(dbURL, ":")
+	if !ok {
 		return ""
 	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	envRegex := regexp.MustCompile(`^\s*` + regexp.QuoteMeta(envVar) + `\s*=\s*(.+)`)
 
-	for scanner.Scan() {
-		line := scanner.Text()
-		if match := envRegex.FindStringSubmatch(line); match != nil {
-			// Remove quotes if present
-			value := strings.TrimSpace(match[1])
-			value = strings.Trim(value, `"'`)
-			return value
-		}
+	switch strings.ToLower(scheme) {
+	case "postgresql", "postgres":
+		return "postgresql"
+	case "mysql", "mariadb":
+		return "mysql"
+	case "sqlserver":
+		return "sqlserver"
+	case "file":
+		return "sqlite"
+	case "mongodb", "mongodb+srv":
+		return "mongodb"
 	}
-
 	return ""
 }
 
@@ -376,3 +417,22 @@ func MaskPassword(dbURL string) string {
 	maskedURL := passwordRegex.ReplaceAllString(dbURL, "${1}****${3}")
 	return maskedURL
 }
+
+// DBFingerprint identifies the database a URL points to without credentials,
+// e.g. "db.example.com:5432/app" or "file:./dev.db" for SQLite.
+func DBFingerprint(dbURL string) string {
+	if dbURL == "" {
+		return ""
+	}
+
+	if strings.HasPrefix(dbURL, "file:") {
+		return dbURL
+	}
+
+	u, err := url.Parse(dbURL)
+	if err != nil || u.Host == "" {
+		return MaskPassword(dbURL)
+	}
+
+	return u.Host + u.Path
+}
@@ -0,0 +1,18 @@
+package prisma
+
+import (
+	"regexp"
+)
+
+// "Applying migration `20240309_add_orders`"
+var applyingMigrationRe = regexp.MustCompile("^\\s*Applying migration\\s+`([^`]+)`")
+
+// ParseApplyingMigration reports whether a line of migrate deploy output
+// announces the next migration being applied, and returns its name
+func ParseApplyingMigration(line string) (name string, ok bool) {
+	m := applyingMigrationRe.FindStringSubmatch(line)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
@@ -0,0 +1,253 @@
+package prisma
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// defaultNodeEnv is the NODE_ENV dotenv-flow assumes when it is not set
+const defaultNodeEnv = "development"
+
+// envRefRegex matches dotenv-expand references: ${VAR}, ${VAR:-default}, ${VAR-default}
+// and $VAR, each optionally escaped with a backslash
+var envRefRegex = regexp.MustCompile(`(\\?)\$(?:\{([A-Za-z_][A-Za-z0-9_]*)(?::?-([^}]*))?\}|([A-Za-z_][A-Za-z0-9_]*))`)
+
+// envFileNames returns the dotenv-flow files for NODE_ENV, highest priority first:
+// .env.{NODE_ENV}.local, .env.local (not for the test environment), .env.{NODE_ENV}, .env
+func envFileNames() []string {
+	nodeEnv := os.Getenv("NODE_ENV")
+	if nodeEnv == "" {
+		nodeEnv = defaultNodeEnv
+	}
+
+	names := []string{".env." + nodeEnv + ".local"}
+	if nodeEnv != "test" {
+		names = append(names, ".env.local")
+	}
+	return append(names, ".env."+nodeEnv, ".env")
+}
+
+// EnvFiles returns the .env files a project's variables are read from, highest
+// priority first: the dotenv-flow files of the project root, then those of the
+// schema directory. Files that don't exist are included.
+func EnvFiles(projectDir string) []string {
+	var paths []string
+	for _, dir := range []string{projectDir, filepath.Join(projectDir, SchemaDirName)} {
+		for _, name := range envFileNames() {
+			paths = append(paths, filepath.Join(dir, name))
+		}
+	}
+	return paths
+}
+
+// envFileValue is a variable's raw value in a .env file
+type envFileValue struct {
+	value   string
+	literal bool // single-quoted: no ${VAR} expansion
+}
+
+// readEnvFile reads a specific environment variable from a .env file.
+// Returns false if the file does not define it.
+func readEnvFile(path, envVar string) (envFileValue, bool) {
+	file, err := os.Open(path)
+	if err != nil {
+		return envFileValue{}, false
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	envRegex := regexp.MustCompile(`^\s*(?:export\s+)?` + regexp.QuoteMeta(envVar) + `\s*=\s*(.*)$`)
+
+	for scanner.Scan() {
+		if match := envRegex.FindStringSubmatch(scanner.Text()); match != nil {
+			return parseEnvValue(match[1]), true
+		}
+	}
+
+	return envFileValue{}, false
+}
+
+// parseEnvValue unquotes a .env value. Unquoted values end at an inline " #" comment.
+func parseEnvValue(raw string) envFileValue {
+	raw = strings.TrimSpace(raw)
+	if len(raw) >= 2 {
+		switch quote := raw[0]; quote {
+		case '"', '\'', '`':
+			if end := strings.IndexByte(raw[1:], quote); end >= 0 {
+				value := raw[1 : end+1]
+				if quote == '"' {
+					value = strings.ReplaceAll(value, `\n`, "\n")
+				}
+				return envFileValue{value: value, literal: quote == '\''}
+			}
+		}
+	}
+
+	if i := strings.Index(raw, " #"); i >= 0 {
+		raw = strings.TrimSpace(raw[:i])
+	}
+	return envFileValue{value: strings.Trim(raw, `"'`)}
+}
+
+// expandEnvRefs replaces the ${VAR} references in value like dotenv-expand,
+// looking each variable up with lookup. A reference to an unset variable
+// expands to its default, or "" without one; \$ keeps a literal dollar sign.
+func expandEnvRefs(value string, lookup func(name string) string) string {
+	return envRefRegex.ReplaceAllStringFunc(value, func(ref string) string {
+		match := envRefRegex.FindStringSubmatch(ref)
+		if match[1] != "" {
+			return ref[1:]
+		}
+
+		name, fallback := match[2], match[3]
+		if name == "" {
+			name = match[4]
+		}
+		if val := lookup(name); val != "" {
+			return val
+		}
+		return fallback
+	})
+}
+
+// resolveEnvVar implements ResolveEnvVarSource; resolving holds the variables
+// being expanded, so that a reference cycle resolves to "" instead of looping
+func resolveEnvVar(projectDir, envVar string, resolving map[string]bool) (string, string) {
+	// 1. The process environment wins over every .env file
+	if val := os.Getenv(envVar); val != "" {
+		return val, ""
+	}
+
+	if resolving[envVar] {
+		return "", ""
+	}
+	resolving[envVar] = true
+	defer delete(resolving, envVar)
+
+	// 2. The dotenv-flow files, with references expanded
+	for _, path := range EnvFiles(projectDir) {
+		raw, ok := readEnvFile(path, envVar)
+		if !ok || raw.value == "" {
+			continue
+		}
+		if raw.literal {
+			return raw.value, path
+		}
+		return expandEnvRefs(raw.value, func(name string) string {
+			val, _ := resolveEnvVar(projectDir, name, resolving)
+			return val
+		}), path
+	}
+
+	return "", ""
+}
+
+// EnvVarDefinition is one place an environment variable is defined
+type EnvVarDefinition struct {
+	Value  string // Value with references expanded
+	Source string // .env file path, or "" for the process environment
+}
+
+// EnvVarDefinitions returns every place envVar is defined, in resolution order:
+// the process environment, then each .env file of EnvFiles. The first one is
+// the value ResolveEnvVar returns.
+func EnvVarDefinitions(projectDir, envVar string) []EnvVarDefinition {
+	var defs []EnvVarDefinition
+	if val := os.Getenv(envVar); val != "" {
+		defs = append(defs, EnvVarDefinition{Value: val})
+	}
+
+	for _, path := range EnvFiles(projectDir) {
+		raw, ok := readEnvFile(path, envVar)
+		if !ok || raw.value == "" {
+			continue
+		}
+		value := raw.value
+		if !raw.literal {
+			value = expandEnvRefs(raw.value, func(name string) string {
+				return ResolveEnvVar(projectDir, name)
+			})
+		}
+		defs = append(defs, EnvVarDefinition{Value: value, Source: path})
+	}
+
+	return defs
+}
+
+// EnvVarConflicts returns the definitions of envVar if they disagree on its
+// value, or nil if it is defined once or everywhere with the same value
+func EnvVarConflicts(projectDir, envVar string) []EnvVarDefinition {
+	defs := EnvVarDefinitions(projectDir, envVar)
+	for i := 1; i < len(defs); i++ {
+		if defs[i].Value != defs[0].Value {
+			return defs
+		}
+	}
+	return nil
+}
+
+// AppendEnvVar adds NAME="value" to the end of the .env file at path,
+// creating the file if it doesn't exist
+func AppendEnvVar(path, name, value string) error {
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	line := fmt.Sprintf("%s=%q\n", name, value)
+	if len(content) > 0 && !strings.HasSuffix(string(content), "\n") {
+		line = "\n" + line
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = file.WriteString(line)
+	return err
+}
+
+// UpdateEnvVar replaces the value of name in the .env file at path with what
+// update returns for it. The value is passed as written, with ${VAR}
+// references unexpanded; its quotes and any comment after it are kept.
+func UpdateEnvVar(path, name string, update func(value string) string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	envRegex := regexp.MustCompile(`^(\s*(?:export\s+)?` + regexp.QuoteMeta(name) + `\s*=\s*)(.*)$`)
+	lines := strings.Split(string(content), "\n")
+	for i, line := range lines {
+		match := envRegex.FindStringSubmatch(strings.TrimSuffix(line, "\r"))
+		if match == nil {
+			continue
+		}
+		prefix, raw := match[1], match[2]
+
+		var quote, value, rest string
+		if len(raw) >= 2 && strings.ContainsRune("\"'`", rune(raw[0])) {
+			if end := strings.IndexByte(raw[1:], raw[0]); end >= 0 {
+				quote, value, rest = raw[:1], raw[1:end+1], raw[end+2:]
+			}
+		}
+		if quote == "" {
+			value = raw
+			if j := strings.Index(raw, " #"); j >= 0 {
+				value, rest = raw[:j], raw[j:]
+			}
+		}
+
+		lines[i] = prefix + quote + update(value) + quote + rest
+		if strings.HasSuffix(line, "\r") {
+			lines[i] += "\r"
+		}
+		return os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0644)
+	}
+	return fmt.Errorf("%s is not set in %s", name, path)
+}
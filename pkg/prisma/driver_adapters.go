@@ -0,0 +1,113 @@
+package prisma
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// driverAdapterPrefix is the npm scope and prefix of Prisma's driver adapters
+const driverAdapterPrefix = "@prisma/adapter-"
+
+// DriverAdapter is the driver adapter a project's Prisma Client connects through
+type DriverAdapter struct {
+	Package string // e.g. "@prisma/adapter-neon"
+	Name    string // Service or driver it is for, e.g. "Neon"
+	Source  string // Where it was found: "package.json", ConfigFileName or "url"
+}
+
+// driverAdapterNames are the display names of the adapters Prisma publishes
+var driverAdapterNames = map[string]string{
+	"@prisma/adapter-neon":           "Neon",
+	"@prisma/adapter-planetscale":    "PlanetScale",
+	"@prisma/adapter-libsql":         "Turso (libSQL)",
+	"@prisma/adapter-d1":             "Cloudflare D1",
+	"@prisma/adapter-pg":             "node-postgres",
+	"@prisma/adapter-pg-worker":      "node-postgres (worker)",
+	"@prisma/adapter-mariadb":        "MariaDB",
+	"@prisma/adapter-mssql":          "SQL Server (mssql)",
+	"@prisma/adapter-better-sqlite3": "better-sqlite3",
+}
+
+// configAdapterImportRegex matches an adapter imported in prisma.config.ts,
+// e.g. import { PrismaNeon } from "@prisma/adapter-neon"
+var configAdapterImportRegex = regexp.MustCompile(`(?:from|require\()\s*['"](@prisma/adapter-[\w-]+)['"]`)
+
+// DetectDriverAdapter returns the driver adapter the project in projectDir
+// connects through, or nil if it uses Prisma's built-in drivers. A libsql://
+// URL names its adapter; otherwise the adapter imported in prisma.config.ts
+// wins over one listed in package.json.
+func DetectDriverAdapter(projectDir, url string) *DriverAdapter {
+	if scheme, _, ok := strings.Cut(url, "://"); ok && strings.EqualFold(scheme, "libsql") {
+		return newDriverAdapter(driverAdapterPrefix+"libsql", "url")
+	}
+
+	if content, err := os.ReadFile(filepath.Join(projectDir, ConfigFileName)); err == nil {
+		if match := configAdapterImportRegex.FindSubmatch(content); match != nil {
+			return newDriverAdapter(string(match[1]), ConfigFileName)
+		}
+	}
+
+	content, err := os.ReadFile(filepath.Join(projectDir, "package.json"))
+	if err != nil {
+		return nil
+	}
+	var pkg struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil {
+		return nil
+	}
+
+	// A known adapter before any other, in a stable order
+	var found []string
+	for _, deps := range []map[string]string{pkg.Dependencies, pkg.DevDependencies} {
+		for name := range deps {
+			if strings.HasPrefix(name, driverAdapterPrefix) {
+				found = append(found, name)
+			}
+		}
+	}
+	if len(found) == 0 {
+		return nil
+	}
+	best := found[0]
+	for _, name := range found[1:] {
+		_, known := driverAdapterNames[name]
+		_, bestKnown := driverAdapterNames[best]
+		if (known && !bestKnown) || (known == bestKnown && name < best) {
+			best = name
+		}
+	}
+	return newDriverAdapter(best, "package.json")
+}
+
+// newDriverAdapter names an adapter package found in source
+func newDriverAdapter(pkg, source string) *DriverAdapter {
+	name, ok := driverAdapterNames[pkg]
+	if !ok {
+		name = strings.TrimPrefix(pkg, driverAdapterPrefix)
+	}
+	return &DriverAdapter{Package: pkg, Name: name, Source: source}
+}
+
+// CanConnectDirectly reports whether lazyprisma can open the database URL
+// itself. Adapters for edge databases (libsql://, https:// endpoints, D1
+// bindings) are reached only through their JavaScript driver.
+func CanConnectDirectly(url string) bool {
+	if strings.HasPrefix(url, "file:") {
+		return true
+	}
+	scheme, _, ok := strings.Cut(url, "://")
+	if !ok {
+		return false
+	}
+	switch strings.ToLower(scheme) {
+	case "postgresql", "postgres", "mysql", "sqlserver", "file":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package prisma
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// EdgeDatabase is a database Prisma Migrate doesn't apply migrations to: the
+// SQL Prisma generates is applied with the service's own CLI instead, so
+// whether a migration is applied can't be read from _prisma_migrations
+type EdgeDatabase struct {
+	Adapter  *DriverAdapter
+	CLI      string // CLI that applies migrations: "turso" or "wrangler"
+	Database string // Database to pass to the CLI ("" = unknown)
+}
+
+// tursoURLEnvVar is the variable Turso's guides keep the database URL in
+const tursoURLEnvVar = "TURSO_DATABASE_URL"
+
+// wranglerConfigFiles are the Cloudflare Workers configs a D1 binding is declared in
+var wranglerConfigFiles = []string{"wrangler.toml", "wrangler.jsonc", "wrangler.json"}
+
+// d1DatabaseNameRegex matches a D1 binding's database_name in wrangler.toml
+// (database_name = "shop") or wrangler.json ("database_name": "shop")
+var d1DatabaseNameRegex = regexp.MustCompile(`"?database_name"?\s*[=:]\s*"([^"]+)"`)
+
+// DetectEdgeDatabase returns the edge database the project in projectDir
+// deploys to, or nil. A D1 project's datasource URL is only a local file for
+// the CLI, so D1 always counts; a libSQL project only when its URL is a Turso
+// one, since prisma migrate works on a local SQLite file as usual.
+func DetectEdgeDatabase(projectDir, dbURL string) *EdgeDatabase {
+	adapter := DetectDriverAdapter(projectDir, dbURL)
+	if adapter == nil {
+		return nil
+	}
+
+	switch adapter.Package {
+	case driverAdapterPrefix + "d1":
+		return &EdgeDatabase{Adapter: adapter, CLI: "wrangler", Database: d1DatabaseName(projectDir)}
+	case driverAdapterPrefix + "libsql":
+		if CanConnectDirectly(dbURL) {
+			return nil
+		}
+		database := tursoDatabase(dbURL)
+		if database == "" {
+			database = tursoDatabase(ResolveEnvVar(projectDir, tursoURLEnvVar))
+		}
+		return &EdgeDatabase{Adapter: adapter, CLI: "turso", Database: database}
+	}
+	return nil
+}
+
+// ApplyCommand returns the command line that applies the migration SQL at
+// sqlPath to the database
+func (e *EdgeDatabase) ApplyCommand(sqlPath string) string {
+	database := e.Database
+	if database == "" {
+		database = "<database>"
+	}
+	if e.CLI == "wrangler" {
+		return "npx wrangler d1 execute " + database + " --remote --file " + sqlPath
+	}
+	return "turso db shell " + database + " < " + sqlPath
+}
+
+// tursoDatabase returns a libsql:// URL without its credentials and query
+// (the auth token), or ""
+func tursoDatabase(dbURL string) string {
+	u, err := url.Parse(dbURL)
+	if err != nil || !strings.EqualFold(u.Scheme, "libsql") || u.Host == "" {
+		return ""
+	}
+	return "libsql://" + u.Host
+}
+
+// d1DatabaseName returns the database_name of the first D1 binding in the
+// project's wrangler config, or ""
+func d1DatabaseName(projectDir string) string {
+	for _, name := range wranglerConfigFiles {
+		content, err := os.ReadFile(filepath.Join(projectDir, name))
+		if err != nil {
+			continue
+		}
+		if match := d1DatabaseNameRegex.FindSubmatch(content); match != nil {
+			return string(match[1])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,30 @@
+package prisma
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	engineDownloadRe = regexp.MustCompile(`(?i)download(ing)?\b.*\bengines?\b`)
+	percentRe        = regexp.MustCompile(`(\d{1,3})\s*%`)
+)
+
+// ParseEngineDownload reports whether a line of prisma output is engine
+// download progress, e.g.
+// "Downloading Prisma engines for Node-API for debian-openssl-3.0.x [====    ] 45%".
+// percent is -1 if the line carries no percentage.
+func ParseEngineDownload(line string) (percent int, ok bool) {
+	if !engineDownloadRe.MatchString(line) || strings.Contains(strings.ToLower(line), "error") {
+		return 0, false
+	}
+
+	percent = -1
+	if m := percentRe.FindStringSubmatch(line); m != nil {
+		if p, err := strconv.Atoi(m[1]); err == nil && p <= 100 {
+			percent = p
+		}
+	}
+	return percent, true
+}
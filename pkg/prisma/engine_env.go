@@ -0,0 +1,86 @@
+package prisma
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+// engineEnvPrefix is the prefix of the environment variables Prisma reads its overrides from
+const engineEnvPrefix = "PRISMA_"
+
+// EngineEnvVars are the Prisma overrides looked up in the project's .env files
+// as well as the process environment: engine locations, binary targets and
+// CLI behaviour
+var EngineEnvVars = []string{
+	"PRISMA_SCHEMA_ENGINE_BINARY",
+	"PRISMA_MIGRATION_ENGINE_BINARY",
+	"PRISMA_QUERY_ENGINE_BINARY",
+	"PRISMA_QUERY_ENGINE_LIBRARY",
+	"PRISMA_CLI_BINARY_TARGETS",
+	"PRISMA_CLI_QUERY_ENGINE_TYPE",
+	"PRISMA_CLIENT_ENGINE_TYPE",
+	"PRISMA_ENGINES_MIRROR",
+	"PRISMA_ENGINES_CHECKSUM_IGNORE_MISSING",
+	"PRISMA_HIDE_UPDATE_MESSAGE",
+	"PRISMA_DISABLE_WARNINGS",
+	"PRISMA_GENERATE_SKIP_AUTOINSTALL",
+	"PRISMA_SCHEMA_DISABLE_ADVISORY_LOCK",
+}
+
+// EngineOverride is a PRISMA_* environment variable that is set for a project
+type EngineOverride struct {
+	Name   string
+	Value  string
+	Source string // .env file path, or "" for the process environment
+}
+
+// FromEnvFile reports whether the override comes from a .env file rather than
+// the process environment
+func (o EngineOverride) FromEnvFile() bool {
+	return o.Source != ""
+}
+
+// EngineOverrides returns the PRISMA_* overrides active for a project, sorted by
+// name: every PRISMA_* variable of the process environment and the known
+// EngineEnvVars set in the project's .env files
+func EngineOverrides(projectDir string) []EngineOverride {
+	seen := make(map[string]bool)
+	var overrides []EngineOverride
+
+	for _, kv := range os.Environ() {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || !strings.HasPrefix(name, engineEnvPrefix) || value == "" {
+			continue
+		}
+		seen[name] = true
+		overrides = append(overrides, EngineOverride{Name: name, Value: value})
+	}
+
+	for _, name := range EngineEnvVars {
+		if seen[name] {
+			continue
+		}
+		if value, source := ResolveEnvVarSource(projectDir, name); value != "" {
+			overrides = append(overrides, EngineOverride{Name: name, Value: value, Source: source})
+		}
+	}
+
+	sort.Slice(overrides, func(i, j int) bool {
+		return overrides[i].Name < overrides[j].Name
+	})
+	return overrides
+}
+
+// EngineOverrideEnv returns the overrides set in the project's .env files as
+// "KEY=value" pairs. Spawned commands inherit the process environment, but
+// Prisma 7 no longer loads .env files itself, so these are passed explicitly.
+func EngineOverrideEnv(projectDir string) []string {
+	var env []string
+	for _, o := range EngineOverrides(projectDir) {
+		if o.FromEnvFile() {
+			env = append(env, o.Name+"="+o.Value)
+		}
+	}
+	return env
+}
@@ -0,0 +1,120 @@
+package prisma
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// EnumChange describes how an enum that existed in an older schema changed
+type EnumChange struct {
+	Name    string
+	Added   []string // Values only in the current schema
+	Removed []string // Values only in the old schema (renames show up as removed + added)
+	Dropped bool     // The enum no longer exists
+}
+
+// EnumUsage is a model field typed with an enum
+type EnumUsage struct {
+	Model  string
+	Table  string // Database name of the model (@@map or model name)
+	Field  string
+	Column string // Database name of the field (@map or field name)
+}
+
+// `@map("user_role")`
+var fieldMapRegex = regexp.MustCompile(`@map\(\s*(?:name:\s*)?"([^"]+)"`)
+
+// ParseEnums returns the values of every enum in a schema, in declaration order
+func ParseEnums(schema string) map[string][]string {
+	lines := strings.Split(schema, "\n")
+	enums := make(map[string][]string)
+
+	for _, block := range FindSchemaBlocks(lines) {
+		if block.Kind != "enum" {
+			continue
+		}
+		values := []string{}
+		for i := block.Line + 1; i < block.EndLine; i++ {
+			trimmed := strings.TrimSpace(lines[i])
+			if trimmed == "" || strings.HasPrefix(trimmed, "//") || strings.HasPrefix(trimmed, "@@") {
+				continue
+			}
+			values = append(values, strings.Fields(trimmed)[0])
+		}
+		enums[block.Name] = values
+	}
+
+	return enums
+}
+
+// DiffEnums compares the enums of two schemas and returns those that lost or
+// gained values or were dropped, sorted by name. New enums are left out since
+// creating a type has none of the caveats of altering one.
+func DiffEnums(oldSchema, currentSchema string) []EnumChange {
+	oldEnums := ParseEnums(oldSchema)
+	currentEnums := ParseEnums(currentSchema)
+
+	var changes []EnumChange
+	for name, oldValues := range oldEnums {
+		currentValues, ok := currentEnums[name]
+		if !ok {
+			changes = append(changes, EnumChange{Name: name, Removed: oldValues, Dropped: true})
+			continue
+		}
+
+		change := EnumChange{
+			Name:    name,
+			Added:   valuesNotIn(currentValues, oldValues),
+			Removed: valuesNotIn(oldValues, currentValues),
+		}
+		if len(change.Added) > 0 || len(change.Removed) > 0 {
+			changes = append(changes, change)
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes
+}
+
+// valuesNotIn returns the values of a that are missing from b, keeping a's order
+func valuesNotIn(a, b []string) []string {
+	in := make(map[string]bool, len(b))
+	for _, v := range b {
+		in[v] = true
+	}
+	var missing []string
+	for _, v := range a {
+		if !in[v] {
+			missing = append(missing, v)
+		}
+	}
+	return missing
+}
+
+// FindEnumUsages lists the model fields of a schema typed with the enum
+func FindEnumUsages(schema, enum string) []EnumUsage {
+	lines := strings.Split(schema, "\n")
+
+	var usages []EnumUsage
+	for _, block := range FindSchemaBlocks(lines) {
+		if block.Kind != "model" && block.Kind != "view" {
+			continue
+		}
+		table := block.DatabaseName(lines)
+
+		for i := block.Line + 1; i < block.EndLine; i++ {
+			if SchemaFieldType(lines[i]) != enum {
+				continue
+			}
+			field := strings.Fields(lines[i])[0]
+			column := field
+			if match := fieldMapRegex.FindStringSubmatch(lines[i]); match != nil {
+				column = match[1]
+			}
+			usages = append(usages, EnumUsage{Model: block.Name, Table: table, Field: field, Column: column})
+		}
+	}
+
+	return usages
+}
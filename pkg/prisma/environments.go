@@ -0,0 +1,46 @@
+package prisma
+
+// EnvironmentStatus is the migration state of one named database
+type EnvironmentStatus struct {
+	Name      string
+	Protected bool
+	Applied   map[string]bool // Successfully applied migrations
+	Failed    map[string]bool // Migrations that started but did not finish
+	Err       error           // Connection or query error (Applied and Failed are empty)
+}
+
+// NewEnvironmentStatus builds an environment status from its _prisma_migrations rows
+func NewEnvironmentStatus(name string, protected bool, rows []DBMigration) *EnvironmentStatus {
+	status := &EnvironmentStatus{
+		Name:      name,
+		Protected: protected,
+		Applied:   make(map[string]bool),
+		Failed:    make(map[string]bool),
+	}
+
+	for _, row := range rows {
+		switch {
+		case row.FinishedAt != nil:
+			status.Applied[row.Name] = true
+		case row.RolledBackAt == nil:
+			status.Failed[row.Name] = true
+		}
+	}
+
+	return status
+}
+
+// Missing returns the local migrations that are not applied in the environment, oldest first
+func (s *EnvironmentStatus) Missing(local []Migration) []string {
+	if s.Err != nil {
+		return nil
+	}
+
+	var missing []string
+	for _, mig := range local {
+		if !s.Applied[mig.Name] {
+			missing = append(missing, mig.Name)
+		}
+	}
+	return missing
+}
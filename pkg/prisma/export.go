@@ -0,0 +1,71 @@
+package prisma
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ExportOpts configures ExportSQL
+type ExportOpts struct {
+	Provider    string    // Datasource provider, for the transaction statements
+	Transaction bool      // Wrap the whole file in BEGIN/COMMIT
+	Time        time.Time // Export time, written in the header
+}
+
+// ExportSQL concatenates the migration.sql files of migrations, in the given
+// order, into one script for applying them by hand. Each migration starts with
+// a marker comment naming it; the header lists the migrate resolve commands
+// that record them as applied afterwards.
+func ExportSQL(migrations []Migration, opts ExportOpts) (string, error) {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "-- %d pending migration(s), exported by lazyprisma at %s\n", len(migrations), opts.Time.Format(time.RFC3339))
+	b.WriteString("--\n")
+	b.WriteString("-- Once this script has run, record the migrations as applied:\n")
+	for _, mig := range migrations {
+		fmt.Fprintf(&b, "--   npx prisma migrate resolve --applied %s\n", mig.Name)
+	}
+	b.WriteString("\n")
+
+	if opts.Transaction {
+		b.WriteString(beginTransaction(opts.Provider) + "\n\n")
+	}
+
+	for i, mig := range migrations {
+		sql, err := os.ReadFile(filepath.Join(mig.Path, "migration.sql"))
+		if err != nil && !os.IsNotExist(err) {
+			return "", fmt.Errorf("failed to read %s: %w", mig.Name, err)
+		}
+
+		b.WriteString("-- ==================================================\n")
+		fmt.Fprintf(&b, "-- Migration %d/%d: %s\n", i+1, len(migrations), mig.Name)
+		b.WriteString("-- ==================================================\n")
+		if body := strings.TrimSpace(string(sql)); body != "" {
+			b.WriteString(body + "\n")
+		} else {
+			b.WriteString("-- (empty migration)\n")
+		}
+		fmt.Fprintf(&b, "-- End of migration %s\n\n", mig.Name)
+	}
+
+	if opts.Transaction {
+		b.WriteString("COMMIT;\n")
+	}
+
+	return b.String(), nil
+}
+
+// beginTransaction returns the statement that starts a transaction on provider
+func beginTransaction(provider string) string {
+	switch provider {
+	case "sqlserver":
+		return "BEGIN TRANSACTION;"
+	case "mysql":
+		return "START TRANSACTION;"
+	default:
+		return "BEGIN;"
+	}
+}
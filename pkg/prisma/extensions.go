@@ -0,0 +1,195 @@
+package prisma
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ExtensionUse is a PostgreSQL extension a schema or migration depends on
+type ExtensionUse struct {
+	Name   string // Extension name, e.g. "uuid-ossp"
+	Reason string // What needs it, e.g. "dbgenerated(uuid_generate_v4())"
+}
+
+// KnownExtensions are the extensions the Workspace panel reports on
+var KnownExtensions = []string{"citext", "postgis", "uuid-ossp"}
+
+// extensionRule recognizes a schema feature or SQL that needs an extension
+type extensionRule struct {
+	extension string
+	schema    *regexp.Regexp
+	sql       *regexp.Regexp
+}
+
+var extensionRules = []extensionRule{
+	{"citext", regexp.MustCompile(`@db\.Citext\b`), regexp.MustCompile(`(?i)\bCITEXT\b`)},
+	{"uuid-ossp", regexp.MustCompile(`uuid_generate_v[1-5]\w*\(\)`), regexp.MustCompile(`(?i)\buuid_generate_v[1-5]\w*\s*\(`)},
+	{"postgis", regexp.MustCompile(`Unsupported\(\s*"(?:geometry|geography)[^"]*"\s*\)`), regexp.MustCompile(`(?i)\b(?:GEOMETRY|GEOGRAPHY)\b|\bST_\w+\s*\(`)},
+}
+
+// createExtensionRegex matches CREATE EXTENSION [IF NOT EXISTS] name
+var createExtensionRegex = regexp.MustCompile(`(?i)^CREATE EXTENSION (?:IF NOT EXISTS )?"?([A-Za-z0-9_-]+)"?`)
+
+// datasourceExtensionsRegex matches the datasource's `extensions = [...]` list
+// (postgresqlExtensions preview feature)
+var datasourceExtensionsRegex = regexp.MustCompile(`(?m)^\s*extensions\s*=\s*\[([^\]]*)\]`)
+
+// extensionEntryRegex matches one entry of that list: name, or name(map: "db_name", ...)
+var extensionEntryRegex = regexp.MustCompile(`([A-Za-z_][A-Za-z0-9_]*)\s*(?:\(([^)]*)\))?`)
+
+// extensionMapRegex matches map: "db_name" inside an entry's arguments
+var extensionMapRegex = regexp.MustCompile(`map\s*:\s*"([^"]+)"`)
+
+// RequiredExtensions returns the extensions schema declares in its datasource
+// or uses through native types and database functions, sorted by name
+func RequiredExtensions(schema string) []ExtensionUse {
+	schema = stripSchemaComments(schema)
+	seen := make(map[string]bool)
+	var uses []ExtensionUse
+
+	if match := datasourceExtensionsRegex.FindStringSubmatch(schema); match != nil {
+		for _, entry := range extensionEntryRegex.FindAllStringSubmatch(match[1], -1) {
+			name := entry[1]
+			if m := extensionMapRegex.FindStringSubmatch(entry[2]); m != nil {
+				name = m[1]
+			}
+			if !seen[name] {
+				seen[name] = true
+				uses = append(uses, ExtensionUse{Name: name, Reason: "extensions = [" + entry[1] + "]"})
+			}
+		}
+	}
+
+	for _, rule := range extensionRules {
+		if seen[rule.extension] {
+			continue
+		}
+		if feature := rule.schema.FindString(schema); feature != "" {
+			seen[rule.extension] = true
+			uses = append(uses, ExtensionUse{Name: rule.extension, Reason: feature})
+		}
+	}
+
+	sort.Slice(uses, func(i, j int) bool { return uses[i].Name < uses[j].Name })
+	return uses
+}
+
+// ExtensionsUsedBySQL returns the extensions a migration script relies on
+// without creating them itself, sorted by name. Reason is the statement that
+// needs the extension.
+func ExtensionsUsedBySQL(sql string) []ExtensionUse {
+	stmts := normalizedStatements(sql)
+
+	created := make(map[string]bool)
+	for _, name := range CreatedExtensions(sql) {
+		created[name] = true
+	}
+
+	var uses []ExtensionUse
+	for _, rule := range extensionRules {
+		if created[rule.extension] {
+			continue
+		}
+		for _, stmt := range stmts {
+			if rule.sql.MatchString(stmt) {
+				uses = append(uses, ExtensionUse{Name: rule.extension, Reason: stmt})
+				break
+			}
+		}
+	}
+
+	sort.Slice(uses, func(i, j int) bool { return uses[i].Name < uses[j].Name })
+	return uses
+}
+
+// CreatedExtensions returns the extensions a migration script creates
+func CreatedExtensions(sql string) []string {
+	var names []string
+	for _, stmt := range normalizedStatements(sql) {
+		if match := createExtensionRegex.FindStringSubmatch(stmt); match != nil {
+			names = append(names, strings.ToLower(match[1]))
+		}
+	}
+	return names
+}
+
+// MigrationExtensionUse is an extension a pending migration needs
+type MigrationExtensionUse struct {
+	Migration Migration
+	ExtensionUse
+}
+
+// MissingMigrationExtensions returns, for migrations in the order they are
+// applied, the extensions each one uses that are neither installed nor
+// created by it or an earlier one of them
+func MissingMigrationExtensions(migrations []Migration, installed []string) []MigrationExtensionUse {
+	available := make(map[string]bool)
+	for _, name := range installed {
+		available[strings.ToLower(name)] = true
+	}
+
+	var missing []MigrationExtensionUse
+	for _, mig := range migrations {
+		content, err := os.ReadFile(filepath.Join(mig.Path, "migration.sql"))
+		if err != nil {
+			continue
+		}
+		sql := string(content)
+
+		for _, use := range ExtensionsUsedBySQL(sql) {
+			if !available[use.Name] {
+				// Reported once, for the first migration that needs it
+				available[use.Name] = true
+				missing = append(missing, MigrationExtensionUse{Migration: mig, ExtensionUse: use})
+			}
+		}
+		for _, name := range CreatedExtensions(sql) {
+			available[name] = true
+		}
+	}
+	return missing
+}
+
+// CreateExtensionsSQL returns the content of a migration that creates the
+// given extensions
+func CreateExtensionsSQL(names []string) string {
+	var b strings.Builder
+	b.WriteString("-- Creates the PostgreSQL extensions later migrations rely on.\n")
+	b.WriteString("-- Created with the lazyprisma extension helper.\n\n")
+	for _, name := range names {
+		b.WriteString(`CREATE EXTENSION IF NOT EXISTS "` + name + "\";\n")
+	}
+	return b.String()
+}
+
+// MissingExtensions returns the uses whose extension is not installed
+func MissingExtensions(uses []ExtensionUse, installed []string) []ExtensionUse {
+	var missing []ExtensionUse
+	for _, use := range uses {
+		found := false
+		for _, name := range installed {
+			if strings.EqualFold(name, use.Name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, use)
+		}
+	}
+	return missing
+}
+
+// stripSchemaComments removes // comments, so commented-out fields don't count
+func stripSchemaComments(schema string) string {
+	lines := strings.Split(schema, "\n")
+	for i, line := range lines {
+		if idx := strings.Index(line, "//"); idx >= 0 {
+			lines[i] = line[:idx]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -19,7 +19,7 @@ type GenerateResult struct {
 // Generate runs `npx prisma generate` to generate Prisma Client
 func Generate(projectDir string, opts *GenerateOptions) (*GenerateResult, error) {
 	// Build command args
-	args := []string{"prisma", "generate"}
+	args := []string{"generate"}
 
 	if opts != nil {
 		if opts.Schema != "" {
@@ -39,9 +39,9 @@ func Generate(projectDir string, opts *GenerateOptions) (*GenerateResult, error)
 		}
 	}
 
-	// Execute command (prepend "npx" to args)
-	cmdArgs := append([]string{"npx"}, args...)
-	cmd := cmdBuilder.New(cmdArgs...).WithWorkingDir(projectDir)
+	// Execute command (prepend "npx prisma" or the local binary to args)
+	cmdArgs := Command(projectDir, args...)
+	cmd := cmdBuilder.New(cmdArgs...).WithWorkingDir(projectDir).WithEnv(EngineOverrideEnv(projectDir)...)
 	result, err := cmd.RunWithOutput()
 
 	generateResult := &GenerateResult{
@@ -72,7 +72,7 @@ type GenerateCallbacks struct {
 // GenerateAsync runs `npx prisma generate` asynchronously with real-time output
 func GenerateAsync(projectDir string, opts *GenerateOptions, callbacks *GenerateCallbacks) error {
 	// Build command args
-	args := []string{"prisma", "generate"}
+	args := []string{"generate"}
 
 	if opts != nil {
 		if opts.Schema != "" {
@@ -92,10 +92,11 @@ func GenerateAsync(projectDir string, opts *GenerateOptions, callbacks *Generate
 		}
 	}
 
-	// Build command with callbacks (prepend "npx" to args)
-	cmdArgs := append([]string{"npx"}, args...)
+	// Build command with callbacks (prepend "npx prisma" or the local binary to args)
+	cmdArgs := Command(projectDir, args...)
 	cmd := cmdBuilder.New(cmdArgs...).
 		WithWorkingDir(projectDir).
+		WithEnv(EngineOverrideEnv(projectDir)...).
 		StreamOutput()
 
 	if callbacks != nil {
@@ -0,0 +1,62 @@
+package prisma
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Generator is a generator block in schema.prisma
+type Generator struct {
+	Name     string // Block name, passed to prisma generate --generator
+	Provider string // e.g. "prisma-client-js", "zod-prisma-types"
+}
+
+var (
+	generatorBlockRegex    = regexp.MustCompile(`^generator\s+(\w+)\s*\{`)
+	generatorProviderRegex = regexp.MustCompile(`provider\s*=\s*"([^"]+)"`)
+)
+
+// GetGenerators returns the generator blocks declared in schema.prisma, in order
+func GetGenerators(projectDir string) ([]Generator, error) {
+	schemaPath := SchemaPath(projectDir)
+
+	file, err := os.Open(schemaPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open schema: %w", err)
+	}
+	defer file.Close()
+
+	var generators []Generator
+	var current *Generator
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if match := generatorBlockRegex.FindStringSubmatch(line); match != nil {
+			generators = append(generators, Generator{Name: match[1]})
+			current = &generators[len(generators)-1]
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+		if line == "}" {
+			current = nil
+			continue
+		}
+		if match := generatorProviderRegex.FindStringSubmatch(line); match != nil && current.Provider == "" {
+			current.Provider = match[1]
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read schema: %w", err)
+	}
+
+	return generators, nil
+}
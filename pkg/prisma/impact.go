@@ -0,0 +1,190 @@
+package prisma
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// TableOp is the kind of change a migration statement makes to a table
+type TableOp string
+
+const (
+	TableOpCreate TableOp = "CREATE"
+	TableOpAlter  TableOp = "ALTER"
+	TableOpDrop   TableOp = "DROP"
+	TableOpRename TableOp = "RENAME"
+	TableOpIndex  TableOp = "INDEX"
+)
+
+// TableChange is a single statement in a migration that touched a table
+type TableChange struct {
+	Migration string  // Migration name (empty when parsed outside an index)
+	Table     string  // Table name as written in the SQL (unquoted, without schema)
+	Op        TableOp // Kind of change
+	Statement string  // The SQL statement (comments stripped, whitespace collapsed)
+}
+
+// identPattern matches a (possibly quoted, possibly schema-qualified) SQL identifier
+const identPattern = "(?:\"[^\"]+\"|`[^`]+`|\\[[^\\]]+\\]|[\\w$]+)(?:\\.(?:\"[^\"]+\"|`[^`]+`|\\[[^\\]]+\\]|[\\w$]+))?"
+
+var (
+	sqlLineCommentRegex  = regexp.MustCompile(`--[^\n]*`)
+	sqlBlockCommentRegex = regexp.MustCompile(`(?s)/\*.*?\*/`)
+	sqlWhitespaceRegex   = regexp.MustCompile(`\s+`)
+
+	tableStmtRegex   = regexp.MustCompile(`(?i)^(CREATE|ALTER|DROP)\s+(?:TEMPORARY\s+)?TABLE\s+(?:IF\s+(?:NOT\s+)?EXISTS\s+)?(?:ONLY\s+)?(` + identPattern + `)`)
+	renameToRegex    = regexp.MustCompile(`(?i)\bRENAME\s+TO\s+(` + identPattern + `)`)
+	createIndexRegex = regexp.MustCompile(`(?i)^CREATE\s+(?:UNIQUE\s+)?INDEX\s+.*?\bON\s+(` + identPattern + `)`)
+	dropIndexOnRegex = regexp.MustCompile(`(?i)^DROP\s+INDEX\s+.*?\bON\s+(` + identPattern + `)`)
+)
+
+// ParseTableChanges extracts the tables touched by CREATE/ALTER/DROP statements in a migration script
+func ParseTableChanges(sql string) []TableChange {
+	var changes []TableChange
+
+	for _, stmt := range normalizedStatements(sql) {
+		if m := tableStmtRegex.FindStringSubmatch(stmt); m != nil {
+			table := unquoteIdent(m[2])
+			op := TableOp(strings.ToUpper(m[1]))
+
+			if op == TableOpAlter {
+				if r := renameToRegex.FindStringSubmatch(stmt); r != nil {
+					newName := unquoteIdent(r[1])
+					changes = append(changes,
+						TableChange{Table: table, Op: TableOpRename, Statement: stmt},
+						TableChange{Table: newName, Op: TableOpRename, Statement: stmt},
+					)
+					continue
+				}
+			}
+
+			changes = append(changes, TableChange{Table: table, Op: op, Statement: stmt})
+			continue
+		}
+
+		if m := createIndexRegex.FindStringSubmatch(stmt); m != nil {
+			changes = append(changes, TableChange{Table: unquoteIdent(m[1]), Op: TableOpIndex, Statement: stmt})
+			continue
+		}
+
+		if m := dropIndexOnRegex.FindStringSubmatch(stmt); m != nil {
+			changes = append(changes, TableChange{Table: unquoteIdent(m[1]), Op: TableOpIndex, Statement: stmt})
+		}
+	}
+
+	return changes
+}
+
+// CreatedTables returns the tables a migration script creates, in statement order
+func CreatedTables(sql string) []string {
+	var tables []string
+	for _, change := range ParseTableChanges(sql) {
+		if change.Op == TableOpCreate {
+			tables = append(tables, change.Table)
+		}
+	}
+	return tables
+}
+
+// unquoteIdent strips quoting and schema qualification from an identifier
+func unquoteIdent(ident string) string {
+	if idx := strings.LastIndex(ident, "."); idx != -1 {
+		// Only split on a dot outside of quotes (the last part is the table)
+		tail := ident[idx+1:]
+		if len(tail) > 0 && strings.Count(ident[:idx], "\"")%2 == 0 && strings.Count(ident[:idx], "`")%2 == 0 {
+			ident = tail
+		}
+	}
+	return strings.Trim(ident, "\"`[]")
+}
+
+// ImpactIndex maps tables to the migrations that touched them, in chronological order
+type ImpactIndex struct {
+	names   map[string]string        // lower-case key -> display name
+	history map[string][]TableChange // lower-case key -> changes in migration order
+	touched map[string][]string      // migration name -> display names of tables it touched
+}
+
+// BuildImpactIndex parses every local migration's SQL and builds an ImpactIndex.
+// Migrations unchanged since the last call are not parsed again.
+// Migrations are expected in chronological order (as returned by GetLocalMigrations).
+func BuildImpactIndex(migrations []Migration) *ImpactIndex {
+	idx := &ImpactIndex{
+		names:   make(map[string]string),
+		history: make(map[string][]TableChange),
+		touched: make(map[string][]string),
+	}
+
+	for _, mig := range migrations {
+		if mig.Path == "" || mig.IsEmpty {
+			continue
+		}
+		changes, err := migrationCache.tableChanges(mig.Path, mig.stamp)
+		if err != nil {
+			continue
+		}
+
+		seen := make(map[string]bool)
+		for _, change := range changes {
+			change.Migration = mig.Name
+			key := strings.ToLower(change.Table)
+			if _, ok := idx.names[key]; !ok {
+				idx.names[key] = change.Table
+			}
+			idx.history[key] = append(idx.history[key], change)
+
+			if !seen[key] {
+				seen[key] = true
+				idx.touched[mig.Name] = append(idx.touched[mig.Name], idx.names[key])
+			}
+		}
+	}
+
+	return idx
+}
+
+// Tables returns every table found in the migrations, sorted by name
+func (idx *ImpactIndex) Tables() []string {
+	tables := make([]string, 0, len(idx.names))
+	for _, name := range idx.names {
+		tables = append(tables, name)
+	}
+	sort.Slice(tables, func(i, j int) bool {
+		return strings.ToLower(tables[i]) < strings.ToLower(tables[j])
+	})
+	return tables
+}
+
+// History returns the chronological list of changes to a table (case-insensitive)
+func (idx *ImpactIndex) History(table string) []TableChange {
+	return idx.history[strings.ToLower(table)]
+}
+
+// TablesTouchedBy returns the tables a migration changed, in statement order
+func (idx *ImpactIndex) TablesTouchedBy(migration string) []string {
+	return idx.touched[migration]
+}
+
+// identReferenceRegex matches an identifier as a whole word or quoted in any SQL dialect (case-insensitive)
+func identReferenceRegex(name string) *regexp.Regexp {
+	quoted := regexp.QuoteMeta(name)
+	return regexp.MustCompile(`(?i)(?:"` + quoted + `"|` + "`" + quoted + "`" + `|\[` + quoted + `\]|\b` + quoted + `\b)`)
+}
+
+// ColumnHistory returns the changes to a table whose statements define or alter the given column.
+// Index statements are skipped since they only reference columns.
+func (idx *ImpactIndex) ColumnHistory(table, column string) []TableChange {
+	columnRegex := identReferenceRegex(column)
+
+	var changes []TableChange
+	for _, change := range idx.History(table) {
+		if change.Op == TableOpIndex {
+			continue
+		}
+		if columnRegex.MatchString(change.Statement) {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
@@ -0,0 +1,24 @@
+package prisma
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IntrospectSchema returns the schema `prisma db pull` would write for the
+// project's database: its datasource and generators with the models, enums
+// and views read from the database. Nothing is written (--print).
+func IntrospectSchema(projectDir string) (string, error) {
+	args := Command(projectDir, "db", "pull", "--print")
+
+	result, err := cmdBuilder.New(args...).WithWorkingDir(projectDir).WithEnv(EngineOverrideEnv(projectDir)...).RunWithOutput()
+	if err != nil || result.ExitCode != 0 {
+		msg := strings.TrimSpace(result.Stderr)
+		if msg == "" && err != nil {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("prisma db pull failed: %s", msg)
+	}
+
+	return result.Stdout, nil
+}
@@ -0,0 +1,95 @@
+package prisma
+
+import (
+	"regexp"
+)
+
+// LockTip is advice for avoiding a statement's lock
+type LockTip string
+
+const (
+	LockTipNone          LockTip = ""
+	LockTipConcurrently  LockTip = "concurrently"   // Build / drop the index CONCURRENTLY
+	LockTipNotValid      LockTip = "not-valid"      // Add the constraint NOT VALID, VALIDATE it later
+	LockTipCheckNotNull  LockTip = "check-not-null" // Validate a CHECK (col IS NOT NULL) first
+	LockTipMetadataLock  LockTip = "metadata-lock"  // Waits behind long transactions on the table
+	LockTipBatchRows     LockTip = "batch-rows"     // Update / delete in batches
+	LockTipNewColumnCopy LockTip = "new-column"     // Add a new column and backfill instead of a rewrite
+)
+
+// LockInfo is a coarse estimate of the lock a statement takes on the table it changes
+type LockInfo struct {
+	Lock         string // Lock mode, e.g. "ACCESS EXCLUSIVE"
+	BlocksReads  bool
+	BlocksWrites bool
+	Rewrite      bool // Rewrites or scans the whole table, so the lock is held for a while
+	Tip          LockTip
+}
+
+// lockRule maps a statement pattern to the lock it takes on one database
+type lockRule struct {
+	provider string
+	regex    *regexp.Regexp
+	info     LockInfo
+}
+
+// lockRules are checked in order against the normalized statement; the first match wins.
+// Based on the PostgreSQL lock documentation and MySQL's InnoDB online DDL tables.
+var lockRules = []lockRule{
+	// PostgreSQL
+	{"postgresql", regexp.MustCompile(`(?i)^CREATE\s+(?:UNIQUE\s+)?INDEX\s+CONCURRENTLY\b`),
+		LockInfo{Lock: "SHARE UPDATE EXCLUSIVE"}},
+	{"postgresql", regexp.MustCompile(`(?i)^CREATE\s+(?:UNIQUE\s+)?INDEX\b`),
+		LockInfo{Lock: "SHARE", BlocksWrites: true, Rewrite: true, Tip: LockTipConcurrently}},
+	{"postgresql", regexp.MustCompile(`(?i)^DROP\s+INDEX\s+CONCURRENTLY\b`),
+		LockInfo{Lock: "SHARE UPDATE EXCLUSIVE"}},
+	{"postgresql", regexp.MustCompile(`(?i)^DROP\s+INDEX\b`),
+		LockInfo{Lock: "ACCESS EXCLUSIVE", BlocksReads: true, BlocksWrites: true, Tip: LockTipConcurrently}},
+	{"postgresql", regexp.MustCompile(`(?i)^ALTER\s+TABLE\b.*\bVALIDATE\s+CONSTRAINT\b`),
+		LockInfo{Lock: "SHARE UPDATE EXCLUSIVE", Rewrite: true}},
+	{"postgresql", regexp.MustCompile(`(?i)^ALTER\s+TABLE\b.*\bADD\s+(?:CONSTRAINT\s+\S+\s+)?(?:FOREIGN\s+KEY|CHECK)\b.*\bNOT\s+VALID\b`),
+		LockInfo{Lock: "SHARE ROW EXCLUSIVE", BlocksWrites: true}},
+	{"postgresql", regexp.MustCompile(`(?i)^ALTER\s+TABLE\b.*\bADD\s+(?:CONSTRAINT\s+\S+\s+)?FOREIGN\s+KEY\b`),
+		LockInfo{Lock: "SHARE ROW EXCLUSIVE", BlocksWrites: true, Rewrite: true, Tip: LockTipNotValid}},
+	{"postgresql", regexp.MustCompile(`(?i)^ALTER\s+TABLE\b.*\bALTER\s+(?:COLUMN\s+)?\S+\s+(?:SET\s+DATA\s+)?TYPE\b`),
+		LockInfo{Lock: "ACCESS EXCLUSIVE", BlocksReads: true, BlocksWrites: true, Rewrite: true, Tip: LockTipNewColumnCopy}},
+	{"postgresql", regexp.MustCompile(`(?i)^ALTER\s+TABLE\b.*\bSET\s+NOT\s+NULL\b`),
+		LockInfo{Lock: "ACCESS EXCLUSIVE", BlocksReads: true, BlocksWrites: true, Rewrite: true, Tip: LockTipCheckNotNull}},
+	{"postgresql", regexp.MustCompile(`(?i)^ALTER\s+TABLE\b.*\bADD\s+(?:CONSTRAINT\s+\S+\s+)?(?:UNIQUE|PRIMARY\s+KEY)\b`),
+		LockInfo{Lock: "ACCESS EXCLUSIVE", BlocksReads: true, BlocksWrites: true, Rewrite: true, Tip: LockTipConcurrently}},
+	{"postgresql", regexp.MustCompile(`(?i)^ALTER\s+TABLE\b.*\bADD\s+(?:CONSTRAINT\s+\S+\s+)?CHECK\b`),
+		LockInfo{Lock: "ACCESS EXCLUSIVE", BlocksReads: true, BlocksWrites: true, Rewrite: true, Tip: LockTipNotValid}},
+	{"postgresql", regexp.MustCompile(`(?i)^(?:ALTER|DROP)\s+TABLE\b|^TRUNCATE\b`),
+		LockInfo{Lock: "ACCESS EXCLUSIVE", BlocksReads: true, BlocksWrites: true}},
+	{"postgresql", regexp.MustCompile(`(?i)^(?:UPDATE|DELETE)\b`),
+		LockInfo{Lock: "ROW EXCLUSIVE", Rewrite: true, Tip: LockTipBatchRows}},
+
+	// MySQL (InnoDB): every ALTER also takes a brief exclusive metadata lock
+	{"mysql", regexp.MustCompile(`(?i)^(?:CREATE\s+(?:UNIQUE\s+)?INDEX\b|ALTER\s+TABLE\b.*\bADD\s+(?:UNIQUE\s+)?(?:INDEX|KEY)\b)`),
+		LockInfo{Lock: "LOCK=NONE", Rewrite: true, Tip: LockTipMetadataLock}},
+	{"mysql", regexp.MustCompile(`(?i)^ALTER\s+TABLE\b.*\b(?:MODIFY|CHANGE)\b`),
+		LockInfo{Lock: "LOCK=SHARED (ALGORITHM=COPY)", BlocksWrites: true, Rewrite: true, Tip: LockTipNewColumnCopy}},
+	{"mysql", regexp.MustCompile(`(?i)^ALTER\s+TABLE\b.*\bADD\s+(?:CONSTRAINT\s+\S+\s+)?FOREIGN\s+KEY\b`),
+		LockInfo{Lock: "LOCK=SHARED (ALGORITHM=COPY)", BlocksWrites: true, Rewrite: true, Tip: LockTipMetadataLock}},
+	{"mysql", regexp.MustCompile(`(?i)^ALTER\s+TABLE\b.*\b(?:DROP\s+COLUMN|ADD\s+PRIMARY\s+KEY|DROP\s+PRIMARY\s+KEY)\b`),
+		LockInfo{Lock: "LOCK=NONE (table rebuild)", Rewrite: true, Tip: LockTipMetadataLock}},
+	{"mysql", regexp.MustCompile(`(?i)^ALTER\s+TABLE\b`),
+		LockInfo{Lock: "LOCK=NONE", Tip: LockTipMetadataLock}},
+	{"mysql", regexp.MustCompile(`(?i)^(?:DROP|TRUNCATE)\s+TABLE\b|^TRUNCATE\b|^RENAME\s+TABLE\b`),
+		LockInfo{Lock: "exclusive metadata lock", BlocksReads: true, BlocksWrites: true}},
+	{"mysql", regexp.MustCompile(`(?i)^(?:UPDATE|DELETE)\b`),
+		LockInfo{Lock: "row locks", Rewrite: true, Tip: LockTipBatchRows}},
+}
+
+// EstimateLock returns the lock a statement takes on PostgreSQL or MySQL.
+// ok is false for other databases and for statements that don't lock an
+// existing table (e.g. CREATE TABLE).
+func EstimateLock(provider, stmt string) (LockInfo, bool) {
+	normalized := NormalizeStatement(stmt)
+	for _, rule := range lockRules {
+		if rule.provider == provider && rule.regex.MatchString(normalized) {
+			return rule.info, true
+		}
+	}
+	return LockInfo{}, false
+}
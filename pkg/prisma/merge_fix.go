@@ -0,0 +1,164 @@
+package prisma
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// migrationTimestampLayout is the timestamp Prisma prefixes migration folders with
+const migrationTimestampLayout = "20060102150405"
+
+// MergeFixReason is what a git merge left wrong with a migration
+type MergeFixReason string
+
+const (
+	MergeDuplicateTimestamp MergeFixReason = "duplicate-timestamp" // Another migration has the same timestamp
+	MergeDuplicateSQL       MergeFixReason = "duplicate-sql"       // Another migration has the same SQL
+	MergeOutOfOrder         MergeFixReason = "out-of-order"        // Sorts before a migration that is already applied
+)
+
+// MergeFix is a change to a pending migration's folder: a rename to a new
+// timestamp, or its removal when DuplicateOf has the same SQL
+type MergeFix struct {
+	Reason      MergeFixReason
+	Name        string // Migration folder
+	NewName     string // Folder after the rename ("" = remove it)
+	DuplicateOf string // Migration with the same SQL or timestamp
+}
+
+// MergeFixPlan is what FixMerge would change, in the order it applies it
+type MergeFixPlan struct {
+	Fixes []MergeFix
+}
+
+// PlanMergeFix works out how to repair the migrations directory after a git
+// merge brought in migrations from another branch. Only pending migrations
+// are touched, since the database records applied ones by name:
+//   - one whose SQL is the same as another's (both branches added the same
+//     change) is removed, keeping the applied one or else the older one
+//   - the others are kept in the order their names sort in, and each one that
+//     shares a timestamp or sorts before an applied migration gets a new
+//     timestamp just after the migration before it
+//
+// A fresh database then applies them in the order this one will. Folders
+// without a timestamp prefix are left alone. Returns nil if nothing is wrong.
+func PlanMergeFix(category MigrationCategory) *MergeFixPlan {
+	pending := make(map[string]bool, len(category.Pending))
+	for _, mig := range category.Pending {
+		pending[mig.Name] = true
+	}
+	local := append([]Migration(nil), category.Local...)
+	sort.Slice(local, func(i, j int) bool { return local[i].Name < local[j].Name })
+
+	plan := &MergeFixPlan{}
+
+	// Same SQL added on both branches
+	removed := make(map[string]bool)
+	kept := make(map[string]string) // Checksum -> migration kept with it
+	for _, mig := range local {
+		if mig.IsEmpty || mig.Checksum == "" || pending[mig.Name] {
+			continue
+		}
+		if _, ok := kept[mig.Checksum]; !ok {
+			kept[mig.Checksum] = mig.Name
+		}
+	}
+	for _, mig := range local {
+		if mig.IsEmpty || mig.Checksum == "" || !pending[mig.Name] {
+			continue
+		}
+		if original, ok := kept[mig.Checksum]; ok {
+			plan.Fixes = append(plan.Fixes, MergeFix{Reason: MergeDuplicateSQL, Name: mig.Name, DuplicateOf: original})
+			removed[mig.Name] = true
+			continue
+		}
+		kept[mig.Checksum] = mig.Name
+	}
+
+	// Timestamps shared by more than one remaining migration
+	byTimestamp := make(map[string][]string)
+	for _, mig := range local {
+		if ts, _, ok := splitMigrationName(mig.Name); ok && !removed[mig.Name] {
+			byTimestamp[ts.Format(migrationTimestampLayout)] = append(byTimestamp[ts.Format(migrationTimestampLayout)], mig.Name)
+		}
+	}
+
+	// New timestamps, in order, after the newest applied migration
+	var last time.Time
+	for _, mig := range local {
+		if !pending[mig.Name] {
+			if ts, _, ok := splitMigrationName(mig.Name); ok && ts.After(last) {
+				last = ts
+			}
+		}
+	}
+	for _, mig := range local {
+		ts, suffix, ok := splitMigrationName(mig.Name)
+		if !ok || !pending[mig.Name] || removed[mig.Name] {
+			continue
+		}
+		if ts.After(last) {
+			last = ts
+			continue
+		}
+
+		last = last.Add(time.Second)
+		fix := MergeFix{
+			Reason:  MergeOutOfOrder,
+			Name:    mig.Name,
+			NewName: last.Format(migrationTimestampLayout) + "_" + suffix,
+		}
+		for _, other := range byTimestamp[ts.Format(migrationTimestampLayout)] {
+			if other != mig.Name {
+				fix.Reason, fix.DuplicateOf = MergeDuplicateTimestamp, other
+				break
+			}
+		}
+		plan.Fixes = append(plan.Fixes, fix)
+	}
+
+	if len(plan.Fixes) == 0 {
+		return nil
+	}
+	return plan
+}
+
+// splitMigrationName splits a migration folder name into its timestamp and
+// the name after it, e.g. 20240101090000_init
+func splitMigrationName(name string) (time.Time, string, bool) {
+	if len(name) < len(migrationTimestampLayout)+2 || name[len(migrationTimestampLayout)] != '_' {
+		return time.Time{}, "", false
+	}
+	ts, err := time.Parse(migrationTimestampLayout, name[:len(migrationTimestampLayout)])
+	if err != nil {
+		return time.Time{}, "", false
+	}
+	return ts, name[len(migrationTimestampLayout)+1:], true
+}
+
+// FixMerge applies plan to the project's migrations directory. It stops at the
+// first fix that fails, returning how many were applied.
+func FixMerge(projectDir string, plan *MergeFixPlan) (int, error) {
+	dir := MigrationsDir(projectDir)
+	for i, fix := range plan.Fixes {
+		path := filepath.Join(dir, fix.Name)
+		var err error
+		if fix.NewName == "" {
+			err = os.RemoveAll(path)
+		} else {
+			target := filepath.Join(dir, fix.NewName)
+			if _, statErr := os.Stat(target); statErr == nil {
+				err = fmt.Errorf("%s already exists", fix.NewName)
+			} else {
+				err = os.Rename(path, target)
+			}
+		}
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(plan.Fixes), nil
+}
@@ -0,0 +1,93 @@
+package prisma
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Resolve actions accepted by prisma migrate resolve
+const (
+	ResolveApplied    = "applied"
+	ResolveRolledBack = "rolled-back"
+)
+
+var (
+	migrateErrorCodeRe = regexp.MustCompile(`\b(P3\d{3})\b`)
+
+	// P3018: "Migration name: 20240101000000_add_slug"
+	failedMigrationNameRe = regexp.MustCompile(`Migration name:\s*(\S+)`)
+	// P3009: "The `20240101000000_add_slug` migration started at ... failed"
+	failedMigrationStartedRe = regexp.MustCompile("The `([^`]+)` migration started at")
+
+	databaseErrorCodeRe = regexp.MustCompile(`Database error code:\s*(\S+)`)
+)
+
+// alreadyExistsMarkers appear in database errors for changes that are already in place
+var alreadyExistsMarkers = []string{
+	"already exists",
+	"duplicate column",
+	"duplicate key name",
+}
+
+// MigrateFailure is a failed migration identified in migrate deploy / dev output
+type MigrateFailure struct {
+	Code          string // Prisma error code, e.g. "P3018"
+	Migration     string // Name of the failed migration
+	DBErrorCode   string // Database error code, if reported
+	DBError       string // First line of the database error, if reported
+	AlreadyExists bool   // The database error says the change is already in place
+}
+
+// ParseMigrateFailure finds the migration that failed in the target database
+// (P3018) or was left failed by an earlier run (P3009) in the output of a
+// failed migrate command. Returns nil if the output doesn't name one.
+func ParseMigrateFailure(output string) *MigrateFailure {
+	var migration string
+	for _, re := range []*regexp.Regexp{failedMigrationNameRe, failedMigrationStartedRe} {
+		if m := re.FindStringSubmatch(output); m != nil {
+			migration = m[1]
+			break
+		}
+	}
+	if migration == "" {
+		return nil
+	}
+
+	failure := &MigrateFailure{Migration: migration}
+	if m := migrateErrorCodeRe.FindStringSubmatch(output); m != nil {
+		failure.Code = m[1]
+	}
+	if m := databaseErrorCodeRe.FindStringSubmatch(output); m != nil {
+		failure.DBErrorCode = m[1]
+	}
+
+	// The database error follows on the line after "Database error:"
+	if _, rest, ok := strings.Cut(output, "Database error:\n"); ok {
+		for _, line := range strings.Split(rest, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				failure.DBError = line
+				break
+			}
+		}
+	}
+
+	lower := strings.ToLower(failure.DBError)
+	for _, marker := range alreadyExistsMarkers {
+		if strings.Contains(lower, marker) {
+			failure.AlreadyExists = true
+			break
+		}
+	}
+
+	return failure
+}
+
+// SuggestedResolution is the migrate resolve action that usually fits the failure:
+// applied when the change is already in the database, otherwise rolled back so
+// the fixed migration runs again on the next deploy
+func (f *MigrateFailure) SuggestedResolution() string {
+	if f.AlreadyExists {
+		return ResolveApplied
+	}
+	return ResolveRolledBack
+}
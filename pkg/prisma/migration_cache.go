@@ -0,0 +1,131 @@
+package prisma
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// migrationStamp identifies the version of a migration folder that was read:
+// the folder's modification time changes when files are added or removed,
+// migration.sql's size and modification time when it is edited
+type migrationStamp struct {
+	dirModTime int64 // Unix nanoseconds
+	sqlSize    int64
+	sqlModTime int64 // Unix nanoseconds
+}
+
+// cachedMigration holds what was read from a migration's migration.sql
+type cachedMigration struct {
+	stamp    migrationStamp
+	checksum string        // "" until computed
+	changes  []TableChange // Valid once parsed is set
+	parsed   bool
+}
+
+// migrationFileCache remembers what was read from each migration's files, so
+// a refresh of a project with thousands of migrations only reads the ones
+// that changed since the last refresh
+type migrationFileCache struct {
+	mu   sync.Mutex
+	dirs map[string]map[string]*cachedMigration // Migrations directory -> folder name -> entry
+}
+
+var migrationCache = &migrationFileCache{dirs: make(map[string]map[string]*cachedMigration)}
+
+// checksum returns the checksum of the migration folder's migration.sql,
+// reading it only if the folder changed since it was last read. The tables it
+// changes are parsed from the same read, for BuildImpactIndex.
+func (c *migrationFileCache) checksum(migrationPath string, stamp migrationStamp) (string, error) {
+	if stamp == (migrationStamp{}) {
+		return calculateChecksum(migrationPath)
+	}
+
+	c.mu.Lock()
+	if entry := c.lookup(migrationPath, stamp); entry != nil && entry.checksum != "" {
+		c.mu.Unlock()
+		return entry.checksum, nil
+	}
+	c.mu.Unlock()
+
+	content, err := os.ReadFile(filepath.Join(migrationPath, "migration.sql"))
+	if err != nil {
+		return "", err
+	}
+	checksum := Checksum(content)
+	changes := ParseTableChanges(string(content))
+
+	c.mu.Lock()
+	entry := c.entry(migrationPath, stamp)
+	entry.checksum = checksum
+	entry.changes, entry.parsed = changes, true
+	c.mu.Unlock()
+	return checksum, nil
+}
+
+// tableChanges returns the tables the migration folder's migration.sql
+// changes (see ParseTableChanges), parsing it only if the folder changed
+// since it was last parsed. The returned slice must not be modified.
+func (c *migrationFileCache) tableChanges(migrationPath string, stamp migrationStamp) ([]TableChange, error) {
+	if stamp != (migrationStamp{}) {
+		c.mu.Lock()
+		if entry := c.lookup(migrationPath, stamp); entry != nil && entry.parsed {
+			c.mu.Unlock()
+			return entry.changes, nil
+		}
+		c.mu.Unlock()
+	}
+
+	content, err := os.ReadFile(filepath.Join(migrationPath, "migration.sql"))
+	if err != nil {
+		return nil, err
+	}
+	changes := ParseTableChanges(string(content))
+
+	if stamp != (migrationStamp{}) {
+		c.mu.Lock()
+		entry := c.entry(migrationPath, stamp)
+		entry.changes, entry.parsed = changes, true
+		c.mu.Unlock()
+	}
+	return changes, nil
+}
+
+// prune drops the entries of a migrations directory's folders that are no
+// longer there. names holds the folders found.
+func (c *migrationFileCache) prune(migrationsPath string, names map[string]bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for name := range c.dirs[migrationsPath] {
+		if !names[name] {
+			delete(c.dirs[migrationsPath], name)
+		}
+	}
+}
+
+// lookup returns the entry of a migration folder if it was cached for the
+// same stamp. Must be called with c.mu held.
+func (c *migrationFileCache) lookup(migrationPath string, stamp migrationStamp) *cachedMigration {
+	entry := c.dirs[filepath.Dir(migrationPath)][filepath.Base(migrationPath)]
+	if entry == nil || entry.stamp != stamp {
+		return nil
+	}
+	return entry
+}
+
+// entry returns the entry of a migration folder for stamp, replacing one
+// cached for an older version of the folder. Must be called with c.mu held.
+func (c *migrationFileCache) entry(migrationPath string, stamp migrationStamp) *cachedMigration {
+	if entry := c.lookup(migrationPath, stamp); entry != nil {
+		return entry
+	}
+
+	dir := filepath.Dir(migrationPath)
+	if c.dirs[dir] == nil {
+		c.dirs[dir] = make(map[string]*cachedMigration)
+	}
+	entry := &cachedMigration{stamp: stamp}
+	c.dirs[dir][filepath.Base(migrationPath)] = entry
+	return entry
+}
@@ -0,0 +1,44 @@
+package prisma
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrShadowDatabaseRequired is returned when Prisma needs a shadow database to
+// replay the migrations but none is configured
+var ErrShadowDatabaseRequired = errors.New("a shadow database URL is required to replay the migrations")
+
+// PreviewMigrationSQL returns the SQL `migrate dev` would put in the next
+// migration: the difference between the state the migrations produce and the
+// current schema. Nothing is written and the database is not changed.
+func PreviewMigrationSQL(projectDir string) (string, error) {
+	migrationsPath := MigrationsDir(projectDir)
+	schemaPath := SchemaArg(projectDir)
+
+	args := Command(projectDir, "migrate", "diff",
+		"--from-migrations", migrationsPath,
+		toSchemaFlag(projectDir), schemaPath,
+		"--script")
+
+	// Prisma 7 reads the shadow database from prisma.config.ts; older versions need the flag
+	if majorVersion(projectDir) < 7 {
+		shadowURL, _ := GetShadowDatabaseURL(projectDir)
+		if shadowURL == "" {
+			return "", ErrShadowDatabaseRequired
+		}
+		args = append(args, "--shadow-database-url", shadowURL)
+	}
+
+	result, err := cmdBuilder.New(args...).WithWorkingDir(projectDir).WithEnv(EngineOverrideEnv(projectDir)...).RunWithOutput()
+	if err != nil || result.ExitCode != 0 {
+		msg := strings.TrimSpace(result.Stderr)
+		if msg == "" && err != nil {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("prisma migrate diff failed: %s", msg)
+	}
+
+	return result.Stdout, nil
+}
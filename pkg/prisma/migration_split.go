@@ -0,0 +1,107 @@
+package prisma
+
+import (
+	"regexp"
+	"strings"
+)
+
+// StatementGroup holds the statements of a migration script that touch one table
+type StatementGroup struct {
+	Table      string   // "" for statements that don't touch a table (enums, extensions, ...)
+	Statements []string // Original text, including the comments above each statement
+	Creates    bool     // The group creates its table
+	References []string // Other tables the group's foreign keys point to
+}
+
+// SplitReference is a foreign key of a group pointing to a table another group creates
+type SplitReference struct {
+	Table      string // Table declaring the foreign key
+	References string // Table it points to
+}
+
+var (
+	referencesRegex      = regexp.MustCompile(`(?i)\bREFERENCES\s+(` + identPattern + `)`)
+	foreignKeyAlterRegex = regexp.MustCompile(`(?i)^ALTER\s+TABLE\b.*\bREFERENCES\b`)
+)
+
+// GroupStatementsByTable splits a migration script into statements and groups
+// them by the table they change. Groups are ordered by their first statement
+// so that, written in this order, tables are created before they are altered.
+func GroupStatementsByTable(sql string) []StatementGroup {
+	var groups []StatementGroup
+	index := make(map[string]int) // lower-case table -> position in groups
+
+	for _, chunk := range splitStatementsVerbatim(sql) {
+		stmt := chunk.text
+		table := ""
+		create := false
+		if changes := ParseTableChanges(stmt); len(changes) > 0 {
+			table = changes[0].Table
+			create = changes[0].Op == TableOpCreate
+		}
+
+		key := strings.ToLower(table)
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, StatementGroup{Table: table})
+		}
+		group := &groups[i]
+		group.Statements = append(group.Statements, stmt)
+		group.Creates = group.Creates || create
+
+		for _, m := range referencesRegex.FindAllStringSubmatch(NormalizeStatement(stmt), -1) {
+			ref := unquoteIdent(m[1])
+			if !strings.EqualFold(ref, table) && !containsFold(group.References, ref) {
+				group.References = append(group.References, ref)
+			}
+		}
+	}
+
+	return groups
+}
+
+// LaterReferences returns the foreign keys of the chosen groups that point to
+// a table one of the later groups creates, which would fail if the chosen
+// groups were deployed first
+func LaterReferences(chosen, later []StatementGroup) []SplitReference {
+	var refs []SplitReference
+	for _, group := range chosen {
+		for _, ref := range group.References {
+			for _, other := range later {
+				if other.Creates && strings.EqualFold(other.Table, ref) {
+					refs = append(refs, SplitReference{Table: group.Table, References: other.Table})
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// JoinStatementGroups renders groups back into a script, keeping their order.
+// Foreign keys added with ALTER TABLE are moved to the end, as Prisma writes
+// them, since they may point to a table a later group creates.
+func JoinStatementGroups(groups []StatementGroup) string {
+	var stmts, foreignKeys []string
+	for _, group := range groups {
+		for _, stmt := range group.Statements {
+			if foreignKeyAlterRegex.MatchString(NormalizeStatement(stmt)) {
+				foreignKeys = append(foreignKeys, stmt)
+			} else {
+				stmts = append(stmts, stmt)
+			}
+		}
+	}
+	return strings.Join(append(stmts, foreignKeys...), "\n\n") + "\n"
+}
+
+// containsFold reports whether list holds s, ignoring case
+func containsFold(list []string, s string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, s) {
+			return true
+		}
+	}
+	return false
+}
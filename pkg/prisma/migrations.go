@@ -4,6 +4,7 @@ import (
 	"crypto/sha256"
 	"database/sql"
 	"encoding/hex"
+	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
@@ -27,12 +28,25 @@ type Migration struct {
 	ChecksumMismatch bool       // true if local checksum != DB checksum
 	Logs             *string    // Migration logs from DB (if failed)
 	StartedAt        *time.Time // Migration start time from DB (for in-transaction migrations)
+
+	stamp migrationStamp // Version of the folder read, for migrationCache (zero = not cached)
 }
 
-// GetLocalMigrations returns a list of local migrations from the prisma/migrations directory
+// migrationScanWorkers is how many migration folders are read at once
+const migrationScanWorkers = 8
+
+// GetLocalMigrations returns a list of local migrations from the project's
+// migrations directory (see MigrationsDir)
 func GetLocalMigrations(projectDir string) ([]Migration, error) {
-	// Build migrations directory path
-	migrationsPath := filepath.Join(projectDir, SchemaDirName, MigrationsDirName)
+	return ScanLocalMigrations(projectDir, nil)
+}
+
+// ScanLocalMigrations is GetLocalMigrations reporting its progress. The
+// migration folders are read concurrently; progress (optional) is called as
+// each one is read with the number read so far and the total.
+func ScanLocalMigrations(projectDir string, progress func(done, total int)) ([]Migration, error) {
+	// Resolve migrations directory path
+	migrationsPath := MigrationsDir(projectDir)
 
 	// Check if migrations directory exists
 	if _, err := os.Stat(migrationsPath); os.IsNotExist(err) {
@@ -46,42 +60,39 @@ func GetLocalMigrations(projectDir string) ([]Migration, error) {
 	}
 
 	// Collect migration directories
-	var migrations []Migration
+	var folders []os.DirEntry
+	names := make(map[string]bool)
 	for _, entry := range entries {
 		if entry.IsDir() {
-			migrationPath := filepath.Join(migrationsPath, entry.Name())
-
-			// Check if migration.sql exists
-			sqlFile := filepath.Join(migrationPath, "migration.sql")
-			isEmpty := true
-			checksum := ""
-			if stat, err := os.Stat(sqlFile); err == nil && !stat.IsDir() {
-				// Check if file has content
-				if stat.Size() > 0 {
-					isEmpty = false
-					// Calculate checksum for non-empty migrations
-					if cs, err := calculateChecksum(migrationPath); err == nil {
-						checksum = cs
-					}
-				}
-			}
+			folders = append(folders, entry)
+			names[entry.Name()] = true
+		}
+	}
 
-			// Check if down.sql exists
-			downSQLFile := filepath.Join(migrationPath, "down.sql")
-			hasDownSQL := false
-			if stat, err := os.Stat(downSQLFile); err == nil && !stat.IsDir() && stat.Size() > 0 {
-				hasDownSQL = true
+	// Read them with a pool of workers, each filling in its own migrations
+	migrations := make([]Migration, len(folders))
+	jobs := make(chan int, len(folders))
+	for i := range folders {
+		jobs <- i
+	}
+	close(jobs)
+
+	read := make(chan struct{})
+	for range min(migrationScanWorkers, len(folders)) {
+		go func() {
+			for i := range jobs {
+				migrations[i] = readMigrationFolder(migrationsPath, folders[i])
+				read <- struct{}{}
 			}
-
-			migrations = append(migrations, Migration{
-				Name:       entry.Name(),
-				Path:       migrationPath,
-				IsEmpty:    isEmpty,
-				HasDownSQL: hasDownSQL,
-				Checksum:   checksum,
-			})
+		}()
+	}
+	for done := 1; done <= len(folders); done++ {
+		<-read
+		if progress != nil {
+			progress(done, len(folders))
 		}
 	}
+	migrationCache.prune(migrationsPath, names)
 
 	// Sort migrations by name (chronological order due to timestamp prefix)
 	sort.Slice(migrations, func(i, j int) bool {
@@ -91,6 +102,50 @@ func GetLocalMigrations(projectDir string) ([]Migration, error) {
 	return migrations, nil
 }
 
+// readMigrationFolder reads a migration folder of the migrations directory
+func readMigrationFolder(migrationsPath string, entry os.DirEntry) Migration {
+	migrationPath := filepath.Join(migrationsPath, entry.Name())
+
+	var stamp migrationStamp
+	if info, err := entry.Info(); err == nil {
+		stamp.dirModTime = info.ModTime().UnixNano()
+	}
+
+	// Check if migration.sql exists
+	sqlFile := filepath.Join(migrationPath, "migration.sql")
+	isEmpty := true
+	checksum := ""
+	if stat, err := os.Stat(sqlFile); err == nil && !stat.IsDir() {
+		// Check if file has content
+		if stat.Size() > 0 {
+			isEmpty = false
+			stamp.sqlSize = stat.Size()
+			stamp.sqlModTime = stat.ModTime().UnixNano()
+			// Calculate checksum for non-empty migrations, unless it
+			// is unchanged since the last time
+			if cs, err := migrationCache.checksum(migrationPath, stamp); err == nil {
+				checksum = cs
+			}
+		}
+	}
+
+	// Check if down.sql exists
+	downSQLFile := filepath.Join(migrationPath, "down.sql")
+	hasDownSQL := false
+	if stat, err := os.Stat(downSQLFile); err == nil && !stat.IsDir() && stat.Size() > 0 {
+		hasDownSQL = true
+	}
+
+	return Migration{
+		Name:       entry.Name(),
+		Path:       migrationPath,
+		IsEmpty:    isEmpty,
+		HasDownSQL: hasDownSQL,
+		Checksum:   checksum,
+		stamp:      stamp,
+	}
+}
+
 // calculateChecksum computes SHA-256 checksum of migration.sql file
 func calculateChecksum(migrationPath string) (string, error) {
 	sqlFile := filepath.Join(migrationPath, "migration.sql")
@@ -100,6 +155,11 @@ func calculateChecksum(migrationPath string) (string, error) {
 		return "", err
 	}
 
+	return Checksum(content), nil
+}
+
+// Checksum computes the SHA-256 checksum of migration SQL the same way Prisma does
+func Checksum(content []byte) string {
 	// Normalize line endings: replace CRLF with LF
 	normalized := make([]byte, 0, len(content))
 	for i := 0; i < len(content); i++ {
@@ -112,7 +172,7 @@ func calculateChecksum(migrationPath string) (string, error) {
 	}
 
 	hash := sha256.Sum256(normalized)
-	return hex.EncodeToString(hash[:]), nil
+	return hex.EncodeToString(hash[:])
 }
 
 // DBMigration represents a migration from the database
@@ -236,3 +296,80 @@ func CompareMigrations(localMigrations []Migration, dbMigrations []DBMigration)
 
 	return category
 }
+
+// bindVar returns the n-th (1-based) query placeholder for the provider
+func bindVar(provider string, n int) string {
+	if provider == "postgresql" || provider == "cockroachdb" {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+// UpdateMigrationChecksum records a new checksum for a migration in
+// _prisma_migrations, e.g. to accept an edited migration.sql as applied
+func UpdateMigrationChecksum(db *sql.DB, provider, name, checksum string) error {
+	query := fmt.Sprintf("UPDATE _prisma_migrations SET checksum = %s WHERE migration_name = %s",
+		bindVar(provider, 1), bindVar(provider, 2))
+
+	result, err := db.Exec(query, checksum, name)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result, name)
+}
+
+// DeleteMigrationRow removes a migration's row from _prisma_migrations. The
+// database objects the migration created are left as they are.
+func DeleteMigrationRow(db *sql.DB, provider, name string) error {
+	query := fmt.Sprintf("DELETE FROM _prisma_migrations WHERE migration_name = %s", bindVar(provider, 1))
+
+	result, err := db.Exec(query, name)
+	if err != nil {
+		return err
+	}
+	return expectRowsAffected(result, name)
+}
+
+// expectRowsAffected reports an error if a statement changed no history row
+func expectRowsAffected(result sql.Result, name string) error {
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return fmt.Errorf("no _prisma_migrations row for migration %q", name)
+	}
+	return nil
+}
+
+// CreateMigrationsTable creates an empty _prisma_migrations table with the
+// definition Prisma uses, so that existing migrations can be marked as applied
+// instead of being run
+func CreateMigrationsTable(db *sql.DB, provider string) error {
+	var query string
+	switch provider {
+	case "postgresql", "cockroachdb":
+		query = `CREATE TABLE IF NOT EXISTS "_prisma_migrations" (
+    "id"                    VARCHAR(36) PRIMARY KEY NOT NULL,
+    "checksum"              VARCHAR(64) NOT NULL,
+    "finished_at"           TIMESTAMPTZ,
+    "migration_name"        VARCHAR(255) NOT NULL,
+    "logs"                  TEXT,
+    "rolled_back_at"        TIMESTAMPTZ,
+    "started_at"            TIMESTAMPTZ NOT NULL DEFAULT now(),
+    "applied_steps_count"   INTEGER NOT NULL DEFAULT 0
+)`
+	case "mysql":
+		query = "CREATE TABLE IF NOT EXISTS `_prisma_migrations` (" + `
+    id                      VARCHAR(36) PRIMARY KEY NOT NULL,
+    checksum                VARCHAR(64) NOT NULL,
+    finished_at             DATETIME(3),
+    migration_name          VARCHAR(255) NOT NULL,
+    logs                    TEXT,
+    rolled_back_at          DATETIME(3),
+    started_at              DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3),
+    applied_steps_count     INTEGER UNSIGNED NOT NULL DEFAULT 0
+) DEFAULT CHARACTER SET utf8mb4 COLLATE utf8mb4_unicode_ci`
+	default:
+		return fmt.Errorf("creating _prisma_migrations is not supported for %s", provider)
+	}
+
+	_, err := db.Exec(query)
+	return err
+}
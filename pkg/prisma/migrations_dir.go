@@ -0,0 +1,70 @@
+package prisma
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+var (
+	// configMigrationsPathRegex matches migrations: { path: "..." } in prisma.config.ts
+	configMigrationsPathRegex = regexp.MustCompile(`migrations\s*:\s*\{[^}]*?path\s*:\s*['"]([^'"]+)['"]`)
+	// configSchemaRegex matches schema: "..." in prisma.config.ts
+	configSchemaRegex = regexp.MustCompile(`(?m)^\s*schema\s*:\s*['"]([^'"]+)['"]`)
+)
+
+// MigrationsDir returns the migrations directory of a project. Like Prisma, it
+// uses migrations.path from prisma.config.ts if set, and otherwise the
+// migrations folder next to the schema, which prisma.config.ts or the "prisma"
+// section of package.json may relocate. Defaults to prisma/migrations.
+func MigrationsDir(projectDir string) string {
+	if content, err := os.ReadFile(filepath.Join(projectDir, ConfigFileName)); err == nil {
+		if match := configMigrationsPathRegex.FindSubmatch(content); match != nil {
+			return resolveProjectPath(projectDir, string(match[1]))
+		}
+	}
+
+	schema := schemaLocation(projectDir)
+	if schema == "" {
+		return filepath.Join(projectDir, SchemaDirName, MigrationsDirName)
+	}
+
+	// A multi-file schema is a directory holding the migrations folder
+	if stat, err := os.Stat(schema); err == nil && stat.IsDir() {
+		return filepath.Join(schema, MigrationsDirName)
+	}
+	return filepath.Join(filepath.Dir(schema), MigrationsDirName)
+}
+
+// schemaLocation returns the schema file or directory configured in
+// prisma.config.ts or package.json, or "" if neither sets one
+func schemaLocation(projectDir string) string {
+	if content, err := os.ReadFile(filepath.Join(projectDir, ConfigFileName)); err == nil {
+		if match := configSchemaRegex.FindSubmatch(content); match != nil {
+			return resolveProjectPath(projectDir, string(match[1]))
+		}
+	}
+
+	content, err := os.ReadFile(filepath.Join(projectDir, "package.json"))
+	if err != nil {
+		return ""
+	}
+	var pkg struct {
+		Prisma struct {
+			Schema string `json:"schema"`
+		} `json:"prisma"`
+	}
+	if err := json.Unmarshal(content, &pkg); err != nil || pkg.Prisma.Schema == "" {
+		return ""
+	}
+	return resolveProjectPath(projectDir, pkg.Prisma.Schema)
+}
+
+// resolveProjectPath resolves a path from the project's config relative to the project
+func resolveProjectPath(projectDir, path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+	return filepath.Join(projectDir, path)
+}
@@ -0,0 +1,152 @@
+package prisma
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MockCall is a command a MockRunner was asked to run
+type MockCall struct {
+	Args []string // Prisma arguments, e.g. ["migrate", "resolve", "--applied", "0_init"]
+	Env  []string
+}
+
+// Subcommand returns the call's leading non-flag arguments, e.g. "migrate resolve"
+func (c MockCall) Subcommand() string {
+	var words []string
+	for _, arg := range c.Args {
+		if strings.HasPrefix(arg, "-") || len(words) == 2 {
+			break
+		}
+		words = append(words, arg)
+	}
+	return strings.Join(words, " ")
+}
+
+// String returns the call as a command line, e.g. "prisma migrate deploy"
+func (c MockCall) String() string {
+	return strings.Join(append([]string{"prisma"}, c.Args...), " ")
+}
+
+// MockResult is how a mocked streamed command behaves
+type MockResult struct {
+	Output   []string // Lines written to stdout
+	ExitCode int
+	Err      error // Returned instead, as if the command could not be started
+}
+
+// MockRunner records the commands it is asked to run instead of running them.
+// Streamed commands complete before the call returns, replaying the result
+// set for their subcommand (no output and exit code 0 by default).
+type MockRunner struct {
+	// Results of streamed commands by subcommand, e.g. "migrate deploy"
+	Results map[string]MockResult
+	// ValidateResult is returned by Validate (nil = a valid schema)
+	ValidateResult *ValidateResult
+	// DiffSQL and DiffErr are returned by Diff
+	DiffSQL string
+	DiffErr error
+	// IntrospectSchema and IntrospectErr are returned by Introspect
+	IntrospectSchema string
+	IntrospectErr    error
+
+	mu    sync.Mutex
+	calls []MockCall
+}
+
+func NewMockRunner() *MockRunner {
+	return &MockRunner{Results: make(map[string]MockResult)}
+}
+
+// Calls returns the commands run so far, oldest first
+func (r *MockRunner) Calls() []MockCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]MockCall(nil), r.calls...)
+}
+
+func (r *MockRunner) Stream(projectDir string, args []string, opts StreamOpts) error {
+	return r.stream(opts, args...)
+}
+
+// Studio records the command and returns a process that does nothing when
+// killed, or the "studio" result's Err
+func (r *MockRunner) Studio(projectDir string, port int, opts StreamOpts) (Process, error) {
+	call := MockCall{Args: []string{"studio", "--port", strconv.Itoa(port)}, Env: opts.Env}
+	r.record(call)
+	if opts.OnStart != nil {
+		opts.OnStart(append([]string{"prisma"}, call.Args...))
+	}
+	if err := r.result(call).Err; err != nil {
+		return nil, err
+	}
+	return mockProcess{}, nil
+}
+
+// Interactive records the command and returns its result's Err
+func (r *MockRunner) Interactive(projectDir string, args []string) error {
+	call := MockCall{Args: args}
+	r.record(call)
+	return r.result(call).Err
+}
+
+func (r *MockRunner) Validate(projectDir string) (*ValidateResult, error) {
+	r.record(MockCall{Args: []string{"validate"}})
+	if r.ValidateResult != nil {
+		return r.ValidateResult, nil
+	}
+	return &ValidateResult{Valid: true}, nil
+}
+
+func (r *MockRunner) Diff(projectDir string) (string, error) {
+	r.record(MockCall{Args: []string{"migrate", "diff"}})
+	return r.DiffSQL, r.DiffErr
+}
+
+func (r *MockRunner) Introspect(projectDir string) (string, error) {
+	r.record(MockCall{Args: []string{"db", "pull", "--print"}})
+	return r.IntrospectSchema, r.IntrospectErr
+}
+
+func (r *MockRunner) record(call MockCall) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, call)
+}
+
+// result returns the result set for the call's subcommand
+func (r *MockRunner) result(call MockCall) MockResult {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.Results[call.Subcommand()]
+}
+
+// stream records the call and replays its result through opts
+func (r *MockRunner) stream(opts StreamOpts, args ...string) error {
+	call := MockCall{Args: args, Env: opts.Env}
+	r.record(call)
+
+	result := r.result(call)
+	if result.Err != nil {
+		return result.Err
+	}
+
+	if opts.OnStart != nil {
+		opts.OnStart(append([]string{"prisma"}, args...))
+	}
+	if opts.OnStdout != nil {
+		for _, line := range result.Output {
+			opts.OnStdout(line)
+		}
+	}
+	if opts.OnComplete != nil {
+		opts.OnComplete(result.ExitCode)
+	}
+	return nil
+}
+
+// mockProcess is a Studio started by a MockRunner
+type mockProcess struct{}
+
+func (mockProcess) Kill() error { return nil }
@@ -0,0 +1,217 @@
+package prisma
+
+import (
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CommandOutput is the structured result an output parser reads from a
+// command's output: one of *MigrateStatusOutput, *MigrateDeployOutput,
+// *GenerateOutput or *ValidateOutput
+type CommandOutput interface {
+	// Subcommand returns the command the output is from, e.g. "migrate deploy"
+	Subcommand() string
+}
+
+// OutputParser reads the output of a finished command
+type OutputParser func(output string, exitCode int) CommandOutput
+
+// MigrateStatusOutput is what migrate status reported
+type MigrateStatusOutput struct {
+	Found    int      // Migrations found in the migrations directory (-1 = not reported)
+	Pending  []string // Migrations not yet applied
+	Failed   []string // Migrations that failed
+	UpToDate bool     // "Database schema is up to date!"
+}
+
+// MigrateDeployOutput is what migrate deploy reported
+type MigrateDeployOutput struct {
+	Applied   []string // Migrations applied, in order
+	Failed    string   // Migration that failed ("" = none)
+	NoPending bool     // There was nothing to apply
+}
+
+// GeneratedOutput is a generator that prisma generate ran
+type GeneratedOutput struct {
+	Name     string        // e.g. "Prisma Client (v5.10.2)"
+	Path     string        // Where it wrote to, e.g. "./node_modules/@prisma/client"
+	Duration time.Duration // As reported (0 = not reported)
+}
+
+// GenerateOutput is what prisma generate reported
+type GenerateOutput struct {
+	Generated []GeneratedOutput
+}
+
+// ValidateOutput is what prisma validate reported
+type ValidateOutput struct {
+	Valid  bool
+	Errors []string
+}
+
+func (o *MigrateStatusOutput) Subcommand() string { return "migrate status" }
+func (o *MigrateDeployOutput) Subcommand() string { return "migrate deploy" }
+func (o *GenerateOutput) Subcommand() string      { return "generate" }
+func (o *ValidateOutput) Subcommand() string      { return "validate" }
+
+// Duration returns the time the generators took together
+func (o *GenerateOutput) Duration() time.Duration {
+	var total time.Duration
+	for _, g := range o.Generated {
+		total += g.Duration
+	}
+	return total
+}
+
+var (
+	outputParsersMu sync.RWMutex
+	// outputParsers maps subcommands, e.g. "migrate deploy", to their parsers
+	outputParsers = map[string]OutputParser{
+		"migrate status": ParseMigrateStatusOutput,
+		"migrate deploy": ParseMigrateDeployOutput,
+		"generate":       ParseGenerateOutput,
+		"validate":       ParseValidateOutput,
+	}
+)
+
+// RegisterOutputParser adds or replaces the parser of a subcommand, e.g.
+// "migrate reset"
+func RegisterOutputParser(subcommand string, parser OutputParser) {
+	outputParsersMu.Lock()
+	defer outputParsersMu.Unlock()
+	outputParsers[subcommand] = parser
+}
+
+// ParseCommandOutput parses the output of a finished prisma command with the
+// parser registered for its subcommand. commandLine is the full command line,
+// e.g. ["npx", "prisma", "migrate", "deploy"]. Returns nil if no parser is
+// registered for it.
+func ParseCommandOutput(commandLine []string, output string, exitCode int) CommandOutput {
+	words := prismaSubcommand(commandLine)
+	if len(words) == 0 {
+		return nil
+	}
+
+	outputParsersMu.RLock()
+	defer outputParsersMu.RUnlock()
+
+	// The longest registered subcommand wins: "migrate deploy" before "migrate"
+	for n := len(words); n > 0; n-- {
+		if parser, ok := outputParsers[strings.Join(words[:n], " ")]; ok {
+			return parser(output, exitCode)
+		}
+	}
+	return nil
+}
+
+// prismaSubcommand returns up to two leading non-flag arguments after the
+// prisma executable, e.g. ["migrate", "deploy"]
+func prismaSubcommand(commandLine []string) []string {
+	start := -1
+	for i, arg := range commandLine {
+		if name := strings.TrimSuffix(filepath.Base(arg), ".cmd"); name == "prisma" {
+			start = i + 1
+			break
+		}
+	}
+	if start < 0 {
+		return nil
+	}
+
+	var words []string
+	for _, arg := range commandLine[start:] {
+		if strings.HasPrefix(arg, "-") || len(words) == 2 {
+			break
+		}
+		words = append(words, arg)
+	}
+	return words
+}
+
+var (
+	// "3 migrations found in prisma/migrations"
+	migrationsFoundRe = regexp.MustCompile(`^(\d+) migrations? found in `)
+	// A migration directory name on a line of its own
+	migrationNameLineRe = regexp.MustCompile(`^[\w.-]+$`)
+	// "✔ Generated Prisma Client (v5.10.2) to ./node_modules/@prisma/client in 74ms"
+	generatedRe = regexp.MustCompile(`Generated (.+?) to (\S+?)(?: in ([\d.]+m?s))?\s*$`)
+)
+
+// ParseMigrateStatusOutput reads migrate status output: the number of
+// migrations found and the lists of pending and failed migrations
+func ParseMigrateStatusOutput(output string, exitCode int) CommandOutput {
+	status := &MigrateStatusOutput{Found: -1}
+
+	var list *[]string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case migrationsFoundRe.MatchString(line):
+			status.Found, _ = strconv.Atoi(migrationsFoundRe.FindStringSubmatch(line)[1])
+			list = nil
+		case strings.HasPrefix(line, "Following migration") && strings.Contains(line, "not yet been applied"):
+			list = &status.Pending
+		case strings.HasPrefix(line, "Following migration") && strings.Contains(line, "failed"):
+			list = &status.Failed
+		case strings.HasPrefix(line, "Database schema is up to date"):
+			status.UpToDate = true
+			list = nil
+		case list != nil && migrationNameLineRe.MatchString(line):
+			*list = append(*list, line)
+		case line == "" && list != nil && len(*list) > 0:
+			list = nil
+		}
+	}
+	return status
+}
+
+// ParseMigrateDeployOutput reads migrate deploy output. Prisma announces each
+// migration before applying it, so after a failure the last one announced is
+// the one that failed.
+func ParseMigrateDeployOutput(output string, exitCode int) CommandOutput {
+	deploy := &MigrateDeployOutput{}
+	for _, line := range strings.Split(output, "\n") {
+		if name, ok := ParseApplyingMigration(line); ok {
+			deploy.Applied = append(deploy.Applied, name)
+		}
+		if strings.Contains(line, "No pending migrations to apply") {
+			deploy.NoPending = true
+		}
+	}
+	if exitCode != 0 && len(deploy.Applied) > 0 {
+		deploy.Failed = deploy.Applied[len(deploy.Applied)-1]
+		deploy.Applied = deploy.Applied[:len(deploy.Applied)-1]
+	}
+	return deploy
+}
+
+// ParseGenerateOutput reads the generators prisma generate ran and how long
+// each took
+func ParseGenerateOutput(output string, exitCode int) CommandOutput {
+	generate := &GenerateOutput{}
+	for _, line := range strings.Split(output, "\n") {
+		m := generatedRe.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		generated := GeneratedOutput{Name: m[1], Path: m[2]}
+		if m[3] != "" {
+			generated.Duration, _ = time.ParseDuration(m[3])
+		}
+		generate.Generated = append(generate.Generated, generated)
+	}
+	return generate
+}
+
+// ParseValidateOutput reads whether prisma validate found the schema valid,
+// and the errors it reported if not
+func ParseValidateOutput(output string, exitCode int) CommandOutput {
+	if exitCode == 0 {
+		return &ValidateOutput{Valid: true}
+	}
+	return &ValidateOutput{Errors: parseValidationErrors(output)}
+}
@@ -0,0 +1,147 @@
+package prisma
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// DirectURLEnvVar is the variable the directUrl quick-fix reads the unpooled URL from
+const DirectURLEnvVar = "DIRECT_URL"
+
+// PooledURL is a connection URL that goes through a transaction-mode
+// connection pooler, which prisma migrate can't work through
+type PooledURL struct {
+	Pooler    string // e.g. "PgBouncer" or "Supabase pooler"
+	DirectURL string // The same database without the pooler, if it can be derived ("" = unknown)
+}
+
+// PooledMigrations is a migrate command that would run through a pooler
+type PooledMigrations struct {
+	*PooledURL
+	Setting string // Datasource setting the pooled URL is in: "url" or "directUrl"
+	EnvVar  string // Variable it is read from ("" = hardcoded)
+}
+
+// Ports transaction-mode poolers listen on by default
+const (
+	pgBouncerPort       = "6432"
+	supabasePoolerPort  = "6543" // Supavisor's transaction mode; 5432 is session mode
+	postgresDefaultPort = "5432"
+)
+
+// DetectPooledURL returns how dbURL goes through a transaction-mode pooler,
+// or nil: Prisma Accelerate, Supabase's and Neon's poolers, pgbouncer=true
+// or a port PgBouncer listens on
+func DetectPooledURL(dbURL string) *PooledURL {
+	u, err := url.Parse(dbURL)
+	if err != nil {
+		return nil
+	}
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Hostname())
+	pgbouncer := strings.EqualFold(u.Query().Get("pgbouncer"), "true")
+
+	switch {
+	case scheme == "prisma" || scheme == "prisma+postgres":
+		return &PooledURL{Pooler: "Prisma Accelerate"}
+	case strings.HasSuffix(host, ".pooler.supabase.com"):
+		if u.Port() != supabasePoolerPort {
+			return nil
+		}
+		return &PooledURL{Pooler: "Supabase pooler", DirectURL: unpooledURL(u, "", postgresDefaultPort)}
+	case strings.HasSuffix(host, ".neon.tech") && strings.Contains(host, "-pooler."):
+		return &PooledURL{Pooler: "Neon pooler", DirectURL: unpooledURL(u, strings.Replace(u.Hostname(), "-pooler.", ".", 1), "")}
+	case scheme != "postgresql" && scheme != "postgres":
+		return nil
+	case pgbouncer || u.Port() == pgBouncerPort || u.Port() == supabasePoolerPort:
+		direct := ""
+		if u.Port() == pgBouncerPort || u.Port() == supabasePoolerPort {
+			direct = unpooledURL(u, "", postgresDefaultPort)
+		}
+		return &PooledURL{Pooler: "PgBouncer", DirectURL: direct}
+	}
+	return nil
+}
+
+// unpooledURL returns u with another host and/or port ("" = keep) and
+// without pgbouncer=true, which only matters to the pooled connection
+func unpooledURL(u *url.URL, host, port string) string {
+	direct := *u
+	if host == "" {
+		host = u.Hostname()
+	}
+	if port == "" {
+		port = u.Port()
+	}
+	direct.Host = host
+	if port != "" {
+		direct.Host += ":" + port
+	}
+	query := direct.Query()
+	query.Del("pgbouncer")
+	direct.RawQuery = query.Encode()
+	return direct.String()
+}
+
+// CheckPooledMigrations returns how migrations in the project would run
+// through a pooler, or nil: through the directUrl if one is set, otherwise
+// through the url
+func CheckPooledMigrations(projectDir string, ds *Datasource) *PooledMigrations {
+	directURL, directEnvVar := GetDirectURL(projectDir)
+	if directURL != "" || directEnvVar != "" {
+		if pooled := DetectPooledURL(directURL); pooled != nil {
+			return &PooledMigrations{PooledURL: pooled, Setting: "directUrl", EnvVar: directEnvVar}
+		}
+		return nil
+	}
+	if pooled := DetectPooledURL(ds.URL); pooled != nil {
+		return &PooledMigrations{PooledURL: pooled, Setting: "url", EnvVar: ds.EnvVarName}
+	}
+	return nil
+}
+
+// datasourceURLLineRegex matches the url line of a datasource block, capturing
+// its indentation and the padding before "="
+var datasourceURLLineRegex = regexp.MustCompile(`(?m)^([ \t]*)url(\s*)=.*$`)
+
+// AddDirectURL adds directUrl = env("DIRECT_URL") after the url of the
+// datasource in the project's schema, aligned with it
+func AddDirectURL(projectDir string) error {
+	path := SchemaPath(projectDir)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	schema := string(content)
+
+	start := strings.Index(schema, "datasource ")
+	if start < 0 {
+		return fmt.Errorf("no datasource block in %s", path)
+	}
+	end := strings.Index(schema[start:], "}")
+	if end < 0 {
+		return fmt.Errorf("unterminated datasource block in %s", path)
+	}
+	block := schema[start : start+end]
+	if strings.Contains(block, "directUrl") {
+		return fmt.Errorf("the datasource in %s already has a directUrl", path)
+	}
+	loc := datasourceURLLineRegex.FindStringSubmatchIndex(block)
+	if loc == nil {
+		return fmt.Errorf("the datasource in %s has no url", path)
+	}
+
+	indent := block[loc[2]:loc[3]]
+	padding := " "
+	if column := len("url") + loc[5] - loc[4]; column > len("directUrl") {
+		padding = strings.Repeat(" ", column-len("directUrl"))
+	}
+	line := fmt.Sprintf("\n%sdirectUrl%s= env(%q)", indent, padding, DirectURLEnvVar)
+
+	at := start + loc[1]
+	schema = schema[:at] + line + schema[at:]
+	return os.WriteFile(path, []byte(schema), 0644)
+}
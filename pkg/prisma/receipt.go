@@ -0,0 +1,68 @@
+package prisma
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+var (
+	// "-- Migration 2/5: 20240115103000_add_name", as written by ExportSQL
+	receiptMarkerRe = regexp.MustCompile(`^--\s*Migration\s+\d+/\d+:\s*(\S+)\s*$`)
+	// "npx prisma migrate resolve --applied 20240115103000_add_name"
+	receiptResolveRe = regexp.MustCompile(`migrate\s+resolve\s+--applied\s+["']?([^\s"']+)`)
+	// A bare migration name on its own line
+	receiptNameRe = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+)
+
+// ParseReceipt returns the migration names listed in a receipt of migrations
+// applied outside lazyprisma, in order and without duplicates. A receipt is a
+// JSON array of names, a script exported by ExportSQL (its migration markers),
+// or text with one name or migrate resolve --applied command per line, where
+// lines starting with # or -- are comments.
+func ParseReceipt(content string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	trimmed := strings.TrimSpace(content)
+	if strings.HasPrefix(trimmed, "[") {
+		var list []string
+		if err := json.Unmarshal([]byte(trimmed), &list); err == nil {
+			for _, name := range list {
+				add(strings.TrimSpace(name))
+			}
+			return names
+		}
+	}
+
+	lines := strings.Split(content, "\n")
+
+	// An exported script: only its markers name migrations, the rest is SQL
+	for _, line := range lines {
+		if m := receiptMarkerRe.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			add(m[1])
+		}
+	}
+	if len(names) > 0 {
+		return names
+	}
+
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "--") {
+			continue
+		}
+		if m := receiptResolveRe.FindStringSubmatch(line); m != nil {
+			add(m[1])
+		} else if receiptNameRe.MatchString(line) {
+			add(line)
+		}
+	}
+	return names
+}
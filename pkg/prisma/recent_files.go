@@ -0,0 +1,96 @@
+package prisma
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ProjectFile is a Prisma-related file of a project and when it last changed
+type ProjectFile struct {
+	Path    string // Relative to the project directory
+	ModTime time.Time
+}
+
+// RecentFiles returns the Prisma-related files of a project, most recently
+// modified first: prisma.config.ts, the schema, the files of the migrations
+// directory and the .env files. limit caps the number of files (0 = no limit).
+func RecentFiles(projectDir string, limit int) []ProjectFile {
+	paths := ProjectFilePaths(projectDir)
+
+	// The files of each migration
+	migrationsDir := MigrationsDir(projectDir)
+	if entries, err := os.ReadDir(migrationsDir); err == nil {
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			files, _ := os.ReadDir(filepath.Join(migrationsDir, entry.Name()))
+			for _, file := range files {
+				if !file.IsDir() {
+					paths = append(paths, filepath.Join(migrationsDir, entry.Name(), file.Name()))
+				}
+			}
+		}
+	}
+
+	var files []ProjectFile
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+
+		if file, ok := StatProjectFile(projectDir, path); ok {
+			files = append(files, file)
+		}
+	}
+
+	sort.SliceStable(files, func(i, j int) bool {
+		if !files[i].ModTime.Equal(files[j].ModTime) {
+			return files[i].ModTime.After(files[j].ModTime)
+		}
+		return files[i].Path < files[j].Path
+	})
+	if limit > 0 && len(files) > limit {
+		files = files[:limit]
+	}
+	return files
+}
+
+// ProjectFilePaths returns the paths of the Prisma-related files of a project
+// outside its migration folders: prisma.config.ts, the schema (every .prisma
+// file of a multi-file schema), migration_lock.toml and the .env files. They
+// may not exist.
+func ProjectFilePaths(projectDir string) []string {
+	var paths []string
+	paths = append(paths, filepath.Join(projectDir, ConfigFileName))
+
+	schema := SchemaPath(projectDir)
+	if stat, err := os.Stat(schema); err == nil && stat.IsDir() {
+		matches, _ := filepath.Glob(filepath.Join(schema, "*.prisma"))
+		paths = append(paths, matches...)
+	} else {
+		paths = append(paths, schema)
+	}
+
+	paths = append(paths, filepath.Join(MigrationsDir(projectDir), "migration_lock.toml"))
+	return append(paths, EnvFiles(projectDir)...)
+}
+
+// StatProjectFile returns a file's path relative to the project directory and
+// its modification time. Returns false if it doesn't exist or is a directory.
+func StatProjectFile(projectDir, path string) (ProjectFile, bool) {
+	stat, err := os.Stat(path)
+	if err != nil || stat.IsDir() {
+		return ProjectFile{}, false
+	}
+	rel, err := filepath.Rel(projectDir, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		rel = path
+	}
+	return ProjectFile{Path: filepath.ToSlash(rel), ModTime: stat.ModTime()}, true
+}
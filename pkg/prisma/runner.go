@@ -0,0 +1,172 @@
+package prisma
+
+import (
+	"strconv"
+
+	"github.com/dokadev/lazyprisma/pkg/commands"
+	"github.com/dokadev/lazyprisma/pkg/node"
+)
+
+// StreamOpts configures a streamed Prisma command. OnStart receives the full
+// command line before it runs, then each output line is passed to OnStdout or
+// OnStderr, and OnComplete gets the exit code. OnError is called instead if
+// the command could not be run.
+type StreamOpts struct {
+	Env []string // Extra environment variables ("KEY=value"), e.g. a DATABASE_URL override
+
+	OnStart    func(commandLine []string)
+	OnStdout   func(line string)
+	OnStderr   func(line string)
+	OnComplete func(exitCode int)
+	OnError    func(err error)
+}
+
+// MigrateDevOpts are the steps migrate dev can leave out
+type MigrateDevOpts struct {
+	SkipGenerate bool // --skip-generate: don't run the generators afterwards
+	SkipSeed     bool // --skip-seed: don't run the seed script
+}
+
+// Flags returns the command-line flags for the options
+func (o MigrateDevOpts) Flags() []string {
+	var flags []string
+	if o.SkipGenerate {
+		flags = append(flags, "--skip-generate")
+	}
+	if o.SkipSeed {
+		flags = append(flags, "--skip-seed")
+	}
+	return flags
+}
+
+// MigrateDevArgs creates (but never applies) a migration for the schema changes
+func MigrateDevArgs(name string, devOpts MigrateDevOpts) []string {
+	return append([]string{"migrate", "dev", "--name", name, "--create-only"}, devOpts.Flags()...)
+}
+
+func MigrateDeployArgs() []string {
+	return []string{"migrate", "deploy"}
+}
+
+// MigrateResolveArgs marks a migration as ResolveApplied or ResolveRolledBack
+func MigrateResolveArgs(migration, action string) []string {
+	return []string{"migrate", "resolve", "--" + action, migration}
+}
+
+// DBExecuteArgs runs a SQL file against the schema's database
+func DBExecuteArgs(file, schema string) []string {
+	return []string{"db", "execute", "--file", file, "--schema", schema}
+}
+
+// GenerateArgs runs the given generators, or all of them if none are given
+func GenerateArgs(generators []string) []string {
+	args := []string{"generate"}
+	for _, name := range generators {
+		args = append(args, "--generator", name)
+	}
+	return args
+}
+
+// Process is a long-running Prisma command, such as Studio
+type Process interface {
+	Kill() error
+}
+
+// Runner runs Prisma CLI commands in a project directory. Streamed commands
+// take their arguments from the *Args functions, return once started (or with
+// the error that kept them from starting) and report through their
+// StreamOpts. CLIRunner runs the real CLI; MockRunner records commands and
+// replays canned results.
+type Runner interface {
+	// Stream starts a prisma command, e.g. MigrateDeployArgs()
+	Stream(projectDir string, args []string, opts StreamOpts) error
+	// Studio starts Prisma Studio on a port. Its output isn't streamed; only
+	// opts.OnStart and opts.Env are used.
+	Studio(projectDir string, port int, opts StreamOpts) (Process, error)
+	// Interactive runs a prisma command on the terminal and waits for it,
+	// for use before the TUI starts
+	Interactive(projectDir string, args []string) error
+
+	Validate(projectDir string) (*ValidateResult, error)
+	// Diff returns the SQL the next migration would contain
+	Diff(projectDir string) (string, error)
+	// Introspect returns the schema db pull would write, without writing it
+	Introspect(projectDir string) (string, error)
+}
+
+// CLIRunner runs the project's prisma binary, or npx when it isn't installed
+type CLIRunner struct {
+	builder *commands.CommandBuilder
+}
+
+func NewCLIRunner() *CLIRunner {
+	return &CLIRunner{builder: cmdBuilder}
+}
+
+func (r *CLIRunner) Stream(projectDir string, args []string, opts StreamOpts) error {
+	cmd, err := r.start(projectDir, args, opts)
+	if err != nil {
+		return err
+	}
+
+	cmd.StreamOutput()
+	if opts.OnStdout != nil {
+		cmd.OnStdout(opts.OnStdout)
+	}
+	if opts.OnStderr != nil {
+		cmd.OnStderr(opts.OnStderr)
+	}
+	if opts.OnComplete != nil {
+		cmd.OnComplete(opts.OnComplete)
+	}
+	if opts.OnError != nil {
+		cmd.OnError(opts.OnError)
+	}
+
+	return cmd.RunAsync()
+}
+
+func (r *CLIRunner) Studio(projectDir string, port int, opts StreamOpts) (Process, error) {
+	cmd, err := r.start(projectDir, []string{"studio", "--port", strconv.Itoa(port)}, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.RunAsync(); err != nil {
+		return nil, err
+	}
+	return cmd, nil
+}
+
+func (r *CLIRunner) Interactive(projectDir string, args []string) error {
+	return r.builder.New(Command(projectDir, args...)...).
+		WithWorkingDir(projectDir).
+		Interactive().
+		Run()
+}
+
+func (r *CLIRunner) Validate(projectDir string) (*ValidateResult, error) {
+	return Validate(projectDir)
+}
+
+func (r *CLIRunner) Diff(projectDir string) (string, error) {
+	return PreviewMigrationSQL(projectDir)
+}
+
+func (r *CLIRunner) Introspect(projectDir string) (string, error) {
+	return IntrospectSchema(projectDir)
+}
+
+// start builds a prisma command, unless node or npx has gone missing
+func (r *CLIRunner) start(projectDir string, args []string, opts StreamOpts) (*commands.Command, error) {
+	commandLine := Command(projectDir, args...)
+	if opts.OnStart != nil {
+		opts.OnStart(commandLine)
+	}
+	if err := node.CheckToolchain(commandLine); err != nil {
+		return nil, err
+	}
+
+	return r.builder.New(commandLine...).
+		WithWorkingDir(projectDir).
+		WithEnv(append(EngineOverrideEnv(projectDir), opts.Env...)...), nil
+}
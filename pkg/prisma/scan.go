@@ -0,0 +1,149 @@
+package prisma
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultExcludeDirs are directory names that are never scanned for Prisma workspaces
+var DefaultExcludeDirs = []string{
+	"node_modules",
+	".git",
+	".next",
+	".turbo",
+	".cache",
+	"dist",
+	"build",
+	"coverage",
+	"vendor",
+}
+
+// monorepoFiles are the files that declare the packages of a monorepo at its root
+var monorepoFiles = []string{"pnpm-workspace.yaml", "lerna.json", "nx.json", "turbo.json", "rush.json"}
+
+// MonorepoRoot returns the directory to search for the projects next to the
+// one in dir: the nearest directory at or above it that declares a monorepo's
+// packages (a workspace file, or the "workspaces" field of package.json), or
+// else the root of its git repository. Returns dir if it is in neither.
+func MonorepoRoot(dir string) string {
+	start, err := filepath.Abs(dir)
+	if err != nil {
+		return dir
+	}
+
+	current := start
+	for {
+		for _, name := range monorepoFiles {
+			if _, err := os.Stat(filepath.Join(current, name)); err == nil {
+				return current
+			}
+		}
+		if hasWorkspacesField(filepath.Join(current, "package.json")) {
+			return current
+		}
+		// A file in worktrees and submodules, a directory otherwise
+		if _, err := os.Stat(filepath.Join(current, ".git")); err == nil {
+			return current
+		}
+
+		parent := filepath.Dir(current)
+		if parent == current {
+			// Reached root
+			return start
+		}
+		current = parent
+	}
+}
+
+// hasWorkspacesField reports whether a package.json declares npm, yarn or bun
+// workspaces
+func hasWorkspacesField(path string) bool {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	var pkg struct {
+		Workspaces json.RawMessage `json:"workspaces"`
+	}
+	return json.Unmarshal(content, &pkg) == nil && len(pkg.Workspaces) > 0 && string(pkg.Workspaces) != "null"
+}
+
+// FindWorkspaces walks root and returns every directory that is a Prisma workspace.
+// maxDepth limits how deep to descend (0 = unlimited). excludeDirs may contain either
+// directory names or absolute paths and is applied in addition to DefaultExcludeDirs.
+// The result is sorted with root (if it is a workspace) first.
+func FindWorkspaces(root string, maxDepth int, excludeDirs []string) ([]string, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	excludeNames := make(map[string]bool)
+	excludePaths := make(map[string]bool)
+	for _, name := range DefaultExcludeDirs {
+		excludeNames[name] = true
+	}
+	for _, dir := range excludeDirs {
+		if filepath.IsAbs(dir) {
+			excludePaths[filepath.Clean(dir)] = true
+		} else {
+			excludeNames[dir] = true
+		}
+	}
+
+	var workspaces []string
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// Unreadable directories are skipped rather than aborting the scan
+			if d != nil && d.IsDir() && path != root {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+
+		if path != root {
+			if excludeNames[d.Name()] || excludePaths[path] {
+				return filepath.SkipDir
+			}
+			if maxDepth > 0 && dirDepth(root, path) > maxDepth {
+				return filepath.SkipDir
+			}
+		}
+
+		if IsWorkspace(path) {
+			workspaces = append(workspaces, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(workspaces, func(i, j int) bool {
+		if workspaces[i] == root {
+			return true
+		}
+		if workspaces[j] == root {
+			return false
+		}
+		return workspaces[i] < workspaces[j]
+	})
+
+	return workspaces, nil
+}
+
+// dirDepth returns how many levels path is below root
+func dirDepth(root, path string) int {
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == "." {
+		return 0
+	}
+	return strings.Count(rel, string(filepath.Separator)) + 1
+}
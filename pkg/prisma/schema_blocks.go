@@ -0,0 +1,93 @@
+package prisma
+
+import (
+	"regexp"
+	"strings"
+)
+
+// SchemaBlock is a model, view, enum or composite type declared in a schema
+type SchemaBlock struct {
+	Kind    string // "model", "view", "enum" or "type"
+	Name    string
+	Line    int // 0-based index of the declaration line
+	EndLine int // 0-based index of the closing brace
+}
+
+var (
+	// "model User {"
+	schemaBlockRegex = regexp.MustCompile(`^\s*(model|view|enum|type)\s+(\w+)\s*\{`)
+	// `@@map("users")`
+	schemaMapRegex = regexp.MustCompile(`^\s*@@map\(\s*(?:name:\s*)?"([^"]+)"`)
+	// "  author   User?   @relation(fields: [authorId], references: [id])"
+	schemaFieldRegex = regexp.MustCompile(`^\s*(\w+)\s+(\w+)(?:\[\])?\??(?:\s|$)`)
+)
+
+// FindSchemaBlocks returns the blocks declared in the schema lines, in order
+func FindSchemaBlocks(lines []string) []SchemaBlock {
+	var blocks []SchemaBlock
+	for i, line := range lines {
+		match := schemaBlockRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		end := len(lines) - 1
+		for j := i + 1; j < len(lines); j++ {
+			if strings.HasPrefix(strings.TrimSpace(lines[j]), "}") {
+				end = j
+				break
+			}
+		}
+		blocks = append(blocks, SchemaBlock{Kind: match[1], Name: match[2], Line: i, EndLine: end})
+	}
+	return blocks
+}
+
+// SchemaBlockAt returns the block that contains the given 0-based line
+func SchemaBlockAt(lines []string, line int) (SchemaBlock, bool) {
+	for _, block := range FindSchemaBlocks(lines) {
+		if line >= block.Line && line <= block.EndLine {
+			return block, true
+		}
+	}
+	return SchemaBlock{}, false
+}
+
+// DatabaseName returns the name the block has in the database: its @@map name
+// if it has one, otherwise the name in the schema
+func (b SchemaBlock) DatabaseName(lines []string) string {
+	for i := b.Line + 1; i < b.EndLine && i < len(lines); i++ {
+		if match := schemaMapRegex.FindStringSubmatch(lines[i]); match != nil {
+			return match[1]
+		}
+	}
+	return b.Name
+}
+
+// FindSchemaBlock returns the block with the given name
+func FindSchemaBlock(lines []string, name string) (SchemaBlock, bool) {
+	for _, block := range FindSchemaBlocks(lines) {
+		if block.Name == name {
+			return block, true
+		}
+	}
+	return SchemaBlock{}, false
+}
+
+// SchemaFieldType returns the type of the field declared on the line without
+// its list / optional modifiers, or "" if the line isn't a field.
+func SchemaFieldType(line string) string {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || strings.HasPrefix(trimmed, "//") || strings.HasPrefix(trimmed, "@@") {
+		return ""
+	}
+	if strings.HasSuffix(trimmed, "{") { // Block header (model, generator, ...)
+		return ""
+	}
+
+	match := schemaFieldRegex.FindStringSubmatch(line)
+	if match == nil {
+		return ""
+	}
+	return match[2]
+}
@@ -0,0 +1,52 @@
+package prisma
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SchemaError is an error Prisma reported at a location in a schema file
+type SchemaError struct {
+	File    string // Path as printed by Prisma, usually relative to the project
+	Line    int    // 1-based
+	Message string
+}
+
+// "  -->  prisma/schema.prisma:13"
+var schemaErrorLocationRegex = regexp.MustCompile(`-->\s*(.+?):(\d+)`)
+
+// ParseSchemaErrors extracts the error locations from generate / validate output.
+// Each location is paired with the nearest "error:" line above it.
+func ParseSchemaErrors(output string) []SchemaError {
+	var errors []SchemaError
+	seen := make(map[string]bool)
+	message := ""
+
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if rest, ok := strings.CutPrefix(strings.ToLower(trimmed), "error:"); ok && rest != "" {
+			message = strings.TrimSpace(trimmed[len("error:"):])
+			continue
+		}
+
+		match := schemaErrorLocationRegex.FindStringSubmatch(trimmed)
+		if match == nil {
+			continue
+		}
+		lineNum, err := strconv.Atoi(match[2])
+		if err != nil {
+			continue
+		}
+
+		key := match[1] + ":" + match[2]
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		errors = append(errors, SchemaError{File: match[1], Line: lineNum, Message: message})
+	}
+
+	return errors
+}
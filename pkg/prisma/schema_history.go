@@ -0,0 +1,199 @@
+package prisma
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/dokadev/lazyprisma/pkg/git"
+)
+
+// SchemaRevision is a commit that changed schema.prisma
+type SchemaRevision struct {
+	git.Revision
+	ModelsAdded   []string
+	ModelsRemoved []string
+}
+
+// modelRegex matches model, view and enum declarations in a Prisma schema
+var modelRegex = regexp.MustCompile(`(?m)^\s*(?:model|view|enum)\s+(\w+)\s*\{`)
+
+// SchemaPath returns the path of a project's schema: the file or, for a
+// multi-file schema, the directory that prisma.config.ts or package.json
+// configures (as MigrationsDir resolves it), and otherwise prisma/schema.prisma
+func SchemaPath(projectDir string) string {
+	if schema := schemaLocation(projectDir); schema != "" {
+		return schema
+	}
+	return filepath.Join(projectDir, SchemaDirName, SchemaFileName)
+}
+
+// SchemaArg returns the schema path to pass to Prisma's --schema flags for a
+// command run from projectDir: relative to it when the schema is inside it
+func SchemaArg(projectDir string) string {
+	schemaPath := SchemaPath(projectDir)
+	if rel, err := filepath.Rel(projectDir, schemaPath); err == nil && !strings.HasPrefix(rel, "..") {
+		return rel
+	}
+	return schemaPath
+}
+
+// IsMultiFileSchema reports whether a project's schema is a directory of
+// .prisma files rather than a single file
+func IsMultiFileSchema(projectDir string) bool {
+	stat, err := os.Stat(SchemaPath(projectDir))
+	return err == nil && stat.IsDir()
+}
+
+// ReadSchema returns the content of a project's schema; the .prisma files of a
+// multi-file schema are concatenated by name, each under a comment naming it
+func ReadSchema(projectDir string) (string, error) {
+	schemaPath := SchemaPath(projectDir)
+	if !IsMultiFileSchema(projectDir) {
+		content, err := os.ReadFile(schemaPath)
+		return string(content), err
+	}
+
+	files, err := filepath.Glob(filepath.Join(schemaPath, "*.prisma"))
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+
+	var parts []schemaPart
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, schemaPart{name: filepath.Base(file), content: string(content)})
+	}
+	return joinSchemaParts(parts), nil
+}
+
+// schemaPart is one file of a multi-file schema
+type schemaPart struct {
+	name    string
+	content string
+}
+
+// joinSchemaParts concatenates the files of a multi-file schema
+func joinSchemaParts(parts []schemaPart) string {
+	var b strings.Builder
+	for i, part := range parts {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("// " + part.name + "\n")
+		b.WriteString(strings.TrimRight(part.content, "\n") + "\n")
+	}
+	return b.String()
+}
+
+// ParseModelNames returns the model, view and enum names declared in a schema, sorted
+func ParseModelNames(schema string) []string {
+	var names []string
+	for _, match := range modelRegex.FindAllStringSubmatch(schema, -1) {
+		names = append(names, match[1])
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SchemaHistory returns the commits that changed the schema, newest first, with the
+// models each one added or removed. limit caps the number of revisions (0 = no limit).
+// Each revision is read with git, so callers should load it on demand.
+func SchemaHistory(projectDir string, limit int) ([]SchemaRevision, error) {
+	schemaPath := SchemaPath(projectDir)
+
+	revisions, err := git.FileRevisions(projectDir, schemaPath, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Model sets per revision (nil if the schema could not be read, e.g. after a rename)
+	multiFile := IsMultiFileSchema(projectDir)
+	models := make([]map[string]bool, len(revisions))
+	for i, rev := range revisions {
+		content, err := schemaAt(projectDir, rev.Hash, schemaPath, multiFile)
+		if err != nil {
+			continue
+		}
+		models[i] = make(map[string]bool)
+		for _, name := range ParseModelNames(content) {
+			models[i][name] = true
+		}
+	}
+
+	history := make([]SchemaRevision, len(revisions))
+	for i, rev := range revisions {
+		history[i].Revision = rev
+
+		// The parent of the oldest revision is unknown when the history was truncated
+		truncated := limit > 0 && len(revisions) == limit
+		if models[i] == nil || (i == len(revisions)-1 && truncated) {
+			continue
+		}
+		var parent map[string]bool
+		if i+1 < len(revisions) {
+			parent = models[i+1]
+			if parent == nil {
+				continue
+			}
+		}
+
+		for name := range models[i] {
+			if !parent[name] {
+				history[i].ModelsAdded = append(history[i].ModelsAdded, name)
+			}
+		}
+		for name := range parent {
+			if !models[i][name] {
+				history[i].ModelsRemoved = append(history[i].ModelsRemoved, name)
+			}
+		}
+		sort.Strings(history[i].ModelsAdded)
+		sort.Strings(history[i].ModelsRemoved)
+	}
+
+	return history, nil
+}
+
+// ShowSchemaAt returns the content of the schema at the given commit, read
+// like ReadSchema reads the working tree
+func ShowSchemaAt(projectDir, rev string) (string, error) {
+	return schemaAt(projectDir, rev, SchemaPath(projectDir), IsMultiFileSchema(projectDir))
+}
+
+// schemaAt reads the schema file, or the .prisma files directly in the schema
+// directory, at a revision
+func schemaAt(projectDir, rev, schemaPath string, multiFile bool) (string, error) {
+	if !multiFile {
+		content, err := git.ShowFile(projectDir, rev, schemaPath)
+		if err != nil {
+			return "", err
+		}
+		return string(content), nil
+	}
+
+	files, err := git.ListTreeFiles(projectDir, rev, schemaPath)
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+
+	var parts []schemaPart
+	for _, file := range files {
+		if filepath.Ext(file) != ".prisma" {
+			continue
+		}
+		content, err := git.ShowFile(projectDir, rev, filepath.Join(schemaPath, file))
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, schemaPart{name: file, content: string(content)})
+	}
+	return joinSchemaParts(parts), nil
+}
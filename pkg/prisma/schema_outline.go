@@ -0,0 +1,185 @@
+package prisma
+
+import (
+	"regexp"
+	"strings"
+)
+
+// SchemaEntry is a block of the schema as listed in the Schema panel: a
+// model, view, enum, composite type or generator
+type SchemaEntry struct {
+	Kind       string // "model", "view", "enum", "type" or "generator"
+	Name       string
+	Line       int           // 0-based index of the declaration line
+	Doc        string        // /// comment above the declaration
+	Fields     []SchemaField // Fields, enum values, or a generator's settings
+	Attributes []string      // Block attributes, e.g. @@unique([a, b])
+}
+
+// SchemaField is a field of a model, view or composite type, a value of an
+// enum, or a setting of a generator
+type SchemaField struct {
+	Name       string
+	Type       string // Type without modifiers; a setting's value ("" for enum values)
+	List       bool   // Type[]
+	Optional   bool   // Type?
+	Attributes string // e.g. @id @default(autoincrement())
+	Doc        string // /// comment above the field
+}
+
+// SchemaOutline is every entry of a schema, in the order they are declared
+type SchemaOutline struct {
+	Entries []SchemaEntry
+}
+
+// SchemaEntryKinds are the kinds of entries, in the order the Schema panel groups them
+var SchemaEntryKinds = []string{"model", "view", "enum", "type", "generator"}
+
+var (
+	// "generator client {"
+	schemaGeneratorRegex = regexp.MustCompile(`^\s*generator\s+(\w+)\s*\{`)
+	// "  email  String?  @unique" or "  ADMIN  @map("admin")"
+	schemaOutlineFieldRegex = regexp.MustCompile(`^(\w+)(?:\s+(\w+(?:\.\w+)?(?:\([^)]*\))?)(\[\])?(\?)?)?\s*(.*)$`)
+	// `provider = "prisma-client-js"`
+	schemaSettingRegex = regexp.MustCompile(`^(\w+)\s*=\s*(.+)$`)
+)
+
+// ParseSchemaOutline lists the entries declared in the schema lines with
+// their fields and attributes
+func ParseSchemaOutline(lines []string) *SchemaOutline {
+	outline := &SchemaOutline{}
+	var current *SchemaEntry
+	var doc []string
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(stripSchemaComment(line))
+		if comment, ok := strings.CutPrefix(strings.TrimSpace(line), "///"); ok {
+			doc = append(doc, strings.TrimSpace(comment))
+			continue
+		}
+
+		if current == nil {
+			kind, name := "", ""
+			if match := schemaBlockRegex.FindStringSubmatch(line); match != nil {
+				kind, name = match[1], match[2]
+			} else if match := schemaGeneratorRegex.FindStringSubmatch(line); match != nil {
+				kind, name = "generator", match[1]
+			}
+			if kind != "" {
+				outline.Entries = append(outline.Entries, SchemaEntry{Kind: kind, Name: name, Line: i, Doc: strings.Join(doc, "\n")})
+				current = &outline.Entries[len(outline.Entries)-1]
+			}
+			doc = nil
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(trimmed, "}"):
+			current, doc = nil, nil
+		case trimmed == "":
+			// Keep a doc comment for the field after a blank line
+		case strings.HasPrefix(trimmed, "@@"):
+			current.Attributes = append(current.Attributes, trimmed)
+			doc = nil
+		case current.Kind == "generator":
+			if match := schemaSettingRegex.FindStringSubmatch(trimmed); match != nil {
+				current.Fields = append(current.Fields, SchemaField{Name: match[1], Type: strings.TrimSpace(match[2])})
+			}
+			doc = nil
+		default:
+			if match := schemaOutlineFieldRegex.FindStringSubmatch(trimmed); match != nil {
+				field := SchemaField{
+					Name:       match[1],
+					Type:       match[2],
+					List:       match[3] != "",
+					Optional:   match[4] != "",
+					Attributes: strings.TrimSpace(match[5]),
+					Doc:        strings.Join(doc, "\n"),
+				}
+				current.Fields = append(current.Fields, field)
+			}
+			doc = nil
+		}
+	}
+	return outline
+}
+
+// stripSchemaComment removes a // comment from the end of a schema line,
+// leaving // inside strings (e.g. in a URL) alone
+func stripSchemaComment(line string) string {
+	inString := false
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && inString:
+			i++
+		case line[i] == '"':
+			inString = !inString
+		case !inString && strings.HasPrefix(line[i:], "//"):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// Entry returns the entry with the given name, or nil
+func (o *SchemaOutline) Entry(name string) *SchemaEntry {
+	for i := range o.Entries {
+		if o.Entries[i].Name == name {
+			return &o.Entries[i]
+		}
+	}
+	return nil
+}
+
+// IsRelation reports whether the field's type is a model or view of the
+// schema, i.e. the field is one side of a relation
+func (o *SchemaOutline) IsRelation(field SchemaField) bool {
+	entry := o.Entry(field.Type)
+	return entry != nil && (entry.Kind == "model" || entry.Kind == "view")
+}
+
+// SchemaBackRelation is a relation field of another model that points at an entry
+type SchemaBackRelation struct {
+	Model string
+	Field SchemaField
+}
+
+// BackRelations returns the relation fields of other models and views whose
+// type is the entry called name, in the order they are declared
+func (o *SchemaOutline) BackRelations(name string) []SchemaBackRelation {
+	var relations []SchemaBackRelation
+	for _, entry := range o.Entries {
+		if entry.Name == name || (entry.Kind != "model" && entry.Kind != "view") {
+			continue
+		}
+		for _, field := range entry.Fields {
+			if field.Type == name {
+				relations = append(relations, SchemaBackRelation{Model: entry.Name, Field: field})
+			}
+		}
+	}
+	return relations
+}
+
+// DatabaseName returns the name the entry has in the database: its @@map
+// name if it has one, otherwise its name in the schema
+func (e SchemaEntry) DatabaseName() string {
+	for _, attr := range e.Attributes {
+		if match := schemaMapRegex.FindStringSubmatch(attr); match != nil {
+			return match[1]
+		}
+	}
+	return e.Name
+}
+
+// TypeString returns the field's type as written, e.g. Post[] or String?
+func (f SchemaField) TypeString() string {
+	s := f.Type
+	if f.List {
+		s += "[]"
+	}
+	if f.Optional {
+		s += "?"
+	}
+	return s
+}
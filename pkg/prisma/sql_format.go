@@ -0,0 +1,387 @@
+package prisma
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// FormatSQLOpts controls how FormatSQL lays out a script
+type FormatSQLOpts struct {
+	Indent       string // Indentation unit (default four spaces)
+	MaxWidth     int    // Wrap lines longer than this at token boundaries (0 = no wrapping)
+	UpperKeyword bool   // Upper-case SQL keywords
+
+	// BackslashEscapes makes a backslash escape the next character in every
+	// quoted string, as MySQL does. Postgres E'...' strings always allow them.
+	BackslashEscapes bool
+}
+
+// DefaultFormatSQLOpts returns the options used for migration display
+func DefaultFormatSQLOpts() FormatSQLOpts {
+	return FormatSQLOpts{
+		Indent:       "    ",
+		MaxWidth:     100,
+		UpperKeyword: true,
+	}
+}
+
+// ProjectFormatSQLOpts returns the options for a project's migrations: the
+// defaults, with backslash escapes on MySQL
+func ProjectFormatSQLOpts(projectDir string) FormatSQLOpts {
+	opts := DefaultFormatSQLOpts()
+	if provider, _ := GetProvider(projectDir); provider == "mysql" {
+		opts.BackslashEscapes = true
+	}
+	return opts
+}
+
+// sqlKeywords are upper-cased when FormatSQLOpts.UpperKeyword is set
+var sqlKeywords = map[string]bool{
+	"ADD": true, "ALL": true, "ALTER": true, "AND": true, "AS": true, "ASC": true,
+	"BEGIN": true, "BETWEEN": true, "BY": true, "CASCADE": true, "CASE": true, "CHECK": true,
+	"COLUMN": true, "COMMIT": true, "CONCURRENTLY": true, "CONSTRAINT": true, "CREATE": true,
+	"DEFAULT": true, "DEFERRABLE": true, "DELETE": true, "DESC": true, "DISTINCT": true,
+	"DROP": true, "ELSE": true, "END": true, "ENUM": true, "EXISTS": true, "EXTENSION": true,
+	"FOREIGN": true, "FROM": true, "FUNCTION": true, "GROUP": true, "HAVING": true, "IF": true,
+	"IN": true, "INDEX": true, "INNER": true, "INSERT": true, "INTO": true, "IS": true,
+	"JOIN": true, "KEY": true, "LEFT": true, "LIKE": true, "LIMIT": true, "MODIFY": true,
+	"NOT": true, "NULL": true, "ON": true, "OR": true, "ORDER": true, "PRIMARY": true,
+	"REFERENCES": true, "RENAME": true, "RESTRICT": true, "RETURNING": true, "RIGHT": true,
+	"ROLLBACK": true, "SCHEMA": true, "SELECT": true, "SEQUENCE": true, "SET": true,
+	"TABLE": true, "THEN": true, "TO": true, "TRIGGER": true, "TYPE": true, "UNION": true,
+	"UNIQUE": true, "UPDATE": true, "USING": true, "VALUES": true, "VIEW": true, "WHEN": true,
+	"WHERE": true, "WITH": true,
+}
+
+// sqlClauseKeywords start a new line when they appear at the top level of a statement
+var sqlClauseKeywords = map[string]bool{
+	"FROM": true, "WHERE": true, "SET": true, "VALUES": true, "GROUP": true, "ORDER": true,
+	"HAVING": true, "LIMIT": true, "RETURNING": true, "UNION": true,
+}
+
+type sqlTokenKind int
+
+const (
+	sqlTokenWord sqlTokenKind = iota
+	sqlTokenSpace
+	sqlTokenLineComment
+	sqlTokenBlockComment
+	sqlTokenString // '...', "...", `...`, [...], $tag$...$tag$
+	sqlTokenPunct
+)
+
+type sqlToken struct {
+	kind sqlTokenKind
+	text string
+}
+
+// FormatSQL reformats a SQL script for display: one statement per block, column
+// lists of CREATE TABLE one per line, clause keywords on their own line, keywords
+// upper-cased and long lines wrapped. Literals, quoted identifiers, comments and
+// dollar-quoted bodies are never altered.
+func FormatSQL(sql string, opts FormatSQLOpts) string {
+	if opts.Indent == "" {
+		opts.Indent = "    "
+	}
+
+	f := &sqlFormatter{opts: opts}
+	for _, tok := range tokenizeSQL(sql, opts.BackslashEscapes) {
+		f.write(tok)
+	}
+	return strings.TrimSpace(f.out.String()) + "\n"
+}
+
+// FormatSQLPreservesLiterals reports whether the script FormatSQL returned,
+// tokenized again with the same options, has the same string literals and
+// quoted identifiers as the original. A formatted script that fails the check
+// must not be written back.
+func FormatSQLPreservesLiterals(original, formatted string, opts FormatSQLOpts) bool {
+	before := sqlLiterals(original, opts.BackslashEscapes)
+	after := sqlLiterals(formatted, opts.BackslashEscapes)
+	if len(before) != len(after) {
+		return false
+	}
+	for i := range before {
+		if before[i] != after[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// sqlLiterals returns the quoted tokens of a script in order. Trailing
+// whitespace is ignored, as an unterminated literal runs to the end.
+func sqlLiterals(sql string, backslashEscapes bool) []string {
+	var literals []string
+	for _, tok := range tokenizeSQL(strings.TrimRightFunc(sql, unicode.IsSpace), backslashEscapes) {
+		if tok.kind == sqlTokenString {
+			literals = append(literals, tok.text)
+		}
+	}
+	return literals
+}
+
+// sqlFormatter tracks layout state while emitting tokens
+type sqlFormatter struct {
+	opts FormatSQLOpts
+	out  strings.Builder
+
+	line        strings.Builder // Current output line
+	depth       int             // Parenthesis depth
+	blockDepths []int           // Depths whose contents are laid out one item per line
+	stmtWords   []string        // First words of the current statement (upper-cased)
+	pendingSp   bool            // A space is due before the next token
+}
+
+func (f *sqlFormatter) write(tok sqlToken) {
+	switch tok.kind {
+	case sqlTokenSpace:
+		if f.lineHasContent() {
+			f.pendingSp = true
+		}
+		return
+
+	case sqlTokenLineComment:
+		if f.lineHasContent() {
+			f.pendingSp = true
+			f.emit(tok.text)
+		} else {
+			f.line.Reset()
+			f.line.WriteString(f.indent() + tok.text)
+		}
+		f.newline(f.indent())
+		return
+
+	case sqlTokenBlockComment, sqlTokenString:
+		f.emit(tok.text)
+		return
+
+	case sqlTokenPunct:
+		f.writePunct(tok.text)
+		return
+	}
+
+	word := tok.text
+	upper := strings.ToUpper(word)
+	if f.opts.UpperKeyword && sqlKeywords[upper] {
+		word = upper
+	}
+
+	// Clause keywords at the top level start a new line
+	if f.depth == 0 && len(f.stmtWords) > 0 && sqlClauseKeywords[upper] {
+		f.newline("")
+	}
+
+	if len(f.stmtWords) < 3 {
+		f.stmtWords = append(f.stmtWords, upper)
+	}
+	f.emit(word)
+}
+
+func (f *sqlFormatter) writePunct(p string) {
+	switch p {
+	case ";":
+		f.pendingSp = false
+		f.line.WriteString(";")
+		f.flush()
+		f.out.WriteString("\n")
+		f.depth = 0
+		f.blockDepths = nil
+		f.stmtWords = nil
+
+	case "(":
+		f.emit("(")
+		f.pendingSp = false
+		f.depth++
+		// Column lists of CREATE TABLE are laid out one per line
+		if f.depth == 1 && f.isCreateTable() {
+			f.blockDepths = append(f.blockDepths, f.depth)
+			f.newline(f.indent())
+		}
+
+	case ")":
+		if f.inBlock() {
+			f.blockDepths = f.blockDepths[:len(f.blockDepths)-1]
+			f.depth--
+			f.newline(f.indent())
+			f.line.WriteString(")")
+		} else {
+			f.pendingSp = false
+			f.line.WriteString(")")
+			if f.depth > 0 {
+				f.depth--
+			}
+		}
+
+	case ",":
+		f.pendingSp = false
+		f.line.WriteString(",")
+		switch {
+		case f.inBlock():
+			f.newline(f.indent())
+		case f.depth == 0 && f.isAlterTable():
+			// Multiple ALTER TABLE actions go one per line
+			f.newline(f.opts.Indent)
+		default:
+			f.pendingSp = true
+		}
+
+	default:
+		f.emit(p)
+	}
+}
+
+// emit appends text to the current line, wrapping first if it would get too long
+func (f *sqlFormatter) emit(text string) {
+	sep := ""
+	if f.pendingSp && f.lineHasContent() {
+		sep = " "
+	}
+	f.pendingSp = false
+
+	if f.opts.MaxWidth > 0 && sep != "" && f.line.Len()+1+len(text) > f.opts.MaxWidth {
+		f.newline(f.indent() + f.opts.Indent)
+		sep = ""
+	}
+	f.line.WriteString(sep + text)
+}
+
+// newline ends the current line and starts a new one with the given prefix
+func (f *sqlFormatter) newline(prefix string) {
+	f.flush()
+	f.line.WriteString(prefix)
+	f.pendingSp = false
+}
+
+func (f *sqlFormatter) flush() {
+	line := strings.TrimRightFunc(f.line.String(), unicode.IsSpace)
+	if line != "" {
+		f.out.WriteString(line + "\n")
+	}
+	f.line.Reset()
+}
+
+func (f *sqlFormatter) lineHasContent() bool {
+	return strings.TrimSpace(f.line.String()) != ""
+}
+
+func (f *sqlFormatter) indent() string {
+	return strings.Repeat(f.opts.Indent, len(f.blockDepths))
+}
+
+func (f *sqlFormatter) inBlock() bool {
+	return len(f.blockDepths) > 0 && f.blockDepths[len(f.blockDepths)-1] == f.depth
+}
+
+func (f *sqlFormatter) isCreateTable() bool {
+	return len(f.stmtWords) >= 2 && f.stmtWords[0] == "CREATE" &&
+		(f.stmtWords[1] == "TABLE" || (len(f.stmtWords) >= 3 && f.stmtWords[2] == "TABLE"))
+}
+
+func (f *sqlFormatter) isAlterTable() bool {
+	return len(f.stmtWords) >= 2 && f.stmtWords[0] == "ALTER" && f.stmtWords[1] == "TABLE"
+}
+
+// tokenizeSQL splits a script into tokens, keeping literals and comments intact.
+// backslashEscapes makes backslashes escape characters in every quoted string.
+func tokenizeSQL(sql string, backslashEscapes bool) []sqlToken {
+	var tokens []sqlToken
+	runes := []rune(sql)
+	n := len(runes)
+
+	for i := 0; i < n; {
+		r := runes[i]
+		start := i
+
+		switch {
+		case unicode.IsSpace(r):
+			for i < n && unicode.IsSpace(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, sqlToken{sqlTokenSpace, " "})
+
+		case r == '-' && i+1 < n && runes[i+1] == '-':
+			for i < n && runes[i] != '\n' {
+				i++
+			}
+			tokens = append(tokens, sqlToken{sqlTokenLineComment, strings.TrimRight(string(runes[start:i]), " \t\r")})
+
+		case r == '/' && i+1 < n && runes[i+1] == '*':
+			i += 2
+			for i < n && !(runes[i] == '*' && i+1 < n && runes[i+1] == '/') {
+				i++
+			}
+			i = min(i+2, n)
+			tokens = append(tokens, sqlToken{sqlTokenBlockComment, string(runes[start:i])})
+
+		case (r == 'E' || r == 'e') && i+1 < n && runes[i+1] == '\'':
+			// Postgres escape string (E'...'); words are scanned whole, so the E starts one
+			i = scanQuoted(runes, i+1, '\'', '\'', true)
+			tokens = append(tokens, sqlToken{sqlTokenString, string(runes[start:i])})
+
+		case r == '\'' || r == '"' || r == '`':
+			i = scanQuoted(runes, i, r, r, backslashEscapes)
+			tokens = append(tokens, sqlToken{sqlTokenString, string(runes[start:i])})
+
+		case r == '[':
+			i = scanQuoted(runes, i, '[', ']', false)
+			tokens = append(tokens, sqlToken{sqlTokenString, string(runes[start:i])})
+
+		case r == '$':
+			// Dollar-quoted body ($$ ... $$ or $tag$ ... $tag$)
+			j := i + 1
+			for j < n && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_') {
+				j++
+			}
+			if j < n && runes[j] == '$' {
+				tag := string(runes[i : j+1])
+				rest := string(runes[j+1:])
+				if end := strings.Index(rest, tag); end == -1 {
+					i = n
+				} else {
+					i = j + 1 + utf8.RuneCountInString(rest[:end]+tag)
+				}
+				tokens = append(tokens, sqlToken{sqlTokenString, string(runes[start:i])})
+			} else {
+				i = j
+				tokens = append(tokens, sqlToken{sqlTokenWord, string(runes[start:i])})
+			}
+
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			for i < n && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, sqlToken{sqlTokenWord, string(runes[start:i])})
+
+		default:
+			i++
+			tokens = append(tokens, sqlToken{sqlTokenPunct, string(r)})
+		}
+	}
+
+	return tokens
+}
+
+// scanQuoted returns the index just past a quoted section starting at i.
+// A doubled closing quote is treated as an escaped quote, and so is a
+// backslashed one if backslashEscapes is set.
+func scanQuoted(runes []rune, i int, open, close rune, backslashEscapes bool) int {
+	n := len(runes)
+	i++ // Skip opening quote
+	for i < n {
+		if backslashEscapes && runes[i] == '\\' {
+			i += 2
+			continue
+		}
+		if runes[i] == close {
+			if close == open && i+1 < n && runes[i+1] == close {
+				i += 2
+				continue
+			}
+			return i + 1
+		}
+		i++
+	}
+	return n
+}
@@ -0,0 +1,186 @@
+package prisma
+
+import (
+	"regexp"
+	"strings"
+)
+
+// StatementRisk is why a statement can lose data or fail on existing rows
+type StatementRisk string
+
+const (
+	RiskNone          StatementRisk = ""
+	RiskDropTable     StatementRisk = "drop-table"
+	RiskDropColumn    StatementRisk = "drop-column"
+	RiskDropObject    StatementRisk = "drop-object"    // Types, views, schemas, sequences, ...
+	RiskDeleteRows    StatementRisk = "delete-rows"    // DELETE / TRUNCATE
+	RiskUpdateRows    StatementRisk = "update-rows"    // UPDATE
+	RiskAlterType     StatementRisk = "alter-type"     // Column type change
+	RiskSetNotNull    StatementRisk = "set-not-null"   // Fails if a row holds NULL
+	RiskRename        StatementRisk = "rename"         // Breaks code using the old name
+	RiskAddUniqueness StatementRisk = "add-uniqueness" // Fails if existing rows have duplicates
+)
+
+// Statement is a single statement of a migration script
+type Statement struct {
+	Text string // As written, including the comments above it
+	Line int    // 1-based line the statement (or its comments) starts on
+	Risk StatementRisk
+}
+
+// IsDestructive reports whether the statement can lose data or fail on existing rows
+func (s Statement) IsDestructive() bool {
+	return s.Risk != RiskNone
+}
+
+// riskRules are checked in order against the statement with comments stripped and whitespace collapsed
+var riskRules = []struct {
+	risk  StatementRisk
+	regex *regexp.Regexp
+}{
+	{RiskDropTable, regexp.MustCompile(`(?i)^DROP\s+TABLE\b`)},
+	{RiskDropColumn, regexp.MustCompile(`(?i)^ALTER\s+TABLE\b.*\bDROP\s+COLUMN\b`)},
+	{RiskDropObject, regexp.MustCompile(`(?i)^DROP\s+(?:TYPE|VIEW|MATERIALIZED\s+VIEW|SCHEMA|SEQUENCE|FUNCTION|EXTENSION|DATABASE)\b`)},
+	{RiskDeleteRows, regexp.MustCompile(`(?i)^(?:DELETE\s+FROM|TRUNCATE)\b`)},
+	{RiskUpdateRows, regexp.MustCompile(`(?i)^UPDATE\b`)},
+	{RiskAlterType, regexp.MustCompile(`(?i)^ALTER\s+TABLE\b.*(?:\bALTER\s+(?:COLUMN\s+)?\S+\s+(?:SET\s+DATA\s+)?TYPE\b|\bMODIFY\b)`)},
+	{RiskSetNotNull, regexp.MustCompile(`(?i)^ALTER\s+TABLE\b.*\bSET\s+NOT\s+NULL\b`)},
+	{RiskRename, regexp.MustCompile(`(?i)\bRENAME\b`)},
+	{RiskAddUniqueness, regexp.MustCompile(`(?i)^(?:CREATE\s+UNIQUE\s+INDEX\b|ALTER\s+TABLE\b.*\bADD\s+(?:CONSTRAINT\s+\S+\s+)?(?:UNIQUE|PRIMARY\s+KEY)\b)`)},
+}
+
+// ParseStatements splits a migration script into statements. Semicolons inside
+// comments, string literals, quoted identifiers and dollar-quoted bodies don't
+// end a statement.
+func ParseStatements(sql string) []Statement {
+	var stmts []Statement
+	for _, chunk := range splitStatementsVerbatim(sql) {
+		stmts = append(stmts, Statement{
+			Text: chunk.text,
+			Line: chunk.line,
+			Risk: ClassifyStatementRisk(chunk.text),
+		})
+	}
+	return stmts
+}
+
+// SplitStatements splits a script into its statements as written, with the
+// same quoting rules as ParseStatements
+func SplitStatements(sql string) []string {
+	var stmts []string
+	for _, chunk := range splitStatementsVerbatim(sql) {
+		stmts = append(stmts, chunk.text)
+	}
+	return stmts
+}
+
+// normalizedStatements splits a script like SplitStatements and normalizes each
+// statement to a single line with NormalizeStatement
+func normalizedStatements(sql string) []string {
+	var stmts []string
+	for _, chunk := range splitStatementsVerbatim(sql) {
+		if stmt := NormalizeStatement(chunk.text); stmt != "" {
+			stmts = append(stmts, stmt)
+		}
+	}
+	return stmts
+}
+
+// ClassifyStatementRisk returns the first risk rule the statement matches
+func ClassifyStatementRisk(stmt string) StatementRisk {
+	normalized := NormalizeStatement(stmt)
+	for _, rule := range riskRules {
+		if rule.regex.MatchString(normalized) {
+			return rule.risk
+		}
+	}
+	return RiskNone
+}
+
+// NormalizeStatement strips comments, collapses whitespace and drops the trailing semicolon
+func NormalizeStatement(stmt string) string {
+	stmt = sqlBlockCommentRegex.ReplaceAllString(stmt, " ")
+	stmt = sqlLineCommentRegex.ReplaceAllString(stmt, " ")
+	stmt = strings.TrimSpace(sqlWhitespaceRegex.ReplaceAllString(stmt, " "))
+	return strings.TrimSpace(strings.TrimSuffix(stmt, ";"))
+}
+
+// statementChunk is a statement's text as written and the line it starts on
+type statementChunk struct {
+	text string
+	line int
+}
+
+// splitStatementsVerbatim splits a script at top-level semicolons and keeps each
+// statement's text (with the comments above it) as written. Comment-only
+// chunks are dropped.
+func splitStatementsVerbatim(sql string) []statementChunk {
+	var chunks []statementChunk
+
+	start := 0 // Byte offset of the current chunk
+	line := 1  // Line at the current position
+	startLine := 1
+
+	flush := func(end int) {
+		raw := sql[start:end]
+		text := strings.TrimSpace(raw)
+		chunkLine := startLine + strings.Count(raw[:len(raw)-len(strings.TrimLeft(raw, " \t\r\n"))], "\n")
+		start, startLine = end, line
+		if NormalizeStatement(text) == "" {
+			return
+		}
+		chunks = append(chunks, statementChunk{text: text, line: chunkLine})
+	}
+
+	for i := 0; i < len(sql); i++ {
+		switch c := sql[i]; {
+		case c == '\n':
+			line++
+		case c == '-' && strings.HasPrefix(sql[i:], "--"):
+			// Stop before the newline so it is counted above
+			if idx := strings.IndexByte(sql[i:], '\n'); idx != -1 {
+				i += idx - 1
+			} else {
+				i = len(sql) - 1
+			}
+		case c == '/' && strings.HasPrefix(sql[i:], "/*"):
+			i = skipUntil(sql, i+2, "*/", &line)
+		case c == '\'' || c == '"' || c == '`':
+			i = skipUntil(sql, i+1, string(c), &line)
+		case c == '$':
+			if tag := dollarQuoteTag(sql[i:]); tag != "" {
+				i = skipUntil(sql, i+len(tag), tag, &line)
+			}
+		case c == ';':
+			flush(i + 1)
+		}
+	}
+	if start < len(sql) {
+		flush(len(sql))
+	}
+
+	return chunks
+}
+
+// skipUntil returns the index of the last byte of the first end marker at or
+// after from (or the end of s), counting the newlines passed
+func skipUntil(s string, from int, end string, line *int) int {
+	if from > len(s) {
+		return len(s) - 1
+	}
+	idx := strings.Index(s[from:], end)
+	stop := len(s)
+	if idx != -1 {
+		stop = from + idx + len(end)
+	}
+	*line += strings.Count(s[from:stop], "\n")
+	return stop - 1
+}
+
+// dollarQuoteTagRegex matches the opening of a PostgreSQL dollar-quoted string ($$ or $tag$)
+var dollarQuoteTagRegex = regexp.MustCompile(`^\$[A-Za-z_]*\$`)
+
+// dollarQuoteTag returns the dollar quote opening s, or "" if there is none
+func dollarQuoteTag(s string) string {
+	return dollarQuoteTagRegex.FindString(s)
+}
@@ -0,0 +1,9 @@
+package prisma
+
+import "strings"
+
+// IsStudioProcess reports whether a process command line looks like a Prisma Studio server
+func IsStudioProcess(cmdline string) bool {
+	cmdline = strings.ToLower(cmdline)
+	return strings.Contains(cmdline, "prisma") && strings.Contains(cmdline, "studio")
+}
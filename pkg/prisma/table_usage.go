@@ -0,0 +1,58 @@
+package prisma
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// usageSnippetLength caps the length of a matching line shown as a preview
+const usageSnippetLength = 120
+
+// UsageMatch is a line of migration SQL that mentions a table
+type UsageMatch struct {
+	Line    int    // 1-based line in migration.sql
+	Snippet string // The line, trimmed and shortened
+}
+
+// MigrationUsage holds the lines of one migration that mention a table
+type MigrationUsage struct {
+	Migration string
+	Matches   []UsageMatch
+}
+
+// SearchTableUsage finds every line of the migrations' SQL that mentions the
+// table, quoted or as a whole word (case-insensitive). Migrations without a
+// match are left out; the rest keep the order they were given in.
+func SearchTableUsage(migrations []Migration, table string) []MigrationUsage {
+	tableRegex := identReferenceRegex(table)
+
+	var usages []MigrationUsage
+	for _, mig := range migrations {
+		if mig.Path == "" || mig.IsEmpty {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(mig.Path, "migration.sql"))
+		if err != nil {
+			continue
+		}
+
+		var matches []UsageMatch
+		for i, line := range strings.Split(string(content), "\n") {
+			if !tableRegex.MatchString(line) {
+				continue
+			}
+			snippet := strings.TrimSpace(line)
+			if runes := []rune(snippet); len(runes) > usageSnippetLength {
+				snippet = string(runes[:usageSnippetLength]) + "…"
+			}
+			matches = append(matches, UsageMatch{Line: i + 1, Snippet: snippet})
+		}
+
+		if len(matches) > 0 {
+			usages = append(usages, MigrationUsage{Migration: mig.Name, Matches: matches})
+		}
+	}
+
+	return usages
+}
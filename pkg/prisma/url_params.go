@@ -0,0 +1,241 @@
+package prisma
+
+import (
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// URLParamProblem is what is wrong with a connection URL parameter
+type URLParamProblem string
+
+const (
+	URLParamOK        URLParamProblem = ""          // Nothing
+	URLParamUnknown   URLParamProblem = "unknown"   // Prisma doesn't read it for the provider
+	URLParamInvalid   URLParamProblem = "invalid"   // Its value isn't one Prisma accepts
+	URLParamDuplicate URLParamProblem = "duplicate" // Set more than once
+)
+
+// URLParam is a parameter of a database connection URL
+type URLParam struct {
+	Name       string
+	Value      string
+	Problem    URLParamProblem
+	Suggestion string // Known parameter an unknown one is likely a typo of ("" = none)
+}
+
+// URLParamsReport is what InspectURLParams found in a connection URL
+type URLParamsReport struct {
+	Provider string
+	Params   []URLParam
+	// pgbouncer=true without a directUrl: migrate then runs through the pooler,
+	// which breaks its advisory locks and prepared statements
+	PgBouncerWithoutDirectURL bool
+}
+
+// Problems returns how many parameters have a problem, counting the
+// pgbouncer warning as one
+func (r *URLParamsReport) Problems() int {
+	count := 0
+	for _, param := range r.Params {
+		if param.Problem != URLParamOK {
+			count++
+		}
+	}
+	if r.PgBouncerWithoutDirectURL {
+		count++
+	}
+	return count
+}
+
+// Connection parameters Prisma's engines read, per provider
+var (
+	postgresURLParams = []string{
+		"schema", "host", "sslmode", "sslcert", "sslrootcert", "sslidentity", "sslpassword", "sslaccept",
+		"connection_limit", "connect_timeout", "pool_timeout", "socket_timeout", "max_connection_lifetime",
+		"max_idle_connection_lifetime", "statement_cache_size", "pgbouncer", "application_name",
+		"channel_binding", "options",
+	}
+	mysqlURLParams = []string{
+		"sslcert", "sslidentity", "sslpassword", "sslaccept", "socket", "connection_limit",
+		"connect_timeout", "pool_timeout", "socket_timeout", "max_connection_lifetime",
+		"max_idle_connection_lifetime", "statement_cache_size",
+	}
+	sqliteURLParams = []string{"connection_limit", "socket_timeout"}
+	// SQL Server's parameters are case-insensitive, listed lowercased
+	sqlServerURLParams = []string{
+		"database", "initial catalog", "schema", "user", "username", "uid", "userid", "password", "pwd",
+		"encrypt", "integratedsecurity", "trustservercertificate", "trustservercertificateca",
+		"connectionlimit", "connecttimeout", "sockettimeout", "pooltimeout", "isolationlevel",
+		"applicationname", "authentication",
+	}
+)
+
+// urlParamValues are the values Prisma accepts for parameters with a fixed set
+var urlParamValues = map[string][]string{
+	"sslmode":   {"disable", "prefer", "require"},
+	"sslaccept": {"strict", "accept_invalid_certs"},
+	"pgbouncer": {"true", "false"},
+}
+
+// numericURLParams take a non-negative whole number (of connections or seconds)
+var numericURLParams = map[string]bool{
+	"connection_limit": true, "connect_timeout": true, "pool_timeout": true, "socket_timeout": true,
+	"max_connection_lifetime": true, "max_idle_connection_lifetime": true, "statement_cache_size": true,
+	"connectionlimit": true, "connecttimeout": true, "sockettimeout": true, "pooltimeout": true,
+}
+
+// SecretURLParams are parameters whose value is shown masked
+var SecretURLParams = map[string]bool{"sslpassword": true, "password": true, "pwd": true}
+
+// InspectURLParams lists the parameters of a connection URL and what is wrong
+// with them. directURL is the datasource's directUrl ("" = not set). MongoDB
+// URLs take the driver's many options, so they are listed without checks, as
+// are those of providers Prisma doesn't document parameters for.
+func InspectURLParams(provider, dbURL, directURL string) *URLParamsReport {
+	report := &URLParamsReport{Provider: provider}
+
+	var known []string
+	caseInsensitive := false
+	switch provider {
+	case "postgresql", "postgres", "cockroachdb":
+		known = postgresURLParams
+	case "mysql":
+		known = mysqlURLParams
+	case "sqlite":
+		known = sqliteURLParams
+	case "sqlserver":
+		known, caseInsensitive = sqlServerURLParams, true
+	}
+
+	seen := make(map[string]bool)
+	for _, pair := range splitURLParams(provider, dbURL) {
+		param := URLParam{Name: pair[0], Value: pair[1]}
+		name := param.Name
+		if caseInsensitive {
+			name = strings.ToLower(name)
+		}
+
+		switch {
+		case known == nil:
+		case seen[name]:
+			param.Problem = URLParamDuplicate
+		case !slices.Contains(known, name):
+			param.Problem = URLParamUnknown
+			param.Suggestion = suggestURLParam(known, name)
+		case !validURLParamValue(name, param.Value):
+			param.Problem = URLParamInvalid
+		}
+		seen[name] = true
+
+		if name == "pgbouncer" && strings.EqualFold(param.Value, "true") && directURL == "" {
+			report.PgBouncerWithoutDirectURL = true
+		}
+		report.Params = append(report.Params, param)
+	}
+	return report
+}
+
+// splitURLParams returns the name/value pairs of a URL's query, or of the
+// ;key=value list that follows a SQL Server URL's host, in order
+func splitURLParams(provider, dbURL string) [][2]string {
+	var raw string
+	sep := "&"
+	if provider == "sqlserver" {
+		_, raw, _ = strings.Cut(dbURL, ";")
+		sep = ";"
+	} else {
+		_, raw, _ = strings.Cut(dbURL, "?")
+	}
+
+	var pairs [][2]string
+	for _, part := range strings.Split(raw, sep) {
+		if part == "" {
+			continue
+		}
+		name, value, _ := strings.Cut(part, "=")
+		if sep == "&" {
+			if unescaped, err := url.QueryUnescape(name); err == nil {
+				name = unescaped
+			}
+			if unescaped, err := url.QueryUnescape(value); err == nil {
+				value = unescaped
+			}
+		}
+		pairs = append(pairs, [2]string{strings.TrimSpace(name), strings.TrimSpace(value)})
+	}
+	return pairs
+}
+
+// AcceptedURLParamValues returns the values Prisma accepts for a parameter
+// with a fixed set, or nil for one that takes a number or any value
+func AcceptedURLParamValues(name string) []string {
+	return urlParamValues[name]
+}
+
+// validURLParamValue reports whether value is one Prisma accepts for name
+func validURLParamValue(name, value string) bool {
+	if values, ok := urlParamValues[name]; ok {
+		return slices.Contains(values, value)
+	}
+	if numericURLParams[name] {
+		n, err := strconv.Atoi(value)
+		return err == nil && n >= 0
+	}
+	return true
+}
+
+// suggestURLParam returns the known parameter name is a likely typo of:
+// the same once case, "_" and "-" are ignored, e.g. ssl_mode for sslmode
+func suggestURLParam(known []string, name string) string {
+	normalize := func(s string) string {
+		return strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.ToLower(s))
+	}
+	for _, candidate := range known {
+		if normalize(candidate) == normalize(name) {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// SetURLParams returns dbURL with the name/value pairs of params set: a
+// parameter already in the URL gets the new value where it is, the others are
+// appended. The rest of the URL is kept as written.
+func SetURLParams(provider, dbURL string, params [][2]string) string {
+	sep, query := "&", "?"
+	caseInsensitive := false
+	if provider == "sqlserver" {
+		sep, query, caseInsensitive = ";", ";", true
+	}
+
+	base, raw, _ := strings.Cut(dbURL, query)
+	var parts []string
+	if raw != "" {
+		parts = strings.Split(raw, sep)
+	}
+
+	for _, param := range params {
+		name, value := param[0], param[1]
+		if sep == "&" {
+			name, value = url.QueryEscape(name), url.QueryEscape(value)
+		}
+		set := false
+		for i, part := range parts {
+			existing, _, _ := strings.Cut(part, "=")
+			existing = strings.TrimSpace(existing)
+			if existing == name || (caseInsensitive && strings.EqualFold(existing, name)) {
+				parts[i] = name + "=" + value
+				set = true
+			}
+		}
+		if !set {
+			parts = append(parts, name+"="+value)
+		}
+	}
+
+	// Drop the empty part a trailing separator leaves
+	parts = slices.DeleteFunc(parts, func(part string) bool { return strings.TrimSpace(part) == "" })
+	return base + query + strings.Join(parts, sep)
+}
@@ -13,7 +13,7 @@ type ValidateResult struct {
 
 // Validate runs `npx prisma validate` to check schema validity
 func Validate(projectDir string) (*ValidateResult, error) {
-	cmd := cmdBuilder.New("npx", "prisma", "validate").WithWorkingDir(projectDir)
+	cmd := cmdBuilder.New(Command(projectDir, "validate")...).WithWorkingDir(projectDir).WithEnv(EngineOverrideEnv(projectDir)...)
 	result, err := cmd.RunWithOutput()
 
 	// Parse result
@@ -22,23 +22,21 @@ func Validate(projectDir string) (*ValidateResult, error) {
 	}
 
 	// Exit code 0 means validation succeeded
-	if err == nil && result.ExitCode == 0 {
-		validateResult.Valid = true
-		return validateResult, nil
+	exitCode := result.ExitCode
+	if err != nil && exitCode == 0 {
+		exitCode = 1
 	}
-
-	// Validation failed - parse errors
-	validateResult.Valid = false
-	validateResult.Errors = parseValidationErrors(result.Stdout, result.Stderr)
+	parsed := ParseValidateOutput(validateResult.Output, exitCode).(*ValidateOutput)
+	validateResult.Valid = parsed.Valid
+	validateResult.Errors = parsed.Errors
 
 	// Return result even if command failed (validation failure is expected behavior)
 	return validateResult, nil
 }
 
 // parseValidationErrors extracts error messages from prisma validate output
-func parseValidationErrors(stdout, stderr string) []string {
+func parseValidationErrors(output string) []string {
 	var errors []string
-	output := stdout + "\n" + stderr
 
 	lines := strings.Split(output, "\n")
 	for _, line := range lines {
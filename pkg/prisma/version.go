@@ -1,6 +1,7 @@
 package prisma
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
@@ -27,8 +28,8 @@ func GetVersion(projectDir string) (*VersionInfo, error) {
 	// Check if prisma is installed locally (check up to 3 parent directories for monorepo support)
 	isLocal := isPrismaInstalledLocally(projectDir)
 
-	// Use npx to get version (it automatically prefers local over global)
-	cmd := cmdBuilder.New("npx", "prisma", "--version").WithWorkingDir(projectDir)
+	// Run the local binary (or npx when prisma is not installed locally)
+	cmd := cmdBuilder.New(Command(projectDir, "--version")...).WithWorkingDir(projectDir)
 	result, err := cmd.RunWithOutput()
 	if err != nil {
 		// Fallback to global prisma command
@@ -117,3 +118,32 @@ func getPrismaVersionFromPath(prismaPath string) (string, error) {
 
 	return "", nil
 }
+
+// GetClientVersion returns the version of @prisma/client installed in the nearest
+// node_modules (searching projectDir and its parents), or "" if it is not installed
+func GetClientVersion(projectDir string) string {
+	dir, err := filepath.Abs(projectDir)
+	if err != nil {
+		return ""
+	}
+
+	for {
+		data, err := os.ReadFile(filepath.Join(dir, "node_modules", "@prisma", "client", "package.json"))
+		if err == nil {
+			var pkg struct {
+				Version string `json:"version"`
+			}
+			if json.Unmarshal(data, &pkg) == nil {
+				return pkg.Version
+			}
+			return ""
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			// Reached root
+			return ""
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,181 @@
+package scripts
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dokadev/lazyprisma/pkg/node"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+)
+
+// DefaultEnvironment names the database the datasource points at without overrides
+const DefaultEnvironment = "default"
+
+// Metadata file and log directory kept inside the scripts directory
+const (
+	historyFileName = ".lazyprisma-runs.json"
+	logDirName      = ".logs"
+)
+
+// Kind is how a script is executed
+type Kind string
+
+const (
+	KindSQL  Kind = "sql"  // Run with prisma db execute
+	KindNode Kind = "node" // Run with node
+)
+
+// kindByExt maps file extensions to script kinds
+var kindByExt = map[string]Kind{
+	".sql": KindSQL,
+	".js":  KindNode,
+	".mjs": KindNode,
+	".cjs": KindNode,
+}
+
+// Script is a maintenance script in the scripts directory
+type Script struct {
+	Name string // File name, e.g. "backfill_slugs.sql"
+	Path string // Absolute path
+	Kind Kind
+}
+
+// List returns the scripts in dir sorted by name. A missing directory has no scripts.
+func List(dir string) ([]Script, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var scripts []Script
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		kind, ok := kindByExt[strings.ToLower(filepath.Ext(entry.Name()))]
+		if !ok {
+			continue
+		}
+		scripts = append(scripts, Script{
+			Name: entry.Name(),
+			Path: filepath.Join(dir, entry.Name()),
+			Kind: kind,
+		})
+	}
+
+	sort.Slice(scripts, func(i, j int) bool { return scripts[i].Name < scripts[j].Name })
+	return scripts, nil
+}
+
+// Command returns the command line that runs a JavaScript script from
+// projectDir, or nil for a SQL script, which runs through prisma (PrismaArgs)
+func (s Script) Command(projectDir string) []string {
+	if s.Kind == KindSQL {
+		return nil
+	}
+	return node.WrapCommand(projectDir, []string{"node", s.Path})
+}
+
+// PrismaArgs returns the prisma arguments that run a SQL script against the
+// schema's database from projectDir, or nil for a JavaScript script
+func (s Script) PrismaArgs(projectDir string) []string {
+	if s.Kind != KindSQL {
+		return nil
+	}
+	file := s.Path
+	if rel, err := filepath.Rel(projectDir, s.Path); err == nil && !strings.HasPrefix(rel, "..") {
+		file = rel
+	}
+	return prisma.DBExecuteArgs(file, prisma.SchemaArg(projectDir))
+}
+
+// LogPath returns the file holding the output of the script's last run in env
+func LogPath(dir, script, env string) string {
+	return filepath.Join(dir, logDirName, fmt.Sprintf("%s.%s.log", script, env))
+}
+
+// WriteLog saves the output of a run, replacing the previous run's log
+func WriteLog(dir, script, env, output string) (string, error) {
+	path := LogPath(dir, script, env)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(output), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// Run is the outcome of a script's last run in one environment
+type Run struct {
+	At         time.Time `json:"at"`
+	ExitCode   int       `json:"exitCode"`
+	DurationMs int64     `json:"durationMs"`
+}
+
+// Succeeded reports whether the run exited with status 0
+func (r Run) Succeeded() bool {
+	return r.ExitCode == 0
+}
+
+// History holds the last run of every script per environment
+type History struct {
+	path string
+	Runs map[string]map[string]Run `json:"runs"` // script name -> environment -> last run
+}
+
+// LoadHistory reads the run history of the scripts in dir.
+// Returns an empty history if none has been recorded yet.
+func LoadHistory(dir string) (*History, error) {
+	h := &History{path: filepath.Join(dir, historyFileName), Runs: map[string]map[string]Run{}}
+
+	data, err := os.ReadFile(h.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return h, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, h); err != nil {
+		return nil, fmt.Errorf("%s: %w", h.path, err)
+	}
+	if h.Runs == nil {
+		h.Runs = map[string]map[string]Run{}
+	}
+	return h, nil
+}
+
+// Last returns the script's last run in env
+func (h *History) Last(script, env string) (Run, bool) {
+	run, ok := h.Runs[script][env]
+	return run, ok
+}
+
+// Record stores a run as the script's last run in env and saves the history
+func (h *History) Record(script, env string, run Run) error {
+	if h.Runs[script] == nil {
+		h.Runs[script] = map[string]Run{}
+	}
+	h.Runs[script][env] = run
+
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write to a temp file first so an interrupted save can't corrupt the history
+	tmp := h.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, h.path)
+}
@@ -0,0 +1,176 @@
+// Package simulate dry-runs migrate deploy: it applies the pending migrations to
+// a scratch database instead of the target, which Prisma has no flag for.
+package simulate
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dokadev/lazyprisma/pkg/database"
+	"github.com/dokadev/lazyprisma/pkg/prisma"
+)
+
+// scratchPrefix starts the name of every scratch database, so a leftover one is recognisable
+const scratchPrefix = "lazyprisma_sim_"
+
+// ErrUnsupportedProvider is returned for providers lazyprisma can't create databases for
+var ErrUnsupportedProvider = errors.New("simulation supports PostgreSQL, CockroachDB and MySQL")
+
+// Options configures a simulated deploy
+type Options struct {
+	Provider string
+	// ServerURL is a database on the server the scratch database is created on:
+	// the shadow database if one is configured, otherwise the target itself.
+	// Only CREATE DATABASE and DROP DATABASE run through it.
+	ServerURL string
+	// Applied are replayed first to recreate the target's schema; Pending are then
+	// applied one by one. Both in migration order.
+	Applied []prisma.Migration
+	Pending []prisma.Migration
+	// OnStep is called as each migration finishes (from the calling goroutine)
+	OnStep func(Step)
+}
+
+// Step is the outcome of applying one migration to the scratch database
+type Step struct {
+	Migration string
+	Replay    bool  // An applied migration replayed to recreate the target's state
+	Err       error // nil if the migration applied cleanly
+	Duration  time.Duration
+}
+
+// Result is the outcome of a simulated deploy
+type Result struct {
+	ScratchDB string   // Name of the scratch database (dropped afterwards)
+	Steps     []Step   // Replayed and pending migrations that ran, in order
+	Skipped   []string // Pending migrations not tried after a failure
+}
+
+// Failed returns the step that failed, or nil if every migration applied
+func (r *Result) Failed() *Step {
+	for i := range r.Steps {
+		if r.Steps[i].Err != nil {
+			return &r.Steps[i]
+		}
+	}
+	return nil
+}
+
+// Run creates a scratch database, replays opts.Applied and applies opts.Pending
+// to it, stopping at the first failure, then drops it. The target database is
+// never written to. An error means the scratch database could not be set up.
+func Run(opts Options) (*Result, error) {
+	switch opts.Provider {
+	case "postgresql", "postgres", "cockroachdb", "mysql":
+	default:
+		return nil, ErrUnsupportedProvider
+	}
+
+	server, err := database.NewClientFromDSN(opts.Provider, opts.ServerURL)
+	if err != nil {
+		return nil, err
+	}
+	defer server.Close()
+
+	name := fmt.Sprintf("%s%d", scratchPrefix, time.Now().UnixNano())
+	if _, err := server.Exec("CREATE DATABASE " + quoteIdent(opts.Provider, name)); err != nil {
+		return nil, fmt.Errorf("failed to create scratch database: %w", err)
+	}
+	defer server.Exec("DROP DATABASE IF EXISTS " + quoteIdent(opts.Provider, name))
+
+	scratchURL, err := scratchDatabaseURL(opts.Provider, opts.ServerURL, name)
+	if err != nil {
+		return nil, err
+	}
+	scratch, err := database.NewClientFromDSN(opts.Provider, scratchURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to scratch database: %w", err)
+	}
+	defer scratch.Close()
+
+	// Migrations of a PostgreSQL schema other than public expect it to exist
+	if schema := postgresSchema(opts.Provider, opts.ServerURL); schema != "" && schema != "public" {
+		if _, err := scratch.Exec("CREATE SCHEMA IF NOT EXISTS " + quoteIdent(opts.Provider, schema)); err != nil {
+			return nil, fmt.Errorf("failed to create schema %s: %w", schema, err)
+		}
+	}
+
+	result := &Result{ScratchDB: name}
+	apply := func(mig prisma.Migration, replay bool) bool {
+		start := time.Now()
+		step := Step{Migration: mig.Name, Replay: replay}
+		if sql, err := os.ReadFile(filepath.Join(mig.Path, "migration.sql")); err != nil {
+			step.Err = err
+		} else if strings.TrimSpace(string(sql)) != "" {
+			_, step.Err = scratch.Exec(string(sql))
+		}
+		step.Duration = time.Since(start)
+
+		result.Steps = append(result.Steps, step)
+		if opts.OnStep != nil {
+			opts.OnStep(step)
+		}
+		return step.Err == nil
+	}
+
+	for _, mig := range opts.Applied {
+		if !apply(mig, true) {
+			return result, nil
+		}
+	}
+	for i, mig := range opts.Pending {
+		if !apply(mig, false) {
+			for _, rest := range opts.Pending[i+1:] {
+				result.Skipped = append(result.Skipped, rest.Name)
+			}
+			return result, nil
+		}
+	}
+
+	return result, nil
+}
+
+// scratchDatabaseURL points serverURL at the scratch database. MySQL needs
+// multiStatements to run a migration file in one call; a PostgreSQL schema is
+// kept through search_path.
+func scratchDatabaseURL(provider, serverURL, name string) (string, error) {
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid database URL: %w", err)
+	}
+	u.Path = "/" + name
+
+	query := u.Query()
+	if provider == "mysql" {
+		query.Set("multiStatements", "true")
+	} else if schema := postgresSchema(provider, serverURL); schema != "" {
+		query.Set("search_path", schema)
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// postgresSchema returns the schema a PostgreSQL URL selects ("" if none)
+func postgresSchema(provider, dbURL string) string {
+	if provider == "mysql" {
+		return ""
+	}
+	u, err := url.Parse(dbURL)
+	if err != nil {
+		return ""
+	}
+	return u.Query().Get("schema")
+}
+
+// quoteIdent quotes a database name for the provider
+func quoteIdent(provider, name string) string {
+	if provider == "mysql" {
+		return "`" + name + "`"
+	}
+	return `"` + name + `"`
+}
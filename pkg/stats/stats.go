@@ -0,0 +1,223 @@
+package stats
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ActionDeploy is the action name of prisma migrate deploy
+const ActionDeploy = "migrate deploy"
+
+// ActionStats holds the counters for one action
+type ActionStats struct {
+	Runs     int   `json:"runs"`
+	Failures int   `json:"failures"`
+	TotalMs  int64 `json:"totalMs"` // Summed duration of successful runs
+	MinMs    int64 `json:"minMs"`
+	MaxMs    int64 `json:"maxMs"`
+}
+
+// Successes returns the number of successful runs
+func (s ActionStats) Successes() int {
+	return s.Runs - s.Failures
+}
+
+// Average returns the mean duration of successful runs
+func (s ActionStats) Average() time.Duration {
+	if s.Successes() == 0 {
+		return 0
+	}
+	return time.Duration(s.TotalMs/int64(s.Successes())) * time.Millisecond
+}
+
+// Stats is the content of the stats file
+type Stats struct {
+	Since   time.Time               `json:"since"`
+	Actions map[string]*ActionStats `json:"actions"`
+}
+
+// ActionCount pairs an action with its stats, for sorting
+type ActionCount struct {
+	Name  string
+	Stats ActionStats
+}
+
+// TotalRuns returns the number of runs across all actions
+func (s *Stats) TotalRuns() int {
+	total := 0
+	for _, a := range s.Actions {
+		total += a.Runs
+	}
+	return total
+}
+
+// TotalFailures returns the number of failed runs across all actions
+func (s *Stats) TotalFailures() int {
+	total := 0
+	for _, a := range s.Actions {
+		total += a.Failures
+	}
+	return total
+}
+
+// Top returns actions ordered by run count (most used first)
+func (s *Stats) Top() []ActionCount {
+	result := make([]ActionCount, 0, len(s.Actions))
+	for name, a := range s.Actions {
+		result = append(result, ActionCount{Name: name, Stats: *a})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Stats.Runs != result[j].Stats.Runs {
+			return result[i].Stats.Runs > result[j].Stats.Runs
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// Action returns the stats for one action (zero if never run)
+func (s *Stats) Action(name string) ActionStats {
+	if a, ok := s.Actions[name]; ok {
+		return *a
+	}
+	return ActionStats{}
+}
+
+// Store reads and updates the stats file. Statistics stay on this machine;
+// nothing is ever sent over the network.
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewStore creates a store backed by the file at path
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Path returns the stats file path
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Load reads the stats file. A missing file yields empty stats.
+func (s *Store) Load() (*Stats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.load()
+}
+
+// Record counts one run of an action. The file is re-read before each update
+// so that several running instances don't overwrite each other's counts.
+func (s *Store) Record(action string, success bool, duration time.Duration) error {
+	if action == "" {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, err := s.load()
+	if err != nil {
+		// Start over rather than failing forever on a corrupt file
+		st = &Stats{}
+	}
+	if st.Since.IsZero() {
+		st.Since = time.Now()
+	}
+	if st.Actions == nil {
+		st.Actions = make(map[string]*ActionStats)
+	}
+
+	a, ok := st.Actions[action]
+	if !ok {
+		a = &ActionStats{}
+		st.Actions[action] = a
+	}
+
+	a.Runs++
+	if !success {
+		a.Failures++
+	} else {
+		ms := duration.Milliseconds()
+		a.TotalMs += ms
+		if a.Successes() == 1 || ms < a.MinMs {
+			a.MinMs = ms
+		}
+		if ms > a.MaxMs {
+			a.MaxMs = ms
+		}
+	}
+
+	return s.save(st)
+}
+
+func (s *Store) load() (*Stats, error) {
+	st := &Stats{Actions: make(map[string]*ActionStats)}
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return st, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, st); err != nil {
+		return nil, err
+	}
+	if st.Actions == nil {
+		st.Actions = make(map[string]*ActionStats)
+	}
+	return st, nil
+}
+
+// save writes the file through a temporary file so a crash never leaves it half-written
+func (s *Store) save(st *Stats) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// CommandAction derives an action name from command args, e.g.
+// ["npx", "prisma", "migrate", "dev", "--name", "init"] -> "migrate dev"
+func CommandAction(args []string) string {
+	// Skip the launcher: "npx [flags] prisma" or a path to the prisma binary
+	start := 0
+	for i, arg := range args {
+		base := strings.TrimSuffix(filepath.Base(arg), ".cmd")
+		if base == "prisma" {
+			start = i + 1
+			break
+		}
+	}
+
+	var words []string
+	for _, arg := range args[start:] {
+		if strings.HasPrefix(arg, "-") {
+			break
+		}
+		words = append(words, arg)
+		if len(words) == 2 || words[0] != "migrate" && words[0] != "db" {
+			break
+		}
+	}
+	return strings.Join(words, " ")
+}